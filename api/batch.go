@@ -0,0 +1,131 @@
+//go:build !js
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// batchOp is a command /api/batch accepts: the route it would otherwise be
+// sent to (for its key scope) and a constructor for its request body.
+type batchOp struct {
+	path string
+	req  func() commandRequest
+}
+
+// batchOps maps batch command names to their single-command equivalents.
+var batchOps = map[game.CommandType]batchOp{
+	game.CmdTrade:              {"/api/market/trade", func() commandRequest { return new(TradeRequest) }},
+	game.CmdStandingOrder:      {"/api/orders", func() commandRequest { return new(StandingOrderRequest) }},
+	game.CmdCancelOrder:        {"/api/orders", func() commandRequest { return new(CancelOrderRequest) }},
+	game.CmdLimitOrder:         {"/api/orders/limit", func() commandRequest { return new(LimitOrderRequest) }},
+	game.CmdCancelLimitOrder:   {"/api/orders/limit", func() commandRequest { return new(CancelLimitOrderRequest) }},
+	game.CmdFuturesOpen:        {"/api/futures", func() commandRequest { return new(FuturesOpenRequest) }},
+	game.CmdFuturesClose:       {"/api/futures", func() commandRequest { return new(FuturesCloseRequest) }},
+	game.CmdBuild:              {"/api/build", func() commandRequest { return new(BuildRequest) }},
+	game.CmdUpgrade:            {"/api/upgrade", func() commandRequest { return new(UpgradeRequest) }},
+	game.CmdDemolish:           {"/api/demolish", func() commandRequest { return new(DemolishRequest) }},
+	game.CmdWorkforceAssign:    {"/api/workforce/assign", func() commandRequest { return new(WorkforceAssignRequest) }},
+	game.CmdCancelConstruction: {"/api/construction/cancel", func() commandRequest { return new(CancelConstructionRequest) }},
+	game.CmdBuildShip:          {"/api/ships/build", func() commandRequest { return new(ShipBuildRequest) }},
+	game.CmdMoveShip:           {"/api/ships/move", func() commandRequest { return new(ShipMoveRequest) }},
+	game.CmdRefuel:             {"/api/ships/refuel", func() commandRequest { return new(ShipRefuelRequest) }},
+	game.CmdTransferFuel:       {"/api/ships/transfer-fuel", func() commandRequest { return new(TransferFuelRequest) }},
+	game.CmdColonize:           {"/api/colonize", func() commandRequest { return new(ColonizeRequest) }},
+	game.CmdCargoLoad:          {"/api/cargo/load", func() commandRequest { return &cargoCommand{load: true} }},
+	game.CmdCargoUnload:        {"/api/cargo/unload", func() commandRequest { return new(cargoCommand) }},
+	game.CmdDockShip:           {"/api/ships/dock", func() commandRequest { return new(DockShipRequest) }},
+	game.CmdUndockShip:         {"/api/ships/undock", func() commandRequest { return new(UndockShipRequest) }},
+	game.CmdSellAtDock:         {"/api/ships/sell-at-dock", func() commandRequest { return new(dockTradeCommand) }},
+	game.CmdBuyAtDock:          {"/api/ships/buy-at-dock", func() commandRequest { return &dockTradeCommand{buy: true} }},
+	game.CmdFleetMove:          {"/api/fleets/move", func() commandRequest { return new(FleetMoveRequest) }},
+	game.CmdFleetCreate:        {"/api/fleets/create", func() commandRequest { return new(FleetCreateRequest) }},
+	game.CmdFleetDisband:       {"/api/fleets/disband", func() commandRequest { return new(FleetDisbandRequest) }},
+	game.CmdFleetAddShip:       {"/api/fleets/add-ship", func() commandRequest { return new(FleetAddShipRequest) }},
+	game.CmdFleetRemoveShip:    {"/api/fleets/remove-ship", func() commandRequest { return new(FleetRemoveShipRequest) }},
+}
+
+// registerBatchEndpoint registers POST /api/batch, which runs an ordered list
+// of commands on the simulation goroutine in one go instead of one request
+// (and one wait on the command channel) each.
+func registerBatchEndpoint(rt *router) {
+	post(rt, "/api/batch", doc{Tag: "batch", Summary: "Run several commands in order within one tick", Cost: 5},
+		func(r *http.Request, req *BatchRequest) (BatchResponse, error) {
+			steps, reqs, err := batchSteps(r, req)
+			if err != nil {
+				return BatchResponse{}, err
+			}
+			result, err := dispatchCommand(r, game.CmdBatch, game.BatchCommandData{Steps: steps, Atomic: req.Atomic})
+			if err != nil {
+				return BatchResponse{}, err
+			}
+			br, ok := result.(game.BatchResult)
+			if !ok {
+				return BatchResponse{}, errStatus(http.StatusInternalServerError, "unexpected result type")
+			}
+
+			resp := BatchResponse{RolledBack: br.RolledBack, Results: make([]BatchStepResult, len(br.Steps))}
+			for i, step := range br.Steps {
+				out := BatchStepResult{Type: string(steps[i].Type), Skipped: step.Skipped}
+				switch {
+				case step.Skipped:
+				case step.Err != nil:
+					out.Error = step.Err.Error()
+				default:
+					out.OK = true
+					out.Data = step.Result
+					if rr, ok := reqs[i].(commandResponder); ok {
+						if out.Data, err = rr.response(step.Result); err != nil {
+							out.OK, out.Data, out.Error = false, nil, err.Error()
+						}
+					}
+				}
+				if out.OK {
+					resp.Succeeded++
+				}
+				resp.Results[i] = out
+			}
+			return resp, nil
+		})
+}
+
+// batchSteps decodes and validates every command in a batch, and checks the
+// caller's key covers each one, before anything runs.
+func batchSteps(r *http.Request, req *BatchRequest) ([]game.BatchStep, []commandRequest, error) {
+	if len(req.Commands) == 0 {
+		return nil, nil, fmt.Errorf("commands required")
+	}
+	if len(req.Commands) > game.MaxBatchSteps {
+		return nil, nil, fmt.Errorf("too many commands (max %d)", game.MaxBatchSteps)
+	}
+	grant := getAuthGrant(r)
+	authed := grant.Player != "" || grant.Admin
+	steps := make([]game.BatchStep, len(req.Commands))
+	reqs := make([]commandRequest, len(req.Commands))
+	for i, c := range req.Commands {
+		op, ok := batchOps[game.CommandType(c.Type)]
+		if !ok {
+			return nil, nil, fmt.Errorf("command %d: unknown type %q", i, c.Type)
+		}
+		if scope := scopeFor(http.MethodPost, op.path); authed && !grant.Allows(scope) {
+			return nil, nil, errStatus(http.StatusForbidden, "command %d: API key %q lacks the %s scope", i, grant.KeyName, scope)
+		}
+		cr := op.req()
+		if len(c.Data) > 0 {
+			if err := json.Unmarshal(c.Data, cr); err != nil {
+				return nil, nil, fmt.Errorf("command %d: invalid JSON: %v", i, err)
+			}
+		}
+		cmdType, data, err := cr.command()
+		if err != nil {
+			return nil, nil, fmt.Errorf("command %d: %v", i, err)
+		}
+		steps[i] = game.BatchStep{Type: cmdType, Data: data}
+		reqs[i] = cr
+	}
+	return steps, reqs, nil
+}
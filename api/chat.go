@@ -5,8 +5,8 @@ package api
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"strings"
@@ -209,7 +209,7 @@ func executeToolCall(p GameStateProvider, playerName, fnName, fnArgs string) str
 
 	case "get_planet":
 		planetID := int(getFloat(args, "planet_id"))
-		data, found := handleGetPlanet(p, planetID)
+		data, found := handleGetPlanet(p, playerVisibility(p, playerName), planetID)
 		if !found {
 			return `{"error":"planet not found"}`
 		}
@@ -299,41 +299,20 @@ func truncate(s string, maxLen int) string {
 }
 
 // registerChatEndpoint registers the POST /api/chat handler.
-func registerChatEndpoint(mux *http.ServeMux, getProvider func() GameStateProvider) {
-	mux.HandleFunc("/api/chat", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method == http.MethodOptions {
-			w.WriteHeader(http.StatusOK)
-			return
-		}
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-
-		var req struct {
-			Message string                   `json:"message"`
-			History []map[string]interface{} `json:"history"` // prior conversation turns
-		}
-		body, _ := io.ReadAll(r.Body)
-		if err := json.Unmarshal(body, &req); err != nil || strings.TrimSpace(req.Message) == "" {
-			writeErr(w, http.StatusBadRequest, "message required")
-			return
-		}
-
-		player := getAuthPlayer(r)
-		if player == "" {
-			player = "Player" // fallback for unauthenticated
-		}
-
-		response, actions, err := handleChat(getProvider(), player, req.Message, req.History)
-		if err != nil {
-			writeErr(w, http.StatusInternalServerError, err.Error())
-			return
-		}
-
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"response": response,
-			"actions":  actions,
-		}})
-	})
+func registerChatEndpoint(rt *router) {
+	post(rt, "/api/chat", doc{Tag: "chat", Summary: "Ask the assistant to act on your behalf", Cost: 20},
+		func(r *http.Request, req *ChatRequest) (ChatReply, error) {
+			if strings.TrimSpace(req.Message) == "" {
+				return ChatReply{}, errors.New("message required")
+			}
+			player := getAuthPlayer(r)
+			if player == "" {
+				player = "Player" // fallback for unauthenticated
+			}
+			response, actions, err := handleChat(getProvider(r), player, req.Message, req.History)
+			if err != nil {
+				return ChatReply{}, errStatus(http.StatusInternalServerError, "%s", err)
+			}
+			return ChatReply{Response: response, Actions: actions}, nil
+		})
 }
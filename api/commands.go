@@ -0,0 +1,455 @@
+//go:build !js
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// commandTimeout bounds how long a request waits for the simulation
+// goroutine to pick up and run its command.
+const commandTimeout = 5 * time.Second
+
+// maxIdempotencyKeyLen bounds the Idempotency-Key header.
+const maxIdempotencyKeyLen = 255
+
+// newCommand creates a GameCommand with the authenticated player name, the
+// request ID and any Idempotency-Key attached.
+func newCommand(r *http.Request, cmdType game.CommandType, data interface{}) game.GameCommand {
+	return game.GameCommand{
+		Type:           cmdType,
+		Data:           data,
+		Result:         make(chan interface{}, 1),
+		PlayerName:     getAuthPlayer(r),
+		RequestID:      getRequestID(r),
+		IdempotencyKey: r.Header.Get("Idempotency-Key"),
+	}
+}
+
+// dispatchCommand runs a command for the requesting player on the simulation
+// goroutine and returns its result. A command that reports an error comes
+// back as that error (a 400); one that doesn't finish in time is a 504.
+// Resending with the same Idempotency-Key returns the first result rather
+// than running the command again.
+func dispatchCommand(r *http.Request, cmdType game.CommandType, data interface{}) (interface{}, error) {
+	if len(r.Header.Get("Idempotency-Key")) > maxIdempotencyKeyLen {
+		return nil, fmt.Errorf("Idempotency-Key longer than %d characters", maxIdempotencyKeyLen)
+	}
+	return awaitCommand(r, newCommand(r, cmdType, data))
+}
+
+// awaitCommand queues cmd on r's game and waits for its result.
+func awaitCommand(r *http.Request, cmd game.GameCommand) (interface{}, error) {
+	ctx := r.Context()
+	timeout := time.NewTimer(commandTimeout)
+	defer timeout.Stop()
+
+	select {
+	case getProvider(r).GetCommandChannel() <- cmd:
+	case <-timeout.C:
+		return nil, errStatus(http.StatusGatewayTimeout, "%s timed out", cmd.Type)
+	case <-ctx.Done():
+		return nil, errStatus(http.StatusGatewayTimeout, "%s cancelled", cmd.Type)
+	}
+
+	select {
+	case result := <-cmd.Result:
+		if err, ok := result.(error); ok {
+			return nil, err
+		}
+		return result, nil
+	case <-timeout.C:
+		return nil, errStatus(http.StatusGatewayTimeout, "%s timed out", cmd.Type)
+	case <-ctx.Done():
+		return nil, errStatus(http.StatusGatewayTimeout, "%s cancelled", cmd.Type)
+	}
+}
+
+// queueCommand hands a fire-and-forget command to r's simulation goroutine.
+func queueCommand(r *http.Request, cmd game.GameCommand) {
+	getProvider(r).GetCommandChannel() <- cmd
+}
+
+// commandRequest is a request body that maps onto one game command. The
+// single-command routes and /api/batch share it, so a command is validated
+// the same way whichever way it arrives.
+type commandRequest interface {
+	command() (game.CommandType, interface{}, error)
+}
+
+// commandResponder is implemented by requests whose command result is
+// reshaped before it's returned.
+type commandResponder interface {
+	response(result interface{}) (interface{}, error)
+}
+
+// dispatch validates req and runs its command for the requesting player.
+func dispatch(r *http.Request, req commandRequest) (interface{}, error) {
+	cmdType, data, err := req.command()
+	if err != nil {
+		return nil, err
+	}
+	result, err := dispatchCommand(r, cmdType, data)
+	if err != nil {
+		return nil, err
+	}
+	if rr, ok := req.(commandResponder); ok {
+		return rr.response(result)
+	}
+	return result, nil
+}
+
+// dispatchAs is dispatch for commands whose response has a known type.
+func dispatchAs[Resp any](r *http.Request, req commandRequest) (Resp, error) {
+	var zero Resp
+	result, err := dispatch(r, req)
+	if err != nil {
+		return zero, err
+	}
+	resp, ok := result.(Resp)
+	if !ok {
+		return zero, errStatus(http.StatusInternalServerError, "unexpected result type")
+	}
+	return resp, nil
+}
+
+func (req *TradeRequest) command() (game.CommandType, interface{}, error) {
+	if req.Resource == "" || req.Quantity <= 0 {
+		return "", nil, errors.New("resource and positive quantity required")
+	}
+	return game.CmdTrade, game.TradeCommandData{
+		Resource: req.Resource,
+		Quantity: req.Quantity,
+		Buy:      strings.EqualFold(req.Action, "buy"),
+		PlanetID: req.PlanetID,
+	}, nil
+}
+
+func (req *TradeRequest) response(result interface{}) (interface{}, error) {
+	rec, ok := result.(economy.TradeRecord)
+	if !ok {
+		return nil, errStatus(http.StatusInternalServerError, "unexpected result type")
+	}
+	return TradeResult{Resource: rec.Resource, Quantity: rec.Quantity, Action: rec.Action, Total: rec.Total}, nil
+}
+
+func (req *StandingOrderRequest) command() (game.CommandType, interface{}, error) {
+	if req.Action != "buy" && req.Action != "sell" {
+		return "", nil, errors.New("action must be 'buy' or 'sell'")
+	}
+	if req.Quantity <= 0 {
+		return "", nil, errors.New("quantity must be positive")
+	}
+	return game.CmdStandingOrder, game.StandingOrderCommandData{
+		PlanetID:  req.PlanetID,
+		Resource:  req.Resource,
+		Action:    req.Action,
+		Quantity:  req.Quantity,
+		Threshold: req.Threshold,
+		MaxPrice:  req.MaxPrice,
+		MinPrice:  req.MinPrice,
+	}, nil
+}
+
+func (req *CancelOrderRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdCancelOrder, game.CancelOrderCommandData{OrderID: req.OrderID}, nil
+}
+
+func (req *LimitOrderRequest) command() (game.CommandType, interface{}, error) {
+	if req.Action != "buy" && req.Action != "sell" {
+		return "", nil, errors.New("action must be 'buy' or 'sell'")
+	}
+	if req.Resource == "" || req.Quantity <= 0 || req.Price < 0 {
+		return "", nil, errors.New("resource, positive quantity and a price required")
+	}
+	return game.CmdLimitOrder, game.LimitOrderCommandData{
+		SystemID:    req.SystemID,
+		PlanetID:    req.PlanetID,
+		Resource:    req.Resource,
+		Action:      req.Action,
+		Quantity:    req.Quantity,
+		Price:       req.Price,
+		Type:        req.Type,
+		StopPrice:   req.StopPrice,
+		TimeInForce: strings.ToLower(req.TimeInForce),
+		ExpiresAt:   req.ExpiresAt,
+		Display:     req.DisplayQuantity,
+	}, nil
+}
+
+func (req *CancelLimitOrderRequest) command() (game.CommandType, interface{}, error) {
+	if req.OrderID <= 0 {
+		return "", nil, errors.New("order_id required")
+	}
+	return game.CmdCancelLimitOrder, game.CancelLimitOrderCommandData{OrderID: req.OrderID}, nil
+}
+
+func (req *FuturesOpenRequest) command() (game.CommandType, interface{}, error) {
+	if req.Resource == "" || req.Quantity <= 0 || req.Term <= 0 {
+		return "", nil, errors.New("resource, positive quantity and term required")
+	}
+	return game.CmdFuturesOpen, game.FuturesOpenCommandData{
+		Kind:       strings.ToLower(req.Kind),
+		Side:       strings.ToLower(req.Side),
+		Resource:   req.Resource,
+		Quantity:   req.Quantity,
+		Term:       req.Term,
+		Strike:     req.Strike,
+		Settlement: strings.ToLower(req.Settlement),
+		SystemID:   req.SystemID,
+		PlanetID:   req.PlanetID,
+	}, nil
+}
+
+func (req *FuturesCloseRequest) command() (game.CommandType, interface{}, error) {
+	if req.PositionID <= 0 {
+		return "", nil, errors.New("position_id required")
+	}
+	return game.CmdFuturesClose, game.FuturesCloseCommandData{PositionID: req.PositionID}, nil
+}
+
+func (req *ScheduleRequest) command() (game.CommandType, interface{}, error) {
+	if req.System == "" || req.Interval < 0 {
+		return "", nil, errors.New("system and an interval >= 0 required")
+	}
+	phase := int64(-1)
+	if req.Phase != nil {
+		if *req.Phase < 0 {
+			return "", nil, errors.New("phase must be >= 0")
+		}
+		phase = *req.Phase
+	}
+	return game.CmdSetSchedule, game.SetScheduleCommandData{System: req.System, Interval: req.Interval, Phase: phase}, nil
+}
+
+func (req *BuildRequest) command() (game.CommandType, interface{}, error) {
+	if req.PlanetID <= 0 || req.BuildingType == "" {
+		return "", nil, errors.New("planet_id and building_type required")
+	}
+	return game.CmdBuild, game.BuildCommandData{
+		PlanetID:     req.PlanetID,
+		BuildingType: req.BuildingType,
+		ResourceID:   req.ResourceID,
+	}, nil
+}
+
+func (req *UpgradeRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdUpgrade, game.UpgradeCommandData{PlanetID: req.PlanetID, BuildingIndex: req.BuildingIndex}, nil
+}
+
+func (req *DemolishRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdDemolish, game.DemolishCommandData{PlanetID: req.PlanetID, BuildingIndex: req.BuildingIndex}, nil
+}
+
+func (req *WorkforceAssignRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdWorkforceAssign, game.WorkforceAssignCommandData{
+		PlanetID:      req.PlanetID,
+		BuildingIndex: req.BuildingIndex,
+		Workers:       req.Workers,
+	}, nil
+}
+
+func (req *CancelConstructionRequest) command() (game.CommandType, interface{}, error) {
+	if req.ConstructionID == "" {
+		return "", nil, errors.New("construction_id required")
+	}
+	return game.CmdCancelConstruction, game.CancelConstructionCommandData{ConstructionID: req.ConstructionID}, nil
+}
+
+func (req *ShipBuildRequest) command() (game.CommandType, interface{}, error) {
+	if req.PlanetID <= 0 || req.ShipType == "" {
+		return "", nil, errors.New("planet_id and ship_type required")
+	}
+	return game.CmdBuildShip, game.ShipBuildCommandData{PlanetID: req.PlanetID, ShipType: req.ShipType}, nil
+}
+
+func (req *ShipMoveRequest) command() (game.CommandType, interface{}, error) {
+	if req.ShipID <= 0 || req.TargetSystemID < 0 {
+		return "", nil, errors.New("ship_id and target_system_id required")
+	}
+	return game.CmdMoveShip, game.ShipMoveCommandData{ShipID: req.ShipID, TargetSystemID: req.TargetSystemID}, nil
+}
+
+func (req *ShipRefuelRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdRefuel, game.ShipRefuelCommandData{ShipID: req.ShipID, PlanetID: req.PlanetID, Amount: req.Amount}, nil
+}
+
+func (req *TransferFuelRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdTransferFuel, game.TransferFuelCommandData{
+		FromShipID: req.FromShipID,
+		ToShipID:   req.ToShipID,
+		Amount:     req.Amount,
+	}, nil
+}
+
+func (req *ColonizeRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdColonize, game.ColonizeCommandData{ShipID: req.ShipID, PlanetID: req.PlanetID}, nil
+}
+
+// cargoCommand is a CargoRequest bound to a direction.
+type cargoCommand struct {
+	CargoRequest
+	load bool
+}
+
+func (req *cargoCommand) command() (game.CommandType, interface{}, error) {
+	if req.ShipID <= 0 || req.PlanetID <= 0 || req.Resource == "" || req.Quantity <= 0 {
+		return "", nil, errors.New("ship_id, planet_id, resource, and positive quantity required")
+	}
+	cmdType := game.CmdCargoUnload
+	if req.load {
+		cmdType = game.CmdCargoLoad
+	}
+	return cmdType, game.CargoCommandData{
+		ShipID:   req.ShipID,
+		PlanetID: req.PlanetID,
+		Resource: req.Resource,
+		Quantity: req.Quantity,
+		Load:     req.load,
+	}, nil
+}
+
+func (req *cargoCommand) response(result interface{}) (interface{}, error) {
+	moved, ok := result.(int)
+	if !ok {
+		return nil, errStatus(http.StatusInternalServerError, "unexpected result type")
+	}
+	action := "unload"
+	if req.load {
+		action = "load"
+	}
+	return CargoResult{
+		ShipID:   req.ShipID,
+		PlanetID: req.PlanetID,
+		Resource: req.Resource,
+		Quantity: moved,
+		Action:   action,
+	}, nil
+}
+
+func (req *DockShipRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdDockShip, game.DockShipCommandData{ShipID: req.ShipID, PlanetID: req.PlanetID}, nil
+}
+
+func (req *UndockShipRequest) command() (game.CommandType, interface{}, error) {
+	return game.CmdUndockShip, game.UndockShipCommandData{ShipID: req.ShipID}, nil
+}
+
+// dockTradeCommand is a SellAtDockRequest bound to a direction; buying at a
+// dock takes the same body as selling.
+type dockTradeCommand struct {
+	SellAtDockRequest
+	buy bool
+}
+
+func (req *dockTradeCommand) command() (game.CommandType, interface{}, error) {
+	cmdType := game.CmdSellAtDock
+	if req.buy {
+		cmdType = game.CmdBuyAtDock
+	}
+	return cmdType, game.SellAtDockCommandData{ShipID: req.ShipID, Resource: req.Resource, Quantity: req.Quantity}, nil
+}
+
+func (req *FleetMoveRequest) command() (game.CommandType, interface{}, error) {
+	if req.FleetID <= 0 || req.TargetSystemID < 0 {
+		return "", nil, errors.New("fleet_id and target_system_id required")
+	}
+	return game.CmdFleetMove, game.FleetMoveCommandData{FleetID: req.FleetID, TargetSystemID: req.TargetSystemID}, nil
+}
+
+func (req *FleetCreateRequest) command() (game.CommandType, interface{}, error) {
+	if req.ShipID <= 0 {
+		return "", nil, errors.New("ship_id required")
+	}
+	return game.CmdFleetCreate, game.FleetCreateCommandData{ShipID: req.ShipID}, nil
+}
+
+func (req *FleetDisbandRequest) command() (game.CommandType, interface{}, error) {
+	if req.FleetID <= 0 {
+		return "", nil, errors.New("fleet_id required")
+	}
+	return game.CmdFleetDisband, game.FleetDisbandCommandData{FleetID: req.FleetID}, nil
+}
+
+func (req *FleetAddShipRequest) command() (game.CommandType, interface{}, error) {
+	if req.ShipID <= 0 || req.FleetID <= 0 {
+		return "", nil, errors.New("ship_id and fleet_id required")
+	}
+	return game.CmdFleetAddShip, game.FleetAddShipCommandData{ShipID: req.ShipID, FleetID: req.FleetID}, nil
+}
+
+func (req *FleetRemoveShipRequest) command() (game.CommandType, interface{}, error) {
+	if req.ShipID <= 0 || req.FleetID <= 0 {
+		return "", nil, errors.New("ship_id and fleet_id required")
+	}
+	return game.CmdFleetRemoveShip, game.FleetRemoveShipCommandData{ShipID: req.ShipID, FleetID: req.FleetID}, nil
+}
+
+func (req *EspionageRequest) command() (game.CommandType, interface{}, error) {
+	if req.Target == "" {
+		return "", nil, errors.New("target required")
+	}
+	return game.CmdEspionage, game.EspionageCommandData{Target: req.Target, Type: req.Type, SystemID: req.SystemID}, nil
+}
+
+func (req *BountyRequest) command() (game.CommandType, interface{}, error) {
+	if req.Reward <= 0 {
+		return "", nil, errors.New("reward must be positive")
+	}
+	return game.CmdPostBounty, game.PostBountyCommandData{
+		Type:        req.Type,
+		Description: req.Description,
+		Reward:      req.Reward,
+		Resource:    req.Resource,
+		Quantity:    req.Quantity,
+		PlanetID:    req.PlanetID,
+		SystemID:    req.SystemID,
+	}, nil
+}
+
+func (req *BlackMarketRequest) command() (game.CommandType, interface{}, error) {
+	if req.Action != "buy" && req.Action != "sell" {
+		return "", nil, errors.New("action must be 'buy' or 'sell'")
+	}
+	if req.Resource == "" || req.Quantity <= 0 {
+		return "", nil, errors.New("resource and positive quantity required")
+	}
+	return game.CmdBlackMarket, game.BlackMarketCommandData{
+		Buy:      req.Action == "buy",
+		Resource: req.Resource,
+		Quantity: req.Quantity,
+		PlanetID: req.PlanetID,
+	}, nil
+}
+
+func (req *BlackMarketRequest) response(result interface{}) (interface{}, error) {
+	out, ok := result.(game.BlackMarketOutcome)
+	if !ok {
+		return nil, errStatus(http.StatusInternalServerError, "unexpected result type")
+	}
+	if req.Action == "buy" {
+		return BlackMarketResult{
+			Action: "buy", Resource: req.Resource,
+			Quantity: req.Quantity, Cost: out.Cost,
+			Message: fmt.Sprintf("Black market purchase! %d %s for %d credits", req.Quantity, req.Resource, out.Cost),
+		}, nil
+	}
+	if out.Seized {
+		return BlackMarketResult{
+			Action: "sell", Seized: &out.Seized, Resource: req.Resource, Quantity: req.Quantity,
+			Message: "Goods confiscated by authorities! You lose the resources and get nothing.",
+		}, nil
+	}
+	return BlackMarketResult{
+		Action: "sell", Seized: &out.Seized, Resource: req.Resource,
+		Quantity: req.Quantity, Credits: out.Credits,
+		Message: fmt.Sprintf("Black market sale! %d %s for %d credits (3x price)", req.Quantity, req.Resource, out.Credits),
+	}, nil
+}
@@ -23,7 +23,7 @@ type ExpansionTarget struct {
 }
 
 // handleGetExpansionTargets finds the best colonization candidates for a player.
-func handleGetExpansionTargets(p GameStateProvider, playerName string) interface{} {
+func handleGetExpansionTargets(p GameStateProvider, playerName string) []ExpansionTarget {
 	player := findPlayer(p, playerName)
 	if player == nil {
 		return []ExpansionTarget{}
@@ -0,0 +1,198 @@
+//go:build !js
+
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// hostedGame is a game served alongside the default one. Each has its own
+// stream hub and spectator feed, since both follow a single game's logs.
+type hostedGame struct {
+	id        string
+	provider  GameStateProvider
+	stream    *streamHub
+	spectator *spectatorFeed
+}
+
+var (
+	gamesMu     sync.RWMutex
+	hostedGames = make(map[string]*hostedGame)
+	gameHost    GameHost
+)
+
+// SetGameHost enables the /api/admin/games endpoints.
+func SetGameHost(h GameHost) {
+	gamesMu.Lock()
+	defer gamesMu.Unlock()
+	gameHost = h
+}
+
+func getGameHost() GameHost {
+	gamesMu.RLock()
+	defer gamesMu.RUnlock()
+	return gameHost
+}
+
+// HostGame serves provider under /api/games/{id}/, replacing whatever was
+// there (a hosted game calls it again after loading a save). The REST
+// server itself is started by the default game's StartServer.
+func HostGame(id string, provider GameStateProvider) {
+	gamesMu.Lock()
+	g, ok := hostedGames[id]
+	if !ok {
+		g = &hostedGame{
+			id:        id,
+			stream:    &streamHub{clients: make(map[*streamClient]bool)},
+			spectator: &spectatorFeed{delay: defaultSpectatorDelay},
+		}
+		hostedGames[id] = g
+	}
+	g.provider = provider
+	gamesMu.Unlock()
+
+	g.stream.attach(provider)
+	g.spectator.attach(provider, spectatorDelayFromEnv())
+}
+
+// UnhostGame stops serving a hosted game.
+func UnhostGame(id string) {
+	gamesMu.Lock()
+	defer gamesMu.Unlock()
+	delete(hostedGames, id)
+}
+
+// gameFor returns the hosted game r addresses, or nil for the default game.
+func gameFor(r *http.Request) *hostedGame {
+	g, _ := r.Context().Value(ctxGame).(*hostedGame)
+	return g
+}
+
+// streamFor returns the stream hub for r's game.
+func streamFor(r *http.Request) *streamHub {
+	if g := gameFor(r); g != nil {
+		return g.stream
+	}
+	return stream
+}
+
+// spectatorFor returns the spectator feed for r's game.
+func spectatorFor(r *http.Request) *spectatorFeed {
+	if g := gameFor(r); g != nil {
+		return g.spectator
+	}
+	return spectator
+}
+
+// scopeToGame rewrites /api/games/{id}/rest to /api/rest, bound to hosted
+// game id, so every route works unchanged against any game. Other paths
+// pass through; ok is false if id isn't hosted.
+func scopeToGame(r *http.Request) (_ *http.Request, ok bool) {
+	rest, found := strings.CutPrefix(r.URL.Path, "/api/games/")
+	if !found {
+		return r, true
+	}
+	id, route, _ := strings.Cut(rest, "/")
+	gamesMu.RLock()
+	g := hostedGames[id]
+	gamesMu.RUnlock()
+	if g == nil {
+		return r, false
+	}
+	r = r.WithContext(context.WithValue(r.Context(), ctxGame, g))
+	u := *r.URL
+	u.Path, u.RawPath = "/api/"+route, ""
+	r.URL = &u
+	return r, true
+}
+
+// openGames lists the hosted games that aren't archived.
+func openGames() []HostedGame {
+	out := make([]HostedGame, 0)
+	if host := getGameHost(); host != nil {
+		for _, g := range host.ListGames() {
+			if g.Status != GameArchived {
+				out = append(out, g)
+			}
+		}
+	}
+	return out
+}
+
+// registerGameEndpoints registers the hosted games list, the lobby and
+// their admin controls:
+//   - GET  /api/games                 games open for play
+//   - GET  /api/lobby                 match setup options and defaults, plus the open games
+//   - GET  /api/admin/games           every hosted game, archived ones too
+//   - POST /api/admin/games           create a game with its own galaxy, accounts and config
+//   - POST /api/admin/games/pause     stop a game's clock
+//   - POST /api/admin/games/resume    restart it
+//   - POST /api/admin/games/archive   save and shut a game down for good
+func registerGameEndpoints(rt *router) {
+	get(rt, "/api/games", doc{Tag: "games", Summary: "Games hosted alongside this one, served under /api/games/{id}/"},
+		func(r *http.Request) ([]HostedGame, error) {
+			return openGames(), nil
+		})
+
+	get(rt, "/api/lobby", doc{Tag: "games", Summary: "Match setup options for POST /api/admin/games, and the games open for play"},
+		func(r *http.Request) (LobbyInfo, error) {
+			systems := getProvider(r).GetTickableSystems().Systems()
+			names := make([]string, 0, len(systems))
+			for _, system := range systems {
+				names = append(names, system.GetName())
+			}
+			return LobbyInfo{
+				Defaults:          game.DefaultGameConfig(),
+				TickableSystems:   names,
+				VictoryConditions: tickable.VictoryConditions(),
+				Games:             openGames(),
+			}, nil
+		})
+
+	get(rt, "/api/admin/games", doc{Tag: "admin", Summary: "Every hosted game, including archived ones", Admin: true},
+		func(r *http.Request) ([]HostedGame, error) {
+			host := getGameHost()
+			if host == nil {
+				return nil, errUnavailable("game hosting")
+			}
+			return host.ListGames(), nil
+		})
+
+	post(rt, "/api/admin/games", doc{Tag: "admin", Summary: "Create a hosted game", Admin: true},
+		func(r *http.Request, req *CreateGameRequest) (HostedGame, error) {
+			host := getGameHost()
+			if host == nil {
+				return HostedGame{}, errUnavailable("game hosting")
+			}
+			return host.CreateGame(*req)
+		})
+
+	control := func(path, summary string, apply func(host GameHost, id string) (HostedGame, error)) {
+		post(rt, path, doc{Tag: "admin", Summary: summary, Admin: true},
+			func(r *http.Request, req *GameRequest) (HostedGame, error) {
+				host := getGameHost()
+				if host == nil {
+					return HostedGame{}, errUnavailable("game hosting")
+				}
+				info, err := apply(host, req.ID)
+				if errors.Is(err, ErrUnknownGame) {
+					return HostedGame{}, errNotFound("%s", err)
+				}
+				return info, err
+			})
+	}
+	control("/api/admin/games/pause", "Pause a hosted game", func(host GameHost, id string) (HostedGame, error) {
+		return host.PauseGame(id, true)
+	})
+	control("/api/admin/games/resume", "Resume a paused hosted game", func(host GameHost, id string) (HostedGame, error) {
+		return host.PauseGame(id, false)
+	})
+	control("/api/admin/games/archive", "Save a hosted game and shut it down", GameHost.ArchiveGame)
+}
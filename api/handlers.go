@@ -1,9 +1,11 @@
 package api
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
 	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
@@ -19,6 +21,7 @@ type GameStateProvider interface {
 	GetPlayers() []*entities.Player
 	GetHumanPlayer() *entities.Player
 	GetSeed() int64
+	GetGameConfig() game.GameConfig
 	GetMarket() *economy.Market
 	GetTradeExecutor() *economy.TradeExecutor
 	GetCargoCommander() *game.CargoCommandExecutor
@@ -26,13 +29,19 @@ type GameStateProvider interface {
 	GetEventLog() *game.EventLog
 	GetChatLog() *game.ChatLog
 	GetRegistry() *game.PlayerRegistry
+	GetWebhooks() *game.WebhookDispatcher
+	GetTickableSystems() *tickable.Registry
 	GetTickInfo() (tick int64, gameTime string, speed string, paused bool)
+	AddTickCallback(fn func(tick int64)) // fn runs on the simulation goroutine after every tick
 	GetCommandChannel() chan game.GameCommand
 	GetStandingOrders(player string) []*game.StandingOrder
 	GetDeliveryManager() *economy.DeliveryManager
 	GetShippingManager() *game.ShippingManager
 	GetCreditLedger() *economy.CreditLedger
+	GetLedger() *economy.Ledger
+	GetCandleHistory() *economy.CandleHistory
 	GetOrderBook() *economy.OrderBook
+	GetFuturesExchange() *economy.FuturesExchange
 	GetContractManager() *economy.ContractManager
 	GetDiplomacyManager() *economy.DiplomacyManager
 	GetEspionageManager() *economy.EspionageManager
@@ -43,6 +52,18 @@ type GameStateProvider interface {
 	RemovePlayer(name string) bool
 }
 
+// ErrUnknownGame is returned by a GameHost for IDs it doesn't host.
+var ErrUnknownGame = errors.New("unknown game")
+
+// GameHost runs the games served under /api/games/{id}/ and backs the
+// admin endpoints that manage them.
+type GameHost interface {
+	ListGames() []HostedGame
+	CreateGame(req CreateGameRequest) (HostedGame, error)
+	PauseGame(id string, paused bool) (HostedGame, error)
+	ArchiveGame(id string) (HostedGame, error)
+}
+
 // findPlayer returns the player matching the given name, or falls back to the human player.
 func findPlayer(p GameStateProvider, name string) *entities.Player {
 	if name != "" {
@@ -55,9 +76,38 @@ func findPlayer(p GameStateProvider, name string) *entities.Player {
 	return p.GetHumanPlayer()
 }
 
+// playerVisibility works out what player can see of other factions. Read
+// handlers take the result as vis; a nil vis (admins) sees everything.
+func playerVisibility(p GameStateProvider, player string) *game.Visibility {
+	return game.ComputeVisibility(player, p.GetSystems(), p.GetHyperlanes(), p.GetPlayers(), intelTargets(p, player))
+}
+
+// intelTargets returns the factions player's intel ops currently reveal.
+func intelTargets(p GameStateProvider, player string) []string {
+	em := p.GetEspionageManager()
+	if em == nil || player == "" {
+		return nil
+	}
+	tick, _, _, _ := p.GetTickInfo()
+	return em.IntelTargets(player, tick)
+}
+
+// planetSystems maps planet IDs to the system they're in.
+func planetSystems(p GameStateProvider) map[int]int {
+	result := make(map[int]int)
+	for _, sys := range p.GetSystems() {
+		for _, e := range sys.Entities {
+			if planet, ok := e.(*entities.Planet); ok {
+				result[planet.GetID()] = sys.ID
+			}
+		}
+	}
+	return result
+}
+
 // --- handler logic (pure functions, no net/http) ---
 
-func handleGetMarket(p GameStateProvider) interface{} {
+func handleGetMarket(p GameStateProvider) []MarketCommodity {
 	market := p.GetMarket()
 	if market == nil {
 		return []MarketCommodity{}
@@ -79,7 +129,9 @@ func handleGetMarket(p GameStateProvider) interface{} {
 	return result
 }
 
-func handleGetTradeHistory(p GameStateProvider, limit int) interface{} {
+// handleGetTradeHistory returns recent trades; who made a trade is only
+// shown for factions vis knows.
+func handleGetTradeHistory(p GameStateProvider, vis *game.Visibility, limit int) []TradeHistoryEntry {
 	exec := p.GetTradeExecutor()
 	if exec == nil {
 		return []TradeHistoryEntry{}
@@ -96,11 +148,14 @@ func handleGetTradeHistory(p GameStateProvider, limit int) interface{} {
 			UnitPrice: r.UnitPrice,
 			Total:     r.Total,
 		}
+		if !vis.Knows(r.Player) {
+			result[i].Player = ""
+		}
 	}
 	return result
 }
 
-func handleGetGalaxy(p GameStateProvider) interface{} {
+func handleGetGalaxy(p GameStateProvider, vis *game.Visibility) []SystemSummary {
 	systems := p.GetSystems()
 	hyperlanes := p.GetHyperlanes()
 
@@ -123,9 +178,11 @@ func handleGetGalaxy(p GameStateProvider) interface{} {
 				starType = v.StarType
 			case *entities.Planet:
 				planets++
-				totalPop += v.Population
-				if v.Owner != "" && owner == "" {
-					owner = v.Owner
+				if vis.Sees(v.Owner, sys.ID) {
+					totalPop += v.Population
+					if v.Owner != "" && owner == "" {
+						owner = v.Owner
+					}
 				}
 				for _, resEntity := range v.Resources {
 					if res, ok := resEntity.(*entities.Resource); ok {
@@ -161,31 +218,23 @@ func handleGetGalaxy(p GameStateProvider) interface{} {
 	return result
 }
 
-func handleGetSystem(p GameStateProvider, id int) (interface{}, bool) {
+func handleGetSystem(p GameStateProvider, vis *game.Visibility, id int) (SystemDetail, bool) {
 	for _, sys := range p.GetSystems() {
 		if sys.ID == id {
-			return buildSystemDetail(sys), true
+			return buildSystemDetail(sys, vis), true
 		}
 	}
-	return nil, false
+	return SystemDetail{}, false
 }
 
-func buildSystemDetail(sys *entities.System) interface{} {
-	type systemDetail struct {
-		ID      int            `json:"id"`
-		Name    string         `json:"name"`
-		X       float64        `json:"x"`
-		Y       float64        `json:"y"`
-		Planets []PlanetDetail `json:"planets"`
-	}
-
+func buildSystemDetail(sys *entities.System, vis *game.Visibility) SystemDetail {
 	planets := make([]PlanetDetail, 0)
 	for _, e := range sys.Entities {
 		if planet, ok := e.(*entities.Planet); ok {
-			planets = append(planets, buildPlanetDetail(planet, sys.ID))
+			planets = append(planets, visiblePlanetDetail(planet, sys.ID, vis))
 		}
 	}
-	return systemDetail{
+	return SystemDetail{
 		ID:      sys.ID,
 		Name:    sys.Name,
 		X:       sys.X,
@@ -194,17 +243,45 @@ func buildSystemDetail(sys *entities.System) interface{} {
 	}
 }
 
-func handleGetPlanet(p GameStateProvider, id int) (interface{}, bool) {
+func handleGetPlanet(p GameStateProvider, vis *game.Visibility, id int) (PlanetDetail, bool) {
 	for _, sys := range p.GetSystems() {
 		for _, e := range sys.Entities {
 			if planet, ok := e.(*entities.Planet); ok {
 				if planet.GetID() == id {
-					return buildPlanetDetail(planet, sys.ID), true
+					return visiblePlanetDetail(planet, sys.ID, vis), true
 				}
 			}
 		}
 	}
-	return nil, false
+	return PlanetDetail{}, false
+}
+
+// visiblePlanetDetail is buildPlanetDetail cut down to the charted fields
+// when the planet is outside vis's sensor range.
+func visiblePlanetDetail(planet *entities.Planet, systemID int, vis *game.Visibility) PlanetDetail {
+	detail := buildPlanetDetail(planet, systemID)
+	if vis.Sees(planet.Owner, systemID) {
+		return detail
+	}
+	deposits := detail.ResourceDeposits
+	for i := range deposits {
+		deposits[i].HasMine = false
+	}
+	return PlanetDetail{
+		ID:               detail.ID,
+		Name:             detail.Name,
+		PlanetType:       detail.PlanetType,
+		Habitability:     detail.Habitability,
+		ResourceDeposits: deposits,
+		SystemID:         systemID,
+		Mass:             detail.Mass,
+		Radius:           detail.Radius,
+		Gravity:          detail.Gravity,
+		Density:          detail.Density,
+		OrbitAU:          detail.OrbitAU,
+		Composition:      detail.Composition,
+		Obscured:         true,
+	}
 }
 
 func buildPlanetDetail(planet *entities.Planet, systemID int) PlanetDetail {
@@ -297,27 +374,15 @@ func buildPlanetDetail(planet *entities.Planet, systemID int) PlanetDetail {
 	return detail
 }
 
-func handleGetPowerGrid(p GameStateProvider) interface{} {
-	type planetPower struct {
-		PlanetID       int       `json:"planet_id"`
-		PlanetName     string    `json:"planet_name"`
-		Owner          string    `json:"owner"`
-		Generated      float64   `json:"generated_mw"`
-		Consumed       float64   `json:"consumed_mw"`
-		Ratio          float64   `json:"ratio"`
-		Generators     int       `json:"generators"`
-		FusionReactors int       `json:"fusion_reactors"`
-		FuelStored     int       `json:"fuel_stored"`
-		He3Stored      int       `json:"he3_stored"`
-		History        []float64 `json:"history,omitempty"`
-	}
-	var result []planetPower
+func handleGetPowerGrid(p GameStateProvider, vis *game.Visibility) []PlanetPower {
+	var result []PlanetPower
+	systemOf := planetSystems(p)
 	for _, player := range p.GetPlayers() {
 		if player == nil {
 			continue
 		}
 		for _, planet := range player.OwnedPlanets {
-			if planet == nil {
+			if planet == nil || !vis.Sees(player.Name, systemOf[planet.GetID()]) {
 				continue
 			}
 			gens, reactors := 0, 0
@@ -330,7 +395,7 @@ func handleGetPowerGrid(p GameStateProvider) interface{} {
 					}
 				}
 			}
-			result = append(result, planetPower{
+			result = append(result, PlanetPower{
 				PlanetID:       planet.GetID(),
 				PlanetName:     planet.Name,
 				Owner:          player.Name,
@@ -348,7 +413,9 @@ func handleGetPowerGrid(p GameStateProvider) interface{} {
 	return result
 }
 
-func handleGetLeaderboard(p GameStateProvider) interface{} {
+// handleGetLeaderboard ranks every faction. Scores are public; the figures
+// behind them are only shown for factions vis knows.
+func handleGetLeaderboard(p GameStateProvider, vis *game.Visibility) []LeaderboardEntry {
 	players := p.GetPlayers()
 
 	entries := make([]LeaderboardEntry, 0, len(players))
@@ -411,19 +478,27 @@ func handleGetLeaderboard(p GameStateProvider) interface{} {
 	}
 	for i := range entries {
 		entries[i].Rank = i + 1
+		if !vis.Knows(entries[i].Name) {
+			entries[i] = LeaderboardEntry{
+				Rank: entries[i].Rank, Name: entries[i].Name, Type: entries[i].Type,
+				Score: entries[i].Score, Obscured: true,
+			}
+		}
 	}
 
 	return entries
 }
 
-func handleRemovePlayer(p GameStateProvider, name string) (interface{}, error) {
+func handleRemovePlayer(p GameStateProvider, name string) (map[string]string, error) {
 	if p.RemovePlayer(name) {
 		return map[string]string{"removed": name}, nil
 	}
 	return nil, fmt.Errorf("player not found: %s", name)
 }
 
-func handleGetPlayers(p GameStateProvider) interface{} {
+// handleGetPlayers lists every faction, with holdings only for the ones
+// vis knows.
+func handleGetPlayers(p GameStateProvider, vis *game.Visibility) []PlayerInfo {
 	players := p.GetPlayers()
 	result := make([]PlayerInfo, 0, len(players))
 	for _, pl := range players {
@@ -434,6 +509,10 @@ func handleGetPlayers(p GameStateProvider) interface{} {
 		if pl.IsHuman() {
 			pType = "human"
 		}
+		if !vis.Knows(pl.Name) {
+			result = append(result, PlayerInfo{ID: pl.ID, Name: pl.Name, Type: pType, Obscured: true})
+			continue
+		}
 		mines := 0
 		bldgs := 0
 		stock := 0
@@ -472,19 +551,12 @@ func handleGetPlayers(p GameStateProvider) interface{} {
 	return result
 }
 
-func handleGetPlayerMe(p GameStateProvider, authPlayer string) interface{} {
+func handleGetPlayerMe(p GameStateProvider, authPlayer string) *PlayerMe {
 	human := findPlayer(p, authPlayer)
 	if human == nil {
 		return nil
 	}
 
-	type playerMe struct {
-		Name    string         `json:"name"`
-		Credits int            `json:"credits"`
-		Planets []PlanetDetail `json:"planets"`
-		Ships   []ShipInfo     `json:"ships"`
-	}
-
 	planets := make([]PlanetDetail, 0)
 	for _, planet := range human.OwnedPlanets {
 		if planet == nil {
@@ -537,7 +609,7 @@ func handleGetPlayerMe(p GameStateProvider, authPlayer string) interface{} {
 		})
 	}
 
-	return playerMe{
+	return &PlayerMe{
 		Name:    human.Name,
 		Credits: human.Credits,
 		Planets: planets,
@@ -545,7 +617,7 @@ func handleGetPlayerMe(p GameStateProvider, authPlayer string) interface{} {
 	}
 }
 
-func handleGetStatus(p GameStateProvider, authPlayer string) interface{} {
+func handleGetStatus(p GameStateProvider, authPlayer string) GameStatus {
 	tick, gameTime, speed, paused := p.GetTickInfo()
 
 	// Player info
@@ -606,7 +678,7 @@ func handleGetStatus(p GameStateProvider, authPlayer string) interface{} {
 	}
 
 	// Economy
-	econ := handleGetEconomy(p).(EconomyOverview)
+	econ := handleGetEconomy(p)
 
 	// Generate actionable hints based on state
 	hints := generateHints(human, &playerStatus, &econ)
@@ -769,7 +841,7 @@ func generateHints(human *entities.Player, player *PlayerStatus, econ *EconomyOv
 	return hints
 }
 
-func handleGetGame(p GameStateProvider) interface{} {
+func handleGetGame(p GameStateProvider) GameInfo {
 	tick, gameTime, speed, paused := p.GetTickInfo()
 	return GameInfo{
 		Tick:     tick,
@@ -779,17 +851,20 @@ func handleGetGame(p GameStateProvider) interface{} {
 		Systems:  len(p.GetSystems()),
 		Players:  len(p.GetPlayers()),
 		Seed:     p.GetSeed(),
+		Config:   p.GetGameConfig(),
 	}
 }
 
-func handleGetShips(p GameStateProvider) interface{} {
+// handleGetShips lists the ships vis can see: its own and intel targets'
+// in full, others in sensor range without fuel, cargo or route.
+func handleGetShips(p GameStateProvider, vis *game.Visibility) []ShipInfo {
 	result := make([]ShipInfo, 0)
 	for _, player := range p.GetPlayers() {
 		if player == nil {
 			continue
 		}
 		for _, ship := range player.OwnedShips {
-			if ship == nil {
+			if ship == nil || !vis.Sees(ship.Owner, ship.CurrentSystem) {
 				continue
 			}
 			cargo := make(map[string]int)
@@ -814,12 +889,33 @@ func handleGetShips(p GameStateProvider) interface{} {
 				TravelProgress: ship.TravelProgress,
 				RoutePath:      ship.RoutePath,
 			})
+			if !vis.Knows(ship.Owner) {
+				obscureShip(&result[len(result)-1])
+			}
 		}
 	}
 	return result
 }
 
-func handleGetFleets(p GameStateProvider) interface{} {
+// obscureShip strips what sensors can't tell about another faction's ship.
+func obscureShip(info *ShipInfo) {
+	*info = ShipInfo{
+		ID:            info.ID,
+		Name:          info.Name,
+		Type:          info.Type,
+		Owner:         info.Owner,
+		Status:        info.Status,
+		SystemID:      info.SystemID,
+		HealthCurrent: info.HealthCurrent,
+		HealthMax:     info.HealthMax,
+		CargoMax:      info.CargoMax,
+		FuelMax:       info.FuelMax,
+		Obscured:      true,
+	}
+}
+
+// handleGetFleets lists fleets with the ships in them vis can see.
+func handleGetFleets(p GameStateProvider, vis *game.Visibility) []FleetInfo {
 	result := make([]FleetInfo, 0)
 	for _, player := range p.GetPlayers() {
 		if player == nil {
@@ -831,7 +927,7 @@ func handleGetFleets(p GameStateProvider) interface{} {
 			}
 			ships := make([]ShipInfo, 0, len(fleet.Ships))
 			for _, ship := range fleet.Ships {
-				if ship == nil {
+				if ship == nil || !vis.Sees(ship.Owner, ship.CurrentSystem) {
 					continue
 				}
 				cargo := make(map[string]int)
@@ -856,11 +952,22 @@ func handleGetFleets(p GameStateProvider) interface{} {
 					TravelProgress: ship.TravelProgress,
 					RoutePath:      ship.RoutePath,
 				})
+				if !vis.Knows(ship.Owner) {
+					obscureShip(&ships[len(ships)-1])
+				}
+			}
+			owner := fleet.GetOwner()
+			size := fleet.Size()
+			if !vis.Knows(owner) {
+				if len(ships) == 0 {
+					continue
+				}
+				size = len(ships)
 			}
 			result = append(result, FleetInfo{
 				ID:    fleet.ID,
-				Owner: fleet.GetOwner(),
-				Size:  fleet.Size(),
+				Owner: owner,
+				Size:  size,
 				Ships: ships,
 			})
 		}
@@ -868,7 +975,7 @@ func handleGetFleets(p GameStateProvider) interface{} {
 	return result
 }
 
-func handleGetEconomy(p GameStateProvider) interface{} {
+func handleGetEconomy(p GameStateProvider) EconomyOverview {
 	overview := EconomyOverview{
 		Resources: make(map[string]ResourceSummary),
 	}
@@ -957,7 +1064,7 @@ func handleGetEconomy(p GameStateProvider) interface{} {
 	return overview
 }
 
-func handleGetSystemPrices(p GameStateProvider) interface{} {
+func handleGetSystemPrices(p GameStateProvider) []SystemPrices {
 	market := p.GetMarket()
 	if market == nil {
 		return []SystemPrices{}
@@ -1003,10 +1110,15 @@ func handleGetSystemPrices(p GameStateProvider) interface{} {
 	return result
 }
 
-func handleGetPlanetStorage(p GameStateProvider, planetID int) (interface{}, bool) {
+// handleGetPlanetStorage reports a planet's stockpile. Like the rates and
+// workforce handlers, it treats a planet vis can't see as not found.
+func handleGetPlanetStorage(p GameStateProvider, vis *game.Visibility, planetID int) ([]PlanetStorageInfo, bool) {
 	for _, sys := range p.GetSystems() {
 		for _, e := range sys.Entities {
 			if planet, ok := e.(*entities.Planet); ok && planet.GetID() == planetID {
+				if !vis.Sees(planet.Owner, sys.ID) {
+					return nil, false
+				}
 				result := make([]PlanetStorageInfo, 0)
 				for resType, storage := range planet.StoredResources {
 					if storage != nil {
@@ -1024,13 +1136,16 @@ func handleGetPlanetStorage(p GameStateProvider, planetID int) (interface{}, boo
 	return nil, false
 }
 
-func handleGetPlanetRates(p GameStateProvider, planetID int) (interface{}, bool) {
+func handleGetPlanetRates(p GameStateProvider, vis *game.Visibility, planetID int) (PlanetRates, bool) {
 	for _, sys := range p.GetSystems() {
 		for _, e := range sys.Entities {
 			planet, ok := e.(*entities.Planet)
 			if !ok || planet.GetID() != planetID {
 				continue
 			}
+			if !vis.Sees(planet.Owner, sys.ID) {
+				return PlanetRates{}, false
+			}
 
 			production := make(map[string]float64)
 			consumption := make(map[string]float64)
@@ -1141,10 +1256,10 @@ func handleGetPlanetRates(p GameStateProvider, planetID int) (interface{}, bool)
 			}, true
 		}
 	}
-	return nil, false
+	return PlanetRates{}, false
 }
 
-func handleGetCatalog() interface{} {
+func handleGetCatalog() Catalog {
 	buildingTypes := []struct {
 		name        string
 		description string
@@ -1245,13 +1360,16 @@ func handleGetCatalog() interface{} {
 	}
 }
 
-func handleGetWorkforce(p GameStateProvider, planetID int) (interface{}, bool) {
+func handleGetWorkforce(p GameStateProvider, vis *game.Visibility, planetID int) (WorkforceInfo, bool) {
 	for _, sys := range p.GetSystems() {
 		for _, e := range sys.Entities {
 			planet, ok := e.(*entities.Planet)
 			if !ok || planet.GetID() != planetID {
 				continue
 			}
+			if !vis.Sees(planet.Owner, sys.ID) {
+				return WorkforceInfo{}, false
+			}
 
 			buildings := make([]WorkforceEntry, 0)
 			for i, be := range planet.Buildings {
@@ -1280,23 +1398,12 @@ func handleGetWorkforce(p GameStateProvider, planetID int) (interface{}, bool) {
 			}, true
 		}
 	}
-	return nil, false
+	return WorkforceInfo{}, false
 }
 
-func handleGetDeposits(p GameStateProvider, filterResource string, filterUnmined bool, filterOwner string) interface{} {
-	type DepositInfo struct {
-		SystemID     int     `json:"system_id"`
-		SystemName   string  `json:"system_name"`
-		PlanetID     int     `json:"planet_id"`
-		PlanetName   string  `json:"planet_name"`
-		Owner        string  `json:"owner"`
-		ResourceType string  `json:"resource_type"`
-		ResourceID   int     `json:"resource_id"`
-		Abundance    int     `json:"abundance"`
-		Rate         float64 `json:"extraction_rate"`
-		HasMine      bool    `json:"has_mine"`
-	}
-
+// handleGetDeposits lists resource deposits. Deposits are charted, but
+// who owns them and whether they're mined is only shown within vis.
+func handleGetDeposits(p GameStateProvider, vis *game.Visibility, filterResource string, filterUnmined bool, filterOwner string) []DepositInfo {
 	deposits := make([]DepositInfo, 0)
 	for _, sys := range p.GetSystems() {
 		for _, e := range sys.Entities {
@@ -1304,6 +1411,11 @@ func handleGetDeposits(p GameStateProvider, filterResource string, filterUnmined
 			if !ok {
 				continue
 			}
+			seen := vis.Sees(planet.Owner, sys.ID)
+			owner := ""
+			if seen {
+				owner = planet.Owner
+			}
 			for _, resEntity := range planet.Resources {
 				res, ok := resEntity.(*entities.Resource)
 				if !ok || res.Abundance <= 0 {
@@ -1314,7 +1426,7 @@ func handleGetDeposits(p GameStateProvider, filterResource string, filterUnmined
 				for _, be := range planet.Buildings {
 					if b, ok := be.(*entities.Building); ok {
 						if b.BuildingType == "Mine" && b.AttachedTo == resIDStr {
-							hasMine = true
+							hasMine = seen
 							break
 						}
 					}
@@ -1326,7 +1438,7 @@ func handleGetDeposits(p GameStateProvider, filterResource string, filterUnmined
 				if filterUnmined && hasMine {
 					continue
 				}
-				if filterOwner != "" && !strings.EqualFold(planet.Owner, filterOwner) {
+				if filterOwner != "" && !strings.EqualFold(owner, filterOwner) {
 					continue
 				}
 				deposits = append(deposits, DepositInfo{
@@ -1334,7 +1446,7 @@ func handleGetDeposits(p GameStateProvider, filterResource string, filterUnmined
 					SystemName:   sys.Name,
 					PlanetID:     planet.GetID(),
 					PlanetName:   planet.Name,
-					Owner:        planet.Owner,
+					Owner:        owner,
 					ResourceType: res.ResourceType,
 					ResourceID:   res.GetID(),
 					Abundance:    res.Abundance,
@@ -1347,7 +1459,7 @@ func handleGetDeposits(p GameStateProvider, filterResource string, filterUnmined
 	return deposits
 }
 
-func handleGetGalaxyFlows(p GameStateProvider) interface{} {
+func handleGetGalaxyFlows(p GameStateProvider) GalaxyFlows {
 	production := make(map[string]float64)
 	consumption := make(map[string]float64)
 	var totalPop int64
@@ -1447,8 +1559,10 @@ func handleGetGalaxyFlows(p GameStateProvider) interface{} {
 	}
 }
 
-func handleGetConstructionQueue(p GameStateProvider) interface{} {
-	cs := tickable.GetConstructionSystem()
+// handleGetConstructionQueue lists construction for the factions vis knows;
+// sensors can't see what's being built.
+func handleGetConstructionQueue(p GameStateProvider, vis *game.Visibility) []ConstructionQueueItem {
+	cs := p.GetTickableSystems().ConstructionSystem()
 	if cs == nil {
 		return []ConstructionQueueItem{}
 	}
@@ -1457,6 +1571,9 @@ func handleGetConstructionQueue(p GameStateProvider) interface{} {
 	result := make([]ConstructionQueueItem, 0)
 	for _, items := range allQueues {
 		for _, item := range items {
+			if !vis.Knows(item.Owner) {
+				continue
+			}
 			progress := 0
 			if item.TotalTicks > 0 {
 				progress = 100 - (item.RemainingTicks*100)/item.TotalTicks
@@ -1538,8 +1655,8 @@ func handleGetDiagnostics(p GameStateProvider) interface{} {
 	}
 
 	// Tickable system diagnostics
-	smTicks, smShips, smMoving := tickable.GetShipMovementDiag()
-	smPlayerMoving := tickable.GetShipMovementPlayers()
+	smTicks, smShips, smMoving := p.GetTickableSystems().ShipMovementDiag()
+	smPlayerMoving := p.GetTickableSystems().ShipMovementPlayers()
 
 	type result struct {
 		diag
@@ -1552,17 +1669,39 @@ func handleGetDiagnostics(p GameStateProvider) interface{} {
 	return result{d, movingDetails, smTicks, smShips, smMoving, smPlayerMoving}
 }
 
-// handleGetDefense returns per-system military defense ratings.
-func handleGetDefense(p GameStateProvider) interface{} {
-	type systemDefense struct {
-		SystemID    int    `json:"system_id"`
-		SystemName  string `json:"system_name"`
-		Power       int    `json:"defense_power"`  // total military AttackPower
-		Ships       int    `json:"military_ships"` // count of military ships present
-		Safe        bool   `json:"safe"`           // power >= 10 (piracy immune)
-		Owner       string `json:"owner,omitempty"`
+// handleGetTickProfile returns the game's rolling per-tickable-system
+// profile, limited to the top systems by total time when top > 0.
+func handleGetTickProfile(p GameStateProvider, top int) tickable.TickProfile {
+	tp := p.GetTickableSystems().TickProfile()
+	if top > 0 && len(tp.Systems) > top {
+		tp.Systems = tp.Systems[:top]
+	}
+	return tp
+}
+
+// handleSetTickProfile applies profiler settings from an admin request to
+// the game's profiler.
+func handleSetTickProfile(p GameStateProvider, req TickProfileRequest) {
+	systems := p.GetTickableSystems()
+	if req.Enabled != nil {
+		systems.SetProfiling(*req.Enabled)
 	}
+	if req.BudgetMs != nil {
+		systems.SetTickBudget(time.Duration(*req.BudgetMs * float64(time.Millisecond)))
+	}
+	if req.Reset {
+		systems.ResetProfile()
+	}
+}
+
+// handleGetSchedules lists every tickable system's cadence in run order.
+func handleGetSchedules(p GameStateProvider) []tickable.ScheduleInfo {
+	return p.GetTickableSystems().Schedules()
+}
 
+// handleGetDefense returns per-system military defense ratings.
+// Only systems in vis's sensor range are reported.
+func handleGetDefense(p GameStateProvider, vis *game.Visibility) []SystemDefense {
 	defensePower := make(map[int]int)
 	defenseShips := make(map[int]int)
 	for _, player := range p.GetPlayers() {
@@ -1584,8 +1723,11 @@ func handleGetDefense(p GameStateProvider) interface{} {
 		}
 	}
 
-	result := make([]systemDefense, 0)
+	result := make([]SystemDefense, 0)
 	for _, sys := range p.GetSystems() {
+		if !vis.SeesSystem(sys.ID) {
+			continue
+		}
 		power := defensePower[sys.ID]
 		ships := defenseShips[sys.ID]
 		owner := ""
@@ -1596,7 +1738,7 @@ func handleGetDefense(p GameStateProvider) interface{} {
 			}
 		}
 		if power > 0 || owner != "" {
-			result = append(result, systemDefense{
+			result = append(result, SystemDefense{
 				SystemID:   sys.ID,
 				SystemName: sys.Name,
 				Power:      power,
@@ -1609,27 +1751,13 @@ func handleGetDefense(p GameStateProvider) interface{} {
 	return result
 }
 
-// handleGetStations returns all stations across the galaxy.
-func handleGetStations(p GameStateProvider) interface{} {
-	type stationInfo struct {
-		ID          int      `json:"id"`
-		Name        string   `json:"name"`
-		Type        string   `json:"type"`
-		SystemID    int      `json:"system_id"`
-		SystemName  string   `json:"system_name"`
-		Owner       string   `json:"owner"`
-		Population  int      `json:"population"`
-		Capacity    int      `json:"capacity"`
-		DockingFee  int      `json:"docking_fee"`
-		Services    []string `json:"services"`
-		DefenseLevel int     `json:"defense_level"`
-	}
-
-	result := make([]stationInfo, 0)
+// handleGetStations returns the stations vis can see across the galaxy.
+func handleGetStations(p GameStateProvider, vis *game.Visibility) []StationInfo {
+	result := make([]StationInfo, 0)
 	for _, sys := range p.GetSystems() {
 		for _, e := range sys.Entities {
-			if station, ok := e.(*entities.Station); ok {
-				result = append(result, stationInfo{
+			if station, ok := e.(*entities.Station); ok && vis.Sees(station.Owner, sys.ID) {
+				result = append(result, StationInfo{
 					ID:          station.GetID(),
 					Name:        station.Name,
 					Type:        station.StationType,
@@ -0,0 +1,181 @@
+//go:build !js
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// routeScopes maps write endpoints (POST and DELETE) to the key scope they
+// need. GETs only need read; any write not listed here needs a full-access
+// key.
+var routeScopes = map[string]string{
+	// Checked per command by the handler
+	"/api/batch": game.ScopeRead,
+
+	// Trade
+	"/api/market/trade":       game.ScopeTrade,
+	"/api/orders":             game.ScopeTrade,
+	"/api/orders/limit":       game.ScopeTrade,
+	"/api/futures":            game.ScopeTrade,
+	"/api/contracts":          game.ScopeTrade,
+	"/api/auctions":           game.ScopeTrade,
+	"/api/black-market":       game.ScopeTrade,
+	"/api/shipping":           game.ScopeTrade,
+	"/api/shipping/routes":    game.ScopeTrade,
+	"/api/ships/sell-at-dock": game.ScopeTrade,
+	"/api/ships/buy-at-dock":  game.ScopeTrade,
+
+	// Build
+	"/api/build":               game.ScopeBuild,
+	"/api/ships/build":         game.ScopeBuild,
+	"/api/upgrade":             game.ScopeBuild,
+	"/api/demolish":            game.ScopeBuild,
+	"/api/colonize":            game.ScopeBuild,
+	"/api/workforce/assign":    game.ScopeBuild,
+	"/api/construction/cancel": game.ScopeBuild,
+
+	// Fleet
+	"/api/ships/move":          game.ScopeFleet,
+	"/api/ships/refuel":        game.ScopeFleet,
+	"/api/ships/transfer-fuel": game.ScopeFleet,
+	"/api/ships/dock":          game.ScopeFleet,
+	"/api/ships/undock":        game.ScopeFleet,
+	"/api/cargo/load":          game.ScopeFleet,
+	"/api/cargo/unload":        game.ScopeFleet,
+	"/api/fleets/move":         game.ScopeFleet,
+	"/api/fleets/create":       game.ScopeFleet,
+	"/api/fleets/disband":      game.ScopeFleet,
+	"/api/fleets/add-ship":     game.ScopeFleet,
+	"/api/fleets/remove-ship":  game.ScopeFleet,
+}
+
+// requiredScope returns the key scope needed for a request.
+func requiredScope(r *http.Request) string {
+	return scopeFor(r.Method, r.URL.Path)
+}
+
+// scopeFor returns the key scope needed to call method on path.
+func scopeFor(method, path string) string {
+	if method == http.MethodGet || method == http.MethodHead {
+		return game.ScopeRead
+	}
+	if scope, ok := routeScopes[path]; ok {
+		return scope
+	}
+	return game.ScopeAll
+}
+
+// keyInfo converts a stored key to its API form.
+func keyInfo(k game.APIKey, current string, now time.Time) APIKeyInfo {
+	info := APIKeyInfo{
+		ID:        k.ID,
+		Name:      k.Name,
+		Prefix:    k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt.Format(time.RFC3339),
+		Expired:   k.Expired(now),
+		Current:   k.ID == current,
+	}
+	if !k.ExpiresAt.IsZero() {
+		info.ExpiresAt = k.ExpiresAt.Format(time.RFC3339)
+	}
+	return info
+}
+
+// keyOwner resolves whose keys a request manages: the caller's own, or for
+// admins the named player.
+func keyOwner(r *http.Request, player string) string {
+	if isAdmin(r) && player != "" {
+		return player
+	}
+	return getAuthPlayer(r)
+}
+
+// registerKeyEndpoints registers API key management:
+//   - GET  /api/keys         list the caller's keys (admin: ?player=)
+//   - POST /api/keys         create a named, scoped key; the secret is returned once
+//   - POST /api/keys/revoke  revoke a key by ID or name
+//
+// Creating and revoking keys needs a full-access key, so a limited key
+// can't mint itself more power. Only admins can issue observer keys.
+func registerKeyEndpoints(rt *router) {
+	get(rt, "/api/keys", doc{Tag: "keys", Summary: "List API keys", Query: []param{
+		{"player", "string", "admin only: whose keys"},
+	}}, func(r *http.Request) ([]APIKeyInfo, error) {
+		registry := getProvider(r).GetRegistry()
+		if registry == nil {
+			return nil, errUnavailable("registry")
+		}
+		player := keyOwner(r, r.URL.Query().Get("player"))
+		if player == "" {
+			return nil, errAuthRequired
+		}
+		keys, err := registry.ListKeys(player)
+		if err != nil {
+			return nil, errNotFound("%s", err)
+		}
+		now := time.Now()
+		current := getAuthGrant(r).KeyID
+		out := make([]APIKeyInfo, 0, len(keys))
+		for _, k := range keys {
+			out = append(out, keyInfo(k, current, now))
+		}
+		return out, nil
+	})
+
+	post(rt, "/api/keys", doc{Tag: "keys", Summary: "Create a named, scoped API key"},
+		func(r *http.Request, req *CreateKeyRequest) (CreatedKey, error) {
+			registry := getProvider(r).GetRegistry()
+			if registry == nil {
+				return CreatedKey{}, errUnavailable("registry")
+			}
+			player := keyOwner(r, req.Player)
+			if player == "" {
+				return CreatedKey{}, errors.New("player required")
+			}
+			observer := slices.ContainsFunc(req.Scopes, func(s string) bool {
+				return strings.EqualFold(strings.TrimSpace(s), game.ScopeObserve)
+			})
+			if observer && !isAdmin(r) {
+				return CreatedKey{}, errStatus(http.StatusForbidden, "only admins can issue %s keys", game.ScopeObserve)
+			}
+			if req.ExpiresInHours < 0 {
+				return CreatedKey{}, errors.New("expires_in_hours must be >= 0")
+			}
+			var expiresAt time.Time
+			if req.ExpiresInHours > 0 {
+				expiresAt = time.Now().Add(time.Duration(req.ExpiresInHours * float64(time.Hour)))
+			}
+			raw, key, err := registry.CreateKey(player, req.Name, req.Scopes, expiresAt)
+			if err != nil {
+				return CreatedKey{}, err
+			}
+			return CreatedKey{APIKey: raw, Key: keyInfo(*key, "", time.Now())}, nil
+		})
+
+	post(rt, "/api/keys/revoke", doc{Tag: "keys", Summary: "Revoke an API key by ID or name"},
+		func(r *http.Request, req *RevokeKeyRequest) (map[string]string, error) {
+			registry := getProvider(r).GetRegistry()
+			if registry == nil {
+				return nil, errUnavailable("registry")
+			}
+			if req.Key == "" {
+				return nil, errors.New("key required")
+			}
+			player := keyOwner(r, req.Player)
+			if player == "" {
+				return nil, errors.New("player required")
+			}
+			if err := registry.RevokeKey(player, req.Key); err != nil {
+				return nil, errNotFound("%s", err)
+			}
+			return map[string]string{"revoked": req.Key}, nil
+		})
+}
@@ -0,0 +1,316 @@
+//go:build !js
+
+package api
+
+import (
+	"encoding"
+	"encoding/json"
+	"net/http"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// apiVersion is the version reported in the OpenAPI document. Bump the minor
+// version when endpoints are added and the major when they change shape.
+const apiVersion = "1.0.0"
+
+// buildOpenAPI generates an OpenAPI 3 document from the registered routes.
+// Request and response schemas come from the Go types via their JSON tags;
+// every JSON response is wrapped in the APIResponse envelope.
+func buildOpenAPI(routes []*route) map[string]interface{} {
+	sg := &schemaGen{
+		components: make(map[string]interface{}),
+		names:      make(map[reflect.Type]string),
+		taken:      make(map[string]reflect.Type),
+	}
+	paths := make(map[string]interface{})
+	for _, rte := range routes {
+		if rte.Doc.Hidden {
+			continue
+		}
+		item, ok := paths[rte.Path].(map[string]interface{})
+		if !ok {
+			item = make(map[string]interface{})
+			paths[rte.Path] = item
+		}
+		item[strings.ToLower(rte.Method)] = sg.operation(rte)
+	}
+
+	return map[string]interface{}{
+		"openapi": "3.0.3",
+		"info": map[string]interface{}{
+			"title":       "Xandaris API",
+			"version":     apiVersion,
+			"description": "REST API for Xandaris II. Responses are wrapped as {ok, data, error} and carry an X-Request-ID header. Writes need an X-API-Key whose scopes cover the operation's x-scope. Each call takes its x-rate-limit-cost from the caller's rate limits, reported in X-RateLimit-* headers.",
+		},
+		"paths": paths,
+		"components": map[string]interface{}{
+			"schemas": sg.components,
+			"securitySchemes": map[string]interface{}{
+				"apiKey": map[string]interface{}{"type": "apiKey", "in": "header", "name": "X-API-Key"},
+			},
+			"responses": map[string]interface{}{
+				"Error": map[string]interface{}{
+					"description": "Error",
+					"content": map[string]interface{}{"application/json": map[string]interface{}{
+						"schema": envelopeSchema(nil),
+					}},
+				},
+			},
+		},
+	}
+}
+
+func (sg *schemaGen) operation(rte *route) map[string]interface{} {
+	op := map[string]interface{}{
+		"operationId":       operationID(rte.Method, rte.Path),
+		"x-scope":           scopeFor(rte.Method, rte.Path),
+		"x-rate-limit-cost": rte.Doc.cost(),
+		"responses": map[string]interface{}{
+			"200":     sg.successResponse(rte),
+			"default": map[string]interface{}{"$ref": "#/components/responses/Error"},
+		},
+	}
+	if rte.Doc.Summary != "" {
+		op["summary"] = rte.Doc.Summary
+	}
+	if rte.Doc.Tag != "" {
+		op["tags"] = []string{rte.Doc.Tag}
+	}
+
+	var params []interface{}
+	for _, seg := range strings.Split(rte.Path, "/") {
+		if strings.HasPrefix(seg, "{") && strings.HasSuffix(seg, "}") {
+			name := strings.TrimSuffix(strings.Trim(seg, "{}"), "...")
+			if name == "$" {
+				continue
+			}
+			params = append(params, map[string]interface{}{
+				"name": name, "in": "path", "required": true,
+				"schema": map[string]interface{}{"type": "integer"},
+			})
+		}
+	}
+	for _, q := range rte.Doc.Query {
+		p := map[string]interface{}{
+			"name": q.Name, "in": "query",
+			"schema": map[string]interface{}{"type": q.Type},
+		}
+		if q.Desc != "" {
+			p["description"] = q.Desc
+		}
+		params = append(params, p)
+	}
+	if rte.Method == http.MethodPost {
+		params = append(params, map[string]interface{}{
+			"name": "Idempotency-Key", "in": "header",
+			"description": "Resending with the same key returns the first result instead of running again",
+			"schema":      map[string]interface{}{"type": "string", "maxLength": maxIdempotencyKeyLen},
+		})
+	}
+	if len(params) > 0 {
+		op["parameters"] = params
+	}
+
+	if rte.Request != nil {
+		op["requestBody"] = map[string]interface{}{
+			"required": true,
+			"content": map[string]interface{}{"application/json": map[string]interface{}{
+				"schema": sg.schema(rte.Request),
+			}},
+		}
+	}
+	if rte.Doc.Admin || rte.Doc.Auth || rte.Method != http.MethodGet {
+		op["security"] = []interface{}{map[string]interface{}{"apiKey": []string{}}}
+	}
+	return op
+}
+
+func (sg *schemaGen) successResponse(rte *route) map[string]interface{} {
+	if rte.Doc.ContentType != "" {
+		return map[string]interface{}{
+			"description": "OK",
+			"content":     map[string]interface{}{rte.Doc.ContentType: map[string]interface{}{}},
+		}
+	}
+	var data map[string]interface{}
+	if rte.Response != nil {
+		data = sg.schema(rte.Response)
+	}
+	return map[string]interface{}{
+		"description": "OK",
+		"content": map[string]interface{}{"application/json": map[string]interface{}{
+			"schema": envelopeSchema(data),
+		}},
+	}
+}
+
+// envelopeSchema is APIResponse with data typed as the given schema.
+func envelopeSchema(data map[string]interface{}) map[string]interface{} {
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"ok":    map[string]interface{}{"type": "boolean"},
+			"data":  data,
+			"error": map[string]interface{}{"type": "string"},
+		},
+	}
+}
+
+// operationID turns "POST /api/ships/sell-at-dock" into "postShipsSellAtDock".
+func operationID(method, p string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+	for _, seg := range strings.Split(strings.TrimPrefix(p, "/api"), "/") {
+		for _, word := range strings.FieldsFunc(seg, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }) {
+			b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+		}
+	}
+	return b.String()
+}
+
+// schemaGen converts Go types to OpenAPI schemas, collecting named structs
+// under components/schemas.
+type schemaGen struct {
+	components map[string]interface{}
+	names      map[reflect.Type]string
+	taken      map[string]reflect.Type
+}
+
+var (
+	timeType          = reflect.TypeFor[time.Time]()
+	jsonMarshalerType = reflect.TypeFor[json.Marshaler]()
+	textMarshalerType = reflect.TypeFor[encoding.TextMarshaler]()
+)
+
+func (sg *schemaGen) schema(t reflect.Type) map[string]interface{} {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	switch {
+	case t == timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]interface{}{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]interface{}{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Int64, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return map[string]interface{}{"type": "string", "format": "byte"}
+		}
+		return map[string]interface{}{"type": "array", "items": sg.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]interface{}{"type": "object", "additionalProperties": sg.schema(t.Elem())}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return sg.structSchema(t)
+		}
+		return map[string]interface{}{"$ref": "#/components/schemas/" + sg.componentName(t)}
+	}
+	return map[string]interface{}{} // interface{} and anything JSON can't encode
+}
+
+// componentName registers a named struct as a component and returns its name.
+func (sg *schemaGen) componentName(t reflect.Type) string {
+	if name, ok := sg.names[t]; ok {
+		return name
+	}
+	name := schemaName(t.Name())
+	if other, ok := sg.taken[name]; ok && other != t {
+		pkg := path.Base(t.PkgPath())
+		name = strings.ToUpper(pkg[:1]) + pkg[1:] + name
+	}
+	sg.names[t] = name
+	sg.taken[name] = t
+	sg.components[name] = map[string]interface{}{} // placeholder for recursive types
+	sg.components[name] = sg.structSchema(t)
+	return name
+}
+
+// schemaName makes a Go type name usable as a component key (generic
+// instantiations contain brackets and package paths).
+func schemaName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' {
+			return r
+		}
+		return -1
+	}, name)
+}
+
+func (sg *schemaGen) structSchema(t reflect.Type) map[string]interface{} {
+	props := make(map[string]interface{})
+	sg.addFields(t, props)
+	return map[string]interface{}{"type": "object", "properties": props}
+}
+
+// addFields adds t's JSON fields to props, flattening embedded structs the
+// way encoding/json does.
+func (sg *schemaGen) addFields(t reflect.Type, props map[string]interface{}) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag := f.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, opts, _ := strings.Cut(tag, ",")
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Pointer {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				sg.addFields(ft, props)
+				continue
+			}
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.Contains(opts, "string") {
+			props[name] = map[string]interface{}{"type": "string"}
+			continue
+		}
+		props[name] = sg.schema(f.Type)
+	}
+}
+
+// registerOpenAPI serves the generated document at GET /api/openapi.json.
+// It's built on first request, once every route has been registered.
+func registerOpenAPI(rt *router) {
+	var (
+		once sync.Once
+		spec []byte
+	)
+	rt.raw(http.MethodGet, "/api/openapi.json", doc{Tag: "meta", Summary: "This OpenAPI document", ContentType: "application/json"}, nil,
+		func(w http.ResponseWriter, r *http.Request) {
+			once.Do(func() {
+				spec, _ = json.MarshalIndent(buildOpenAPI(rt.routes), "", "  ")
+			})
+			w.Header().Set("Content-Type", "application/json")
+			w.Write(spec)
+		})
+}
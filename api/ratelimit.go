@@ -3,38 +3,63 @@
 package api
 
 import (
+	"fmt"
+	"math"
+	"net"
 	"net/http"
+	"os"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 )
 
-// RateLimiter implements per-key token bucket rate limiting.
+// defaultRateLimits: 30 reads/sec, 10 writes/sec per caller, burst of 60/20,
+// and no daily quota unless XANDARIS_DAILY_QUOTA sets one.
+var defaultRateLimits = RateLimits{ReadRate: 30, WriteRate: 10, BurstRead: 60, BurstWrite: 20}
+
+// RateLimiter meters requests at two levels. Each caller has a token bucket
+// for reads and one for writes that refill every second, and each player
+// has a quota per UTC day. A request takes its route's cost from both.
+// Admins can override an account's limits while the server runs.
 type RateLimiter struct {
-	mu      sync.Mutex
-	buckets map[string]*bucket
-	// Config
-	readRate   float64 // tokens per second for GET
-	writeRate  float64 // tokens per second for POST
-	burstRead  int     // max burst for GET
-	burstWrite int     // max burst for POST
+	mu        sync.Mutex
+	defaults  RateLimits
+	overrides map[string]RateLimits // by player name
+	buckets   map[string]*bucket
+	daily     map[string]*dailyUsage // by player name
 }
 
 type bucket struct {
 	tokens    float64
 	lastCheck time.Time
-	capacity  int
-	rate      float64
 }
 
-// NewRateLimiter creates a rate limiter.
-// readRate/writeRate = requests per second. burst = max burst size.
-func NewRateLimiter(readRate, writeRate float64, burstRead, burstWrite int) *RateLimiter {
+type dailyUsage struct {
+	day  string // UTC date
+	used float64
+}
+
+// RateStatus is the outcome of metering one request.
+type RateStatus struct {
+	Allowed    bool
+	Cost       float64
+	Limit      int           // bucket size
+	Remaining  float64       // left in the bucket
+	Reset      time.Duration // until the bucket is full
+	DailyLimit float64       // 0 = no quota
+	DailyUsed  float64
+	DailyReset time.Duration // until the quota resets
+	RetryAfter time.Duration // set when not allowed
+}
+
+// NewRateLimiter creates a rate limiter with the given default limits.
+func NewRateLimiter(defaults RateLimits) *RateLimiter {
 	rl := &RateLimiter{
-		buckets:    make(map[string]*bucket),
-		readRate:   readRate,
-		writeRate:  writeRate,
-		burstRead:  burstRead,
-		burstWrite: burstWrite,
+		defaults:  defaults,
+		overrides: make(map[string]RateLimits),
+		buckets:   make(map[string]*bucket),
+		daily:     make(map[string]*dailyUsage),
 	}
 	// Cleanup stale buckets every 5 minutes
 	go func() {
@@ -46,86 +71,252 @@ func NewRateLimiter(readRate, writeRate float64, burstRead, burstWrite int) *Rat
 	return rl
 }
 
-// Allow checks if a request should be allowed.
-func (rl *RateLimiter) Allow(key string, isWrite bool) bool {
+// dailyQuotaFromEnv reads XANDARIS_DAILY_QUOTA, the default cost units a
+// player may spend per UTC day (unset or 0 = unlimited).
+func dailyQuotaFromEnv() float64 {
+	s := os.Getenv("XANDARIS_DAILY_QUOTA")
+	if s == "" {
+		return 0
+	}
+	q, err := strconv.ParseFloat(s, 64)
+	if err != nil || q < 0 {
+		fmt.Printf("[API] Ignoring XANDARIS_DAILY_QUOTA=%q\n", s)
+		return 0
+	}
+	return q
+}
+
+// limitsLocked returns player's effective limits. Caller must hold the lock.
+func (rl *RateLimiter) limitsLocked(player string) RateLimits {
+	limits := rl.defaults
+	o, ok := rl.overrides[player]
+	if !ok {
+		return limits
+	}
+	if o.ReadRate > 0 {
+		limits.ReadRate = o.ReadRate
+	}
+	if o.WriteRate > 0 {
+		limits.WriteRate = o.WriteRate
+	}
+	if o.BurstRead > 0 {
+		limits.BurstRead = o.BurstRead
+	}
+	if o.BurstWrite > 0 {
+		limits.BurstWrite = o.BurstWrite
+	}
+	if o.DailyQuota > 0 {
+		limits.DailyQuota = o.DailyQuota
+	} else if o.DailyQuota < 0 {
+		limits.DailyQuota = 0
+	}
+	return limits
+}
+
+// usageLocked returns player's usage for now's UTC day. Caller must hold the lock.
+func (rl *RateLimiter) usageLocked(player string, now time.Time) *dailyUsage {
+	day := now.UTC().Format(time.DateOnly)
+	u, ok := rl.daily[player]
+	if !ok || u.day != day {
+		u = &dailyUsage{day: day}
+		rl.daily[player] = u
+	}
+	return u
+}
+
+// Take meters a request costing cost from key's bucket and, if player is
+// set, from their daily quota. Nothing is taken from either if the request
+// is refused. A request costing more than the whole bucket runs on a full one.
+func (rl *RateLimiter) Take(key, player string, isWrite bool, cost float64, now time.Time) RateStatus {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
 
-	bucketKey := key
+	limits := rl.limitsLocked(player)
+	rate, capacity := limits.ReadRate, limits.BurstRead
+	bucketKey := key + ":r"
 	if isWrite {
-		bucketKey += ":w"
-	} else {
-		bucketKey += ":r"
+		rate, capacity = limits.WriteRate, limits.BurstWrite
+		bucketKey = key + ":w"
 	}
 
 	b, exists := rl.buckets[bucketKey]
 	if !exists {
-		rate := rl.readRate
-		cap := rl.burstRead
-		if isWrite {
-			rate = rl.writeRate
-			cap = rl.burstWrite
-		}
-		b = &bucket{
-			tokens:    float64(cap),
-			lastCheck: time.Now(),
-			capacity:  cap,
-			rate:      rate,
-		}
+		b = &bucket{tokens: float64(capacity), lastCheck: now}
 		rl.buckets[bucketKey] = b
 	}
+	// Refill tokens; an override may have shrunk the bucket
+	b.tokens = math.Min(float64(capacity), b.tokens+now.Sub(b.lastCheck).Seconds()*rate)
+	b.lastCheck = now
+	need := math.Min(cost, float64(capacity))
 
-	// Refill tokens
-	now := time.Now()
-	elapsed := now.Sub(b.lastCheck).Seconds()
-	b.tokens += elapsed * b.rate
-	if b.tokens > float64(b.capacity) {
-		b.tokens = float64(b.capacity)
+	st := RateStatus{Cost: cost, Limit: capacity}
+	var usage *dailyUsage
+	if player != "" {
+		usage = rl.usageLocked(player, now)
+		st.DailyLimit = limits.DailyQuota
+		y, m, d := now.UTC().Date()
+		st.DailyReset = time.Date(y, m, d+1, 0, 0, 0, 0, time.UTC).Sub(now)
+	}
+
+	switch {
+	case usage != nil && st.DailyLimit > 0 && usage.used+cost > st.DailyLimit:
+		st.RetryAfter = st.DailyReset
+	case b.tokens < need:
+		st.RetryAfter = time.Duration((need - b.tokens) / rate * float64(time.Second))
+	default:
+		st.Allowed = true
+		b.tokens -= need
+		if usage != nil {
+			usage.used += cost
+		}
 	}
-	b.lastCheck = now
 
-	// Try to consume
-	if b.tokens >= 1 {
-		b.tokens--
-		return true
+	st.Remaining = b.tokens
+	st.Reset = time.Duration((float64(capacity) - b.tokens) / rate * float64(time.Second))
+	if usage != nil {
+		st.DailyUsed = usage.used
 	}
-	return false
+	return st
 }
 
-// Middleware wraps an http.Handler with rate limiting.
-func (rl *RateLimiter) Middleware(next http.Handler) http.Handler {
-	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// Skip rate limiting for OPTIONS (CORS preflight)
-		if r.Method == "OPTIONS" {
-			next.ServeHTTP(w, r)
-			return
-		}
+// writeHeaders reports the status as X-RateLimit-* headers, plus
+// Retry-After on a refusal. Durations are rounded up to whole seconds.
+func (st RateStatus) writeHeaders(h http.Header) {
+	secs := func(d time.Duration) string {
+		return strconv.Itoa(int(math.Ceil(d.Seconds())))
+	}
+	h.Set("X-RateLimit-Limit", strconv.Itoa(st.Limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(int(st.Remaining)))
+	h.Set("X-RateLimit-Reset", secs(st.Reset))
+	h.Set("X-RateLimit-Cost", strconv.FormatFloat(st.Cost, 'g', -1, 64))
+	if st.DailyLimit > 0 {
+		h.Set("X-RateLimit-Daily-Limit", strconv.FormatFloat(st.DailyLimit, 'g', -1, 64))
+		h.Set("X-RateLimit-Daily-Remaining", strconv.FormatFloat(math.Max(0, st.DailyLimit-st.DailyUsed), 'g', -1, 64))
+		h.Set("X-RateLimit-Daily-Reset", secs(st.DailyReset))
+	}
+	if !st.Allowed {
+		h.Set("Retry-After", secs(max(st.RetryAfter, time.Second)))
+	}
+}
 
-		// Key: API key or IP address
-		key := r.Header.Get("X-API-Key")
-		if key == "" {
-			key = r.RemoteAddr
-		}
+// rateLimitKey identifies who a request is metered as: a player (all their
+// keys share buckets and a quota), the admin, another key, or an address.
+// Hosted games keep their own accounts, so their players are metered apart.
+func rateLimitKey(r *http.Request) (key, player string) {
+	grant := getAuthGrant(r)
+	switch {
+	case grant.Admin:
+		return "admin", ""
+	case grant.Player != "" && getAuthPlayer(r) != "":
+		player = rateLimitPlayer(r, grant.Player)
+		return "player:" + player, player
+	case grant.KeyID != "":
+		return "key:" + rateLimitPlayer(r, grant.KeyID), ""
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	return "addr:" + host, ""
+}
 
-		isWrite := r.Method == http.MethodPost
+// rateLimitPlayer qualifies name with r's hosted game ID, if any.
+func rateLimitPlayer(r *http.Request, name string) string {
+	if g := gameFor(r); g != nil {
+		return g.id + "/" + name
+	}
+	return name
+}
 
-		if !rl.Allow(key, isWrite) {
-			w.Header().Set("Retry-After", "1")
-			writeErr(w, http.StatusTooManyRequests, "rate limit exceeded")
-			return
-		}
+// SetOverride replaces player's limits. Zero fields keep the defaults.
+func (rl *RateLimiter) SetOverride(player string, limits RateLimits) error {
+	if limits.ReadRate < 0 || limits.WriteRate < 0 || limits.BurstRead < 0 || limits.BurstWrite < 0 {
+		return fmt.Errorf("rates and bursts must be >= 0")
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.overrides[player] = limits
+	return nil
+}
+
+// ClearOverride returns player to the default limits.
+func (rl *RateLimiter) ClearOverride(player string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.overrides, player)
+}
+
+// ResetUsage zeroes player's usage for today.
+func (rl *RateLimiter) ResetUsage(player string) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.daily, player)
+}
+
+// Account reports player's effective limits and today's usage.
+func (rl *RateLimiter) Account(player string) AccountRateLimit {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.accountLocked(player, time.Now())
+}
 
-		next.ServeHTTP(w, r)
+func (rl *RateLimiter) accountLocked(player string, now time.Time) AccountRateLimit {
+	info := AccountRateLimit{Player: player, Limits: rl.limitsLocked(player)}
+	if o, ok := rl.overrides[player]; ok {
+		info.Override = &o
+	}
+	if u, ok := rl.daily[player]; ok && u.day == now.UTC().Format(time.DateOnly) {
+		info.DailyUsed = u.used
+	}
+	return info
+}
+
+// Report lists the defaults and every account with an override or usage
+// today, heaviest users first.
+func (rl *RateLimiter) Report() RateLimitReport {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	now := time.Now()
+	report := RateLimitReport{Defaults: rl.defaults, Accounts: make([]AccountRateLimit, 0)}
+	seen := make(map[string]bool)
+	add := func(player string) {
+		if !seen[player] {
+			seen[player] = true
+			report.Accounts = append(report.Accounts, rl.accountLocked(player, now))
+		}
+	}
+	for player := range rl.overrides {
+		add(player)
+	}
+	for player, u := range rl.daily {
+		if u.day == now.UTC().Format(time.DateOnly) {
+			add(player)
+		}
+	}
+	sort.Slice(report.Accounts, func(i, j int) bool {
+		a, b := report.Accounts[i], report.Accounts[j]
+		if a.DailyUsed != b.DailyUsed {
+			return a.DailyUsed > b.DailyUsed
+		}
+		return a.Player < b.Player
 	})
+	return report
 }
 
 func (rl *RateLimiter) cleanup() {
 	rl.mu.Lock()
 	defer rl.mu.Unlock()
-	cutoff := time.Now().Add(-10 * time.Minute)
+	now := time.Now()
+	cutoff := now.Add(-10 * time.Minute)
 	for key, b := range rl.buckets {
 		if b.lastCheck.Before(cutoff) {
 			delete(rl.buckets, key)
 		}
 	}
+	today := now.UTC().Format(time.DateOnly)
+	for player, u := range rl.daily {
+		if u.day != today {
+			delete(rl.daily, player)
+		}
+	}
 }
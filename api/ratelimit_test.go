@@ -0,0 +1,212 @@
+//go:build !js
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func TestRateLimiterTake(t *testing.T) {
+	rl := NewRateLimiter(RateLimits{ReadRate: 2, WriteRate: 1, BurstRead: 4, BurstWrite: 2, DailyQuota: 10})
+	now := time.Date(2026, 3, 1, 23, 59, 0, 0, time.UTC)
+
+	// A 3-unit read leaves 1 of 4; the next one is refused and takes nothing
+	if st := rl.Take("p", "Alpha", false, 3, now); !st.Allowed || st.Remaining != 1 || st.Limit != 4 || st.DailyUsed != 3 {
+		t.Fatalf("expected a 3-unit read allowed, got %+v", st)
+	}
+	st := rl.Take("p", "Alpha", false, 3, now)
+	if st.Allowed || st.Remaining != 1 || st.DailyUsed != 3 {
+		t.Fatalf("expected a refusal that takes nothing, got %+v", st)
+	}
+	if st.RetryAfter != time.Second {
+		t.Errorf("expected to wait 1s for 2 tokens at 2/s, got %v", st.RetryAfter)
+	}
+
+	// Writes have their own bucket
+	if st := rl.Take("p", "Alpha", true, 1, now); !st.Allowed || st.Limit != 2 || st.Remaining != 1 {
+		t.Errorf("expected a write from the write bucket, got %+v", st)
+	}
+
+	// The bucket refills at the read rate, up to its size
+	if st := rl.Take("p", "Alpha", false, 3, now.Add(time.Second)); !st.Allowed || st.Remaining != 0 || st.Reset != 2*time.Second {
+		t.Errorf("expected a refilled bucket, got %+v", st)
+	}
+
+	// A call costing more than the bucket runs on a full one
+	if st := rl.Take("q", "", false, 50, now); !st.Allowed || st.Remaining != 0 {
+		t.Errorf("expected an oversized call to drain a full bucket, got %+v", st)
+	}
+
+	// Over the daily quota, even with tokens to spare, until the UTC day turns
+	later := now.Add(30 * time.Second)
+	rl.Take("p", "Alpha", true, 1, later)
+	st = rl.Take("p", "Alpha", false, 3, later)
+	if st.Allowed || st.DailyUsed != 8 || st.RetryAfter != 30*time.Second || st.DailyReset != 30*time.Second {
+		t.Errorf("expected the daily quota to refuse until midnight, got %+v", st)
+	}
+	if st := rl.Take("p", "Alpha", false, 3, now.Add(2*time.Minute)); !st.Allowed || st.DailyUsed != 3 {
+		t.Errorf("expected a fresh quota the next day, got %+v", st)
+	}
+	// Anonymous callers have no quota
+	if st := rl.Take("r", "", false, 1, now); st.DailyLimit != 0 || st.DailyReset != 0 {
+		t.Errorf("expected no quota without a player, got %+v", st)
+	}
+}
+
+func TestRateLimiterOverrides(t *testing.T) {
+	rl := NewRateLimiter(RateLimits{ReadRate: 10, WriteRate: 10, BurstRead: 10, BurstWrite: 10, DailyQuota: 5})
+	now := time.Now()
+
+	if err := rl.SetOverride("Alpha", RateLimits{BurstRead: 2, DailyQuota: -1}); err != nil {
+		t.Fatalf("set override: %v", err)
+	}
+	if err := rl.SetOverride("Alpha", RateLimits{ReadRate: -1}); err == nil {
+		t.Error("expected a negative rate rejected")
+	}
+	st := rl.Take("a", "Alpha", false, 1, now)
+	if st.Limit != 2 || st.DailyLimit != 0 {
+		t.Errorf("expected a 2-token bucket and no quota, got %+v", st)
+	}
+	info := rl.Account("Alpha")
+	if info.Override == nil || info.Limits.ReadRate != 10 || info.Limits.BurstRead != 2 || info.DailyUsed != 1 {
+		t.Errorf("expected the override over the defaults, got %+v", info)
+	}
+
+	rl.ClearOverride("Alpha")
+	rl.ResetUsage("Alpha")
+	if info := rl.Account("Alpha"); info.Override != nil || info.Limits.DailyQuota != 5 || info.DailyUsed != 0 {
+		t.Errorf("expected the defaults back with no usage, got %+v", info)
+	}
+}
+
+func TestRateStatusHeaders(t *testing.T) {
+	h := http.Header{}
+	RateStatus{Allowed: true, Cost: 2.5, Limit: 60, Remaining: 41.7, Reset: 1500 * time.Millisecond}.writeHeaders(h)
+	want := map[string]string{
+		"X-RateLimit-Limit":     "60",
+		"X-RateLimit-Remaining": "41",
+		"X-RateLimit-Reset":     "2",
+		"X-RateLimit-Cost":      "2.5",
+	}
+	for name, value := range want {
+		if got := h.Get(name); got != value {
+			t.Errorf("%s: expected %q, got %q", name, value, got)
+		}
+	}
+	if h.Get("X-RateLimit-Daily-Limit") != "" || h.Get("Retry-After") != "" {
+		t.Errorf("expected no quota or Retry-After headers, got %v", h)
+	}
+
+	h = http.Header{}
+	RateStatus{Cost: 1, Limit: 20, DailyLimit: 100, DailyUsed: 120, DailyReset: 90 * time.Minute, RetryAfter: 10 * time.Millisecond}.writeHeaders(h)
+	want = map[string]string{
+		"X-RateLimit-Daily-Limit":     "100",
+		"X-RateLimit-Daily-Remaining": "0",
+		"X-RateLimit-Daily-Reset":     "5400",
+		"Retry-After":                 "1",
+	}
+	for name, value := range want {
+		if got := h.Get(name); got != value {
+			t.Errorf("%s: expected %q, got %q", name, value, got)
+		}
+	}
+}
+
+func TestRouteCostsAndQuotas(t *testing.T) {
+	p := newTestProvider()
+	h := testServer(t, p, NewRateLimiter(RateLimits{ReadRate: 0.001, WriteRate: 0.001, BurstRead: 20, BurstWrite: 20, DailyQuota: 12}))
+	key := p.key(t, "Alpha", "all")
+
+	// Each call takes its route's cost from the bucket and the quota
+	rec := call(h, http.MethodGet, "/api/market", key, nil)
+	if rec.Header().Get("X-RateLimit-Cost") != "1" || rec.Header().Get("X-RateLimit-Remaining") != "19" ||
+		rec.Header().Get("X-RateLimit-Daily-Remaining") != "11" {
+		t.Errorf("expected a 1-unit call, got %v", rec.Header())
+	}
+	rec = call(h, http.MethodGet, "/api/spectate", key, nil)
+	if rec.Header().Get("X-RateLimit-Cost") != "5" || rec.Header().Get("X-RateLimit-Remaining") != "14" ||
+		rec.Header().Get("X-RateLimit-Daily-Remaining") != "6" {
+		t.Errorf("expected a 5-unit call, got %v", rec.Header())
+	}
+	// Another player's keys don't share Alpha's buckets; Alpha's do
+	other := call(h, http.MethodGet, "/api/market", p.key(t, "Beta", "all"), nil)
+	if other.Header().Get("X-RateLimit-Daily-Remaining") != "11" {
+		t.Errorf("expected Beta metered apart, got %v", other.Header())
+	}
+
+	rec = call(h, http.MethodGet, "/api/spectate", p.key(t, "Alpha", "trade"), nil)
+	if rec.Header().Get("X-RateLimit-Daily-Remaining") != "1" {
+		t.Errorf("expected Alpha's second key on the same quota, got %v", rec.Header())
+	}
+
+	// Over the quota: refused with how long until it resets
+	rec = call(h, http.MethodGet, "/api/spectate", key, nil)
+	if rec.Code != http.StatusTooManyRequests || decode(t, rec, nil).Error != "daily quota exceeded" {
+		t.Fatalf("expected 429 daily quota exceeded, got %d %s", rec.Code, rec.Body)
+	}
+	retry, err := strconv.Atoi(rec.Header().Get("Retry-After"))
+	if err != nil || retry < 1 || retry > 86400 || rec.Header().Get("X-RateLimit-Daily-Reset") != rec.Header().Get("Retry-After") {
+		t.Errorf("expected Retry-After until the quota resets, got %v", rec.Header())
+	}
+
+	// Anonymous callers are metered by address with no quota
+	rec = call(h, http.MethodGet, "/api/market", "", nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("X-RateLimit-Daily-Limit") != "" {
+		t.Errorf("expected an anonymous call without a quota, got %d %v", rec.Code, rec.Header())
+	}
+	// An emptied bucket is a plain rate limit
+	for range 20 {
+		rec = call(h, http.MethodGet, "/api/market", "", nil)
+	}
+	if rec.Code != http.StatusTooManyRequests || decode(t, rec, nil).Error != "rate limit exceeded" || rec.Header().Get("Retry-After") == "" {
+		t.Errorf("expected 429 rate limit exceeded, got %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestAdminRateLimitOverrides(t *testing.T) {
+	p := newTestProvider()
+	h := testServer(t, p, NewRateLimiter(RateLimits{ReadRate: 100, WriteRate: 100, BurstRead: 100, BurstWrite: 100, DailyQuota: 2}))
+	key := p.key(t, "Alpha", "all")
+
+	call(h, http.MethodGet, "/api/market", key, nil)
+	call(h, http.MethodGet, "/api/market", key, nil)
+	if rec := call(h, http.MethodGet, "/api/market", key, nil); rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected Alpha over quota, got %d", rec.Code)
+	}
+
+	if rec := call(h, http.MethodPost, "/api/admin/rate-limits", p.key(t, "Beta", "all"), RateLimitRequest{Player: "Beta", Limits: RateLimits{DailyQuota: 50}}); rec.Code != http.StatusForbidden {
+		t.Errorf("expected players kept out of overrides, got %d", rec.Code)
+	}
+	if rec := call(h, http.MethodPost, "/api/admin/rate-limits", testAdminKey, RateLimitRequest{Player: "Nobody"}); rec.Code != http.StatusNotFound {
+		t.Errorf("expected 404 for an unknown account, got %d", rec.Code)
+	}
+
+	var info AccountRateLimit
+	rec := call(h, http.MethodPost, "/api/admin/rate-limits", testAdminKey, RateLimitRequest{Player: "alpha", Limits: RateLimits{DailyQuota: 50}})
+	if rec.Code != http.StatusOK || !decode(t, rec, &info).OK {
+		t.Fatalf("set override: %d %s", rec.Code, rec.Body)
+	}
+	if info.Player != "Alpha" || info.Override == nil || info.Limits.DailyQuota != 50 || info.DailyUsed != 2 {
+		t.Errorf("unexpected account %+v", info)
+	}
+	rec = call(h, http.MethodGet, "/api/market", key, nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("X-RateLimit-Daily-Remaining") != "47" {
+		t.Errorf("expected Alpha unblocked under the raised quota, got %d %v", rec.Code, rec.Header())
+	}
+
+	var report RateLimitReport
+	decode(t, call(h, http.MethodGet, "/api/admin/rate-limits", testAdminKey, nil), &report)
+	// Beta's refused call still counted against their quota
+	if report.Defaults.DailyQuota != 2 || len(report.Accounts) != 2 || report.Accounts[0].Player != "Alpha" || report.Accounts[0].DailyUsed != 3 {
+		t.Errorf("unexpected report %+v", report)
+	}
+
+	var cleared AccountRateLimit
+	rec = call(h, http.MethodPost, "/api/admin/rate-limits", testAdminKey, RateLimitRequest{Player: "Alpha", Clear: true, ResetUsage: true})
+	if decode(t, rec, &cleared); cleared.Override != nil || cleared.Limits.DailyQuota != 2 || cleared.DailyUsed != 0 {
+		t.Errorf("expected the defaults back with no usage, got %+v", cleared)
+	}
+}
@@ -0,0 +1,245 @@
+//go:build !js
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// apiError is an error with the HTTP status it should be reported as.
+// Handlers return plain errors for ordinary rejections (400).
+type apiError struct {
+	Code int
+	Msg  string
+}
+
+func (e *apiError) Error() string { return e.Msg }
+
+func errStatus(code int, format string, args ...interface{}) error {
+	return &apiError{Code: code, Msg: fmt.Sprintf(format, args...)}
+}
+
+func errNotFound(format string, args ...interface{}) error {
+	return errStatus(http.StatusNotFound, format, args...)
+}
+
+func errUnavailable(what string) error {
+	return errStatus(http.StatusInternalServerError, "%s not available", what)
+}
+
+var errAuthRequired = &apiError{Code: http.StatusUnauthorized, Msg: "auth required"}
+
+// writeError reports err with its status (400 unless it's an apiError).
+func writeError(w http.ResponseWriter, err error) {
+	var ae *apiError
+	if errors.As(err, &ae) {
+		writeErr(w, ae.Code, ae.Msg)
+		return
+	}
+	writeErr(w, http.StatusBadRequest, err.Error())
+}
+
+// param documents a query parameter.
+type param struct {
+	Name string
+	Type string // OpenAPI type: string, integer, boolean
+	Desc string
+}
+
+// doc describes an endpoint for the OpenAPI document and the checks the
+// router runs before the handler.
+type doc struct {
+	Summary string
+	Tag     string
+	Query   []param
+	Admin   bool    // admin key required (403 otherwise)
+	Auth    bool    // a player identity required (401 otherwise)
+	Hidden  bool    // left out of the OpenAPI document
+	Cost    float64 // rate-limit cost; 0 = 1
+
+	// ContentType is set for raw routes whose body isn't an APIResponse.
+	ContentType string
+}
+
+// cost is what a call takes from the caller's rate limits.
+func (d doc) cost() float64 {
+	if d.Cost > 0 {
+		return d.Cost
+	}
+	return 1
+}
+
+// route is one method on one path.
+type route struct {
+	Method   string
+	Path     string // ServeMux pattern, e.g. /api/planets/{id}
+	Doc      doc
+	Request  reflect.Type // JSON body type, nil if none
+	Response reflect.Type // type of APIResponse.Data, nil if untyped
+	handler  http.HandlerFunc
+}
+
+// router registers every method of a path under one ServeMux pattern so
+// that unsupported methods get a JSON 405 like the rest of the API, and
+// meters each call against the caller's rate limits.
+type router struct {
+	mux     *http.ServeMux
+	limiter *RateLimiter
+	routes  []*route
+	paths   map[string]map[string]*route
+}
+
+func newRouter(mux *http.ServeMux, limiter *RateLimiter) *router {
+	return &router{mux: mux, limiter: limiter, paths: make(map[string]map[string]*route)}
+}
+
+func (rt *router) add(rte *route) {
+	methods, ok := rt.paths[rte.Path]
+	if !ok {
+		methods = make(map[string]*route)
+		rt.paths[rte.Path] = methods
+		rt.mux.HandleFunc(rte.Path, func(w http.ResponseWriter, r *http.Request) {
+			rt.serve(methods, w, r)
+		})
+	}
+	if _, dup := methods[rte.Method]; dup {
+		panic(fmt.Sprintf("api: duplicate route %s %s", rte.Method, rte.Path))
+	}
+	methods[rte.Method] = rte
+	rt.routes = append(rt.routes, rte)
+}
+
+func (rt *router) serve(methods map[string]*route, w http.ResponseWriter, r *http.Request) {
+	rte, ok := methods[r.Method]
+	if !ok && r.Method == http.MethodHead {
+		rte, ok = methods[http.MethodGet]
+	}
+	if !ok {
+		allowed := make([]string, 0, len(methods))
+		for _, m := range []string{http.MethodGet, http.MethodPost, http.MethodDelete} {
+			if _, ok := methods[m]; ok {
+				allowed = append(allowed, m)
+			}
+		}
+		w.Header().Set("Allow", strings.Join(allowed, ", "))
+		writeErr(w, http.StatusMethodNotAllowed, strings.Join(allowed, " or ")+" only")
+		return
+	}
+	if rt.limiter != nil {
+		key, player := rateLimitKey(r)
+		isWrite := r.Method != http.MethodGet && r.Method != http.MethodHead
+		st := rt.limiter.Take(key, player, isWrite, rte.Doc.cost(), time.Now())
+		st.writeHeaders(w.Header())
+		if !st.Allowed {
+			msg := "rate limit exceeded"
+			if st.DailyLimit > 0 && st.DailyUsed+st.Cost > st.DailyLimit {
+				msg = "daily quota exceeded"
+			}
+			writeErr(w, http.StatusTooManyRequests, msg)
+			return
+		}
+	}
+	if rte.Doc.Admin && !isAdmin(r) {
+		writeErr(w, http.StatusForbidden, "admin only")
+		return
+	}
+	if rte.Doc.Auth && getAuthPlayer(r) == "" {
+		writeError(w, errAuthRequired)
+		return
+	}
+	rte.handler(w, r)
+}
+
+// raw registers a handler that writes its own response (redirects, HTML,
+// streams). The response type, if any, is only for the OpenAPI document.
+func (rt *router) raw(method, path string, d doc, resp interface{}, h http.HandlerFunc) {
+	rte := &route{Method: method, Path: path, Doc: d, handler: h}
+	if resp != nil {
+		rte.Response = reflect.TypeOf(resp)
+	}
+	rt.add(rte)
+}
+
+// get registers a read endpoint.
+func get[Resp any](rt *router, path string, d doc, fn func(r *http.Request) (Resp, error)) {
+	rt.add(&route{
+		Method:   http.MethodGet,
+		Path:     path,
+		Doc:      d,
+		Response: reflect.TypeFor[Resp](),
+		handler: func(w http.ResponseWriter, r *http.Request) {
+			resp, err := fn(r)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, APIResponse{OK: true, Data: resp})
+		},
+	})
+}
+
+// noBody is the request type of endpoints that take no JSON body.
+type noBody struct{}
+
+// post registers a write endpoint whose JSON body decodes into Req.
+func post[Req, Resp any](rt *router, path string, d doc, fn func(r *http.Request, req *Req) (Resp, error)) {
+	rt.add(bodyRoute(http.MethodPost, path, d, fn))
+}
+
+// del registers a DELETE endpoint whose JSON body decodes into Req.
+func del[Req, Resp any](rt *router, path string, d doc, fn func(r *http.Request, req *Req) (Resp, error)) {
+	rt.add(bodyRoute(http.MethodDelete, path, d, fn))
+}
+
+func bodyRoute[Req, Resp any](method, path string, d doc, fn func(r *http.Request, req *Req) (Resp, error)) *route {
+	rte := &route{
+		Method:   method,
+		Path:     path,
+		Doc:      d,
+		Response: reflect.TypeFor[Resp](),
+		handler: func(w http.ResponseWriter, r *http.Request) {
+			req := new(Req)
+			if _, empty := any(req).(*noBody); !empty {
+				if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+					writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
+					return
+				}
+			}
+			resp, err := fn(r, req)
+			if err != nil {
+				writeError(w, err)
+				return
+			}
+			writeJSON(w, APIResponse{OK: true, Data: resp})
+		},
+	}
+	if t := reflect.TypeFor[Req](); t != reflect.TypeFor[noBody]() {
+		rte.Request = t
+	}
+	return rte
+}
+
+// pathID parses the integer path parameter name.
+func pathID(r *http.Request, name, what string) (int, error) {
+	id, err := strconv.Atoi(r.PathValue(name))
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", what)
+	}
+	return id, nil
+}
+
+// queryInt parses an integer query parameter, returning def if it's missing
+// or not a positive number.
+func queryInt(r *http.Request, name string, def int) int {
+	if n, err := strconv.Atoi(r.URL.Query().Get(name)); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
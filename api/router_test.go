@@ -0,0 +1,330 @@
+//go:build !js
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/utils"
+)
+
+const testAdminKey = "test-admin-key"
+
+// testProvider is a GameStateProvider for a two-faction game with no galaxy.
+// Methods the tests don't need fall through to the nil embedded interface
+// and panic, so a test reaching one says so loudly.
+type testProvider struct {
+	GameStateProvider
+	registry *game.PlayerRegistry
+	players  []*entities.Player
+	events   *game.EventLog
+	commands chan game.GameCommand
+
+	mu        sync.Mutex
+	callbacks []func(tick int64)
+}
+
+func newTestProvider() *testProvider {
+	p := &testProvider{
+		registry: game.NewPlayerRegistryAt(testAdminKey, ""),
+		events:   game.NewEventLog(100),
+		commands: make(chan game.GameCommand, 8),
+	}
+	for i, name := range []string{"Alpha", "Beta"} {
+		p.players = append(p.players, entities.NewPlayer(i, name, utils.PlayerGreen, entities.PlayerTypeHuman))
+		p.registry.FindOrCreateByName(name)
+	}
+	return p
+}
+
+func (p *testProvider) GetSystems() []*entities.System                 { return nil }
+func (p *testProvider) GetHyperlanes() []entities.Hyperlane            { return nil }
+func (p *testProvider) GetPlayers() []*entities.Player                 { return p.players }
+func (p *testProvider) GetHumanPlayer() *entities.Player               { return nil }
+func (p *testProvider) GetMarket() *economy.Market                     { return nil }
+func (p *testProvider) GetDeliveryManager() *economy.DeliveryManager   { return nil }
+func (p *testProvider) GetShippingManager() *game.ShippingManager      { return nil }
+func (p *testProvider) GetEspionageManager() *economy.EspionageManager { return nil }
+func (p *testProvider) GetRegistry() *game.PlayerRegistry              { return p.registry }
+func (p *testProvider) GetEventLog() *game.EventLog                    { return p.events }
+func (p *testProvider) GetCommandChannel() chan game.GameCommand       { return p.commands }
+func (p *testProvider) GetTickInfo() (int64, string, string, bool) {
+	return 100, "0:01:40", "1x", false
+}
+
+func (p *testProvider) AddTickCallback(fn func(tick int64)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.callbacks = append(p.callbacks, fn)
+}
+
+// tick runs the tick callbacks as the simulation goroutine would.
+func (p *testProvider) tick(tick int64) {
+	p.mu.Lock()
+	callbacks := p.callbacks
+	p.mu.Unlock()
+	for _, fn := range callbacks {
+		fn(tick)
+	}
+}
+
+// serveCommands answers every command with reply until the test ends.
+func (p *testProvider) serveCommands(t *testing.T, reply func(cmd game.GameCommand) interface{}) {
+	done := make(chan struct{})
+	t.Cleanup(func() { close(done) })
+	go func() {
+		for {
+			select {
+			case cmd := <-p.commands:
+				cmd.Result <- reply(cmd)
+			case <-done:
+				return
+			}
+		}
+	}()
+}
+
+// key creates an API key for player with the given scopes.
+func (p *testProvider) key(t *testing.T, player string, scopes ...string) string {
+	t.Helper()
+	raw, _, err := p.registry.CreateKey(player, strings.Join(scopes, "-"), scopes, time.Time{})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	return raw
+}
+
+// testServer serves p as the default game through the full middleware
+// stack, metered by limiter (nil for the default limits).
+func testServer(t *testing.T, p *testProvider, limiter *RateLimiter) http.Handler {
+	t.Helper()
+	providerMu.Lock()
+	prev := activeProvider
+	activeProvider = p
+	providerMu.Unlock()
+	t.Cleanup(func() {
+		providerMu.Lock()
+		activeProvider = prev
+		providerMu.Unlock()
+	})
+	if limiter == nil {
+		limiter = NewRateLimiter(defaultRateLimits)
+	}
+	return newHandler(limiter)
+}
+
+// call makes a request with an optional API key and JSON body.
+func call(h http.Handler, method, path, key string, body interface{}) *httptest.ResponseRecorder {
+	var buf bytes.Buffer
+	if s, ok := body.(string); ok {
+		buf.WriteString(s)
+	} else if body != nil {
+		json.NewEncoder(&buf).Encode(body)
+	}
+	req := httptest.NewRequest(method, path, &buf)
+	if key != "" {
+		req.Header.Set("X-API-Key", key)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+// decode unwraps an APIResponse, decoding its data into out if given.
+func decode(t *testing.T, rec *httptest.ResponseRecorder, out interface{}) APIResponse {
+	t.Helper()
+	var resp struct {
+		OK    bool            `json:"ok"`
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("response %q isn't an APIResponse: %v", rec.Body.String(), err)
+	}
+	if out != nil && len(resp.Data) > 0 {
+		if err := json.Unmarshal(resp.Data, out); err != nil {
+			t.Fatalf("decode data %s: %v", resp.Data, err)
+		}
+	}
+	return APIResponse{OK: resp.OK, Error: resp.Error}
+}
+
+func TestRouterMethodsAndBodies(t *testing.T) {
+	p := newTestProvider()
+	h := testServer(t, p, nil)
+
+	rec := call(h, http.MethodGet, "/api/market", "", nil)
+	if rec.Code != http.StatusOK || !decode(t, rec, nil).OK {
+		t.Fatalf("GET /api/market: %d %s", rec.Code, rec.Body)
+	}
+	if rec.Header().Get("X-Request-ID") == "" {
+		t.Error("expected an X-Request-ID header")
+	}
+	if rec := call(h, http.MethodHead, "/api/market", "", nil); rec.Code != http.StatusOK {
+		t.Errorf("expected HEAD to be served by GET, got %d", rec.Code)
+	}
+
+	rec = call(h, http.MethodPut, "/api/orders", "", nil)
+	if rec.Code != http.StatusMethodNotAllowed || rec.Header().Get("Allow") != "GET, POST, DELETE" {
+		t.Errorf("expected 405 allowing GET, POST, DELETE, got %d %q", rec.Code, rec.Header().Get("Allow"))
+	}
+	if resp := decode(t, rec, nil); resp.OK || resp.Error != "GET or POST or DELETE only" {
+		t.Errorf("expected a JSON 405, got %+v", resp)
+	}
+
+	rec = call(h, http.MethodPost, "/api/market/trade", p.key(t, "Alpha", game.ScopeAll), "{not json")
+	if rec.Code != http.StatusBadRequest || !strings.HasPrefix(decode(t, rec, nil).Error, "invalid JSON") {
+		t.Errorf("expected a 400 for a malformed body, got %d %s", rec.Code, rec.Body)
+	}
+
+	// Typed bodies reach the handler and typed results come back in the envelope
+	p.serveCommands(t, func(cmd game.GameCommand) interface{} {
+		td := cmd.Data.(game.TradeCommandData)
+		return economy.TradeRecord{Player: cmd.PlayerName, Resource: td.Resource, Quantity: td.Quantity, Action: "buy", Total: 40}
+	})
+	rec = call(h, http.MethodPost, "/api/market/trade", p.key(t, "Alpha", game.ScopeTrade),
+		TradeRequest{Resource: "Iron", Quantity: 4, Action: "buy"})
+	var trade TradeResult
+	if rec.Code != http.StatusOK || !decode(t, rec, &trade).OK {
+		t.Fatalf("POST /api/market/trade: %d %s", rec.Code, rec.Body)
+	}
+	if trade != (TradeResult{Resource: "Iron", Quantity: 4, Action: "buy", Total: 40}) {
+		t.Errorf("unexpected trade result %+v", trade)
+	}
+}
+
+func TestRouterAuthAndScopes(t *testing.T) {
+	p := newTestProvider()
+	h := testServer(t, p, nil)
+	tradeKey := p.key(t, "Alpha", game.ScopeTrade)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		key    string
+		status int
+		err    string
+	}{
+		{"auth route, anonymous", http.MethodPost, "/api/black-market", "", http.StatusUnauthorized, "auth required"},
+		{"admin route, player key", http.MethodGet, "/api/admin/games", tradeKey, http.StatusForbidden, "admin only"},
+		{"write outside the key's scopes", http.MethodPost, "/api/build", tradeKey, http.StatusForbidden, `API key "trade" lacks the build scope`},
+		{"unlisted write needs full access", http.MethodPost, "/api/council", tradeKey, http.StatusForbidden, `API key "trade" lacks the all scope`},
+		{"unknown key is anonymous", http.MethodPost, "/api/black-market", "nope", http.StatusUnauthorized, "auth required"},
+	}
+	for _, tc := range cases {
+		rec := call(h, tc.method, tc.path, tc.key, "{}")
+		if resp := decode(t, rec, nil); rec.Code != tc.status || resp.Error != tc.err {
+			t.Errorf("%s: expected %d %q, got %d %q", tc.name, tc.status, tc.err, rec.Code, resp.Error)
+		}
+	}
+
+	// Reads need no scope; the admin may do anything as anyone
+	if rec := call(h, http.MethodGet, "/api/players", tradeKey, nil); rec.Code != http.StatusOK {
+		t.Errorf("expected a trade key to read, got %d", rec.Code)
+	}
+	if rec := call(h, http.MethodGet, "/api/admin/games", testAdminKey, nil); rec.Code == http.StatusForbidden {
+		t.Errorf("expected the admin key past the admin check, got %s", rec.Body)
+	}
+}
+
+func TestScopeFor(t *testing.T) {
+	cases := []struct {
+		method, path, scope string
+	}{
+		{http.MethodGet, "/api/build", game.ScopeRead},
+		{http.MethodHead, "/api/market", game.ScopeRead},
+		{http.MethodPost, "/api/market/trade", game.ScopeTrade},
+		{http.MethodDelete, "/api/orders", game.ScopeTrade},
+		{http.MethodPost, "/api/black-market", game.ScopeTrade},
+		{http.MethodPost, "/api/build", game.ScopeBuild},
+		{http.MethodPost, "/api/fleets/move", game.ScopeFleet},
+		{http.MethodPost, "/api/batch", game.ScopeRead},
+		{http.MethodPost, "/api/keys", game.ScopeAll},
+		{http.MethodPost, "/api/espionage", game.ScopeAll},
+	}
+	for _, tc := range cases {
+		if got := scopeFor(tc.method, tc.path); got != tc.scope {
+			t.Errorf("%s %s: expected %s, got %s", tc.method, tc.path, tc.scope, got)
+		}
+	}
+}
+
+func TestOpenAPIDocument(t *testing.T) {
+	h := testServer(t, newTestProvider(), nil)
+	rec := call(h, http.MethodGet, "/api/openapi.json", "", nil)
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "application/json" {
+		t.Fatalf("GET /api/openapi.json: %d %s", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	var spec struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &spec); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if spec.OpenAPI != "3.0.3" {
+		t.Errorf("expected OpenAPI 3.0.3, got %q", spec.OpenAPI)
+	}
+	if _, ok := spec.Paths["/{$}"]; ok {
+		t.Error("expected hidden pages left out")
+	}
+
+	// Every scoped write is documented with its scope
+	for path, scope := range routeScopes {
+		op := spec.Paths[path]["post"]
+		if op == nil {
+			op = spec.Paths[path]["delete"]
+		}
+		if op == nil {
+			t.Errorf("routeScopes lists %s, which has no write route", path)
+			continue
+		}
+		if op["x-scope"] != scope {
+			t.Errorf("%s: expected x-scope %s, got %v", path, scope, op["x-scope"])
+		}
+	}
+
+	ids := make(map[string]string)
+	for path, methods := range spec.Paths {
+		for method, op := range methods {
+			id, _ := op["operationId"].(string)
+			if prev, dup := ids[id]; dup || id == "" {
+				t.Errorf("%s %s: operationId %q already used by %s", method, path, id, prev)
+			}
+			ids[id] = method + " " + path
+		}
+	}
+
+	batch := spec.Paths["/api/batch"]["post"]
+	if batch["x-rate-limit-cost"] != 5.0 {
+		t.Errorf("expected /api/batch to cost 5, got %v", batch["x-rate-limit-cost"])
+	}
+	if spec.Paths["/api/market"]["get"]["x-rate-limit-cost"] != 1.0 {
+		t.Errorf("expected /api/market to cost 1, got %v", spec.Paths["/api/market"]["get"]["x-rate-limit-cost"])
+	}
+	body, _ := json.Marshal(batch["requestBody"])
+	if !strings.Contains(string(body), `"$ref":"#/components/schemas/BatchRequest"`) {
+		t.Errorf("expected the batch body to reference BatchRequest, got %s", body)
+	}
+	if _, ok := spec.Components.Schemas["BatchRequest"]; !ok {
+		t.Error("expected a BatchRequest schema")
+	}
+	params, _ := json.Marshal(spec.Paths["/api/planets/{id}"]["get"]["parameters"])
+	if !strings.Contains(string(params), `"in":"path"`) || !strings.Contains(string(params), `"name":"id"`) {
+		t.Errorf("expected an id path parameter, got %s", params)
+	}
+}
@@ -0,0 +1,372 @@
+//go:build !js
+
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// registerAdminRoutes registers game control, diagnostics and admin-only
+// account management.
+func registerAdminRoutes(rt *router) {
+	get(rt, "/api/game", doc{Tag: "game", Summary: "Seed, tick and speed"},
+		func(r *http.Request) (GameInfo, error) {
+			return handleGetGame(getProvider(r)), nil
+		})
+
+	post(rt, "/api/game/speed", doc{Tag: "game", Summary: "Set simulation speed"},
+		func(r *http.Request, req *SpeedRequest) (map[string]string, error) {
+			speed, ok := parseSpeed(req.Speed)
+			if !ok {
+				return nil, errors.New("invalid speed; use: slow, normal, fast, very_fast")
+			}
+			queueCommand(r, game.GameCommand{Type: game.CmdSetSpeed, Data: speed})
+			return map[string]string{"speed": req.Speed}, nil
+		})
+
+	post(rt, "/api/game/pause", doc{Tag: "game", Summary: "Toggle pause"},
+		func(r *http.Request, _ *noBody) (map[string]string, error) {
+			queueCommand(r, game.GameCommand{Type: game.CmdTogglePause})
+			return map[string]string{"action": "toggled"}, nil
+		})
+
+	post(rt, "/api/game/save", doc{Tag: "game", Summary: "Save the game and report where"},
+		func(r *http.Request, _ *noBody) (map[string]string, error) {
+			name := "Player"
+			if human := getProvider(r).GetHumanPlayer(); human != nil {
+				name = human.Name
+			}
+			cmd := newCommand(r, game.CmdSave, name)
+			cmd.IdempotencyKey = "" // the reply arrives after the handler returns, so it can't be cached
+			result, err := awaitCommand(r, cmd)
+			if err != nil {
+				var apiErr *apiError
+				if errors.As(err, &apiErr) {
+					return nil, err // timed out or cancelled
+				}
+				return nil, errStatus(http.StatusInternalServerError, "save failed: %v", err)
+			}
+			path, _ := result.(string)
+			return map[string]string{"action": "saved", "file": path}, nil
+		})
+
+	get(rt, "/api/diagnostics", doc{Tag: "admin", Summary: "Simulation internals", Admin: true},
+		func(r *http.Request) (interface{}, error) {
+			return handleGetDiagnostics(getProvider(r)), nil
+		})
+
+	// Per-tickable-system timing/allocation profile and tick budget.
+	get(rt, "/api/admin/tick-profile", doc{Tag: "admin", Summary: "Per-system tick timings", Admin: true, Query: []param{
+		{"top", "integer", "only the N most expensive systems"},
+	}}, func(r *http.Request) (tickable.TickProfile, error) {
+		top, _ := strconv.Atoi(r.URL.Query().Get("top"))
+		return handleGetTickProfile(getProvider(r), top), nil
+	})
+
+	post(rt, "/api/admin/tick-profile", doc{Tag: "admin", Summary: "Configure the tick profiler", Admin: true},
+		func(r *http.Request, req *TickProfileRequest) (tickable.TickProfile, error) {
+			if req.BudgetMs != nil && *req.BudgetMs < 0 {
+				return tickable.TickProfile{}, errors.New("budget_ms must be >= 0")
+			}
+			handleSetTickProfile(getProvider(r), *req)
+			return handleGetTickProfile(getProvider(r), 0), nil
+		})
+
+	get(rt, "/api/admin/schedule", doc{Tag: "admin", Summary: "Tickable system cadences", Admin: true},
+		func(r *http.Request) ([]tickable.ScheduleInfo, error) {
+			return handleGetSchedules(getProvider(r)), nil
+		})
+
+	post(rt, "/api/admin/schedule", doc{Tag: "admin", Summary: "Change a tickable system's cadence", Admin: true},
+		func(r *http.Request, req *ScheduleRequest) ([]tickable.ScheduleInfo, error) {
+			// Runs on the simulation goroutine, and is journaled like any command
+			if _, err := dispatch(r, req); err != nil {
+				return nil, err
+			}
+			return handleGetSchedules(getProvider(r)), nil
+		})
+
+	// Player registration without Discord OAuth
+	post(rt, "/api/admin/register", doc{Tag: "admin", Summary: "Register a player and return their login key", Admin: true},
+		func(r *http.Request, req *RegisterRequest) (RegisterResult, error) {
+			if req.Name == "" {
+				return RegisterResult{}, errors.New("name required")
+			}
+			registry := getProvider(r).GetRegistry()
+			if registry == nil {
+				return RegisterResult{}, errUnavailable("registry")
+			}
+			account, isNew, err := registry.FindOrCreateByName(req.Name)
+			if err != nil {
+				return RegisterResult{}, err
+			}
+			loginKey := registry.LoginKey(account)
+			if isNew {
+				createFaction(r, registry, account, loginKey)
+			}
+			return RegisterResult{Name: account.Name, APIKey: loginKey, PlayerID: account.PlayerID, New: isNew}, nil
+		})
+
+	// Mine-to-resource attachment details
+	get(rt, "/api/admin/diagnose", doc{Tag: "admin", Summary: "A player's deposits and mine attachments", Admin: true, Query: []param{
+		{"player", "string", ""},
+	}}, func(r *http.Request) ([]map[string]interface{}, error) {
+		player := findPlayer(getProvider(r), r.URL.Query().Get("player"))
+		if player == nil {
+			return nil, errNotFound("player not found")
+		}
+		return handleDiagnosePlayer(player), nil
+	})
+
+	post(rt, "/api/admin/remove-player", doc{Tag: "admin", Summary: "Remove a player and their account", Admin: true},
+		func(r *http.Request, req *RemovePlayerRequest) (map[string]string, error) {
+			if req.Name == "" {
+				return nil, errors.New("name required")
+			}
+			p := getProvider(r)
+			result, err := handleRemovePlayer(p, req.Name)
+			if err != nil {
+				return nil, errNotFound("%s", err)
+			}
+			// Also remove from auth registry
+			if registry := p.GetRegistry(); registry != nil {
+				registry.RemoveAccount(req.Name)
+			}
+			return result, nil
+		})
+
+	// Rate limits: view usage and throttle (or free up) an account live
+	get(rt, "/api/admin/rate-limits", doc{Tag: "admin", Summary: "Default rate limits and per-account overrides and usage", Admin: true, Query: []param{
+		{"player", "string", "just this account"},
+	}}, func(r *http.Request) (RateLimitReport, error) {
+		if name := r.URL.Query().Get("player"); name != "" {
+			player, err := rateLimitAccount(r, name)
+			if err != nil {
+				return RateLimitReport{}, err
+			}
+			return RateLimitReport{Defaults: rt.limiter.Report().Defaults, Accounts: []AccountRateLimit{rt.limiter.Account(player)}}, nil
+		}
+		return rt.limiter.Report(), nil
+	})
+
+	post(rt, "/api/admin/rate-limits", doc{Tag: "admin", Summary: "Set or clear an account's rate limits", Admin: true},
+		func(r *http.Request, req *RateLimitRequest) (AccountRateLimit, error) {
+			player, err := rateLimitAccount(r, req.Player)
+			if err != nil {
+				return AccountRateLimit{}, err
+			}
+			if req.Clear {
+				rt.limiter.ClearOverride(player)
+			} else if err := rt.limiter.SetOverride(player, req.Limits); err != nil {
+				return AccountRateLimit{}, err
+			}
+			if req.ResetUsage {
+				rt.limiter.ResetUsage(player)
+			}
+			return rt.limiter.Account(player), nil
+		})
+}
+
+// rateLimitAccount resolves the account name rate limits are kept under.
+func rateLimitAccount(r *http.Request, name string) (string, error) {
+	if name == "" {
+		return "", errors.New("player required")
+	}
+	registry := getProvider(r).GetRegistry()
+	if registry == nil {
+		return "", errUnavailable("registry")
+	}
+	acc := registry.GetAccount(name)
+	if acc == nil {
+		return "", errNotFound("no account for %q", name)
+	}
+	return rateLimitPlayer(r, acc.Name), nil
+}
+
+// registerAuthRoutes registers the Discord OAuth2 login flow.
+func registerAuthRoutes(rt *router) {
+	discordSecret := os.Getenv("DISCORD_CLIENT_SECRET")
+	baseURL := os.Getenv("BASE_URL")
+	if baseURL == "" {
+		baseURL = "http://localhost:8080"
+	}
+	frontendURL := os.Getenv("FRONTEND_URL")
+	if frontendURL == "" {
+		frontendURL = "https://hunterjsb.github.io/xandaris"
+	}
+	redirectURI := baseURL + "/api/auth/discord/callback"
+
+	rt.raw(http.MethodGet, "/api/auth/discord", doc{Tag: "auth", Summary: "Redirect to Discord login", ContentType: "text/html", Query: []param{
+		{"local_callback", "string", "desktop client URL to return credentials to"},
+	}}, nil, func(w http.ResponseWriter, r *http.Request) {
+		// Support local_callback for desktop OAuth flow
+		state := r.URL.Query().Get("local_callback")
+		authURL := fmt.Sprintf(
+			"https://discord.com/api/oauth2/authorize?client_id=%s&redirect_uri=%s&response_type=code&scope=identify&state=%s",
+			discordClientID, url.QueryEscape(redirectURI), url.QueryEscape(state),
+		)
+		http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
+	})
+
+	rt.raw(http.MethodGet, "/api/auth/discord/callback", doc{Tag: "auth", Summary: "Discord OAuth callback", Hidden: true}, nil,
+		func(w http.ResponseWriter, r *http.Request) {
+			code := r.URL.Query().Get("code")
+			if code == "" {
+				writeErr(w, http.StatusBadRequest, "missing code parameter")
+				return
+			}
+
+			// Exchange code for access token
+			tokenResp, err := http.PostForm("https://discord.com/api/oauth2/token", url.Values{
+				"client_id":     {discordClientID},
+				"client_secret": {discordSecret},
+				"grant_type":    {"authorization_code"},
+				"code":          {code},
+				"redirect_uri":  {redirectURI},
+			})
+			if err != nil {
+				writeErr(w, http.StatusBadGateway, "failed to contact Discord")
+				return
+			}
+			defer tokenResp.Body.Close()
+
+			tokenBody, _ := io.ReadAll(tokenResp.Body)
+			fmt.Printf("[Auth] Discord token response (%d): %s\n", tokenResp.StatusCode, string(tokenBody))
+
+			var tokenData struct {
+				AccessToken string `json:"access_token"`
+				TokenType   string `json:"token_type"`
+				Error       string `json:"error"`
+				ErrorDesc   string `json:"error_description"`
+			}
+			if err := json.Unmarshal(tokenBody, &tokenData); err != nil || tokenData.AccessToken == "" {
+				errMsg := "failed to get Discord token"
+				if tokenData.Error != "" {
+					errMsg = tokenData.Error + ": " + tokenData.ErrorDesc
+				}
+				writeErr(w, http.StatusBadGateway, errMsg)
+				return
+			}
+
+			// Fetch Discord user info
+			userReq, _ := http.NewRequest("GET", "https://discord.com/api/users/@me", nil)
+			userReq.Header.Set("Authorization", "Bearer "+tokenData.AccessToken)
+			userResp, err := http.DefaultClient.Do(userReq)
+			if err != nil {
+				writeErr(w, http.StatusBadGateway, "failed to fetch Discord user")
+				return
+			}
+			defer userResp.Body.Close()
+
+			body, _ := io.ReadAll(userResp.Body)
+			var discordUser struct {
+				ID       string `json:"id"`
+				Username string `json:"username"`
+			}
+			if err := json.Unmarshal(body, &discordUser); err != nil || discordUser.ID == "" {
+				writeErr(w, http.StatusBadGateway, "failed to parse Discord user")
+				return
+			}
+
+			// Find or create account
+			registry := getProvider(r).GetRegistry()
+			if registry == nil {
+				writeErr(w, http.StatusInternalServerError, "auth not available")
+				return
+			}
+
+			account, isNew, err := registry.FindOrCreateByDiscord(discordUser.ID, discordUser.Username)
+			if err != nil {
+				writeErr(w, http.StatusBadRequest, err.Error())
+				return
+			}
+			loginKey := registry.LoginKey(account)
+
+			// Create in-game faction for new players
+			if isNew {
+				createFaction(r, registry, account, loginKey)
+			}
+
+			// Redirect with credentials
+			params := url.Values{
+				"key":       {loginKey},
+				"name":      {account.Name},
+				"player_id": {strconv.Itoa(account.PlayerID)},
+				"new":       {strconv.FormatBool(isNew)},
+			}
+
+			// Desktop OAuth: redirect to local callback with query params
+			localCallback := r.URL.Query().Get("state")
+			fmt.Printf("[Auth] Callback state=%q, account=%s, isNew=%v\n", localCallback, account.Name, isNew)
+			if localCallback != "" {
+				redirectTo := localCallback + "?" + params.Encode()
+				fmt.Printf("[Auth] Redirecting to local callback: %s\n", redirectTo)
+				http.Redirect(w, r, redirectTo, http.StatusTemporaryRedirect)
+			} else {
+				// Web OAuth: redirect to frontend with fragment (never sent to server)
+				http.Redirect(w, r, frontendURL+"/#"+params.Encode(), http.StatusTemporaryRedirect)
+			}
+		})
+}
+
+// createFaction creates the in-game faction for a new account and records
+// its player ID. If the simulation doesn't respond in time the account
+// still exists and gets its faction on a later login.
+func createFaction(r *http.Request, registry *game.PlayerRegistry, account *game.PlayerAccount, loginKey string) {
+	cmd := newCommand(r, game.CmdRegisterPlayer, game.RegisterPlayerCommandData{Name: account.Name, AccountKey: loginKey})
+	cmd.PlayerName = account.Name
+	result, err := awaitCommand(r, cmd)
+	if err != nil {
+		return
+	}
+	if pid, ok := result.(int); ok {
+		account.PlayerID = pid
+		registry.Save() // persist player ID
+	}
+}
+
+func handleDiagnosePlayer(player *entities.Player) []map[string]interface{} {
+	var result []map[string]interface{}
+	for _, planet := range player.OwnedPlanets {
+		if planet == nil {
+			continue
+		}
+		pInfo := map[string]interface{}{
+			"planet_id":   planet.GetID(),
+			"planet_name": planet.Name,
+			"owner":       planet.Owner,
+			"resources":   []map[string]interface{}{},
+			"mines":       []map[string]interface{}{},
+		}
+		for _, re := range planet.Resources {
+			if res, ok := re.(*entities.Resource); ok {
+				pInfo["resources"] = append(pInfo["resources"].([]map[string]interface{}), map[string]interface{}{
+					"id": res.GetID(), "type": res.ResourceType, "owner": res.Owner,
+					"abundance": res.Abundance, "rate": res.ExtractionRate,
+				})
+			}
+		}
+		for i, be := range planet.Buildings {
+			if b, ok := be.(*entities.Building); ok && b.BuildingType == "Mine" {
+				pInfo["mines"] = append(pInfo["mines"].([]map[string]interface{}), map[string]interface{}{
+					"index": i, "level": b.Level, "attached_to": b.AttachedTo,
+					"attachment_type": b.AttachmentType, "staffing": b.GetStaffingRatio(),
+					"operational": b.IsOperational, "owner": b.Owner,
+				})
+			}
+		}
+		result = append(result, pInfo)
+	}
+	return result
+}
@@ -0,0 +1,932 @@
+//go:build !js
+
+package api
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// registerMarketRoutes registers the galactic market, order books,
+// contracts, auctions and economy reports.
+func registerMarketRoutes(rt *router) {
+	get(rt, "/api/market", doc{Tag: "market", Summary: "Galactic market prices, supply and demand"},
+		func(r *http.Request) ([]MarketCommodity, error) {
+			return handleGetMarket(getProvider(r)), nil
+		})
+
+	post(rt, "/api/market/trade", doc{Tag: "market", Summary: "Buy or sell on the galactic market"},
+		func(r *http.Request, req *TradeRequest) (TradeResult, error) {
+			return dispatchAs[TradeResult](r, req)
+		})
+
+	get(rt, "/api/market/history", doc{Tag: "market", Summary: "OHLCV candles of a resource's mid price, galaxy-wide or in one system, oldest first", Query: []param{
+		{"resource", "string", ""},
+		{"system_id", "integer", "local prices in this system (default galaxy-wide)"},
+		{"resolution", "integer", "ticks per candle: 100 (default), 1000 or 10000"},
+		{"limit", "integer", "most recent candles (default all retained)"},
+	}}, func(r *http.Request) (PriceCandles, error) {
+		candles := getProvider(r).GetCandleHistory()
+		if candles == nil {
+			return PriceCandles{}, errUnavailable("price history")
+		}
+		q := r.URL.Query()
+		resource := q.Get("resource")
+		if resource == "" {
+			return PriceCandles{}, errors.New("resource required")
+		}
+		sysID := economy.GlobalCandles
+		if s := q.Get("system_id"); s != "" {
+			var err error
+			if sysID, err = strconv.Atoi(s); err != nil {
+				return PriceCandles{}, fmt.Errorf("invalid system_id %q", s)
+			}
+		}
+		resolution := economy.CandleResolutions[0]
+		if s := q.Get("resolution"); s != "" {
+			n, _ := strconv.ParseInt(s, 10, 64)
+			if !validCandleResolution(n) {
+				return PriceCandles{}, fmt.Errorf("resolution must be one of %v", economy.CandleResolutions)
+			}
+			resolution = n
+		}
+		return priceCandles(candles, sysID, resource, resolution, queryInt(r, "limit", 0)), nil
+	})
+
+	get(rt, "/api/market/trades", doc{Tag: "market", Summary: "Recent trades, newest first", Query: []param{
+		{"limit", "integer", "max entries (default 50)"},
+		{"resource", "string", "only this resource"},
+		{"player", "string", "only this player's trades"},
+	}}, func(r *http.Request) ([]TradeHistoryEntry, error) {
+		filterResource := r.URL.Query().Get("resource")
+		filterPlayer := r.URL.Query().Get("player")
+		entries := handleGetTradeHistory(getProvider(r), visibilityFor(r), queryInt(r, "limit", 50))
+		if filterResource == "" && filterPlayer == "" {
+			return entries, nil
+		}
+		filtered := make([]TradeHistoryEntry, 0)
+		for _, e := range entries {
+			if filterResource != "" && !strings.EqualFold(e.Resource, filterResource) {
+				continue
+			}
+			if filterPlayer != "" && !strings.EqualFold(e.Player, filterPlayer) {
+				continue
+			}
+			filtered = append(filtered, e)
+		}
+		return filtered, nil
+	})
+
+	get(rt, "/api/market/prices", doc{Tag: "market", Summary: "Price history per resource"},
+		func(r *http.Request) (map[string][]float64, error) {
+			result := make(map[string][]float64)
+			market := getProvider(r).GetMarket()
+			if market == nil {
+				return result, nil
+			}
+			for name, rm := range market.GetSnapshot().Resources {
+				result[name] = rm.PriceHistory
+			}
+			return result, nil
+		})
+
+	get(rt, "/api/prices", doc{Tag: "market", Summary: "Local buy prices per system"},
+		func(r *http.Request) ([]SystemPrices, error) {
+			return handleGetSystemPrices(getProvider(r)), nil
+		})
+
+	get(rt, "/api/local-market/{id}", doc{Tag: "market", Summary: "What's available to trade in a system"},
+		func(r *http.Request) (LocalMarket, error) {
+			sysID, err := pathID(r, "id", "system_id")
+			if err != nil {
+				return LocalMarket{}, err
+			}
+			return handleGetLocalMarket(getProvider(r), sysID, getAuthPlayer(r)), nil
+		})
+
+	get(rt, "/api/trade-opportunities", doc{Tag: "market", Summary: "Best cross-system arbitrage, top 20 by margin", Cost: 10},
+		func(r *http.Request) ([]TradeOpportunity, error) {
+			return handleGetTradeOpportunities(getProvider(r)), nil
+		})
+
+	// Standing orders: automatic buys/sells when stock crosses a threshold
+	get(rt, "/api/orders", doc{Tag: "orders", Summary: "Your standing orders"},
+		func(r *http.Request) ([]*game.StandingOrder, error) {
+			orders := getProvider(r).GetStandingOrders(getAuthPlayer(r))
+			if orders == nil {
+				orders = []*game.StandingOrder{}
+			}
+			return orders, nil
+		})
+
+	post(rt, "/api/orders", doc{Tag: "orders", Summary: "Create a standing order"},
+		func(r *http.Request, req *StandingOrderRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	del(rt, "/api/orders", doc{Tag: "orders", Summary: "Cancel a standing order"},
+		func(r *http.Request, req *CancelOrderRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	// Order book: limit buy/sell orders per system
+	get(rt, "/api/orders/limit", doc{Tag: "orders", Summary: "Limit orders in a system, or your own when system_id is omitted", Query: []param{
+		{"system_id", "integer", ""},
+		{"resource", "string", ""},
+	}}, func(r *http.Request) ([]*economy.MarketOrder, error) {
+		ob := getProvider(r).GetOrderBook()
+		if ob == nil {
+			return nil, errUnavailable("order book")
+		}
+		sysStr := r.URL.Query().Get("system_id")
+		playerName := getAuthPlayer(r)
+		if playerName != "" && sysStr == "" {
+			return ob.GetPlayerOrders(playerName), nil
+		}
+		sysID, _ := strconv.Atoi(sysStr)
+		return ob.GetOrders(sysID, r.URL.Query().Get("resource")), nil
+	})
+
+	post(rt, "/api/orders/limit", doc{Tag: "orders", Summary: "Place a limit, stop, stop-limit, take-profit, IOC/FOK, good-till-tick or iceberg order, escrowing its credits or goods", Auth: true},
+		func(r *http.Request, req *LimitOrderRequest) (*economy.MarketOrder, error) {
+			return dispatchAs[*economy.MarketOrder](r, req)
+		})
+
+	del(rt, "/api/orders/limit", doc{Tag: "orders", Summary: "Cancel a limit order and refund its escrow", Auth: true, Query: []param{
+		{"order_id", "integer", ""},
+	}}, func(r *http.Request, _ *noBody) (interface{}, error) {
+		orderID, _ := strconv.Atoi(r.URL.Query().Get("order_id"))
+		return dispatch(r, &CancelLimitOrderRequest{OrderID: orderID})
+	})
+
+	get(rt, "/api/orders/book", doc{Tag: "orders", Summary: "Depth of a resource's order book in a system", Query: []param{
+		{"system_id", "integer", ""},
+		{"resource", "string", ""},
+		{"levels", "integer", "price levels per side (default 10, 0 = all)"},
+	}}, func(r *http.Request) (OrderBookDepth, error) {
+		ob := getProvider(r).GetOrderBook()
+		if ob == nil {
+			return OrderBookDepth{}, errUnavailable("order book")
+		}
+		q := r.URL.Query()
+		resource := q.Get("resource")
+		if resource == "" {
+			return OrderBookDepth{}, errors.New("resource required")
+		}
+		sysID, _ := strconv.Atoi(q.Get("system_id"))
+		levels := 10
+		if s := q.Get("levels"); s != "" {
+			levels, _ = strconv.Atoi(s)
+		}
+		return bookDepth(ob.Depth(sysID, resource, levels)), nil
+	})
+
+	// Futures exchange: margined futures and European options
+	get(rt, "/api/futures", doc{Tag: "orders", Summary: "Your futures margin account and positions", Auth: true, Query: []param{
+		{"all", "boolean", "include closed positions"},
+	}}, func(r *http.Request) (FuturesAccount, error) {
+		fx := getProvider(r).GetFuturesExchange()
+		if fx == nil {
+			return FuturesAccount{}, errUnavailable("futures exchange")
+		}
+		player := getAuthPlayer(r)
+		acct := FuturesAccount{Player: player, Positions: make([]FuturesPosition, 0)}
+		acct.Balance, acct.InitialMargin, acct.MaintenanceMargin = fx.GetAccount(player)
+		for _, pos := range fx.GetPositions(player, r.URL.Query().Get("all") != "true") {
+			acct.Positions = append(acct.Positions, futuresPosition(pos))
+		}
+		return acct, nil
+	})
+
+	get(rt, "/api/futures/quote", doc{Tag: "orders", Summary: "Futures prices and margin for a resource, and an option's premium when kind and strike are given", Query: []param{
+		{"resource", "string", ""},
+		{"kind", "string", "call or put to price an option"},
+		{"strike", "number", ""},
+		{"term", "integer", "ticks until expiry"},
+	}}, func(r *http.Request) (FuturesQuote, error) {
+		market := getProvider(r).GetMarket()
+		if market == nil {
+			return FuturesQuote{}, errUnavailable("market")
+		}
+		q := r.URL.Query()
+		resource := q.Get("resource")
+		if _, ok := economy.BasePrices[resource]; !ok {
+			return FuturesQuote{}, errors.New("unknown resource")
+		}
+		mid := market.GetCurrentPrice(resource)
+		quote := FuturesQuote{
+			Resource:      resource,
+			Bid:           market.GetSellPrice(resource),
+			Ask:           market.GetBuyPrice(resource),
+			Mid:           mid,
+			Volatility:    market.Volatility(resource),
+			InitialMargin: mid * economy.FuturesInitialMargin,
+		}
+		kind := strings.ToLower(q.Get("kind"))
+		if kind == "" {
+			return quote, nil
+		}
+		if kind != economy.KindCall && kind != economy.KindPut {
+			return FuturesQuote{}, errors.New("kind must be call or put")
+		}
+		strike, _ := strconv.ParseFloat(q.Get("strike"), 64)
+		term, _ := strconv.ParseInt(q.Get("term"), 10, 64)
+		if strike <= 0 {
+			return FuturesQuote{}, economy.ErrInvalidStrike
+		}
+		if term < economy.FuturesMinTerm || term > economy.FuturesMaxTerm {
+			return FuturesQuote{}, economy.ErrInvalidTerm
+		}
+		quote.Kind, quote.Strike, quote.Term = kind, strike, term
+		quote.Premium = economy.OptionQuote(market, kind, resource, strike, term)
+		return quote, nil
+	})
+
+	post(rt, "/api/futures", doc{Tag: "orders", Summary: "Open a futures position on margin, or buy a call or put option", Auth: true},
+		func(r *http.Request, req *FuturesOpenRequest) (FuturesPosition, error) {
+			pos, err := dispatchAs[*economy.FuturesPosition](r, req)
+			if err != nil {
+				return FuturesPosition{}, err
+			}
+			return futuresPosition(pos), nil
+		})
+
+	del(rt, "/api/futures", doc{Tag: "orders", Summary: "Close a position early at the market", Auth: true, Query: []param{
+		{"position_id", "integer", ""},
+	}}, func(r *http.Request, _ *noBody) (FuturesPosition, error) {
+		positionID, _ := strconv.Atoi(r.URL.Query().Get("position_id"))
+		pos, err := dispatchAs[*economy.FuturesPosition](r, &FuturesCloseRequest{PositionID: positionID})
+		if err != nil {
+			return FuturesPosition{}, err
+		}
+		return futuresPosition(pos), nil
+	})
+
+	// Trade contracts: binding supply agreements
+	get(rt, "/api/contracts", doc{Tag: "orders", Summary: "Your active supply contracts"},
+		func(r *http.Request) ([]*economy.TradeContract, error) {
+			cm := getProvider(r).GetContractManager()
+			if cm == nil {
+				return nil, errUnavailable("contracts")
+			}
+			return cm.GetActiveContracts(getAuthPlayer(r)), nil
+		})
+
+	post(rt, "/api/contracts", doc{Tag: "orders", Summary: "Offer a supply contract", Auth: true},
+		func(r *http.Request, req *ContractRequest) (*economy.TradeContract, error) {
+			cm := getProvider(r).GetContractManager()
+			if cm == nil {
+				return nil, errUnavailable("contracts")
+			}
+			if req.Quantity <= 0 || req.PricePerUnit <= 0 || req.Interval <= 0 {
+				return nil, errors.New("quantity, price, and interval must be positive")
+			}
+			return cm.CreateContract(getAuthPlayer(r), req.Buyer, req.Resource, req.Quantity, req.PricePerUnit, req.Interval, req.SystemID, req.PlanetID), nil
+		})
+
+	del(rt, "/api/contracts", doc{Tag: "orders", Summary: "Cancel a contract", Query: []param{
+		{"contract_id", "integer", ""},
+	}}, func(r *http.Request, _ *noBody) (string, error) {
+		cm := getProvider(r).GetContractManager()
+		if cm == nil {
+			return "", errUnavailable("contracts")
+		}
+		contractID, _ := strconv.Atoi(r.URL.Query().Get("contract_id"))
+		if !cm.CancelContract(contractID, getAuthPlayer(r)) {
+			return "", errNotFound("contract not found or not yours")
+		}
+		return "cancelled", nil
+	})
+
+	// Black market: high prices, risk of seizure, no location restriction
+	post(rt, "/api/black-market", doc{Tag: "market", Summary: "Trade at 3x (sell, may be seized) or 1.5x (buy) market price", Auth: true},
+		func(r *http.Request, req *BlackMarketRequest) (BlackMarketResult, error) {
+			return dispatchAs[BlackMarketResult](r, req)
+		})
+
+	// Auctions: bid on rare items
+	get(rt, "/api/auctions", doc{Tag: "market", Summary: "Open auctions"},
+		func(r *http.Request) ([]*economy.Auction, error) {
+			ah := getProvider(r).GetAuctionHouse()
+			if ah == nil {
+				return nil, errUnavailable("auctions")
+			}
+			return ah.GetActiveAuctions(), nil
+		})
+
+	post(rt, "/api/auctions", doc{Tag: "market", Summary: "Bid on an auction", Auth: true},
+		func(r *http.Request, req *BidRequest) (BidResult, error) {
+			ah := getProvider(r).GetAuctionHouse()
+			if ah == nil {
+				return BidResult{}, errUnavailable("auctions")
+			}
+			playerName := getAuthPlayer(r)
+			if !ah.PlaceBid(req.AuctionID, playerName, req.Bid) {
+				return BidResult{}, errors.New("bid rejected (too low, auction ended, or you're the seller)")
+			}
+			return BidResult{AuctionID: req.AuctionID, Bid: req.Bid, Bidder: playerName}, nil
+		})
+
+	get(rt, "/api/economy", doc{Tag: "economy", Summary: "Galaxy-wide economic overview"},
+		func(r *http.Request) (EconomyOverview, error) {
+			return handleGetEconomy(getProvider(r)), nil
+		})
+
+	// Player economic summary — income, expenses, net flow
+	get(rt, "/api/economy/summary", doc{Tag: "economy", Summary: "Your income, expenses and net flow per tick"},
+		func(r *http.Request) (EconomySummary, error) {
+			player := findPlayer(getProvider(r), getAuthPlayer(r))
+			if player == nil {
+				return EconomySummary{}, errNotFound("player not found")
+			}
+			return handleGetEconomySummary(player), nil
+		})
+
+	rt.raw(http.MethodGet, "/api/ledger", doc{Tag: "economy", Summary: "Every movement of your credits, with its reason", Query: []param{
+		{"from", "integer", "first tick (default 0)"},
+		{"to", "integer", "last tick (default now)"},
+		{"player", "string", "faction to audit (admin only; default all)"},
+		{"format", "string", "json (default) or csv"},
+	}}, []LedgerEntry{}, func(w http.ResponseWriter, r *http.Request) {
+		p := getProvider(r)
+		ledger := p.GetLedger()
+		if ledger == nil {
+			writeError(w, errUnavailable("ledger"))
+			return
+		}
+		player, err := ledgerPlayer(r)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		from := int64(queryInt(r, "from", 0))
+		to := int64(queryInt(r, "to", 0))
+		entries := ledger.Entries(player, from, to)
+
+		switch r.URL.Query().Get("format") {
+		case "", "json":
+			writeJSON(w, APIResponse{OK: true, Data: ledgerEntries(entries)})
+		case "csv":
+			w.Header().Set("Content-Type", "text/csv")
+			w.Header().Set("Content-Disposition", `attachment; filename="ledger.csv"`)
+			writeLedgerCSV(w, entries)
+		default:
+			writeError(w, errors.New("format must be json or csv"))
+		}
+	})
+
+	get(rt, "/api/ledger/pnl", doc{Tag: "economy", Summary: "Realized and unrealized trading P&L on FIFO cost basis", Query: []param{
+		{"player", "string", "faction to audit (admin only; default all)"},
+	}}, func(r *http.Request) ([]FactionPnL, error) {
+		p := getProvider(r)
+		ledger := p.GetLedger()
+		if ledger == nil {
+			return nil, errUnavailable("ledger")
+		}
+		player, err := ledgerPlayer(r)
+		if err != nil {
+			return nil, err
+		}
+		mark := func(resource string) float64 { return 0 }
+		if market := p.GetMarket(); market != nil {
+			mark = market.GetCurrentPrice
+		}
+		ob := p.GetOrderBook()
+		result := make([]FactionPnL, 0)
+		for _, pl := range p.GetPlayers() {
+			if pl == nil || (player != "" && pl.Name != player) {
+				continue
+			}
+			var escrowed map[string]int
+			if ob != nil {
+				escrowed = ob.EscrowedGoods(pl.Name)
+			}
+			result = append(result, factionPnL(ledger.PnL(pl, escrowed, mark)))
+		}
+		return result, nil
+	})
+
+	// Economy report: narrative summary of the galactic economy
+	get(rt, "/api/economy/report", doc{Tag: "economy", Summary: "Scarcity, order book depth and faction sizes", Cost: 10},
+		func(r *http.Request) (EconomyReport, error) {
+			return handleGetEconomyReport(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/flows", doc{Tag: "economy", Summary: "Galaxy-wide production and consumption rates", Cost: 5},
+		func(r *http.Request) (GalaxyFlows, error) {
+			return handleGetGalaxyFlows(getProvider(r)), nil
+		})
+}
+
+// bookDepth converts the order book's depth to its API shape.
+func bookDepth(d economy.BookDepth) OrderBookDepth {
+	out := OrderBookDepth{
+		SystemID: d.SystemID,
+		Resource: d.Resource,
+		Bids:     make([]DepthLevel, 0, len(d.Bids)),
+		Asks:     make([]DepthLevel, 0, len(d.Asks)),
+	}
+	for _, l := range d.Bids {
+		out.Bids = append(out.Bids, DepthLevel{Price: l.Price, Quantity: l.Quantity, Orders: l.Orders})
+	}
+	for _, l := range d.Asks {
+		out.Asks = append(out.Asks, DepthLevel{Price: l.Price, Quantity: l.Quantity, Orders: l.Orders})
+	}
+	if len(out.Bids) > 0 && len(out.Asks) > 0 {
+		out.Spread = out.Asks[0].Price - out.Bids[0].Price
+	}
+	return out
+}
+
+func handleGetLocalMarket(p GameStateProvider, sysID int, playerName string) LocalMarket {
+	result := LocalMarket{SystemID: sysID}
+	buyable := make(map[string]int) // total stock on OTHER players' planets
+
+	for _, sys := range p.GetSystems() {
+		if sys.ID != sysID {
+			continue
+		}
+		for _, e := range sys.Entities {
+			planet, ok := e.(*entities.Planet)
+			if !ok || planet.Owner == "" {
+				continue
+			}
+
+			stock := make(map[string]int)
+			for resType, s := range planet.StoredResources {
+				if s != nil && s.Amount > 0 {
+					stock[resType] = s.Amount
+				}
+			}
+
+			hasTP := false
+			for _, be := range planet.Buildings {
+				if b, ok := be.(*entities.Building); ok && b.BuildingType == entities.BuildingTradingPost && b.IsOperational {
+					hasTP = true
+					break
+				}
+			}
+
+			result.Planets = append(result.Planets, LocalPlanetStock{
+				PlanetID: planet.GetID(), PlanetName: planet.Name,
+				Owner: planet.Owner, HasTP: hasTP, Stock: stock,
+			})
+
+			if planet.Owner == playerName {
+				result.YourPlanets = append(result.YourPlanets, planet.GetID())
+			} else {
+				// Stock on other players' planets = what you can buy
+				for resType, amt := range stock {
+					buyable[resType] += amt
+				}
+			}
+		}
+		break
+	}
+
+	// Add market prices for context
+	if market := p.GetMarket(); market != nil {
+		for res, amt := range buyable {
+			result.BuyableStock = append(result.BuyableStock, LocalPricedStock{
+				Resource:  res,
+				Available: amt,
+				BuyPrice:  market.GetBuyPrice(res) * economy.LocalPriceMultiplier(amt, 0),
+				SellPrice: market.GetSellPrice(res) * economy.LocalSellPriceMultiplier(amt),
+			})
+		}
+	}
+	return result
+}
+
+func handleGetEconomySummary(player *entities.Player) EconomySummary {
+	// Calculate income sources
+	laborIncome := 0
+	domesticIncome := 0
+	tpIncome := 0
+	totalUpkeep := 0
+	popAdmin := 0
+	totalPop := int64(0)
+
+	for _, planet := range player.OwnedPlanets {
+		if planet == nil {
+			continue
+		}
+		pop := planet.Population
+		totalPop += pop
+
+		// Labor (matches credit_production.go)
+		labor := int(float64(pop/50) * planet.ProductivityBonus)
+		if labor < 10 && pop > 0 {
+			labor = 10
+		}
+		laborIncome += labor
+
+		// Domestic (rough estimate)
+		domestic := int(float64(pop) / 1000 * 8) // simplified
+		domesticIncome += domestic
+
+		// TP revenue
+		for _, be := range planet.Buildings {
+			if b, ok := be.(*entities.Building); ok {
+				if b.BuildingType == entities.BuildingTradingPost && b.IsOperational {
+					tpIncome += 2 * b.Level
+				}
+				// Upkeep
+				upkeep := map[string]int{
+					"Fusion Reactor": 2, "Refinery": 3, "Factory": 5, "Shipyard": 6,
+				}
+				if cost, ok := upkeep[b.BuildingType]; ok && b.IsOperational {
+					totalUpkeep += cost + (b.Level - 1)
+				}
+			}
+		}
+
+		popAdmin += int(pop / 1000)
+	}
+
+	// Resource diversity per planet
+	var diversity []PlanetDiversity
+	allResources := []string{"Water", "Iron", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
+	for _, planet := range player.OwnedPlanets {
+		if planet == nil {
+			continue
+		}
+		stocked := 0
+		var missing []string
+		for _, res := range allResources {
+			if planet.GetStoredAmount(res) > 0 {
+				stocked++
+			} else {
+				missing = append(missing, res)
+			}
+		}
+		mult := 1.0
+		if stocked >= 7 {
+			mult = 3.0
+		} else if stocked >= 5 {
+			mult = 2.0
+		} else if stocked >= 3 {
+			mult = 1.5
+		}
+		diversity = append(diversity, PlanetDiversity{
+			PlanetID: planet.GetID(), Name: planet.Name,
+			TypesStocked: stocked, Multiplier: mult, Missing: missing,
+		})
+	}
+
+	totalIncome := laborIncome + domesticIncome + tpIncome
+	totalExpenses := totalUpkeep + popAdmin
+	netFlow := totalIncome - totalExpenses
+
+	return EconomySummary{
+		Player:     player.Name,
+		Credits:    player.Credits,
+		Population: totalPop,
+		Planets:    len(player.OwnedPlanets),
+		IncomePerTick: map[string]int{
+			"labor":    laborIncome,
+			"domestic": domesticIncome,
+			"trading":  tpIncome,
+			"total":    totalIncome,
+		},
+		ExpensesPerTick: map[string]int{
+			"building_upkeep": totalUpkeep,
+			"administration":  popAdmin,
+			"total":           totalExpenses,
+		},
+		NetFlowPerTick: netFlow,
+		Profitable:     netFlow > 0,
+		Diversity:      diversity,
+	}
+}
+
+// handleGetEconomyReport summarises the galaxy economy. Faction figures are
+// only given for the factions vis knows.
+func handleGetEconomyReport(p GameStateProvider, vis *game.Visibility) EconomyReport {
+	market := p.GetMarket()
+	players := p.GetPlayers()
+	systems := p.GetSystems()
+	ob := p.GetOrderBook()
+
+	// GDP
+	totalCredits := 0
+	for _, pl := range players {
+		if pl != nil {
+			totalCredits += pl.Credits
+		}
+	}
+
+	// Resource scarcity analysis
+	resources := []string{"Water", "Iron", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
+	var resInfo []ResourceReport
+	for _, res := range resources {
+		supply := 0
+		for _, sys := range systems {
+			for _, e := range sys.Entities {
+				if planet, ok := e.(*entities.Planet); ok && planet.Owner != "" {
+					supply += planet.GetStoredAmount(res)
+				}
+			}
+		}
+
+		priceRatio := 0.0
+		if market != nil {
+			base := economy.GetBasePrice(res)
+			if base > 0 {
+				priceRatio = market.GetBuyPrice(res) / base
+			}
+		}
+
+		scarcity := "Abundant"
+		if priceRatio > 3.0 {
+			scarcity = "Critical"
+		} else if priceRatio > 1.5 {
+			scarcity = "Scarce"
+		} else if priceRatio > 0.8 {
+			scarcity = "Normal"
+		} else {
+			scarcity = "Oversupplied"
+		}
+
+		buyDemand := 0
+		sellSupply := 0
+		if ob != nil {
+			for _, sys := range systems {
+				for _, o := range ob.GetOrders(sys.ID, res) {
+					if o.Action == "buy" {
+						buyDemand += o.Quantity
+					} else {
+						sellSupply += o.Quantity
+					}
+				}
+			}
+		}
+
+		resInfo = append(resInfo, ResourceReport{
+			Resource: res, TotalSupply: supply, PriceRatio: priceRatio,
+			Scarcity: scarcity, BuyDemand: buyDemand, SellSupply: sellSupply,
+		})
+	}
+
+	// Top traders
+	var factions []FactionSummary
+	for _, pl := range players {
+		if pl == nil {
+			continue
+		}
+		ships := 0
+		for _, s := range pl.OwnedShips {
+			if s != nil {
+				ships++
+			}
+		}
+		if !vis.Knows(pl.Name) {
+			factions = append(factions, FactionSummary{Name: pl.Name, Obscured: true})
+			continue
+		}
+		factions = append(factions, FactionSummary{
+			Name: pl.Name, Credits: pl.Credits,
+			Planets: len(pl.OwnedPlanets), Ships: ships,
+		})
+	}
+
+	// Shipping activity
+	activeRoutes := 0
+	totalTrips := 0
+	if sm := p.GetShippingManager(); sm != nil {
+		for _, r := range sm.GetRoutes("") {
+			if r.Active {
+				activeRoutes++
+			}
+			totalTrips += r.TripsComplete
+		}
+	}
+
+	return EconomyReport{
+		GDP:          totalCredits,
+		Resources:    resInfo,
+		Factions:     factions,
+		ActiveRoutes: activeRoutes,
+		TotalTrips:   totalTrips,
+	}
+}
+
+func handleGetTradeOpportunities(p GameStateProvider) []TradeOpportunity {
+	market := p.GetMarket()
+	if market == nil {
+		return []TradeOpportunity{}
+	}
+
+	// Build per-system stock map
+	type systemStock struct {
+		Stock  map[string]int
+		Owners []string
+	}
+	systemStocks := make(map[int]*systemStock)
+	for _, sys := range p.GetSystems() {
+		ss := &systemStock{Stock: make(map[string]int)}
+		for _, e := range sys.Entities {
+			planet, ok := e.(*entities.Planet)
+			if !ok || planet.Owner == "" {
+				continue
+			}
+			found := false
+			for _, o := range ss.Owners {
+				if o == planet.Owner {
+					found = true
+					break
+				}
+			}
+			if !found {
+				ss.Owners = append(ss.Owners, planet.Owner)
+			}
+			for resType, s := range planet.StoredResources {
+				if s != nil && s.Amount > 0 {
+					ss.Stock[resType] += s.Amount
+				}
+			}
+		}
+		if len(ss.Owners) > 0 {
+			systemStocks[sys.ID] = ss
+		}
+	}
+
+	// Find arbitrage: resource cheap in system A, expensive in system B
+	var opps []TradeOpportunity
+	resources := []string{"Iron", "Water", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
+
+	for _, res := range resources {
+		basePrice := market.GetBuyPrice(res)
+
+		for fromID, fromSS := range systemStocks {
+			fromStock := fromSS.Stock[res]
+			if fromStock < 50 {
+				continue // not enough to trade
+			}
+			fromBuyMult := economy.LocalPriceMultiplier(fromStock, 0)
+
+			for toID, toSS := range systemStocks {
+				if toID == fromID {
+					continue
+				}
+				toStock := toSS.Stock[res]
+				toSellMult := economy.LocalSellPriceMultiplier(toStock)
+
+				buyAt := basePrice * fromBuyMult
+				sellAt := market.GetSellPrice(res) * toSellMult
+				margin := sellAt - buyAt
+
+				if margin > 10 {
+					cargoCapacity := 500
+					profitPerTrip := int(margin * float64(cargoCapacity))
+					// Fuel cost: ~25 fuel per jump, fuel worth ~200cr each, round trip = 2 jumps
+					fuelCost := 25 * 2 * 200 // 10,000cr round trip fuel cost estimate
+					netProfit := profitPerTrip - fuelCost
+
+					opps = append(opps, TradeOpportunity{
+						Resource:         res,
+						FromSystem:       fromID,
+						ToSystem:         toID,
+						BuyPrice:         buyAt,
+						SellPrice:        sellAt,
+						Margin:           margin,
+						ProfitPerTrip:    profitPerTrip,
+						FuelCostPerTrip:  fuelCost,
+						NetProfitPerTrip: netProfit,
+						Available:        fromStock,
+						Demand:           1000 - toStock,
+					})
+				}
+			}
+		}
+	}
+
+	// Sort by margin descending
+	for i := 0; i < len(opps); i++ {
+		for j := i + 1; j < len(opps); j++ {
+			if opps[j].Margin > opps[i].Margin {
+				opps[i], opps[j] = opps[j], opps[i]
+			}
+		}
+	}
+
+	// Return top 20
+	if len(opps) > 20 {
+		opps = opps[:20]
+	}
+	return opps
+}
+
+// ledgerPlayer is whose ledger a request may read: its own, or for admins
+// the ?player= faction ("" for all of them).
+func ledgerPlayer(r *http.Request) (string, error) {
+	if isAdmin(r) {
+		return r.URL.Query().Get("player"), nil
+	}
+	player := getAuthPlayer(r)
+	if player == "" {
+		return "", errAuthRequired
+	}
+	if q := r.URL.Query().Get("player"); q != "" && !strings.EqualFold(q, player) {
+		return "", errStatus(http.StatusForbidden, "you can only read your own ledger")
+	}
+	return player, nil
+}
+
+func futuresPosition(p *economy.FuturesPosition) FuturesPosition {
+	return FuturesPosition{
+		ID: p.ID, Kind: p.Kind, Side: p.Side, Resource: p.Resource, Quantity: p.Quantity,
+		Price: p.Price, Premium: p.Premium, Mark: p.Mark,
+		OpenedTick: p.OpenedTick, ExpiryTick: p.ExpiryTick,
+		Settlement: p.Settlement, SystemID: p.SystemID, PlanetID: p.PlanetID,
+		Open: p.Open, ClosedTick: p.ClosedTick, Outcome: p.Outcome, Realized: p.Realized,
+	}
+}
+
+func ledgerEntries(entries []economy.LedgerEntry) []LedgerEntry {
+	result := make([]LedgerEntry, len(entries))
+	for i, e := range entries {
+		result[i] = LedgerEntry{
+			Seq: e.Seq, Tick: e.Tick, Player: e.Player,
+			Amount: e.Amount, Balance: e.Balance,
+			Reason: e.Reason, Source: e.Source, Counterparty: e.Counterparty,
+			Resource: e.Resource, Quantity: e.Quantity, Detail: e.Detail,
+			Entries: e.Entries,
+		}
+	}
+	return result
+}
+
+// writeLedgerCSV writes entries as CSV with a header row.
+func writeLedgerCSV(w http.ResponseWriter, entries []economy.LedgerEntry) {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"seq", "tick", "player", "amount", "balance", "reason", "source", "counterparty", "resource", "quantity", "detail"})
+	for _, e := range entries {
+		cw.Write([]string{
+			strconv.FormatInt(e.Seq, 10), strconv.FormatInt(e.Tick, 10), e.Player,
+			strconv.Itoa(e.Amount), strconv.Itoa(e.Balance), e.Reason, e.Source, e.Counterparty,
+			e.Resource, strconv.Itoa(e.Quantity), e.Detail,
+		})
+	}
+	cw.Flush()
+}
+
+func factionPnL(pnl economy.FactionPnL) FactionPnL {
+	result := FactionPnL{
+		Player:     pnl.Player,
+		Realized:   pnl.Realized,
+		Unrealized: pnl.Unrealized,
+		Positions:  make([]PnLPosition, len(pnl.Positions)),
+	}
+	for i, pos := range pnl.Positions {
+		result.Positions[i] = PnLPosition{
+			Resource:   pos.Resource,
+			Quantity:   pos.Quantity,
+			CostBasis:  pos.CostBasis,
+			AvgCost:    float64(pos.CostBasis) / float64(pos.Quantity),
+			Mark:       pos.Mark,
+			Unrealized: pos.Unrealized,
+		}
+	}
+	return result
+}
+
+// validCandleResolution reports whether candles are kept at n ticks each.
+func validCandleResolution(n int64) bool {
+	for _, res := range economy.CandleResolutions {
+		if n == res {
+			return true
+		}
+	}
+	return false
+}
+
+// priceCandles converts a candle series for the API.
+func priceCandles(h *economy.CandleHistory, systemID int, resource string, resolution int64, limit int) PriceCandles {
+	series := h.Candles(systemID, resource, resolution, limit)
+	result := PriceCandles{
+		Resource:   resource,
+		SystemID:   systemID,
+		Resolution: resolution,
+		Candles:    make([]PriceCandle, len(series)),
+	}
+	for i, c := range series {
+		result.Candles[i] = PriceCandle{
+			Start:  c.Start,
+			Open:   c.Open,
+			High:   c.High,
+			Low:    c.Low,
+			Close:  c.Close,
+			Volume: c.Volume,
+		}
+	}
+	return result
+}
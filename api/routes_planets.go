@@ -0,0 +1,335 @@
+//go:build !js
+
+package api
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+// registerPlanetRoutes registers the galaxy map, planets, buildings and
+// workforce.
+func registerPlanetRoutes(rt *router) {
+	get(rt, "/api/galaxy", doc{Tag: "galaxy", Summary: "Every system with owner and links"},
+		func(r *http.Request) ([]SystemSummary, error) {
+			return handleGetGalaxy(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/systems/{id}", doc{Tag: "galaxy", Summary: "A system with full planet details"},
+		func(r *http.Request) (SystemDetail, error) {
+			id, err := pathID(r, "id", "system ID")
+			if err != nil {
+				return SystemDetail{}, err
+			}
+			data, found := handleGetSystem(getProvider(r), visibilityFor(r), id)
+			if !found {
+				return SystemDetail{}, errNotFound("system not found")
+			}
+			return data, nil
+		})
+
+	get(rt, "/api/planets/{id}", doc{Tag: "planets", Summary: "Planet details"},
+		func(r *http.Request) (PlanetDetail, error) {
+			id, err := pathID(r, "id", "planet ID")
+			if err != nil {
+				return PlanetDetail{}, err
+			}
+			data, found := handleGetPlanet(getProvider(r), visibilityFor(r), id)
+			if !found {
+				return PlanetDetail{}, errNotFound("planet not found")
+			}
+			return data, nil
+		})
+
+	get(rt, "/api/planets/rates/{id}", doc{Tag: "planets", Summary: "Planet production and consumption rates"},
+		func(r *http.Request) (PlanetRates, error) {
+			id, err := pathID(r, "id", "planet ID")
+			if err != nil {
+				return PlanetRates{}, err
+			}
+			data, found := handleGetPlanetRates(getProvider(r), visibilityFor(r), id)
+			if !found {
+				return PlanetRates{}, errNotFound("planet not found")
+			}
+			return data, nil
+		})
+
+	get(rt, "/api/planets/storage/{id}", doc{Tag: "planets", Summary: "Planet storage by resource"},
+		func(r *http.Request) ([]PlanetStorageInfo, error) {
+			id, err := pathID(r, "id", "planet ID")
+			if err != nil {
+				return nil, err
+			}
+			data, found := handleGetPlanetStorage(getProvider(r), visibilityFor(r), id)
+			if !found {
+				return nil, errNotFound("planet not found")
+			}
+			return data, nil
+		})
+
+	get(rt, "/api/planets/workforce/{id}", doc{Tag: "planets", Summary: "Planet workforce allocation"},
+		func(r *http.Request) (WorkforceInfo, error) {
+			id, err := pathID(r, "id", "planet ID")
+			if err != nil {
+				return WorkforceInfo{}, err
+			}
+			data, found := handleGetWorkforce(getProvider(r), visibilityFor(r), id)
+			if !found {
+				return WorkforceInfo{}, errNotFound("planet not found")
+			}
+			return data, nil
+		})
+
+	// Per-planet resource flows: what's being produced and consumed
+	get(rt, "/api/planet-flows/{id}", doc{Tag: "planets", Summary: "Per-resource flows and time until empty"},
+		func(r *http.Request) (PlanetFlows, error) {
+			planetID, err := pathID(r, "id", "planet_id")
+			if err != nil {
+				return PlanetFlows{}, err
+			}
+			p := getProvider(r)
+			planet := findPlanet(p, planetID)
+			if planet == nil || !visibilityFor(r).Sees(planet.Owner, planetSystems(p)[planetID]) {
+				return PlanetFlows{}, errNotFound("planet not found")
+			}
+			return handleGetPlanetFlows(planet), nil
+		})
+
+	// Planet formation data for the dashboard
+	get(rt, "/api/planets/physics", doc{Tag: "planets", Summary: "Physical properties of every formed planet"},
+		func(r *http.Request) ([]PlanetPhysics, error) {
+			return handleGetPlanetPhysics(getProvider(r)), nil
+		})
+
+	get(rt, "/api/deposits", doc{Tag: "planets", Summary: "Resource deposits", Query: []param{
+		{"resource", "string", "only this resource type"},
+		{"unmined", "boolean", "only deposits without a mine"},
+		{"owner", "string", "only this owner's planets"},
+	}}, func(r *http.Request) ([]DepositInfo, error) {
+		q := r.URL.Query()
+		return handleGetDeposits(getProvider(r), visibilityFor(r), q.Get("resource"), q.Get("unmined") == "true", q.Get("owner")), nil
+	})
+
+	get(rt, "/api/expansion", doc{Tag: "planets", Summary: "Best colonization targets for you", Cost: 5},
+		func(r *http.Request) ([]ExpansionTarget, error) {
+			return handleGetExpansionTargets(getProvider(r), getAuthPlayer(r)), nil
+		})
+
+	get(rt, "/api/power", doc{Tag: "planets", Summary: "Power generation and demand per planet"},
+		func(r *http.Request) ([]PlanetPower, error) {
+			return handleGetPowerGrid(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/defense", doc{Tag: "galaxy", Summary: "Military defense rating per system"},
+		func(r *http.Request) ([]SystemDefense, error) {
+			return handleGetDefense(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/stations", doc{Tag: "galaxy", Summary: "All stations"},
+		func(r *http.Request) ([]StationInfo, error) {
+			return handleGetStations(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/catalog", doc{Tag: "planets", Summary: "Buildings, ships and resources with costs"},
+		func(r *http.Request) (Catalog, error) {
+			return handleGetCatalog(), nil
+		})
+
+	post(rt, "/api/build", doc{Tag: "planets", Summary: "Queue a building on a planet"},
+		func(r *http.Request, req *BuildRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/upgrade", doc{Tag: "planets", Summary: "Upgrade a building"},
+		func(r *http.Request, req *UpgradeRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/demolish", doc{Tag: "planets", Summary: "Demolish a building"},
+		func(r *http.Request, req *DemolishRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/workforce/assign", doc{Tag: "planets", Summary: "Set workers on a building"},
+		func(r *http.Request, req *WorkforceAssignRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	get(rt, "/api/construction", doc{Tag: "planets", Summary: "Construction queue"},
+		func(r *http.Request) ([]ConstructionQueueItem, error) {
+			return handleGetConstructionQueue(getProvider(r), visibilityFor(r)), nil
+		})
+
+	post(rt, "/api/construction/cancel", doc{Tag: "planets", Summary: "Cancel queued construction"},
+		func(r *http.Request, req *CancelConstructionRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+}
+
+// findPlanet returns the planet with the given ID, or nil.
+func findPlanet(p GameStateProvider, id int) *entities.Planet {
+	for _, sys := range p.GetSystems() {
+		for _, e := range sys.Entities {
+			if pl, ok := e.(*entities.Planet); ok && pl.GetID() == id {
+				return pl
+			}
+		}
+	}
+	return nil
+}
+
+func handleGetPlanetFlows(planet *entities.Planet) PlanetFlows {
+	pop := float64(planet.Population)
+	var flows []ResourceFlow
+
+	allRes := []string{"Water", "Iron", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
+	for _, res := range allRes {
+		stored := planet.GetStoredAmount(res)
+		production := 0.0
+		popDrain := 0.0
+		buildDrain := 0.0
+
+		// Population consumption
+		for _, rate := range economy.PopulationConsumption {
+			if rate.ResourceType == res {
+				popDrain = pop / rate.PopDivisor * rate.PerPopulation
+			}
+		}
+
+		// Building consumption
+		for _, be := range planet.Buildings {
+			b, ok := be.(*entities.Building)
+			if !ok || !b.IsOperational || b.GetStaffingRatio() <= 0 {
+				continue
+			}
+			if upkeeps, found := economy.BuildingResourceUpkeep[b.BuildingType]; found {
+				for _, u := range upkeeps {
+					if u.ResourceType == res {
+						buildDrain += float64(u.Amount)
+					}
+				}
+			}
+			// Power system fuel/he3 burn
+			if b.BuildingType == entities.BuildingGenerator && res == "Fuel" {
+				levelMult := 1.0 + float64(b.Level-1)*0.3
+				buildDrain += 2.0 * levelMult
+			}
+			if b.BuildingType == entities.BuildingFusionReactor && res == "Helium-3" {
+				levelMult := 1.0 + float64(b.Level-1)*0.3
+				buildDrain += 1.0 * levelMult
+			}
+			// Refinery consumes Oil
+			if b.BuildingType == entities.BuildingRefinery && res == "Oil" {
+				levelMult := 1.0 + float64(b.Level-1)*0.3
+				buildDrain += 2.0 * levelMult
+			}
+			// Refinery produces Fuel
+			if b.BuildingType == entities.BuildingRefinery && res == "Fuel" {
+				levelMult := 1.0 + float64(b.Level-1)*0.3
+				production += 3.0 * levelMult
+			}
+			// Factory consumes RM + Iron, produces Electronics
+			if b.BuildingType == entities.BuildingFactory {
+				levelMult := 1.0 + float64(b.Level-1)*0.3
+				staffing := b.GetStaffingRatio()
+				if res == "Rare Metals" {
+					buildDrain += 2.0 * levelMult * staffing
+				}
+				if res == "Iron" {
+					buildDrain += 1.0 * levelMult * staffing
+				}
+				if res == "Electronics" {
+					production += 2.0 * levelMult * staffing
+				}
+			}
+		}
+
+		// Mine production (estimate from deposits)
+		for _, re := range planet.Resources {
+			if resource, ok := re.(*entities.Resource); ok && resource.ResourceType == res {
+				for _, be := range planet.Buildings {
+					if b, ok := be.(*entities.Building); ok && b.BuildingType == "Mine" && b.IsOperational {
+						resID := fmt.Sprintf("%d", resource.GetID())
+						if b.AttachedTo == resID && b.GetStaffingRatio() > 0 {
+							rate := 8.0 * resource.ExtractionRate * b.GetStaffingRatio() * b.ProductionBonus
+							abFactor := float64(resource.Abundance) / 70.0
+							if abFactor > 1.0 {
+								abFactor = 1.0
+							}
+							powerFactor := 0.25 + 0.75*planet.GetPowerRatio()
+							production += rate * abFactor * powerFactor
+						}
+					}
+				}
+			}
+		}
+
+		netFlow := production - popDrain - buildDrain
+		tte := 0
+		if netFlow < 0 && stored > 0 {
+			tte = int(float64(stored) / (-netFlow))
+		}
+
+		flows = append(flows, ResourceFlow{
+			Resource:        res,
+			Stored:          stored,
+			Production:      math.Round(production*10) / 10,
+			PopDrain:        math.Round(popDrain*10) / 10,
+			BuildDrain:      math.Round(buildDrain*10) / 10,
+			NetFlow:         math.Round(netFlow*10) / 10,
+			TicksUntilEmpty: tte,
+		})
+	}
+
+	return PlanetFlows{
+		PlanetID:   planet.GetID(),
+		PlanetName: planet.Name,
+		Population: planet.Population,
+		PowerRatio: planet.GetPowerRatio(),
+		Flows:      flows,
+	}
+}
+
+func handleGetPlanetPhysics(p GameStateProvider) []PlanetPhysics {
+	var result []PlanetPhysics
+	for _, sys := range p.GetSystems() {
+		for _, e := range sys.Entities {
+			pl, ok := e.(*entities.Planet)
+			if !ok || pl.Mass == 0 {
+				continue
+			}
+			result = append(result, PlanetPhysics{
+				ID: pl.GetID(), Name: pl.Name, Type: pl.PlanetType,
+				Owner: pl.Owner, System: sys.Name,
+				Mass:           math.Round(pl.Mass*100) / 100,
+				Radius:         math.Round(pl.RadiusAU*100) / 100,
+				Gravity:        math.Round(pl.Gravity*100) / 100,
+				Density:        math.Round(pl.Density*10) / 10,
+				OrbitAU:        math.Round(pl.OrbitAU*100) / 100,
+				TempC:          pl.Temperature,
+				Iron:           pl.Comp.Iron,
+				Silicate:       pl.Comp.Silicate,
+				Water:          pl.Comp.Water,
+				Gas:            pl.Comp.Gas,
+				Organics:       pl.Comp.Organics,
+				RareEarth:      pl.Comp.RareEarth,
+				MagneticField:  math.Round(pl.MagneticField*100) / 100,
+				AtmoPressure:   math.Round(pl.AtmoPressure*100) / 100,
+				OceanCoverage:  math.Round(pl.OceanCoverage*100) / 100,
+				IceCoverage:    math.Round(pl.IceCoverage*100) / 100,
+				TidallyLocked:  pl.TidallyLocked,
+				TectonicActive: pl.TectonicActive,
+				VolcanicLevel:  math.Round(pl.VolcanicLevel*100) / 100,
+				DayLength:      math.Round(pl.DayLength*10) / 10,
+				Moons:          len(pl.Moons),
+				ParentID:       pl.ParentPlanetID,
+				Habitability:   pl.Habitability,
+			})
+		}
+	}
+	return result
+}
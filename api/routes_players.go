@@ -0,0 +1,315 @@
+//go:build !js
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// registerPlayerRoutes registers player state, chat, events and the
+// diplomacy/espionage/bounty/council systems.
+func registerPlayerRoutes(rt *router) {
+	get(rt, "/api/status", doc{Tag: "players", Summary: "Everything an agent needs in one call"},
+		func(r *http.Request) (GameStatus, error) {
+			return handleGetStatus(getProvider(r), getAuthPlayer(r)), nil
+		})
+
+	get(rt, "/api/player/me", doc{Tag: "players", Summary: "Your planets and ships"},
+		func(r *http.Request) (*PlayerMe, error) {
+			data := handleGetPlayerMe(getProvider(r), getAuthPlayer(r))
+			if data == nil {
+				return nil, errNotFound("no player found")
+			}
+			return data, nil
+		})
+
+	get(rt, "/api/players", doc{Tag: "players", Summary: "All factions"},
+		func(r *http.Request) ([]PlayerInfo, error) {
+			return handleGetPlayers(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/leaderboard", doc{Tag: "players", Summary: "Faction rankings"},
+		func(r *http.Request) ([]LeaderboardEntry, error) {
+			return handleGetLeaderboard(getProvider(r), visibilityFor(r)), nil
+		})
+
+	get(rt, "/api/victory", doc{Tag: "players", Summary: "Progress toward each victory condition"},
+		func(r *http.Request) ([]VictoryProgress, error) {
+			return handleGetVictory(getProvider(r)), nil
+		})
+
+	get(rt, "/api/events", doc{Tag: "players", Summary: "Recent game events", Query: []param{
+		{"limit", "integer", "max events (default 30)"},
+	}}, func(r *http.Request) ([]game.GameEvent, error) {
+		el := getProvider(r).GetEventLog()
+		if el == nil {
+			return []game.GameEvent{}, nil
+		}
+		vis := visibilityFor(r)
+		limit := queryInt(r, "limit", 30)
+		events := make([]game.GameEvent, 0, limit)
+		for _, ev := range el.Recent(200) {
+			if len(events) == limit {
+				break
+			}
+			if eventVisible(vis, ev) {
+				events = append(events, ev)
+			}
+		}
+		return events, nil
+	})
+
+	// Galaxy intel: one-stop overview of special map features
+	get(rt, "/api/galaxy/intel", doc{Tag: "galaxy", Summary: "Anomalies, pirates, wormholes and other notable events"},
+		func(r *http.Request) ([]IntelItem, error) {
+			return handleGetGalaxyIntel(getProvider(r), visibilityFor(r)), nil
+		})
+
+	// Multiplayer chat
+	post(rt, "/api/chat/send", doc{Tag: "chat", Summary: "Send a chat message (max 200 chars)", Auth: true},
+		func(r *http.Request, req *ChatSendRequest) (map[string]string, error) {
+			msg := strings.TrimSpace(req.Message)
+			if msg == "" {
+				return nil, errors.New("message required")
+			}
+			p := getProvider(r)
+			chatLog := p.GetChatLog()
+			if chatLog == nil {
+				return nil, errUnavailable("chat")
+			}
+			if len(msg) > 200 {
+				msg = msg[:200]
+			}
+			tick, gameTime, _, _ := p.GetTickInfo()
+			chatLog.Send(tick, gameTime, getAuthPlayer(r), msg)
+			return map[string]string{"status": "sent"}, nil
+		})
+
+	get(rt, "/api/chat/messages", doc{Tag: "chat", Summary: "Last 20 chat messages, oldest first"},
+		func(r *http.Request) ([]game.ChatMsg, error) {
+			chatLog := getProvider(r).GetChatLog()
+			if chatLog == nil {
+				return []game.ChatMsg{}, nil
+			}
+			messages := chatLog.Recent(20)
+			// Reverse to chronological order
+			for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
+				messages[i], messages[j] = messages[j], messages[i]
+			}
+			return messages, nil
+		})
+
+	// Diplomacy: view and manage faction relations. Unauthenticated
+	// requests get every faction's relations.
+	get(rt, "/api/diplomacy", doc{Tag: "diplomacy", Summary: "Your relations, or all relations when unauthenticated"},
+		func(r *http.Request) (interface{}, error) {
+			p := getProvider(r)
+			dm := p.GetDiplomacyManager()
+			if dm == nil {
+				return nil, errUnavailable("diplomacy")
+			}
+			named := func(player string) map[string]string {
+				out := make(map[string]string)
+				for k, v := range dm.GetAllRelations(player) {
+					out[k] = economy.RelationName(v)
+				}
+				return out
+			}
+			if playerName := getAuthPlayer(r); playerName != "" {
+				return named(playerName), nil
+			}
+			overview := make(map[string]map[string]string)
+			for _, pl := range p.GetPlayers() {
+				if pl == nil {
+					continue
+				}
+				if rels := named(pl.Name); len(rels) > 0 {
+					overview[pl.Name] = rels
+				}
+			}
+			return overview, nil
+		})
+
+	post(rt, "/api/diplomacy", doc{Tag: "diplomacy", Summary: "Improve or degrade a relation", Auth: true},
+		func(r *http.Request, req *DiplomacyRequest) (DiplomacyResult, error) {
+			dm := getProvider(r).GetDiplomacyManager()
+			if dm == nil {
+				return DiplomacyResult{}, errUnavailable("diplomacy")
+			}
+			playerName := getAuthPlayer(r)
+			var newLevel int
+			switch req.Action {
+			case "improve":
+				newLevel = dm.ImproveRelation(playerName, req.Target)
+			case "degrade":
+				newLevel = dm.DegradeRelation(playerName, req.Target)
+			default:
+				return DiplomacyResult{}, errors.New("action must be 'improve' or 'degrade'")
+			}
+			return DiplomacyResult{
+				You:      playerName,
+				Target:   req.Target,
+				Relation: economy.RelationName(newLevel),
+				Level:    newLevel,
+			}, nil
+		})
+
+	// Espionage: launch spy operations
+	get(rt, "/api/espionage", doc{Tag: "diplomacy", Summary: "Your active spy operations"},
+		func(r *http.Request) ([]*economy.SpyOperation, error) {
+			em := getProvider(r).GetEspionageManager()
+			if em == nil {
+				return nil, errUnavailable("espionage")
+			}
+			return em.GetActiveOps(getAuthPlayer(r)), nil
+		})
+
+	post(rt, "/api/espionage", doc{Tag: "diplomacy", Summary: "Launch a spy operation", Auth: true},
+		func(r *http.Request, req *EspionageRequest) (*economy.SpyOperation, error) {
+			return dispatchAs[*economy.SpyOperation](r, req)
+		})
+
+	// Bounty board
+	get(rt, "/api/bounties", doc{Tag: "diplomacy", Summary: "Open bounties"},
+		func(r *http.Request) ([]*economy.Bounty, error) {
+			bb := getProvider(r).GetBountyBoard()
+			if bb == nil {
+				return nil, errUnavailable("bounty board")
+			}
+			return bb.GetActiveBounties(), nil
+		})
+
+	post(rt, "/api/bounties", doc{Tag: "diplomacy", Summary: "Post a bounty; the reward is escrowed", Auth: true},
+		func(r *http.Request, req *BountyRequest) (*economy.Bounty, error) {
+			return dispatchAs[*economy.Bounty](r, req)
+		})
+
+	post(rt, "/api/bounties/claim", doc{Tag: "diplomacy", Summary: "Claim a bounty"},
+		func(r *http.Request, req *BountyClaimRequest) (string, error) {
+			bb := getProvider(r).GetBountyBoard()
+			if bb == nil {
+				return "", errUnavailable("bounty board")
+			}
+			if !bb.ClaimBounty(req.BountyID, getAuthPlayer(r)) {
+				return "", errors.New("bounty not available")
+			}
+			return "claimed", nil
+		})
+
+	// Galactic Council: propose and vote on policies
+	get(rt, "/api/council", doc{Tag: "diplomacy", Summary: "Open council proposals"},
+		func(r *http.Request) ([]*economy.CouncilProposal, error) {
+			council := getProvider(r).GetCouncil()
+			if council == nil {
+				return nil, errUnavailable("council")
+			}
+			return council.GetActiveProposals(), nil
+		})
+
+	post(rt, "/api/council", doc{Tag: "diplomacy", Summary: "Propose a policy", Auth: true},
+		func(r *http.Request, req *ProposalRequest) (*economy.CouncilProposal, error) {
+			council := getProvider(r).GetCouncil()
+			if council == nil {
+				return nil, errUnavailable("council")
+			}
+			return council.Propose(getAuthPlayer(r), req.Title, req.Description, req.Effect, 3000), nil
+		})
+
+	post(rt, "/api/council/vote", doc{Tag: "diplomacy", Summary: "Vote on a proposal"},
+		func(r *http.Request, req *VoteRequest) (string, error) {
+			council := getProvider(r).GetCouncil()
+			if council == nil {
+				return "", errUnavailable("council")
+			}
+			if !council.Vote(req.ProposalID, getAuthPlayer(r), req.Yes) {
+				return "", errors.New("proposal not found or voting closed")
+			}
+			return "vote recorded", nil
+		})
+}
+
+// handleGetGalaxyIntel picks notable happenings out of the events vis may see.
+func handleGetGalaxyIntel(p GameStateProvider, vis *game.Visibility) []IntelItem {
+	events := p.GetEventLog()
+	if events == nil {
+		return nil
+	}
+
+	// Extract anomalies, pirates, wormholes, sector control from recent events
+	var intel []IntelItem
+	for _, e := range events.Recent(200) {
+		if e.Type != "event" && e.Type != "explore" || !eventVisible(vis, e) {
+			continue
+		}
+		msg := e.Message
+		switch {
+		case strings.Contains(msg, "Anomaly detected"):
+			intel = append(intel, IntelItem{Type: "anomaly", Message: msg})
+		case strings.Contains(msg, "Pirate fleet") || strings.Contains(msg, "pirate"):
+			intel = append(intel, IntelItem{Type: "pirate", Message: msg})
+		case strings.Contains(msg, "Wormhole") || strings.Contains(msg, "wormhole"):
+			intel = append(intel, IntelItem{Type: "wormhole", Message: msg})
+		case strings.Contains(msg, "controls") && strings.Contains(msg, "production bonus"):
+			intel = append(intel, IntelItem{Type: "sector_control", Message: msg})
+		case strings.Contains(msg, "LEGENDARY"):
+			intel = append(intel, IntelItem{Type: "legendary", Message: msg})
+		case strings.Contains(msg, "AUCTION"):
+			intel = append(intel, IntelItem{Type: "auction", Message: msg})
+		case strings.Contains(msg, "Trade boom"):
+			intel = append(intel, IntelItem{Type: "trade_boom", Message: msg})
+		case strings.Contains(msg, "Solar flare"):
+			intel = append(intel, IntelItem{Type: "solar_flare", Message: msg})
+		case strings.Contains(msg, "Asteroid"):
+			intel = append(intel, IntelItem{Type: "asteroid", Message: msg})
+		case strings.Contains(msg, "Population boom"):
+			intel = append(intel, IntelItem{Type: "pop_boom", Message: msg})
+		case strings.Contains(msg, "Void Crystal") || strings.Contains(msg, "data cache") || strings.Contains(msg, "Ancient Ruins") || strings.Contains(msg, "Derelict"):
+			intel = append(intel, IntelItem{Type: "discovery", Message: msg})
+		}
+	}
+	return intel
+}
+
+func handleGetVictory(p GameStateProvider) []VictoryProgress {
+	systems := p.GetSystems()
+
+	var results []VictoryProgress
+	for _, player := range p.GetPlayers() {
+		if player == nil {
+			continue
+		}
+		planets := 0
+		maxTech := 0.0
+		var pop int64
+		for _, sys := range systems {
+			for _, e := range sys.Entities {
+				if pl, ok := e.(*entities.Planet); ok && pl.Owner == player.Name {
+					planets++
+					pop += pl.Population
+					if pl.TechLevel > maxTech {
+						maxTech = pl.TechLevel
+					}
+				}
+			}
+		}
+
+		results = append(results, VictoryProgress{
+			Player: player.Name, Credits: player.Credits,
+			Planets: planets, MaxTech: maxTech, Population: pop,
+			Progress: map[string]string{
+				"economic":   fmt.Sprintf("%d / 50,000,000 credits", player.Credits),
+				"domination": fmt.Sprintf("%d / 20 planets", planets),
+				"technology": fmt.Sprintf("%.1f / 5.0 tech level", maxTech),
+				"population": fmt.Sprintf("%d / 1,000,000 citizens", pop),
+			},
+		})
+	}
+	return results
+}
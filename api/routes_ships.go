@@ -0,0 +1,393 @@
+//go:build !js
+
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// registerShipRoutes registers ships, fleets, cargo and shipping routes.
+func registerShipRoutes(rt *router) {
+	get(rt, "/api/ships", doc{Tag: "ships", Summary: "All ships", Query: []param{
+		{"owner", "string", "only this owner's ships"},
+	}}, func(r *http.Request) ([]ShipInfo, error) {
+		ships := handleGetShips(getProvider(r), visibilityFor(r))
+		filterOwner := r.URL.Query().Get("owner")
+		if filterOwner == "" {
+			return ships, nil
+		}
+		filtered := make([]ShipInfo, 0)
+		for _, s := range ships {
+			if strings.EqualFold(s.Owner, filterOwner) {
+				filtered = append(filtered, s)
+			}
+		}
+		return filtered, nil
+	})
+
+	post(rt, "/api/ships/build", doc{Tag: "ships", Summary: "Queue a ship at a shipyard"},
+		func(r *http.Request, req *ShipBuildRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/ships/move", doc{Tag: "ships", Summary: "Send a ship to a system"},
+		func(r *http.Request, req *ShipMoveRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/ships/refuel", doc{Tag: "ships", Summary: "Refuel a ship from a planet's Fuel"},
+		func(r *http.Request, req *ShipRefuelRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/ships/transfer-fuel", doc{Tag: "ships", Summary: "Move fuel between two ships in the same system"},
+		func(r *http.Request, req *TransferFuelRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/colonize", doc{Tag: "ships", Summary: "Colonize a planet with a colony ship"},
+		func(r *http.Request, req *ColonizeRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/cargo/load", doc{Tag: "ships", Summary: "Load cargo from a planet onto a ship"},
+		func(r *http.Request, req *CargoRequest) (CargoResult, error) {
+			return dispatchAs[CargoResult](r, &cargoCommand{CargoRequest: *req, load: true})
+		})
+
+	post(rt, "/api/cargo/unload", doc{Tag: "ships", Summary: "Unload cargo from a ship onto a planet"},
+		func(r *http.Request, req *CargoRequest) (CargoResult, error) {
+			return dispatchAs[CargoResult](r, &cargoCommand{CargoRequest: *req})
+		})
+
+	post(rt, "/api/ships/dock", doc{Tag: "ships", Summary: "Dock a ship at a planet"},
+		func(r *http.Request, req *DockShipRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/ships/undock", doc{Tag: "ships", Summary: "Undock a ship"},
+		func(r *http.Request, req *UndockShipRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/ships/sell-at-dock", doc{Tag: "ships", Summary: "Sell cargo from a docked ship"},
+		func(r *http.Request, req *SellAtDockRequest) (interface{}, error) {
+			return dispatch(r, &dockTradeCommand{SellAtDockRequest: *req})
+		})
+
+	// Same body shape as sell-at-dock: ship_id, resource, quantity
+	post(rt, "/api/ships/buy-at-dock", doc{Tag: "ships", Summary: "Buy from the docked planet into cargo"},
+		func(r *http.Request, req *SellAtDockRequest) (interface{}, error) {
+			return dispatch(r, &dockTradeCommand{SellAtDockRequest: *req, buy: true})
+		})
+
+	get(rt, "/api/fleets", doc{Tag: "fleets", Summary: "All fleets"},
+		func(r *http.Request) ([]FleetInfo, error) {
+			return handleGetFleets(getProvider(r), visibilityFor(r)), nil
+		})
+
+	post(rt, "/api/fleets/move", doc{Tag: "fleets", Summary: "Send a fleet to a system"},
+		func(r *http.Request, req *FleetMoveRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/fleets/create", doc{Tag: "fleets", Summary: "Form a fleet around a ship"},
+		func(r *http.Request, req *FleetCreateRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/fleets/disband", doc{Tag: "fleets", Summary: "Disband a fleet"},
+		func(r *http.Request, req *FleetDisbandRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/fleets/add-ship", doc{Tag: "fleets", Summary: "Add a ship to a fleet"},
+		func(r *http.Request, req *FleetAddShipRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	post(rt, "/api/fleets/remove-ship", doc{Tag: "fleets", Summary: "Remove a ship from a fleet"},
+		func(r *http.Request, req *FleetRemoveShipRequest) (interface{}, error) {
+			return dispatch(r, req)
+		})
+
+	// Hyperlane/route info
+	get(rt, "/api/routes/{id}", doc{Tag: "galaxy", Summary: "Systems one hyperlane jump away"},
+		func(r *http.Request) (SystemLinks, error) {
+			id, err := pathID(r, "id", "system ID")
+			if err != nil {
+				return SystemLinks{}, err
+			}
+			connected := make([]int, 0)
+			for _, hl := range getProvider(r).GetHyperlanes() {
+				if hl.From == id {
+					connected = append(connected, hl.To)
+				} else if hl.To == id {
+					connected = append(connected, hl.From)
+				}
+			}
+			return SystemLinks{SystemID: id, Connected: connected}, nil
+		})
+
+	get(rt, "/api/deliveries", doc{Tag: "shipping", Summary: "Cargo currently in flight"},
+		func(r *http.Request) ([]*economy.PendingDelivery, error) {
+			dm := getProvider(r).GetDeliveryManager()
+			if dm == nil {
+				return []*economy.PendingDelivery{}, nil
+			}
+			return dm.GetActiveDeliveries(), nil
+		})
+
+	get(rt, "/api/shipping", doc{Tag: "shipping", Summary: "Your shipping routes"},
+		func(r *http.Request) ([]*game.ShippingRoute, error) {
+			sm := getProvider(r).GetShippingManager()
+			if sm == nil {
+				return []*game.ShippingRoute{}, nil
+			}
+			return sm.GetRoutes(getAuthPlayer(r)), nil
+		})
+
+	post(rt, "/api/shipping", doc{Tag: "shipping", Summary: "Create a shipping route"},
+		func(r *http.Request, req *ShippingRequest) (map[string]int, error) {
+			sm := getProvider(r).GetShippingManager()
+			if sm == nil {
+				return nil, errUnavailable("shipping")
+			}
+			player := getAuthPlayer(r)
+			if player == "" {
+				player = "Server"
+			}
+			id := sm.CreateRoute(player, req.SourcePlanet, req.DestPlanet, req.Resource, req.Quantity, req.ShipID)
+			return map[string]int{"route_id": id}, nil
+		})
+
+	del(rt, "/api/shipping", doc{Tag: "shipping", Summary: "Cancel a shipping route"},
+		func(r *http.Request, req *CancelShippingRequest) (map[string]int, error) {
+			sm := getProvider(r).GetShippingManager()
+			if sm == nil || !sm.CancelRoute(req.RouteID) {
+				return nil, errNotFound("route not found")
+			}
+			return map[string]int{"cancelled": req.RouteID}, nil
+		})
+
+	get(rt, "/api/shipping/routes", doc{Tag: "shipping", Summary: "Your shipping routes with whether each can run"},
+		func(r *http.Request) ([]ShippingRouteStatus, error) {
+			p := getProvider(r)
+			sm := p.GetShippingManager()
+			if sm == nil {
+				return nil, errUnavailable("shipping system")
+			}
+			return handleGetShippingRoutes(p, sm.GetRoutes(getAuthPlayer(r))), nil
+		})
+
+	post(rt, "/api/shipping/routes", doc{Tag: "shipping", Summary: "Create a shipping route between two planets", Auth: true},
+		func(r *http.Request, req *ShippingRouteRequest) (ShippingRouteCreated, error) {
+			p := getProvider(r)
+			sm := p.GetShippingManager()
+			if sm == nil {
+				return ShippingRouteCreated{}, errUnavailable("shipping system")
+			}
+			// Validate planet IDs — must actually exist in the galaxy
+			srcFound := false
+			dstFound := false
+			for _, sys := range p.GetSystems() {
+				for _, e := range sys.Entities {
+					if pl, ok := e.(*entities.Planet); ok {
+						if pl.GetID() == req.SourcePlanetID {
+							srcFound = true
+						}
+						if pl.GetID() == req.DestPlanetID {
+							dstFound = true
+						}
+					}
+				}
+			}
+			if !srcFound {
+				return ShippingRouteCreated{}, fmt.Errorf("source planet %d not found — use planet IDs from get_planet, not system IDs or planet names", req.SourcePlanetID)
+			}
+			if !dstFound {
+				return ShippingRouteCreated{}, fmt.Errorf("dest planet %d not found — use planet IDs from get_planet, not system IDs or planet names", req.DestPlanetID)
+			}
+			if req.SourcePlanetID == req.DestPlanetID {
+				return ShippingRouteCreated{}, errors.New("source and dest cannot be the same planet")
+			}
+			playerName := getAuthPlayer(r)
+			routeID := sm.CreateRoute(playerName, req.SourcePlanetID, req.DestPlanetID, req.Resource, req.Quantity, req.ShipID)
+			return ShippingRouteCreated{RouteID: routeID, Owner: playerName}, nil
+		})
+
+	// Enriched route data with planet/system names for the logistics page
+	get(rt, "/api/logistics", doc{Tag: "shipping", Summary: "Every shipping route with names, stock and ship status"},
+		func(r *http.Request) (Logistics, error) {
+			return handleGetLogistics(getProvider(r)), nil
+		})
+}
+
+func handleGetShippingRoutes(p GameStateProvider, routes []*game.ShippingRoute) []ShippingRouteStatus {
+	result := make([]ShippingRouteStatus, 0, len(routes))
+	for _, rt := range routes {
+		diag := ShippingRouteStatus{
+			ShippingRouteInfo: ShippingRouteInfo{
+				ID: rt.ID, Owner: rt.Owner,
+				SourcePlanet: rt.SourcePlanet, DestPlanet: rt.DestPlanet,
+				Resource: rt.Resource, Quantity: rt.Quantity,
+				ShipID: rt.ShipID, Active: rt.Active,
+				TripsComplete: rt.TripsComplete,
+			},
+			Status: "no_ship",
+		}
+
+		// Check source stock
+		for _, sys := range p.GetSystems() {
+			for _, e := range sys.Entities {
+				if pl, ok := e.(*entities.Planet); ok && pl.GetID() == rt.SourcePlanet {
+					diag.SourceStock = pl.GetStoredAmount(rt.Resource)
+				}
+			}
+		}
+
+		// Check ship status
+		if rt.ShipID != 0 {
+			for _, pl := range p.GetPlayers() {
+				if pl == nil {
+					continue
+				}
+				for _, s := range pl.OwnedShips {
+					if s != nil && s.GetID() == rt.ShipID {
+						diag.ShipFuel = s.CurrentFuel
+						diag.ShipSystem = s.CurrentSystem
+						if s.CurrentFuel < s.FuelPerJump {
+							diag.Status = "no_fuel"
+						} else if diag.SourceStock == 0 {
+							diag.Status = "no_stock"
+						} else {
+							diag.Status = "ready"
+						}
+					}
+				}
+			}
+		}
+
+		result = append(result, diag)
+	}
+	return result
+}
+
+func handleGetLogistics(p GameStateProvider) Logistics {
+	result := Logistics{Routes: make([]LogisticsRoute, 0)}
+	sm := p.GetShippingManager()
+	if sm == nil {
+		return result
+	}
+
+	// Build planet→name and planet→system maps
+	planetName := make(map[int]string)
+	planetSystem := make(map[int]int)
+	systemName := make(map[int]string)
+	for _, sys := range p.GetSystems() {
+		systemName[sys.ID] = sys.Name
+		for _, e := range sys.Entities {
+			if pl, ok := e.(*entities.Planet); ok {
+				planetName[pl.GetID()] = pl.Name
+				planetSystem[pl.GetID()] = sys.ID
+			}
+		}
+	}
+
+	// Build ship lookup
+	shipMap := make(map[int]LogisticsShip)
+	for _, pl := range p.GetPlayers() {
+		if pl == nil {
+			continue
+		}
+		for _, s := range pl.OwnedShips {
+			if s == nil {
+				continue
+			}
+			sn := systemName[s.CurrentSystem]
+			if sn == "" {
+				sn = fmt.Sprintf("SYS-%d", s.CurrentSystem+1)
+			}
+			shipMap[s.GetID()] = LogisticsShip{
+				Name: s.Name, Fuel: s.CurrentFuel, MaxFuel: s.MaxFuel,
+				System: s.CurrentSystem, SysName: sn,
+				Status: string(s.Status), Cargo: s.GetTotalCargo(),
+			}
+		}
+	}
+
+	for _, rt := range sm.GetRoutes("") {
+		srcName := planetName[rt.SourcePlanet]
+		if srcName == "" {
+			srcName = fmt.Sprintf("Planet-%d", rt.SourcePlanet)
+		}
+		dstName := planetName[rt.DestPlanet]
+		if dstName == "" {
+			dstName = fmt.Sprintf("Planet-%d", rt.DestPlanet)
+		}
+		srcSysID := planetSystem[rt.SourcePlanet]
+		dstSysID := planetSystem[rt.DestPlanet]
+		srcSysName := systemName[srcSysID]
+		if srcSysName == "" {
+			srcSysName = fmt.Sprintf("SYS-%d", srcSysID+1)
+		}
+		dstSysName := systemName[dstSysID]
+		if dstSysName == "" {
+			dstSysName = fmt.Sprintf("SYS-%d", dstSysID+1)
+		}
+
+		// Source stock
+		srcStock := 0
+		for _, sys := range p.GetSystems() {
+			for _, e := range sys.Entities {
+				if pl, ok := e.(*entities.Planet); ok && pl.GetID() == rt.SourcePlanet {
+					srcStock = pl.GetStoredAmount(rt.Resource)
+				}
+			}
+		}
+
+		status := "no_ship"
+		var ship *LogisticsShip
+		if rt.ShipID != 0 {
+			if si, ok := shipMap[rt.ShipID]; ok {
+				ship = &si
+				if si.Fuel < 25 {
+					status = "no_fuel"
+				} else if srcStock == 0 {
+					status = "no_stock"
+				} else if si.Status == "Moving" {
+					status = "in_transit"
+				} else {
+					status = "ready"
+				}
+			}
+		}
+
+		result.Routes = append(result.Routes, LogisticsRoute{
+			ID: rt.ID, Owner: rt.Owner, Resource: rt.Resource,
+			Quantity: rt.Quantity, TripsComplete: rt.TripsComplete,
+			Active:       rt.Active,
+			SourcePlanet: srcName, SourceSystem: srcSysName,
+			DestPlanet: dstName, DestSystem: dstSysName,
+			SourceStock: srcStock, ShipID: rt.ShipID,
+			Ship: ship, Status: status,
+		})
+	}
+
+	// Summary stats
+	for _, r := range result.Routes {
+		result.TotalTrips += r.TripsComplete
+		if r.TripsComplete > 0 {
+			result.Delivering++
+		}
+	}
+	result.Total = len(result.Routes)
+	return result
+}
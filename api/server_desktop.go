@@ -4,21 +4,16 @@ package api
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"io"
-	"math"
 	"net/http"
-	"net/url"
 	"os"
-	"strconv"
 	"strings"
 	"sync"
 	"sync/atomic"
-	"time"
 
-	"github.com/hunterjsb/xandaris/economy"
-	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/game"
 	"github.com/hunterjsb/xandaris/systems"
 )
@@ -29,6 +24,9 @@ type ctxKey string
 
 const ctxPlayerName ctxKey = "playerName"
 const ctxIsAdmin ctxKey = "isAdmin"
+const ctxGrant ctxKey = "grant"
+const ctxRequestID ctxKey = "requestID"
+const ctxGame ctxKey = "game"
 
 // getAuthPlayer returns the authenticated player name from the request context.
 // Returns empty string for admin keys or unauthenticated requests.
@@ -39,3348 +37,193 @@ func getAuthPlayer(r *http.Request) string {
 	return ""
 }
 
-// newCommand creates a GameCommand with the authenticated player name attached.
-func newCommand(r *http.Request, cmdType game.CommandType, data interface{}) game.GameCommand {
-	return game.GameCommand{
-		Type:       cmdType,
-		Data:       data,
-		Result:     make(chan interface{}, 1),
-		PlayerName: getAuthPlayer(r),
-	}
-}
-
-// isAdmin returns whether the request was authenticated with the admin key.
-func isAdmin(r *http.Request) bool {
-	if v, ok := r.Context().Value(ctxIsAdmin).(bool); ok {
-		return v
-	}
-	return false
-}
-
-var (
-	serverStarted  atomic.Bool
-	providerMu     sync.RWMutex
-	activeProvider GameStateProvider
-	apiKey         string // if set, POST endpoints require X-API-Key header
-)
-
-func getProvider() GameStateProvider {
-	providerMu.RLock()
-	defer providerMu.RUnlock()
-	return activeProvider
-}
-
-// StartServer launches the REST API on :8080 in a background goroutine.
-// Subsequent calls update the provider without starting a second server.
-func StartServer(provider GameStateProvider) {
-	providerMu.Lock()
-	activeProvider = provider
-	providerMu.Unlock()
-
-	// Load API key from environment
-	apiKey = os.Getenv("XANDARIS_API_KEY")
-	if apiKey != "" {
-		fmt.Println("[API] API key auth enabled for POST endpoints")
-	}
-
-	if serverStarted.Swap(true) {
-		fmt.Println("[API] Provider updated (server already running)")
-		return
-	}
-
-	mux := http.NewServeMux()
-
-	mux.HandleFunc("/api/market", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetMarket(getProvider())})
-	})
-
-	mux.HandleFunc("/api/market/trade", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req TradeRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.Resource == "" || req.Quantity <= 0 {
-			writeErr(w, http.StatusBadRequest, "resource and positive quantity required")
-			return
-		}
-		buy := strings.EqualFold(req.Action, "buy")
-
-		// Execute trade on main goroutine via command channel
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "trade",
-			Data:   game.TradeCommandData{Resource: req.Resource, Quantity: req.Quantity, Buy: buy, PlanetID: req.PlanetID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-
-		// Wait for result with timeout
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			case economy.TradeRecord:
-				writeJSON(w, APIResponse{OK: true, Data: TradeResult{
-					Resource: v.Resource,
-					Quantity: v.Quantity,
-					Action:   v.Action,
-					Total:    v.Total,
-				}})
-			default:
-				writeErr(w, http.StatusInternalServerError, "unexpected result type")
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "trade execution timed out")
-		}
-	})
-
-	// Cargo load endpoint
-	mux.HandleFunc("/api/cargo/load", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req CargoRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ShipID <= 0 || req.PlanetID <= 0 || req.Resource == "" || req.Quantity <= 0 {
-			writeErr(w, http.StatusBadRequest, "ship_id, planet_id, resource, and positive quantity required")
-			return
-		}
-
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type: "cargo_load",
-			Data: game.CargoCommandData{
-				ShipID:   req.ShipID,
-				PlanetID: req.PlanetID,
-				Resource: req.Resource,
-				Quantity: req.Quantity,
-				Load:     true,
-			},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			case int:
-				writeJSON(w, APIResponse{OK: true, Data: CargoResult{
-					ShipID:   req.ShipID,
-					PlanetID: req.PlanetID,
-					Resource: req.Resource,
-					Quantity: v,
-					Action:   "load",
-				}})
-			default:
-				writeErr(w, http.StatusInternalServerError, "unexpected result type")
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "cargo operation timed out")
-		}
-	})
-
-	// Cargo unload endpoint
-	mux.HandleFunc("/api/cargo/unload", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req CargoRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ShipID <= 0 || req.PlanetID <= 0 || req.Resource == "" || req.Quantity <= 0 {
-			writeErr(w, http.StatusBadRequest, "ship_id, planet_id, resource, and positive quantity required")
-			return
-		}
-
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type: "cargo_unload",
-			Data: game.CargoCommandData{
-				ShipID:   req.ShipID,
-				PlanetID: req.PlanetID,
-				Resource: req.Resource,
-				Quantity: req.Quantity,
-				Load:     false,
-			},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			case int:
-				writeJSON(w, APIResponse{OK: true, Data: CargoResult{
-					ShipID:   req.ShipID,
-					PlanetID: req.PlanetID,
-					Resource: req.Resource,
-					Quantity: v,
-					Action:   "unload",
-				}})
-			default:
-				writeErr(w, http.StatusInternalServerError, "unexpected result type")
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "cargo operation timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/market/history", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		limit := 50
-		if l := r.URL.Query().Get("limit"); l != "" {
-			if n, err := strconv.Atoi(l); err == nil && n > 0 {
-				limit = n
-			}
-		}
-		filterResource := r.URL.Query().Get("resource")
-		filterPlayer := r.URL.Query().Get("player")
-		result := handleGetTradeHistory(getProvider(), limit)
-		// Apply filters if provided
-		if filterResource != "" || filterPlayer != "" {
-			if entries, ok := result.([]TradeHistoryEntry); ok {
-				filtered := make([]TradeHistoryEntry, 0)
-				for _, e := range entries {
-					if filterResource != "" && !strings.EqualFold(e.Resource, filterResource) {
-						continue
-					}
-					if filterPlayer != "" && !strings.EqualFold(e.Player, filterPlayer) {
-						continue
-					}
-					filtered = append(filtered, e)
-				}
-				result = filtered
-			}
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
-
-	mux.HandleFunc("/api/galaxy", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetGalaxy(getProvider())})
-	})
-
-	mux.HandleFunc("/api/systems/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		idStr := strings.TrimPrefix(r.URL.Path, "/api/systems/")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid system ID")
-			return
-		}
-		data, found := handleGetSystem(getProvider(), id)
-		if !found {
-			writeErr(w, http.StatusNotFound, "system not found")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: data})
-	})
-
-	mux.HandleFunc("/api/planets/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		idStr := strings.TrimPrefix(r.URL.Path, "/api/planets/")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid planet ID")
-			return
-		}
-		data, found := handleGetPlanet(getProvider(), id)
-		if !found {
-			writeErr(w, http.StatusNotFound, "planet not found")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: data})
-	})
-
-	mux.HandleFunc("/api/player/me", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		data := handleGetPlayerMe(getProvider(), getAuthPlayer(r))
-		if data == nil {
-			writeErr(w, http.StatusNotFound, "no player found")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: data})
-	})
-
-	mux.HandleFunc("/api/players", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetPlayers(getProvider())})
-	})
-
-	mux.HandleFunc("/api/orders", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		switch r.Method {
-		case http.MethodGet:
-			player := getAuthPlayer(r)
-			orders := p.GetStandingOrders(player)
-			if orders == nil {
-				orders = []*game.StandingOrder{}
-			}
-			writeJSON(w, APIResponse{OK: true, Data: orders})
-		case http.MethodPost:
-			var req struct {
-				PlanetID  int    `json:"planet_id"`
-				Resource  string `json:"resource"`
-				Action    string `json:"action"`
-				Quantity  int    `json:"quantity"`
-				Threshold int    `json:"threshold"`
-				MaxPrice  int    `json:"max_price"`
-				MinPrice  int    `json:"min_price"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, "invalid JSON")
-				return
-			}
-			if req.Action != "buy" && req.Action != "sell" {
-				writeErr(w, http.StatusBadRequest, "action must be 'buy' or 'sell'")
-				return
-			}
-			if req.Quantity <= 0 {
-				writeErr(w, http.StatusBadRequest, "quantity must be positive")
-				return
-			}
-			resultCh := make(chan interface{}, 1)
-			cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-				Type: "standing_order",
-				Data: game.StandingOrderCommandData{
-					PlanetID:  req.PlanetID,
-					Resource:  req.Resource,
-					Action:    req.Action,
-					Quantity:  req.Quantity,
-					Threshold: req.Threshold,
-					MaxPrice:  req.MaxPrice,
-					MinPrice:  req.MinPrice,
-				},
-				Result: resultCh,
-			}
-		p.GetCommandChannel() <- cmd
-			select {
-			case result := <-resultCh:
-				switch v := result.(type) {
-				case error:
-					writeErr(w, http.StatusBadRequest, v.Error())
-				default:
-					writeJSON(w, APIResponse{OK: true, Data: v})
-				}
-			case <-time.After(5 * time.Second):
-				writeErr(w, http.StatusGatewayTimeout, "timed out")
-			}
-		case http.MethodDelete:
-			var req struct {
-				OrderID int `json:"order_id"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, "invalid JSON")
-				return
-			}
-			resultCh := make(chan interface{}, 1)
-			cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-				Type:   "cancel_order",
-				Data:   game.CancelOrderCommandData{OrderID: req.OrderID},
-				Result: resultCh,
-			}
-		p.GetCommandChannel() <- cmd
-			select {
-			case result := <-resultCh:
-				switch v := result.(type) {
-				case error:
-					writeErr(w, http.StatusBadRequest, v.Error())
-				default:
-					writeJSON(w, APIResponse{OK: true, Data: v})
-				}
-			case <-time.After(5 * time.Second):
-				writeErr(w, http.StatusGatewayTimeout, "timed out")
-			}
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET, POST, or DELETE")
-		}
-	})
-
-	mux.HandleFunc("/api/shipping", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		switch r.Method {
-		case http.MethodGet:
-			sm := p.GetShippingManager()
-			if sm == nil {
-				writeJSON(w, APIResponse{OK: true, Data: []interface{}{}})
-				return
-			}
-			writeJSON(w, APIResponse{OK: true, Data: sm.GetRoutes(getAuthPlayer(r))})
-		case http.MethodPost:
-			var req struct {
-				SourcePlanet int    `json:"source_planet"`
-				DestPlanet   int    `json:"dest_planet"`
-				Resource     string `json:"resource"`
-				Quantity     int    `json:"quantity"`
-				ShipID       int    `json:"ship_id"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, "invalid JSON")
-				return
-			}
-			sm := p.GetShippingManager()
-			if sm == nil {
-				writeErr(w, http.StatusInternalServerError, "shipping not available")
-				return
-			}
-			player := getAuthPlayer(r)
-			if player == "" {
-				player = "Server"
-			}
-			id := sm.CreateRoute(player, req.SourcePlanet, req.DestPlanet, req.Resource, req.Quantity, req.ShipID)
-			writeJSON(w, APIResponse{OK: true, Data: map[string]int{"route_id": id}})
-		case http.MethodDelete:
-			var req struct {
-				RouteID int `json:"route_id"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, "invalid JSON")
-				return
-			}
-			sm := p.GetShippingManager()
-			if sm != nil && sm.CancelRoute(req.RouteID) {
-				writeJSON(w, APIResponse{OK: true, Data: map[string]int{"cancelled": req.RouteID}})
-			} else {
-				writeErr(w, http.StatusNotFound, "route not found")
-			}
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET, POST, or DELETE")
-		}
-	})
-
-	mux.HandleFunc("/api/expansion", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetExpansionTargets(getProvider(), getAuthPlayer(r))})
-	})
-
-	mux.HandleFunc("/api/deliveries", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		p := getProvider()
-		dm := p.GetDeliveryManager()
-		if dm == nil {
-			writeJSON(w, APIResponse{OK: true, Data: []interface{}{}})
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: dm.GetActiveDeliveries()})
-	})
-
-	mux.HandleFunc("/api/power", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetPowerGrid(getProvider())})
-	})
-
-	mux.HandleFunc("/api/leaderboard", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetLeaderboard(getProvider())})
-	})
-
-	mux.HandleFunc("/api/defense", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetDefense(getProvider())})
-	})
-
-	mux.HandleFunc("/api/stations", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetStations(getProvider())})
-	})
-
-	mux.HandleFunc("/api/status", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetStatus(getProvider(), getAuthPlayer(r))})
-	})
-
-	mux.HandleFunc("/api/game", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetGame(getProvider())})
-	})
-
-	mux.HandleFunc("/api/diagnostics", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		if !isAdmin(r) {
-			writeErr(w, http.StatusForbidden, "admin only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetDiagnostics(getProvider())})
-	})
-
-	mux.HandleFunc("/api/build", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req BuildRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.PlanetID <= 0 || req.BuildingType == "" {
-			writeErr(w, http.StatusBadRequest, "planet_id and building_type required")
-			return
-		}
-
-		p := getProvider()
-		cmd := newCommand(r, game.CmdBuild, game.BuildCommandData{
-			PlanetID:     req.PlanetID,
-			BuildingType: req.BuildingType,
-			ResourceID:   req.ResourceID,
-		})
-		p.GetCommandChannel() <- cmd
-
-		select {
-		case result := <-cmd.Result:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "build command timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/economy", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetEconomy(getProvider())})
-	})
-
-	mux.HandleFunc("/api/ships", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		result := handleGetShips(getProvider())
-		filterOwner := r.URL.Query().Get("owner")
-		if filterOwner != "" {
-			if ships, ok := result.([]ShipInfo); ok {
-				filtered := make([]ShipInfo, 0)
-				for _, s := range ships {
-					if strings.EqualFold(s.Owner, filterOwner) {
-						filtered = append(filtered, s)
-					}
-				}
-				result = filtered
-			}
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
-
-	mux.HandleFunc("/api/fleets", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetFleets(getProvider())})
-	})
-
-	mux.HandleFunc("/api/prices", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetSystemPrices(getProvider())})
-	})
-
-	mux.HandleFunc("/api/planets/rates/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		idStr := strings.TrimPrefix(r.URL.Path, "/api/planets/rates/")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid planet ID")
-			return
-		}
-		data, found := handleGetPlanetRates(getProvider(), id)
-		if !found {
-			writeErr(w, http.StatusNotFound, "planet not found")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: data})
-	})
-
-	mux.HandleFunc("/api/planets/storage/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		idStr := strings.TrimPrefix(r.URL.Path, "/api/planets/storage/")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid planet ID")
-			return
-		}
-		data, found := handleGetPlanetStorage(getProvider(), id)
-		if !found {
-			writeErr(w, http.StatusNotFound, "planet not found")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: data})
-	})
-
-	mux.HandleFunc("/api/ships/build", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req ShipBuildRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.PlanetID <= 0 || req.ShipType == "" {
-			writeErr(w, http.StatusBadRequest, "planet_id and ship_type required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "build_ship",
-			Data:   game.ShipBuildCommandData{PlanetID: req.PlanetID, ShipType: req.ShipType},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/ships/move", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req ShipMoveRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ShipID <= 0 || req.TargetSystemID < 0 {
-			writeErr(w, http.StatusBadRequest, "ship_id and target_system_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "move_ship",
-			Data:   game.ShipMoveCommandData{ShipID: req.ShipID, TargetSystemID: req.TargetSystemID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/ships/refuel", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req ShipRefuelRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "refuel",
-			Data:   game.ShipRefuelCommandData{ShipID: req.ShipID, PlanetID: req.PlanetID, Amount: req.Amount},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/colonize", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req ColonizeRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "colonize",
-			Data:   game.ColonizeCommandData{ShipID: req.ShipID, PlanetID: req.PlanetID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/ships/transfer-fuel", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req struct {
-			FromShipID int `json:"from_ship_id"`
-			ToShipID   int `json:"to_ship_id"`
-			Amount     int `json:"amount"` // 0 = fill up target
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		p := getProvider()
-		cmd := newCommand(r, game.CmdTransferFuel, game.TransferFuelCommandData{
-			FromShipID: req.FromShipID,
-			ToShipID:   req.ToShipID,
-			Amount:     req.Amount,
-		})
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-cmd.Result:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/demolish", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req struct {
-			PlanetID      int `json:"planet_id"`
-			BuildingIndex int `json:"building_index"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		p := getProvider()
-		cmd := newCommand(r, game.CmdDemolish, game.DemolishCommandData{
-			PlanetID:      req.PlanetID,
-			BuildingIndex: req.BuildingIndex,
-		})
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-cmd.Result:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/upgrade", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req UpgradeRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "upgrade",
-			Data:   game.UpgradeCommandData{PlanetID: req.PlanetID, BuildingIndex: req.BuildingIndex},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/market/prices", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		p := getProvider()
-		market := p.GetMarket()
-		if market == nil {
-			writeJSON(w, APIResponse{OK: true, Data: map[string][]float64{}})
-			return
-		}
-		snap := market.GetSnapshot()
-		result := make(map[string][]float64)
-		for name, rm := range snap.Resources {
-			result[name] = rm.PriceHistory
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
-
-	mux.HandleFunc("/api/events", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		limit := 30
-		if l := r.URL.Query().Get("limit"); l != "" {
-			if n, err := strconv.Atoi(l); err == nil && n > 0 {
-				limit = n
-			}
-		}
-		p := getProvider()
-		el := p.GetEventLog()
-		if el == nil {
-			writeJSON(w, APIResponse{OK: true, Data: []game.GameEvent{}})
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: el.Recent(limit)})
-	})
-
-	mux.HandleFunc("/api/deposits", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		filterResource := r.URL.Query().Get("resource")
-		filterUnmined := r.URL.Query().Get("unmined") == "true"
-		filterOwner := r.URL.Query().Get("owner")
-		writeJSON(w, APIResponse{OK: true, Data: handleGetDeposits(getProvider(), filterResource, filterUnmined, filterOwner)})
-	})
-
-	mux.HandleFunc("/api/planets/workforce/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		idStr := strings.TrimPrefix(r.URL.Path, "/api/planets/workforce/")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid planet ID")
-			return
-		}
-		data, found := handleGetWorkforce(getProvider(), id)
-		if !found {
-			writeErr(w, http.StatusNotFound, "planet not found")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: data})
-	})
-
-	mux.HandleFunc("/api/flows", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetGalaxyFlows(getProvider())})
-	})
-
-	mux.HandleFunc("/api/catalog", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetCatalog()})
-	})
-
-	mux.HandleFunc("/api/workforce/assign", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req WorkforceAssignRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "workforce_assign",
-			Data:   game.WorkforceAssignCommandData{PlanetID: req.PlanetID, BuildingIndex: req.BuildingIndex, Workers: req.Workers},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/construction/cancel", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req CancelConstructionRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ConstructionID == "" {
-			writeErr(w, http.StatusBadRequest, "construction_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "cancel_construction",
-			Data:   game.CancelConstructionCommandData{ConstructionID: req.ConstructionID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/construction", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		writeJSON(w, APIResponse{OK: true, Data: handleGetConstructionQueue(getProvider())})
-	})
-
-	// Fleet management endpoints
-	mux.HandleFunc("/api/fleets/move", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req FleetMoveRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.FleetID <= 0 || req.TargetSystemID < 0 {
-			writeErr(w, http.StatusBadRequest, "fleet_id and target_system_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "fleet_move",
-			Data:   game.FleetMoveCommandData{FleetID: req.FleetID, TargetSystemID: req.TargetSystemID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/fleets/create", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req FleetCreateRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ShipID <= 0 {
-			writeErr(w, http.StatusBadRequest, "ship_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "fleet_create",
-			Data:   game.FleetCreateCommandData{ShipID: req.ShipID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/fleets/disband", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req FleetDisbandRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.FleetID <= 0 {
-			writeErr(w, http.StatusBadRequest, "fleet_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "fleet_disband",
-			Data:   game.FleetDisbandCommandData{FleetID: req.FleetID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/fleets/add-ship", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req FleetAddShipRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ShipID <= 0 || req.FleetID <= 0 {
-			writeErr(w, http.StatusBadRequest, "ship_id and fleet_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "fleet_add_ship",
-			Data:   game.FleetAddShipCommandData{ShipID: req.ShipID, FleetID: req.FleetID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	mux.HandleFunc("/api/fleets/remove-ship", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req FleetRemoveShipRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		if req.ShipID <= 0 || req.FleetID <= 0 {
-			writeErr(w, http.StatusBadRequest, "ship_id and fleet_id required")
-			return
-		}
-		p := getProvider()
-		resultCh := make(chan interface{}, 1)
-		cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-			Type:   "fleet_remove_ship",
-			Data:   game.FleetRemoveShipCommandData{ShipID: req.ShipID, FleetID: req.FleetID},
-			Result: resultCh,
-		}
-		p.GetCommandChannel() <- cmd
-		select {
-		case result := <-resultCh:
-			switch v := result.(type) {
-			case error:
-				writeErr(w, http.StatusBadRequest, v.Error())
-			default:
-				writeJSON(w, APIResponse{OK: true, Data: v})
-			}
-		case <-time.After(5 * time.Second):
-			writeErr(w, http.StatusGatewayTimeout, "timed out")
-		}
-	})
-
-	// Hyperlane/route info
-	mux.HandleFunc("/api/routes/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		idStr := strings.TrimPrefix(r.URL.Path, "/api/routes/")
-		id, err := strconv.Atoi(idStr)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid system ID")
-			return
-		}
-		p := getProvider()
-		connected := make([]int, 0)
-		for _, hl := range p.GetHyperlanes() {
-			if hl.From == id {
-				connected = append(connected, hl.To)
-			} else if hl.To == id {
-				connected = append(connected, hl.From)
-			}
-		}
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"system_id": id,
-			"connected": connected,
-		}})
-	})
-
-	mux.HandleFunc("/api/game/speed", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req SpeedRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid JSON: "+err.Error())
-			return
-		}
-		speed, ok := parseSpeed(req.Speed)
-		if !ok {
-			writeErr(w, http.StatusBadRequest, "invalid speed; use: slow, normal, fast, very_fast")
-			return
-		}
-		ch := getProvider().GetCommandChannel()
-		ch <- game.GameCommand{Type: "set_speed", Data: speed}
-		writeJSON(w, APIResponse{OK: true, Data: map[string]string{"speed": req.Speed}})
-	})
-
-	mux.HandleFunc("/api/game/pause", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		ch := getProvider().GetCommandChannel()
-		ch <- game.GameCommand{Type: "toggle_pause"}
-		writeJSON(w, APIResponse{OK: true, Data: map[string]string{"action": "toggled"}})
-	})
-
-	mux.HandleFunc("/api/game/save", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		p := getProvider()
-		human := p.GetHumanPlayer()
-		name := "Player"
-		if human != nil {
-			name = human.Name
-		}
-		ch := p.GetCommandChannel()
-		ch <- game.GameCommand{Type: "save", Data: name}
-		writeJSON(w, APIResponse{OK: true, Data: map[string]string{"action": "save_queued"}})
-	})
-
-	// Discord OAuth2
-	discordSecret := os.Getenv("DISCORD_CLIENT_SECRET")
-	baseURL := os.Getenv("BASE_URL")
-	if baseURL == "" {
-		baseURL = "http://localhost:8080"
-	}
-	frontendURL := os.Getenv("FRONTEND_URL")
-	if frontendURL == "" {
-		frontendURL = "https://hunterjsb.github.io/xandaris"
-	}
-	redirectURI := baseURL + "/api/auth/discord/callback"
-
-	mux.HandleFunc("/api/auth/discord", func(w http.ResponseWriter, r *http.Request) {
-		// Support local_callback for desktop OAuth flow
-		state := r.URL.Query().Get("local_callback")
-		authURL := fmt.Sprintf(
-			"https://discord.com/api/oauth2/authorize?client_id=%s&redirect_uri=%s&response_type=code&scope=identify&state=%s",
-			discordClientID, url.QueryEscape(redirectURI), url.QueryEscape(state),
-		)
-		http.Redirect(w, r, authURL, http.StatusTemporaryRedirect)
-	})
-
-	mux.HandleFunc("/api/auth/discord/callback", func(w http.ResponseWriter, r *http.Request) {
-		code := r.URL.Query().Get("code")
-		if code == "" {
-			writeErr(w, http.StatusBadRequest, "missing code parameter")
-			return
-		}
-
-		// Exchange code for access token
-		tokenResp, err := http.PostForm("https://discord.com/api/oauth2/token", url.Values{
-			"client_id":     {discordClientID},
-			"client_secret": {discordSecret},
-			"grant_type":    {"authorization_code"},
-			"code":          {code},
-			"redirect_uri":  {redirectURI},
-		})
-		if err != nil {
-			writeErr(w, http.StatusBadGateway, "failed to contact Discord")
-			return
-		}
-		defer tokenResp.Body.Close()
-
-		tokenBody, _ := io.ReadAll(tokenResp.Body)
-		fmt.Printf("[Auth] Discord token response (%d): %s\n", tokenResp.StatusCode, string(tokenBody))
-
-		var tokenData struct {
-			AccessToken string `json:"access_token"`
-			TokenType   string `json:"token_type"`
-			Error       string `json:"error"`
-			ErrorDesc   string `json:"error_description"`
-		}
-		if err := json.Unmarshal(tokenBody, &tokenData); err != nil || tokenData.AccessToken == "" {
-			errMsg := "failed to get Discord token"
-			if tokenData.Error != "" {
-				errMsg = tokenData.Error + ": " + tokenData.ErrorDesc
-			}
-			writeErr(w, http.StatusBadGateway, errMsg)
-			return
-		}
-
-		// Fetch Discord user info
-		userReq, _ := http.NewRequest("GET", "https://discord.com/api/users/@me", nil)
-		userReq.Header.Set("Authorization", "Bearer "+tokenData.AccessToken)
-		userResp, err := http.DefaultClient.Do(userReq)
-		if err != nil {
-			writeErr(w, http.StatusBadGateway, "failed to fetch Discord user")
-			return
-		}
-		defer userResp.Body.Close()
-
-		body, _ := io.ReadAll(userResp.Body)
-		var discordUser struct {
-			ID       string `json:"id"`
-			Username string `json:"username"`
-		}
-		if err := json.Unmarshal(body, &discordUser); err != nil || discordUser.ID == "" {
-			writeErr(w, http.StatusBadGateway, "failed to parse Discord user")
-			return
-		}
-
-		// Find or create account
-		p := getProvider()
-		registry := p.GetRegistry()
-		if registry == nil {
-			writeErr(w, http.StatusInternalServerError, "auth not available")
-			return
-		}
-
-		account, isNew, err := registry.FindOrCreateByDiscord(discordUser.ID, discordUser.Username)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		// Create in-game faction for new players
-		if isNew {
-			resultCh := make(chan interface{}, 1)
-			cmd := game.GameCommand{PlayerName: getAuthPlayer(r),
-				Type:   "register_player",
-				Data:   game.RegisterPlayerCommandData{Name: account.Name, AccountKey: account.APIKey},
-				Result: resultCh,
-			}
-		p.GetCommandChannel() <- cmd
-			select {
-			case result := <-resultCh:
-				if pid, ok := result.(int); ok {
-					account.PlayerID = pid
-					registry.Save() // persist player ID
-				}
-			case <-time.After(5 * time.Second):
-				// faction creation timed out, but account exists
-			}
-		}
-
-		// Redirect with credentials
-		params := url.Values{
-			"key":       {account.APIKey},
-			"name":      {account.Name},
-			"player_id": {strconv.Itoa(account.PlayerID)},
-			"new":       {strconv.FormatBool(isNew)},
-		}
-
-		// Desktop OAuth: redirect to local callback with query params
-		localCallback := r.URL.Query().Get("state")
-		fmt.Printf("[Auth] Callback state=%q, account=%s, isNew=%v\n", localCallback, account.Name, isNew)
-		if localCallback != "" {
-			redirectTo := localCallback + "?" + params.Encode()
-			fmt.Printf("[Auth] Redirecting to local callback: %s\n", redirectTo)
-			http.Redirect(w, r, redirectTo, http.StatusTemporaryRedirect)
-		} else {
-			// Web OAuth: redirect to frontend with fragment (never sent to server)
-			http.Redirect(w, r, frontendURL+"/#"+params.Encode(), http.StatusTemporaryRedirect)
-		}
-	})
-
-	// Admin-only player registration (no Discord OAuth needed)
-	mux.HandleFunc("/api/admin/register", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		if !isAdmin(r) {
-			writeErr(w, http.StatusForbidden, "admin only")
-			return
-		}
-		var req struct {
-			Name string `json:"name"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
-			writeErr(w, http.StatusBadRequest, "name required")
-			return
-		}
-		p := getProvider()
-		registry := p.GetRegistry()
-		if registry == nil {
-			writeErr(w, http.StatusInternalServerError, "registry not available")
-			return
-		}
-		account, isNew, err := registry.FindOrCreateByName(req.Name)
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		if isNew {
-			resultCh := make(chan interface{}, 1)
-			cmd := game.GameCommand{
-				Type:       game.CmdRegisterPlayer,
-				Data:       game.RegisterPlayerCommandData{Name: account.Name, AccountKey: account.APIKey},
-				Result:     resultCh,
-				PlayerName: account.Name,
-			}
-			p.GetCommandChannel() <- cmd
-			select {
-			case result := <-resultCh:
-				if pid, ok := result.(int); ok {
-					account.PlayerID = pid
-					registry.Save()
-				}
-			case <-time.After(5 * time.Second):
-			}
-		}
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"name":      account.Name,
-			"api_key":   account.APIKey,
-			"player_id": account.PlayerID,
-			"new":       isNew,
-		}})
-	})
-
-	// Admin diagnostic: show mine-to-resource attachment details
-	mux.HandleFunc("/api/admin/diagnose", func(w http.ResponseWriter, r *http.Request) {
-		if !isAdmin(r) {
-			writeErr(w, http.StatusForbidden, "admin only")
-			return
-		}
-		playerName := r.URL.Query().Get("player")
-		p := getProvider()
-		player := findPlayer(p, playerName)
-		if player == nil {
-			writeErr(w, http.StatusNotFound, "player not found")
-			return
-		}
-		var result []map[string]interface{}
-		for _, planet := range player.OwnedPlanets {
-			if planet == nil {
-				continue
-			}
-			pInfo := map[string]interface{}{
-				"planet_id":   planet.GetID(),
-				"planet_name": planet.Name,
-				"owner":       planet.Owner,
-				"resources":   []map[string]interface{}{},
-				"mines":       []map[string]interface{}{},
-			}
-			for _, re := range planet.Resources {
-				if res, ok := re.(*entities.Resource); ok {
-					pInfo["resources"] = append(pInfo["resources"].([]map[string]interface{}), map[string]interface{}{
-						"id": res.GetID(), "type": res.ResourceType, "owner": res.Owner,
-						"abundance": res.Abundance, "rate": res.ExtractionRate,
-					})
-				}
-			}
-			for i, be := range planet.Buildings {
-				if b, ok := be.(*entities.Building); ok && b.BuildingType == "Mine" {
-					pInfo["mines"] = append(pInfo["mines"].([]map[string]interface{}), map[string]interface{}{
-						"index": i, "level": b.Level, "attached_to": b.AttachedTo,
-						"attachment_type": b.AttachmentType, "staffing": b.GetStaffingRatio(),
-						"operational": b.IsOperational, "owner": b.Owner,
-					})
-				}
-			}
-			result = append(result, pInfo)
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
-
-	// Admin-only player removal
-	mux.HandleFunc("/api/admin/remove-player", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		if !isAdmin(r) {
-			writeErr(w, http.StatusForbidden, "admin only")
-			return
-		}
-		var req struct {
-			Name string `json:"name"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Name == "" {
-			writeErr(w, http.StatusBadRequest, "name required")
-			return
-		}
-		p := getProvider()
-
-		result, err := handleRemovePlayer(p, req.Name)
-		if err != nil {
-			writeErr(w, http.StatusNotFound, err.Error())
-			return
-		}
-		// Also remove from auth registry
-		registry := p.GetRegistry()
-		if registry != nil {
-			registry.RemoveAccount(req.Name)
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
-
-	// Serve pages
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		switch r.URL.Path {
-		case "/":
-			fmt.Fprint(w, spectatorHTML)
-		case "/data":
-			fmt.Fprint(w, dashboardHTML)
-		case "/logistics":
-			fmt.Fprint(w, logisticsHTML)
-		default:
-			http.NotFound(w, r)
-		}
-	})
-
-	// LLM chat endpoint
-	registerChatEndpoint(mux, getProvider)
-
-	// Multiplayer chat
-	mux.HandleFunc("/api/chat/send", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req struct {
-			Message string `json:"message"`
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil || strings.TrimSpace(req.Message) == "" {
-			writeErr(w, http.StatusBadRequest, "message required")
-			return
-		}
-		player := getAuthPlayer(r)
-		if player == "" {
-			writeErr(w, http.StatusUnauthorized, "auth required")
-			return
-		}
-		p := getProvider()
-		tick, gameTime, _, _ := p.GetTickInfo()
-		chatLog := p.GetChatLog()
-		if chatLog == nil {
-			writeErr(w, http.StatusInternalServerError, "chat not available")
-			return
-		}
-		msg := strings.TrimSpace(req.Message)
-		if len(msg) > 200 {
-			msg = msg[:200]
-		}
-		chatLog.Send(tick, gameTime, player, msg)
-		writeJSON(w, APIResponse{OK: true, Data: map[string]string{"status": "sent"}})
-	})
-
-	mux.HandleFunc("/api/chat/messages", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		p := getProvider()
-		chatLog := p.GetChatLog()
-		if chatLog == nil {
-			writeJSON(w, APIResponse{OK: true, Data: []interface{}{}})
-			return
-		}
-		messages := chatLog.Recent(20)
-		// Reverse to chronological order
-		for i, j := 0, len(messages)-1; i < j; i, j = i+1, j-1 {
-			messages[i], messages[j] = messages[j], messages[i]
-		}
-		writeJSON(w, APIResponse{OK: true, Data: messages})
-	})
-
-	// Local market: what's available to trade in a specific system
-	mux.HandleFunc("/api/local-market/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		// Parse system ID from /api/local-market/{system_id}
-		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/local-market/"), "/")
-		sysID, err := strconv.Atoi(parts[0])
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid system_id")
-			return
-		}
-
-		p := getProvider()
-		playerName := getAuthPlayer(r)
-		_ = playerName
-		systems := p.GetSystems()
-
-		// Find all planets in this system with their stock
-		type PlanetStock struct {
-			PlanetID   int            `json:"planet_id"`
-			PlanetName string         `json:"planet_name"`
-			Owner      string         `json:"owner"`
-			HasTP      bool           `json:"has_trading_post"`
-			Stock      map[string]int `json:"stock"`
-		}
-
-		var result struct {
-			SystemID     int           `json:"system_id"`
-			Planets      []PlanetStock `json:"planets"`
-			YourPlanets  []int         `json:"your_planets"`
-			BuyableStock map[string]int `json:"buyable_stock"` // total stock on OTHER players' planets
-		}
-		result.SystemID = sysID
-		result.BuyableStock = make(map[string]int)
-
-		for _, sys := range systems {
-			if sys.ID != sysID {
-				continue
-			}
-			for _, e := range sys.Entities {
-				planet, ok := e.(*entities.Planet)
-				if !ok || planet.Owner == "" {
-					continue
-				}
-
-				stock := make(map[string]int)
-				for resType, s := range planet.StoredResources {
-					if s != nil && s.Amount > 0 {
-						stock[resType] = s.Amount
-					}
-				}
-
-				hasTP := false
-				for _, be := range planet.Buildings {
-					if b, ok := be.(*entities.Building); ok && b.BuildingType == entities.BuildingTradingPost && b.IsOperational {
-						hasTP = true
-						break
-					}
-				}
-
-				ps := PlanetStock{
-					PlanetID: planet.GetID(), PlanetName: planet.Name,
-					Owner: planet.Owner, HasTP: hasTP, Stock: stock,
-				}
-				result.Planets = append(result.Planets, ps)
-
-				if planet.Owner == playerName {
-					result.YourPlanets = append(result.YourPlanets, planet.GetID())
-				} else {
-					// Stock on other players' planets = what you can buy
-					for resType, amt := range stock {
-						result.BuyableStock[resType] += amt
-					}
-				}
-			}
-			break
-		}
-
-		// Add market prices for context
-		market := p.GetMarket()
-		type PricedStock struct {
-			Resource  string  `json:"resource"`
-			Available int     `json:"available"`
-			BuyPrice  float64 `json:"buy_price"`
-			SellPrice float64 `json:"sell_price"`
-		}
-		var priced []PricedStock
-		if market != nil {
-			for res, amt := range result.BuyableStock {
-				baseBuy := market.GetBuyPrice(res)
-				baseSell := market.GetSellPrice(res)
-				localBuyMult := economy.LocalPriceMultiplier(amt, 0)
-				localSellMult := economy.LocalSellPriceMultiplier(amt)
-				priced = append(priced, PricedStock{
-					Resource: res, Available: amt,
-					BuyPrice: baseBuy * localBuyMult, SellPrice: baseSell * localSellMult,
-				})
-			}
-		}
-
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"system_id":     result.SystemID,
-			"planets":       result.Planets,
-			"your_planets":  result.YourPlanets,
-			"buyable_stock": priced,
-		}})
-	})
-
-	// Player economic summary — income, expenses, net flow
-	mux.HandleFunc("/api/economy/summary", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		p := getProvider()
-		playerName := getAuthPlayer(r)
-		player := findPlayer(p, playerName)
-		if player == nil {
-			writeErr(w, http.StatusNotFound, "player not found")
-			return
-		}
-
-		// Calculate income sources
-		laborIncome := 0
-		domesticIncome := 0
-		tpIncome := 0
-		totalUpkeep := 0
-		popAdmin := 0
-		totalPop := int64(0)
-
-		for _, planet := range player.OwnedPlanets {
-			if planet == nil {
-				continue
-			}
-			pop := planet.Population
-			totalPop += pop
-
-			// Labor (matches credit_production.go)
-			labor := int(float64(pop/50) * planet.ProductivityBonus)
-			if labor < 10 && pop > 0 {
-				labor = 10
-			}
-			laborIncome += labor
-
-			// Domestic (rough estimate)
-			domestic := int(float64(pop) / 1000 * 8) // simplified
-			domesticIncome += domestic
-
-			// TP revenue
-			for _, be := range planet.Buildings {
-				if b, ok := be.(*entities.Building); ok {
-					if b.BuildingType == entities.BuildingTradingPost && b.IsOperational {
-						tpIncome += 2 * b.Level
-					}
-					// Upkeep
-					upkeep := map[string]int{
-						"Fusion Reactor": 2, "Refinery": 3, "Factory": 5, "Shipyard": 6,
-					}
-					if cost, ok := upkeep[b.BuildingType]; ok && b.IsOperational {
-						totalUpkeep += cost + (b.Level - 1)
-					}
-				}
-			}
-
-			popAdmin += int(pop / 1000)
-		}
-
-		// Resource diversity per planet
-		type PlanetDiversity struct {
-			PlanetID     int      `json:"planet_id"`
-			Name         string   `json:"name"`
-			TypesStocked int      `json:"types_stocked"`
-			Multiplier   float64  `json:"multiplier"`
-			Missing      []string `json:"missing"`
-		}
-		var diversity []PlanetDiversity
-		allResources := []string{"Water", "Iron", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
-		for _, planet := range player.OwnedPlanets {
-			if planet == nil {
-				continue
-			}
-			stocked := 0
-			var missing []string
-			for _, res := range allResources {
-				if planet.GetStoredAmount(res) > 0 {
-					stocked++
-				} else {
-					missing = append(missing, res)
-				}
-			}
-			mult := 1.0
-			if stocked >= 7 {
-				mult = 3.0
-			} else if stocked >= 5 {
-				mult = 2.0
-			} else if stocked >= 3 {
-				mult = 1.5
-			}
-			diversity = append(diversity, PlanetDiversity{
-				PlanetID: planet.GetID(), Name: planet.Name,
-				TypesStocked: stocked, Multiplier: mult, Missing: missing,
-			})
-		}
-
-		totalIncome := laborIncome + domesticIncome + tpIncome
-		totalExpenses := totalUpkeep + popAdmin
-		netFlow := totalIncome - totalExpenses
-
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"player":          player.Name,
-			"credits":         player.Credits,
-			"population":      totalPop,
-			"planets":         len(player.OwnedPlanets),
-			"income_per_tick": map[string]int{
-				"labor":    laborIncome,
-				"domestic": domesticIncome,
-				"trading":  tpIncome,
-				"total":    totalIncome,
-			},
-			"expenses_per_tick": map[string]int{
-				"building_upkeep": totalUpkeep,
-				"administration":  popAdmin,
-				"total":           totalExpenses,
-			},
-			"net_flow_per_tick": netFlow,
-			"profitable":       netFlow > 0,
-			"diversity":        diversity,
-		}})
-	})
-
-	// Per-planet resource flows: what's being produced and consumed
-	mux.HandleFunc("/api/planet-flows/", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/api/planet-flows/"), "/")
-		planetID, err := strconv.Atoi(parts[0])
-		if err != nil {
-			writeErr(w, http.StatusBadRequest, "invalid planet_id")
-			return
-		}
-
-		p := getProvider()
-		systems := p.GetSystems()
-		market := p.GetMarket()
-
-		// Find the planet
-		var planet *entities.Planet
-		for _, sys := range systems {
-			for _, e := range sys.Entities {
-				if pl, ok := e.(*entities.Planet); ok && pl.GetID() == planetID {
-					planet = pl
-					break
-				}
-			}
-			if planet != nil {
-				break
-			}
-		}
-		if planet == nil {
-			writeErr(w, http.StatusNotFound, "planet not found")
-			return
-		}
-
-		type ResourceFlow struct {
-			Resource    string  `json:"resource"`
-			Stored      int     `json:"stored"`
-			Production  float64 `json:"production"`  // per interval from mines
-			PopDrain    float64 `json:"pop_drain"`    // consumed by population
-			BuildDrain  float64 `json:"build_drain"`  // consumed by buildings (refinery, generator, etc)
-			NetFlow     float64 `json:"net_flow"`
-			TicksUntilEmpty int `json:"ticks_until_empty"` // at current drain rate, 0=stable/growing
-		}
-
-		pop := float64(planet.Population)
-		var flows []ResourceFlow
-
-		allRes := []string{"Water", "Iron", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
-		for _, res := range allRes {
-			stored := planet.GetStoredAmount(res)
-			production := 0.0
-			popDrain := 0.0
-			buildDrain := 0.0
-
-			// Population consumption
-			for _, rate := range economy.PopulationConsumption {
-				if rate.ResourceType == res {
-					popDrain = pop / rate.PopDivisor * rate.PerPopulation
-				}
-			}
-
-			// Building consumption
-			for _, be := range planet.Buildings {
-				b, ok := be.(*entities.Building)
-				if !ok || !b.IsOperational || b.GetStaffingRatio() <= 0 {
-					continue
-				}
-				if upkeeps, found := economy.BuildingResourceUpkeep[b.BuildingType]; found {
-					for _, u := range upkeeps {
-						if u.ResourceType == res {
-							buildDrain += float64(u.Amount)
-						}
-					}
-				}
-				// Power system fuel/he3 burn
-				if b.BuildingType == entities.BuildingGenerator && res == "Fuel" {
-					levelMult := 1.0 + float64(b.Level-1)*0.3
-					buildDrain += 2.0 * levelMult
-				}
-				if b.BuildingType == entities.BuildingFusionReactor && res == "Helium-3" {
-					levelMult := 1.0 + float64(b.Level-1)*0.3
-					buildDrain += 1.0 * levelMult
-				}
-				// Refinery consumes Oil
-				if b.BuildingType == entities.BuildingRefinery && res == "Oil" {
-					levelMult := 1.0 + float64(b.Level-1)*0.3
-					buildDrain += 2.0 * levelMult
-				}
-				// Refinery produces Fuel
-				if b.BuildingType == entities.BuildingRefinery && res == "Fuel" {
-					levelMult := 1.0 + float64(b.Level-1)*0.3
-					production += 3.0 * levelMult
-				}
-				// Factory consumes RM + Iron, produces Electronics
-				if b.BuildingType == entities.BuildingFactory {
-					levelMult := 1.0 + float64(b.Level-1)*0.3
-					staffing := b.GetStaffingRatio()
-					if res == "Rare Metals" {
-						buildDrain += 2.0 * levelMult * staffing
-					}
-					if res == "Iron" {
-						buildDrain += 1.0 * levelMult * staffing
-					}
-					if res == "Electronics" {
-						production += 2.0 * levelMult * staffing
-					}
-				}
-			}
-
-			// Mine production (estimate from deposits)
-			for _, re := range planet.Resources {
-				if resource, ok := re.(*entities.Resource); ok && resource.ResourceType == res {
-					for _, be := range planet.Buildings {
-						if b, ok := be.(*entities.Building); ok && b.BuildingType == "Mine" && b.IsOperational {
-							resID := fmt.Sprintf("%d", resource.GetID())
-							if b.AttachedTo == resID && b.GetStaffingRatio() > 0 {
-								rate := 8.0 * resource.ExtractionRate * b.GetStaffingRatio() * b.ProductionBonus
-								abFactor := float64(resource.Abundance) / 70.0
-								if abFactor > 1.0 {
-									abFactor = 1.0
-								}
-								powerFactor := 0.25 + 0.75*planet.GetPowerRatio()
-								production += rate * abFactor * powerFactor
-							}
-						}
-					}
-				}
-			}
-
-			netFlow := production - popDrain - buildDrain
-			tte := 0
-			if netFlow < 0 && stored > 0 {
-				tte = int(float64(stored) / (-netFlow))
-			}
-
-			flows = append(flows, ResourceFlow{
-				Resource: res, Stored: stored,
-				Production: math.Round(production*10) / 10,
-				PopDrain: math.Round(popDrain*10) / 10,
-				BuildDrain: math.Round(buildDrain*10) / 10,
-				NetFlow: math.Round(netFlow*10) / 10,
-				TicksUntilEmpty: tte,
-			})
-		}
-
-		_ = market // available if needed for price context
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"planet_id":   planet.GetID(),
-			"planet_name": planet.Name,
-			"population":  planet.Population,
-			"power_ratio": planet.GetPowerRatio(),
-			"flows":       flows,
-		}})
-	})
-
-	// Order book: limit buy/sell orders per system
-	mux.HandleFunc("/api/orders/limit", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		ob := p.GetOrderBook()
-		if ob == nil {
-			writeErr(w, http.StatusInternalServerError, "order book not available")
-			return
-		}
-
-		switch r.Method {
-		case http.MethodGet:
-			sysStr := r.URL.Query().Get("system_id")
-			sysID, _ := strconv.Atoi(sysStr)
-			resource := r.URL.Query().Get("resource")
-			playerName := getAuthPlayer(r)
-
-			var orders []*economy.MarketOrder
-			if playerName != "" && sysStr == "" {
-				orders = ob.GetPlayerOrders(playerName)
-			} else {
-				orders = ob.GetOrders(sysID, resource)
-			}
-			writeJSON(w, APIResponse{OK: true, Data: orders})
-
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				SystemID int    `json:"system_id"`
-				PlanetID int    `json:"planet_id"`
-				Resource string `json:"resource"`
-				Action   string `json:"action"` // "buy" or "sell"
-				Quantity int    `json:"quantity"`
-				Price    int    `json:"price"` // limit price
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			if req.Action != "buy" && req.Action != "sell" {
-				writeErr(w, http.StatusBadRequest, "action must be 'buy' or 'sell'")
-				return
-			}
-			if req.Quantity <= 0 || req.Price <= 0 {
-				writeErr(w, http.StatusBadRequest, "quantity and price must be positive")
-				return
-			}
-			order := ob.PlaceOrder(req.SystemID, req.PlanetID, playerName, req.Resource, req.Action, req.Quantity, req.Price)
-			writeJSON(w, APIResponse{OK: true, Data: order})
-
-		case http.MethodDelete:
-			playerName := getAuthPlayer(r)
-			orderID, _ := strconv.Atoi(r.URL.Query().Get("order_id"))
-			if ob.CancelOrder(orderID, playerName) {
-				writeJSON(w, APIResponse{OK: true, Data: "cancelled"})
-			} else {
-				writeErr(w, http.StatusNotFound, "order not found")
-			}
-
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET, POST, or DELETE")
-		}
-	})
-
-	// Trade contracts: binding supply agreements
-	mux.HandleFunc("/api/contracts", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		cm := p.GetContractManager()
-		if cm == nil {
-			writeErr(w, http.StatusInternalServerError, "contracts not available")
-			return
-		}
-
-		switch r.Method {
-		case http.MethodGet:
-			playerName := getAuthPlayer(r)
-			contracts := cm.GetActiveContracts(playerName)
-			writeJSON(w, APIResponse{OK: true, Data: contracts})
-
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				Buyer        string `json:"buyer"`
-				Resource     string `json:"resource"`
-				Quantity     int    `json:"quantity"`
-				PricePerUnit int    `json:"price_per_unit"`
-				Interval     int    `json:"interval"` // ticks between deliveries
-				SystemID     int    `json:"system_id"`
-				PlanetID     int    `json:"planet_id"` // buyer's delivery planet
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			if req.Quantity <= 0 || req.PricePerUnit <= 0 || req.Interval <= 0 {
-				writeErr(w, http.StatusBadRequest, "quantity, price, and interval must be positive")
-				return
-			}
-			contract := cm.CreateContract(playerName, req.Buyer, req.Resource, req.Quantity, req.PricePerUnit, req.Interval, req.SystemID, req.PlanetID)
-			writeJSON(w, APIResponse{OK: true, Data: contract})
-
-		case http.MethodDelete:
-			playerName := getAuthPlayer(r)
-			contractID, _ := strconv.Atoi(r.URL.Query().Get("contract_id"))
-			if cm.CancelContract(contractID, playerName) {
-				writeJSON(w, APIResponse{OK: true, Data: "cancelled"})
-			} else {
-				writeErr(w, http.StatusNotFound, "contract not found or not yours")
-			}
-
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET, POST, or DELETE")
-		}
-	})
-
-	// Economy report: narrative summary of the galactic economy
-	mux.HandleFunc("/api/economy/report", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		p := getProvider()
-		market := p.GetMarket()
-		players := p.GetPlayers()
-		systems := p.GetSystems()
-		ob := p.GetOrderBook()
-
-		// GDP
-		totalCredits := 0
-		for _, pl := range players {
-			if pl != nil {
-				totalCredits += pl.Credits
-			}
-		}
-
-		// Resource scarcity analysis
-		type ResourceInfo struct {
-			Resource    string  `json:"resource"`
-			TotalSupply int     `json:"total_supply"`
-			PriceRatio  float64 `json:"price_ratio"` // current/base
-			Scarcity    string  `json:"scarcity"`
-			BuyDemand   int     `json:"buy_demand"`  // from order book
-			SellSupply  int     `json:"sell_supply"`  // from order book
-		}
-
-		resources := []string{"Water", "Iron", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
-		var resInfo []ResourceInfo
-		for _, res := range resources {
-			supply := 0
-			for _, sys := range systems {
-				for _, e := range sys.Entities {
-					if planet, ok := e.(*entities.Planet); ok && planet.Owner != "" {
-						supply += planet.GetStoredAmount(res)
-					}
-				}
-			}
-
-			priceRatio := 0.0
-			if market != nil {
-				base := economy.GetBasePrice(res)
-				if base > 0 {
-					priceRatio = market.GetBuyPrice(res) / base
-				}
-			}
-
-			scarcity := "Abundant"
-			if priceRatio > 3.0 {
-				scarcity = "Critical"
-			} else if priceRatio > 1.5 {
-				scarcity = "Scarce"
-			} else if priceRatio > 0.8 {
-				scarcity = "Normal"
-			} else {
-				scarcity = "Oversupplied"
-			}
-
-			buyDemand := 0
-			sellSupply := 0
-			if ob != nil {
-				for _, sys := range systems {
-					for _, o := range ob.GetOrders(sys.ID, res) {
-						if o.Action == "buy" {
-							buyDemand += o.Quantity
-						} else {
-							sellSupply += o.Quantity
-						}
-					}
-				}
-			}
-
-			resInfo = append(resInfo, ResourceInfo{
-				Resource: res, TotalSupply: supply, PriceRatio: priceRatio,
-				Scarcity: scarcity, BuyDemand: buyDemand, SellSupply: sellSupply,
-			})
-		}
-
-		// Top traders
-		type FactionSummary struct {
-			Name    string `json:"name"`
-			Credits int    `json:"credits"`
-			Planets int    `json:"planets"`
-			Ships   int    `json:"ships"`
-		}
-		var factions []FactionSummary
-		for _, pl := range players {
-			if pl == nil {
-				continue
-			}
-			ships := 0
-			for _, s := range pl.OwnedShips {
-				if s != nil {
-					ships++
-				}
-			}
-			factions = append(factions, FactionSummary{
-				Name: pl.Name, Credits: pl.Credits,
-				Planets: len(pl.OwnedPlanets), Ships: ships,
-			})
-		}
-
-		// Shipping activity
-		sm := p.GetShippingManager()
-		activeRoutes := 0
-		totalTrips := 0
-		if sm != nil {
-			for _, r := range sm.GetRoutes("") {
-				if r.Active {
-					activeRoutes++
-				}
-				totalTrips += r.TripsComplete
-			}
-		}
-
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"gdp":            totalCredits,
-			"resources":      resInfo,
-			"factions":       factions,
-			"active_routes":  activeRoutes,
-			"total_trips":    totalTrips,
-		}})
-	})
-
-	// Diplomacy: view and manage faction relations
-	mux.HandleFunc("/api/diplomacy", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		dm := p.GetDiplomacyManager()
-		if dm == nil {
-			writeErr(w, http.StatusInternalServerError, "diplomacy not available")
-			return
-		}
-
-		switch r.Method {
-		case http.MethodGet:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				// Return all relations overview
-				players := p.GetPlayers()
-				overview := make(map[string]map[string]string)
-				for _, pl := range players {
-					if pl == nil {
-						continue
-					}
-					rels := dm.GetAllRelations(pl.Name)
-					named := make(map[string]string)
-					for k, v := range rels {
-						named[k] = economy.RelationName(v)
-					}
-					if len(named) > 0 {
-						overview[pl.Name] = named
-					}
-				}
-				writeJSON(w, APIResponse{OK: true, Data: overview})
-			} else {
-				rels := dm.GetAllRelations(playerName)
-				named := make(map[string]string)
-				for k, v := range rels {
-					named[k] = economy.RelationName(v)
-				}
-				writeJSON(w, APIResponse{OK: true, Data: named})
-			}
-
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				Target string `json:"target"`
-				Action string `json:"action"` // "improve" or "degrade"
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			var newLevel int
-			switch req.Action {
-			case "improve":
-				newLevel = dm.ImproveRelation(playerName, req.Target)
-			case "degrade":
-				newLevel = dm.DegradeRelation(playerName, req.Target)
-			default:
-				writeErr(w, http.StatusBadRequest, "action must be 'improve' or 'degrade'")
-				return
-			}
-			writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-				"you":      playerName,
-				"target":   req.Target,
-				"relation": economy.RelationName(newLevel),
-				"level":    newLevel,
-			}})
-
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET or POST")
-		}
-	})
-
-	// Espionage: launch spy operations
-	mux.HandleFunc("/api/espionage", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		em := p.GetEspionageManager()
-		if em == nil {
-			writeErr(w, http.StatusInternalServerError, "espionage not available")
-			return
-		}
-		switch r.Method {
-		case http.MethodGet:
-			playerName := getAuthPlayer(r)
-			writeJSON(w, APIResponse{OK: true, Data: em.GetActiveOps(playerName)})
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				Target   string `json:"target"`
-				Type     string `json:"type"`
-				SystemID int    `json:"system_id"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			costs := map[string]int{"intel": 500, "sabotage": 2000, "steal_tech": 5000}
-			durations := map[string]int{"intel": 200, "sabotage": 500, "steal_tech": 1000}
-			cost, ok := costs[req.Type]
-			if !ok {
-				writeErr(w, http.StatusBadRequest, "type must be 'intel', 'sabotage', or 'steal_tech'")
-				return
-			}
-			player := findPlayer(p, playerName)
-			if player == nil || player.Credits < cost {
-				writeErr(w, http.StatusBadRequest, fmt.Sprintf("need %d credits", cost))
-				return
-			}
-			player.Credits -= cost
-			op := em.LaunchOperation(playerName, req.Target, req.Type, req.SystemID, cost, durations[req.Type])
-			writeJSON(w, APIResponse{OK: true, Data: op})
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET or POST")
-		}
-	})
-
-	// Bounty board
-	mux.HandleFunc("/api/bounties", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		bb := p.GetBountyBoard()
-		if bb == nil {
-			writeErr(w, http.StatusInternalServerError, "bounty board not available")
-			return
-		}
-		switch r.Method {
-		case http.MethodGet:
-			writeJSON(w, APIResponse{OK: true, Data: bb.GetActiveBounties()})
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				Type        string `json:"type"`
-				Description string `json:"description"`
-				Reward      int    `json:"reward"`
-				Resource    string `json:"resource,omitempty"`
-				Quantity    int    `json:"quantity,omitempty"`
-				PlanetID    int    `json:"planet_id,omitempty"`
-				SystemID    int    `json:"system_id,omitempty"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			if req.Reward <= 0 {
-				writeErr(w, http.StatusBadRequest, "reward must be positive")
-				return
-			}
-			player := findPlayer(p, playerName)
-			if player == nil || player.Credits < req.Reward {
-				writeErr(w, http.StatusBadRequest, "insufficient credits")
-				return
-			}
-			player.Credits -= req.Reward
-			bounty := bb.PostBounty(playerName, req.Type, req.Description, req.Reward,
-				req.Resource, req.Quantity, req.PlanetID, req.SystemID)
-			writeJSON(w, APIResponse{OK: true, Data: bounty})
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET or POST")
-		}
-	})
-
-	mux.HandleFunc("/api/bounties/claim", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		bb := getProvider().GetBountyBoard()
-		if bb == nil {
-			writeErr(w, http.StatusInternalServerError, "bounty board not available")
-			return
-		}
-		playerName := getAuthPlayer(r)
-		var req struct{ BountyID int `json:"bounty_id"` }
-		json.NewDecoder(r.Body).Decode(&req)
-		if bb.ClaimBounty(req.BountyID, playerName) {
-			writeJSON(w, APIResponse{OK: true, Data: "claimed"})
-		} else {
-			writeErr(w, http.StatusBadRequest, "bounty not available")
-		}
-	})
-
-	// Black market: high prices, risk of seizure, no location restriction
-	mux.HandleFunc("/api/black-market", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		p := getProvider()
-		bm := p.GetBlackMarket()
-		market := p.GetMarket()
-		if bm == nil || market == nil {
-			writeErr(w, http.StatusInternalServerError, "black market not available")
-			return
-		}
-		playerName := getAuthPlayer(r)
-		if playerName == "" {
-			writeErr(w, http.StatusUnauthorized, "auth required")
-			return
-		}
-		player := findPlayer(p, playerName)
-		if player == nil {
-			writeErr(w, http.StatusNotFound, "player not found")
-			return
-		}
-		var req struct {
-			Action   string `json:"action"` // "buy" or "sell"
-			Resource string `json:"resource"`
-			Quantity int    `json:"quantity"`
-			PlanetID int    `json:"planet_id"` // planet for delivery/pickup
-		}
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-
-		// Find the planet
-		var planet *entities.Planet
-		for _, sys := range p.GetSystems() {
-			for _, e := range sys.Entities {
-				if pl, ok := e.(*entities.Planet); ok && pl.GetID() == req.PlanetID && pl.Owner == playerName {
-					planet = pl
-				}
-			}
-		}
-		if planet == nil {
-			writeErr(w, http.StatusBadRequest, "planet not found or not owned")
-			return
-		}
-
-		if req.Action == "sell" {
-			stored := planet.GetStoredAmount(req.Resource)
-			if stored < req.Quantity {
-				writeErr(w, http.StatusBadRequest, fmt.Sprintf("need %d %s, have %d", req.Quantity, req.Resource, stored))
-				return
-			}
-			planet.RemoveStoredResource(req.Resource, req.Quantity)
-			basePrice := market.GetSellPrice(req.Resource)
-			credits, seized := bm.BlackMarketSell(basePrice, req.Quantity)
-			if seized {
-				writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-					"action": "sell", "seized": true, "resource": req.Resource, "quantity": req.Quantity,
-					"message": "Goods confiscated by authorities! You lose the resources and get nothing.",
-				}})
-				return
-			}
-			player.Credits += credits
-			writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-				"action": "sell", "seized": false, "resource": req.Resource,
-				"quantity": req.Quantity, "credits": credits,
-				"message": fmt.Sprintf("Black market sale! %d %s for %d credits (3x price)", req.Quantity, req.Resource, credits),
-			}})
-		} else if req.Action == "buy" {
-			basePrice := market.GetBuyPrice(req.Resource)
-			cost := bm.BlackMarketBuy(basePrice, req.Quantity)
-			if player.Credits < cost {
-				writeErr(w, http.StatusBadRequest, fmt.Sprintf("need %d credits (1.5x market price)", cost))
-				return
-			}
-			player.Credits -= cost
-			planet.AddStoredResource(req.Resource, req.Quantity)
-			writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-				"action": "buy", "resource": req.Resource,
-				"quantity": req.Quantity, "cost": cost,
-				"message": fmt.Sprintf("Black market purchase! %d %s for %d credits", req.Quantity, req.Resource, cost),
-			}})
-		} else {
-			writeErr(w, http.StatusBadRequest, "action must be 'buy' or 'sell'")
-		}
-	})
-
-	// Auctions: bid on rare items
-	mux.HandleFunc("/api/auctions", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		ah := p.GetAuctionHouse()
-		if ah == nil {
-			writeErr(w, http.StatusInternalServerError, "auctions not available")
-			return
-		}
-		switch r.Method {
-		case http.MethodGet:
-			writeJSON(w, APIResponse{OK: true, Data: ah.GetActiveAuctions()})
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				AuctionID int `json:"auction_id"`
-				Bid       int `json:"bid"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			if ah.PlaceBid(req.AuctionID, playerName, req.Bid) {
-				writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-					"auction_id": req.AuctionID, "bid": req.Bid, "bidder": playerName,
-				}})
-			} else {
-				writeErr(w, http.StatusBadRequest, "bid rejected (too low, auction ended, or you're the seller)")
-			}
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET or POST")
-		}
-	})
-
-	// Galactic Council: propose and vote on policies
-	mux.HandleFunc("/api/council", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		council := p.GetCouncil()
-		if council == nil {
-			writeErr(w, http.StatusInternalServerError, "council not available")
-			return
-		}
-		switch r.Method {
-		case http.MethodGet:
-			writeJSON(w, APIResponse{OK: true, Data: council.GetActiveProposals()})
-		case http.MethodPost:
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			var req struct {
-				Title       string `json:"title"`
-				Description string `json:"description"`
-				Effect      string `json:"effect"`
-			}
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			proposal := council.Propose(playerName, req.Title, req.Description, req.Effect, 3000)
-			writeJSON(w, APIResponse{OK: true, Data: proposal})
-		default:
-			writeErr(w, http.StatusMethodNotAllowed, "GET or POST")
-		}
-	})
-
-	mux.HandleFunc("/api/council/vote", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		playerName := getAuthPlayer(r)
-		council := getProvider().GetCouncil()
-		if council == nil {
-			writeErr(w, http.StatusInternalServerError, "council not available")
-			return
-		}
-		var req struct {
-			ProposalID int  `json:"proposal_id"`
-			Yes        bool `json:"yes"`
-		}
-		json.NewDecoder(r.Body).Decode(&req)
-		if council.Vote(req.ProposalID, playerName, req.Yes) {
-			writeJSON(w, APIResponse{OK: true, Data: "vote recorded"})
-		} else {
-			writeErr(w, http.StatusBadRequest, "proposal not found or voting closed")
-		}
-	})
-
-	// Galaxy intel: one-stop overview of special map features
-	mux.HandleFunc("/api/galaxy/intel", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		events := p.GetEventLog()
-
-		// Extract anomalies, pirates, wormholes, sector control from recent events
-		type IntelItem struct {
-			Type     string `json:"type"`
-			SystemID int    `json:"system_id,omitempty"`
-			Message  string `json:"message"`
-		}
-		var intel []IntelItem
-
-		if events != nil {
-			for _, e := range events.Recent(200) {
-				msg := e.Message
-				if e.Type == "event" || e.Type == "explore" {
-					switch {
-					case strings.Contains(msg, "Anomaly detected"):
-						intel = append(intel, IntelItem{Type: "anomaly", Message: msg})
-					case strings.Contains(msg, "Pirate fleet") || strings.Contains(msg, "pirate"):
-						intel = append(intel, IntelItem{Type: "pirate", Message: msg})
-					case strings.Contains(msg, "Wormhole") || strings.Contains(msg, "wormhole"):
-						intel = append(intel, IntelItem{Type: "wormhole", Message: msg})
-					case strings.Contains(msg, "controls") && strings.Contains(msg, "production bonus"):
-						intel = append(intel, IntelItem{Type: "sector_control", Message: msg})
-					case strings.Contains(msg, "LEGENDARY"):
-						intel = append(intel, IntelItem{Type: "legendary", Message: msg})
-					case strings.Contains(msg, "AUCTION"):
-						intel = append(intel, IntelItem{Type: "auction", Message: msg})
-					case strings.Contains(msg, "Trade boom"):
-						intel = append(intel, IntelItem{Type: "trade_boom", Message: msg})
-					case strings.Contains(msg, "Solar flare"):
-						intel = append(intel, IntelItem{Type: "solar_flare", Message: msg})
-					case strings.Contains(msg, "Asteroid"):
-						intel = append(intel, IntelItem{Type: "asteroid", Message: msg})
-					case strings.Contains(msg, "Population boom"):
-						intel = append(intel, IntelItem{Type: "pop_boom", Message: msg})
-					case strings.Contains(msg, "Void Crystal") || strings.Contains(msg, "data cache") || strings.Contains(msg, "Ancient Ruins") || strings.Contains(msg, "Derelict"):
-						intel = append(intel, IntelItem{Type: "discovery", Message: msg})
-					}
-				}
-			}
-		}
-
-		writeJSON(w, APIResponse{OK: true, Data: intel})
-	})
-
-	// Victory progress
-	mux.HandleFunc("/api/victory", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		players := p.GetPlayers()
-		systems := p.GetSystems()
-
-		type VictoryProgress struct {
-			Player     string         `json:"player"`
-			Credits    int            `json:"credits"`
-			Planets    int            `json:"planets"`
-			MaxTech    float64        `json:"max_tech"`
-			Population int64          `json:"population"`
-			Progress   map[string]string `json:"progress"`
-		}
-
-		var results []VictoryProgress
-		for _, player := range players {
-			if player == nil {
-				continue
-			}
-			planets := 0
-			maxTech := 0.0
-			var pop int64
-			for _, sys := range systems {
-				for _, e := range sys.Entities {
-					if pl, ok := e.(*entities.Planet); ok && pl.Owner == player.Name {
-						planets++
-						pop += pl.Population
-						if pl.TechLevel > maxTech {
-							maxTech = pl.TechLevel
-						}
-					}
-				}
-			}
-
-			progress := map[string]string{
-				"economic":   fmt.Sprintf("%d / 50,000,000 credits", player.Credits),
-				"domination": fmt.Sprintf("%d / 20 planets", planets),
-				"technology": fmt.Sprintf("%.1f / 5.0 tech level", maxTech),
-				"population": fmt.Sprintf("%d / 1,000,000 citizens", pop),
-			}
-
-			results = append(results, VictoryProgress{
-				Player: player.Name, Credits: player.Credits,
-				Planets: planets, MaxTech: maxTech, Population: pop,
-				Progress: progress,
-			})
-		}
-
-		writeJSON(w, APIResponse{OK: true, Data: results})
-	})
-
-	// Trade opportunities: find the best cross-system arbitrage
-	mux.HandleFunc("/api/trade-opportunities", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodGet {
-			writeErr(w, http.StatusMethodNotAllowed, "GET only")
-			return
-		}
-		p := getProvider()
-		market := p.GetMarket()
-		if market == nil {
-			writeJSON(w, APIResponse{OK: true, Data: []interface{}{}})
-			return
-		}
-
-		systems := p.GetSystems()
-		players := p.GetPlayers()
-
-		// Build per-system stock map
-		type SystemStock struct {
-			SystemID int
-			Stock    map[string]int
-			Owners   []string
-		}
-		systemStocks := make(map[int]*SystemStock)
-		for _, sys := range systems {
-			ss := &SystemStock{SystemID: sys.ID, Stock: make(map[string]int)}
-			for _, e := range sys.Entities {
-				planet, ok := e.(*entities.Planet)
-				if !ok || planet.Owner == "" {
-					continue
-				}
-				found := false
-				for _, o := range ss.Owners {
-					if o == planet.Owner {
-						found = true
-						break
-					}
-				}
-				if !found {
-					ss.Owners = append(ss.Owners, planet.Owner)
-				}
-				for resType, s := range planet.StoredResources {
-					if s != nil && s.Amount > 0 {
-						ss.Stock[resType] += s.Amount
-					}
-				}
-			}
-			if len(ss.Owners) > 0 {
-				systemStocks[sys.ID] = ss
-			}
-		}
-
-		// Find arbitrage: resource cheap in system A, expensive in system B
-		type Opportunity struct {
-			Resource       string  `json:"resource"`
-			FromSystem     int     `json:"from_system"`
-			ToSystem       int     `json:"to_system"`
-			BuyPrice       float64 `json:"buy_price"`
-			SellPrice      float64 `json:"sell_price"`
-			Margin         float64 `json:"margin"`
-			ProfitPerTrip  int     `json:"profit_per_trip"`  // margin * 500 (cargo capacity)
-			FuelCostPerTrip int    `json:"fuel_cost_per_trip"` // estimated fuel for round trip
-			NetProfitPerTrip int   `json:"net_profit_per_trip"` // profit - fuel cost
-			Available      int     `json:"available"`
-			Demand         int     `json:"demand"`
-		}
-
-		var opps []Opportunity
-		resources := []string{"Iron", "Water", "Oil", "Fuel", "Rare Metals", "Helium-3", "Electronics"}
-
-		for _, res := range resources {
-			basePrice := market.GetBuyPrice(res)
-			_ = players // used indirectly via systemStocks
-
-			for fromID, fromSS := range systemStocks {
-				fromStock := fromSS.Stock[res]
-				if fromStock < 50 {
-					continue // not enough to trade
-				}
-				fromBuyMult := economy.LocalPriceMultiplier(fromStock, 0)
-
-				for toID, toSS := range systemStocks {
-					if toID == fromID {
-						continue
-					}
-					toStock := toSS.Stock[res]
-					toSellMult := economy.LocalSellPriceMultiplier(toStock)
-
-					buyAt := basePrice * fromBuyMult
-					sellAt := market.GetSellPrice(res) * toSellMult
-					margin := sellAt - buyAt
+// isAdmin returns whether the request was authenticated with the admin key.
+func isAdmin(r *http.Request) bool {
+	if v, ok := r.Context().Value(ctxIsAdmin).(bool); ok {
+		return v
+	}
+	return false
+}
 
-					if margin > 10 {
-						cargoCapacity := 500
-						profitPerTrip := int(margin * float64(cargoCapacity))
-						// Fuel cost: ~25 fuel per jump, fuel worth ~200cr each, round trip = 2 jumps
-						fuelCost := 25 * 2 * 200 // 10,000cr round trip fuel cost estimate
-						netProfit := profitPerTrip - fuelCost
+// visibilityFor returns what the caller may see of other factions: nil
+// (everything) for admins, otherwise the authenticated player's sensor and
+// intel coverage. Anonymous callers only see the charted galaxy.
+func visibilityFor(r *http.Request) *game.Visibility {
+	if isAdmin(r) {
+		return nil
+	}
+	return playerVisibility(getProvider(r), getAuthPlayer(r))
+}
 
-						opps = append(opps, Opportunity{
-							Resource:        res,
-							FromSystem:      fromID,
-							ToSystem:        toID,
-							BuyPrice:        buyAt,
-							SellPrice:       sellAt,
-							Margin:          margin,
-							ProfitPerTrip:   profitPerTrip,
-							FuelCostPerTrip: fuelCost,
-							NetProfitPerTrip: netProfit,
-							Available:       fromStock,
-							Demand:          1000 - toStock,
-						})
-					}
-				}
-			}
-		}
+// getRequestID returns the ID the middleware assigned to the request.
+func getRequestID(r *http.Request) string {
+	if v, ok := r.Context().Value(ctxRequestID).(string); ok {
+		return v
+	}
+	return ""
+}
 
-		// Sort by margin descending
-		for i := 0; i < len(opps); i++ {
-			for j := i + 1; j < len(opps); j++ {
-				if opps[j].Margin > opps[i].Margin {
-					opps[i], opps[j] = opps[j], opps[i]
-				}
-			}
-		}
+// newRequestID keeps a client-supplied X-Request-ID if it's short and
+// printable, so callers can correlate their own logs; otherwise it makes one.
+func newRequestID(r *http.Request) string {
+	if id := r.Header.Get("X-Request-ID"); id != "" && len(id) <= 64 && strings.IndexFunc(id, func(c rune) bool { return c <= ' ' || c > '~' }) < 0 {
+		return id
+	}
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "req-" + hex.EncodeToString(b)
+}
 
-		// Return top 20
-		if len(opps) > 20 {
-			opps = opps[:20]
-		}
+// getAuthGrant returns what the request's API key may do (zero if unauthenticated).
+func getAuthGrant(r *http.Request) game.KeyGrant {
+	if v, ok := r.Context().Value(ctxGrant).(game.KeyGrant); ok {
+		return v
+	}
+	return game.KeyGrant{}
+}
 
-		writeJSON(w, APIResponse{OK: true, Data: opps})
-	})
+var (
+	serverStarted  atomic.Bool
+	providerMu     sync.RWMutex
+	activeProvider GameStateProvider
+	apiKey         string // if set, POST endpoints require X-API-Key header
+)
 
-	// --- Logistics Endpoints ---
+// getProvider returns the game r addresses: a hosted game for
+// /api/games/{id}/... routes, otherwise the default game.
+func getProvider(r *http.Request) GameStateProvider {
+	if g := gameFor(r); g != nil {
+		return g.provider
+	}
+	providerMu.RLock()
+	defer providerMu.RUnlock()
+	return activeProvider
+}
 
-	// POST /api/ships/dock — dock a ship at a planet
-	mux.HandleFunc("/api/ships/dock", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req DockShipRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		cmd := newCommand(r, game.CmdDockShip, game.DockShipCommandData{
-			ShipID: req.ShipID, PlanetID: req.PlanetID,
-		})
-		p := getProvider()
-		p.GetCommandChannel() <- cmd
-		select {
-		case res := <-cmd.Result:
-			if err, ok := res.(error); ok {
-				writeErr(w, http.StatusBadRequest, err.Error())
-			} else {
-				writeJSON(w, APIResponse{OK: true, Data: res})
-			}
-		case <-r.Context().Done():
-			writeErr(w, http.StatusGatewayTimeout, "timeout")
-		}
-	})
+// StartServer launches the REST API on :8080 in a background goroutine.
+// Subsequent calls update the provider without starting a second server.
+// More games can be served alongside this one with HostGame.
+func StartServer(provider GameStateProvider) {
+	providerMu.Lock()
+	activeProvider = provider
+	providerMu.Unlock()
 
-	// POST /api/ships/undock — undock a ship
-	mux.HandleFunc("/api/ships/undock", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req UndockShipRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		cmd := newCommand(r, game.CmdUndockShip, game.UndockShipCommandData{
-			ShipID: req.ShipID,
-		})
-		p := getProvider()
-		p.GetCommandChannel() <- cmd
-		select {
-		case res := <-cmd.Result:
-			if err, ok := res.(error); ok {
-				writeErr(w, http.StatusBadRequest, err.Error())
-			} else {
-				writeJSON(w, APIResponse{OK: true, Data: res})
-			}
-		case <-r.Context().Done():
-			writeErr(w, http.StatusGatewayTimeout, "timeout")
-		}
-	})
+	// Point stream subscribers at the new game's logs
+	stream.attach(provider)
+	// Start snapshotting the new game for observers
+	spectator.attach(provider, spectatorDelayFromEnv())
 
-	// POST /api/ships/sell-at-dock — sell cargo from a docked ship
-	mux.HandleFunc("/api/ships/sell-at-dock", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req SellAtDockRequest
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		cmd := newCommand(r, game.CmdSellAtDock, game.SellAtDockCommandData{
-			ShipID: req.ShipID, Resource: req.Resource, Quantity: req.Quantity,
-		})
-		p := getProvider()
-		p.GetCommandChannel() <- cmd
-		select {
-		case res := <-cmd.Result:
-			if err, ok := res.(error); ok {
-				writeErr(w, http.StatusBadRequest, err.Error())
-			} else {
-				writeJSON(w, APIResponse{OK: true, Data: res})
-			}
-		case <-r.Context().Done():
-			writeErr(w, http.StatusGatewayTimeout, "timeout")
-		}
-	})
+	// Load API key from environment
+	apiKey = os.Getenv("XANDARIS_API_KEY")
+	if apiKey != "" {
+		fmt.Println("[API] API key auth enabled for POST endpoints")
+	}
 
-	// POST /api/ships/buy-at-dock — buy resources from a docked planet into cargo
-	mux.HandleFunc("/api/ships/buy-at-dock", func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != http.MethodPost {
-			writeErr(w, http.StatusMethodNotAllowed, "POST only")
-			return
-		}
-		var req SellAtDockRequest // same shape: ship_id, resource, quantity
-		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-			writeErr(w, http.StatusBadRequest, err.Error())
-			return
-		}
-		cmd := newCommand(r, game.CmdBuyAtDock, game.SellAtDockCommandData{
-			ShipID: req.ShipID, Resource: req.Resource, Quantity: req.Quantity,
-		})
-		p := getProvider()
-		p.GetCommandChannel() <- cmd
-		select {
-		case res := <-cmd.Result:
-			if err, ok := res.(error); ok {
-				writeErr(w, http.StatusBadRequest, err.Error())
-			} else {
-				writeJSON(w, APIResponse{OK: true, Data: res})
-			}
-		case <-r.Context().Done():
-			writeErr(w, http.StatusGatewayTimeout, "timeout")
-		}
-	})
+	if serverStarted.Swap(true) {
+		fmt.Println("[API] Provider updated (server already running)")
+		return
+	}
 
-	// GET/POST /api/shipping/routes — list or create shipping routes
-	mux.HandleFunc("/api/shipping/routes", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		sm := p.GetShippingManager()
-		if sm == nil {
-			writeErr(w, http.StatusInternalServerError, "shipping system not available")
-			return
-		}
+	// Rate limits: per-caller buckets and per-player daily quotas, metered
+	// by route cost once the caller is known
+	limits := defaultRateLimits
+	limits.DailyQuota = dailyQuotaFromEnv()
+	handler := newHandler(NewRateLimiter(limits))
 
-		if r.Method == http.MethodPost {
-			// Create a new shipping route
-			var req ShippingRouteRequest
-			if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-				writeErr(w, http.StatusBadRequest, err.Error())
-				return
-			}
-			playerName := getAuthPlayer(r)
-			if playerName == "" {
-				writeErr(w, http.StatusUnauthorized, "auth required")
-				return
-			}
-			// Validate planet IDs — must actually exist in the galaxy
-			srcFound := false
-			dstFound := false
-			for _, sys := range p.GetSystems() {
-				for _, e := range sys.Entities {
-					if pl, ok := e.(*entities.Planet); ok {
-						if pl.GetID() == req.SourcePlanetID {
-							srcFound = true
-						}
-						if pl.GetID() == req.DestPlanetID {
-							dstFound = true
-						}
-					}
-				}
-			}
-			if !srcFound {
-				writeErr(w, http.StatusBadRequest, fmt.Sprintf("source planet %d not found — use planet IDs from get_planet, not system IDs or planet names", req.SourcePlanetID))
-				return
-			}
-			if !dstFound {
-				writeErr(w, http.StatusBadRequest, fmt.Sprintf("dest planet %d not found — use planet IDs from get_planet, not system IDs or planet names", req.DestPlanetID))
-				return
-			}
-			if req.SourcePlanetID == req.DestPlanetID {
-				writeErr(w, http.StatusBadRequest, "source and dest cannot be the same planet")
-				return
-			}
-			routeID := sm.CreateRoute(playerName, req.SourcePlanetID, req.DestPlanetID, req.Resource, req.Quantity, req.ShipID)
-			writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-				"route_id": routeID,
-				"owner":    playerName,
-			}})
-			return
+	go func() {
+		fmt.Println("[API] Starting REST server on :8080")
+		if err := http.ListenAndServe(":8080", handler); err != nil {
+			fmt.Printf("[API] Server error: %v\n", err)
 		}
+	}()
+}
 
-		// GET — list routes with diagnostic info
-		playerName := getAuthPlayer(r)
-		routes := sm.GetRoutes(playerName)
+// newHandler registers every route on a router metered by limiter and wraps
+// it in the request ID, game scoping, CORS and auth middleware.
+func newHandler(limiter *RateLimiter) http.Handler {
+	mux := http.NewServeMux()
+	rt := newRouter(mux, limiter)
+	registerMarketRoutes(rt)
+	registerShipRoutes(rt)
+	registerPlanetRoutes(rt)
+	registerPlayerRoutes(rt)
+	registerAdminRoutes(rt)
+	registerAuthRoutes(rt)
+	registerKeyEndpoints(rt)
+	registerWebhookEndpoints(rt)
+	registerGameEndpoints(rt)
+	registerBatchEndpoint(rt)
 
-		type RouteWithDiag struct {
-			ShippingRouteInfo
-			SourceStock int    `json:"source_stock"`
-			ShipFuel    int    `json:"ship_fuel,omitempty"`
-			ShipSystem  int    `json:"ship_system,omitempty"`
-			Status      string `json:"status"` // "running", "no_stock", "no_fuel", "no_ship"
-		}
+	// LLM chat endpoint
+	registerChatEndpoint(rt)
 
-		result := make([]RouteWithDiag, 0, len(routes))
-		for _, rt := range routes {
-			diag := RouteWithDiag{
-				ShippingRouteInfo: ShippingRouteInfo{
-					ID: rt.ID, Owner: rt.Owner,
-					SourcePlanet: rt.SourcePlanet, DestPlanet: rt.DestPlanet,
-					Resource: rt.Resource, Quantity: rt.Quantity,
-					ShipID: rt.ShipID, Active: rt.Active,
-					TripsComplete: rt.TripsComplete,
-				},
-				Status: "no_ship",
-			}
+	// Push feed of events, chat, trades and tick summaries
+	registerStreamEndpoint(rt)
 
-			// Check source stock
-			for _, sys := range p.GetSystems() {
-				for _, e := range sys.Entities {
-					if pl, ok := e.(*entities.Planet); ok && pl.GetID() == rt.SourcePlanet {
-						diag.SourceStock = pl.GetStoredAmount(rt.Resource)
-					}
-				}
-			}
+	// Delayed full-galaxy snapshots for observer keys
+	registerSpectateEndpoint(rt)
 
-			// Check ship status
-			if rt.ShipID != 0 {
-				for _, pl := range p.GetPlayers() {
-					if pl == nil { continue }
-					for _, s := range pl.OwnedShips {
-						if s != nil && s.GetID() == rt.ShipID {
-							diag.ShipFuel = s.CurrentFuel
-							diag.ShipSystem = s.CurrentSystem
-							if s.CurrentFuel < s.FuelPerJump {
-								diag.Status = "no_fuel"
-							} else if diag.SourceStock == 0 {
-								diag.Status = "no_stock"
-							} else {
-								diag.Status = "ready"
-							}
-						}
-					}
-				}
-			}
+	registerPages(rt)
+	registerOpenAPI(rt)
 
-			result = append(result, diag)
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
+	// Wrap mux with auth + CORS middleware
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Every response carries the request's ID; commands log it with their events
+		reqID := newRequestID(r)
+		w.Header().Set("X-Request-ID", reqID)
+		r = r.WithContext(context.WithValue(r.Context(), ctxRequestID, reqID))
 
-	// GET /api/logistics — enriched route data with planet/system names for the logistics page
-	mux.HandleFunc("/api/logistics", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		sm := p.GetShippingManager()
-		if sm == nil {
-			writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{"routes": []interface{}{}}})
+		// /api/games/{id}/... is the plain route, served by a hosted game
+		r, ok := scopeToGame(r)
+		if !ok {
+			writeErr(w, http.StatusNotFound, "unknown game")
 			return
 		}
 
-		// Build planet→name and planet→system maps
-		planetName := make(map[int]string)
-		planetSystem := make(map[int]int)
-		systemName := make(map[int]string)
-		for _, sys := range p.GetSystems() {
-			systemName[sys.ID] = sys.Name
-			for _, e := range sys.Entities {
-				if pl, ok := e.(*entities.Planet); ok {
-					planetName[pl.GetID()] = pl.Name
-					planetSystem[pl.GetID()] = sys.ID
-				}
-			}
-		}
-
-		// Build ship lookup
-		type shipInfo struct {
-			Name    string `json:"name"`
-			Fuel    int    `json:"fuel"`
-			MaxFuel int    `json:"max_fuel"`
-			System  int    `json:"system"`
-			SysName string `json:"sys_name"`
-			Status  string `json:"status"`
-			Cargo   int    `json:"cargo"`
-		}
-		shipMap := make(map[int]shipInfo)
-		for _, pl := range p.GetPlayers() {
-			if pl == nil { continue }
-			for _, s := range pl.OwnedShips {
-				if s == nil { continue }
-				sn := systemName[s.CurrentSystem]
-				if sn == "" { sn = fmt.Sprintf("SYS-%d", s.CurrentSystem+1) }
-				shipMap[s.GetID()] = shipInfo{
-					Name: s.Name, Fuel: s.CurrentFuel, MaxFuel: s.MaxFuel,
-					System: s.CurrentSystem, SysName: sn,
-					Status: string(s.Status), Cargo: s.GetTotalCargo(),
-				}
-			}
-		}
-
-		type enrichedRoute struct {
-			ID             int      `json:"id"`
-			Owner          string   `json:"owner"`
-			Resource       string   `json:"resource"`
-			Quantity       int      `json:"quantity"`
-			TripsComplete  int      `json:"trips_complete"`
-			Active         bool     `json:"active"`
-			SourcePlanet   string   `json:"source_planet"`
-			SourceSystem   string   `json:"source_system"`
-			DestPlanet     string   `json:"dest_planet"`
-			DestSystem     string   `json:"dest_system"`
-			SourceStock    int      `json:"source_stock"`
-			ShipID         int      `json:"ship_id"`
-			Ship           *shipInfo `json:"ship,omitempty"`
-			Status         string   `json:"status"`
-		}
-
-		routes := sm.GetRoutes("")
-		result := make([]enrichedRoute, 0, len(routes))
-		for _, rt := range routes {
-			srcName := planetName[rt.SourcePlanet]
-			if srcName == "" { srcName = fmt.Sprintf("Planet-%d", rt.SourcePlanet) }
-			dstName := planetName[rt.DestPlanet]
-			if dstName == "" { dstName = fmt.Sprintf("Planet-%d", rt.DestPlanet) }
-			srcSysID := planetSystem[rt.SourcePlanet]
-			dstSysID := planetSystem[rt.DestPlanet]
-			srcSysName := systemName[srcSysID]
-			if srcSysName == "" { srcSysName = fmt.Sprintf("SYS-%d", srcSysID+1) }
-			dstSysName := systemName[dstSysID]
-			if dstSysName == "" { dstSysName = fmt.Sprintf("SYS-%d", dstSysID+1) }
-
-			// Source stock
-			srcStock := 0
-			for _, sys := range p.GetSystems() {
-				for _, e := range sys.Entities {
-					if pl, ok := e.(*entities.Planet); ok && pl.GetID() == rt.SourcePlanet {
-						srcStock = pl.GetStoredAmount(rt.Resource)
-					}
-				}
-			}
-
-			status := "no_ship"
-			var ship *shipInfo
-			if rt.ShipID != 0 {
-				if si, ok := shipMap[rt.ShipID]; ok {
-					ship = &si
-					if si.Fuel < 25 {
-						status = "no_fuel"
-					} else if srcStock == 0 {
-						status = "no_stock"
-					} else if si.Status == "Moving" {
-						status = "in_transit"
-					} else {
-						status = "ready"
-					}
-				}
-			}
-
-			result = append(result, enrichedRoute{
-				ID: rt.ID, Owner: rt.Owner, Resource: rt.Resource,
-				Quantity: rt.Quantity, TripsComplete: rt.TripsComplete,
-				Active: rt.Active,
-				SourcePlanet: srcName, SourceSystem: srcSysName,
-				DestPlanet: dstName, DestSystem: dstSysName,
-				SourceStock: srcStock, ShipID: rt.ShipID,
-				Ship: ship, Status: status,
-			})
-		}
-
-		// Summary stats
-		totalTrips := 0
-		delivering := 0
-		for _, r := range result {
-			totalTrips += r.TripsComplete
-			if r.TripsComplete > 0 { delivering++ }
-		}
-
-		writeJSON(w, APIResponse{OK: true, Data: map[string]interface{}{
-			"routes":     result,
-			"total":      len(result),
-			"delivering": delivering,
-			"total_trips": totalTrips,
-		}})
-	})
-
-	// GET /api/planets/physics — planet formation data for dashboard
-	mux.HandleFunc("/api/planets/physics", func(w http.ResponseWriter, r *http.Request) {
-		p := getProvider()
-		type planetPhys struct {
-			ID             int     `json:"id"`
-			Name           string  `json:"name"`
-			Type           string  `json:"type"`
-			Owner          string  `json:"owner"`
-			System         string  `json:"system"`
-			Mass           float64 `json:"mass"`
-			Radius         float64 `json:"radius"`
-			Gravity        float64 `json:"gravity"`
-			Density        float64 `json:"density"`
-			OrbitAU        float64 `json:"orbit_au"`
-			TempC          int     `json:"temp_c"`
-			Iron           float64 `json:"iron"`
-			Silicate       float64 `json:"silicate"`
-			Water          float64 `json:"water"`
-			Gas            float64 `json:"gas"`
-			Organics       float64 `json:"organics"`
-			RareEarth      float64 `json:"rare_earth"`
-			MagneticField  float64 `json:"magnetic_field"`
-			AtmoPressure   float64 `json:"atmo_pressure"`
-			OceanCoverage  float64 `json:"ocean_coverage"`
-			IceCoverage    float64 `json:"ice_coverage"`
-			TidallyLocked  bool    `json:"tidally_locked"`
-			TectonicActive bool    `json:"tectonic_active"`
-			VolcanicLevel  float64 `json:"volcanic_level"`
-			DayLength      float64 `json:"day_length"`
-			Moons          int     `json:"moons"`
-			ParentID       int     `json:"parent_id,omitempty"`
-			Habitability   int     `json:"habitability"`
-		}
-		var result []planetPhys
-		for _, sys := range p.GetSystems() {
-			for _, e := range sys.Entities {
-				pl, ok := e.(*entities.Planet)
-				if !ok || pl.Mass == 0 {
-					continue
-				}
-				result = append(result, planetPhys{
-					ID: pl.GetID(), Name: pl.Name, Type: pl.PlanetType,
-					Owner: pl.Owner, System: sys.Name,
-					Mass: math.Round(pl.Mass*100) / 100,
-					Radius: math.Round(pl.RadiusAU*100) / 100,
-					Gravity: math.Round(pl.Gravity*100) / 100,
-					Density: math.Round(pl.Density*10) / 10,
-					OrbitAU: math.Round(pl.OrbitAU*100) / 100,
-					TempC: pl.Temperature,
-					Iron: pl.Comp.Iron, Silicate: pl.Comp.Silicate,
-					Water: pl.Comp.Water, Gas: pl.Comp.Gas,
-					Organics: pl.Comp.Organics, RareEarth: pl.Comp.RareEarth,
-					MagneticField: math.Round(pl.MagneticField*100) / 100,
-					AtmoPressure: math.Round(pl.AtmoPressure*100) / 100,
-					OceanCoverage: math.Round(pl.OceanCoverage*100) / 100,
-					IceCoverage: math.Round(pl.IceCoverage*100) / 100,
-					TidallyLocked: pl.TidallyLocked,
-					TectonicActive: pl.TectonicActive,
-					VolcanicLevel: math.Round(pl.VolcanicLevel*100) / 100,
-					DayLength: math.Round(pl.DayLength*10) / 10,
-					Moons: len(pl.Moons),
-					ParentID: pl.ParentPlanetID,
-					Habitability: pl.Habitability,
-				})
-			}
-		}
-		writeJSON(w, APIResponse{OK: true, Data: result})
-	})
-
-	// Rate limiter: 30 reads/sec, 10 writes/sec per key, burst of 60/20
-	rateLimiter := NewRateLimiter(30, 10, 60, 20)
-
-	// Wrap mux with auth + CORS + rate limiting middleware
-	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		// CORS
 		w.Header().Set("Access-Control-Allow-Origin", "*")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, X-Player")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, X-API-Key, X-Player, X-Request-ID, Idempotency-Key")
+		w.Header().Set("Access-Control-Expose-Headers", "X-Request-ID, X-RateLimit-Limit, X-RateLimit-Remaining, X-RateLimit-Reset, X-RateLimit-Cost, X-RateLimit-Daily-Limit, X-RateLimit-Daily-Remaining, X-RateLimit-Daily-Reset, Retry-After")
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
 			return
 		}
 
-		// Rate limiting
-		rlKey := r.Header.Get("X-API-Key")
-		if rlKey == "" {
-			rlKey = r.RemoteAddr
-		}
-		if !rateLimiter.Allow(rlKey, r.Method == http.MethodPost) {
-			w.Header().Set("Retry-After", "1")
-			writeErr(w, http.StatusTooManyRequests, "rate limit exceeded")
-			return
-		}
-
 		// Auth — inject player identity into context
 		key := r.Header.Get("X-API-Key")
+		if key == "" && r.URL.Path == "/api/stream" {
+			key = r.URL.Query().Get("key") // EventSource can't set headers
+		}
 		if key != "" {
-			p := getProvider()
+			p := getProvider(r)
 			registry := p.GetRegistry()
 			if registry != nil {
-				playerName, admin, ok := registry.Authenticate(key)
+				grant, ok := registry.AuthenticateKey(key)
 				if ok {
+					if scope := requiredScope(r); !grant.Allows(scope) {
+						writeErr(w, http.StatusForbidden, fmt.Sprintf("API key %q lacks the %s scope", grant.KeyName, scope))
+						return
+					}
+					playerName := grant.Player
+					// Observers watch through the delayed spectator feed; live,
+					// they're as anonymous as anyone else
+					if grant.Observer() {
+						if r.Method != http.MethodGet && r.Method != http.MethodHead {
+							writeErr(w, http.StatusForbidden, "observer keys are read-only")
+							return
+						}
+						playerName = ""
+					}
 					// Admin impersonation: X-Player header lets admin act as any faction
-					if admin {
+					if grant.Admin {
 						if impersonate := r.Header.Get("X-Player"); impersonate != "" {
 							playerName = impersonate
 						}
 					}
 					ctx := context.WithValue(r.Context(), ctxPlayerName, playerName)
-					ctx = context.WithValue(ctx, ctxIsAdmin, admin)
+					ctx = context.WithValue(ctx, ctxIsAdmin, grant.Admin)
+					ctx = context.WithValue(ctx, ctxGrant, grant)
 					r = r.WithContext(ctx)
 				}
 			}
@@ -3399,13 +242,6 @@ func StartServer(provider GameStateProvider) {
 		}
 		mux.ServeHTTP(w, r)
 	})
-
-	go func() {
-		fmt.Println("[API] Starting REST server on :8080")
-		if err := http.ListenAndServe(":8080", handler); err != nil {
-			fmt.Printf("[API] Server error: %v\n", err)
-		}
-	}()
 }
 
 func parseSpeed(s string) (systems.TickSpeed, bool) {
@@ -3422,939 +258,6 @@ func parseSpeed(s string) (systems.TickSpeed, bool) {
 	return 0, false
 }
 
-const spectatorHTML = `<!DOCTYPE html>
-<html><head>
-<meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1.0">
-<title>Xandaris II — Live Galaxy</title>
-<style>
-*{margin:0;padding:0;box-sizing:border-box}
-body{background:#060810;overflow:hidden;font-family:'Courier New',monospace}
-canvas{display:block}
-#hud{position:fixed;top:10px;right:10px;background:rgba(8,12,24,0.92);border:1px solid #1a2040;border-radius:8px;padding:14px;color:#b0b8c8;font-size:12px;width:260px;max-height:90vh;overflow-y:auto;backdrop-filter:blur(8px)}
-#hud h2{color:#7fdbca;font-size:14px;margin-bottom:10px;letter-spacing:1px}
-#hud .row{display:flex;justify-content:space-between;padding:3px 0}
-#hud .g{color:#5cdb5c}#hud .r{color:#db5555}#hud .o{color:#dba855}#hud .d{color:#556}
-#hud hr{border:none;border-top:1px solid #1a2040;margin:10px 0}
-#hud .section{font-size:11px;color:#7fdbca;margin:6px 0 4px;text-transform:uppercase;letter-spacing:1px}
-#status{position:fixed;bottom:10px;left:12px;color:#4a4;font-size:11px}
-#title{position:fixed;top:14px;left:16px;color:#7fdbca;font-size:20px;letter-spacing:2px;text-shadow:0 0 20px rgba(127,219,202,0.3)}
-#subtitle{position:fixed;top:38px;left:16px;color:#445;font-size:11px}
-#tooltip{position:fixed;display:none;background:rgba(8,12,24,0.95);border:1px solid #2a3060;border-radius:6px;padding:10px 12px;color:#c0c8d8;font-size:11px;pointer-events:none;z-index:100;backdrop-filter:blur(4px);max-width:220px}
-a{color:#446}
-</style></head><body>
-<canvas id="c"></canvas>
-<div id="title">XANDARIS II</div>
-<div id="subtitle">Live Galaxy — Spectator Mode</div>
-<div id="hud">
-<div class="section">Factions</div>
-<div id="players"></div>
-<hr>
-<div class="section">Market</div>
-<div id="market"></div>
-<hr>
-<div class="section">Economy</div>
-<div id="info"></div>
-<hr>
-<div class="section">Power Grid</div>
-<div id="power" style="font-size:10px"></div>
-<hr>
-<div class="section">Construction</div>
-<div id="construction" style="font-size:10px;max-height:80px;overflow-y:auto;color:#889"></div>
-<hr>
-<div class="section">Events</div>
-<div id="events" style="font-size:10px;max-height:120px;overflow-y:auto;color:#889"></div>
-<hr>
-<p style="font-size:10px;color:#334;margin-top:4px"><a href="/data">Data View</a> · <a href="/api/game" target="_blank">API</a> · <a href="https://github.com/hunterjsb/xandaris" target="_blank">GitHub</a></p>
-</div>
-<div id="tooltip"></div>
-<div id="detail" style="display:none;position:fixed;bottom:10px;left:10px;background:rgba(8,12,24,0.95);border:1px solid #1a2040;border-radius:8px;padding:14px;color:#b0b8c8;font-size:12px;width:320px;max-height:50vh;overflow-y:auto;backdrop-filter:blur(8px)">
-<div style="display:flex;justify-content:space-between"><span id="dtitle" style="color:#7fdbca;font-size:14px"></span><span onclick="selected=null;this.parentElement.parentElement.style.display='none'" style="cursor:pointer;color:#556">✕</span></div>
-<div id="dbody" style="margin-top:8px"></div>
-</div>
-<div id="status">Connecting...</div>
-<script>
-const B=location.origin,C=document.getElementById('c'),X=C.getContext('2d');
-let W,H,systems=[],ships=[],players=[],economy={},flows={},power=[],deliveries=[],construction=[],mx=0,my=0,hover=null,selected=null,detail=null,tracked=null,hoverShip=null,t=0;
-const COLORS={Human:'#4caf50',Server:'#4caf50','Llama Logistics':'#ff9800','DeepSeek Ventures':'#e84040','Gemini Exchange':'#8bc34a','Grok Industries':'#ffca28','Opus Cartel':'#ab47bc'};
-// Background stars
-let stars=[];
-function initStars(){stars=[];for(let i=0;i<200;i++)stars.push({x:Math.random(),y:Math.random(),s:Math.random()*1.5+0.5,b:Math.random()})}
-initStars();
-function resize(){W=C.width=innerWidth;H=C.height=innerHeight}
-addEventListener('resize',()=>{resize();initStars()});resize();
-C.addEventListener('mousemove',e=>{mx=e.clientX;my=e.clientY});
-C.addEventListener('click',e=>{
-if(hoverShip){tracked=tracked===hoverShip?null:hoverShip;selected=null;document.getElementById('detail').style.display='none'}
-else if(hover){selected=hover;tracked=null;loadDetail(hover.id)}
-else{selected=null;tracked=null;document.getElementById('detail').style.display='none'}});
-// Zoom
-let zoom=1,panX=0,panY=0,dragging=false,dragX=0,dragY=0;
-C.addEventListener('wheel',e=>{
-const oz=zoom;zoom=Math.max(0.3,Math.min(5,zoom*(e.deltaY>0?0.9:1.1)));
-// Zoom toward mouse cursor (world-space pivot)
-panX+=(mx-W/2-panX)*(1-zoom/oz);panY+=(my-H/2-panY)*(1-zoom/oz);
-e.preventDefault()},{passive:false});
-C.addEventListener('mousedown',e=>{if(e.button===0&&!hover){dragging=true;dragX=e.clientX-panX;dragY=e.clientY-panY}});
-C.addEventListener('mouseup',()=>{dragging=false});
-C.addEventListener('mousemove',e=>{if(dragging){panX=e.clientX-dragX;panY=e.clientY-dragY}});
-function pc(name){return COLORS[name]||'#6688aa'}
-function hexA(hex,a){const r=parseInt(hex.slice(1,3),16),g=parseInt(hex.slice(3,5),16),b=parseInt(hex.slice(5,7),16);return'rgba('+r+','+g+','+b+','+a+')'}
-async function load(){
-try{
-const[g,s,p,e,f,ev,pw,dl,cx]=await Promise.all(['/api/galaxy','/api/ships','/api/players','/api/economy','/api/flows','/api/events?limit=50','/api/power','/api/deliveries','/api/construction'].map(u=>fetch(B+u).then(r=>r.json())));
-systems=g.data;ships=s.data;players=p.data;economy=e.data;flows=f.data;power=pw.data||[];deliveries=dl.data||[];construction=cx.data||[];
-document.getElementById('status').textContent='Live · '+new Date().toLocaleTimeString();
-document.getElementById('players').innerHTML=players.sort((a,b)=>b.credits-a.credits).map(p=>{
-const c=pc(p.name);return'<div class="row"><span style="color:'+c+'">'+p.name+'</span><span>'+p.credits.toLocaleString()+'cr</span></div>'}).join('');
-const res=economy.resources||{};
-document.getElementById('market').innerHTML=Object.entries(res).sort().map(([n,r])=>{
-const c=r.price_ratio>1.5?'r':r.price_ratio<0.5?'g':'d';
-return'<div class="row"><span>'+n+'</span><span class="'+c+'">'+r.buy_price.toFixed(0)+' ('+r.price_ratio.toFixed(1)+'x)</span></div>'}).join('');
-const nf=flows.net_flow||{};
-document.getElementById('info').innerHTML=
-'<div class="row"><span>Population</span><span>'+(economy.total_population||0).toLocaleString()+'</span></div>'+
-'<div class="row"><span>GDP</span><span>'+(economy.gdp||0).toLocaleString(undefined,{maximumFractionDigits:0})+' cr/int</span></div>'+
-'<div class="row"><span>Trade Volume</span><span>'+(economy.trade_volume||0).toFixed(0)+'</span></div>'+
-'<div class="row"><span>Planets</span><span>'+(economy.total_planets||0)+'</span></div>'+
-'<div class="row"><span>Routes</span><span>'+(economy.active_routes||0)+'</span></div>'+
-'<div class="row"><span>Freight</span><span>'+(economy.active_deliveries||0)+' in transit</span></div>'+
-Object.entries(nf).sort().map(([n,v])=>'<div class="row"><span>'+n+'</span><span class="'+(v>0?'g':v<-1?'r':'d')+'">'+((v>0?'+':'')+v.toFixed(0))+'/int</span></div>').join('');
-// Power grid
-document.getElementById('power').innerHTML=power.map(x=>{
-const pct=x.consumed_mw>0?Math.min(1,x.generated_mw/x.consumed_mw):1;
-const c=pct<0.5?'#c55':pct<0.8?'#ca4':'#5c5';
-return'<div class="row"><span style="color:'+pc(x.owner)+'">'+x.owner+'</span><span style="color:'+c+'">'+x.generated_mw.toFixed(0)+'/'+x.consumed_mw.toFixed(0)+'MW</span></div>'}).join('')||'<span class="d">No data</span>';
-// Construction queue
-document.getElementById('construction').innerHTML=construction.map(x=>{
-return'<div style="padding:1px 0;color:#889">'+x.name+' <span style="color:'+pc(x.owner)+'">'+x.owner+'</span> <span style="color:#5cf">'+x.progress+'%</span></div>'}).join('')||'<span class="d">Idle</span>';
-// Events
-const evts=ev.data||[];
-document.getElementById('events').innerHTML=evts.map(x=>{
-const c=x.type==='trade'?'#889':x.type==='colonize'?'#7fdbca':x.type==='build'?'#6c6':x.type==='alert'?'#c55':x.type==='event'?'#f0c050':x.type==='news'?'#50c0f0':x.type==='achievement'?'#ffd700':x.type==='explore'?'#c080ff':x.type==='combat'?'#ff4444':x.type==='victory'?'#00ff88':'#889';
-return'<div style="color:'+c+';padding:1px 0">'+x.time+' '+x.message+'</div>'}).join('');
-}catch(e){document.getElementById('status').textContent='Disconnected';document.getElementById('status').style.color='#a44'}}
-async function loadDetail(sysId){
-const dp=document.getElementById('detail');dp.style.display='block';
-document.getElementById('dtitle').textContent=selected?.name||'';
-document.getElementById('dbody').innerHTML='Loading...';
-try{
-const sys=await fetch(B+'/api/systems/'+sysId).then(r=>r.json());
-const planets=sys.data.planets||[];
-let h='<div style="color:#556">'+selected?.star_type+' · '+planets.length+' planets</div>';
-if(selected?.resources?.length)h+='<div style="margin:6px 0;color:#889">Resources: '+selected.resources.join(', ')+'</div>';
-planets.forEach(p=>{
-h+='<div style="margin-top:8px;border-top:1px solid #1a2040;padding-top:6px">';
-h+='<b style="color:#7fdbca">'+p.name+'</b> <span style="color:#556">('+p.planet_type+')</span>';
-h+='<div style="color:#889">Pop: '+p.population.toLocaleString()+' / '+p.population_cap.toLocaleString()+'</div>';
-if(p.owner)h+='<div>Owner: <span style="color:'+pc(p.owner)+'">'+p.owner+'</span></div>';
-if(p.happiness!==undefined){const hc=p.happiness>0.7?'#5c5':p.happiness>0.4?'#ca4':'#c55';h+='<div style="color:'+hc+'">Happy: '+(p.happiness*100).toFixed(0)+'% · Prod: '+p.productivity_bonus.toFixed(1)+'x</div>'}
-if(p.power_consumed>0){const pr=p.power_ratio||0;const pc2=pr>0.8?'#5c5':pr>0.5?'#ca4':'#c55';h+='<div style="color:'+pc2+'">Power: '+(pr*100).toFixed(0)+'% ('+p.power_generated.toFixed(0)+'/'+p.power_consumed.toFixed(0)+' MW)</div>'}
-if(p.buildings?.length){h+='<div style="margin-top:4px">';
-p.buildings.forEach(b=>{
-const col=b.is_operational?'#6c6':'#c55';
-h+='<span style="color:'+col+';margin-right:8px">'+b.type+(b.level>1?' L'+b.level:'')+'</span>'});
-h+='</div>'}
-if(p.stored_resources){h+='<div style="margin-top:4px;font-size:11px">';
-Object.entries(p.stored_resources).sort().forEach(([k,v])=>{
-const col=v===0?'#c55':v>800?'#6c6':'#889';
-h+='<span style="color:'+col+';margin-right:8px">'+k+':'+v+'</span>'});
-h+='</div>'}
-h+='</div>'});
-// Ships at this system
-const sysShips=ships.filter(s=>s.system_id===sysId);
-if(sysShips.length){h+='<div style="margin-top:8px;border-top:1px solid #1a2040;padding-top:6px;color:#889">Ships: ';
-sysShips.forEach(s=>{h+='<span style="color:'+pc(s.owner)+'">'+s.name+'</span> '});h+='</div>'}
-document.getElementById('dbody').innerHTML=h;
-}catch(e){document.getElementById('dbody').innerHTML='<span style="color:#c55">Failed to load</span>'}}
-function sp(s){const pad=80;
-const bx=(s.x/1280)*(W-pad*2)+pad,by=(s.y/720)*(H-pad*2)+pad;
-return[(bx-W/2)*zoom+W/2+panX,(by-H/2)*zoom+H/2+panY]}
-function draw(){
-t+=0.016;
-// Background
-X.fillStyle='#060810';X.fillRect(0,0,W,H);
-// Stars (parallax at 30% of camera for depth)
-stars.forEach(s=>{
-const flicker=0.6+0.4*Math.sin(t*2+s.b*20);
-X.fillStyle=hexA('#ffffff',flicker*0.5*s.s);
-X.fillRect(s.x*W+panX*0.3,s.y*H+panY*0.3,s.s,s.s)});
-if(!systems.length){requestAnimationFrame(draw);return}
-// Hyperlanes
-systems.forEach(s=>{(s.links||[]).forEach(lid=>{
-const tgt=systems.find(x=>x.id===lid);if(!tgt)return;
-const[x1,y1]=sp(s),[x2,y2]=sp(tgt);
-X.strokeStyle='rgba(50,60,90,0.25)';X.lineWidth=1;
-X.beginPath();X.moveTo(x1,y1);X.lineTo(x2,y2);X.stroke()})});
-// Active delivery routes
-deliveries.forEach(d=>{
-const src=systems.find(x=>x.id===d.source_system),dst=systems.find(x=>x.id===d.dest_system);
-if(!src||!dst)return;
-const[x1,y1]=sp(src),[x2,y2]=sp(dst);
-X.strokeStyle='rgba(127,219,202,0.08)';X.lineWidth=3;
-X.beginPath();X.moveTo(x1,y1);X.lineTo(x2,y2);X.stroke()});
-// Trade routes + moving ships
-hoverShip=null;
-const shipPositions=[];
-ships.filter(s=>s.status==='Moving').forEach(s=>{
-const src=systems.find(x=>x.id===s.system_id),tgt=systems.find(x=>x.id===s.target_system);
-if(!src||!tgt)return;
-const c=pc(s.owner);
-// Build full route: current hop + remaining path
-const routeIDs=[s.system_id,s.target_system,...(s.route_path||[])];
-const routeSys=routeIDs.map(id=>systems.find(x=>x.id===id)).filter(Boolean);
-// Draw route line along hyperlanes (only for laden ships)
-if(s.cargo_used>0&&routeSys.length>=2){
-X.strokeStyle=hexA(c,0.15);X.lineWidth=4;X.beginPath();
-const[fx,fy]=sp(routeSys[0]);X.moveTo(fx,fy);
-for(let i=1;i<routeSys.length;i++){const[nx,ny]=sp(routeSys[i]);X.lineTo(nx,ny)}
-X.stroke();
-X.strokeStyle=hexA(c,0.4);X.lineWidth=1;X.setLineDash([8,8]);
-X.beginPath();X.moveTo(fx,fy);
-for(let i=1;i<routeSys.length;i++){const[nx,ny]=sp(routeSys[i]);X.lineTo(nx,ny)}
-X.stroke();X.setLineDash([])}
-// Ship position: lerp along current hop (src→tgt)
-const[x1,y1]=sp(src),[x2,y2]=sp(tgt);
-const p=s.travel_progress||((t*0.3+s.id*0.1)%1);
-const sx=x1+(x2-x1)*p,sy=y1+(y2-y1)*p;
-shipPositions.push({ship:s,x:sx,y:sy});
-// Track highlight
-const isTracked=tracked&&tracked.id===s.id;
-const r=isTracked?5:3;
-X.fillStyle=c;X.shadowColor=c;X.shadowBlur=isTracked?15:8;
-X.beginPath();X.arc(sx,sy,r,0,Math.PI*2);X.fill();
-X.shadowBlur=0;
-if(isTracked){X.strokeStyle='#7fdbca';X.lineWidth=1.5;X.beginPath();X.arc(sx,sy,10,0,Math.PI*2);X.stroke()}
-// Label
-X.fillStyle=hexA(c,0.7);X.font='8px monospace';X.textAlign='center';
-if(s.cargo_used>0)X.fillText(s.cargo_used+'u',sx,sy-10);
-if(isTracked)X.fillText(s.name,sx,sy+14);
-// Hover detect
-if((mx-sx)**2+(my-sy)**2<200)hoverShip=s});
-// Systems
-hover=null;
-systems.forEach(s=>{
-const[sx,sy]=sp(s);
-const owner=s.owner||'';const c=owner?pc(owner):'#556';
-const nP=s.planets||1;
-// Glow for owned systems
-if(owner){X.fillStyle=hexA(c,0.06);X.beginPath();X.arc(sx,sy,20+nP*3,0,Math.PI*2);X.fill()}
-// Orbit rings with animated planets
-for(let i=0;i<nP;i++){
-const r=8+i*5;
-X.strokeStyle=hexA(c,owner?0.2:0.1);X.lineWidth=0.5;
-X.beginPath();X.arc(sx,sy,r,0,Math.PI*2);X.stroke();
-// Planet dot orbiting
-const pa=t*0.5/(i+1)+i*2.1;
-const px=sx+r*Math.cos(pa),py=sy+r*Math.sin(pa);
-X.fillStyle=hexA(c,0.6);X.beginPath();X.arc(px,py,1.5,0,Math.PI*2);X.fill()}
-// Star with glow
-X.fillStyle=c;X.shadowColor=c;X.shadowBlur=owner?12:4;
-X.beginPath();X.arc(sx,sy,owner?3.5:2.5,0,Math.PI*2);X.fill();
-X.shadowBlur=0;
-// System name
-X.fillStyle='#667';X.font='10px monospace';X.textAlign='center';
-X.fillText(s.name,sx,sy+nP*5+16);
-// Owner label
-if(owner){
-const pop=s.population?s.population.toLocaleString():'';
-X.fillStyle=hexA(c,0.8);X.font='9px monospace';
-X.fillText((owner.length>10?owner.slice(0,8)+'..':owner)+(pop?' '+pop:''),sx,sy+nP*5+26)}
-// Selection ring
-if(selected&&selected.id===s.id){
-X.strokeStyle='#7fdbca';X.lineWidth=2;X.setLineDash([4,4]);
-X.beginPath();X.arc(sx,sy,25+nP*3,0,Math.PI*2);X.stroke();X.setLineDash([])}
-// Hover
-if((mx-sx)**2+(my-sy)**2<500*zoom)hover=s});
-// Docked ships
-ships.filter(s=>s.status!=='Moving').forEach(s=>{
-const sys=systems.find(x=>x.id===s.system_id);if(!sys)return;
-const[bx,by]=sp(sys);
-const a=t+s.id*0.7;const r=18*zoom;
-const sx=bx+r*Math.cos(a),sy=by+r*Math.sin(a);
-const isTracked=tracked&&tracked.id===s.id;
-X.fillStyle=hexA(pc(s.owner),isTracked?0.9:0.5);
-X.beginPath();X.arc(sx,sy,isTracked?4:2,0,Math.PI*2);X.fill();
-if(isTracked){X.strokeStyle='#7fdbca';X.lineWidth=1;X.beginPath();X.arc(sx,sy,8,0,Math.PI*2);X.stroke();
-X.fillStyle='#889';X.font='8px monospace';X.textAlign='center';X.fillText(s.name,sx,sy+14)}
-if((mx-sx)**2+(my-sy)**2<150)hoverShip=s});
-// Tracked ship info panel
-if(tracked){
-const ts=ships.find(s=>s.id===tracked.id);
-if(ts){tracked=ts; // update with fresh data
-const dp=document.getElementById('detail');dp.style.display='block';
-const c=pc(ts.owner);
-document.getElementById('dtitle').innerHTML='<span style="color:'+c+'">'+ts.name+'</span>';
-let h='<div style="color:#556">'+ts.type+' · '+ts.owner+'</div>';
-h+='<div style="margin:6px 0"><span style="color:#889">Status:</span> <span style="color:'+(ts.status==='Moving'?'#5cf':'#6c6')+'">'+ts.status+'</span></div>';
-if(ts.target_system>=0){const tgt=systems.find(x=>x.id===ts.target_system);h+='<div><span style="color:#889">Route:</span> SYS-'+ts.system_id+' → '+(tgt?tgt.name:'SYS-'+ts.target_system)+'</div>'}
-// Fuel bar
-const fp=ts.fuel_current/ts.fuel_max;
-h+='<div style="margin:6px 0"><span style="color:#889">Fuel:</span> '+ts.fuel_current+'/'+ts.fuel_max+'</div>';
-h+='<div style="background:#1a2040;border-radius:3px;height:6px;margin:2px 0"><div style="background:'+(fp>0.5?'#5c5':fp>0.25?'#ca4':'#c44')+';width:'+(fp*100)+'%;height:100%;border-radius:3px"></div></div>';
-// Health bar
-const hp=ts.health_current/ts.health_max;
-h+='<div><span style="color:#889">Health:</span> '+ts.health_current+'/'+ts.health_max+'</div>';
-h+='<div style="background:#1a2040;border-radius:3px;height:6px;margin:2px 0"><div style="background:'+(hp>0.5?'#5c5':'#c44')+';width:'+(hp*100)+'%;height:100%;border-radius:3px"></div></div>';
-// Cargo
-h+='<div style="margin:6px 0"><span style="color:#889">Cargo:</span> '+ts.cargo_used+'/'+ts.cargo_max+'</div>';
-if(ts.cargo_hold&&Object.keys(ts.cargo_hold).length){h+='<div style="font-size:11px">';Object.entries(ts.cargo_hold).forEach(([k,v])=>{h+='<span style="color:#7fdbca;margin-right:8px">'+k+': '+v+'</span>'});h+='</div>'}
-document.getElementById('dbody').innerHTML=h}}
-// Tooltip
-const tt=document.getElementById('tooltip');
-if(hoverShip){
-tt.style.display='block';tt.style.left=(mx+15)+'px';tt.style.top=Math.min(my-10,H-100)+'px';
-const s=hoverShip,c=pc(s.owner);
-let h='<b style="color:'+c+'">'+s.name+'</b><br><span style="color:#556">'+s.type+' · '+s.owner+'</span>';
-h+='<br>'+s.status+(s.target_system>=0?' → SYS-'+s.target_system:'');
-h+='<br>Fuel: '+s.fuel_current+'/'+s.fuel_max;
-if(s.cargo_used>0)h+='<br>Cargo: '+s.cargo_used+'/'+s.cargo_max;
-h+='<br><span style="color:#556">Click to track</span>';
-tt.innerHTML=h;C.style.cursor='pointer';
-}else if(hover){
-tt.style.display='block';tt.style.left=(mx+15)+'px';tt.style.top=Math.min(my-10,H-120)+'px';
-let h='<b style="color:#7fdbca">'+hover.name+'</b><br>Planets: '+hover.planets;
-if(hover.owner)h+='<br>Owner: <span style="color:'+pc(hover.owner)+'">'+hover.owner+'</span>';
-if(hover.population)h+='<br>Pop: '+hover.population.toLocaleString();
-if(hover.resources?.length)h+='<br>Resources: '+hover.resources.join(', ');
-const ls=ships.filter(x=>x.system_id===hover.id);
-if(ls.length)h+='<br>Ships: '+ls.length;
-h+='<br><span style="color:#556">Click for details</span>';
-tt.innerHTML=h;C.style.cursor='pointer';
-}else{tt.style.display='none';C.style.cursor=dragging?'grabbing':'default'}
-requestAnimationFrame(draw)}
-load();setInterval(load,3000);draw();
-</script></body></html>`
-
-const dashboardHTML = `<!DOCTYPE html>
-<html><head>
-<meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1.0">
-<title>Xandaris II — Live Economy Dashboard</title>
-<style>
-*{margin:0;padding:0;box-sizing:border-box}
-body{background:#0a0c14;color:#c0c8d8;font-family:'Courier New',monospace;padding:16px 24px}
-h1{color:#7fdbca;margin-bottom:2px;font-size:1.6em;display:inline}
-.sub{color:#445;font-size:12px}
-.grid{display:grid;grid-template-columns:1fr 1fr;gap:10px;margin-top:12px}
-.wide{grid-column:span 2}
-.panel{background:#10142a;border:1px solid #1a2040;border-radius:5px;padding:10px 12px}
-.panel h2{color:#7fdbca;font-size:11px;margin-bottom:6px;letter-spacing:0.5px;text-transform:uppercase;display:flex;justify-content:space-between;align-items:center}
-.panel h2 .tag{font-size:9px;color:#334;font-weight:normal;text-transform:none}
-table{width:100%;border-collapse:collapse;font-size:11px}
-th{text-align:left;color:#445;padding:2px 4px;font-weight:normal;font-size:9px;text-transform:uppercase;letter-spacing:0.3px}
-td{padding:3px 4px}
-.g{color:#5cb85c}.r{color:#d9534f}.o{color:#c8a84e}.d{color:#334}.b{color:#5bc0de}.p{color:#b088df}.w{color:#c0c8d8}
-.bar-bg{background:#0c1020;border-radius:2px;overflow:hidden;height:6px;margin:1px 0}
-.bar-fill{height:100%;border-radius:2px;transition:width 0.5s}
-.spark{font-size:10px;letter-spacing:-1px;color:#5cb85c}
-.event{font-size:10px;padding:3px 0;border-bottom:1px solid #0c1020}
-.rank{color:#7fdbca;font-weight:bold;margin-right:4px}
-.st{position:fixed;bottom:6px;right:10px;font-size:9px;color:#3a5}
-.stats-row{display:flex;gap:6px;margin:10px 0;flex-wrap:wrap;align-items:flex-end}
-.stat{text-align:center;padding:2px 8px}
-.stat .val{font-size:22px;color:#7fdbca;font-weight:bold}
-.stat .lbl{font-size:8px;color:#334;text-transform:uppercase;letter-spacing:0.5px}
-.stat-sep{width:1px;height:30px;background:#1a2040;align-self:center}
-.sg{display:flex;gap:6px;background:#0c1020;border-radius:4px;padding:3px 8px}
-.sg .stat .val{font-size:18px}
-.pwr-grid{display:flex;flex-wrap:wrap;gap:3px}
-.pwr-cell{width:30px;height:30px;border-radius:3px;display:flex;flex-direction:column;align-items:center;justify-content:center;font-size:7px;color:#fff;cursor:default;transition:transform 0.15s}
-.pwr-cell:hover{transform:scale(1.2)}
-.pwr-cell .pct{font-size:10px;font-weight:bold}
-.row{display:flex;justify-content:space-between;align-items:center;padding:3px 0;border-bottom:1px solid #0c1020;font-size:11px}
-.chain{font-size:11px;color:#667;line-height:1.8;padding:2px 0}
-.chain b{color:#7fdbca}.chain .arr{color:#2a3a5a;margin:0 2px}
-.chat{font-size:11px;padding:4px 0;border-bottom:1px solid #0c1020}
-.chat .name{color:#5bc0de;font-weight:bold}
-.res-bar{display:flex;align-items:center;gap:6px;padding:2px 0;font-size:11px}
-.res-bar .lbl{width:80px;color:#889}
-.res-bar .wrap{flex:1;position:relative;height:14px;background:#0c1020;border-radius:2px;overflow:hidden}
-.res-bar .fill{height:100%;border-radius:2px;transition:width 0.5s}
-.res-bar .txt{position:absolute;right:4px;top:0;font-size:9px;line-height:14px;color:#aab}
-.lb-row{display:flex;align-items:center;gap:6px;padding:3px 0;font-size:11px}
-.lb-bar{flex:1;height:10px;background:#0c1020;border-radius:2px;overflow:hidden}
-.lb-fill{height:100%;background:#1a5a3a;border-radius:2px;transition:width 0.5s}
-@media(max-width:800px){.grid{grid-template-columns:1fr}.wide{grid-column:span 1}}
-.ticker-wrap{overflow:hidden;background:#080c18;border:1px solid #1a2040;border-radius:4px;margin:8px 0;height:28px;position:relative}
-.ticker-wrap::before,.ticker-wrap::after{content:'';position:absolute;top:0;bottom:0;width:40px;z-index:1;pointer-events:none}
-.ticker-wrap::before{left:0;background:linear-gradient(to right,#080c18,transparent)}
-.ticker-wrap::after{right:0;background:linear-gradient(to left,#080c18,transparent)}
-.ticker{display:flex;white-space:nowrap;align-items:center;height:100%}
-.tick-item{display:inline-flex;align-items:center;gap:4px;padding:0 16px;font-size:11px;border-right:1px solid #151a30;height:100%;flex-shrink:0}
-.tick-item .res{color:#7fdbca;font-weight:bold}
-.tick-item .buy{color:#5cb85c}
-.tick-item .sell{color:#d9534f}
-.tick-item .who{color:#556}
-.tick-item .price{color:#c8a84e}
-</style></head><body>
-<h1>XANDARIS II</h1> <span class="sub">&mdash; Live Economy</span>
-<div class="stats-row" id="top"></div>
-<div class="ticker-wrap"><div class="ticker" id="ticker"><span class="tick-item" style="color:#334">Loading trades...</span></div></div>
-<div class="grid">
-<div class="panel"><h2>Leaderboard <span class="tag">empire score</span></h2><div id="lb"></div></div>
-<div class="panel"><h2>Faction Chat <span class="tag">live</span></h2><div id="ch" style="max-height:220px;overflow-y:auto"></div></div>
-<div class="panel"><h2>Resource Balance <span class="tag">supply vs demand</span></h2><div id="rf"></div></div>
-<div class="panel"><h2>Power Grid <span class="tag">MW</span></h2><div id="pw"></div></div>
-<div class="panel" style="padding:6px 8px 0;overflow:hidden;position:relative"><div id="priceTickers" style="display:flex;gap:4px;margin-bottom:4px;flex-wrap:wrap"></div><canvas id="priceChart" style="width:100%;height:200px;display:block;cursor:crosshair"></canvas><div id="priceTooltip" style="display:none;position:absolute;background:rgba(8,12,24,0.95);border:1px solid #2a3060;border-radius:4px;padding:6px 8px;font-size:10px;color:#c0c8d8;pointer-events:none;z-index:10;white-space:nowrap"></div></div>
-<div class="panel"><h2>Trading Hubs <span class="tag">top planets by stock</span></h2><div id="hubs"></div></div>
-<div class="panel"><h2>Planet Physics <span class="tag">formation data</span></h2><div id="phys" style="max-height:240px;overflow-y:auto"></div></div>
-<div class="panel wide" style="background:#0d1125;border-color:#152040;padding:0;overflow:hidden"><canvas id="flowCanvas" style="width:100%;height:240px;display:block"></canvas></div>
-<div class="panel"><h2>Shipping Routes <span class="tag">logistics network</span></h2><div id="sr"></div></div>
-<div class="panel"><h2>Galaxy Status <span class="tag">live</span></h2><div id="gs2"></div></div>
-<div class="panel"><h2>Events <span class="tag">activity feed</span></h2><div id="ev" style="max-height:320px;overflow-y:auto"></div></div>
-<div class="panel"><h2>Fleet <span class="tag">ships by faction</span></h2><div id="sh"></div></div>
-</div>
-<div id="s" class="st">Loading...</div>
-<script>
-const B=location.origin,BL='\u2581\u2582\u2583\u2584\u2585\u2586\u2587\u2588';
-function sp(h){if(!h||h.length<3)return'';const mn=Math.min(...h),mx=Math.max(...h),rng=mx-mn||1;return'<span class="spark">'+h.slice(-25).map(v=>BL[Math.min(7,Math.max(0,Math.round((v-mn)/rng*7)))]).join('')+'</span>'}
-// Trade ticker — smooth scrolling tape
-let tickerOffset=0,tickerItems=[],lastTradeId='';
-function updateTicker(){
-const el=document.getElementById('ticker');if(!el||!tickerItems.length)return;
-// Build items HTML (duplicate for seamless loop)
-const html=tickerItems.map(t=>{
-const cls=t.action==='buy'||t.action==='bought'?'buy':'sell';
-const verb=t.action==='buy'||t.action==='bought'?'\u25B2':'\u25BC';
-return'<span class="tick-item"><span class="'+cls+'">'+verb+'</span><span class="res">'+t.resource+'</span><span>'+t.qty+'\u00d7</span><span class="price">'+t.price+'cr</span><span class="who">'+t.player+'</span></span>'}).join('');
-el.innerHTML=html+html; // duplicate for seamless wrap
-el.style.width=(el.scrollWidth/2)+'px';
-}
-function animateTicker(){
-const el=document.getElementById('ticker');if(!el||el.children.length<2)return requestAnimationFrame(animateTicker);
-tickerOffset-=0.5;
-const halfW=el.scrollWidth/2;
-if(Math.abs(tickerOffset)>=halfW)tickerOffset=0;
-el.style.transform='translateX('+tickerOffset+'px)';
-requestAnimationFrame(animateTicker)}
-async function pollTrades(){try{
-const r=await fetch(B+'/api/events?limit=30').then(r=>r.json());
-const trades=(r.data||[]).filter(e=>e.type==='trade');
-if(trades.length>0&&trades[0].message!==lastTradeId){
-lastTradeId=trades[0].message;
-tickerItems=trades.map(t=>{
-const m=t.message;
-const parts=m.match(/(\S+)\s+(bought|sold)\s+(\d+)\s+(\S+(?:\s+\S+)?)\s+@\s+(\d+)/);
-if(!parts)return null;
-return{player:parts[1],action:parts[2],qty:parts[3],resource:parts[4],price:parts[5]};
-}).filter(Boolean);
-updateTicker()}}catch(e){}}
-pollTrades();setInterval(pollTrades,5000);requestAnimationFrame(animateTicker);
-async function R(){try{
-const[e,p,f,g,lb,pw,ev,sh,ch,sr,ph]=await Promise.all(['/api/economy','/api/players','/api/flows','/api/game','/api/leaderboard','/api/power','/api/events?limit=50','/api/ships','/api/chat/messages','/api/shipping/routes','/api/planets/physics'].map(u=>fetch(B+u).then(r=>r.json())));
-const d=g.data;
-const st=(l,v)=>'<div class="stat"><div class="val">'+v+'</div><div class="lbl">'+l+'</div></div>';
-const sep='<div class="stat-sep"></div>';
-document.getElementById('top').innerHTML=
-st('Time',d.game_time)+st('Speed',d.speed+(d.paused?' \u23F8':''))+sep+
-'<div class="sg">'+st('Pop',e.data.total_population.toLocaleString())+st('Planets',e.data.total_planets||0)+st('Systems',d.systems)+'</div>'+sep+
-'<div class="sg">'+st('GDP',(e.data.gdp||0).toLocaleString(undefined,{maximumFractionDigits:0}))+st('Credits',e.data.total_credits.toLocaleString())+st('Trade',e.data.trade_volume.toFixed(0))+'</div>'+sep+
-'<div class="sg">'+st('Routes',e.data.active_routes||0)+st('Freight',e.data.active_deliveries||0)+'</div>';
-// Leaderboard with bars
-const maxScore=Math.max(...(lb.data||[]).map(x=>x.score),1);
-document.getElementById('lb').innerHTML=(lb.data||[]).map(x=>{
-const c=x.type=='human'?'b':'w';const pct=(x.score/maxScore*100).toFixed(0);
-return'<div class="lb-row"><span class="rank">#'+x.rank+'</span><span class="'+c+'" style="width:90px">'+x.name+'</span><div class="lb-bar"><div class="lb-fill" style="width:'+pct+'%"></div></div><span class="d" style="width:60px;text-align:right;font-size:10px">'+x.score.toLocaleString()+'</span></div>'}).join('');
-// Resource balance with visual bars
-const fl=f.data,pr=fl.production,co=fl.consumption;
-const allRes=[...new Set([...Object.keys(pr),...Object.keys(co)])].sort();
-const maxFlow=Math.max(...allRes.map(r=>Math.max(pr[r]||0,co[r]||0)),1);
-document.getElementById('rf').innerHTML=allRes.map(r=>{
-const pv=pr[r]||0,cv=co[r]||0,nv=pv-cv;
-const pw2=Math.round(pv/maxFlow*100),cw=Math.round(cv/maxFlow*100);
-const nc=nv>1?'g':nv<-1?'r':'d';
-return'<div class="res-bar"><span class="lbl">'+r+'</span><div class="wrap"><div class="fill" style="width:'+pw2+'%;background:#1a4a2a"></div><div class="fill" style="width:'+cw+'%;background:#4a1a1a;position:absolute;top:0;left:0;opacity:0.6"></div><div class="txt '+nc+'">'+(nv>0?'+':'')+nv.toFixed(0)+'/s</div></div></div>'}).join('');
-// Power — compact rows with inline tiles + sparkline
-const byOwner={};(pw.data||[]).forEach(x=>{if(!byOwner[x.owner])byOwner[x.owner]=[];byOwner[x.owner].push(x)});
-document.getElementById('pw').innerHTML=Object.entries(byOwner).sort().map(([owner,planets])=>{
-const totalGen=planets.reduce((s,p)=>s+p.generated_mw,0);
-const totalCons=planets.reduce((s,p)=>s+p.consumed_mw,0);
-const avgPct=totalCons>0?Math.min(1,totalGen/totalCons):1;
-let hist=[];planets.forEach(p=>{if(p.history&&p.history.length>hist.length)hist=p.history});
-const spark=hist.length>3?sp(hist):'';
-const tiles=planets.map(p=>{
-const pct=p.consumed_mw>0?Math.min(1,p.generated_mw/p.consumed_mw):1;
-const bg2=pct<0.3?'#4a1515':pct<0.5?'#4a2a15':pct<0.8?'#3a3a15':'#153a15';
-return'<span style="display:inline-block;width:16px;height:16px;border-radius:2px;background:'+bg2+'" title="'+p.planet_name+': '+(pct*100).toFixed(0)+'%"></span>'}).join('');
-return'<div style="display:flex;align-items:center;gap:6px;padding:3px 0;border-bottom:1px solid #0c1020;font-size:11px"><span style="width:85px;color:#7fdbca;white-space:nowrap;overflow:hidden;text-overflow:ellipsis">'+owner+'</span><span style="display:flex;gap:2px">'+tiles+'</span><span style="flex:1;text-align:right">'+spark+'</span><span class="d" style="width:55px;text-align:right;font-size:9px">'+(avgPct*100).toFixed(0)+'%</span></div>'}).join('');
-// Market — store data for interactive chart
-window._marketData=e.data.resources;
-// Trading hubs — top planets by total stock value
-const hubs=[];(p.data||[]).forEach(pl=>{pl.planets&&pl.planets.forEach&&0;/* players don't have planets inline */});
-// Build from players data — sort by stock
-const hubData=(p.data||[]).sort((a,b)=>b.stock-a.stock).slice(0,8);
-document.getElementById('hubs').innerHTML=hubData.map(x=>{
-const maxStock=Math.max(...hubData.map(h=>h.stock),1);
-const bw2=Math.round(x.stock/maxStock*100);
-const tc=x.type=='human'?'b':'w';
-return'<div style="display:flex;align-items:center;gap:6px;padding:3px 0;border-bottom:1px solid #0c1020;font-size:11px"><span class="'+tc+'" style="width:85px;white-space:nowrap;overflow:hidden;text-overflow:ellipsis">'+x.name+'</span><div style="flex:1;height:8px;background:#0c1020;border-radius:2px;overflow:hidden"><div style="height:100%;width:'+bw2+'%;background:#1a3a5a;border-radius:2px"></div></div><span class="d" style="width:45px;text-align:right;font-size:10px">'+x.stock.toLocaleString()+'</span><span class="d" style="width:20px;text-align:right;font-size:9px">'+x.planets+'p</span></div>'}).join('');
-// Production chains — update flow data for canvas animation
-window._flowProd=pr;window._flowCons=co;
-// Chat
-document.getElementById('ch').innerHTML=(ch.data||[]).map(x=>{
-return'<div class="chat"><span class="d">['+x.time+']</span> <span class="name">'+x.player+'</span> '+x.message+'</div>'}).join('')||'<div class="d" style="padding:20px;text-align:center;font-size:12px">Waiting for factions to chat...</div>';
-// Events
-document.getElementById('ev').innerHTML=(ev.data||[]).map(x=>{
-const c=x.type=='trade'?'g':x.type=='build'?'b':x.type=='alert'?'r':x.type=='event'?'o':x.type=='intel'?'b':x.type=='logistics'?'p':x.type=='explore'?'o':x.type=='combat'||x.type=='military'?'r':x.type=='victory'?'o':x.type=='join'||x.type=='colonize'?'p':'d';
-return'<div class="event"><span class="d">['+x.time+']</span> <span class="'+c+'">'+x.message+'</span></div>'}).join('');
-// Ships
-const so={};(sh.data||[]).forEach(s=>{if(!so[s.owner])so[s.owner]=[];so[s.owner].push(s)});
-document.getElementById('sh').innerHTML=Object.entries(so).sort().map(([o,ss])=>{
-const t={};ss.forEach(s=>{t[s.type]=(t[s.type]||0)+1});const mv=ss.filter(s=>s.status==='Moving').length;
-return'<div class="row"><span>'+o+'</span><span class="d">'+Object.entries(t).map(([k,v])=>v+'\u00d7'+k).join(' ')+(mv?' <span class="o">('+mv+' moving)</span>':'')+'</span></div>'}).join('');
-// Planet Physics panel
-const planets=(ph.data||[]);
-if(planets.length>0){
-const CC={iron:'#b4784f',silicate:'#8a7050',water:'#508cc8',gas:'#b4dcff',organics:'#50a050',rare_earth:'#c8b464'};
-let phH='';
-planets.sort((a,b)=>b.mass-a.mass).slice(0,12).forEach(pl=>{
-const compBar=[
-{k:'iron',v:pl.iron,c:CC.iron},{k:'sil',v:pl.silicate,c:CC.silicate},
-{k:'h2o',v:pl.water,c:CC.water},{k:'gas',v:pl.gas,c:CC.gas},
-{k:'org',v:pl.organics,c:CC.organics},{k:'re',v:pl.rare_earth*10,c:CC.rare_earth}]
-.filter(x=>x.v>0.02).map(x=>'<span style="display:inline-block;height:8px;width:'+Math.max(2,x.v*120)+'px;background:'+x.c+';border-radius:1px" title="'+x.k+': '+(x.v*100).toFixed(1)+'%"></span>').join('');
-const oc=pl.owner?'b':'d';
-phH+='<div style="display:flex;align-items:center;gap:6px;padding:3px 0;border-bottom:1px solid #0c1020;font-size:10px">';
-phH+='<span style="width:75px;color:#7fdbca;white-space:nowrap;overflow:hidden;text-overflow:ellipsis">'+pl.name+'</span>';
-phH+='<span class="d" style="width:55px;font-size:9px">'+pl.type+'</span>';
-phH+='<span style="width:40px;text-align:right">'+pl.mass.toFixed(1)+'M\u2295</span>';
-phH+='<span style="width:35px;text-align:right;color:#889">'+pl.gravity.toFixed(1)+'g</span>';
-phH+='<span style="flex:1;display:flex;gap:1px">'+compBar+'</span>';
-phH+='<span class="'+oc+'" style="width:50px;font-size:9px;text-align:right;overflow:hidden;text-overflow:ellipsis">'+( pl.owner||'unclaimed')+'</span>';
-phH+='</div>'});
-document.getElementById('phys').innerHTML=phH;
-}else{document.getElementById('phys').innerHTML='<div class="d" style="padding:20px;text-align:center;font-size:11px">No formation data (legacy planets)</div>'}
-// Shipping Routes panel
-const routes=(sr.data||[]);
-const activeR=routes.filter(r=>r.active!==false);
-const deliveringR=activeR.filter(r=>r.trips_complete>0);
-const totalTrips=activeR.reduce((s,r)=>s+(r.trips_complete||0),0);
-const srByOwner={};activeR.forEach(r=>{if(!srByOwner[r.owner])srByOwner[r.owner]={routes:0,trips:0,delivering:0};srByOwner[r.owner].routes++;srByOwner[r.owner].trips+=(r.trips_complete||0);if(r.trips_complete>0)srByOwner[r.owner].delivering++});
-let srH='<div style="display:flex;gap:12px;margin-bottom:6px;font-size:11px"><span class="g">'+activeR.length+' routes</span><span class="b">'+deliveringR.length+' delivering</span><span class="o">'+totalTrips+' total trips</span></div>';
-srH+=Object.entries(srByOwner).sort((a,b)=>b[1].trips-a[1].trips).map(([name,d])=>{
-const health=d.routes>0?Math.round(d.delivering/d.routes*100):0;
-const hc=health>=80?'g':health>=50?'o':health>0?'r':'d';
-return'<div class="row"><span>'+name+'</span><span class="d">'+d.routes+'r '+d.delivering+'d '+d.trips+'t <span class="'+hc+'">'+health+'%</span></span></div>'}).join('');
-// Top routes by trips
-const topR=activeR.filter(r=>r.trips_complete>0).sort((a,b)=>b.trips_complete-a.trips_complete).slice(0,5);
-if(topR.length>0){srH+='<div style="margin-top:6px;font-size:9px;color:#334;text-transform:uppercase">Top Routes</div>';
-srH+=topR.map(r=>'<div class="row"><span class="g">#'+r.id+' '+r.resource+'</span><span class="d">'+r.owner+' \u2022 '+r.trips_complete+' trips</span></div>').join('')}
-document.getElementById('sr').innerHTML=srH;
-// Galaxy Status panel
-const tick=d.tick||0;const cyclePos=tick%20000;
-const season=cyclePos<5000?'\uD83C\uDF31 Spring':cyclePos<10000?'\u2600\uFE0F Summer':cyclePos<15000?'\uD83C\uDF42 Autumn':'\u2744\uFE0F Winter';
-const gameHours=Math.floor(tick/36000);const gameMins=Math.floor((tick%36000)/600);
-// Extract intel events for status
-const intelEvents=(ev.data||[]).filter(x=>x.type==='intel'||x.type==='event').slice(0,8);
-let gs2H='<div style="display:flex;gap:8px;margin-bottom:8px;flex-wrap:wrap">';
-gs2H+='<span class="stat" style="padding:2px 6px"><span class="val" style="font-size:14px">'+season+'</span><span class="lbl">season</span></span>';
-gs2H+='<span class="stat" style="padding:2px 6px"><span class="val" style="font-size:14px">'+gameHours+'h'+String(gameMins).padStart(2,'0')+'m</span><span class="lbl">galaxy age</span></span>';
-gs2H+='<span class="stat" style="padding:2px 6px"><span class="val" style="font-size:14px">'+(e.data.total_credits||0).toLocaleString()+'</span><span class="lbl">galaxy wealth</span></span>';
-gs2H+='</div>';
-gs2H+='<div style="font-size:9px;color:#334;text-transform:uppercase;margin-bottom:4px">Latest Intel</div>';
-gs2H+=intelEvents.map(x=>{
-const ic=x.type==='intel'?'b':'o';
-return'<div class="event"><span class="'+ic+'">'+x.message+'</span></div>'}).join('');
-document.getElementById('gs2').innerHTML=gs2H;
-document.getElementById('s').textContent='Live \u2022 '+new Date().toLocaleTimeString();
-}catch(err){document.getElementById('s').textContent='Disconnected';document.getElementById('s').style.color='#a44'}}
-R();setInterval(R,3000);
-// === Candlestick Price Chart ===
-(function(){
-const pc=document.getElementById('priceChart');if(!pc)return;
-const cx=pc.getContext('2d');
-const tt=document.getElementById('priceTooltip');
-const tickers=document.getElementById('priceTickers');
-function resize(){pc.width=pc.offsetWidth*2;pc.height=pc.offsetHeight*2;cx.scale(2,2)}
-resize();addEventListener('resize',resize);
-const W=()=>pc.width/2,H=()=>pc.height/2;
-const RC={Iron:'#b4784f',Water:'#508cc8',Oil:'#808080','Rare Metals':'#c8b464','Helium-3':'#b4dcff',Fuel:'#50a050',Electronics:'#5090d0'};
-const UP='#26a69a',DN='#ef5350'; // green/red candle colors
-let selected='Iron';
-// Build ticker buttons
-function buildTickers(){
-const res=window._marketData;if(!res)return;
-tickers.innerHTML=Object.keys(res).sort().map(n=>{
-const r=res[n],c=RC[n]||'#888';
-const cur=r.buy_price,base=r.base_price,diff=cur-base;
-const dc=diff>0?DN:UP;
-const sel=n===selected;
-return'<span data-res="'+n+'" style="cursor:pointer;padding:3px 8px;border-radius:3px;font-size:10px;'
-+'background:'+(sel?'#151a30':'transparent')+';border:1px solid '+(sel?c+'60':'#1a2040')
-+';display:inline-flex;align-items:center;gap:4px">'
-+'<span style="color:'+c+'">\u25CF</span>'
-+'<span style="color:'+(sel?'#ccd':'#556')+'">'+n+'</span>'
-+'<b style="color:'+(sel?'#ccd':'#556')+'">'+cur.toFixed(0)+'</b>'
-+'<span style="color:'+dc+';font-size:9px">'+(diff>0?'+':'')+diff.toFixed(0)+'</span>'
-+'</span>'}).join('');
-tickers.querySelectorAll('span[data-res]').forEach(el=>{
-el.onclick=()=>{selected=el.dataset.res;buildTickers()}})}
-buildTickers();setInterval(buildTickers,3000);
-// Mouse
-let mouseX=-1,mouseIn=false;
-pc.addEventListener('mousemove',e=>{const r=pc.getBoundingClientRect();mouseX=e.clientX-r.left;mouseIn=true});
-pc.addEventListener('mouseleave',()=>{mouseIn=false;tt.style.display='none'});
-// Build OHLC candles from price history (group every 3 ticks)
-function buildCandles(hist){
-const candles=[],gs=3;
-for(let i=0;i<hist.length;i+=gs){
-const slice=hist.slice(i,i+gs);
-candles.push({o:slice[0],h:Math.max(...slice),l:Math.min(...slice),c:slice[slice.length-1]})}
-return candles}
-function drawChart(){
-const w=W(),h=H();
-cx.clearRect(0,0,w,h);
-const res=window._marketData;
-if(!res||!res[selected]){cx.fillStyle='#334';cx.font='11px monospace';cx.textAlign='center';cx.fillText('Waiting for data...',w/2,h/2);requestAnimationFrame(drawChart);return}
-const r=res[selected],hist=r.price_history||[];
-if(hist.length<3){requestAnimationFrame(drawChart);return}
-const c=RC[selected]||'#888';
-const candles=buildCandles(hist);
-const pad={t:14,b:14,l:44,r:16};
-const cw=w-pad.l-pad.r,ch=h-pad.t-pad.b;
-// Y range from candle data
-let yMin=Infinity,yMax=-Infinity;
-candles.forEach(cd=>{if(cd.l<yMin)yMin=cd.l;if(cd.h>yMax)yMax=cd.h});
-// Include base price in range
-if(r.base_price<yMin)yMin=r.base_price;if(r.base_price>yMax)yMax=r.base_price;
-const yPad=(yMax-yMin)*0.12||5;yMin-=yPad;yMax+=yPad;
-if(yMin<0)yMin=0;
-const toY=v=>pad.t+ch*(1-(v-yMin)/(yMax-yMin));
-// Grid
-cx.strokeStyle='#131828';cx.lineWidth=0.5;
-const range=yMax-yMin;
-let step=Math.pow(10,Math.floor(Math.log10(range)));
-if(range/step<3)step/=2;if(range/step>8)step*=2;
-for(let v=Math.ceil(yMin/step)*step;v<=yMax;v+=step){
-const y=toY(v);
-cx.beginPath();cx.moveTo(pad.l,y);cx.lineTo(pad.l+cw,y);cx.stroke();
-cx.fillStyle='#2a2a3a';cx.font='8px monospace';cx.textAlign='right';
-cx.fillText(v.toFixed(0),pad.l-4,y+3)}
-// Base price line
-const baseY=toY(r.base_price);
-cx.strokeStyle=c+'25';cx.lineWidth=0.5;cx.setLineDash([4,4]);
-cx.beginPath();cx.moveTo(pad.l,baseY);cx.lineTo(pad.l+cw,baseY);cx.stroke();
-cx.setLineDash([]);
-cx.fillStyle=c+'40';cx.font='7px monospace';cx.textAlign='left';
-cx.fillText('base '+r.base_price.toFixed(0),pad.l+2,baseY-3);
-// Candles
-const candleW=Math.max(3,Math.min(12,(cw/candles.length)*0.7));
-const gap=cw/candles.length;
-let hoverCandle=-1;
-candles.forEach((cd,i)=>{
-const x=pad.l+gap*i+gap/2;
-const bull=cd.c>=cd.o;
-const col=bull?UP:DN;
-// Wick (high-low line)
-cx.strokeStyle=col+'80';cx.lineWidth=1;
-cx.beginPath();cx.moveTo(x,toY(cd.h));cx.lineTo(x,toY(cd.l));cx.stroke();
-// Body (open-close rect)
-const bodyTop=toY(Math.max(cd.o,cd.c)),bodyBot=toY(Math.min(cd.o,cd.c));
-const bodyH=Math.max(1,bodyBot-bodyTop);
-if(bull){cx.fillStyle=col+'30';cx.strokeStyle=col;cx.lineWidth=1;
-cx.fillRect(x-candleW/2,bodyTop,candleW,bodyH);cx.strokeRect(x-candleW/2,bodyTop,candleW,bodyH)}
-else{cx.fillStyle=col;cx.fillRect(x-candleW/2,bodyTop,candleW,bodyH)}
-// Hover detect
-if(mouseIn){
-const mx2=mouseX*2;
-if(Math.abs(mx2-x)<gap/2)hoverCandle=i}});
-// Current price label on right edge
-const lastPrice=hist[hist.length-1];
-const lastY=toY(lastPrice);
-const lastDiff=lastPrice-r.base_price;
-cx.fillStyle=lastDiff>=0?DN:UP;
-cx.font='bold 10px monospace';cx.textAlign='left';
-cx.fillText(lastPrice.toFixed(0),pad.l+cw+4,lastY+4);
-// Hover tooltip
-if(hoverCandle>=0&&hoverCandle<candles.length){
-const cd=candles[hoverCandle];
-const x=pad.l+gap*hoverCandle+gap/2;
-// Highlight candle
-cx.strokeStyle='#ffffff30';cx.lineWidth=0.5;
-cx.beginPath();cx.moveTo(x,pad.t);cx.lineTo(x,pad.t+ch);cx.stroke();
-const bull=cd.c>=cd.o;
-tt.style.display='block';
-tt.innerHTML='<div style="color:'+c+';font-weight:bold;margin-bottom:3px">'+selected+'</div>'
-+'<div style="display:grid;grid-template-columns:auto auto;gap:1px 8px;font-size:10px">'
-+'<span style="color:#556">O</span><span>'+cd.o.toFixed(1)+'</span>'
-+'<span style="color:#556">H</span><span style="color:#5cb85c">'+cd.h.toFixed(1)+'</span>'
-+'<span style="color:#556">L</span><span style="color:#d9534f">'+cd.l.toFixed(1)+'</span>'
-+'<span style="color:#556">C</span><span style="color:'+(bull?UP:DN)+'">'+cd.c.toFixed(1)+'</span>'
-+'</div>';
-const tr=pc.getBoundingClientRect();
-let tx=mouseX+12,ty=20;
-if(mouseX>tr.width*0.7)tx=mouseX-tt.offsetWidth-12;
-tt.style.left=tx+'px';tt.style.top=ty+'px'}
-requestAnimationFrame(drawChart)}
-drawChart()})();
-// === Flow Diagram Canvas ===
-(function(){
-const fc=document.getElementById('flowCanvas');if(!fc)return;
-const fx=fc.getContext('2d');
-function resizeFlow(){fc.width=fc.offsetWidth*2;fc.height=fc.offsetHeight*2;fx.scale(2,2)}
-resizeFlow();addEventListener('resize',resizeFlow);
-const W=()=>fc.width/2,H=()=>fc.height/2;
-// Layout: 3 rows
-// Row 1 (y=0.22): Iron → Factory → Electronics → Tech Level
-// Row 2 (y=0.50): Mines → Water/RM/He-3 → (middle) → Power → Happiness → Pop Growth
-// Row 3 (y=0.78): Oil → Refinery → Fuel → Generator
-const BW=52,BH=28; // box size
-const nodes=[
-// Col 1: extraction
-{id:'mine',label:'\u26CF Mines',x:0.06,y:0.50,c:'#8a7050',kind:'building'},
-// Col 2: raw resources
-{id:'iron',label:'Iron',x:0.19,y:0.22,c:'#b4784f',res:'Iron'},
-{id:'water',label:'Water',x:0.19,y:0.50,c:'#508cc8',res:'Water'},
-{id:'oil',label:'Oil',x:0.19,y:0.78,c:'#808080',res:'Oil'},
-{id:'rm',label:'Rare Metals',x:0.32,y:0.22,c:'#c8b464',res:'Rare Metals'},
-{id:'he3',label:'Helium-3',x:0.32,y:0.50,c:'#b4dcff',res:'Helium-3'},
-// Col 3: processing
-{id:'factory',label:'\u2699 Factory',x:0.45,y:0.22,c:'#b482ff',kind:'building'},
-{id:'refinery',label:'\u2699 Refinery',x:0.45,y:0.78,c:'#c88232',kind:'building'},
-// Col 4: products
-{id:'elec',label:'Electronics',x:0.58,y:0.22,c:'#5090d0',res:'Electronics'},
-{id:'fuel',label:'Fuel',x:0.58,y:0.78,c:'#50a050',res:'Fuel'},
-// Col 5: power
-{id:'gen',label:'\u26A1 Generator',x:0.72,y:0.78,c:'#ffa030',kind:'building'},
-{id:'fusion',label:'\u26A1 Fusion',x:0.72,y:0.50,c:'#64dcff',kind:'building'},
-{id:'tech',label:'Tech Level',x:0.72,y:0.22,c:'#a0a0ff',kind:'outcome'},
-// Col 6: outcomes
-{id:'power',label:'\u26A1 Power',x:0.85,y:0.64,c:'#ffcc00',kind:'outcome'},
-{id:'happy',label:'\u263A Happiness',x:0.85,y:0.36,c:'#50c878',kind:'outcome'},
-{id:'growth',label:'\u2191 Growth',x:0.95,y:0.50,c:'#7fdbca',kind:'outcome'},
-];
-const edges=[
-{from:'mine',to:'iron',c:'#b4784f'},{from:'mine',to:'water',c:'#508cc8'},{from:'mine',to:'oil',c:'#808080'},
-{from:'mine',to:'rm',c:'#c8b464'},{from:'mine',to:'he3',c:'#b4dcff'},
-{from:'oil',to:'refinery',c:'#808080',lbl:'2\u00d7 Oil'},
-{from:'iron',to:'factory',c:'#b4784f',lbl:'1\u00d7 Iron'},
-{from:'rm',to:'factory',c:'#c8b464',lbl:'2\u00d7 RM'},
-{from:'refinery',to:'fuel',c:'#50a050',lbl:'\u2192 3\u00d7 Fuel'},
-{from:'factory',to:'elec',c:'#5090d0',lbl:'\u2192 2\u00d7 Elec'},
-{from:'fuel',to:'gen',c:'#ffa030',lbl:'burns Fuel'},
-{from:'he3',to:'fusion',c:'#64dcff',lbl:'burns He-3'},
-{from:'gen',to:'power',c:'#ffcc00',lbl:'50 MW'},
-{from:'fusion',to:'power',c:'#ffcc00',lbl:'200 MW'},
-{from:'power',to:'happy',c:'#50c878'},
-{from:'elec',to:'tech',c:'#a0a0ff',lbl:'+3%/lvl'},
-{from:'happy',to:'growth',c:'#7fdbca'},
-];
-const nMap={};nodes.forEach(n=>nMap[n.id]=n);
-// Particles
-let particles=[];
-function spawnParticles(){
-edges.forEach(e=>{
-const pr=window._flowProd||{},co=window._flowCons||{};
-const a=nMap[e.from],b=nMap[e.to];
-// Flow rate determines particle density
-let rate=3;
-if(b&&b.res){rate=Math.max(1,(pr[b.res]||0))}
-else if(a&&a.res){rate=Math.max(1,(pr[a.res]||0))}
-const count=Math.min(4,Math.max(1,Math.round(rate/6)));
-for(let i=0;i<count;i++){
-particles.push({e,t:Math.random(),speed:0.0006+Math.random()*0.001})}})}
-spawnParticles();setInterval(()=>{particles=[];spawnParticles()},15000);
-function drawFlow(){
-const w=W(),h=H();
-fx.clearRect(0,0,w,h);
-// Title
-fx.fillStyle='#334';fx.font='9px monospace';fx.textAlign='left';
-fx.fillText('PRODUCTION CHAINS',8,14);
-// Edges (draw first, behind nodes)
-edges.forEach(e=>{
-const a=nMap[e.from],b=nMap[e.to];if(!a||!b)return;
-const x1=a.x*w,y1=a.y*h,x2=b.x*w,y2=b.y*h;
-fx.strokeStyle=e.c+'18';fx.lineWidth=1;
-fx.beginPath();fx.moveTo(x1,y1);fx.lineTo(x2,y2);fx.stroke();
-// Small arrow
-const ang=Math.atan2(y2-y1,x2-x1),d=5;
-const ax=x2-d*2*Math.cos(ang),ay=y2-d*2*Math.sin(ang);
-fx.fillStyle=e.c+'30';fx.beginPath();
-fx.moveTo(ax,ay);fx.lineTo(ax-d*Math.cos(ang-0.4),ay-d*Math.sin(ang-0.4));
-fx.lineTo(ax-d*Math.cos(ang+0.4),ay-d*Math.sin(ang+0.4));fx.fill();
-// Edge label
-if(e.lbl){
-const mx=(x1+x2)/2,my=(y1+y2)/2;
-fx.fillStyle='#3a3a50';fx.font='7px monospace';fx.textAlign='center';
-fx.fillText(e.lbl,mx,my-4)}});
-// Particles — subtle glowing dots
-particles.forEach(p=>{
-p.t+=p.speed;if(p.t>1)p.t-=1;
-const a=nMap[p.e.from],b=nMap[p.e.to];if(!a||!b)return;
-const px=a.x*w+(b.x-a.x)*w*p.t,py=a.y*h+(b.y-a.y)*h*p.t;
-const alpha=p.t<0.08?p.t/0.08:p.t>0.92?(1-p.t)/0.08:1;
-// Soft glow
-fx.globalAlpha=alpha*0.08;fx.fillStyle=p.e.c;
-fx.beginPath();fx.arc(px,py,4,0,Math.PI*2);fx.fill();
-// Core dot
-fx.globalAlpha=alpha*0.35;
-fx.beginPath();fx.arc(px,py,1.2,0,Math.PI*2);fx.fill();
-fx.globalAlpha=1});
-// Nodes — rectangular boxes
-nodes.forEach(n=>{
-const nx=n.x*w,ny=n.y*h;
-const bw=n.kind?BW+8:BW,bh=BH;
-// Box background — subtle rounded rect
-fx.fillStyle=n.c+'10';
-fx.beginPath();fx.roundRect(nx-bw/2,ny-bh/2,bw,bh,5);fx.fill();
-fx.strokeStyle=n.c+'25';fx.lineWidth=0.5;
-fx.beginPath();fx.roundRect(nx-bw/2,ny-bh/2,bw,bh,5);fx.stroke();
-// Label
-fx.fillStyle=n.kind?n.c+'aa':'#99a';fx.font='10px monospace';
-fx.textAlign='center';fx.textBaseline='middle';
-fx.fillText(n.label,nx,ny);
-fx.textBaseline='alphabetic';
-// Flow rate for resource nodes — small, below box
-if(n.res){
-const pr=window._flowProd||{},co=window._flowCons||{};
-const v=(pr[n.res]||0)-(co[n.res]||0);
-const fc2=v>0?'#4a9a4a':v<-1?'#9a4a4a':'#3a3a4a';
-fx.fillStyle=fc2;fx.font='8px monospace';fx.textAlign='center';
-fx.fillText((v>0?'+':'')+v.toFixed(0)+'/s',nx,ny+bh/2+10)}});
-requestAnimationFrame(drawFlow)}
-drawFlow()})();
-</script></body></html>`
-
-const logisticsHTML = `<!DOCTYPE html>
-<html><head>
-<meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1.0">
-<title>Xandaris II — Logistics Command</title>
-<style>
-*{margin:0;padding:0;box-sizing:border-box}
-body{background:#0a0c14;color:#c0c8d8;font-family:'Courier New',monospace;padding:16px 24px}
-h1{color:#7fdbca;font-size:1.6em;display:inline}
-.sub{color:#445;font-size:12px}
-a{color:#5bc0de;text-decoration:none}
-a:hover{text-decoration:underline}
-.stats-row{display:flex;gap:8px;margin:12px 0;flex-wrap:wrap;align-items:flex-end}
-.stat{text-align:center;padding:2px 10px}
-.stat .val{font-size:26px;color:#7fdbca;font-weight:bold}
-.stat .lbl{font-size:8px;color:#334;text-transform:uppercase;letter-spacing:0.5px}
-.stat-sep{width:1px;height:30px;background:#1a2040;align-self:center}
-.filters{display:flex;gap:6px;margin:8px 0;flex-wrap:wrap}
-.filters button{background:#10142a;border:1px solid #1a2040;color:#889;padding:4px 12px;border-radius:3px;font-family:inherit;font-size:11px;cursor:pointer}
-.filters button.active{border-color:#7fdbca;color:#7fdbca}
-.filters button:hover{border-color:#3a5a7a}
-.route-list{display:flex;flex-direction:column;gap:6px;margin-top:10px}
-.route{background:#10142a;border:1px solid #1a2040;border-radius:6px;padding:12px 16px;transition:border-color 0.2s}
-.route:hover{border-color:#2a3a5a}
-.route-header{display:flex;justify-content:space-between;align-items:center;margin-bottom:8px}
-.route-id{color:#7fdbca;font-weight:bold;font-size:13px}
-.route-owner{color:#5bc0de;font-size:11px}
-.route-status{padding:2px 8px;border-radius:3px;font-size:9px;text-transform:uppercase;font-weight:bold}
-.st-ready{background:#1a3a1a;color:#5cb85c;border:1px solid #2a5a2a}
-.st-in_transit{background:#1a2a4a;color:#5bc0de;border:1px solid #2a4a6a}
-.st-no_fuel{background:#3a2a1a;color:#c8a84e;border:1px solid #5a4a2a}
-.st-no_stock{background:#3a1a2a;color:#d9534f;border:1px solid #5a2a3a}
-.st-no_ship{background:#1a1a2a;color:#556;border:1px solid #2a2a3a}
-.route-path{display:flex;align-items:center;gap:8px;margin:6px 0;font-size:12px}
-.route-path .node{background:#0c1020;padding:4px 10px;border-radius:4px;border:1px solid #1a2040}
-.route-path .arrow{color:#2a4a3a;font-size:16px}
-.route-path .res{color:#c8a84e;font-weight:bold}
-.route-details{display:flex;gap:16px;font-size:10px;color:#556;margin-top:6px;flex-wrap:wrap}
-.route-details .detail{display:flex;gap:4px}
-.route-details .detail b{color:#889}
-.bar-sm{width:80px;height:6px;background:#0c1020;border-radius:3px;overflow:hidden;display:inline-block;vertical-align:middle}
-.bar-sm .fill{height:100%;border-radius:3px}
-.trips-chart{display:flex;align-items:flex-end;gap:1px;height:40px;margin-top:8px}
-.trips-bar{background:#1a4a2a;border-radius:2px 2px 0 0;min-width:4px;flex:1;transition:height 0.3s}
-.g{color:#5cb85c}.r{color:#d9534f}.o{color:#c8a84e}.b{color:#5bc0de}.d{color:#334}
-.summary-grid{display:grid;grid-template-columns:repeat(auto-fit,minmax(200px,1fr));gap:10px;margin:12px 0}
-.summary-card{background:#10142a;border:1px solid #1a2040;border-radius:6px;padding:12px}
-.summary-card h3{color:#7fdbca;font-size:10px;text-transform:uppercase;letter-spacing:0.5px;margin-bottom:8px}
-.faction-row{display:flex;justify-content:space-between;align-items:center;padding:4px 0;border-bottom:1px solid #0c1020;font-size:11px}
-.st2{position:fixed;bottom:6px;right:10px;font-size:9px;color:#3a5}
-</style></head><body>
-<h1>LOGISTICS COMMAND</h1> <span class="sub">&mdash; <a href="/data">Dashboard</a> &bull; Shipping Routes &amp; Fleet</span>
-<div class="stats-row" id="topStats"></div>
-<div class="filters" id="filters"></div>
-<div class="summary-grid" id="summary"></div>
-<div class="route-list" id="routes"></div>
-<div id="st2" class="st2">Loading...</div>
-<script>
-const B=location.origin;
-let filter='all',sortBy='trips';
-async function R(){try{
-const[data,ev]=await Promise.all([
-fetch(B+'/api/logistics').then(r=>r.json()),
-fetch(B+'/api/events?limit=20').then(r=>r.json())]);
-const d=data.data;const routes=d.routes||[];
-const total=d.total||0,delivering=d.delivering||0,totalTrips=d.total_trips||0;
-
-// Top stats
-const st=(l,v)=>'<div class="stat"><div class="val">'+v+'</div><div class="lbl">'+l+'</div></div>';
-const sep='<div class="stat-sep"></div>';
-const healthPct=total>0?Math.round(delivering/total*100):0;
-const hc=healthPct>=50?'g':healthPct>=20?'o':'r';
-document.getElementById('topStats').innerHTML=
-st('Routes',total)+st('Delivering',delivering)+st('Stuck',total-delivering)+sep+
-st('Total Trips',totalTrips)+sep+
-'<div class="stat"><div class="val '+hc+'" style="font-size:26px">'+healthPct+'%</div><div class="lbl">network health</div></div>';
-
-// Filters
-const owners=[...new Set(routes.map(r=>r.owner))].sort();
-const statuses=[...new Set(routes.map(r=>r.status))].sort();
-let fhtml='<button class="'+(filter==='all'?'active':'')+'" onclick="filter=\'all\';R()">All ('+total+')</button>';
-owners.forEach(o=>{const c=routes.filter(r=>r.owner===o).length;fhtml+='<button class="'+(filter===o?'active':'')+'" onclick="filter=\''+o+'\';R()">'+o+' ('+c+')</button>'});
-fhtml+='<span style="width:1px;height:20px;background:#1a2040;align-self:center"></span>';
-statuses.forEach(s=>{const c=routes.filter(r=>r.status===s).length;fhtml+='<button class="'+(filter===s?'active':'')+'" onclick="filter=\''+s+'\';R()">'+s+' ('+c+')</button>'});
-document.getElementById('filters').innerHTML=fhtml;
-
-// Summary cards per faction
-const byOwner={};
-routes.forEach(r=>{
-if(!byOwner[r.owner])byOwner[r.owner]={routes:0,delivering:0,trips:0,resources:{}};
-byOwner[r.owner].routes++;
-if(r.trips_complete>0)byOwner[r.owner].delivering++;
-byOwner[r.owner].trips+=r.trips_complete;
-byOwner[r.owner].resources[r.resource]=(byOwner[r.owner].resources[r.resource]||0)+1});
-let sumHtml='';
-Object.entries(byOwner).sort((a,b)=>b[1].trips-a[1].trips).forEach(([name,d])=>{
-const hp=d.routes>0?Math.round(d.delivering/d.routes*100):0;
-const hc2=hp>=50?'g':hp>=20?'o':'r';
-sumHtml+='<div class="summary-card"><h3>'+name+'</h3>';
-sumHtml+='<div style="font-size:20px;color:#7fdbca;font-weight:bold">'+d.trips+' <span style="font-size:11px;color:#556">trips</span></div>';
-sumHtml+='<div style="font-size:11px;margin:4px 0">'+d.routes+' routes, '+d.delivering+' delivering <span class="'+hc2+'">('+hp+'%)</span></div>';
-sumHtml+='<div style="font-size:10px;color:#445">'+Object.entries(d.resources).map(([r,c])=>c+'x '+r).join(', ')+'</div>';
-sumHtml+='</div>'});
-document.getElementById('summary').innerHTML=sumHtml;
-
-// Filter routes
-let filtered=routes;
-if(filter!=='all'){
-filtered=routes.filter(r=>r.owner===filter||r.status===filter)}
-// Sort
-filtered.sort((a,b)=>b.trips_complete-a.trips_complete);
-
-// Render routes
-let rhtml='';
-filtered.forEach(r=>{
-const stCls='st-'+r.status;
-const fuelPct=r.ship?Math.round(r.ship.fuel/r.ship.max_fuel*100):0;
-const fuelColor=fuelPct>50?'#1a4a2a':fuelPct>20?'#4a3a1a':'#4a1a1a';
-rhtml+='<div class="route">';
-rhtml+='<div class="route-header"><span class="route-id">#'+r.id+' '+r.resource+'</span><span class="route-owner">'+r.owner+'</span><span class="route-status '+stCls+'">'+r.status.replace('_',' ')+'</span></div>';
-rhtml+='<div class="route-path">';
-rhtml+='<span class="node">'+r.source_planet+'<br><span class="d" style="font-size:9px">'+r.source_system+'</span></span>';
-rhtml+='<span class="arrow">\u2192 <span class="res">'+r.resource+'</span> \u2192</span>';
-rhtml+='<span class="node">'+r.dest_planet+'<br><span class="d" style="font-size:9px">'+r.dest_system+'</span></span>';
-rhtml+='</div>';
-rhtml+='<div class="route-details">';
-rhtml+='<span class="detail"><b>Trips:</b> <span class="'+(r.trips_complete>0?'g':'d')+'">'+r.trips_complete+'</span></span>';
-rhtml+='<span class="detail"><b>Source stock:</b> <span class="'+(r.source_stock>50?'g':r.source_stock>0?'o':'r')+'">'+r.source_stock+'</span></span>';
-if(r.ship){
-rhtml+='<span class="detail"><b>Ship:</b> '+r.ship.name+'</span>';
-rhtml+='<span class="detail"><b>Fuel:</b> <span class="bar-sm"><span class="fill" style="width:'+fuelPct+'%;background:'+fuelColor+'"></span></span> '+r.ship.fuel+'/'+r.ship.max_fuel+'</span>';
-rhtml+='<span class="detail"><b>At:</b> '+r.ship.sys_name+'</span>';
-rhtml+='<span class="detail"><b>Cargo:</b> '+(r.ship.cargo||0)+'</span>';
-rhtml+='<span class="detail"><b>Status:</b> '+r.ship.status+'</span>';
-}else{rhtml+='<span class="detail r"><b>No ship assigned</b></span>'}
-rhtml+='</div></div>'});
-document.getElementById('routes').innerHTML=rhtml||'<div style="text-align:center;padding:40px;color:#334">No routes match filter</div>';
-document.getElementById('st2').textContent='Live \u2022 '+new Date().toLocaleTimeString()+' \u2022 '+total+' routes';
-}catch(err){document.getElementById('st2').textContent='Disconnected';document.getElementById('st2').style.color='#a44'}}
-R();setInterval(R,3000);
-</script></body></html>`
-
 func writeJSON(w http.ResponseWriter, v interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(v)
@@ -4,3 +4,12 @@ package api
 
 // StartServer is a no-op on WASM builds.
 func StartServer(provider GameStateProvider) {}
+
+// HostGame is a no-op on WASM builds.
+func HostGame(id string, provider GameStateProvider) {}
+
+// UnhostGame is a no-op on WASM builds.
+func UnhostGame(id string) {}
+
+// SetGameHost is a no-op on WASM builds.
+func SetGameHost(h GameHost) {}
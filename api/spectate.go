@@ -0,0 +1,140 @@
+//go:build !js
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+const (
+	// defaultSpectatorDelay is how far behind the live game observers watch,
+	// unless XANDARIS_SPECTATOR_DELAY says otherwise.
+	defaultSpectatorDelay = 5 * time.Minute
+	// spectatorInterval is the wall time between snapshots.
+	spectatorInterval = 10 * time.Second
+	// spectatorEvents is how many recent public events a snapshot carries.
+	spectatorEvents = 50
+)
+
+// spectatorFeed captures the whole galaxy every spectatorInterval and hands
+// observers the newest snapshot that's at least delay old. Casters see
+// everything, just too late for it to be useful to anyone playing.
+type spectatorFeed struct {
+	mu        sync.Mutex
+	delay     time.Duration
+	source    GameStateProvider
+	snapshots []*SpectatorSnapshot // oldest first
+	captured  []time.Time          // capture time of each snapshot
+}
+
+var spectator = &spectatorFeed{delay: defaultSpectatorDelay}
+
+// spectatorDelayFromEnv reads XANDARIS_SPECTATOR_DELAY (a Go duration such
+// as "5m" or "90s"; "0" turns the delay off).
+func spectatorDelayFromEnv() time.Duration {
+	s := os.Getenv("XANDARIS_SPECTATOR_DELAY")
+	if s == "" {
+		return defaultSpectatorDelay
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		fmt.Printf("[API] Ignoring XANDARIS_SPECTATOR_DELAY=%q, using %v\n", s, defaultSpectatorDelay)
+		return defaultSpectatorDelay
+	}
+	return d
+}
+
+// attach starts capturing p's state, dropping snapshots of any previous game.
+func (f *spectatorFeed) attach(p GameStateProvider, delay time.Duration) {
+	if p == nil {
+		return
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.delay = delay
+	if p == f.source {
+		return
+	}
+	f.source = p
+	f.snapshots, f.captured = nil, nil
+	p.AddTickCallback(func(tick int64) { f.capture(p, tick, time.Now()) })
+}
+
+// capture takes a snapshot if one is due. Runs on the simulation goroutine,
+// so the state is safe to read.
+func (f *spectatorFeed) capture(p GameStateProvider, tick int64, now time.Time) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if p != f.source {
+		return // a callback left behind by a replaced game
+	}
+	if n := len(f.captured); n > 0 && now.Sub(f.captured[n-1]) < spectatorInterval {
+		return
+	}
+
+	_, gameTime, _, _ := p.GetTickInfo()
+	snap := &SpectatorSnapshot{
+		Tick:        tick,
+		GameTime:    gameTime,
+		CapturedAt:  now.UTC().Format(time.RFC3339),
+		Galaxy:      handleGetGalaxy(p, nil),
+		Players:     handleGetPlayers(p, nil),
+		Leaderboard: handleGetLeaderboard(p, nil),
+		Ships:       handleGetShips(p, nil),
+		Economy:     handleGetEconomy(p),
+		Events:      make([]game.GameEvent, 0, spectatorEvents),
+	}
+	if el := p.GetEventLog(); el != nil {
+		for _, ev := range el.Recent(spectatorEvents) {
+			if !privateEventTypes[ev.Type] {
+				snap.Events = append(snap.Events, ev)
+			}
+		}
+	}
+	f.snapshots = append(f.snapshots, snap)
+	f.captured = append(f.captured, now)
+
+	// Keep the newest snapshot that's old enough to serve and everything after it
+	for len(f.captured) > 1 && now.Sub(f.captured[1]) >= f.delay {
+		f.snapshots, f.captured = f.snapshots[1:], f.captured[1:]
+	}
+}
+
+// latest returns the newest snapshot at least delay old. If there's none
+// yet, only DelaySeconds is set.
+func (f *spectatorFeed) latest(now time.Time) (SpectatorSnapshot, bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for i := len(f.captured) - 1; i >= 0; i-- {
+		if now.Sub(f.captured[i]) >= f.delay {
+			snap := *f.snapshots[i]
+			snap.DelaySeconds = f.delay.Seconds()
+			return snap, true
+		}
+	}
+	return SpectatorSnapshot{DelaySeconds: f.delay.Seconds()}, false
+}
+
+// registerSpectateEndpoint registers GET /api/spectate, the delayed
+// full-galaxy feed for observer keys (and admins). Observers get nothing
+// live beyond what an anonymous caller sees.
+func registerSpectateEndpoint(rt *router) {
+	get(rt, "/api/spectate", doc{Tag: "galaxy", Summary: "Delayed snapshot of the whole galaxy, for observers", Cost: 5},
+		func(r *http.Request) (SpectatorSnapshot, error) {
+			if !getAuthGrant(r).Allows(game.ScopeObserve) {
+				return SpectatorSnapshot{}, errStatus(http.StatusForbidden, "an observer key is required")
+			}
+			snap, ok := spectatorFor(r).latest(time.Now())
+			if !ok {
+				return SpectatorSnapshot{}, errStatus(http.StatusServiceUnavailable,
+					"no snapshot is old enough yet; observers watch %gs behind the live game", snap.DelaySeconds)
+			}
+			return snap, nil
+		})
+}
@@ -0,0 +1,104 @@
+//go:build !js
+
+package api
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+func TestSpectatorFeedDelay(t *testing.T) {
+	p := newTestProvider()
+	p.events.Add(10, "0:00:10", game.EventTrade, "Alpha", "Alpha bought Iron")
+	p.events.Add(10, "0:00:10", game.EventAlert, "Alpha", "Alpha's storage is full")
+	f := &spectatorFeed{}
+	f.attach(p, time.Minute)
+	start := time.Now()
+
+	f.capture(p, 10, start)
+	f.capture(p, 11, start.Add(5*time.Second)) // within spectatorInterval: skipped
+	f.capture(p, 40, start.Add(30*time.Second))
+
+	snap, ok := f.latest(start.Add(59 * time.Second))
+	if ok || snap.DelaySeconds != 60 {
+		t.Errorf("expected nothing a minute old yet, got %+v", snap)
+	}
+	snap, ok = f.latest(start.Add(time.Minute))
+	if !ok || snap.Tick != 10 || snap.DelaySeconds != 60 || len(snap.Players) != 2 {
+		t.Fatalf("expected the first snapshot after a minute, got %+v", snap)
+	}
+	if len(snap.Events) != 1 || snap.Events[0].Type != game.EventTrade {
+		t.Errorf("expected only public events, got %+v", snap.Events)
+	}
+	if snap, _ := f.latest(start.Add(100 * time.Second)); snap.Tick != 40 {
+		t.Errorf("expected the newest snapshot a minute old, got tick %d", snap.Tick)
+	}
+
+	// Snapshots older than the newest servable one are dropped
+	f.capture(p, 100, start.Add(100*time.Second))
+	if len(f.snapshots) != 2 || f.snapshots[0].Tick != 40 {
+		t.Errorf("expected ticks 40 and 100 kept, got %d snapshots", len(f.snapshots))
+	}
+
+	// A replaced game's callbacks stop capturing
+	f.attach(newTestProvider(), time.Minute)
+	p.tick(200)
+	if len(f.snapshots) != 0 {
+		t.Errorf("expected the old game's tick ignored, got %d snapshots", len(f.snapshots))
+	}
+}
+
+func TestSpectateIsObserverOnly(t *testing.T) {
+	p := newTestProvider()
+	h := testServer(t, p, nil)
+	prev := spectator
+	spectator = &spectatorFeed{}
+	t.Cleanup(func() { spectator = prev })
+	spectator.attach(p, 0)
+
+	raw, _, err := p.registry.CreateKey("Alpha", "caster", []string{game.ScopeObserve}, time.Time{})
+	if err != nil {
+		t.Fatalf("create observer key: %v", err)
+	}
+	playerKey := p.key(t, "Alpha", game.ScopeAll)
+
+	rec := call(h, http.MethodGet, "/api/spectate", playerKey, nil)
+	if rec.Code != http.StatusForbidden || decode(t, rec, nil).Error != "an observer key is required" {
+		t.Errorf("expected players refused, got %d %s", rec.Code, rec.Body)
+	}
+	if rec := call(h, http.MethodGet, "/api/spectate", raw, nil); rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("expected 503 before the first snapshot, got %d %s", rec.Code, rec.Body)
+	}
+
+	p.tick(7)
+	for _, key := range []string{raw, testAdminKey} {
+		var snap SpectatorSnapshot
+		rec := call(h, http.MethodGet, "/api/spectate", key, nil)
+		if rec.Code != http.StatusOK || !decode(t, rec, &snap).OK {
+			t.Fatalf("GET /api/spectate: %d %s", rec.Code, rec.Body)
+		}
+		if snap.Tick != 7 || len(snap.Players) != 2 || snap.Players[1].Obscured {
+			t.Errorf("expected the whole galaxy at tick 7, got %+v", snap)
+		}
+	}
+
+	// Live, an observer is as anonymous as anyone and can't act
+	var players []PlayerInfo
+	decode(t, call(h, http.MethodGet, "/api/players", raw, nil), &players)
+	if len(players) != 2 || !players[0].Obscured || !players[1].Obscured {
+		t.Errorf("expected every faction obscured to an observer, got %+v", players)
+	}
+	var seen []PlayerInfo
+	decode(t, call(h, http.MethodGet, "/api/players", playerKey, nil), &seen)
+	if len(seen) != 2 || seen[0].Obscured || !seen[1].Obscured {
+		t.Errorf("expected Alpha to see only themselves, got %+v", seen)
+	}
+	// Batches check scopes per command, so the key gets as far as being an observer's
+	rec = call(h, http.MethodPost, "/api/batch", raw, BatchRequest{})
+	if rec.Code != http.StatusForbidden || decode(t, rec, nil).Error != "observer keys are read-only" {
+		t.Errorf("expected observer writes refused, got %d %s", rec.Code, rec.Body)
+	}
+}
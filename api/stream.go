@@ -0,0 +1,242 @@
+//go:build !js
+
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// privateEventTypes are only pushed to the player they concern.
+var privateEventTypes = map[game.EventType]bool{
+	game.EventAlert:     true,
+	game.EventLogistics: true,
+}
+
+// eventVisible reports whether vis may see an event: private types only go
+// to the player they concern, and other factions' events need intel.
+func eventVisible(vis *game.Visibility, ev game.GameEvent) bool {
+	if vis == nil || ev.Player == vis.Player {
+		return true
+	}
+	return !privateEventTypes[ev.Type] && vis.Knows(ev.Player)
+}
+
+type streamMessage struct {
+	kind string
+	data []byte
+}
+
+// streamClient is one connected GET /api/stream subscriber.
+type streamClient struct {
+	player string
+	admin  bool
+	kinds  map[string]bool // nil = everything
+	every  int64           // tick summary interval
+	ch     chan streamMessage
+}
+
+func (c *streamClient) wants(kind string) bool {
+	return c.kinds == nil || c.kinds[kind]
+}
+
+// send queues a message without blocking; a client that falls behind
+// drops messages rather than stalling the simulation.
+func (c *streamClient) send(kind string, data []byte) {
+	select {
+	case c.ch <- streamMessage{kind: kind, data: data}:
+	default:
+	}
+}
+
+// streamHub subscribes once to each in-process event source and fans
+// messages out to stream clients, filtered by what each player may see.
+type streamHub struct {
+	mu      sync.Mutex
+	clients map[*streamClient]bool
+
+	// Sources already subscribed to (logs are replaced on new game / load)
+	events *game.EventLog
+	chat   *game.ChatLog
+	trades *economy.TradeExecutor
+	ticks  GameStateProvider
+}
+
+var stream = &streamHub{clients: make(map[*streamClient]bool)}
+
+// attach subscribes the hub to any of the provider's sources it hasn't seen yet.
+func (h *streamHub) attach(p GameStateProvider) {
+	if p == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if el := p.GetEventLog(); el != nil && el != h.events {
+		h.events = el
+		el.Subscribe(func(ev game.GameEvent) {
+			h.broadcast(StreamEvent, ev, func(c *streamClient) bool {
+				if c.admin {
+					return true
+				}
+				// Events only need to know whose they are, not sensor coverage
+				return eventVisible(game.ComputeVisibility(c.player, nil, nil, nil, intelTargets(p, c.player)), ev)
+			})
+		})
+	}
+	if cl := p.GetChatLog(); cl != nil && cl != h.chat {
+		h.chat = cl
+		cl.Subscribe(func(msg game.ChatMsg) {
+			h.broadcast(StreamChat, msg, nil)
+		})
+	}
+	if te := p.GetTradeExecutor(); te != nil && te != h.trades {
+		h.trades = te
+		te.Subscribe(func(r economy.TradeRecord) {
+			entry := TradeHistoryEntry{
+				Tick: r.Tick, Player: r.Player, Resource: r.Resource, Quantity: r.Quantity,
+				Action: r.Action, UnitPrice: r.UnitPrice, Total: r.Total,
+			}
+			h.broadcast(StreamTrade, entry, func(c *streamClient) bool {
+				return c.admin || r.Player == c.player
+			})
+		})
+	}
+	if p != h.ticks {
+		h.ticks = p
+		p.AddTickCallback(func(tick int64) { h.publishTick(p, tick) })
+	}
+}
+
+// broadcast sends v to every client that wants kind and passes visible (nil = all).
+func (h *streamHub) broadcast(kind string, v interface{}, visible func(*streamClient) bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.clients) == 0 {
+		return
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		fmt.Printf("[Stream] Encode %s: %v\n", kind, err)
+		return
+	}
+	for c := range h.clients {
+		if c.wants(kind) && (visible == nil || visible(c)) {
+			c.send(kind, data)
+		}
+	}
+}
+
+// publishTick sends each due client a summary carrying its own credits.
+// Runs on the simulation goroutine, so player state is safe to read.
+func (h *streamHub) publishTick(p GameStateProvider, tick int64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.clients) == 0 {
+		return
+	}
+	_, gameTime, speed, paused := p.GetTickInfo()
+	players := p.GetPlayers()
+	for c := range h.clients {
+		if !c.wants(StreamTick) || tick%c.every != 0 {
+			continue
+		}
+		summary := TickSummary{Tick: tick, GameTime: gameTime, Speed: speed, Paused: paused, Players: len(players)}
+		for _, pl := range players {
+			if pl != nil && pl.Name == c.player {
+				summary.Credits = pl.Credits
+				break
+			}
+		}
+		data, _ := json.Marshal(summary)
+		c.send(StreamTick, data)
+	}
+}
+
+func (h *streamHub) add(c *streamClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.clients[c] = true
+}
+
+func (h *streamHub) remove(c *streamClient) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.clients, c)
+}
+
+// registerStreamEndpoint registers GET /api/stream, a Server-Sent Events feed
+// of game events, chat, trades and tick summaries.
+//
+// Query parameters:
+//   - kinds: comma-separated subset of event,chat,trade,tick (default all)
+//   - every: ticks between tick summaries (default 10, i.e. once a second at 1x)
+//   - key:   API key, for clients like EventSource that can't set X-API-Key
+func registerStreamEndpoint(rt *router) {
+	rt.raw(http.MethodGet, "/api/stream", doc{Tag: "stream", Summary: "Server-Sent Events feed", ContentType: "text/event-stream", Query: []param{
+		{"kinds", "string", "comma-separated subset of event,chat,trade,tick"},
+		{"every", "integer", "ticks between tick summaries"},
+		{"key", "string", "API key, for clients that can't set X-API-Key"},
+	}}, nil, func(w http.ResponseWriter, r *http.Request) {
+		player := getAuthPlayer(r)
+		admin := isAdmin(r)
+		if player == "" && !admin {
+			writeErr(w, http.StatusUnauthorized, "auth required")
+			return
+		}
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			writeErr(w, http.StatusInternalServerError, "streaming unsupported")
+			return
+		}
+
+		client := &streamClient{player: player, admin: admin, every: 10, ch: make(chan streamMessage, 256)}
+		if k := r.URL.Query().Get("kinds"); k != "" {
+			client.kinds = make(map[string]bool)
+			for _, kind := range strings.Split(k, ",") {
+				client.kinds[strings.TrimSpace(kind)] = true
+			}
+		}
+		if e := r.URL.Query().Get("every"); e != "" {
+			if n, err := strconv.ParseInt(e, 10, 64); err == nil && n > 0 {
+				client.every = n
+			}
+		}
+
+		hub := streamFor(r)
+		hub.attach(getProvider(r))
+		hub.add(client)
+		defer hub.remove(client)
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprint(w, "retry: 5000\n\n")
+		flusher.Flush()
+
+		heartbeat := time.NewTicker(15 * time.Second)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case msg := <-client.ch:
+				fmt.Fprintf(w, "event: %s\ndata: %s\n\n", msg.kind, msg.data)
+				flusher.Flush()
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": ping\n\n")
+				flusher.Flush()
+			}
+		}
+	})
+}
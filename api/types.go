@@ -1,5 +1,11 @@
 package api
 
+import (
+	"encoding/json"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
 // APIResponse wraps all API responses.
 type APIResponse struct {
 	OK    bool        `json:"ok"`
@@ -95,6 +101,9 @@ type PlanetDetail struct {
 	Density           float64            `json:"density,omitempty"`     // g/cm³
 	OrbitAU           float64            `json:"orbit_au,omitempty"`    // AU
 	Composition       *CompositionInfo   `json:"composition,omitempty"` // mass fractions
+	// Obscured is set when the planet is outside the caller's sensor range:
+	// only its charted fields (name, type, deposits, physics) are filled in.
+	Obscured bool `json:"obscured,omitempty"`
 }
 
 // CompositionInfo represents planet material composition for the API.
@@ -151,17 +160,19 @@ type PlayerInfo struct {
 	Buildings  int    `json:"buildings"`
 	Population int64  `json:"population"`
 	Stock      int    `json:"stock"`
+	Obscured   bool   `json:"obscured,omitempty"` // only name and type known
 }
 
 // GameInfo represents the game state endpoint.
 type GameInfo struct {
-	Tick      int64   `json:"tick"`
-	GameTime  string  `json:"game_time"`
-	Speed     string  `json:"speed"`
-	Paused    bool    `json:"paused"`
-	Systems   int     `json:"systems"`
-	Players   int     `json:"players"`
-	Seed      int64   `json:"seed"`
+	Tick     int64           `json:"tick"`
+	GameTime string          `json:"game_time"`
+	Speed    string          `json:"speed"`
+	Paused   bool            `json:"paused"`
+	Systems  int             `json:"systems"`
+	Players  int             `json:"players"`
+	Seed     int64           `json:"seed"`
+	Config   game.GameConfig `json:"config"` // match setup the game was created with
 }
 
 // SpeedRequest is the body for POST /api/game/speed.
@@ -180,6 +191,59 @@ type TradeHistoryEntry struct {
 	Total     int     `json:"total"`
 }
 
+// PriceCandles is a resource's OHLCV candle series on
+// GET /api/market/history, oldest first.
+type PriceCandles struct {
+	Resource   string        `json:"resource"`
+	SystemID   int           `json:"system_id"`  // -1 for galaxy-wide prices
+	Resolution int64         `json:"resolution"` // ticks per candle
+	Candles    []PriceCandle `json:"candles"`
+}
+
+// PriceCandle is one candle of mid prices (between buy and sell).
+type PriceCandle struct {
+	Start  int64   `json:"start"` // first tick covered
+	Open   float64 `json:"open"`
+	High   float64 `json:"high"`
+	Low    float64 `json:"low"`
+	Close  float64 `json:"close"`
+	Volume int     `json:"volume"` // units traded
+}
+
+// Stream kinds, sent as the SSE "event:" field on GET /api/stream.
+const (
+	StreamEvent = "event" // game.GameEvent
+	StreamChat  = "chat"  // game.ChatMsg
+	StreamTrade = "trade" // TradeHistoryEntry
+	StreamTick  = "tick"  // TickSummary
+)
+
+// TickSummary is the per-tick digest pushed on GET /api/stream.
+type TickSummary struct {
+	Tick     int64  `json:"tick"`
+	GameTime string `json:"game_time"`
+	Speed    string `json:"speed"`
+	Paused   bool   `json:"paused"`
+	Players  int    `json:"players"`
+	Credits  int    `json:"credits"` // the subscriber's own credits
+}
+
+// SpectatorSnapshot is the whole galaxy as of CapturedAt, served on
+// GET /api/spectate once it's older than the spectator delay. Each section
+// has the same shape as the live endpoint of the same name.
+type SpectatorSnapshot struct {
+	Tick         int64              `json:"tick"`
+	GameTime     string             `json:"game_time"`
+	CapturedAt   string             `json:"captured_at"`
+	DelaySeconds float64            `json:"delay_seconds"`
+	Galaxy       []SystemSummary    `json:"galaxy"`
+	Players      []PlayerInfo       `json:"players"`
+	Leaderboard  []LeaderboardEntry `json:"leaderboard"`
+	Ships        []ShipInfo         `json:"ships"`
+	Economy      EconomyOverview    `json:"economy"`
+	Events       []game.GameEvent   `json:"events"` // recent public events
+}
+
 // ShipInfo represents a ship for the API.
 type ShipInfo struct {
 	ID            int            `json:"id"`
@@ -198,6 +262,7 @@ type ShipInfo struct {
 	CargoHold      map[string]int `json:"cargo_hold"`
 	TravelProgress float64        `json:"travel_progress"` // 0.0-1.0 for moving ships
 	RoutePath      []int          `json:"route_path,omitempty"` // remaining multi-hop path
+	Obscured       bool           `json:"obscured,omitempty"`   // seen on sensors: no fuel, cargo or route
 }
 
 // FleetInfo represents a fleet for the API.
@@ -222,6 +287,77 @@ type BuildRequest struct {
 	ResourceID   int    `json:"resource_id"`   // for mines: which resource node
 }
 
+// TickProfileRequest is the body for POST /api/admin/tick-profile. Omitted
+// fields leave the setting unchanged.
+type TickProfileRequest struct {
+	Enabled  *bool    `json:"enabled,omitempty"`   // turn profiling on/off
+	BudgetMs *float64 `json:"budget_ms,omitempty"` // per-system, per-tick budget (0 = no flagging)
+	Reset    bool     `json:"reset,omitempty"`     // discard recorded samples
+}
+
+// ScheduleRequest is the body for POST /api/admin/schedule.
+type ScheduleRequest struct {
+	System   string `json:"system"`
+	Interval int64  `json:"interval"`        // ticks between runs (0 or 1 = every tick)
+	Phase    *int64 `json:"phase,omitempty"` // pin the phase; omitted = spread by the registry
+}
+
+// CreateKeyRequest is the body for POST /api/keys.
+type CreateKeyRequest struct {
+	Name           string   `json:"name"`
+	Scopes         []string `json:"scopes"`                     // read, trade, build, fleet, all, observe (admin only); empty = read
+	ExpiresInHours float64  `json:"expires_in_hours,omitempty"` // 0 = never expires
+	Player         string   `json:"player,omitempty"`           // admin only: whose account
+}
+
+// RevokeKeyRequest is the body for POST /api/keys/revoke.
+type RevokeKeyRequest struct {
+	Key    string `json:"key"`              // key ID or name
+	Player string `json:"player,omitempty"` // admin only: whose account
+}
+
+// CreateWebhookRequest is the body for POST /api/webhooks.
+type CreateWebhookRequest struct {
+	URL    string   `json:"url"`              // https only
+	Events []string `json:"events"`           // event types, e.g. military, logistics, event
+	Secret string   `json:"secret,omitempty"` // HMAC key, 16+ characters (empty = generated)
+	Player string   `json:"player,omitempty"` // admin only: whose account
+}
+
+// WebhookRequest is the body for POST /api/webhooks/delete and
+// /api/webhooks/redeliver.
+type WebhookRequest struct {
+	ID     string `json:"id"`               // webhook ID, or delivery ID to redeliver
+	Player string `json:"player,omitempty"` // admin only: whose account
+}
+
+// WebhookInfo describes a webhook without its secret.
+type WebhookInfo struct {
+	ID        string   `json:"id"`
+	URL       string   `json:"url"`
+	Events    []string `json:"events"`
+	CreatedAt string   `json:"created_at"`
+}
+
+// CreatedWebhook is the response for POST /api/webhooks. Secret is shown
+// only once; deliveries carry X-Xandaris-Signature: sha256=HMAC(secret, body).
+type CreatedWebhook struct {
+	Secret  string      `json:"secret"`
+	Webhook WebhookInfo `json:"webhook"`
+}
+
+// APIKeyInfo describes an API key without its secret.
+type APIKeyInfo struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Prefix    string   `json:"prefix"`
+	Scopes    []string `json:"scopes"`
+	CreatedAt string   `json:"created_at"`
+	ExpiresAt string   `json:"expires_at,omitempty"` // empty = never
+	Expired   bool     `json:"expired"`
+	Current   bool     `json:"current"` // the key making this request
+}
+
 // ShipBuildRequest is the body for POST /api/ships/build.
 type ShipBuildRequest struct {
 	PlanetID int    `json:"planet_id"`
@@ -333,6 +469,7 @@ type LeaderboardEntry struct {
 	StockValue int     `json:"stock_value"`
 	MaxTech    float64 `json:"max_tech"`    // highest tech level across all planets
 	TechEra    string  `json:"tech_era"`    // era name of highest tech planet
+	Obscured   bool    `json:"obscured,omitempty"` // only rank and score known
 }
 
 // CatalogResource describes a tradeable resource.
@@ -553,3 +690,716 @@ type ResourceSummary struct {
 	PriceRatio   float64   `json:"price_ratio"`
 	PriceHistory []float64 `json:"price_history,omitempty"` // last 100 mid prices
 }
+
+// SystemDetail is a system with full planet details.
+type SystemDetail struct {
+	ID      int            `json:"id"`
+	Name    string         `json:"name"`
+	X       float64        `json:"x"`
+	Y       float64        `json:"y"`
+	Planets []PlanetDetail `json:"planets"`
+}
+
+// PlanetPower is one planet's power grid for GET /api/power.
+type PlanetPower struct {
+	PlanetID       int       `json:"planet_id"`
+	PlanetName     string    `json:"planet_name"`
+	Owner          string    `json:"owner"`
+	Generated      float64   `json:"generated_mw"`
+	Consumed       float64   `json:"consumed_mw"`
+	Ratio          float64   `json:"ratio"`
+	Generators     int       `json:"generators"`
+	FusionReactors int       `json:"fusion_reactors"`
+	FuelStored     int       `json:"fuel_stored"`
+	He3Stored      int       `json:"he3_stored"`
+	History        []float64 `json:"history,omitempty"`
+}
+
+// PlayerMe is the authenticated player's planets and ships.
+type PlayerMe struct {
+	Name    string         `json:"name"`
+	Credits int            `json:"credits"`
+	Planets []PlanetDetail `json:"planets"`
+	Ships   []ShipInfo     `json:"ships"`
+}
+
+// DepositInfo is a minable resource deposit for GET /api/deposits.
+type DepositInfo struct {
+	SystemID     int     `json:"system_id"`
+	SystemName   string  `json:"system_name"`
+	PlanetID     int     `json:"planet_id"`
+	PlanetName   string  `json:"planet_name"`
+	Owner        string  `json:"owner"`
+	ResourceType string  `json:"resource_type"`
+	ResourceID   int     `json:"resource_id"`
+	Abundance    int     `json:"abundance"`
+	Rate         float64 `json:"extraction_rate"`
+	HasMine      bool    `json:"has_mine"`
+}
+
+// SystemDefense is a system's military defense rating.
+type SystemDefense struct {
+	SystemID    int    `json:"system_id"`
+	SystemName  string `json:"system_name"`
+	Power       int    `json:"defense_power"`  // total military AttackPower
+	Ships       int    `json:"military_ships"` // count of military ships present
+	Safe        bool   `json:"safe"`           // power >= 10 (piracy immune)
+	Owner       string `json:"owner,omitempty"`
+}
+
+// StationInfo describes a station for GET /api/stations.
+type StationInfo struct {
+	ID          int      `json:"id"`
+	Name        string   `json:"name"`
+	Type        string   `json:"type"`
+	SystemID    int      `json:"system_id"`
+	SystemName  string   `json:"system_name"`
+	Owner       string   `json:"owner"`
+	Population  int      `json:"population"`
+	Capacity    int      `json:"capacity"`
+	DockingFee  int      `json:"docking_fee"`
+	Services    []string `json:"services"`
+	DefenseLevel int     `json:"defense_level"`
+}
+
+// StandingOrderRequest is the body for POST /api/orders.
+type StandingOrderRequest struct {
+	PlanetID  int    `json:"planet_id"`
+	Resource  string `json:"resource"`
+	Action    string `json:"action"` // "buy" or "sell"
+	Quantity  int    `json:"quantity"`
+	Threshold int    `json:"threshold"`
+	MaxPrice  int    `json:"max_price"`
+	MinPrice  int    `json:"min_price"`
+}
+
+// CancelOrderRequest is the body for DELETE /api/orders.
+type CancelOrderRequest struct {
+	OrderID int `json:"order_id"`
+}
+
+// ShippingRequest is the body for POST /api/shipping.
+type ShippingRequest struct {
+	SourcePlanet int    `json:"source_planet"`
+	DestPlanet   int    `json:"dest_planet"`
+	Resource     string `json:"resource"`
+	Quantity     int    `json:"quantity"`
+	ShipID       int    `json:"ship_id"`
+}
+
+// CancelShippingRequest is the body for DELETE /api/shipping.
+type CancelShippingRequest struct {
+	RouteID int `json:"route_id"`
+}
+
+// ShippingRouteStatus is a shipping route with whether it can run right now.
+type ShippingRouteStatus struct {
+	ShippingRouteInfo
+	SourceStock int    `json:"source_stock"`
+	ShipFuel    int    `json:"ship_fuel,omitempty"`
+	ShipSystem  int    `json:"ship_system,omitempty"`
+	Status      string `json:"status"` // "ready", "no_stock", "no_fuel", "no_ship"
+}
+
+// ShippingRouteCreated is the response for POST /api/shipping/routes.
+type ShippingRouteCreated struct {
+	RouteID int    `json:"route_id"`
+	Owner   string `json:"owner"`
+}
+
+// Logistics is the route overview behind the logistics page.
+type Logistics struct {
+	Routes     []LogisticsRoute `json:"routes"`
+	Total      int              `json:"total"`
+	Delivering int              `json:"delivering"`
+	TotalTrips int              `json:"total_trips"`
+}
+
+// LogisticsRoute is a shipping route with planet and system names filled in.
+type LogisticsRoute struct {
+	ID            int            `json:"id"`
+	Owner         string         `json:"owner"`
+	Resource      string         `json:"resource"`
+	Quantity      int            `json:"quantity"`
+	TripsComplete int            `json:"trips_complete"`
+	Active        bool           `json:"active"`
+	SourcePlanet  string         `json:"source_planet"`
+	SourceSystem  string         `json:"source_system"`
+	DestPlanet    string         `json:"dest_planet"`
+	DestSystem    string         `json:"dest_system"`
+	SourceStock   int            `json:"source_stock"`
+	ShipID        int            `json:"ship_id"`
+	Ship          *LogisticsShip `json:"ship,omitempty"`
+	Status        string         `json:"status"` // "ready", "in_transit", "no_stock", "no_fuel", "no_ship"
+}
+
+// LogisticsShip is the ship assigned to a logistics route.
+type LogisticsShip struct {
+	Name    string `json:"name"`
+	Fuel    int    `json:"fuel"`
+	MaxFuel int    `json:"max_fuel"`
+	System  int    `json:"system"`
+	SysName string `json:"sys_name"`
+	Status  string `json:"status"`
+	Cargo   int    `json:"cargo"`
+}
+
+// SystemLinks lists the systems one hyperlane jump from a system.
+type SystemLinks struct {
+	SystemID  int   `json:"system_id"`
+	Connected []int `json:"connected"`
+}
+
+// TransferFuelRequest is the body for POST /api/ships/transfer-fuel.
+type TransferFuelRequest struct {
+	FromShipID int `json:"from_ship_id"`
+	ToShipID   int `json:"to_ship_id"`
+	Amount     int `json:"amount"` // 0 = fill up target
+}
+
+// DemolishRequest is the body for POST /api/demolish.
+type DemolishRequest struct {
+	PlanetID      int `json:"planet_id"`
+	BuildingIndex int `json:"building_index"`
+}
+
+// LimitOrderRequest is the body for POST /api/orders/limit.
+type LimitOrderRequest struct {
+	SystemID int    `json:"system_id"`
+	PlanetID int    `json:"planet_id"`
+	Resource string `json:"resource"`
+	Action   string `json:"action"` // "buy" or "sell"
+	Quantity int    `json:"quantity"`
+	Price    int    `json:"price"` // limit price; for a stop buy, the most it will pay
+
+	Type            string `json:"type,omitempty"`             // limit (default), stop, stop_limit, take_profit, take_profit_limit
+	StopPrice       int    `json:"stop_price,omitempty"`       // current price that triggers a stop or take-profit
+	TimeInForce     string `json:"time_in_force,omitempty"`    // gtc (default), ioc or fok
+	ExpiresAt       int64  `json:"expires_at,omitempty"`       // tick the order lapses at
+	DisplayQuantity int    `json:"display_quantity,omitempty"` // iceberg: quantity shown at a time
+}
+
+// CancelLimitOrderRequest is the order to cancel for DELETE /api/orders/limit
+// (given as the order_id query parameter, or in a batch step's body).
+type CancelLimitOrderRequest struct {
+	OrderID int `json:"order_id"`
+}
+
+// FuturesOpenRequest is the body for POST /api/futures.
+type FuturesOpenRequest struct {
+	Kind       string  `json:"kind"`           // future, call or put
+	Side       string  `json:"side,omitempty"` // futures: long or short
+	Resource   string  `json:"resource"`
+	Quantity   int     `json:"quantity"`
+	Term       int64   `json:"term"`                 // ticks until expiry
+	Strike     float64 `json:"strike,omitempty"`     // options only
+	Settlement string  `json:"settlement,omitempty"` // futures: cash (default) or physical
+	SystemID   int     `json:"system_id,omitempty"`  // physical settlement: your planet to deliver to/from
+	PlanetID   int     `json:"planet_id,omitempty"`
+}
+
+// FuturesCloseRequest is the position to close for DELETE /api/futures
+// (given as the position_id query parameter, or in a batch step's body).
+type FuturesCloseRequest struct {
+	PositionID int `json:"position_id"`
+}
+
+// FuturesAccount is the response for GET /api/futures: a faction's margin
+// account and positions.
+type FuturesAccount struct {
+	Player            string            `json:"player"`
+	Balance           int               `json:"balance"`            // margin account, paid back once no futures are open
+	InitialMargin     int               `json:"initial_margin"`     // what open futures need at their marks
+	MaintenanceMargin int               `json:"maintenance_margin"` // below this, a margin call
+	Positions         []FuturesPosition `json:"positions"`
+}
+
+// FuturesPosition is a futures or option position.
+type FuturesPosition struct {
+	ID         int     `json:"id"`
+	Kind       string  `json:"kind"`
+	Side       string  `json:"side"`
+	Resource   string  `json:"resource"`
+	Quantity   int     `json:"quantity"`
+	Price      float64 `json:"price"`             // futures: entry price; options: strike
+	Premium    int     `json:"premium,omitempty"` // options: what was paid
+	Mark       float64 `json:"mark"`              // mid price at the last mark
+	OpenedTick int64   `json:"opened_tick"`
+	ExpiryTick int64   `json:"expiry_tick"`
+	Settlement string  `json:"settlement,omitempty"`
+	SystemID   int     `json:"system_id,omitempty"`
+	PlanetID   int     `json:"planet_id,omitempty"`
+	Open       bool    `json:"open"`
+	ClosedTick int64   `json:"closed_tick,omitempty"`
+	Outcome    string  `json:"outcome,omitempty"` // closed, settled, cash, liquidated or expired
+	Realized   int     `json:"realized"`          // futures: variation margin to date; options: payout minus premium
+}
+
+// FuturesQuote is the response for GET /api/futures/quote.
+type FuturesQuote struct {
+	Resource      string  `json:"resource"`
+	Bid           float64 `json:"bid"`            // a short opens here
+	Ask           float64 `json:"ask"`            // a long opens here
+	Mid           float64 `json:"mid"`            // positions are marked here
+	Volatility    float64 `json:"volatility"`     // std dev of log price change per price update
+	InitialMargin float64 `json:"initial_margin"` // futures margin per unit at the mid
+	Kind          string  `json:"kind,omitempty"`
+	Strike        float64 `json:"strike,omitempty"`
+	Term          int64   `json:"term,omitempty"`
+	Premium       float64 `json:"premium,omitempty"` // options: per unit
+}
+
+// OrderBookDepth is the response for GET /api/orders/book: a resource's bid
+// and ask levels in a system, best price first.
+type OrderBookDepth struct {
+	SystemID int          `json:"system_id"`
+	Resource string       `json:"resource"`
+	Bids     []DepthLevel `json:"bids"`
+	Asks     []DepthLevel `json:"asks"`
+	Spread   int          `json:"spread,omitempty"` // best ask - best bid, when both sides have orders
+}
+
+// DepthLevel is the resting quantity at one price on one side of the book.
+type DepthLevel struct {
+	Price    int `json:"price"`
+	Quantity int `json:"quantity"`
+	Orders   int `json:"orders"`
+}
+
+// LedgerEntry is one credit movement in GET /api/ledger. Amount is
+// positive for credits in and negative for credits out; Balance is the
+// faction's credits afterwards.
+type LedgerEntry struct {
+	Seq          int64  `json:"seq"`
+	Tick         int64  `json:"tick"`
+	Player       string `json:"player"`
+	Amount       int    `json:"amount"`
+	Balance      int    `json:"balance"`
+	Reason       string `json:"reason"`
+	Source       string `json:"source"`
+	Counterparty string `json:"counterparty,omitempty"`
+	Resource     string `json:"resource,omitempty"`
+	Quantity     int    `json:"quantity,omitempty"`
+	Detail       string `json:"detail,omitempty"`
+	Entries      int    `json:"entries,omitempty"` // older history compacted into one entry per faction and reason
+}
+
+// FactionPnL is a faction's trading profit and loss for GET /api/ledger/pnl:
+// realized on sales against FIFO cost basis, unrealized on what it holds.
+type FactionPnL struct {
+	Player     string        `json:"player"`
+	Realized   int           `json:"realized"`
+	Unrealized int           `json:"unrealized"`
+	Positions  []PnLPosition `json:"positions"`
+}
+
+// PnLPosition is a faction's bought inventory of one resource, marked at
+// the current price.
+type PnLPosition struct {
+	Resource   string  `json:"resource"`
+	Quantity   int     `json:"quantity"`
+	CostBasis  int     `json:"cost_basis"`
+	AvgCost    float64 `json:"avg_cost"`
+	Mark       float64 `json:"mark"`
+	Unrealized int     `json:"unrealized"`
+}
+
+// ContractRequest is the body for POST /api/contracts.
+type ContractRequest struct {
+	Buyer        string `json:"buyer"`
+	Resource     string `json:"resource"`
+	Quantity     int    `json:"quantity"`
+	PricePerUnit int    `json:"price_per_unit"`
+	Interval     int    `json:"interval"`  // ticks between deliveries
+	SystemID     int    `json:"system_id"`
+	PlanetID     int    `json:"planet_id"` // buyer's delivery planet
+}
+
+// BlackMarketRequest is the body for POST /api/black-market.
+type BlackMarketRequest struct {
+	Action   string `json:"action"` // "buy" or "sell"
+	Resource string `json:"resource"`
+	Quantity int    `json:"quantity"`
+	PlanetID int    `json:"planet_id"` // planet for delivery/pickup
+}
+
+// BlackMarketResult is the outcome of a black market deal. Seized is only
+// reported for sales.
+type BlackMarketResult struct {
+	Action   string `json:"action"`
+	Seized   *bool  `json:"seized,omitempty"`
+	Resource string `json:"resource"`
+	Quantity int    `json:"quantity"`
+	Credits  int    `json:"credits,omitempty"` // sale proceeds
+	Cost     int    `json:"cost,omitempty"`    // purchase price
+	Message  string `json:"message"`
+}
+
+// BidRequest is the body for POST /api/auctions.
+type BidRequest struct {
+	AuctionID int `json:"auction_id"`
+	Bid       int `json:"bid"`
+}
+
+// BidResult is the response for an accepted bid.
+type BidResult struct {
+	AuctionID int    `json:"auction_id"`
+	Bid       int    `json:"bid"`
+	Bidder    string `json:"bidder"`
+}
+
+// LocalMarket is what can be traded within one system.
+type LocalMarket struct {
+	SystemID     int                `json:"system_id"`
+	Planets      []LocalPlanetStock `json:"planets"`
+	YourPlanets  []int              `json:"your_planets"`
+	BuyableStock []LocalPricedStock `json:"buyable_stock"` // stock on other players' planets
+}
+
+// LocalPlanetStock is one owned planet's stock in a local market.
+type LocalPlanetStock struct {
+	PlanetID   int            `json:"planet_id"`
+	PlanetName string         `json:"planet_name"`
+	Owner      string         `json:"owner"`
+	HasTP      bool           `json:"has_trading_post"`
+	Stock      map[string]int `json:"stock"`
+}
+
+// LocalPricedStock is buyable stock of one resource with local prices.
+type LocalPricedStock struct {
+	Resource  string  `json:"resource"`
+	Available int     `json:"available"`
+	BuyPrice  float64 `json:"buy_price"`
+	SellPrice float64 `json:"sell_price"`
+}
+
+// EconomySummary is a player's income, expenses and net flow per tick.
+type EconomySummary struct {
+	Player          string            `json:"player"`
+	Credits         int               `json:"credits"`
+	Population      int64             `json:"population"`
+	Planets         int               `json:"planets"`
+	IncomePerTick   map[string]int    `json:"income_per_tick"`   // labor, domestic, trading, total
+	ExpensesPerTick map[string]int    `json:"expenses_per_tick"` // building_upkeep, administration, total
+	NetFlowPerTick  int               `json:"net_flow_per_tick"`
+	Profitable      bool              `json:"profitable"`
+	Diversity       []PlanetDiversity `json:"diversity"`
+}
+
+// PlanetDiversity is how many resource types a planet stocks and the
+// multiplier that earns.
+type PlanetDiversity struct {
+	PlanetID     int      `json:"planet_id"`
+	Name         string   `json:"name"`
+	TypesStocked int      `json:"types_stocked"`
+	Multiplier   float64  `json:"multiplier"`
+	Missing      []string `json:"missing"`
+}
+
+// EconomyReport is a narrative-ready summary of the galactic economy.
+type EconomyReport struct {
+	GDP          int              `json:"gdp"`
+	Resources    []ResourceReport `json:"resources"`
+	Factions     []FactionSummary `json:"factions"`
+	ActiveRoutes int              `json:"active_routes"`
+	TotalTrips   int              `json:"total_trips"`
+}
+
+// ResourceReport is one resource's supply, price pressure and order book depth.
+type ResourceReport struct {
+	Resource    string  `json:"resource"`
+	TotalSupply int     `json:"total_supply"`
+	PriceRatio  float64 `json:"price_ratio"` // current/base
+	Scarcity    string  `json:"scarcity"`
+	BuyDemand   int     `json:"buy_demand"`  // from order book
+	SellSupply  int     `json:"sell_supply"` // from order book
+}
+
+// FactionSummary is one faction's size in the economy report.
+type FactionSummary struct {
+	Name     string `json:"name"`
+	Credits  int    `json:"credits"`
+	Planets  int    `json:"planets"`
+	Ships    int    `json:"ships"`
+	Obscured bool   `json:"obscured,omitempty"` // only the name known
+}
+
+// TradeOpportunity is a cross-system arbitrage for one resource.
+type TradeOpportunity struct {
+	Resource         string  `json:"resource"`
+	FromSystem       int     `json:"from_system"`
+	ToSystem         int     `json:"to_system"`
+	BuyPrice         float64 `json:"buy_price"`
+	SellPrice        float64 `json:"sell_price"`
+	Margin           float64 `json:"margin"`
+	ProfitPerTrip    int     `json:"profit_per_trip"`     // margin * 500 (cargo capacity)
+	FuelCostPerTrip  int     `json:"fuel_cost_per_trip"`  // estimated fuel for round trip
+	NetProfitPerTrip int     `json:"net_profit_per_trip"` // profit - fuel cost
+	Available        int     `json:"available"`
+	Demand           int     `json:"demand"`
+}
+
+// PlanetFlows is a planet's per-resource production and consumption.
+type PlanetFlows struct {
+	PlanetID   int            `json:"planet_id"`
+	PlanetName string         `json:"planet_name"`
+	Population int64          `json:"population"`
+	PowerRatio float64        `json:"power_ratio"`
+	Flows      []ResourceFlow `json:"flows"`
+}
+
+// ResourceFlow is one resource's flow on a planet.
+type ResourceFlow struct {
+	Resource        string  `json:"resource"`
+	Stored          int     `json:"stored"`
+	Production      float64 `json:"production"`        // per interval from mines
+	PopDrain        float64 `json:"pop_drain"`         // consumed by population
+	BuildDrain      float64 `json:"build_drain"`       // consumed by buildings (refinery, generator, etc)
+	NetFlow         float64 `json:"net_flow"`
+	TicksUntilEmpty int     `json:"ticks_until_empty"` // at current drain rate, 0=stable/growing
+}
+
+// PlanetPhysics is a planet's formation data for the dashboard.
+type PlanetPhysics struct {
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	Type           string  `json:"type"`
+	Owner          string  `json:"owner"`
+	System         string  `json:"system"`
+	Mass           float64 `json:"mass"`
+	Radius         float64 `json:"radius"`
+	Gravity        float64 `json:"gravity"`
+	Density        float64 `json:"density"`
+	OrbitAU        float64 `json:"orbit_au"`
+	TempC          int     `json:"temp_c"`
+	Iron           float64 `json:"iron"`
+	Silicate       float64 `json:"silicate"`
+	Water          float64 `json:"water"`
+	Gas            float64 `json:"gas"`
+	Organics       float64 `json:"organics"`
+	RareEarth      float64 `json:"rare_earth"`
+	MagneticField  float64 `json:"magnetic_field"`
+	AtmoPressure   float64 `json:"atmo_pressure"`
+	OceanCoverage  float64 `json:"ocean_coverage"`
+	IceCoverage    float64 `json:"ice_coverage"`
+	TidallyLocked  bool    `json:"tidally_locked"`
+	TectonicActive bool    `json:"tectonic_active"`
+	VolcanicLevel  float64 `json:"volcanic_level"`
+	DayLength      float64 `json:"day_length"`
+	Moons          int     `json:"moons"`
+	ParentID       int     `json:"parent_id,omitempty"`
+	Habitability   int     `json:"habitability"`
+}
+
+// ChatSendRequest is the body for POST /api/chat/send.
+type ChatSendRequest struct {
+	Message string `json:"message"`
+}
+
+// ChatRequest is the body for POST /api/chat.
+type ChatRequest struct {
+	Message string                   `json:"message"`
+	History []map[string]interface{} `json:"history"` // prior conversation turns
+}
+
+// ChatReply is the assistant's answer and the UI actions it requested.
+type ChatReply struct {
+	Response string   `json:"response"`
+	Actions  []string `json:"actions"`
+}
+
+// DiplomacyRequest is the body for POST /api/diplomacy.
+type DiplomacyRequest struct {
+	Target string `json:"target"`
+	Action string `json:"action"` // "improve" or "degrade"
+}
+
+// DiplomacyResult is a relation after it changed.
+type DiplomacyResult struct {
+	You      string `json:"you"`
+	Target   string `json:"target"`
+	Relation string `json:"relation"`
+	Level    int    `json:"level"`
+}
+
+// EspionageRequest is the body for POST /api/espionage.
+type EspionageRequest struct {
+	Target   string `json:"target"`
+	Type     string `json:"type"` // "intel", "sabotage" or "steal_tech"
+	SystemID int    `json:"system_id"`
+}
+
+// BountyRequest is the body for POST /api/bounties.
+type BountyRequest struct {
+	Type        string `json:"type"`
+	Description string `json:"description"`
+	Reward      int    `json:"reward"`
+	Resource    string `json:"resource,omitempty"`
+	Quantity    int    `json:"quantity,omitempty"`
+	PlanetID    int    `json:"planet_id,omitempty"`
+	SystemID    int    `json:"system_id,omitempty"`
+}
+
+// BountyClaimRequest is the body for POST /api/bounties/claim.
+type BountyClaimRequest struct {
+	BountyID int `json:"bounty_id"`
+}
+
+// ProposalRequest is the body for POST /api/council.
+type ProposalRequest struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	Effect      string `json:"effect"`
+}
+
+// VoteRequest is the body for POST /api/council/vote.
+type VoteRequest struct {
+	ProposalID int  `json:"proposal_id"`
+	Yes        bool `json:"yes"`
+}
+
+// IntelItem is a notable map feature pulled from recent events.
+type IntelItem struct {
+	Type     string `json:"type"`
+	SystemID int    `json:"system_id,omitempty"`
+	Message  string `json:"message"`
+}
+
+// VictoryProgress is a player's progress toward each victory condition.
+type VictoryProgress struct {
+	Player     string            `json:"player"`
+	Credits    int               `json:"credits"`
+	Planets    int               `json:"planets"`
+	MaxTech    float64           `json:"max_tech"`
+	Population int64             `json:"population"`
+	Progress   map[string]string `json:"progress"`
+}
+
+// RegisterRequest is the body for POST /api/admin/register.
+type RegisterRequest struct {
+	Name string `json:"name"`
+}
+
+// RegisterResult is a registered account and its login key.
+type RegisterResult struct {
+	Name     string `json:"name"`
+	APIKey   string `json:"api_key"`
+	PlayerID int    `json:"player_id"`
+	New      bool   `json:"new"`
+}
+
+// RemovePlayerRequest is the body for POST /api/admin/remove-player.
+type RemovePlayerRequest struct {
+	Name string `json:"name"`
+}
+
+// RateLimits are an account's request limits, in cost units (most routes
+// cost 1; see x-rate-limit-cost in the OpenAPI document). In an admin
+// override, zero fields keep the server default and a negative DailyQuota
+// lifts the quota.
+type RateLimits struct {
+	ReadRate   float64 `json:"read_rate"`   // per second, for GETs
+	WriteRate  float64 `json:"write_rate"`  // per second, for POSTs
+	BurstRead  int     `json:"burst_read"`  // GET bucket size
+	BurstWrite int     `json:"burst_write"` // POST bucket size
+	DailyQuota float64 `json:"daily_quota"` // per UTC day; 0 = unlimited
+}
+
+// AccountRateLimit is one account's effective limits and today's usage.
+type AccountRateLimit struct {
+	Player    string      `json:"player"`
+	Limits    RateLimits  `json:"limits"`
+	Override  *RateLimits `json:"override,omitempty"` // as set by an admin
+	DailyUsed float64     `json:"daily_used"`
+}
+
+// RateLimitReport is the response for GET /api/admin/rate-limits.
+type RateLimitReport struct {
+	Defaults RateLimits         `json:"defaults"`
+	Accounts []AccountRateLimit `json:"accounts"` // accounts with an override or usage today
+}
+
+// RateLimitRequest is the body for POST /api/admin/rate-limits.
+type RateLimitRequest struct {
+	Player     string     `json:"player"`
+	Limits     RateLimits `json:"limits"`                // the override to set
+	Clear      bool       `json:"clear,omitempty"`       // drop the override instead
+	ResetUsage bool       `json:"reset_usage,omitempty"` // also zero today's usage
+}
+
+// CreatedKey is the response for POST /api/keys. APIKey is shown only once.
+type CreatedKey struct {
+	APIKey string     `json:"api_key"`
+	Key    APIKeyInfo `json:"key"`
+}
+
+// BatchRequest is the body for POST /api/batch.
+type BatchRequest struct {
+	Commands []BatchCommand `json:"commands"`
+	Atomic   bool           `json:"atomic,omitempty"` // stop at the first failure and roll back credits and resources
+}
+
+// BatchCommand is one command in a batch. Data is the body the command's own
+// endpoint takes, e.g. {"type": "build", "data": {"planet_id": 12, "building_type": "Mine"}}.
+type BatchCommand struct {
+	Type string          `json:"type"` // trade, build, build_ship, cargo_load, move_ship, ...
+	Data json.RawMessage `json:"data"`
+}
+
+// BatchResponse is the response for POST /api/batch.
+type BatchResponse struct {
+	Results    []BatchStepResult `json:"results"`
+	Succeeded  int               `json:"succeeded"`
+	RolledBack bool              `json:"rolled_back,omitempty"`
+}
+
+// BatchStepResult is the outcome of one batched command.
+type BatchStepResult struct {
+	Type    string      `json:"type"`
+	OK      bool        `json:"ok"`
+	Data    interface{} `json:"data,omitempty"`
+	Error   string      `json:"error,omitempty"`
+	Skipped bool        `json:"skipped,omitempty"` // not run: an earlier command in an atomic batch failed
+}
+
+// HostedGame describes a game hosted alongside the default one, served
+// under /api/games/{id}/.
+type HostedGame struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"` // running, paused or archived
+	Seed      int64  `json:"seed"`
+	Tick      int64  `json:"tick"`
+	GameTime  string `json:"game_time,omitempty"`
+	Players   int    `json:"players"`
+	CreatedAt string `json:"created_at"`
+}
+
+// Hosted game statuses.
+const (
+	GameRunning  = "running"
+	GamePaused   = "paused"
+	GameArchived = "archived"
+)
+
+// CreateGameRequest is the body for POST /api/admin/games.
+type CreateGameRequest struct {
+	ID     string           `json:"id"`               // lowercase letters, digits and dashes
+	Name   string           `json:"name,omitempty"`   // default: the ID
+	Seed   int64            `json:"seed,omitempty"`   // galaxy seed (0 = time-based); overrides config.seed
+	Config *game.GameConfig `json:"config,omitempty"` // match setup (default: the standard game; see /api/lobby)
+}
+
+// LobbyInfo is the response for GET /api/lobby: what a new game can be
+// set up with, and the games already open.
+type LobbyInfo struct {
+	Defaults          game.GameConfig `json:"defaults"`           // the standard setup; omitted config fields keep these
+	TickableSystems   []string        `json:"tickable_systems"`   // names accepted by disabled_systems
+	VictoryConditions []string        `json:"victory_conditions"` // names accepted by victory
+	Games             []HostedGame    `json:"games"`              // hosted games open for play
+}
+
+// GameRequest names a hosted game for the admin pause/resume/archive endpoints.
+type GameRequest struct {
+	ID string `json:"id"`
+}
@@ -0,0 +1,120 @@
+//go:build !js
+
+package api
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// webhookInfo converts a stored webhook to its API form.
+func webhookInfo(h game.Webhook) WebhookInfo {
+	events := make([]string, len(h.Events))
+	for i, e := range h.Events {
+		events[i] = string(e)
+	}
+	return WebhookInfo{ID: h.ID, URL: h.URL, Events: events, CreatedAt: h.CreatedAt.Format(time.RFC3339)}
+}
+
+// registerWebhookEndpoints registers outbound webhooks:
+//   - GET  /api/webhooks               list the caller's webhooks (admin: ?player=)
+//   - POST /api/webhooks               register a public HTTPS URL for some event types
+//   - POST /api/webhooks/delete        remove a webhook
+//   - GET  /api/webhooks/dead-letters  deliveries that ran out of retries
+//   - POST /api/webhooks/redeliver     queue a dead-lettered delivery again
+//
+// A webhook gets the owner's own events of the chosen types, plus
+// galaxy-wide ones. Changing webhooks needs a full-access key.
+func registerWebhookEndpoints(rt *router) {
+	get(rt, "/api/webhooks", doc{Tag: "webhooks", Summary: "List your webhooks", Query: []param{
+		{"player", "string", "admin only: whose webhooks"},
+	}}, func(r *http.Request) ([]WebhookInfo, error) {
+		registry := getProvider(r).GetRegistry()
+		if registry == nil {
+			return nil, errUnavailable("registry")
+		}
+		player := keyOwner(r, r.URL.Query().Get("player"))
+		if player == "" {
+			return nil, errAuthRequired
+		}
+		hooks, err := registry.ListWebhooks(player)
+		if err != nil {
+			return nil, errNotFound("%s", err)
+		}
+		out := make([]WebhookInfo, 0, len(hooks))
+		for _, h := range hooks {
+			out = append(out, webhookInfo(h))
+		}
+		return out, nil
+	})
+
+	post(rt, "/api/webhooks", doc{Tag: "webhooks", Summary: "Register a webhook for some event types"},
+		func(r *http.Request, req *CreateWebhookRequest) (CreatedWebhook, error) {
+			registry := getProvider(r).GetRegistry()
+			if registry == nil {
+				return CreatedWebhook{}, errUnavailable("registry")
+			}
+			player := keyOwner(r, req.Player)
+			if player == "" {
+				return CreatedWebhook{}, errors.New("player required")
+			}
+			events := make([]game.EventType, len(req.Events))
+			for i, e := range req.Events {
+				events[i] = game.EventType(e)
+			}
+			hook, err := registry.CreateWebhook(player, req.URL, events, req.Secret)
+			if err != nil {
+				return CreatedWebhook{}, err
+			}
+			return CreatedWebhook{Secret: hook.Secret, Webhook: webhookInfo(*hook)}, nil
+		})
+
+	post(rt, "/api/webhooks/delete", doc{Tag: "webhooks", Summary: "Remove a webhook"},
+		func(r *http.Request, req *WebhookRequest) (map[string]string, error) {
+			registry := getProvider(r).GetRegistry()
+			if registry == nil {
+				return nil, errUnavailable("registry")
+			}
+			player := keyOwner(r, req.Player)
+			if player == "" {
+				return nil, errors.New("player required")
+			}
+			if err := registry.DeleteWebhook(player, req.ID); err != nil {
+				return nil, errNotFound("%s", err)
+			}
+			return map[string]string{"deleted": req.ID}, nil
+		})
+
+	get(rt, "/api/webhooks/dead-letters", doc{Tag: "webhooks", Summary: "Deliveries that failed every retry, newest first", Query: []param{
+		{"player", "string", "admin only: whose deliveries"},
+	}}, func(r *http.Request) ([]game.WebhookDelivery, error) {
+		webhooks := getProvider(r).GetWebhooks()
+		if webhooks == nil {
+			return nil, errUnavailable("webhooks")
+		}
+		player := keyOwner(r, r.URL.Query().Get("player"))
+		if player == "" {
+			return nil, errAuthRequired
+		}
+		return webhooks.DeadLetters(player), nil
+	})
+
+	post(rt, "/api/webhooks/redeliver", doc{Tag: "webhooks", Summary: "Retry a dead-lettered delivery"},
+		func(r *http.Request, req *WebhookRequest) (map[string]string, error) {
+			webhooks := getProvider(r).GetWebhooks()
+			if webhooks == nil {
+				return nil, errUnavailable("webhooks")
+			}
+			player := keyOwner(r, req.Player)
+			if player == "" {
+				return nil, errors.New("player required")
+			}
+			if err := webhooks.Redeliver(player, req.ID); err != nil {
+				return nil, errNotFound("%s", err)
+			}
+			return map[string]string{"queued": req.ID}, nil
+		})
+}
@@ -66,7 +66,7 @@ STRATEGY PRIORITIES:
 12. At Tech 2.0: build Fusion Reactor (He-3→200MW clean power)
 
 ADVANCED TRADING:
-- place_limit_order: set a buy/sell price and the order auto-matches with counterparties
+- place_limit_order: set a buy/sell price; it fills at the best resting prices and the rest waits on the book (credits or goods are held until it fills or you cancel)
   Example: place_limit_order(system_id=17, planet_id=17523, resource="Oil", action="buy", quantity=100, price=500)
 - create_contract: lock in a recurring supply deal with another faction
   Example: create_contract(buyer="Llama Logistics", resource="Oil", quantity=50, price_per_unit=400, interval=200, system_id=17, planet_id=17523)
@@ -101,6 +101,8 @@ type Faction struct {
 	MaxHistory  int
 }
 
+// factionPersonalities are the default factions' briefs, for servers that
+// don't publish their game config. Otherwise the config's take precedence.
 var factionPersonalities = map[string]string{
 	"Llama Logistics":    "You are methodical and logistics-focused. Prioritize cargo ships, trade routes, and efficient supply chains. You prefer steady income over risky plays.",
 	"DeepSeek Ventures":  "You are analytical and data-driven. Focus on arbitrage opportunities — buy low, sell high. Upgrade buildings for maximum efficiency. You crunch numbers before every decision.",
@@ -249,8 +251,8 @@ var tools = []openai.Tool{
 		Parameters: json.RawMessage(`{"type":"object","properties":{"system_id":{"type":"integer"}},"required":["system_id"]}`),
 	}},
 	{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{
-		Name: "place_limit_order", Description: "Place a limit buy/sell order on a system's order book. Buy: 'I will pay up to X credits for Y units'. Sell: 'I will sell Y units at X credits minimum'. Orders match automatically when buy price >= sell price.",
-		Parameters: json.RawMessage(`{"type":"object","properties":{"system_id":{"type":"integer"},"planet_id":{"type":"integer","description":"your planet in this system"},"resource":{"type":"string"},"action":{"type":"string","enum":["buy","sell"]},"quantity":{"type":"integer"},"price":{"type":"integer","description":"limit price per unit"}},"required":["system_id","planet_id","resource","action","quantity","price"]}`),
+		Name: "place_limit_order", Description: "Place a limit buy/sell order on a system's order book. Buy: 'I will pay up to X credits for Y units'. Sell: 'I will sell Y units at X credits minimum'. Placing escrows the credits (buy) or goods (sell) up front; orders fill immediately against the best resting prices and the rest waits on the book.",
+		Parameters: json.RawMessage(`{"type":"object","properties":{"system_id":{"type":"integer"},"planet_id":{"type":"integer","description":"your planet in this system"},"resource":{"type":"string"},"action":{"type":"string","enum":["buy","sell"]},"quantity":{"type":"integer"},"price":{"type":"integer","description":"limit price per unit (for a stop buy, the most you'll pay)"},"type":{"type":"string","enum":["limit","stop","stop_limit","take_profit","take_profit_limit"],"description":"stop/take_profit orders wait until the market price reaches stop_price"},"stop_price":{"type":"integer"},"time_in_force":{"type":"string","enum":["gtc","ioc","fok"],"description":"gtc rests until filled; ioc fills what it can now; fok fills completely now or not at all"},"expires_at":{"type":"integer","description":"tick the order lapses at"},"display_quantity":{"type":"integer","description":"iceberg: show only this many units at a time"}},"required":["system_id","planet_id","resource","action","quantity"]}`),
 	}},
 	{Type: openai.ToolTypeFunction, Function: &openai.FunctionDefinition{
 		Name: "create_contract", Description: "Create a supply contract: you commit to delivering X units of a resource to a buyer every N ticks at a fixed price. The delivery auto-executes if you have stock in the same system. Great for guaranteed income.",
@@ -601,6 +603,26 @@ func main() {
 		time.Sleep(2 * time.Second)
 	}
 
+	// Use the personalities the game was set up with, where it has them
+	if result, err := callAPI("GET", "/api/game", "", gameAPIKey); err == nil {
+		var gameResp struct {
+			Data struct {
+				Config struct {
+					AIFactions []struct {
+						Name        string `json:"name"`
+						Personality string `json:"personality"`
+					} `json:"ai_factions"`
+				} `json:"config"`
+			} `json:"data"`
+		}
+		json.Unmarshal([]byte(result), &gameResp)
+		for _, f := range gameResp.Data.Config.AIFactions {
+			if f.Personality != "" {
+				factionPersonalities[f.Name] = f.Personality
+			}
+		}
+	}
+
 	// Get faction list from the server
 	result, err := callAPI("GET", "/api/players", "", gameAPIKey)
 	if err != nil {
@@ -16,6 +16,7 @@ func (a *App) GetHumanPlayer() *entities.Player      { return a.Server.GetHumanP
 func (a *App) GetSeed() int64                        { return a.Server.GetSeed() }
 func (a *App) GetMarket() *economy.Market            { return a.Server.GetMarket() }
 func (a *App) GetTradeExecutor() *economy.TradeExecutor { return a.Server.GetTradeExecutor() }
+func (a *App) GetCandleHistory() *economy.CandleHistory { return a.Server.GetCandleHistory() }
 
 func (a *App) GetCargoCommander() *game.CargoCommandExecutor {
 	return a.Server.GetCargoCommander()
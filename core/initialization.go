@@ -117,14 +117,14 @@ func (a *App) LoadGameFromPath(path string) error {
 func (a *App) ConnectToRemote(serverURL, playerName, apiKey string) error {
 	remote := server.NewRemoteSync(a.Server, serverURL, apiKey)
 
-	// Fetch the remote galaxy seed so we generate the same universe
-	seed, err := remote.FetchSeed()
+	// Fetch the remote galaxy seed and setup so we generate the same universe
+	cfg, err := remote.FetchConfig()
 	if err != nil {
 		return fmt.Errorf("failed to fetch galaxy: %v", err)
 	}
 
 	// Generate the same galaxy as the remote server
-	if err := a.Server.NewGameWithSeed(playerName, seed); err != nil {
+	if err := a.Server.NewGameWithConfig(playerName, cfg); err != nil {
 		return fmt.Errorf("failed to initialize: %v", err)
 	}
 
@@ -1,6 +1,9 @@
 package economy
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/hunterjsb/xandaris/entities"
 )
 
@@ -33,7 +36,10 @@ func processAIPlayer(executor *TradeExecutor, player *entities.Player, allPlayer
 		if planet == nil {
 			continue
 		}
-		for resType, storage := range planet.StoredResources {
+		// In name order: each trade moves the price the next one sees, and
+		// seeded games must replay identically
+		for _, resType := range slices.Sorted(maps.Keys(planet.StoredResources)) {
+			storage := planet.StoredResources[resType]
 			if storage == nil || storage.Capacity <= 0 {
 				continue
 			}
@@ -126,7 +132,8 @@ func processAIPlayer(executor *TradeExecutor, player *entities.Player, allPlayer
 		// Speculative trading: sell when price is very high (even below surplus threshold)
 		// This creates more dynamic markets — AI acts as price stabilizer
 		if player.Credits > 3000 {
-			for resType, storage := range planet.StoredResources {
+			for _, resType := range slices.Sorted(maps.Keys(planet.StoredResources)) {
+				storage := planet.StoredResources[resType]
 				if storage == nil || storage.Amount < 50 {
 					continue // keep minimum buffer
 				}
@@ -105,3 +105,34 @@ func (ah *AuctionHouse) GetActiveAuctions() []*Auction {
 	}
 	return result
 }
+
+// GetAllAuctions returns every auction, including completed ones (for save/load).
+func (ah *AuctionHouse) GetAllAuctions() []*Auction {
+	ah.mu.RLock()
+	defer ah.mu.RUnlock()
+	return append([]*Auction{}, ah.auctions...)
+}
+
+// GetNextID returns the ID the next auction will receive (for save/load).
+func (ah *AuctionHouse) GetNextID() int {
+	ah.mu.RLock()
+	defer ah.mu.RUnlock()
+	return ah.nextID
+}
+
+// RestoreAuctions loads auctions and the ID counter from a save.
+// The counter never goes below the highest restored ID + 1.
+func (ah *AuctionHouse) RestoreAuctions(auctions []*Auction, nextID int) {
+	ah.mu.Lock()
+	defer ah.mu.Unlock()
+	ah.auctions = append([]*Auction{}, auctions...)
+	ah.nextID = nextID
+	for _, a := range ah.auctions {
+		if a.ID >= ah.nextID {
+			ah.nextID = a.ID + 1
+		}
+	}
+	if ah.nextID < 1 {
+		ah.nextID = 1
+	}
+}
@@ -23,15 +23,16 @@ func NewBlackMarket() *BlackMarket {
 	return &BlackMarket{}
 }
 
-// BlackMarketSell attempts to sell on the black market.
-// Returns: credits earned (0 if seized), seized bool, error
-func (bm *BlackMarket) BlackMarketSell(basePrice float64, quantity int) (int, bool) {
+// BlackMarketSell attempts to sell on the black market, rolling for
+// seizure with rng.
+// Returns: credits earned (0 if seized), seized bool
+func (bm *BlackMarket) BlackMarketSell(basePrice float64, quantity int, rng *rand.Rand) (int, bool) {
 	bm.mu.Lock()
 	defer bm.mu.Unlock()
 	bm.transactions++
 
 	// 15% chance of seizure
-	if rand.Intn(100) < 15 {
+	if rng.Intn(100) < 15 {
 		bm.seizures++
 		fmt.Printf("[BlackMarket] Goods seized! (%d seizures / %d transactions)\n",
 			bm.seizures, bm.transactions)
@@ -59,3 +60,11 @@ func (bm *BlackMarket) GetStats() (transactions, seizures int) {
 	defer bm.mu.RUnlock()
 	return bm.transactions, bm.seizures
 }
+
+// RestoreStats loads activity counters from a save.
+func (bm *BlackMarket) RestoreStats(transactions, seizures int) {
+	bm.mu.Lock()
+	defer bm.mu.Unlock()
+	bm.transactions = transactions
+	bm.seizures = seizures
+}
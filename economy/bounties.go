@@ -124,3 +124,34 @@ func (bb *BountyBoard) GetActiveBounties() []*Bounty {
 	}
 	return result
 }
+
+// GetAllBounties returns every bounty, including finished ones (for save/load).
+func (bb *BountyBoard) GetAllBounties() []*Bounty {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return append([]*Bounty{}, bb.bounties...)
+}
+
+// GetNextID returns the ID the next bounty will receive (for save/load).
+func (bb *BountyBoard) GetNextID() int {
+	bb.mu.RLock()
+	defer bb.mu.RUnlock()
+	return bb.nextID
+}
+
+// RestoreBounties loads bounties and the ID counter from a save.
+// The counter never goes below the highest restored ID + 1.
+func (bb *BountyBoard) RestoreBounties(bounties []*Bounty, nextID int) {
+	bb.mu.Lock()
+	defer bb.mu.Unlock()
+	bb.bounties = append([]*Bounty{}, bounties...)
+	bb.nextID = nextID
+	for _, b := range bb.bounties {
+		if b.ID >= bb.nextID {
+			bb.nextID = b.ID + 1
+		}
+	}
+	if bb.nextID < 1 {
+		bb.nextID = 1
+	}
+}
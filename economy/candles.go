@@ -0,0 +1,186 @@
+package economy
+
+import (
+	"sort"
+	"sync"
+)
+
+// CandleResolutions are the candle widths, in ticks, every series is kept at.
+var CandleResolutions = []int64{100, 1000, 10000}
+
+// GlobalCandles is the system ID of the galaxy-wide series.
+const GlobalCandles = -1
+
+// candleRetention is how many candles each series keeps: 120 candles is
+// 12k ticks at the finest resolution and 1.2M at the coarsest.
+const candleRetention = 120
+
+// Candle is one OHLCV bar covering [Start, Start+resolution). Prices are
+// mid prices, halfway between the buy and sell price; Volume is units
+// traded.
+type Candle struct {
+	Start  int64
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume int
+}
+
+// CandleSeries is one series' candles, oldest first, as saved.
+type CandleSeries struct {
+	SystemID   int
+	Resource   string
+	Resolution int64
+	Candles    []Candle
+}
+
+type candleKey struct {
+	systemID   int
+	resource   string
+	resolution int64
+}
+
+// CandleHistory keeps OHLCV candles per resource, galaxy-wide and per
+// system, at each of CandleResolutions. Thread-safe.
+type CandleHistory struct {
+	mu     sync.RWMutex
+	series map[candleKey][]Candle
+}
+
+// NewCandleHistory creates an empty candle history.
+func NewCandleHistory() *CandleHistory {
+	return &CandleHistory{series: make(map[candleKey][]Candle)}
+}
+
+// Sample folds a price observed at tick into systemID's series for the
+// resource (GlobalCandles for the galaxy-wide one).
+func (h *CandleHistory) Sample(tick int64, systemID int, resource string, price float64) {
+	if price <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for _, res := range CandleResolutions {
+		key := candleKey{systemID, resource, res}
+		c := h.candleLocked(key, tick, true)
+		if c == nil {
+			continue
+		}
+		if c.Open == 0 {
+			c.Open, c.High, c.Low = price, price, price
+		}
+		if price > c.High {
+			c.High = price
+		}
+		if price < c.Low {
+			c.Low = price
+		}
+		c.Close = price
+	}
+}
+
+// AddVolume counts units traded at tick toward systemID's series and the
+// galaxy-wide one. Volume is dropped for a series with no price yet.
+func (h *CandleHistory) AddVolume(tick int64, systemID int, resource string, quantity int) {
+	if quantity <= 0 {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ids := []int{GlobalCandles}
+	if systemID != GlobalCandles {
+		ids = append(ids, systemID)
+	}
+	for _, id := range ids {
+		for _, res := range CandleResolutions {
+			if c := h.candleLocked(candleKey{id, resource, res}, tick, false); c != nil {
+				c.Volume += quantity
+			}
+		}
+	}
+}
+
+// candleLocked returns the candle covering tick, opening one if the last
+// candle is older. A new candle starts flat at the previous close; with no
+// previous candle it is only opened for a sample, and left for the caller
+// to price. Samples from before the last candle are ignored, while late
+// volume (trades stamped with a lagging tick) counts toward it.
+func (h *CandleHistory) candleLocked(key candleKey, tick int64, sample bool) *Candle {
+	start := tick - tick%key.resolution
+	s := h.series[key]
+	if n := len(s); n > 0 {
+		last := &s[n-1]
+		if start == last.Start {
+			return last
+		}
+		if start < last.Start {
+			if sample {
+				return nil
+			}
+			return last
+		}
+		p := last.Close
+		s = append(s, Candle{Start: start, Open: p, High: p, Low: p, Close: p})
+	} else if sample {
+		s = append(s, Candle{Start: start})
+	} else {
+		return nil
+	}
+	if len(s) > candleRetention {
+		s = s[len(s)-candleRetention:]
+	}
+	h.series[key] = s
+	return &s[len(s)-1]
+}
+
+// Candles returns up to limit of the most recent candles for a series,
+// oldest first (all retained candles when limit <= 0).
+func (h *CandleHistory) Candles(systemID int, resource string, resolution int64, limit int) []Candle {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	s := h.series[candleKey{systemID, resource, resolution}]
+	if limit > 0 && len(s) > limit {
+		s = s[len(s)-limit:]
+	}
+	out := make([]Candle, len(s))
+	copy(out, s)
+	return out
+}
+
+// Snapshot returns every series for saving, in a stable order.
+func (h *CandleHistory) Snapshot() []CandleSeries {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	out := make([]CandleSeries, 0, len(h.series))
+	for key, s := range h.series {
+		candles := make([]Candle, len(s))
+		copy(candles, s)
+		out = append(out, CandleSeries{key.systemID, key.resource, key.resolution, candles})
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.SystemID != b.SystemID {
+			return a.SystemID < b.SystemID
+		}
+		if a.Resource != b.Resource {
+			return a.Resource < b.Resource
+		}
+		return a.Resolution < b.Resolution
+	})
+	return out
+}
+
+// Restore replaces all series with ones from a save.
+func (h *CandleHistory) Restore(series []CandleSeries) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.series = make(map[candleKey][]Candle, len(series))
+	for _, s := range series {
+		candles := s.Candles
+		if len(candles) > candleRetention {
+			candles = candles[len(candles)-candleRetention:]
+		}
+		h.series[candleKey{s.SystemID, s.Resource, s.Resolution}] = append([]Candle(nil), candles...)
+	}
+}
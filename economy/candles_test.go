@@ -0,0 +1,71 @@
+package economy
+
+import "testing"
+
+func TestCandleHistoryOHLCV(t *testing.T) {
+	h := NewCandleHistory()
+	h.AddVolume(5, 3, "Iron", 10) // no price yet: dropped
+	for _, s := range []struct {
+		tick  int64
+		price float64
+	}{{110, 100}, {120, 130}, {150, 80}, {190, 90}} {
+		h.Sample(s.tick, 3, "Iron", s.price)
+	}
+	h.AddVolume(160, 3, "Iron", 7)
+	h.Sample(250, 3, "Iron", 95)
+	h.AddVolume(190, 3, "Iron", 1) // stamped late: counts toward the newest candle
+	h.AddVolume(260, GlobalCandles, "Iron", 4) // no galaxy-wide price yet: dropped
+
+	got := h.Candles(3, "Iron", 100, 0)
+	if len(got) != 2 {
+		t.Fatalf("got %d candles, want 2: %+v", len(got), got)
+	}
+	want := Candle{Start: 100, Open: 100, High: 130, Low: 80, Close: 90, Volume: 7}
+	if got[0] != want {
+		t.Errorf("first candle = %+v, want %+v", got[0], want)
+	}
+	// The next candle opens at the previous close
+	if got[1] != (Candle{Start: 200, Open: 90, High: 95, Low: 90, Close: 95, Volume: 1}) {
+		t.Errorf("second candle = %+v", got[1])
+	}
+	if coarse := h.Candles(3, "Iron", 1000, 0); len(coarse) != 1 || coarse[0].Open != 100 || coarse[0].Close != 95 || coarse[0].Volume != 8 {
+		t.Errorf("1000-tick candles = %+v", coarse)
+	}
+
+	// Local volume also counts galaxy-wide, which opens flat at its last close
+	h.Sample(100, GlobalCandles, "Iron", 50)
+	h.AddVolume(300, 3, "Iron", 2)
+	global := h.Candles(GlobalCandles, "Iron", 100, 0)
+	if len(global) != 2 || global[1] != (Candle{Start: 300, Open: 50, High: 50, Low: 50, Close: 50, Volume: 2}) {
+		t.Errorf("global candles = %+v", global)
+	}
+}
+
+func TestCandleHistoryRetentionAndRestore(t *testing.T) {
+	h := NewCandleHistory()
+	for i := int64(0); i < candleRetention+30; i++ {
+		h.Sample(i*100, GlobalCandles, "Fuel", float64(100+i))
+	}
+	got := h.Candles(GlobalCandles, "Fuel", 100, 0)
+	if len(got) != candleRetention || got[0].Start != 3000 {
+		t.Fatalf("kept %d candles from tick %d, want %d from 3000", len(got), got[0].Start, candleRetention)
+	}
+	if last := h.Candles(GlobalCandles, "Fuel", 100, 5); len(last) != 5 || last[4].Close != float64(100+candleRetention+29) {
+		t.Errorf("last 5 candles = %+v", last)
+	}
+
+	// Samples from before the newest candle are ignored
+	h.Sample(500, GlobalCandles, "Fuel", 1)
+	if got := h.Candles(GlobalCandles, "Fuel", 100, 1); got[0].Low == 1 {
+		t.Errorf("stale sample changed the newest candle: %+v", got[0])
+	}
+
+	restored := NewCandleHistory()
+	restored.Restore(h.Snapshot())
+	for _, res := range CandleResolutions {
+		a, b := h.Candles(GlobalCandles, "Fuel", res, 0), restored.Candles(GlobalCandles, "Fuel", res, 0)
+		if len(a) != len(b) || a[len(a)-1] != b[len(b)-1] {
+			t.Errorf("resolution %d: restored %d candles, want %d", res, len(b), len(a))
+		}
+	}
+}
@@ -110,3 +110,37 @@ func (gc *GalacticCouncil) GetActiveProposals() []*CouncilProposal {
 	}
 	return result
 }
+
+// GetAllProposals returns every proposal, including resolved ones (for save/load).
+func (gc *GalacticCouncil) GetAllProposals() []*CouncilProposal {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return append([]*CouncilProposal{}, gc.proposals...)
+}
+
+// GetNextID returns the ID the next proposal will receive (for save/load).
+func (gc *GalacticCouncil) GetNextID() int {
+	gc.mu.RLock()
+	defer gc.mu.RUnlock()
+	return gc.nextID
+}
+
+// RestoreProposals loads proposals and the ID counter from a save.
+// The counter never goes below the highest restored ID + 1.
+func (gc *GalacticCouncil) RestoreProposals(proposals []*CouncilProposal, nextID int) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	gc.proposals = append([]*CouncilProposal{}, proposals...)
+	gc.nextID = nextID
+	for _, p := range gc.proposals {
+		if p.Votes == nil {
+			p.Votes = make(map[string]bool) // gob drops empty maps
+		}
+		if p.ID >= gc.nextID {
+			gc.nextID = p.ID + 1
+		}
+	}
+	if gc.nextID < 1 {
+		gc.nextID = 1
+	}
+}
@@ -176,6 +176,28 @@ func (dm *DeliveryManager) GetAllDeliveries() []*PendingDelivery {
 	return append([]*PendingDelivery{}, dm.deliveries...)
 }
 
+// GetNextID returns the ID the next delivery will receive.
+func (dm *DeliveryManager) GetNextID() int {
+	dm.mu.RLock()
+	defer dm.mu.RUnlock()
+	return dm.nextID
+}
+
+// DiscardFrom removes every delivery created since GetNextID returned id,
+// as if they had never been made (for rolling back an atomic batch).
+func (dm *DeliveryManager) DiscardFrom(id int) {
+	dm.mu.Lock()
+	defer dm.mu.Unlock()
+	kept := dm.deliveries[:0]
+	for _, d := range dm.deliveries {
+		if d.ID < id {
+			kept = append(kept, d)
+		}
+	}
+	dm.deliveries = kept
+	dm.nextID = id
+}
+
 // RestoreDeliveries loads deliveries from a save (for save/load).
 func (dm *DeliveryManager) RestoreDeliveries(deliveries []*PendingDelivery) {
 	dm.mu.Lock()
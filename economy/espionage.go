@@ -16,8 +16,16 @@ type SpyOperation struct {
 	TicksLeft  int    // ticks until completion
 	Cost       int    // credits already paid
 	Active     bool
+
+	// Set when the op completes.
+	Succeeded     bool
+	CompletedTick int64
 }
 
+// IntelDuration is how many ticks a successful intel op keeps the target's
+// holdings visible to the operator.
+const IntelDuration = 2000
+
 // SpyResult is returned when an operation completes.
 type SpyResult struct {
 	Success bool
@@ -67,8 +75,9 @@ func (em *EspionageManager) LaunchOperation(operator, target, opType string, sys
 	return op
 }
 
-// TickOperations decrements timers and returns completed operations.
-func (em *EspionageManager) TickOperations() []*SpyOperation {
+// TickOperations advances timers by elapsed ticks and returns the
+// operations that completed.
+func (em *EspionageManager) TickOperations(elapsed int) []*SpyOperation {
 	em.mu.Lock()
 	defer em.mu.Unlock()
 
@@ -77,7 +86,7 @@ func (em *EspionageManager) TickOperations() []*SpyOperation {
 		if !op.Active {
 			continue
 		}
-		op.TicksLeft--
+		op.TicksLeft -= elapsed
 		if op.TicksLeft <= 0 {
 			op.Active = false
 			completed = append(completed, op)
@@ -89,7 +98,7 @@ func (em *EspionageManager) TickOperations() []*SpyOperation {
 // ResolveOperation determines success/failure of a completed spy op.
 // Base success rate: 60% for intel, 40% for sabotage, 30% for steal_tech.
 // Planetary Shield reduces success by 20%.
-func ResolveOperation(op *SpyOperation, targetHasShield bool) SpyResult {
+func ResolveOperation(op *SpyOperation, targetHasShield bool, rng *rand.Rand) SpyResult {
 	baseRate := 0.6
 	switch op.Type {
 	case "sabotage":
@@ -102,7 +111,7 @@ func ResolveOperation(op *SpyOperation, targetHasShield bool) SpyResult {
 		baseRate -= 0.2
 	}
 
-	success := rand.Float64() < baseRate
+	success := rng.Float64() < baseRate
 
 	if !success {
 		return SpyResult{
@@ -148,3 +157,57 @@ func (em *EspionageManager) GetActiveOps(player string) []*SpyOperation {
 	}
 	return result
 }
+
+// RecordOutcome marks a completed operation as succeeded or failed.
+func (em *EspionageManager) RecordOutcome(op *SpyOperation, succeeded bool, tick int64) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	op.Succeeded = succeeded
+	op.CompletedTick = tick
+}
+
+// IntelTargets returns the factions whose holdings operator can currently
+// see thanks to an intel op that succeeded within IntelDuration ticks.
+func (em *EspionageManager) IntelTargets(operator string, tick int64) []string {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	var targets []string
+	for _, op := range em.ops {
+		if op.Operator == operator && op.Type == "intel" && op.Succeeded &&
+			tick-op.CompletedTick < IntelDuration {
+			targets = append(targets, op.Target)
+		}
+	}
+	return targets
+}
+
+// GetAllOps returns every operation, active or finished (for save/load).
+func (em *EspionageManager) GetAllOps() []*SpyOperation {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	return append([]*SpyOperation{}, em.ops...)
+}
+
+// GetNextID returns the ID the next operation will receive (for save/load).
+func (em *EspionageManager) GetNextID() int {
+	em.mu.RLock()
+	defer em.mu.RUnlock()
+	return em.nextID
+}
+
+// RestoreOps loads operations and the ID counter from a save.
+// The counter never goes below the highest restored ID + 1.
+func (em *EspionageManager) RestoreOps(ops []*SpyOperation, nextID int) {
+	em.mu.Lock()
+	defer em.mu.Unlock()
+	em.ops = append([]*SpyOperation{}, ops...)
+	em.nextID = nextID
+	for _, op := range em.ops {
+		if op.ID >= em.nextID {
+			em.nextID = op.ID + 1
+		}
+	}
+	if em.nextID < 1 {
+		em.nextID = 1
+	}
+}
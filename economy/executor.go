@@ -34,13 +34,16 @@ type ShipDispatcher interface {
 // Both the UI and API call into this to execute trades.
 type TradeExecutor struct {
 	market     *Market
-	history    []TradeRecord
 	mu         sync.Mutex
 	tick       int64
 	OnTrade    TradeCallback // optional callback for event logging
+	listeners  []TradeCallback
+	listenerMu sync.RWMutex // separate from mu: trades publish while mu is held
 	Deliveries *DeliveryManager
 	Dispatcher ShipDispatcher // for cross-system cargo ship dispatch
 	Credits    *CreditLedger  // credit limit tracking between empires
+	Ledger     *Ledger        // trade history and cost basis
+	Candles    *CandleHistory // optional: trade volume for price candles
 
 	// Systems reference for system-scoped trading.
 	// When set, human trades are scoped to the trading planet's system.
@@ -52,8 +55,8 @@ type TradeExecutor struct {
 // NewTradeExecutor creates a new executor bound to the given market.
 func NewTradeExecutor(market *Market) *TradeExecutor {
 	return &TradeExecutor{
-		market:  market,
-		history: make([]TradeRecord, 0, 200),
+		market: market,
+		Ledger: NewLedger(),
 	}
 }
 
@@ -183,6 +186,16 @@ func (te *TradeExecutor) Buy(player *entities.Player, players []*entities.Player
 	// Execute: remove from local sellers, deduct credits, create local delivery
 	te.removeFromOthersInSystem(players, player, resource, quantity, systemID)
 	player.Credits -= total
+	if te.Ledger != nil {
+		te.Ledger.Record(player, LedgerEntry{
+			Amount:   -total,
+			Reason:   ReasonMarketTrade,
+			Source:   "market",
+			Resource: resource,
+			Quantity: quantity,
+			Detail:   fmt.Sprintf("bought %d %s @ %.0f (local, system %d)", quantity, resource, price, systemID),
+		})
+	}
 
 	if te.Deliveries != nil {
 		te.Deliveries.CreateLocalDelivery(
@@ -200,6 +213,9 @@ func (te *TradeExecutor) Buy(player *entities.Player, players []*entities.Player
 	}
 
 	te.market.AddTradeVolume(resource, quantity, true)
+	if te.Candles != nil {
+		te.Candles.AddVolume(te.tick, systemID, resource, quantity)
+	}
 
 	record := TradeRecord{
 		Tick:      te.tick,
@@ -210,10 +226,7 @@ func (te *TradeExecutor) Buy(player *entities.Player, players []*entities.Player
 		UnitPrice: price,
 		Total:     total,
 	}
-	te.appendRecord(record)
-	if te.OnTrade != nil {
-		te.OnTrade(record)
-	}
+	te.PublishTrade(record)
 
 	fmt.Printf("[Trade] %s bought %d %s @ %.0f = %d credits (local, system %d)\n",
 		player.Name, quantity, resource, price, total, systemID)
@@ -320,6 +333,9 @@ func (te *TradeExecutor) Sell(player *entities.Player, players []*entities.Playe
 	)
 
 	te.market.AddTradeVolume(resource, quantity, false)
+	if te.Candles != nil {
+		te.Candles.AddVolume(te.tick, sellSystemID, resource, quantity)
+	}
 
 	record := TradeRecord{
 		Tick:      te.tick,
@@ -330,10 +346,7 @@ func (te *TradeExecutor) Sell(player *entities.Player, players []*entities.Playe
 		UnitPrice: price,
 		Total:     total,
 	}
-	te.appendRecord(record)
-	if te.OnTrade != nil {
-		te.OnTrade(record)
-	}
+	te.PublishTrade(record)
 
 	fmt.Printf("[Trade] %s sold %d %s @ %.0f = %d credits (local, system %d)\n",
 		player.Name, quantity, resource, price, total, sellSystemID)
@@ -341,24 +354,37 @@ func (te *TradeExecutor) Sell(player *entities.Player, players []*entities.Playe
 	return record, nil
 }
 
-// GetHistory returns the most recent N trade records.
-func (te *TradeExecutor) GetHistory(limit int) []TradeRecord {
-	te.mu.Lock()
-	defer te.mu.Unlock()
-	if limit <= 0 || limit > len(te.history) {
-		limit = len(te.history)
+// Subscribe registers a callback that fires after every trade, in addition to OnTrade.
+func (te *TradeExecutor) Subscribe(fn TradeCallback) {
+	te.listenerMu.Lock()
+	defer te.listenerMu.Unlock()
+	te.listeners = append(te.listeners, fn)
+}
+
+// PublishTrade adds a completed trade to the ledger and notifies OnTrade
+// and all subscribers. Trades settled outside Buy/Sell (e.g. selling at a
+// dock) call this directly.
+func (te *TradeExecutor) PublishTrade(record TradeRecord) {
+	if te.Ledger != nil {
+		te.Ledger.AddTrade(record)
+	}
+	if te.OnTrade != nil {
+		te.OnTrade(record)
+	}
+	te.listenerMu.RLock()
+	listeners := te.listeners
+	te.listenerMu.RUnlock()
+	for _, fn := range listeners {
+		fn(record)
 	}
-	start := len(te.history) - limit
-	result := make([]TradeRecord, limit)
-	copy(result, te.history[start:])
-	return result
 }
 
-func (te *TradeExecutor) appendRecord(r TradeRecord) {
-	te.history = append(te.history, r)
-	if len(te.history) > 200 {
-		te.history = te.history[len(te.history)-200:]
+// GetHistory returns the most recent N trade records from the ledger.
+func (te *TradeExecutor) GetHistory(limit int) []TradeRecord {
+	if te.Ledger == nil {
+		return nil
 	}
+	return te.Ledger.RecentTrades(limit)
 }
 
 // getSystemForPlanet returns the system ID containing the planet, or -1.
@@ -0,0 +1,655 @@
+package economy
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+// Derivative kinds. Futures can be held long or short; options are
+// European, cash-settled and only bought, with the exchange as writer.
+const (
+	KindFuture = "future"
+	KindCall   = "call"
+	KindPut    = "put"
+)
+
+// Futures settlement at expiry.
+const (
+	SettleCash     = "cash"     // the final variation margin is all that changes hands
+	SettlePhysical = "physical" // the long takes delivery at the final price; the short delivers
+)
+
+// Position outcomes once closed.
+const (
+	OutcomeClosed     = "closed"     // closed out early by its holder
+	OutcomeSettled    = "settled"    // expired and settled as agreed
+	OutcomeCashed     = "cash"       // physical settlement impossible: settled in cash instead
+	OutcomeLiquidated = "liquidated" // margin call the faction couldn't meet
+	OutcomeExpired    = "expired"    // option expired worthless
+)
+
+// Futures event types, returned by MarkToMarket for logging.
+const (
+	FuturesMarginCall = "margin_call"
+	FuturesLiquidated = "liquidated"
+	FuturesSettled    = "settled"
+)
+
+const (
+	// FuturesInitialMargin is the collateral a futures position needs, as a
+	// fraction of its notional value at the mark.
+	FuturesInitialMargin = 0.15
+	// FuturesMaintenanceMargin is where a margin call tops the account back
+	// up to the initial margin.
+	FuturesMaintenanceMargin = 0.10
+
+	// FuturesMinTerm and FuturesMaxTerm bound how far out an expiry can be.
+	FuturesMinTerm int64 = 1000
+	FuturesMaxTerm int64 = 100000
+
+	// priceCycleTicks is how often the market reprices (and so how often
+	// positions are marked).
+	priceCycleTicks = 10
+
+	// shortfallPenalty is charged per unit a physical short fails to
+	// deliver, as a fraction of the final price.
+	shortfallPenalty = 0.10
+
+	// minOptionVolatility floors the per-cycle volatility options are priced
+	// with, so a quiet market doesn't make them free.
+	minOptionVolatility = 0.005
+
+	// maxClosedPositions is how many closed positions are kept for history.
+	maxClosedPositions = 500
+)
+
+var (
+	ErrInvalidDerivative  = errors.New("kind must be future, call or put, with a priced resource and positive quantity")
+	ErrInvalidSide        = errors.New("side must be 'long' or 'short'")
+	ErrInvalidSettlement  = errors.New("settlement must be 'cash' or 'physical'")
+	ErrInvalidStrike      = errors.New("options need a positive strike")
+	ErrInvalidTerm        = fmt.Errorf("term must be between %d and %d ticks", FuturesMinTerm, FuturesMaxTerm)
+	ErrInsufficientMargin = errors.New("not enough credits for the margin or premium")
+	ErrPositionNotFound   = errors.New("open position not found")
+	ErrPositionExpired    = errors.New("position has expired and settles at the next price update")
+)
+
+// FuturesPosition is a futures or option position against the exchange.
+//
+// Futures are marked to the market's mid price every price cycle, and the
+// change in value moves between the holder's margin account and the
+// exchange (variation margin). Options are paid for up front and pay out
+// their intrinsic value at expiry.
+type FuturesPosition struct {
+	ID         int
+	Player     string
+	Kind       string // KindFuture, KindCall or KindPut
+	Side       string // "long" or "short" (options are always long)
+	Resource   string
+	Quantity   int
+	Price      float64 // futures: entry price; options: strike
+	Premium    int     // options: what the holder paid
+	Mark       float64 // mid price at the last mark
+	OpenedTick int64
+	ExpiryTick int64
+	Settlement string // futures: SettleCash or SettlePhysical
+	SystemID   int    // physical settlement: where the goods are delivered/collected
+	PlanetID   int
+	Open       bool
+	ClosedTick int64
+	Outcome    string // an Outcome code once closed
+	Realized   int    // futures: variation margin to date; options: payout minus premium
+}
+
+// sign is +1 for a long position and -1 for a short.
+func (p *FuturesPosition) sign() float64 {
+	if p.Side == "short" {
+		return -1
+	}
+	return 1
+}
+
+// MarginAccount holds the collateral and variation margin behind a
+// faction's open futures. It is paid back to the faction's credits once
+// it has none open.
+type MarginAccount struct {
+	Player  string
+	Balance int
+}
+
+// FuturesOrder opens a position.
+type FuturesOrder struct {
+	Player     string
+	Kind       string
+	Side       string // futures only
+	Resource   string
+	Quantity   int
+	Term       int64   // ticks until expiry
+	Strike     float64 // options only
+	Settlement string  // futures only; SettleCash when empty
+	SystemID   int     // physical settlement planet
+	PlanetID   int
+}
+
+// FuturesEvent is something MarkToMarket did that a faction should hear about.
+type FuturesEvent struct {
+	Type     string // FuturesMarginCall, FuturesLiquidated or FuturesSettled
+	Player   string
+	Position *FuturesPosition // nil for a margin call
+	Amount   int              // margin call: credits taken; settlement: cash paid in (+) or out (-)
+	Quantity int              // physical settlement: units received (+) or delivered (-)
+}
+
+// FuturesSnapshot is the exchange's state for saving.
+type FuturesSnapshot struct {
+	Positions []*FuturesPosition
+	Accounts  []MarginAccount
+	NextID    int
+}
+
+// FuturesExchange is the clearing house for futures and options: the
+// counterparty to every position. Thread-safe.
+type FuturesExchange struct {
+	mu        sync.Mutex
+	positions []*FuturesPosition
+	accounts  map[string]*MarginAccount
+	nextID    int
+
+	Accounts OrderAccounts // players and planets margin and deliveries move through
+}
+
+// NewFuturesExchange creates an exchange with no positions.
+func NewFuturesExchange() *FuturesExchange {
+	return &FuturesExchange{
+		accounts: make(map[string]*MarginAccount),
+		nextID:   1,
+	}
+}
+
+// OptionPrice is the Black-Scholes value per unit, at zero interest, of a
+// European option on a resource at spot, struck at strike. vol is the
+// standard deviation of the log price per price cycle and cycles the
+// number of price cycles to expiry; at or past expiry the option is worth
+// its intrinsic value.
+func OptionPrice(kind string, spot, strike, vol, cycles float64) float64 {
+	if spot <= 0 || strike <= 0 {
+		return 0
+	}
+	intrinsic := math.Max(spot-strike, 0)
+	if kind == KindPut {
+		intrinsic = math.Max(strike-spot, 0)
+	}
+	if !(cycles > 0) || !(vol > 0) {
+		return intrinsic
+	}
+	sd := vol * math.Sqrt(cycles)
+	if !(sd > 0) || math.IsInf(sd, 0) {
+		return intrinsic
+	}
+	d1 := (math.Log(spot/strike) + sd*sd/2) / sd
+	d2 := d1 - sd
+	if kind == KindPut {
+		return strike*normCDF(-d2) - spot*normCDF(-d1)
+	}
+	return spot*normCDF(d1) - strike*normCDF(d2)
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * (1 + math.Erf(x/math.Sqrt2))
+}
+
+// OptionQuote is the premium per unit the exchange charges for an option
+// expiring term ticks from now, priced off the resource's recent volatility.
+func OptionQuote(market *Market, kind, resource string, strike float64, term int64) float64 {
+	if term < 0 {
+		term = 0
+	}
+	vol := math.Max(market.Volatility(resource), minOptionVolatility)
+	return OptionPrice(kind, market.GetCurrentPrice(resource), strike, vol, float64(term)/priceCycleTicks)
+}
+
+// Open opens a futures or option position at the current market price,
+// returning a copy of it.
+// A future draws its initial margin from the faction's credits into its
+// margin account; an option draws its premium.
+func (fx *FuturesExchange) Open(tick int64, market *Market, o FuturesOrder) (*FuturesPosition, error) {
+	if _, ok := BasePrices[o.Resource]; !ok || o.Quantity <= 0 {
+		return nil, ErrInvalidDerivative
+	}
+	if o.Term < FuturesMinTerm || o.Term > FuturesMaxTerm {
+		return nil, ErrInvalidTerm
+	}
+
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	if fx.Accounts == nil {
+		return nil, ErrNoAccounts
+	}
+	player := fx.Accounts.FindPlayer(o.Player)
+	if player == nil {
+		return nil, ErrUnknownPlayer
+	}
+
+	p := &FuturesPosition{
+		Player:     o.Player,
+		Kind:       o.Kind,
+		Side:       "long",
+		Resource:   o.Resource,
+		Quantity:   o.Quantity,
+		Mark:       market.GetCurrentPrice(o.Resource),
+		OpenedTick: tick,
+		ExpiryTick: tick + o.Term,
+		Open:       true,
+	}
+
+	switch o.Kind {
+	case KindFuture:
+		switch o.Side {
+		case "long":
+			p.Price = market.GetBuyPrice(o.Resource)
+		case "short":
+			p.Price = market.GetSellPrice(o.Resource)
+		default:
+			return nil, ErrInvalidSide
+		}
+		p.Side = o.Side
+		p.Settlement = o.Settlement
+		if p.Settlement == "" {
+			p.Settlement = SettleCash
+		}
+		if p.Settlement != SettleCash && p.Settlement != SettlePhysical {
+			return nil, ErrInvalidSettlement
+		}
+		if p.Settlement == SettlePhysical {
+			planet := fx.Accounts.FindPlanet(o.SystemID, o.PlanetID)
+			if planet == nil || planet.Owner != o.Player {
+				return nil, ErrPlanetNotOwned
+			}
+			p.SystemID, p.PlanetID = o.SystemID, o.PlanetID
+		}
+		// Marks start at the entry price; the first mark to the mid costs
+		// half the spread
+		p.Mark = p.Price
+
+		acct := fx.accountLocked(o.Player)
+		initial, _ := fx.requirementLocked(o.Player)
+		need := initial + marginFor(p, FuturesInitialMargin) - acct.Balance
+		if need > 0 {
+			if player.Credits < need {
+				return nil, ErrInsufficientMargin
+			}
+			player.Credits -= need
+			acct.Balance += need
+		}
+	case KindCall, KindPut:
+		if o.Strike <= 0 {
+			return nil, ErrInvalidStrike
+		}
+		p.Price = o.Strike
+		p.Premium = int(math.Ceil(OptionQuote(market, o.Kind, o.Resource, o.Strike, o.Term) * float64(o.Quantity)))
+		if p.Premium < 1 {
+			p.Premium = 1
+		}
+		if player.Credits < p.Premium {
+			return nil, ErrInsufficientMargin
+		}
+		player.Credits -= p.Premium
+		p.Realized = -p.Premium
+	default:
+		return nil, ErrInvalidDerivative
+	}
+
+	p.ID = fx.nextID
+	fx.nextID++
+	fx.positions = append(fx.positions, p)
+	fmt.Printf("[Futures] #%d: %s %s %d %s %s @ %.0f, expires tick %d\n",
+		p.ID, p.Player, p.Side, p.Quantity, p.Resource, p.Kind, p.Price, p.ExpiryTick)
+	cp := *p
+	return &cp, nil
+}
+
+// Close closes an open position early: a future at the bid (long) or ask
+// (short), an option at its current model value. Expired positions can't
+// be closed: MarkToMarket settles them. It returns a copy of the
+// closed position.
+func (fx *FuturesExchange) Close(tick int64, market *Market, id int, playerName string) (*FuturesPosition, error) {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	if fx.Accounts == nil {
+		return nil, ErrNoAccounts
+	}
+	p := fx.findLocked(id)
+	if p == nil || !p.Open || p.Player != playerName {
+		return nil, ErrPositionNotFound
+	}
+	if tick >= p.ExpiryTick {
+		return nil, ErrPositionExpired
+	}
+	if p.Kind == KindFuture {
+		exit := market.GetSellPrice(p.Resource)
+		if p.Side == "short" {
+			exit = market.GetBuyPrice(p.Resource)
+		}
+		fx.markLocked(p, exit)
+	} else {
+		value := int(OptionQuote(market, p.Kind, p.Resource, p.Price, p.ExpiryTick-tick) * float64(p.Quantity))
+		if player := fx.Accounts.FindPlayer(p.Player); player != nil {
+			player.Credits += value
+		}
+		p.Realized = value - p.Premium
+	}
+	fx.closeLocked(p, tick, OutcomeClosed)
+	fx.releaseLocked()
+	fx.pruneLocked()
+	cp := *p
+	return &cp, nil
+}
+
+// MarkToMarket runs after every price update: it pays variation margin on
+// every open future, makes margin calls on accounts below maintenance
+// (liquidating factions that can't meet them), settles what has expired
+// and pays back the margin of factions left with no open futures.
+func (fx *FuturesExchange) MarkToMarket(tick int64, market *Market) []FuturesEvent {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	if fx.Accounts == nil {
+		return nil
+	}
+
+	var events []FuturesEvent
+	var players []string
+	seen := make(map[string]bool)
+	for _, p := range fx.positions {
+		if !p.Open {
+			continue
+		}
+		if p.Kind == KindFuture {
+			fx.markLocked(p, market.GetCurrentPrice(p.Resource))
+			if !seen[p.Player] {
+				seen[p.Player] = true
+				players = append(players, p.Player)
+			}
+		} else {
+			p.Mark = market.GetCurrentPrice(p.Resource)
+		}
+	}
+
+	for _, name := range players {
+		events = append(events, fx.marginCallLocked(tick, name)...)
+	}
+
+	for _, p := range fx.positions {
+		if p.Open && tick >= p.ExpiryTick {
+			events = append(events, fx.settleLocked(tick, p))
+		}
+	}
+
+	fx.releaseLocked()
+	fx.pruneLocked()
+	return events
+}
+
+// markLocked pays the variation margin for moving p's mark to price.
+func (fx *FuturesExchange) markLocked(p *FuturesPosition, price float64) {
+	value := int(math.Round((price - p.Price) * float64(p.Quantity) * p.sign()))
+	fx.accountLocked(p.Player).Balance += value - p.Realized
+	p.Realized = value
+	p.Mark = price
+}
+
+// marginCallLocked tops up an account that has fallen below maintenance
+// margin from the faction's credits, or liquidates its futures if the
+// credits aren't there.
+func (fx *FuturesExchange) marginCallLocked(tick int64, name string) []FuturesEvent {
+	acct := fx.accountLocked(name)
+	initial, maintenance := fx.requirementLocked(name)
+	if acct.Balance >= maintenance {
+		return nil
+	}
+	topUp := initial - acct.Balance
+	if player := fx.Accounts.FindPlayer(name); player != nil && player.Credits >= topUp {
+		player.Credits -= topUp
+		acct.Balance += topUp
+		return []FuturesEvent{{Type: FuturesMarginCall, Player: name, Amount: topUp}}
+	}
+
+	var events []FuturesEvent
+	for _, p := range fx.positions {
+		if p.Open && p.Kind == KindFuture && p.Player == name {
+			fx.closeLocked(p, tick, OutcomeLiquidated)
+			events = append(events, FuturesEvent{Type: FuturesLiquidated, Player: name, Position: p, Amount: p.Realized})
+		}
+	}
+	return events
+}
+
+// settleLocked settles an expired position. Futures have already been
+// marked to the final price.
+func (fx *FuturesExchange) settleLocked(tick int64, p *FuturesPosition) FuturesEvent {
+	ev := FuturesEvent{Type: FuturesSettled, Player: p.Player, Position: p}
+	player := fx.Accounts.FindPlayer(p.Player)
+
+	if p.Kind != KindFuture {
+		payout := math.Max(p.Mark-p.Price, 0)
+		if p.Kind == KindPut {
+			payout = math.Max(p.Price-p.Mark, 0)
+		}
+		ev.Amount = int(payout * float64(p.Quantity))
+		if player != nil {
+			player.Credits += ev.Amount
+		}
+		p.Realized = ev.Amount - p.Premium
+		outcome := OutcomeSettled
+		if ev.Amount == 0 {
+			outcome = OutcomeExpired
+		}
+		fx.closeLocked(p, tick, outcome)
+		return ev
+	}
+
+	ev.Amount = p.Realized
+	outcome := OutcomeSettled
+	if p.Settlement == SettlePhysical {
+		if delivered, cash, ok := fx.deliverLocked(p, player); ok {
+			ev.Quantity, ev.Amount = delivered, ev.Amount+cash
+		} else {
+			outcome = OutcomeCashed
+		}
+	}
+	fx.closeLocked(p, tick, outcome)
+	return ev
+}
+
+// deliverLocked settles a future physically at its final mark, through
+// the margin account: the long pays for the goods and gets them on its
+// planet; the short delivers from its planet and is paid, less a penalty
+// for every unit it is short. It fails, leaving the position to settle in
+// cash, if the planet has changed hands or the long can't pay.
+func (fx *FuturesExchange) deliverLocked(p *FuturesPosition, player *entities.Player) (units, cash int, ok bool) {
+	planet := fx.Accounts.FindPlanet(p.SystemID, p.PlanetID)
+	if player == nil || planet == nil || planet.Owner != p.Player {
+		return 0, 0, false
+	}
+	acct := fx.accountLocked(p.Player)
+	if p.Side == "long" {
+		cost := int(math.Round(p.Mark * float64(p.Quantity)))
+		if acct.Balance+player.Credits < cost {
+			return 0, 0, false
+		}
+		acct.Balance -= cost
+		planet.AddStoredResource(p.Resource, p.Quantity)
+		return p.Quantity, -cost, true
+	}
+	delivered := planet.GetStoredAmount(p.Resource)
+	if delivered > p.Quantity {
+		delivered = p.Quantity
+	}
+	planet.RemoveStoredResource(p.Resource, delivered)
+	cash = int(math.Round(p.Mark*float64(delivered))) -
+		int(math.Round(p.Mark*shortfallPenalty*float64(p.Quantity-delivered)))
+	acct.Balance += cash
+	return -delivered, cash, true
+}
+
+// releaseLocked pays margin accounts with no open futures back to their
+// factions. A deficit left by a liquidation is taken from credits as far
+// as they go; the exchange absorbs the rest.
+func (fx *FuturesExchange) releaseLocked() {
+	open := make(map[string]bool)
+	for _, p := range fx.positions {
+		if p.Open && p.Kind == KindFuture {
+			open[p.Player] = true
+		}
+	}
+	names := make([]string, 0, len(fx.accounts))
+	for name := range fx.accounts {
+		if !open[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if player := fx.Accounts.FindPlayer(name); player != nil {
+			player.Credits += fx.accounts[name].Balance
+			if player.Credits < 0 {
+				player.Credits = 0
+			}
+		}
+		delete(fx.accounts, name)
+	}
+}
+
+// closeLocked marks p closed.
+func (fx *FuturesExchange) closeLocked(p *FuturesPosition, tick int64, outcome string) {
+	p.Open = false
+	p.ClosedTick = tick
+	p.Outcome = outcome
+}
+
+// pruneLocked drops the oldest closed positions beyond maxClosedPositions.
+func (fx *FuturesExchange) pruneLocked() {
+	closed := 0
+	for _, p := range fx.positions {
+		if !p.Open {
+			closed++
+		}
+	}
+	kept := fx.positions[:0]
+	for _, p := range fx.positions {
+		if !p.Open && closed > maxClosedPositions {
+			closed--
+			continue
+		}
+		kept = append(kept, p)
+	}
+	fx.positions = kept
+}
+
+// requirementLocked returns the initial and maintenance margin a
+// faction's open futures need at their last marks.
+func (fx *FuturesExchange) requirementLocked(name string) (initial, maintenance int) {
+	for _, p := range fx.positions {
+		if p.Open && p.Kind == KindFuture && p.Player == name {
+			initial += marginFor(p, FuturesInitialMargin)
+			maintenance += marginFor(p, FuturesMaintenanceMargin)
+		}
+	}
+	return initial, maintenance
+}
+
+func marginFor(p *FuturesPosition, rate float64) int {
+	return int(math.Ceil(p.Mark * float64(p.Quantity) * rate))
+}
+
+func (fx *FuturesExchange) accountLocked(name string) *MarginAccount {
+	acct, ok := fx.accounts[name]
+	if !ok {
+		acct = &MarginAccount{Player: name}
+		fx.accounts[name] = acct
+	}
+	return acct
+}
+
+func (fx *FuturesExchange) findLocked(id int) *FuturesPosition {
+	for _, p := range fx.positions {
+		if p.ID == id {
+			return p
+		}
+	}
+	return nil
+}
+
+// GetPositions returns a faction's positions (everyone's when player is
+// empty), optionally only the open ones, oldest first.
+func (fx *FuturesExchange) GetPositions(player string, openOnly bool) []*FuturesPosition {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	var result []*FuturesPosition
+	for _, p := range fx.positions {
+		if (player == "" || p.Player == player) && (p.Open || !openOnly) {
+			cp := *p
+			result = append(result, &cp)
+		}
+	}
+	return result
+}
+
+// GetAccount returns a faction's margin balance and what its open futures
+// need at initial and maintenance margin.
+func (fx *FuturesExchange) GetAccount(player string) (balance, initial, maintenance int) {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	if acct, ok := fx.accounts[player]; ok {
+		balance = acct.Balance
+	}
+	initial, maintenance = fx.requirementLocked(player)
+	return balance, initial, maintenance
+}
+
+// Snapshot copies the exchange's state for saving.
+func (fx *FuturesExchange) Snapshot() FuturesSnapshot {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	snap := FuturesSnapshot{NextID: fx.nextID}
+	for _, p := range fx.positions {
+		cp := *p
+		snap.Positions = append(snap.Positions, &cp)
+	}
+	for _, acct := range fx.accounts {
+		snap.Accounts = append(snap.Accounts, *acct)
+	}
+	sort.Slice(snap.Accounts, func(i, j int) bool { return snap.Accounts[i].Player < snap.Accounts[j].Player })
+	return snap
+}
+
+// Restore loads a saved state. The ID counter never goes below the highest
+// restored ID + 1.
+func (fx *FuturesExchange) Restore(snap FuturesSnapshot) {
+	fx.mu.Lock()
+	defer fx.mu.Unlock()
+	fx.positions = nil
+	fx.nextID = snap.NextID
+	for _, p := range snap.Positions {
+		if p == nil {
+			continue
+		}
+		cp := *p
+		fx.positions = append(fx.positions, &cp)
+		if cp.ID >= fx.nextID {
+			fx.nextID = cp.ID + 1
+		}
+	}
+	if fx.nextID < 1 {
+		fx.nextID = 1
+	}
+	fx.accounts = make(map[string]*MarginAccount, len(snap.Accounts))
+	for _, acct := range snap.Accounts {
+		a := acct
+		fx.accounts[a.Player] = &a
+	}
+}
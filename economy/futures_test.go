@@ -0,0 +1,153 @@
+package economy
+
+import (
+	"math"
+	"testing"
+)
+
+func newTestFutures(names ...string) (*FuturesExchange, *testAccounts, *Market) {
+	a := newTestAccounts(names...)
+	fx := NewFuturesExchange()
+	fx.Accounts = a
+	m := NewMarket()
+	m.SetPrice("Iron", 110, 90)
+	return fx, a, m
+}
+
+func TestFuturesMarginCallAndCashSettlement(t *testing.T) {
+	fx, a, m := newTestFutures("Alpha")
+	alpha := a.players["Alpha"]
+
+	// A long enters at the buy price with 15% of its notional as margin
+	p, err := fx.Open(0, m, FuturesOrder{Player: "Alpha", Kind: KindFuture, Side: "long", Resource: "Iron", Quantity: 100, Term: 1000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Price != 110 || alpha.Credits != 10000-1650 {
+		t.Fatalf("entry %.0f, credits %d; want 110 and %d", p.Price, alpha.Credits, 10000-1650)
+	}
+
+	// Marked at the 100 mid, the account drops to 650, below the 1000
+	// maintenance margin, and is topped back up to the 1500 initial margin
+	events := fx.MarkToMarket(10, m)
+	if len(events) != 1 || events[0].Type != FuturesMarginCall || events[0].Amount != 850 {
+		t.Fatalf("events = %+v, want an 850cr margin call", events)
+	}
+	if balance, initial, _ := fx.GetAccount("Alpha"); balance != 1500 || initial != 1500 {
+		t.Errorf("account %d (needs %d), want 1500", balance, initial)
+	}
+
+	// Cash settlement at a 120 mid: the margin comes back with 10/unit profit
+	m.SetPrice("Iron", 130, 110)
+	events = fx.MarkToMarket(1000, m)
+	if len(events) != 1 || events[0].Type != FuturesSettled || events[0].Amount != 1000 {
+		t.Fatalf("events = %+v, want settlement for 1000", events)
+	}
+	if alpha.Credits != 10000+1000 {
+		t.Errorf("credits after settlement = %d, want 11000", alpha.Credits)
+	}
+	if open := fx.GetPositions("Alpha", true); len(open) != 0 {
+		t.Errorf("positions still open: %+v", open)
+	}
+}
+
+func TestFuturesLiquidation(t *testing.T) {
+	fx, a, m := newTestFutures("Alpha")
+	alpha := a.players["Alpha"]
+	if _, err := fx.Open(0, m, FuturesOrder{Player: "Alpha", Kind: KindFuture, Side: "long", Resource: "Iron", Quantity: 100, Term: 1000}); err != nil {
+		t.Fatal(err)
+	}
+	alpha.Credits = 0
+
+	events := fx.MarkToMarket(10, m)
+	if len(events) != 1 || events[0].Type != FuturesLiquidated || events[0].Position.Outcome != OutcomeLiquidated {
+		t.Fatalf("events = %+v, want a liquidation", events)
+	}
+	// What's left of the margin is paid back
+	if alpha.Credits != 650 {
+		t.Errorf("credits after liquidation = %d, want 650", alpha.Credits)
+	}
+}
+
+func TestFuturesPhysicalDeliveryShortfall(t *testing.T) {
+	fx, a, m := newTestFutures("Alpha", "Beta")
+	beta := a.players["Beta"]
+
+	// Beta shorts 150 Iron for delivery from a planet holding only 100
+	if _, err := fx.Open(0, m, FuturesOrder{Player: "Beta", Kind: KindFuture, Side: "short", Resource: "Iron", Quantity: 150, Term: 1000, Settlement: SettlePhysical, PlanetID: 1}); err != ErrPlanetNotOwned {
+		t.Errorf("delivery from someone else's planet: err = %v", err)
+	}
+	if _, err := fx.Open(0, m, FuturesOrder{Player: "Beta", Kind: KindFuture, Side: "short", Resource: "Iron", Quantity: 150, Term: 1000, Settlement: SettlePhysical, PlanetID: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	var settled FuturesEvent
+	for _, ev := range fx.MarkToMarket(1000, m) {
+		if ev.Type == FuturesSettled {
+			settled = ev
+		}
+	}
+	// 100 delivered at the 100 mid, less a 10cr penalty for each of the 50 missing
+	if settled.Quantity != -100 || settled.Position == nil || settled.Position.Outcome != OutcomeSettled {
+		t.Fatalf("settlement = %+v, want 100 units delivered", settled)
+	}
+	if got := a.planets[2].GetStoredAmount("Iron"); got != 0 {
+		t.Errorf("planet has %d Iron left, want 0", got)
+	}
+	if beta.Credits != 10000-1500+10000-500 {
+		t.Errorf("credits = %d, want %d", beta.Credits, 10000-1500+10000-500)
+	}
+}
+
+func TestFuturesOptions(t *testing.T) {
+	// Put-call parity holds at zero interest
+	call := OptionPrice(KindCall, 100, 95, 0.02, 50)
+	put := OptionPrice(KindPut, 100, 95, 0.02, 50)
+	if math.Abs(call-put-5) > 1e-9 {
+		t.Errorf("call %.4f - put %.4f != spot - strike", call, put)
+	}
+
+	fx, a, m := newTestFutures("Alpha")
+	alpha := a.players["Alpha"]
+	if _, err := fx.Open(0, m, FuturesOrder{Player: "Alpha", Kind: KindCall, Resource: "Iron", Quantity: 10, Term: 1000}); err != ErrInvalidStrike {
+		t.Errorf("option without a strike: err = %v", err)
+	}
+	p, err := fx.Open(0, m, FuturesOrder{Player: "Alpha", Kind: KindCall, Resource: "Iron", Quantity: 10, Term: 1000, Strike: 100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := int(math.Ceil(OptionQuote(m, KindCall, "Iron", 100, 1000) * 10)); p.Premium != want || p.Premium <= 0 || alpha.Credits != 10000-want {
+		t.Fatalf("premium %d, credits %d; want premium %d", p.Premium, alpha.Credits, want)
+	}
+
+	// Past expiry but before the next mark the option can't be closed, and
+	// is never priced with negative time left
+	if v := OptionPrice(KindCall, 100, 90, 0.02, -0.5); v != 10 {
+		t.Errorf("call with negative time left = %v, want its intrinsic 10", v)
+	}
+	if v := OptionQuote(m, KindPut, "Iron", 120, -5); v != 20 {
+		t.Errorf("expired put quote = %v, want its intrinsic 20", v)
+	}
+	credits := alpha.Credits
+	if _, err := fx.Close(1005, m, p.ID, "Alpha"); err != ErrPositionExpired || alpha.Credits != credits {
+		t.Errorf("close after expiry: err = %v, credits %d -> %d", err, credits, alpha.Credits)
+	}
+
+	// Expiring 20 in the money pays 20/unit
+	m.SetPrice("Iron", 130, 110)
+	fx.MarkToMarket(1000, m)
+	got := fx.GetPositions("Alpha", false)
+	if len(got) != 1 || got[0].Outcome != OutcomeSettled || got[0].Realized != 200-p.Premium {
+		t.Fatalf("positions = %+v", got)
+	}
+	if alpha.Credits != 10000-p.Premium+200 {
+		t.Errorf("credits = %d, want %d", alpha.Credits, 10000-p.Premium+200)
+	}
+
+	restored := NewFuturesExchange()
+	restored.Restore(fx.Snapshot())
+	restored.Accounts = a
+	if q, err := restored.Open(1000, m, FuturesOrder{Player: "Alpha", Kind: KindPut, Resource: "Iron", Quantity: 1, Term: 1000, Strike: 100}); err != nil || q.ID != p.ID+1 {
+		t.Errorf("position after restore = %+v, %v; want ID %d", q, err, p.ID+1)
+	}
+}
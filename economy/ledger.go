@@ -0,0 +1,470 @@
+package economy
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+// Ledger reason codes: why credits moved.
+const (
+	ReasonOpening       = "opening"      // balance a faction had when the ledger first saw it
+	ReasonMarketTrade   = "market_trade" // market buys and sells, their deliveries, limit orders
+	ReasonDockSale      = "dock_sale"
+	ReasonLocalExchange = "local_exchange"
+	ReasonContract      = "contract"
+	ReasonTariff        = "tariff" // tariffs and docking fees
+	ReasonMaintenance   = "maintenance"
+	ReasonTax           = "tax"
+	ReasonLoan          = "loan"
+	ReasonBounty        = "bounty"
+	ReasonIncome        = "income"
+	ReasonConstruction  = "construction"
+	ReasonEspionage     = "espionage"
+	ReasonBlackMarket   = "black_market"
+	ReasonFutures       = "futures"  // margin, variation margin, premiums and settlements
+	ReasonRollback      = "rollback" // an atomic batch undone
+	ReasonEvent         = "event"    // any other tickable system
+	ReasonCommand       = "command"  // any other player command
+)
+
+const (
+	// ledgerMaxEntries caps the entries kept. Past it, the oldest half is
+	// compacted into one rollup entry per faction and reason, so entries
+	// still sum to each faction's balance.
+	ledgerMaxEntries = 20000
+	// ledgerMaxTrades caps the trade history; the oldest half is dropped.
+	// Realized P&L totals are kept separately and aren't affected.
+	ledgerMaxTrades = 10000
+)
+
+// LedgerEntry is one movement of a faction's credits.
+type LedgerEntry struct {
+	Seq          int64
+	Tick         int64
+	Player       string
+	Amount       int    // credits in (positive) or out (negative)
+	Balance      int    // the faction's credits after this entry
+	Reason       string // a Reason code
+	Source       string // tickable system or command that moved the credits
+	Counterparty string
+	Resource     string
+	Quantity     int
+	Detail       string
+	Entries      int // rollups: how many entries were compacted into this one
+}
+
+// LedgerTrade is a trade with the cost basis FIFO assigned to it.
+type LedgerTrade struct {
+	TradeRecord
+	CostBasis int // what the units sold cost to acquire (sells only)
+	Realized  int // Total - CostBasis (sells only)
+}
+
+// CostLot is a quantity of a resource bought together at one unit cost.
+type CostLot struct {
+	Tick     int64
+	Quantity int
+	UnitCost float64
+}
+
+// Position is a faction's open inventory of one resource.
+type Position struct {
+	Resource   string
+	Quantity   int
+	CostBasis  int
+	Mark       float64 // current price per unit
+	Unrealized int
+}
+
+// FactionPnL is a faction's trading profit and loss.
+type FactionPnL struct {
+	Player     string
+	Realized   int
+	Unrealized int
+	Positions  []Position
+}
+
+// LedgerSnapshot is the ledger's persistent state.
+type LedgerSnapshot struct {
+	Entries  []LedgerEntry
+	Trades   []LedgerTrade
+	Balances map[string]int
+	Lots     map[string]map[string][]CostLot
+	Realized map[string]int
+	NextSeq  int64
+}
+
+// Ledger is the append-only record of every credit movement, plus the
+// FIFO cost basis of what each faction has bought.
+//
+// Credits change in hundreds of places, so most entries come from
+// Reconcile: after every tickable system and every command, each
+// faction's balance is compared with the last one the ledger accounted
+// for and the difference is booked to that source. Callers that know more
+// (the counterparty, the resource) Record the movement themselves first,
+// and Reconcile only books what's left over, so entries always sum to
+// the faction's balance.
+type Ledger struct {
+	mu       sync.Mutex
+	entries  []LedgerEntry
+	trades   []LedgerTrade
+	balances map[string]int                  // credits accounted for so far
+	lots     map[string]map[string][]CostLot // player → resource → open lots, oldest first
+	realized map[string]int
+	nextSeq  int64
+	tick     int64
+}
+
+// NewLedger creates an empty ledger.
+func NewLedger() *Ledger {
+	return &Ledger{
+		balances: make(map[string]int),
+		lots:     make(map[string]map[string][]CostLot),
+		realized: make(map[string]int),
+		nextSeq:  1,
+	}
+}
+
+// SetTick updates the tick new entries are stamped with.
+func (l *Ledger) SetTick(tick int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.tick = tick
+}
+
+// Record books a movement of e.Amount credits that has just been applied
+// to player. Player, Seq, Tick and Balance are filled in.
+func (l *Ledger) Record(player *entities.Player, e LedgerEntry) {
+	if player == nil || e.Amount == 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if _, ok := l.balances[player.Name]; !ok {
+		l.openLocked(player.Name, player.Credits-e.Amount, e.Source)
+	}
+	e.Player = player.Name
+	l.appendLocked(e)
+}
+
+// Reconcile books any change in the players' credits since the ledger last
+// looked to source, under reason. Factions the ledger hasn't seen yet get
+// an opening entry for their whole balance.
+func (l *Ledger) Reconcile(players []*entities.Player, source, reason string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, p := range players {
+		if p == nil {
+			continue
+		}
+		balance, ok := l.balances[p.Name]
+		if !ok {
+			l.openLocked(p.Name, p.Credits, source)
+			continue
+		}
+		if p.Credits != balance {
+			l.appendLocked(LedgerEntry{Player: p.Name, Amount: p.Credits - balance, Reason: reason, Source: source})
+		}
+	}
+}
+
+func (l *Ledger) openLocked(player string, credits int, source string) {
+	l.balances[player] = 0
+	if credits != 0 {
+		l.appendLocked(LedgerEntry{Player: player, Amount: credits, Reason: ReasonOpening, Source: source})
+	}
+}
+
+func (l *Ledger) appendLocked(e LedgerEntry) {
+	e.Seq = l.nextSeq
+	l.nextSeq++
+	if e.Tick == 0 {
+		e.Tick = l.tick
+	}
+	l.balances[e.Player] += e.Amount
+	e.Balance = l.balances[e.Player]
+	l.entries = append(l.entries, e)
+	if len(l.entries) > ledgerMaxEntries {
+		l.compactLocked(len(l.entries) / 2)
+	}
+}
+
+// compactLocked replaces the oldest n entries with one rollup per faction
+// and reason, stamped with the tick and sequence number of the last entry
+// it covers. Earlier rollups are folded into the new ones, so their
+// number stays bounded by factions × reasons.
+func (l *Ledger) compactLocked(n int) {
+	type rollupKey struct{ player, reason string }
+	rollups := make(map[rollupKey]*LedgerEntry)
+	var firstTick int64
+	for i, e := range l.entries[:n] {
+		if i == 0 {
+			firstTick = e.Tick
+		}
+		count := max(e.Entries, 1)
+		r, ok := rollups[rollupKey{e.Player, e.Reason}]
+		if !ok {
+			r = &LedgerEntry{Player: e.Player, Reason: e.Reason, Source: "rollup"}
+			rollups[rollupKey{e.Player, e.Reason}] = r
+		}
+		r.Amount += e.Amount
+		r.Seq, r.Tick = e.Seq, e.Tick
+		r.Entries += count
+	}
+
+	compacted := make([]LedgerEntry, 0, len(rollups))
+	for _, r := range rollups {
+		r.Detail = fmt.Sprintf("%d entries from tick %d", r.Entries, firstTick)
+		compacted = append(compacted, *r)
+	}
+	sort.Slice(compacted, func(i, j int) bool { return compacted[i].Seq < compacted[j].Seq })
+	balances := make(map[string]int)
+	for i := range compacted {
+		balances[compacted[i].Player] += compacted[i].Amount
+		compacted[i].Balance = balances[compacted[i].Player]
+	}
+	l.entries = append(compacted, l.entries[n:]...)
+}
+
+// AddTrade adds a trade to the trade history and the trader's cost basis.
+// Buys ("buy", "buy_at_dock") open a lot at the trade's all-in unit cost;
+// sells consume lots oldest first and realize the difference. Units sold
+// beyond the open lots were produced rather than bought and carry no cost.
+func (l *Ledger) AddTrade(r TradeRecord) {
+	if r.Quantity <= 0 {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	t := LedgerTrade{TradeRecord: r}
+	if strings.HasPrefix(r.Action, "buy") {
+		byResource := l.lots[r.Player]
+		if byResource == nil {
+			byResource = make(map[string][]CostLot)
+			l.lots[r.Player] = byResource
+		}
+		byResource[r.Resource] = append(byResource[r.Resource], CostLot{
+			Tick:     r.Tick,
+			Quantity: r.Quantity,
+			UnitCost: float64(r.Total) / float64(r.Quantity),
+		})
+	} else {
+		t.CostBasis = int(math.Round(l.consumeLocked(r.Player, r.Resource, r.Quantity)))
+		t.Realized = r.Total - t.CostBasis
+		l.realized[r.Player] += t.Realized
+	}
+	l.trades = append(l.trades, t)
+	if len(l.trades) > ledgerMaxTrades {
+		l.trades = append([]LedgerTrade(nil), l.trades[len(l.trades)-ledgerMaxTrades/2:]...)
+	}
+}
+
+// consumeLocked removes qty units from the player's lots, oldest first,
+// and returns what they cost.
+func (l *Ledger) consumeLocked(player, resource string, qty int) float64 {
+	lots := l.lots[player][resource]
+	cost := 0.0
+	for qty > 0 && len(lots) > 0 {
+		take := min(qty, lots[0].Quantity)
+		cost += float64(take) * lots[0].UnitCost
+		qty -= take
+		lots[0].Quantity -= take
+		if lots[0].Quantity == 0 {
+			lots = lots[1:]
+		}
+	}
+	if len(lots) == 0 {
+		delete(l.lots[player], resource)
+	} else {
+		l.lots[player][resource] = lots
+	}
+	return cost
+}
+
+// Entries returns the entries from tick from through tick to (0 = no
+// upper bound), oldest first. An empty player means every faction.
+func (l *Ledger) Entries(player string, from, to int64) []LedgerEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	start := sort.Search(len(l.entries), func(i int) bool { return l.entries[i].Tick >= from })
+	result := make([]LedgerEntry, 0)
+	for _, e := range l.entries[start:] {
+		if to > 0 && e.Tick > to {
+			break
+		}
+		if player == "" || e.Player == player {
+			result = append(result, e)
+		}
+	}
+	return result
+}
+
+// RecentTrades returns the most recent limit trades (all if limit <= 0),
+// oldest first.
+func (l *Ledger) RecentTrades(limit int) []TradeRecord {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit <= 0 || limit > len(l.trades) {
+		limit = len(l.trades)
+	}
+	result := make([]TradeRecord, limit)
+	for i, t := range l.trades[len(l.trades)-limit:] {
+		result[i] = t.TradeRecord
+	}
+	return result
+}
+
+// RealizedSince returns the P&L the player has realized on sales since tick.
+func (l *Ledger) RealizedSince(player string, tick int64) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	total := 0
+	for i := len(l.trades) - 1; i >= 0 && l.trades[i].Tick >= tick; i-- {
+		if l.trades[i].Player == player {
+			total += l.trades[i].Realized
+		}
+	}
+	return total
+}
+
+// PnL returns the player's realized P&L and the unrealized P&L of the
+// inventory they hold, marked at mark(resource). Goods escrowed by their
+// resting sell orders (by resource) count as held. Bought units the player
+// no longer holds (eaten, used in construction, lost with a ship) are left
+// out of the positions, oldest first. PnL only reads: the lots themselves
+// are consumed by sales alone.
+func (l *Ledger) PnL(player *entities.Player, escrowed map[string]int, mark func(resource string) float64) FactionPnL {
+	held := holdings(player)
+	for res, qty := range escrowed {
+		held[res] += qty
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	result := FactionPnL{Player: player.Name, Realized: l.realized[player.Name], Positions: []Position{}}
+	resources := make([]string, 0, len(l.lots[player.Name]))
+	for res := range l.lots[player.Name] {
+		resources = append(resources, res)
+	}
+	sort.Strings(resources)
+
+	for _, res := range resources {
+		// Write the lots down to what's held, on a copy
+		lots := l.lots[player.Name][res]
+		open := 0
+		for _, lot := range lots {
+			open += lot.Quantity
+		}
+		skip := open - held[res]
+
+		pos := Position{Resource: res}
+		cost := 0.0
+		for _, lot := range lots {
+			qty := lot.Quantity
+			if skip > 0 {
+				drop := min(skip, qty)
+				skip -= drop
+				qty -= drop
+			}
+			pos.Quantity += qty
+			cost += float64(qty) * lot.UnitCost
+		}
+		if pos.Quantity == 0 {
+			continue
+		}
+		pos.CostBasis = int(math.Round(cost))
+		pos.Mark = mark(res)
+		pos.Unrealized = int(math.Round(float64(pos.Quantity)*pos.Mark - cost))
+		result.Unrealized += pos.Unrealized
+		result.Positions = append(result.Positions, pos)
+	}
+	return result
+}
+
+// holdings totals what the player has stored on their planets and aboard
+// their ships.
+func holdings(player *entities.Player) map[string]int {
+	held := make(map[string]int)
+	for _, planet := range player.OwnedPlanets {
+		if planet == nil {
+			continue
+		}
+		for res, s := range planet.StoredResources {
+			if s != nil {
+				held[res] += s.Amount
+			}
+		}
+	}
+	for _, ship := range player.OwnedShips {
+		if ship == nil {
+			continue
+		}
+		for res, qty := range ship.CargoHold {
+			held[res] += qty
+		}
+	}
+	return held
+}
+
+// Snapshot returns a copy of the ledger's state for saving.
+func (l *Ledger) Snapshot() LedgerSnapshot {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	s := LedgerSnapshot{
+		Entries:  append([]LedgerEntry(nil), l.entries...),
+		Trades:   append([]LedgerTrade(nil), l.trades...),
+		Balances: make(map[string]int, len(l.balances)),
+		Lots:     make(map[string]map[string][]CostLot, len(l.lots)),
+		Realized: make(map[string]int, len(l.realized)),
+		NextSeq:  l.nextSeq,
+	}
+	for name, b := range l.balances {
+		s.Balances[name] = b
+	}
+	for name, byResource := range l.lots {
+		s.Lots[name] = make(map[string][]CostLot, len(byResource))
+		for res, lots := range byResource {
+			s.Lots[name][res] = append([]CostLot(nil), lots...)
+		}
+	}
+	for name, r := range l.realized {
+		s.Realized[name] = r
+	}
+	return s
+}
+
+// Restore replaces the ledger's state with a saved snapshot. Saves from
+// before the ledger existed restore to an empty one, which opens each
+// faction at its current balance on the next Reconcile.
+func (l *Ledger) Restore(s LedgerSnapshot) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = s.Entries
+	l.trades = s.Trades
+	l.balances = s.Balances
+	l.lots = s.Lots
+	l.realized = s.Realized
+	l.nextSeq = max(s.NextSeq, 1)
+	if l.balances == nil {
+		l.balances = make(map[string]int)
+	}
+	if l.lots == nil {
+		l.lots = make(map[string]map[string][]CostLot)
+	}
+	if l.realized == nil {
+		l.realized = make(map[string]int)
+	}
+	if len(l.entries) > ledgerMaxEntries {
+		l.compactLocked(len(l.entries) - ledgerMaxEntries/2)
+	}
+	if len(l.trades) > ledgerMaxTrades {
+		l.trades = append([]LedgerTrade(nil), l.trades[len(l.trades)-ledgerMaxTrades/2:]...)
+	}
+}
@@ -0,0 +1,185 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+func TestLedgerReconcileSumsToBalance(t *testing.T) {
+	l := NewLedger()
+	alpha := &entities.Player{Name: "Alpha", Credits: 1000}
+	beta := &entities.Player{Name: "Beta", Credits: 500}
+	players := []*entities.Player{alpha, beta}
+
+	l.SetTick(1)
+	l.Reconcile(players, "start", ReasonEvent)
+
+	// A recorded movement with detail, then an unexplained one in the same window
+	l.SetTick(2)
+	alpha.Credits += 200
+	l.Record(alpha, LedgerEntry{Amount: 200, Reason: ReasonDockSale, Source: "dock", Resource: "Iron", Quantity: 4})
+	alpha.Credits -= 50
+	beta.Credits -= 75
+	l.Reconcile(players, "WealthTax", ReasonTax)
+	l.Reconcile(players, "Idle", ReasonEvent) // nothing changed: no entries
+
+	entries := l.Entries("", 0, 0)
+	if len(entries) != 5 {
+		t.Fatalf("got %d entries, want 5: %+v", len(entries), entries)
+	}
+	want := []struct {
+		player, reason string
+		amount         int
+	}{
+		{"Alpha", ReasonOpening, 1000},
+		{"Beta", ReasonOpening, 500},
+		{"Alpha", ReasonDockSale, 200},
+		{"Alpha", ReasonTax, -50},
+		{"Beta", ReasonTax, -75},
+	}
+	for i, w := range want {
+		e := entries[i]
+		if e.Player != w.player || e.Reason != w.reason || e.Amount != w.amount || e.Seq != int64(i+1) {
+			t.Errorf("entry %d = %+v, want %s %s %d", i, e, w.player, w.reason, w.amount)
+		}
+	}
+
+	for _, p := range players {
+		sum, last := 0, 0
+		for _, e := range l.Entries(p.Name, 0, 0) {
+			sum += e.Amount
+			last = e.Balance
+		}
+		if sum != p.Credits || last != p.Credits {
+			t.Errorf("%s: entries sum to %d (last balance %d), credits %d", p.Name, sum, last, p.Credits)
+		}
+	}
+
+	if got := l.Entries("Alpha", 2, 2); len(got) != 2 {
+		t.Errorf("tick 2 for Alpha: got %d entries, want 2", len(got))
+	}
+}
+
+func TestLedgerFIFOCostBasis(t *testing.T) {
+	l := NewLedger()
+	l.AddTrade(TradeRecord{Tick: 1, Player: "Alpha", Resource: "Iron", Quantity: 10, Action: "buy", Total: 100})
+	l.AddTrade(TradeRecord{Tick: 2, Player: "Alpha", Resource: "Iron", Quantity: 10, Action: "buy", Total: 200})
+
+	// 15 units: all of the 10/unit lot and 5 of the 20/unit lot
+	l.AddTrade(TradeRecord{Tick: 3, Player: "Alpha", Resource: "Iron", Quantity: 15, Action: "sell", Total: 300})
+	if got := l.RealizedSince("Alpha", 0); got != 300-200 {
+		t.Errorf("realized = %d, want 100", got)
+	}
+	if got := l.RealizedSince("Alpha", 4); got != 0 {
+		t.Errorf("realized since tick 4 = %d, want 0", got)
+	}
+
+	planet := &entities.Planet{Owner: "Alpha"}
+	planet.AddStoredResource("Iron", 5)
+	alpha := &entities.Player{Name: "Alpha", OwnedPlanets: []*entities.Planet{planet}}
+	pnl := l.PnL(alpha, nil, func(string) float64 { return 30 })
+	if pnl.Realized != 100 || pnl.Unrealized != 5*30-100 || len(pnl.Positions) != 1 {
+		t.Fatalf("pnl = %+v", pnl)
+	}
+	if pos := pnl.Positions[0]; pos.Quantity != 5 || pos.CostBasis != 100 {
+		t.Errorf("position = %+v, want 5 Iron costing 100", pos)
+	}
+
+	// Goods escrowed by a resting sell order are still held
+	planet.RemoveStoredResource("Iron", 3)
+	if pos := l.PnL(alpha, map[string]int{"Iron": 3}, func(string) float64 { return 30 }).Positions[0]; pos.Quantity != 5 || pos.CostBasis != 100 {
+		t.Errorf("with 3 escrowed, position = %+v, want 5 Iron costing 100", pos)
+	}
+
+	// Units used up outside trades leave the position oldest first, without
+	// touching the lots: the escrowed units' cost is still there when they sell
+	if pos := l.PnL(alpha, nil, func(string) float64 { return 30 }).Positions[0]; pos.Quantity != 2 || pos.CostBasis != 40 {
+		t.Errorf("after consumption position = %+v, want 2 Iron costing 40", pos)
+	}
+	l.AddTrade(TradeRecord{Tick: 4, Player: "Alpha", Resource: "Iron", Quantity: 3, Action: "sell", Total: 90})
+	if got := l.RealizedSince("Alpha", 4); got != 90-60 {
+		t.Errorf("realized on escrowed sale = %d, want 30", got)
+	}
+
+	// Selling more than was bought: the produced units cost nothing
+	l.AddTrade(TradeRecord{Tick: 5, Player: "Alpha", Resource: "Iron", Quantity: 4, Action: "sell_at_dock", Total: 100})
+	if got := l.RealizedSince("Alpha", 5); got != 100-40 {
+		t.Errorf("realized on oversold dock sale = %d, want 60", got)
+	}
+	if got := len(l.RecentTrades(0)); got != 5 {
+		t.Errorf("trade history has %d trades, want 5", got)
+	}
+}
+
+func TestLedgerSnapshotRestore(t *testing.T) {
+	l := NewLedger()
+	alpha := &entities.Player{Name: "Alpha", Credits: 1000}
+	l.Reconcile([]*entities.Player{alpha}, "start", ReasonEvent)
+	l.AddTrade(TradeRecord{Player: "Alpha", Resource: "Iron", Quantity: 10, Action: "buy", Total: 100})
+
+	restored := NewLedger()
+	restored.Restore(l.Snapshot())
+
+	alpha.Credits += 10
+	restored.Reconcile([]*entities.Player{alpha}, "CreditProduction", ReasonIncome)
+	entries := restored.Entries("Alpha", 0, 0)
+	if len(entries) != 2 || entries[1].Amount != 10 || entries[1].Seq != 2 || entries[1].Balance != 1010 {
+		t.Errorf("entries after restore = %+v", entries)
+	}
+	restored.AddTrade(TradeRecord{Player: "Alpha", Resource: "Iron", Quantity: 10, Action: "sell", Total: 150})
+	if got := restored.RealizedSince("Alpha", 0); got != 50 {
+		t.Errorf("realized against restored lots = %d, want 50", got)
+	}
+}
+
+func TestLedgerCompaction(t *testing.T) {
+	l := NewLedger()
+	alpha := &entities.Player{Name: "Alpha", Credits: 1000}
+	beta := &entities.Player{Name: "Beta", Credits: 1000}
+	players := []*entities.Player{alpha, beta}
+	l.Reconcile(players, "start", ReasonEvent)
+	for tick := int64(1); len(l.entries) < ledgerMaxEntries; tick++ {
+		l.SetTick(tick)
+		alpha.Credits += 10
+		beta.Credits -= 1
+		l.Reconcile(players, "CreditProduction", ReasonIncome)
+	}
+	l.SetTick(1 << 20)
+	alpha.Credits -= 5
+	l.Reconcile(players, "WealthTax", ReasonTax)
+
+	entries := l.Entries("", 0, 0)
+	if len(entries) > ledgerMaxEntries/2+10 {
+		t.Fatalf("kept %d entries after compaction", len(entries))
+	}
+	// One rollup per faction and reason, first, in tick order
+	if e := entries[0]; e.Player != "Alpha" || e.Reason != ReasonOpening || e.Entries != 1 {
+		t.Errorf("first rollup = %+v", e)
+	}
+	for i := 1; i < len(entries); i++ {
+		if entries[i].Tick < entries[i-1].Tick || entries[i].Seq <= entries[i-1].Seq {
+			t.Fatalf("entries out of order at %d: %+v then %+v", i, entries[i-1], entries[i])
+		}
+	}
+	for _, p := range players {
+		sum, last := 0, 0
+		for _, e := range l.Entries(p.Name, 0, 0) {
+			sum += e.Amount
+			last = e.Balance
+		}
+		if sum != p.Credits || last != p.Credits {
+			t.Errorf("%s: entries sum to %d (last balance %d), credits %d", p.Name, sum, last, p.Credits)
+		}
+	}
+
+	for i := 0; i <= ledgerMaxTrades; i++ {
+		l.AddTrade(TradeRecord{Player: "Alpha", Resource: "Iron", Quantity: 1, Action: "sell", Total: 1})
+	}
+	if got := len(l.RecentTrades(0)); got > ledgerMaxTrades {
+		t.Errorf("kept %d trades", got)
+	}
+	if got := l.RealizedSince("Alpha", 0); got != ledgerMaxTrades/2 {
+		t.Errorf("realized over kept trades = %d", got)
+	}
+}
@@ -2,6 +2,7 @@ package economy
 
 import (
 	"math"
+	"sort"
 	"sync"
 
 	"github.com/hunterjsb/xandaris/entities"
@@ -315,6 +316,64 @@ func (m *Market) GetSnapshot() MarketSnapshot {
 	return snap
 }
 
+// Volatility returns the standard deviation of a resource's log price
+// change per price update, over its PriceHistory (0 with too little
+// history).
+func (m *Market) Volatility(resourceType string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	rm, ok := m.resources[resourceType]
+	if !ok || len(rm.PriceHistory) < 3 {
+		return 0
+	}
+	returns := make([]float64, 0, len(rm.PriceHistory)-1)
+	for i := 1; i < len(rm.PriceHistory); i++ {
+		prev, cur := rm.PriceHistory[i-1], rm.PriceHistory[i]
+		if prev > 0 && cur > 0 {
+			returns = append(returns, math.Log(cur/prev))
+		}
+	}
+	if len(returns) < 2 {
+		return 0
+	}
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	return math.Sqrt(variance / float64(len(returns)-1))
+}
+
+// ResourceNames returns the priced resources, sorted.
+func (m *Market) ResourceNames() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	names := make([]string, 0, len(m.resources))
+	for name := range m.resources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// SupplySystems returns the systems with local supply data as of the last
+// price update (those with owned planets), sorted. Only these have local
+// prices that differ from the global ones.
+func (m *Market) SupplySystems() []int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	ids := make([]int, 0, len(m.systemSupply))
+	for id := range m.systemSupply {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
 // GetBuyPrice returns the current buy price for a resource.
 func (m *Market) GetBuyPrice(resourceType string) float64 {
 	m.mu.RLock()
@@ -325,6 +384,17 @@ func (m *Market) GetBuyPrice(resourceType string) float64 {
 	return GetBasePrice(resourceType)
 }
 
+// GetCurrentPrice returns the mid price for a resource (what stop orders
+// trigger on).
+func (m *Market) GetCurrentPrice(resourceType string) float64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if rm, ok := m.resources[resourceType]; ok {
+		return rm.CurrentPrice
+	}
+	return GetBasePrice(resourceType)
+}
+
 // GetSellPrice returns the current sell price for a resource.
 func (m *Market) GetSellPrice(resourceType string) float64 {
 	m.mu.RLock()
@@ -1,71 +1,204 @@
 package economy
 
 import (
+	"errors"
 	"fmt"
+	"sort"
 	"sync"
+
+	"github.com/hunterjsb/xandaris/entities"
 )
 
 // MarketOrder is a limit buy or sell order on a system's order book.
 // Buy orders: "I'll pay up to MaxPrice for Quantity units"
 // Sell orders: "I'll sell Quantity units at MinPrice or higher"
+//
+// Placing an order escrows what it could cost: Quantity*Price credits for
+// a buy, Quantity units taken from the planet for a sell. Fills pay out of
+// escrow and cancelling refunds whatever is left.
 type MarketOrder struct {
-	ID        int
-	SystemID  int
-	PlanetID  int    // planet where goods are delivered/collected
-	Player    string
-	Resource  string
-	Action    string // "buy" or "sell"
-	Quantity  int    // remaining unfilled quantity
-	Price     int    // limit price (max for buy, min for sell)
-	Filled    int    // how much has been filled so far
-	Active    bool
-}
-
-// OrderBook manages per-system market orders.
+	ID       int
+	SystemID int
+	PlanetID int // planet where goods are delivered/collected
+	Player   string
+	Resource string
+	Action   string // "buy" or "sell"
+	Quantity int    // remaining unfilled quantity
+	Price    int    // limit price (max for buy, min for sell)
+	Filled   int    // how much has been filled so far
+	Active   bool
+	Escrowed bool // credits/goods for the remaining quantity are held by the book
+
+	Type        string // OrderLimit, OrderStop, ... ("" = limit, from older saves)
+	StopPrice   int    // CurrentPrice that triggers a stop or take-profit
+	Pending     bool   // waiting for its trigger, off the book
+	TimeInForce string // GoodTillCancel, ImmediateOrCancel or FillOrKill
+	ExpiresAt   int64  // tick the order lapses at (0 = never)
+	Display     int    // iceberg: quantity shown at a time (0 = all)
+	Shown       int    // iceberg: what's left of the shown slice
+
+	seq int // time priority; an iceberg gets a new one each time it refreshes
+}
+
+// escrowCredits is what a buy order holds for its remaining quantity.
+func (o *MarketOrder) escrowCredits() int {
+	return o.Quantity * o.Price
+}
+
+// OrderAccounts gives the order book access to the players and planets it
+// escrows from and settles to.
+type OrderAccounts interface {
+	FindPlayer(name string) *entities.Player
+	FindPlanet(systemID, planetID int) *entities.Planet
+}
+
+// OrderFill is one execution between a resting order and an incoming one.
+// Price is the resting (maker) order's limit.
+type OrderFill struct {
+	SystemID    int
+	Resource    string
+	Buyer       string
+	Seller      string
+	BuyOrderID  int
+	SellOrderID int
+	Quantity    int
+	Price       int
+}
+
+// FillCallback is called after every fill (for event logging and volume).
+type FillCallback func(fill OrderFill)
+
+// DepthLevel is the total resting quantity at one price.
+type DepthLevel struct {
+	Price    int
+	Quantity int
+	Orders   int
+}
+
+// BookDepth is the aggregated bid and ask levels for one resource in a
+// system, best price first.
+type BookDepth struct {
+	SystemID int
+	Resource string
+	Bids     []DepthLevel
+	Asks     []DepthLevel
+}
+
+// Errors returned by PlaceOrder.
+var (
+	ErrNoAccounts        = errors.New("order book has no accounts")
+	ErrInvalidOrder      = errors.New("action must be 'buy' or 'sell' with positive quantity and price")
+	ErrUnknownPlayer     = errors.New("unknown player")
+	ErrPlanetNotOwned    = errors.New("planet not found in that system or not yours")
+	ErrInsufficientFunds = errors.New("not enough credits to escrow the order")
+	ErrInsufficientStock = errors.New("not enough stock on the planet to escrow the order")
+)
+
+// bookKey identifies one resource's book in one system.
+type bookKey struct {
+	systemID int
+	resource string
+}
+
+// book holds the resting orders for one resource in one system. Bids are
+// sorted by price descending, asks by price ascending, and ties by time
+// (earliest first), so the head of each side is the next to fill.
+type book struct {
+	bids []*MarketOrder
+	asks []*MarketOrder
+}
+
+// better reports whether a should fill before b on the same side.
+func better(a, b *MarketOrder) bool {
+	if a.Price != b.Price {
+		if a.Action == "buy" {
+			return a.Price > b.Price
+		}
+		return a.Price < b.Price
+	}
+	return a.seq < b.seq
+}
+
+// side returns the slice o rests on.
+func (b *book) side(action string) *[]*MarketOrder {
+	if action == "buy" {
+		return &b.bids
+	}
+	return &b.asks
+}
+
+// insert rests o at its price-time position.
+func (b *book) insert(o *MarketOrder) {
+	s := b.side(o.Action)
+	i := sort.Search(len(*s), func(i int) bool { return better(o, (*s)[i]) })
+	*s = append(*s, nil)
+	copy((*s)[i+1:], (*s)[i:])
+	(*s)[i] = o
+}
+
+// remove takes o off its side of the book.
+func (b *book) remove(o *MarketOrder) {
+	s := b.side(o.Action)
+	for i, r := range *s {
+		if r == o {
+			*s = append((*s)[:i], (*s)[i+1:]...)
+			return
+		}
+	}
+}
+
+// OrderBook is a price-time priority exchange with one book per resource
+// per system. Incoming orders match against the best resting orders at the
+// resting order's price; whatever is left rests on the book.
 type OrderBook struct {
-	mu     sync.RWMutex
-	orders []*MarketOrder
-	nextID int
+	mu       sync.RWMutex
+	orders   map[int]*MarketOrder // active orders by ID, pending ones included
+	books    map[bookKey]*book
+	nextID   int
+	nextSeq  int
+	tick     int64         // last tick seen by ExpireOrders
+	Accounts OrderAccounts // players and planets to escrow from and settle to
+	OnFill   FillCallback  // optional callback for event logging
 }
 
 // NewOrderBook creates an empty order book.
 func NewOrderBook() *OrderBook {
 	return &OrderBook{
-		orders: make([]*MarketOrder, 0),
+		orders: make(map[int]*MarketOrder),
+		books:  make(map[bookKey]*book),
 		nextID: 1,
 	}
 }
 
-// ClearPlayerOrders removes all active orders for a player in a system.
-// Used by auto-orders to refresh instead of stacking.
+// ClearPlayerOrders cancels all active orders for a player in a system,
+// refunding their escrow. Used by auto-orders to refresh instead of stacking.
 func (ob *OrderBook) ClearPlayerOrders(player string, systemID int) {
 	ob.mu.Lock()
 	defer ob.mu.Unlock()
-	for _, o := range ob.orders {
-		if o.Active && o.Player == player && o.SystemID == systemID {
-			o.Active = false
+	for _, o := range ob.sortedOrders() {
+		if o.Player == player && o.SystemID == systemID {
+			ob.cancelLocked(o)
 		}
 	}
 }
 
-// PruneExpired removes filled and inactive orders to prevent unbounded growth.
-func (ob *OrderBook) PruneExpired() {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
-	alive := make([]*MarketOrder, 0, len(ob.orders)/2)
-	for _, o := range ob.orders {
-		if o.Active && o.Quantity > 0 {
-			alive = append(alive, o)
-		}
-	}
-	ob.orders = alive
+// PlaceOrder escrows a new limit order and matches it against the book.
+// Any unfilled remainder rests on the book. The returned order is a
+// snapshot taken after matching.
+func (ob *OrderBook) PlaceOrder(systemID, planetID int, player, resource, action string, quantity, price int) (*MarketOrder, error) {
+	return ob.PlaceOrderWithOptions(systemID, planetID, player, resource, action, quantity, price, OrderOptions{})
 }
 
-// PlaceOrder adds a new limit order to the book.
-func (ob *OrderBook) PlaceOrder(systemID, planetID int, player, resource, action string, quantity, price int) *MarketOrder {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
+// PlaceOrderWithOptions is PlaceOrder for any order type. Stop and
+// take-profit orders are escrowed now but wait off the book for Trigger;
+// IOC and FOK orders never rest; icebergs rest showing opts.Display at a
+// time.
+func (ob *OrderBook) PlaceOrderWithOptions(systemID, planetID int, player, resource, action string, quantity, price int, opts OrderOptions) (*MarketOrder, error) {
+	if (action != "buy" && action != "sell") || quantity <= 0 {
+		return nil, ErrInvalidOrder
+	}
 
+	ob.mu.Lock()
 	order := &MarketOrder{
 		ID:       ob.nextID,
 		SystemID: systemID,
@@ -77,138 +210,501 @@ func (ob *OrderBook) PlaceOrder(systemID, planetID int, player, resource, action
 		Price:    price,
 		Active:   true,
 	}
+	if err := order.applyOptions(opts, ob.tick); err != nil {
+		ob.mu.Unlock()
+		return nil, err
+	}
+	if order.Price <= 0 {
+		ob.mu.Unlock()
+		return nil, ErrInvalidOrder
+	}
+	if err := ob.escrowLocked(order); err != nil {
+		ob.mu.Unlock()
+		return nil, err
+	}
+
+	var fills []OrderFill
+	if order.Pending {
+		ob.orders[order.ID] = order
+	} else {
+		var err error
+		if fills, err = ob.activateLocked(order); err != nil {
+			ob.mu.Unlock()
+			return nil, err
+		}
+	}
 	ob.nextID++
-	ob.orders = append(ob.orders, order)
+	snapshot := *order
+	ob.mu.Unlock()
+
+	fmt.Printf("[OrderBook] #%d: %s %s %s %d %s @ %dcr in SYS-%d (%d filled)\n",
+		order.ID, player, order.Type, action, quantity, resource, order.Price, systemID+1, snapshot.Filled)
+	ob.publish(fills)
+	return &snapshot, nil
+}
+
+// activateLocked puts an escrowed order in play: fill-or-kill orders are
+// checked first, then the order matches and its remainder either rests or,
+// for orders that can't rest, is cancelled. A fill-or-kill order that can't
+// fill is refunded and ErrNotFilled returned.
+func (ob *OrderBook) activateLocked(o *MarketOrder) ([]OrderFill, error) {
+	o.Pending = false
+	o.seq = ob.nextSeq
+	ob.nextSeq++
+
+	if o.TimeInForce == FillOrKill && ob.fillableLocked(o) < o.Quantity {
+		ob.refundLocked(o)
+		o.Active = false
+		delete(ob.orders, o.ID)
+		return nil, ErrNotFilled
+	}
+
+	fills := ob.matchLocked(o)
+	switch {
+	case o.Quantity == 0:
+		o.Active = false
+		delete(ob.orders, o.ID)
+	case o.restsOnBook():
+		ob.restLocked(o)
+	default:
+		ob.refundLocked(o)
+		o.Active = false
+		delete(ob.orders, o.ID)
+	}
+	return fills, nil
+}
+
+// fillableLocked is how much of o the book could fill right now, hidden
+// iceberg quantity included.
+func (ob *OrderBook) fillableLocked(o *MarketOrder) int {
+	b := ob.books[bookKey{o.SystemID, o.Resource}]
+	if b == nil {
+		return 0
+	}
+	opposite := b.asks
+	if o.Action == "sell" {
+		opposite = b.bids
+	}
+	total := 0
+	for _, r := range opposite {
+		if !crosses(o, r) {
+			break
+		}
+		if r.Player != o.Player {
+			total += r.Quantity
+		}
+	}
+	return total
+}
+
+// crosses reports whether incoming can trade with resting on price.
+func crosses(incoming, resting *MarketOrder) bool {
+	if incoming.Action == "sell" {
+		return incoming.Price <= resting.Price
+	}
+	return incoming.Price >= resting.Price
+}
+
+// restLocked puts o's remainder on the book, showing the first slice of an
+// iceberg.
+func (ob *OrderBook) restLocked(o *MarketOrder) {
+	key := bookKey{o.SystemID, o.Resource}
+	b := ob.books[key]
+	if b == nil {
+		b = &book{}
+		ob.books[key] = b
+	}
+	if o.Display > 0 && (o.Shown <= 0 || o.Shown > o.Quantity) {
+		o.Shown = min(o.Display, o.Quantity)
+	}
+	ob.orders[o.ID] = o
+	b.insert(o)
+}
+
+// escrowLocked takes the credits or goods o needs from its owner.
+func (ob *OrderBook) escrowLocked(o *MarketOrder) error {
+	if ob.Accounts == nil {
+		return ErrNoAccounts
+	}
+	p := ob.Accounts.FindPlayer(o.Player)
+	if p == nil {
+		return ErrUnknownPlayer
+	}
+	planet := ob.Accounts.FindPlanet(o.SystemID, o.PlanetID)
+	if planet == nil || planet.Owner != o.Player {
+		return ErrPlanetNotOwned
+	}
+	if o.Action == "buy" {
+		if p.Credits < o.escrowCredits() {
+			return ErrInsufficientFunds
+		}
+		p.Credits -= o.escrowCredits()
+	} else {
+		if planet.GetStoredAmount(o.Resource) < o.Quantity {
+			return ErrInsufficientStock
+		}
+		planet.RemoveStoredResource(o.Resource, o.Quantity)
+	}
+	o.Escrowed = true
+	return nil
+}
+
+// refundLocked returns the escrow held for o's remaining quantity.
+func (ob *OrderBook) refundLocked(o *MarketOrder) {
+	if !o.Escrowed || o.Quantity <= 0 || ob.Accounts == nil {
+		return
+	}
+	if o.Action == "buy" {
+		if p := ob.Accounts.FindPlayer(o.Player); p != nil {
+			p.Credits += o.escrowCredits()
+		}
+	} else if planet := ob.Accounts.FindPlanet(o.SystemID, o.PlanetID); planet != nil {
+		planet.AddStoredResource(o.Resource, o.Quantity)
+	}
+	o.Escrowed = false
+}
+
+// matchLocked fills incoming against the opposite side of its book, best
+// price then earliest first. A player's orders never fill against each
+// other. The caller decides what happens to any remainder.
+func (ob *OrderBook) matchLocked(incoming *MarketOrder) []OrderFill {
+	b := ob.books[bookKey{incoming.SystemID, incoming.Resource}]
+	if b == nil {
+		return nil
+	}
+	opposite := &b.asks
+	if incoming.Action == "sell" {
+		opposite = &b.bids
+	}
+
+	var fills []OrderFill
+	for i := 0; i < len(*opposite) && incoming.Quantity > 0; {
+		resting := (*opposite)[i]
+		if !crosses(incoming, resting) {
+			break
+		}
+		if resting.Player == incoming.Player {
+			i++
+			continue
+		}
+
+		buy, sell := incoming, resting
+		if incoming.Action == "sell" {
+			buy, sell = resting, incoming
+		}
+		qty := min(incoming.Quantity, resting.visible())
+		fills = append(fills, ob.settleLocked(buy, sell, resting.Price, qty))
+
+		if resting.Display > 0 {
+			resting.Shown -= qty
+		}
+		switch {
+		case resting.Quantity == 0:
+			resting.Active = false
+			delete(ob.orders, resting.ID)
+			*opposite = append((*opposite)[:i], (*opposite)[i+1:]...)
+		case resting.Display > 0 && resting.Shown == 0:
+			// Show the next slice of the iceberg, behind the orders
+			// already at its price.
+			*opposite = append((*opposite)[:i], (*opposite)[i+1:]...)
+			resting.Shown = min(resting.Display, resting.Quantity)
+			resting.seq = ob.nextSeq
+			ob.nextSeq++
+			b.insert(resting)
+		}
+	}
+	return fills
+}
 
-	fmt.Printf("[OrderBook] #%d: %s %s %d %s @ %dcr in SYS-%d\n",
-		order.ID, player, action, quantity, resource, price, systemID+1)
-	return order
+// settleLocked fills qty between buy and sell at price, paying the seller
+// and delivering goods out of escrow. The buyer gets back the difference
+// between their limit and the fill price.
+func (ob *OrderBook) settleLocked(buy, sell *MarketOrder, price, qty int) OrderFill {
+	if buy.Escrowed {
+		if p := ob.Accounts.FindPlayer(buy.Player); p != nil {
+			p.Credits += qty * (buy.Price - price)
+		}
+	}
+	if p := ob.Accounts.FindPlayer(sell.Player); p != nil {
+		p.Credits += qty * price
+	}
+	if planet := ob.Accounts.FindPlanet(buy.SystemID, buy.PlanetID); planet != nil {
+		planet.AddStoredResource(buy.Resource, qty)
+	}
+
+	buy.Quantity -= qty
+	buy.Filled += qty
+	sell.Quantity -= qty
+	sell.Filled += qty
+
+	return OrderFill{
+		SystemID:    buy.SystemID,
+		Resource:    buy.Resource,
+		Buyer:       buy.Player,
+		Seller:      sell.Player,
+		BuyOrderID:  buy.ID,
+		SellOrderID: sell.ID,
+		Quantity:    qty,
+		Price:       price,
+	}
 }
 
-// GetOrders returns active orders for a system, optionally filtered.
+// publish reports fills to OnFill. Called without the lock held so the
+// callback can read the book.
+func (ob *OrderBook) publish(fills []OrderFill) {
+	if ob.OnFill == nil {
+		return
+	}
+	for _, f := range fills {
+		ob.OnFill(f)
+	}
+}
+
+// GetOrders returns the orders on a system's book, optionally filtered by
+// resource, in price-time priority (bids then asks). Pending stops aren't
+// on the book, and icebergs report only their shown quantity.
 func (ob *OrderBook) GetOrders(systemID int, resource string) []*MarketOrder {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
 
 	var result []*MarketOrder
-	for _, o := range ob.orders {
-		if !o.Active || o.Quantity <= 0 {
+	for _, key := range ob.sortedKeys() {
+		if key.systemID != systemID || (resource != "" && key.resource != resource) {
 			continue
 		}
-		if o.SystemID != systemID {
-			continue
-		}
-		if resource != "" && o.Resource != resource {
-			continue
+		b := ob.books[key]
+		for _, side := range [][]*MarketOrder{b.bids, b.asks} {
+			for _, o := range side {
+				c := *o
+				c.Quantity = o.visible()
+				result = append(result, &c)
+			}
 		}
-		result = append(result, o)
 	}
 	return result
 }
 
-// GetPlayerOrders returns all active orders for a player.
+// GetPlayerOrders returns all of a player's open orders, pending stops
+// included, oldest first.
 func (ob *OrderBook) GetPlayerOrders(player string) []*MarketOrder {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
 
 	var result []*MarketOrder
-	for _, o := range ob.orders {
-		if o.Active && o.Player == player {
-			result = append(result, o)
+	for _, o := range ob.sortedOrders() {
+		if o.Player == player {
+			c := *o
+			result = append(result, &c)
 		}
 	}
 	return result
 }
 
-// CancelOrder cancels an order by ID if owned by the player.
-func (ob *OrderBook) CancelOrder(id int, player string) bool {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
+// EscrowedGoods returns the goods the player's sell orders hold off their
+// planets, by resource.
+func (ob *OrderBook) EscrowedGoods(player string) map[string]int {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
 
+	held := make(map[string]int)
 	for _, o := range ob.orders {
-		if o.ID == id && o.Player == player {
-			o.Active = false
-			return true
+		if o.Player == player && o.Action == "sell" && o.Escrowed {
+			held[o.Resource] += o.Quantity
 		}
 	}
-	return false
+	return held
 }
 
-// MatchOrders finds buy+sell pairs in a system that can be filled.
-// Returns matched pairs. Caller handles resource/credit transfer.
-type OrderMatch struct {
-	BuyOrder  *MarketOrder
-	SellOrder *MarketOrder
-	Quantity  int
-	Price     int // transaction price (average of buy/sell limits)
-}
-
-// FindMatches returns matchable buy+sell order pairs for a system.
-func (ob *OrderBook) FindMatches(systemID int) []OrderMatch {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
+// Depth returns up to levels aggregated price levels on each side of a
+// resource's book in a system (levels <= 0 means all). Iceberg orders count
+// only their shown quantity.
+func (ob *OrderBook) Depth(systemID int, resource string, levels int) BookDepth {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
 
-	var matches []OrderMatch
+	depth := BookDepth{SystemID: systemID, Resource: resource}
+	if b := ob.books[bookKey{systemID, resource}]; b != nil {
+		depth.Bids = aggregateLevels(b.bids, levels)
+		depth.Asks = aggregateLevels(b.asks, levels)
+	}
+	return depth
+}
 
-	for _, buy := range ob.orders {
-		if !buy.Active || buy.Action != "buy" || buy.SystemID != systemID || buy.Quantity <= 0 {
+// aggregateLevels sums an already-sorted side of the book by price.
+func aggregateLevels(side []*MarketOrder, limit int) []DepthLevel {
+	levels := make([]DepthLevel, 0)
+	for _, o := range side {
+		if n := len(levels); n > 0 && levels[n-1].Price == o.Price {
+			levels[n-1].Quantity += o.visible()
+			levels[n-1].Orders++
 			continue
 		}
-		for _, sell := range ob.orders {
-			if !sell.Active || sell.Action != "sell" || sell.SystemID != systemID || sell.Quantity <= 0 {
-				continue
-			}
-			if buy.Resource != sell.Resource || buy.Player == sell.Player {
-				continue
-			}
-			// Match: buy price >= sell price
-			if buy.Price >= sell.Price {
-				qty := buy.Quantity
-				if qty > sell.Quantity {
-					qty = sell.Quantity
-				}
-				price := (buy.Price + sell.Price) / 2 // split the difference
-
-				matches = append(matches, OrderMatch{
-					BuyOrder:  buy,
-					SellOrder: sell,
-					Quantity:  qty,
-					Price:     price,
-				})
-
-				buy.Quantity -= qty
-				buy.Filled += qty
-				sell.Quantity -= qty
-				sell.Filled += qty
-
-				if buy.Quantity <= 0 {
-					buy.Active = false
-					break
-				}
-			}
+		if limit > 0 && len(levels) == limit {
+			break
 		}
+		levels = append(levels, DepthLevel{Price: o.Price, Quantity: o.visible(), Orders: 1})
 	}
+	return levels
+}
+
+// CancelOrder cancels an order by ID if owned by the player, refunding its
+// escrow.
+func (ob *OrderBook) CancelOrder(id int, player string) bool {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
 
-	return matches
+	o := ob.orders[id]
+	if o == nil || o.Player != player {
+		return false
+	}
+	ob.cancelLocked(o)
+	return true
 }
 
-// GetAllOrders returns all orders (for save/load).
+// cancelLocked refunds o and takes it off the book.
+func (ob *OrderBook) cancelLocked(o *MarketOrder) {
+	ob.refundLocked(o)
+	o.Active = false
+	delete(ob.orders, o.ID)
+	if b := ob.books[bookKey{o.SystemID, o.Resource}]; b != nil {
+		b.remove(o)
+	}
+}
+
+// GetAllOrders returns snapshots of all active orders, oldest first (for
+// save/load).
 func (ob *OrderBook) GetAllOrders() []*MarketOrder {
 	ob.mu.RLock()
 	defer ob.mu.RUnlock()
-	result := make([]*MarketOrder, len(ob.orders))
-	copy(result, ob.orders)
-	return result
+	return appendCopies(nil, ob.sortedOrders())
 }
 
-// RestoreOrders loads orders from a save.
-func (ob *OrderBook) RestoreOrders(orders []*MarketOrder) {
-	ob.mu.Lock()
-	defer ob.mu.Unlock()
-	ob.orders = orders
+// GetNextID returns the ID the next order will get (for save/load).
+func (ob *OrderBook) GetNextID() int {
+	ob.mu.RLock()
+	defer ob.mu.RUnlock()
+	return ob.nextID
+}
+
+// RestoreOrders loads orders from a save, replacing the book. Orders saved
+// with their escrow go straight back on the book; older orders that never
+// held escrow are escrowed now, and dropped if their owner can't cover them.
+func (ob *OrderBook) RestoreOrders(orders []*MarketOrder, nextID int) {
+	sorted := make([]*MarketOrder, 0, len(orders))
 	for _, o := range orders {
+		if o != nil && o.Active && o.Quantity > 0 {
+			sorted = append(sorted, o)
+		}
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ID < sorted[j].ID })
+
+	ob.mu.Lock()
+	ob.orders = make(map[int]*MarketOrder)
+	ob.books = make(map[bookKey]*book)
+	if nextID > ob.nextID {
+		ob.nextID = nextID
+	}
+	var fills []OrderFill
+	for _, o := range sorted {
 		if o.ID >= ob.nextID {
 			ob.nextID = o.ID + 1
 		}
+		if !o.Escrowed {
+			if err := ob.escrowLocked(o); err != nil {
+				fmt.Printf("[OrderBook] Dropped order #%d on load: %v\n", o.ID, err)
+				continue
+			}
+		}
+		if o.Pending {
+			ob.orders[o.ID] = o
+			continue
+		}
+		o.seq = ob.nextSeq
+		ob.nextSeq++
+		fills = append(fills, ob.matchLocked(o)...)
+		if o.Quantity > 0 {
+			ob.restLocked(o)
+		} else {
+			o.Active = false
+		}
+	}
+	ob.mu.Unlock()
+	ob.publish(fills)
+}
+
+// ExpireOrders cancels and refunds orders whose ExpiresAt has come, and
+// records tick as the current tick for new orders' expiry checks. Returns
+// how many orders lapsed.
+func (ob *OrderBook) ExpireOrders(tick int64) int {
+	ob.mu.Lock()
+	defer ob.mu.Unlock()
+	ob.tick = tick
+
+	expired := 0
+	for _, o := range ob.sortedOrders() {
+		if o.ExpiresAt != 0 && o.ExpiresAt <= tick {
+			ob.cancelLocked(o)
+			expired++
+		}
+	}
+	return expired
+}
+
+// Trigger fires pending stop and take-profit orders whose resource's
+// current price (from price) has reached their StopPrice, oldest first.
+// Fired orders go through the book like a new order would. Returns the
+// fired orders as they stood after executing.
+func (ob *OrderBook) Trigger(price func(resource string) float64) []*MarketOrder {
+	ob.mu.Lock()
+	var fired []*MarketOrder
+	var fills []OrderFill
+	for _, o := range ob.sortedOrders() {
+		if !o.Pending || !o.fires(price(o.Resource)) {
+			continue
+		}
+		f, err := ob.activateLocked(o)
+		if err != nil {
+			fmt.Printf("[OrderBook] #%d %s triggered but %v\n", o.ID, o.Type, err)
+		}
+		fills = append(fills, f...)
+		c := *o
+		fired = append(fired, &c)
+	}
+	ob.mu.Unlock()
+	ob.publish(fills)
+	return fired
+}
+
+// sortedOrders returns the active orders by ID.
+func (ob *OrderBook) sortedOrders() []*MarketOrder {
+	result := make([]*MarketOrder, 0, len(ob.orders))
+	for _, o := range ob.orders {
+		result = append(result, o)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].ID < result[j].ID })
+	return result
+}
+
+// sortedKeys returns the book keys in a stable order.
+func (ob *OrderBook) sortedKeys() []bookKey {
+	keys := make([]bookKey, 0, len(ob.books))
+	for k := range ob.books {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].systemID != keys[j].systemID {
+			return keys[i].systemID < keys[j].systemID
+		}
+		return keys[i].resource < keys[j].resource
+	})
+	return keys
+}
+
+// appendCopies appends snapshots of orders to dst.
+func appendCopies(dst, orders []*MarketOrder) []*MarketOrder {
+	for _, o := range orders {
+		c := *o
+		dst = append(dst, &c)
 	}
+	return dst
 }
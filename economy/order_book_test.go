@@ -0,0 +1,337 @@
+package economy
+
+import (
+	"testing"
+
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+// testAccounts is a one-system world: each player owns one planet whose ID
+// is its index + 1.
+type testAccounts struct {
+	players map[string]*entities.Player
+	planets map[int]*entities.Planet
+}
+
+func newTestAccounts(names ...string) *testAccounts {
+	a := &testAccounts{players: map[string]*entities.Player{}, planets: map[int]*entities.Planet{}}
+	for i, name := range names {
+		a.players[name] = &entities.Player{Name: name, Credits: 10000}
+		planet := &entities.Planet{BaseEntity: entities.BaseEntity{ID: i + 1}, Owner: name}
+		planet.AddStoredResource("Iron", 100)
+		a.planets[i+1] = planet
+	}
+	return a
+}
+
+func (a *testAccounts) FindPlayer(name string) *entities.Player { return a.players[name] }
+
+func (a *testAccounts) FindPlanet(systemID, planetID int) *entities.Planet {
+	if systemID != 0 {
+		return nil
+	}
+	return a.planets[planetID]
+}
+
+func newTestBook(names ...string) (*OrderBook, *testAccounts, *[]OrderFill) {
+	a := newTestAccounts(names...)
+	ob := NewOrderBook()
+	ob.Accounts = a
+	fills := &[]OrderFill{}
+	ob.OnFill = func(f OrderFill) { *fills = append(*fills, f) }
+	return ob, a, fills
+}
+
+func mustPlace(t *testing.T, ob *OrderBook, planetID int, player, action string, qty, price int) *MarketOrder {
+	t.Helper()
+	o, err := ob.PlaceOrder(0, planetID, player, "Iron", action, qty, price)
+	if err != nil {
+		t.Fatalf("place %s %d @ %d for %s: %v", action, qty, price, player, err)
+	}
+	return o
+}
+
+func TestOrderBookEscrowAndCancel(t *testing.T) {
+	ob, a, _ := newTestBook("Alpha", "Beta")
+
+	buy := mustPlace(t, ob, 1, "Alpha", "buy", 10, 50)
+	if got := a.players["Alpha"].Credits; got != 9500 {
+		t.Errorf("buyer credits after escrow = %d, want 9500", got)
+	}
+	sell := mustPlace(t, ob, 2, "Beta", "sell", 30, 60)
+	if got := a.planets[2].GetStoredAmount("Iron"); got != 70 {
+		t.Errorf("seller stock after escrow = %d, want 70", got)
+	}
+	if got := ob.EscrowedGoods("Beta")["Iron"]; got != 30 {
+		t.Errorf("escrowed goods = %d, want 30", got)
+	}
+
+	if !ob.CancelOrder(buy.ID, "Alpha") || !ob.CancelOrder(sell.ID, "Beta") {
+		t.Fatal("cancel failed")
+	}
+	if a.players["Alpha"].Credits != 10000 || a.planets[2].GetStoredAmount("Iron") != 100 {
+		t.Errorf("escrow not refunded: credits %d, stock %d",
+			a.players["Alpha"].Credits, a.planets[2].GetStoredAmount("Iron"))
+	}
+	if ob.CancelOrder(buy.ID, "Alpha") {
+		t.Error("cancelling twice should fail")
+	}
+
+	if _, err := ob.PlaceOrder(0, 1, "Alpha", "Iron", "buy", 1000, 50); err != ErrInsufficientFunds {
+		t.Errorf("expected ErrInsufficientFunds, got %v", err)
+	}
+	if _, err := ob.PlaceOrder(0, 1, "Alpha", "Iron", "sell", 101, 50); err != ErrInsufficientStock {
+		t.Errorf("expected ErrInsufficientStock, got %v", err)
+	}
+	if _, err := ob.PlaceOrder(0, 2, "Alpha", "Iron", "sell", 1, 50); err != ErrPlanetNotOwned {
+		t.Errorf("expected ErrPlanetNotOwned, got %v", err)
+	}
+}
+
+func TestOrderBookPriceTimePriority(t *testing.T) {
+	ob, a, fills := newTestBook("Alpha", "Beta", "Gamma", "Delta")
+
+	// Two asks at 40: Beta's first. Gamma's 35 beats both on price.
+	first := mustPlace(t, ob, 2, "Beta", "sell", 10, 40)
+	mustPlace(t, ob, 3, "Gamma", "sell", 10, 40)
+	mustPlace(t, ob, 3, "Gamma", "sell", 5, 35)
+	mustPlace(t, ob, 4, "Delta", "sell", 10, 45)
+
+	buy := mustPlace(t, ob, 1, "Alpha", "buy", 12, 50)
+	if buy.Filled != 12 || buy.Active {
+		t.Fatalf("buy should fill completely, got %+v", buy)
+	}
+	if len(*fills) != 2 {
+		t.Fatalf("expected 2 fills, got %+v", *fills)
+	}
+	if f := (*fills)[0]; f.Seller != "Gamma" || f.Price != 35 || f.Quantity != 5 {
+		t.Errorf("first fill should be Gamma's 5 @ 35, got %+v", f)
+	}
+	if f := (*fills)[1]; f.Seller != "Beta" || f.SellOrderID != first.ID || f.Price != 40 || f.Quantity != 7 {
+		t.Errorf("second fill should be Beta's earlier 7 @ 40, got %+v", f)
+	}
+
+	// The buyer pays maker prices and gets the rest of their escrow back.
+	if got, want := a.players["Alpha"].Credits, 10000-5*35-7*40; got != want {
+		t.Errorf("buyer credits = %d, want %d", got, want)
+	}
+	if got := a.planets[1].GetStoredAmount("Iron"); got != 112 {
+		t.Errorf("buyer stock = %d, want 112", got)
+	}
+	if got := a.players["Beta"].Credits; got != 10000+7*40 {
+		t.Errorf("seller credits = %d, want %d", got, 10000+7*40)
+	}
+
+	depth := ob.Depth(0, "Iron", 0)
+	if len(depth.Bids) != 0 || len(depth.Asks) != 2 {
+		t.Fatalf("unexpected depth %+v", depth)
+	}
+	if l := depth.Asks[0]; l.Price != 40 || l.Quantity != 13 || l.Orders != 2 {
+		t.Errorf("best ask level = %+v, want 13 over 2 orders @ 40", l)
+	}
+	if l := depth.Asks[1]; l.Price != 45 || l.Quantity != 10 {
+		t.Errorf("second ask level = %+v", l)
+	}
+	if got := ob.Depth(0, "Iron", 1); len(got.Asks) != 1 {
+		t.Errorf("levels=1 should return one ask level, got %+v", got.Asks)
+	}
+}
+
+func TestOrderBookPartialFillRests(t *testing.T) {
+	ob, a, fills := newTestBook("Alpha", "Beta")
+
+	bid := mustPlace(t, ob, 1, "Alpha", "buy", 20, 50)
+	sell := mustPlace(t, ob, 2, "Beta", "sell", 8, 45)
+
+	// The incoming sell takes the resting bid's price.
+	if len(*fills) != 1 || (*fills)[0].Price != 50 || (*fills)[0].Quantity != 8 {
+		t.Fatalf("unexpected fills %+v", *fills)
+	}
+	if sell.Active || sell.Filled != 8 {
+		t.Errorf("sell should be filled, got %+v", sell)
+	}
+	orders := ob.GetOrders(0, "Iron")
+	if len(orders) != 1 || orders[0].ID != bid.ID || orders[0].Quantity != 12 || orders[0].Filled != 8 {
+		t.Fatalf("bid should rest with 12 left, got %+v", orders)
+	}
+
+	// Cancelling refunds only the unfilled part.
+	ob.CancelOrder(bid.ID, "Alpha")
+	if got, want := a.players["Alpha"].Credits, 10000-8*50; got != want {
+		t.Errorf("buyer credits = %d, want %d", got, want)
+	}
+}
+
+func TestOrderBookNoSelfTrade(t *testing.T) {
+	ob, _, fills := newTestBook("Alpha", "Beta")
+
+	mustPlace(t, ob, 1, "Alpha", "sell", 10, 30)
+	mustPlace(t, ob, 2, "Beta", "sell", 10, 40)
+	mustPlace(t, ob, 1, "Alpha", "buy", 10, 50)
+
+	if len(*fills) != 1 || (*fills)[0].Seller != "Beta" {
+		t.Fatalf("buy should skip its own ask and fill Beta's, got %+v", *fills)
+	}
+	if got := ob.GetPlayerOrders("Alpha"); len(got) != 1 || got[0].Action != "sell" {
+		t.Errorf("Alpha's ask should still rest, got %+v", got)
+	}
+}
+
+func TestOrderBookRestore(t *testing.T) {
+	ob, a, _ := newTestBook("Alpha", "Beta")
+	mustPlace(t, ob, 1, "Alpha", "buy", 10, 20)
+	saved := ob.GetAllOrders()
+	nextID := ob.GetNextID()
+
+	// A saved, escrowed order goes back on the book without escrowing twice.
+	restored := NewOrderBook()
+	restored.Accounts = a
+	restored.RestoreOrders(saved, nextID)
+	if got := a.players["Alpha"].Credits; got != 9800 {
+		t.Errorf("credits after restore = %d, want 9800", got)
+	}
+	if got := restored.Depth(0, "Iron", 0).Bids; len(got) != 1 || got[0].Quantity != 10 {
+		t.Errorf("restored bids = %+v", got)
+	}
+
+	// Orders from older saves are escrowed on load; unfunded ones dropped.
+	legacy := []*MarketOrder{
+		{ID: 3, SystemID: 0, PlanetID: 2, Player: "Beta", Resource: "Iron", Action: "sell", Quantity: 40, Price: 30, Active: true},
+		{ID: 4, SystemID: 0, PlanetID: 2, Player: "Beta", Resource: "Iron", Action: "sell", Quantity: 500, Price: 30, Active: true},
+	}
+	restored.RestoreOrders(legacy, 0)
+	if got := a.planets[2].GetStoredAmount("Iron"); got != 60 {
+		t.Errorf("seller stock after legacy restore = %d, want 60", got)
+	}
+	if got := restored.GetAllOrders(); len(got) != 1 || got[0].ID != 3 || !got[0].Escrowed {
+		t.Errorf("expected only order #3 to survive, got %+v", got)
+	}
+	if o, _ := restored.PlaceOrder(0, 1, "Alpha", "Iron", "buy", 1, 1); o.ID != 5 {
+		t.Errorf("next order ID = %d, want 5", o.ID)
+	}
+}
+
+func TestOrderBookStopOrders(t *testing.T) {
+	ob, a, fills := newTestBook("Alpha", "Beta")
+	mustPlace(t, ob, 1, "Alpha", "buy", 20, 50)
+
+	// A stop-loss sell waits off the book with its goods escrowed.
+	stop, err := ob.PlaceOrderWithOptions(0, 2, "Beta", "Iron", "sell", 30, 0, OrderOptions{Type: OrderStop, StopPrice: 60})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !stop.Pending || stop.Price != 1 || a.planets[2].GetStoredAmount("Iron") != 70 {
+		t.Fatalf("stop should be pending with goods escrowed, got %+v", stop)
+	}
+	tp, err := ob.PlaceOrderWithOptions(0, 2, "Beta", "Iron", "sell", 5, 55, OrderOptions{Type: OrderTakeProfitLimit, StopPrice: 90})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ob.Depth(0, "Iron", 0).Asks) != 0 || len(ob.GetOrders(0, "Iron")) != 1 {
+		t.Error("pending orders shouldn't show on the book")
+	}
+
+	price := 70.0
+	current := func(string) float64 { return price }
+	if fired := ob.Trigger(current); len(fired) != 0 {
+		t.Fatalf("nothing should fire at 70, got %+v", fired)
+	}
+
+	// The stop fires at 60, sells into the bid at the bid's price and
+	// cancels the rest.
+	price = 60
+	fired := ob.Trigger(current)
+	if len(fired) != 1 || fired[0].ID != stop.ID || fired[0].Filled != 20 || fired[0].Active {
+		t.Fatalf("stop should fire, fill 20 and close, got %+v", fired)
+	}
+	if len(*fills) != 1 || (*fills)[0].Price != 50 {
+		t.Errorf("unexpected fills %+v", *fills)
+	}
+	if got := a.planets[2].GetStoredAmount("Iron"); got != 75 {
+		t.Errorf("unfilled stop goods should be refunded: stock %d, want 75", got)
+	}
+
+	// The take-profit fires on the way up and rests as a limit.
+	price = 95
+	if fired := ob.Trigger(current); len(fired) != 1 || fired[0].ID != tp.ID || !fired[0].Active {
+		t.Fatalf("take-profit should fire and rest, got %+v", fired)
+	}
+	if asks := ob.Depth(0, "Iron", 0).Asks; len(asks) != 1 || asks[0].Price != 55 {
+		t.Errorf("take-profit should rest at 55, got %+v", asks)
+	}
+
+	if _, err := ob.PlaceOrderWithOptions(0, 1, "Alpha", "Iron", "buy", 5, 50, OrderOptions{Type: OrderStop}); err == nil {
+		t.Error("a stop without a stop price should be rejected")
+	}
+}
+
+func TestOrderBookTimeInForce(t *testing.T) {
+	ob, a, _ := newTestBook("Alpha", "Beta")
+	mustPlace(t, ob, 2, "Beta", "sell", 10, 40)
+
+	// Fill-or-kill: not enough on the book, so nothing happens.
+	if _, err := ob.PlaceOrderWithOptions(0, 1, "Alpha", "Iron", "buy", 15, 40, OrderOptions{TimeInForce: FillOrKill}); err != ErrNotFilled {
+		t.Fatalf("expected ErrNotFilled, got %v", err)
+	}
+	if a.players["Alpha"].Credits != 10000 || ob.Depth(0, "Iron", 0).Asks[0].Quantity != 10 {
+		t.Error("a killed order should leave credits and the book untouched")
+	}
+
+	// Immediate-or-cancel: takes the 10 and refunds the other 5.
+	ioc, err := ob.PlaceOrderWithOptions(0, 1, "Alpha", "Iron", "buy", 15, 40, OrderOptions{TimeInForce: ImmediateOrCancel})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ioc.Filled != 10 || ioc.Active || a.players["Alpha"].Credits != 10000-400 {
+		t.Errorf("IOC should fill 10 and refund the rest, got %+v with %d credits", ioc, a.players["Alpha"].Credits)
+	}
+	if len(ob.GetPlayerOrders("Alpha")) != 0 {
+		t.Error("IOC remainder shouldn't rest")
+	}
+
+	// Good-till-tick: lapses and refunds when its tick comes.
+	ob.ExpireOrders(100)
+	if _, err := ob.PlaceOrderWithOptions(0, 1, "Alpha", "Iron", "buy", 5, 30, OrderOptions{ExpiresAt: 100}); err == nil {
+		t.Error("an expiry in the past should be rejected")
+	}
+	mustPlaceOpts := func(opts OrderOptions) {
+		if _, err := ob.PlaceOrderWithOptions(0, 1, "Alpha", "Iron", "buy", 5, 30, opts); err != nil {
+			t.Fatal(err)
+		}
+	}
+	mustPlaceOpts(OrderOptions{ExpiresAt: 150})
+	if n := ob.ExpireOrders(149); n != 0 {
+		t.Errorf("expired %d orders early", n)
+	}
+	if n := ob.ExpireOrders(150); n != 1 || a.players["Alpha"].Credits != 10000-400 {
+		t.Errorf("expected the order to lapse and refund, expired %d, credits %d", n, a.players["Alpha"].Credits)
+	}
+}
+
+func TestOrderBookIceberg(t *testing.T) {
+	ob, _, fills := newTestBook("Alpha", "Beta", "Gamma")
+
+	berg, err := ob.PlaceOrderWithOptions(0, 2, "Beta", "Iron", "sell", 50, 40, OrderOptions{Display: 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	mustPlace(t, ob, 3, "Gamma", "sell", 10, 40)
+
+	if asks := ob.Depth(0, "Iron", 0).Asks; len(asks) != 1 || asks[0].Quantity != 20 {
+		t.Fatalf("only the shown slice should count, got %+v", asks)
+	}
+	if got := ob.GetOrders(0, "Iron"); got[0].ID != berg.ID || got[0].Quantity != 10 {
+		t.Errorf("public view should show the slice, got %+v", got[0])
+	}
+
+	// Taking 15: the iceberg's shown 10 first, then it refreshes behind
+	// Gamma, who supplies the last 5.
+	mustPlace(t, ob, 1, "Alpha", "buy", 15, 40)
+	if len(*fills) != 2 || (*fills)[0].Seller != "Beta" || (*fills)[0].Quantity != 10 ||
+		(*fills)[1].Seller != "Gamma" || (*fills)[1].Quantity != 5 {
+		t.Fatalf("unexpected fills %+v", *fills)
+	}
+	if got := ob.GetPlayerOrders("Beta"); len(got) != 1 || got[0].Quantity != 40 || got[0].Shown != 10 {
+		t.Errorf("iceberg should keep 40 with a fresh slice, got %+v", got)
+	}
+}
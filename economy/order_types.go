@@ -0,0 +1,130 @@
+package economy
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Order types. A limit order goes straight to the book. The others wait,
+// escrowed but off the book, until the resource's CurrentPrice reaches
+// StopPrice: stops fire when the price moves against the order (a sell
+// at or below, a buy at or above), take-profits when it moves in its
+// favour. The plain variants then take whatever the book offers and cancel
+// the rest; the _limit variants become limit orders at Price.
+const (
+	OrderLimit           = "limit"
+	OrderStop            = "stop"
+	OrderStopLimit       = "stop_limit"
+	OrderTakeProfit      = "take_profit"
+	OrderTakeProfitLimit = "take_profit_limit"
+)
+
+// Time in force: how long an order may rest on the book.
+const (
+	GoodTillCancel    = "gtc" // rest until filled or cancelled (or ExpiresAt)
+	ImmediateOrCancel = "ioc" // fill what's possible now, cancel the rest
+	FillOrKill        = "fok" // fill completely now or not at all
+)
+
+// OrderOptions are the parts of an order beyond a plain resting limit.
+type OrderOptions struct {
+	Type        string // OrderLimit (default) or a stop/take-profit type
+	StopPrice   int    // CurrentPrice that triggers a stop or take-profit
+	TimeInForce string // GoodTillCancel (default), ImmediateOrCancel or FillOrKill
+	ExpiresAt   int64  // tick the order lapses at if still open (0 = never)
+	Display     int    // iceberg: show only this much on the book at a time (0 = all)
+}
+
+// Errors for orders the book won't accept.
+var (
+	ErrInvalidOrderType = errors.New("type must be limit, stop, stop_limit, take_profit or take_profit_limit")
+	ErrInvalidTIF       = errors.New("time_in_force must be gtc, ioc or fok")
+	ErrNotFilled        = errors.New("fill-or-kill order could not be filled completely")
+)
+
+// conditional reports whether the order waits for a trigger price.
+func (o *MarketOrder) conditional() bool {
+	return o.Type != "" && o.Type != OrderLimit
+}
+
+// takesBook reports whether a triggered order executes immediately and
+// cancels its remainder instead of resting at its limit.
+func (o *MarketOrder) takesBook() bool {
+	return o.Type == OrderStop || o.Type == OrderTakeProfit
+}
+
+// restsOnBook reports whether an unfilled remainder may rest on the book.
+func (o *MarketOrder) restsOnBook() bool {
+	return !o.takesBook() && (o.TimeInForce == "" || o.TimeInForce == GoodTillCancel)
+}
+
+// fires reports whether price triggers a pending order.
+func (o *MarketOrder) fires(price float64) bool {
+	if price <= 0 {
+		return false
+	}
+	stop := float64(o.StopPrice)
+	against := o.Type == OrderStop || o.Type == OrderStopLimit
+	if (o.Action == "sell") == against {
+		return price <= stop
+	}
+	return price >= stop
+}
+
+// visible is how much of a resting order shows on the book.
+func (o *MarketOrder) visible() int {
+	if o.Display > 0 {
+		return o.Shown
+	}
+	return o.Quantity
+}
+
+// applyOptions validates opts for o and copies them on. tick is the current
+// tick, which ExpiresAt must be after.
+func (o *MarketOrder) applyOptions(opts OrderOptions, tick int64) error {
+	switch opts.Type {
+	case "", OrderLimit:
+		opts.Type = OrderLimit
+	case OrderStop, OrderStopLimit, OrderTakeProfit, OrderTakeProfitLimit:
+		if opts.StopPrice <= 0 {
+			return fmt.Errorf("%s orders need a positive stop_price", opts.Type)
+		}
+	default:
+		return ErrInvalidOrderType
+	}
+
+	switch opts.TimeInForce {
+	case "":
+		opts.TimeInForce = GoodTillCancel
+	case GoodTillCancel, ImmediateOrCancel, FillOrKill:
+	default:
+		return ErrInvalidTIF
+	}
+
+	if opts.ExpiresAt != 0 && opts.ExpiresAt <= tick {
+		return fmt.Errorf("expires_at must be after the current tick (%d)", tick)
+	}
+	if opts.Display < 0 {
+		return errors.New("display quantity can't be negative")
+	}
+	if opts.Display >= o.Quantity {
+		opts.Display = 0
+	}
+	if opts.TimeInForce != GoodTillCancel && (opts.ExpiresAt != 0 || opts.Display > 0) {
+		return fmt.Errorf("%s orders never rest, so they can't expire or be icebergs", opts.TimeInForce)
+	}
+
+	// A triggered stop sell takes any bid; a buy still needs Price as the
+	// most it will pay (which is what's escrowed).
+	if o.Action == "sell" && (opts.Type == OrderStop || opts.Type == OrderTakeProfit) {
+		o.Price = 1
+	}
+
+	o.Type = opts.Type
+	o.StopPrice = opts.StopPrice
+	o.TimeInForce = opts.TimeInForce
+	o.ExpiresAt = opts.ExpiresAt
+	o.Display = opts.Display
+	o.Pending = o.conditional()
+	return nil
+}
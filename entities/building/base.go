@@ -3,7 +3,6 @@ package building
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -29,7 +28,9 @@ func (g *BaseGenerator) GetSubType() string {
 }
 
 func (g *BaseGenerator) Generate(params entities.GenerationParams) entities.Entity {
-	id := params.SystemID*1000000 + rand.Intn(100000)
+	rng := params.Rng()
+
+	id := params.SystemID*1000000 + rng.Intn(100000)
 
 	buildingColor := color.RGBA{
 		R: 180,
@@ -40,7 +41,7 @@ func (g *BaseGenerator) Generate(params entities.GenerationParams) entities.Enti
 
 	base := entities.NewBuilding(
 		id,
-		fmt.Sprintf("Planetary Base %d", rand.Intn(900)+100),
+		fmt.Sprintf("Planetary Base %d", rng.Intn(900)+100),
 		entities.BuildingBase,
 		params.OrbitDistance,
 		params.OrbitAngle,
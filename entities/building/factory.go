@@ -2,7 +2,6 @@ package building
 
 import (
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -26,7 +25,9 @@ func (g *FactoryGenerator) GetSubType() string {
 }
 
 func (g *FactoryGenerator) Generate(params entities.GenerationParams) entities.Entity {
-	id := params.SystemID*100000 + rand.Intn(10000)
+	rng := params.Rng()
+
+	id := params.SystemID*100000 + rng.Intn(10000)
 
 	factoryColor := color.RGBA{
 		R: 180,
@@ -2,7 +2,6 @@ package building
 
 import (
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -18,7 +17,9 @@ func (g *FusionReactorGenerator) GetEntityType() entities.EntityType { return en
 func (g *FusionReactorGenerator) GetSubType() string         { return entities.BuildingFusionReactor }
 
 func (g *FusionReactorGenerator) Generate(params entities.GenerationParams) entities.Entity {
-	id := params.SystemID*100000 + rand.Intn(10000)
+	rng := params.Rng()
+
+	id := params.SystemID*100000 + rng.Intn(10000)
 	reactor := entities.NewBuilding(id, "Fusion Reactor", entities.BuildingFusionReactor, params.OrbitDistance, params.OrbitAngle,
 		color.RGBA{100, 220, 255, 255})
 	reactor.AttachmentType = "Planet"
@@ -2,7 +2,6 @@ package building
 
 import (
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -18,7 +17,9 @@ func (g *GeneratorGenerator) GetEntityType() entities.EntityType { return entiti
 func (g *GeneratorGenerator) GetSubType() string         { return entities.BuildingGenerator }
 
 func (g *GeneratorGenerator) Generate(params entities.GenerationParams) entities.Entity {
-	id := params.SystemID*100000 + rand.Intn(10000)
+	rng := params.Rng()
+
+	id := params.SystemID*100000 + rng.Intn(10000)
 	gen := entities.NewBuilding(id, "Fuel Generator", entities.BuildingGenerator, params.OrbitDistance, params.OrbitAngle,
 		color.RGBA{255, 180, 50, 255})
 	gen.AttachmentType = "Planet"
@@ -3,7 +3,6 @@ package building
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,17 +26,19 @@ func (g *HabitatGenerator) GetSubType() string {
 }
 
 func (g *HabitatGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000000 + rand.Intn(100000)
+	id := params.SystemID*1000000 + rng.Intn(100000)
 
 	// Generate name
-	name := fmt.Sprintf("Habitat %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Habitat %d", rng.Intn(100)+1)
 
 	// Habitat color (blue/green living space)
 	buildingColor := color.RGBA{
-		R: uint8(80 + rand.Intn(60)),
-		G: uint8(140 + rand.Intn(80)),
-		B: uint8(180 + rand.Intn(60)),
+		R: uint8(80 + rng.Intn(60)),
+		G: uint8(140 + rng.Intn(80)),
+		B: uint8(180 + rng.Intn(60)),
 		A: 255,
 	}
 
@@ -3,7 +3,6 @@ package building
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,17 +26,19 @@ func (g *MineGenerator) GetSubType() string {
 }
 
 func (g *MineGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000000 + rand.Intn(100000)
+	id := params.SystemID*1000000 + rng.Intn(100000)
 
 	// Generate name
-	name := fmt.Sprintf("Mine %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Mine %d", rng.Intn(100)+1)
 
 	// Mine color (industrial gray/brown)
 	buildingColor := color.RGBA{
-		R: uint8(120 + rand.Intn(40)),
-		G: uint8(100 + rand.Intn(40)),
-		B: uint8(80 + rand.Intn(40)),
+		R: uint8(120 + rng.Intn(40)),
+		G: uint8(100 + rng.Intn(40)),
+		B: uint8(80 + rng.Intn(40)),
 		A: 255,
 	}
 
@@ -2,7 +2,6 @@ package building
 
 import (
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -26,8 +25,10 @@ func (g *RefineryGenerator) GetSubType() string {
 }
 
 func (g *RefineryGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*100000 + rand.Intn(10000)
+	id := params.SystemID*100000 + rng.Intn(10000)
 
 	// Generate name
 	name := "Oil Refinery"
@@ -2,7 +2,6 @@ package building
 
 import (
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -18,7 +17,9 @@ func (g *ResearchLabGenerator) GetEntityType() entities.EntityType    { return e
 func (g *ResearchLabGenerator) GetSubType() string                    { return entities.BuildingResearchLab }
 
 func (g *ResearchLabGenerator) Generate(params entities.GenerationParams) entities.Entity {
-	id := params.SystemID*100000 + rand.Intn(10000)
+	rng := params.Rng()
+
+	id := params.SystemID*100000 + rng.Intn(10000)
 	lab := entities.NewBuilding(id, "Research Lab", entities.BuildingResearchLab, params.OrbitDistance, params.OrbitAngle,
 		color.RGBA{160, 255, 180, 255})
 	lab.AttachmentType = "Planet"
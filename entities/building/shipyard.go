@@ -3,7 +3,6 @@ package building
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,18 +26,20 @@ func (g *ShipyardGenerator) GetSubType() string {
 }
 
 func (g *ShipyardGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000000 + rand.Intn(100000)
+	id := params.SystemID*1000000 + rng.Intn(100000)
 
 	// Generate name
 	names := []string{"Orbital Shipyard", "Construction Dock", "Assembly Station", "Forge"}
-	name := fmt.Sprintf("%s %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Shipyard color (metallic silver/blue industrial)
 	buildingColor := color.RGBA{
-		R: uint8(140 + rand.Intn(60)),
-		G: uint8(150 + rand.Intn(60)),
-		B: uint8(180 + rand.Intn(60)),
+		R: uint8(140 + rng.Intn(60)),
+		G: uint8(150 + rng.Intn(60)),
+		B: uint8(180 + rng.Intn(60)),
 		A: 255,
 	}
 
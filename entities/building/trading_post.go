@@ -2,7 +2,6 @@ package building
 
 import (
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,7 +26,9 @@ func (g *TradingPostGenerator) GetSubType() string {
 }
 
 func (g *TradingPostGenerator) Generate(params entities.GenerationParams) entities.Entity {
-	id := params.SystemID*1000000 + rand.Intn(100000)
+	rng := params.Rng()
+
+	id := params.SystemID*1000000 + rng.Intn(100000)
 
 	// Warm accent color to differentiate trade structures
 	buildingColor := color.RGBA{
@@ -0,0 +1,105 @@
+package entities
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// EntityList is a slice of entities of mixed concrete types, as held by
+// systems and planets. Gob handles it through registered types; for JSON
+// each element is written as its concrete struct with an extra "Kind"
+// field naming the type, so it can be decoded back into the same type.
+type EntityList []Entity
+
+// entityKinds maps the JSON "Kind" of each concrete entity type to a constructor.
+var entityKinds = map[string]func() Entity{
+	"Planet":   func() Entity { return &Planet{} },
+	"Star":     func() Entity { return &Star{} },
+	"Station":  func() Entity { return &Station{} },
+	"Ship":     func() Entity { return &Ship{} },
+	"Fleet":    func() Entity { return &Fleet{} },
+	"Resource": func() Entity { return &Resource{} },
+	"Building": func() Entity { return &Building{} },
+}
+
+// entityKind returns the JSON kind for e (its struct name).
+func entityKind(e Entity) (string, error) {
+	t := reflect.TypeOf(e)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if _, ok := entityKinds[t.Name()]; !ok {
+		return "", fmt.Errorf("entity type %T has no JSON kind", e)
+	}
+	return t.Name(), nil
+}
+
+// MarshalJSON implements json.Marshaler.
+func (l EntityList) MarshalJSON() ([]byte, error) {
+	if l == nil {
+		return []byte("null"), nil
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('[')
+	for i, e := range l {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		if e == nil {
+			buf.WriteString("null")
+			continue
+		}
+		kind, err := entityKind(e)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			return nil, fmt.Errorf("%s %d: %w", kind, e.GetID(), err)
+		}
+		// Splice the kind in as the object's first field
+		fmt.Fprintf(&buf, `{"Kind":%q`, kind)
+		if len(data) > 2 {
+			buf.WriteByte(',')
+		}
+		buf.Write(data[1:])
+	}
+	buf.WriteByte(']')
+	return buf.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler.
+func (l *EntityList) UnmarshalJSON(data []byte) error {
+	var raw []json.RawMessage
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	if raw == nil {
+		*l = nil
+		return nil
+	}
+	list := make(EntityList, 0, len(raw))
+	for i, r := range raw {
+		if bytes.Equal(bytes.TrimSpace(r), []byte("null")) {
+			list = append(list, nil)
+			continue
+		}
+		var head struct{ Kind string }
+		if err := json.Unmarshal(r, &head); err != nil {
+			return fmt.Errorf("entity %d: %w", i, err)
+		}
+		newEntity, ok := entityKinds[head.Kind]
+		if !ok {
+			return fmt.Errorf("entity %d: unknown kind %q", i, head.Kind)
+		}
+		e := newEntity()
+		if err := json.Unmarshal(r, e); err != nil {
+			return fmt.Errorf("entity %d (%s): %w", i, head.Kind, err)
+		}
+		list = append(list, e)
+	}
+	*l = list
+	return nil
+}
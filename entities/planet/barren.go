@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,18 +27,20 @@ func (g *BarrenGenerator) GetSubType() string {
 }
 
 func (g *BarrenGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
 	names := []string{"Void", "Null", "Empty", "Nil", "NaN", "None"}
-	name := fmt.Sprintf("%s %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Generate grey color for barren planet
 	planetColor := color.RGBA{
-		R: uint8(100 + rand.Intn(50)),
-		G: uint8(100 + rand.Intn(50)),
-		B: uint8(100 + rand.Intn(50)),
+		R: uint8(100 + rng.Intn(50)),
+		G: uint8(100 + rng.Intn(50)),
+		B: uint8(100 + rng.Intn(50)),
 		A: 255,
 	}
 
@@ -54,10 +55,10 @@ func (g *BarrenGenerator) Generate(params entities.GenerationParams) entities.En
 	)
 
 	// Set desert-specific properties
-	planet.Size = 5 + rand.Intn(2)           // 5-6 pixels (medium size)
-	planet.Temperature = 30 - rand.Intn(100) // probably cold
+	planet.Size = 5 + rng.Intn(2)           // 5-6 pixels (medium size)
+	planet.Temperature = 30 - rng.Intn(100) // probably cold
 
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType)
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng)
 
 	// Generate resource entities for barren worlds
 	generatePlanetResources(planet, params, 1, 2) // 1-2 resource deposits
@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,18 +27,20 @@ func (g *DesertGenerator) GetSubType() string {
 }
 
 func (g *DesertGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
 	names := []string{"Dune", "Arid", "Sahara", "Gobi", "Mojave", "Atacama", "Kalahari"}
-	name := fmt.Sprintf("%s %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Desert planet color (sandy/tan tones)
 	planetColor := color.RGBA{
-		R: uint8(220 + rand.Intn(35)),
-		G: uint8(180 + rand.Intn(55)),
-		B: uint8(100 + rand.Intn(50)),
+		R: uint8(220 + rng.Intn(35)),
+		G: uint8(180 + rng.Intn(55)),
+		B: uint8(100 + rng.Intn(50)),
 		A: 255,
 	}
 
@@ -54,10 +55,10 @@ func (g *DesertGenerator) Generate(params entities.GenerationParams) entities.En
 	)
 
 	// Set desert-specific properties
-	planet.Size = 5 + rand.Intn(2)          // 5-6 pixels (medium size)
-	planet.Temperature = 30 + rand.Intn(70) // 30 to 100°C - hot and dry
+	planet.Size = 5 + rng.Intn(2)          // 5-6 pixels (medium size)
+	planet.Temperature = 30 + rng.Intn(70) // 30 to 100°C - hot and dry
 
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType)
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng)
 
 	// Civilian population starts at zero; habitation will grow once colonised
 	planet.Population = 0
@@ -70,7 +71,7 @@ func (g *DesertGenerator) Generate(params entities.GenerationParams) entities.En
 	building.EnsurePlanetHasBase(planet, params)
 
 	// 5% chance of rings (rare for desert worlds)
-	planet.HasRings = rand.Float32() < 0.05
+	planet.HasRings = rng.Float32() < 0.05
 
 	return planet
 }
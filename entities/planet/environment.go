@@ -80,7 +80,7 @@ var planetTemperatureProfiles = map[string]temperatureProfile{
 }
 
 // randomAtmosphereForType picks a weighted atmosphere for the provided planet subtype
-func randomAtmosphereForType(planetType string) string {
+func randomAtmosphereForType(planetType string, rng *rand.Rand) string {
 	distribution, ok := planetAtmosphereDistributions[planetType]
 	if !ok || len(distribution) == 0 {
 		return entities.AtmosphereNone
@@ -95,7 +95,7 @@ func randomAtmosphereForType(planetType string) string {
 		return distribution[len(distribution)-1].Type
 	}
 
-	choice := rand.Float64() * totalWeight
+	choice := rng.Float64() * totalWeight
 	accumulated := 0.0
 	for _, entry := range distribution {
 		accumulated += entry.Weight
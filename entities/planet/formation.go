@@ -226,6 +226,7 @@ func FormSystem(star *entities.Star, rng *rand.Rand, systemID int, seed int64) [
 			OrbitDistance: orbitPx,
 			OrbitAngle:   orbitAngle,
 			SystemSeed:   seed,
+			Rand:         rng,
 		})
 
 		result = append(result, planet)
@@ -417,6 +418,7 @@ func generateMoon(parent *entities.Planet, index, systemID int, star *entities.S
 	building.EnsurePlanetHasBase(moon, entities.GenerationParams{
 		SystemID: systemID, OrbitDistance: moonOrbitPx,
 		OrbitAngle: moonAngle, SystemSeed: seed,
+		Rand: rng,
 	})
 
 	return moon
@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,12 +27,14 @@ func (g *GasGiantGenerator) GetSubType() string {
 }
 
 func (g *GasGiantGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
 	names := []string{"Goliath", "Titan", "Colossus", "Behemoth", "Giant", "Leviathan"}
-	name := fmt.Sprintf("%s %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Gas giant colors (varied: Jupiter-like browns, Saturn-like yellows, Neptune-like blues)
 	colorTypes := []color.RGBA{
@@ -42,7 +43,7 @@ func (g *GasGiantGenerator) Generate(params entities.GenerationParams) entities.
 		{R: 80, G: 120, B: 200, A: 255},  // Neptune-like blue
 		{R: 160, G: 180, B: 200, A: 255}, // Uranus-like cyan
 	}
-	planetColor := colorTypes[rand.Intn(len(colorTypes))]
+	planetColor := colorTypes[rng.Intn(len(colorTypes))]
 
 	// Create the planet
 	planet := entities.NewPlanet(
@@ -55,21 +56,21 @@ func (g *GasGiantGenerator) Generate(params entities.GenerationParams) entities.
 	)
 
 	// Set gas giant-specific properties
-	planet.Size = 8 + rand.Intn(4)                                 // 8-11 pixels (much larger than terrestrial)
-	planet.Temperature = -150 + rand.Intn(50)                      // -150 to -100°C - very cold
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType) // Gas giants default to dense atmospheres
-	planet.Population = 0                                          // No surface, but could have floating cities in future
+	planet.Size = 8 + rng.Intn(4)                                       // 8-11 pixels (much larger than terrestrial)
+	planet.Temperature = -150 + rng.Intn(50)                            // -150 to -100°C - very cold
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng) // Gas giants default to dense atmospheres
+	planet.Population = 0                                               // No surface, but could have floating cities in future
 
 	// Generate resource entities for gas giants
 	generatePlanetResources(planet, params, 2, 3) // 2-4 resource deposits
 
 	// Very low habitability (no solid surface)
-	planet.Habitability = 5 + rand.Intn(10) // 5-15% (potential for floating stations)
+	planet.Habitability = 5 + rng.Intn(10) // 5-15% (potential for floating stations)
 
 	// 40% chance of rings (gas giants often have rings)
 	building.EnsurePlanetHasBase(planet, params)
 
-	planet.HasRings = rand.Float32() < 0.40
+	planet.HasRings = rng.Float32() < 0.40
 
 	return planet
 }
@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,18 +27,20 @@ func (g *IceGenerator) GetSubType() string {
 }
 
 func (g *IceGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
 	names := []string{"Frost", "Glacier", "Tundra", "Icarus", "Borea", "Cryo"}
-	name := fmt.Sprintf("%s %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Ice world color (blue/white icy tones)
 	planetColor := color.RGBA{
-		R: uint8(200 + rand.Intn(55)),
-		G: uint8(220 + rand.Intn(35)),
-		B: uint8(240 + rand.Intn(15)),
+		R: uint8(200 + rng.Intn(55)),
+		G: uint8(220 + rng.Intn(35)),
+		B: uint8(240 + rng.Intn(15)),
 		A: 255,
 	}
 
@@ -54,10 +55,10 @@ func (g *IceGenerator) Generate(params entities.GenerationParams) entities.Entit
 	)
 
 	// Set ice world-specific properties
-	planet.Size = 4 + rand.Intn(3)           // 4-6 pixels
-	planet.Temperature = -80 + rand.Intn(40) // -80 to -40°C - very cold
+	planet.Size = 4 + rng.Intn(3)           // 4-6 pixels
+	planet.Temperature = -80 + rng.Intn(40) // -80 to -40°C - very cold
 
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType)
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng)
 
 	// Civilian population starts at zero; any presence represents later colonisation
 	planet.Population = 0
@@ -71,7 +72,7 @@ func (g *IceGenerator) Generate(params entities.GenerationParams) entities.Entit
 	building.EnsurePlanetHasBase(planet, params)
 
 	// 15% chance of rings
-	planet.HasRings = rand.Float32() < 0.15
+	planet.HasRings = rng.Float32() < 0.15
 
 	return planet
 }
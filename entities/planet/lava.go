@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,17 +27,19 @@ func (g *LavaGenerator) GetSubType() string {
 }
 
 func (g *LavaGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
-	name := fmt.Sprintf("Inferno %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Inferno %d", rng.Intn(100)+1)
 
 	// Lava planet color (red/orange tones)
 	planetColor := color.RGBA{
-		R: uint8(200 + rand.Intn(55)),
-		G: uint8(50 + rand.Intn(100)),
-		B: uint8(20 + rand.Intn(50)),
+		R: uint8(200 + rng.Intn(55)),
+		G: uint8(50 + rng.Intn(100)),
+		B: uint8(20 + rng.Intn(50)),
 		A: 255,
 	}
 
@@ -53,10 +54,10 @@ func (g *LavaGenerator) Generate(params entities.GenerationParams) entities.Enti
 	)
 
 	// Set lava-specific properties
-	planet.Size = 4 + rand.Intn(3)                                 // 4-6 pixels (smaller than terrestrial)
-	planet.Temperature = 800 + rand.Intn(500)                      // 800 to 1300°C - extremely hot
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType) // Always corrosive for lava worlds
-	planet.Population = 0                                          // Uninhabitable
+	planet.Size = 4 + rng.Intn(3)                                       // 4-6 pixels (smaller than terrestrial)
+	planet.Temperature = 800 + rng.Intn(500)                            // 800 to 1300°C - extremely hot
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng) // Always corrosive for lava worlds
+	planet.Population = 0                                               // Uninhabitable
 
 	// Generate resource entities for lava planets
 	generatePlanetResources(planet, params, 2, 2) // 2-3 resource deposits
@@ -67,7 +68,7 @@ func (g *LavaGenerator) Generate(params entities.GenerationParams) entities.Enti
 	// 5% chance of rings (rare)
 	building.EnsurePlanetHasBase(planet, params)
 
-	planet.HasRings = rand.Float32() < 0.05
+	planet.HasRings = rng.Float32() < 0.05
 
 	return planet
 }
@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,18 +27,20 @@ func (g *OceanGenerator) GetSubType() string {
 }
 
 func (g *OceanGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
 	names := []string{"Aqua", "Marina", "Oceanus", "Poseidon", "Nautilus", "Coral", "Atlantis"}
-	name := fmt.Sprintf("%s %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Ocean world color (blue/teal tones)
 	planetColor := color.RGBA{
-		R: uint8(20 + rand.Intn(60)),
-		G: uint8(100 + rand.Intn(100)),
-		B: uint8(180 + rand.Intn(75)),
+		R: uint8(20 + rng.Intn(60)),
+		G: uint8(100 + rng.Intn(100)),
+		B: uint8(180 + rng.Intn(75)),
 		A: 255,
 	}
 
@@ -54,10 +55,10 @@ func (g *OceanGenerator) Generate(params entities.GenerationParams) entities.Ent
 	)
 
 	// Set ocean world-specific properties
-	planet.Size = 5 + rand.Intn(3)         // 5-7 pixels (similar to terrestrial)
-	planet.Temperature = 0 + rand.Intn(40) // 0 to 40°C - temperate water worlds
+	planet.Size = 5 + rng.Intn(3)         // 5-7 pixels (similar to terrestrial)
+	planet.Temperature = 0 + rng.Intn(40) // 0 to 40°C - temperate water worlds
 
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType)
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng)
 
 	// Civilian population now starts at zero; growth systems will populate habitable worlds later
 	planet.Population = 0
@@ -70,7 +71,7 @@ func (g *OceanGenerator) Generate(params entities.GenerationParams) entities.Ent
 	building.EnsurePlanetHasBase(planet, params)
 
 	// 20% chance of rings
-	planet.HasRings = rand.Float32() < 0.20
+	planet.HasRings = rng.Float32() < 0.20
 
 	return planet
 }
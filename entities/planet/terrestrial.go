@@ -3,7 +3,6 @@ package planet
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/building"
@@ -28,17 +27,19 @@ func (g *TerrestrialGenerator) GetSubType() string {
 }
 
 func (g *TerrestrialGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*1000 + rand.Intn(1000)
+	id := params.SystemID*1000 + rng.Intn(1000)
 
 	// Generate name
-	name := fmt.Sprintf("Planet %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Planet %d", rng.Intn(100)+1)
 
 	// Terrestrial planet color (earth-like tones)
 	planetColor := color.RGBA{
-		R: uint8(50 + rand.Intn(100)),
-		G: uint8(100 + rand.Intn(100)),
-		B: uint8(50 + rand.Intn(80)),
+		R: uint8(50 + rng.Intn(100)),
+		G: uint8(100 + rng.Intn(100)),
+		B: uint8(50 + rng.Intn(80)),
 		A: 255,
 	}
 
@@ -53,10 +54,10 @@ func (g *TerrestrialGenerator) Generate(params entities.GenerationParams) entiti
 	)
 
 	// Set terrestrial-specific properties
-	planet.Size = 5 + rand.Intn(3)           // 5-7 pixels
-	planet.Temperature = -20 + rand.Intn(60) // -20 to 40°C
+	planet.Size = 5 + rng.Intn(3)           // 5-7 pixels
+	planet.Temperature = -20 + rng.Intn(60) // -20 to 40°C
 
-	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType)
+	planet.Atmosphere = randomAtmosphereForType(planet.PlanetType, rng)
 
 	// Civilian population starts at zero; future growth will depend on habitability and housing
 	planet.Population = 0
@@ -70,16 +71,18 @@ func (g *TerrestrialGenerator) Generate(params entities.GenerationParams) entiti
 	building.EnsurePlanetHasBase(planet, params)
 
 	// 10% chance of rings
-	planet.HasRings = rand.Float32() < 0.10
+	planet.HasRings = rng.Float32() < 0.10
 
 	return planet
 }
 
 // generatePlanetResources generates resource nodes for a planet with proper distribution
 func generatePlanetResources(planet *entities.Planet, params entities.GenerationParams, minResources, maxResourcesRange int) {
+	rng := params.Rng()
+
 	// Max 6 resource nodes per planet (to ensure space for mines and visual clarity)
 	maxResources := 6
-	resourceCount := minResources + rand.Intn(maxResourcesRange)
+	resourceCount := minResources + rng.Intn(maxResourcesRange)
 	if resourceCount > maxResources {
 		resourceCount = maxResources
 	}
@@ -89,14 +92,15 @@ func generatePlanetResources(planet *entities.Planet, params entities.Generation
 		// Distribute resource nodes evenly around the planet
 		angleStep := 6.28318 / float64(maxResources) // 2π divided by max nodes
 		for i := 0; i < resourceCount; i++ {
-			gen := entities.SelectRandomGenerator(resourceGenerators)
+			gen := entities.SelectRandomGenerator(resourceGenerators, rng)
 			// Assign evenly distributed angles for node positions
-			nodeAngle := float64(i)*angleStep + rand.Float64()*0.3 // Small random offset
+			nodeAngle := float64(i)*angleStep + rng.Float64()*0.3 // Small random offset
 			resourceParams := entities.GenerationParams{
 				SystemID:      params.SystemID,
-				OrbitDistance: 10.0 + float64(i)*5.0 + rand.Float64()*5.0,
+				OrbitDistance: 10.0 + float64(i)*5.0 + rng.Float64()*5.0,
 				OrbitAngle:    nodeAngle, // This will become NodePosition
 				SystemSeed:    params.SystemSeed,
+				Rand:          params.Rand,
 			}
 			resource := gen.Generate(resourceParams)
 			planet.Resources = append(planet.Resources, resource)
@@ -32,8 +32,8 @@ type Planet struct {
 	Size            int                         // Radius in pixels (visual)
 	PlanetType      string                      // Subtype like "Terrestrial", "Gas Giant", etc.
 	Population      int64                       // Number of inhabitants
-	Resources       []Entity                    // Resource entities on this planet
-	Buildings       []Entity                    // Building entities on this planet
+	Resources       EntityList                  // Resource entities on this planet
+	Buildings       EntityList                  // Building entities on this planet
 	Temperature     int                         // Temperature in Celsius
 	Atmosphere      string                      // Type of atmosphere
 	HasRings        bool                        // Whether the planet has rings
@@ -4,8 +4,9 @@ import (
 	"math/rand"
 )
 
-// InitializePlayer sets up a new player with a starting planet
-func InitializePlayer(player *Player, systems []*System) {
+// InitializePlayer sets up a new player with a starting planet. The home
+// system and scout orbit are drawn from rng.
+func InitializePlayer(player *Player, systems []*System, rng *rand.Rand) {
 	// Find systems with habitable terrestrial planets that have both Oil and Iron
 	validSystems := make([]*System, 0)
 
@@ -80,7 +81,7 @@ func InitializePlayer(player *Player, systems []*System) {
 	}
 
 	// Pick a random system
-	homeSystem := validSystems[rand.Intn(len(validSystems))]
+	homeSystem := validSystems[rng.Intn(len(validSystems))]
 	player.HomeSystem = homeSystem
 
 	// Find the best habitable planet — prefer one with Water + Iron
@@ -153,7 +154,7 @@ func InitializePlayer(player *Player, systems []*System) {
 
 	// Position ship in orbit around home planet
 	scoutShip.OrbitDistance = bestPlanet.GetOrbitDistance()
-	scoutShip.OrbitAngle = rand.Float64() * 6.28318 // Random angle around planet
+	scoutShip.OrbitAngle = rng.Float64() * 6.28318 // Random angle around planet
 
 	// Add ship to home system and player
 	homeSystem.AddEntity(scoutShip)
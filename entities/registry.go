@@ -11,8 +11,29 @@ type GenerationParams struct {
 	OrbitDistance float64
 	OrbitAngle    float64
 	SystemSeed    int64
+	Rand          *rand.Rand // nil draws from the global math/rand source
 }
 
+// Rng returns the source generators draw from. Galaxy generation passes
+// one seeded from the galaxy seed, so every planet, deposit and building
+// it creates is reproducible from that seed.
+func (p GenerationParams) Rng() *rand.Rand {
+	if p.Rand != nil {
+		return p.Rand
+	}
+	return globalRand
+}
+
+// globalRand draws from the top-level math/rand functions, which, unlike
+// a *rand.Rand of their own, are safe for concurrent use.
+var globalRand = rand.New(globalSource{})
+
+type globalSource struct{}
+
+func (globalSource) Int63() int64   { return rand.Int63() }
+func (globalSource) Uint64() uint64 { return rand.Uint64() }
+func (globalSource) Seed(int64)     {}
+
 // EntityGenerator is the interface that all entity generators must implement
 type EntityGenerator interface {
 	// Generate creates a new entity instance
@@ -60,7 +81,7 @@ func GetAllGenerators() []EntityGenerator {
 }
 
 // SelectRandomGenerator picks a random generator from a list based on weights
-func SelectRandomGenerator(generators []EntityGenerator) EntityGenerator {
+func SelectRandomGenerator(generators []EntityGenerator, rng *rand.Rand) EntityGenerator {
 	if len(generators) == 0 {
 		return nil
 	}
@@ -72,7 +93,7 @@ func SelectRandomGenerator(generators []EntityGenerator) EntityGenerator {
 	}
 
 	// Pick random value
-	r := rand.Float64() * totalWeight
+	r := rng.Float64() * totalWeight
 
 	// Find the generator
 	currentWeight := 0.0
@@ -96,12 +117,13 @@ func GenerateEntitiesForSystem(systemID int, seed int64) []Entity {
 	// Generate exactly one star per system (always first)
 	starGenerators := GetGeneratorsByType(EntityTypeStar)
 	if len(starGenerators) > 0 {
-		gen := SelectRandomGenerator(starGenerators)
+		gen := SelectRandomGenerator(starGenerators, rng)
 		params := GenerationParams{
 			SystemID:      systemID,
 			OrbitDistance: 0.0, // Stars are at the center
 			OrbitAngle:    0.0,
 			SystemSeed:    seed,
+			Rand:          rng,
 		}
 		entity := gen.Generate(params)
 		entities = append(entities, entity)
@@ -122,12 +144,13 @@ func GenerateEntitiesForSystem(systemID int, seed int64) []Entity {
 		planetGenerators := GetGeneratorsByType(EntityTypePlanet)
 		if len(planetGenerators) > 0 {
 			for i := range make([]struct{}, planetCount) {
-				gen := SelectRandomGenerator(planetGenerators)
+				gen := SelectRandomGenerator(planetGenerators, rng)
 				params := GenerationParams{
 					SystemID:      systemID,
 					OrbitDistance: 50.0 + float64(i)*30.0,
 					OrbitAngle:    rng.Float64() * 6.28,
 					SystemSeed:    seed,
+					Rand:          rng,
 				}
 				entity := gen.Generate(params)
 				entities = append(entities, entity)
@@ -139,12 +162,13 @@ func GenerateEntitiesForSystem(systemID int, seed int64) []Entity {
 	if rng.Float32() < 0.4 {
 		stationGenerators := GetGeneratorsByType(EntityTypeStation)
 		if len(stationGenerators) > 0 {
-			gen := SelectRandomGenerator(stationGenerators)
+			gen := SelectRandomGenerator(stationGenerators, rng)
 			params := GenerationParams{
 				SystemID:      systemID,
 				OrbitDistance: 200.0 + rng.Float64()*100.0,
 				OrbitAngle:    rng.Float64() * 6.28,
 				SystemSeed:    seed,
+				Rand:          rng,
 			}
 			entity := gen.Generate(params)
 			entities = append(entities, entity)
@@ -3,7 +3,6 @@ package resource
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,17 +26,19 @@ func (g *IronGenerator) GetSubType() string {
 }
 
 func (g *IronGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*100000 + rand.Intn(10000)
+	id := params.SystemID*100000 + rng.Intn(10000)
 
 	// Generate name
-	name := fmt.Sprintf("Iron Deposit %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Iron Deposit %d", rng.Intn(100)+1)
 
 	// Iron color (gray/metallic)
 	resourceColor := color.RGBA{
-		R: uint8(140 + rand.Intn(60)),
-		G: uint8(140 + rand.Intn(60)),
-		B: uint8(140 + rand.Intn(60)),
+		R: uint8(140 + rng.Intn(60)),
+		G: uint8(140 + rng.Intn(60)),
+		B: uint8(140 + rng.Intn(60)),
 		A: 255,
 	}
 
@@ -52,12 +53,12 @@ func (g *IronGenerator) Generate(params entities.GenerationParams) entities.Enti
 	)
 
 	// Set iron-specific properties
-	resource.Abundance = 40 + rand.Intn(50)            // 40-90% abundance
-	resource.ExtractionRate = 0.6 + rand.Float64()*0.3 // 0.6-0.9 (fairly easy to extract)
-	resource.Value = 50 + rand.Intn(50)                // 50-100 credits/unit
+	resource.Abundance = 40 + rng.Intn(50)            // 40-90% abundance
+	resource.ExtractionRate = 0.6 + rng.Float64()*0.3 // 0.6-0.9 (fairly easy to extract)
+	resource.Value = 50 + rng.Intn(50)                // 50-100 credits/unit
 	resource.Rarity = "Common"
-	resource.Size = 4 + rand.Intn(3)      // 4-6 pixels
-	resource.Quality = 50 + rand.Intn(40) // 50-90% quality
+	resource.Size = 4 + rng.Intn(3)      // 4-6 pixels
+	resource.Quality = 50 + rng.Intn(40) // 50-90% quality
 
 	return resource
 }
@@ -3,7 +3,6 @@ package resource
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -28,17 +27,19 @@ func (g *OilGenerator) GetSubType() string {
 }
 
 func (g *OilGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*100000 + rand.Intn(10000)
+	id := params.SystemID*100000 + rng.Intn(10000)
 
 	// Generate name
-	name := fmt.Sprintf("Oil Deposit %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Oil Deposit %d", rng.Intn(100)+1)
 
 	// Oil color (dark brown/black)
 	resourceColor := color.RGBA{
-		R: uint8(40 + rand.Intn(60)),
-		G: uint8(30 + rand.Intn(40)),
-		B: uint8(20 + rand.Intn(30)),
+		R: uint8(40 + rng.Intn(60)),
+		G: uint8(30 + rng.Intn(40)),
+		B: uint8(20 + rng.Intn(30)),
 		A: 255,
 	}
 
@@ -53,12 +54,12 @@ func (g *OilGenerator) Generate(params entities.GenerationParams) entities.Entit
 	)
 
 	// Set oil-specific properties
-	resource.Abundance = 20 + rand.Intn(50)            // 20-70% abundance
-	resource.ExtractionRate = 0.5 + rand.Float64()*0.3 // 0.5-0.8 (moderate extraction)
-	resource.Value = 100 + rand.Intn(100)              // 100-200 credits/unit
+	resource.Abundance = 20 + rng.Intn(50)            // 20-70% abundance
+	resource.ExtractionRate = 0.5 + rng.Float64()*0.3 // 0.5-0.8 (moderate extraction)
+	resource.Value = 100 + rng.Intn(100)              // 100-200 credits/unit
 	resource.Rarity = "Uncommon"
-	resource.Size = 6 + rand.Intn(5)      // 6-10 pixels
-	resource.Quality = 50 + rand.Intn(45) // 50-95% quality
+	resource.Size = 6 + rng.Intn(5)      // 6-10 pixels
+	resource.Quality = 50 + rng.Intn(45) // 50-95% quality
 
 	return resource
 }
@@ -80,9 +81,11 @@ func (g *FuelGenerator) GetSubType() string {
 }
 
 func (g *FuelGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// This should never be called since weight is 0.0
 	// But we need it defined so "Fuel" is a valid resource type
-	id := params.SystemID*100000 + rand.Intn(10000)
+	id := params.SystemID*100000 + rng.Intn(10000)
 	name := "Fuel"
 
 	// Fuel color (orange/yellow)
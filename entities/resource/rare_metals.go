@@ -3,7 +3,6 @@ package resource
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,18 +26,20 @@ func (g *RareMetalsGenerator) GetSubType() string {
 }
 
 func (g *RareMetalsGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*100000 + rand.Intn(10000)
+	id := params.SystemID*100000 + rng.Intn(10000)
 
 	// Generate name
 	names := []string{"Platinum", "Iridium", "Palladium", "Rhodium", "Gold"}
-	name := fmt.Sprintf("%s Deposit %d", names[rand.Intn(len(names))], rand.Intn(100)+1)
+	name := fmt.Sprintf("%s Deposit %d", names[rng.Intn(len(names))], rng.Intn(100)+1)
 
 	// Rare metals color (gold/bronze metallic)
 	resourceColor := color.RGBA{
-		R: uint8(200 + rand.Intn(55)),
-		G: uint8(160 + rand.Intn(60)),
-		B: uint8(60 + rand.Intn(80)),
+		R: uint8(200 + rng.Intn(55)),
+		G: uint8(160 + rng.Intn(60)),
+		B: uint8(60 + rng.Intn(80)),
 		A: 255,
 	}
 
@@ -53,12 +54,12 @@ func (g *RareMetalsGenerator) Generate(params entities.GenerationParams) entitie
 	)
 
 	// Set rare metals-specific properties
-	resource.Abundance = 10 + rand.Intn(40)            // 10-50% abundance (scarce)
-	resource.ExtractionRate = 0.2 + rand.Float64()*0.4 // 0.2-0.6 (difficult to extract)
-	resource.Value = 300 + rand.Intn(500)              // 300-800 credits/unit (very valuable)
-	resource.Rarity = []string{"Uncommon", "Rare"}[rand.Intn(2)]
-	resource.Size = 3 + rand.Intn(3)      // 3-5 pixels (smaller deposits)
-	resource.Quality = 40 + rand.Intn(50) // 40-90% quality
+	resource.Abundance = 10 + rng.Intn(40)            // 10-50% abundance (scarce)
+	resource.ExtractionRate = 0.2 + rng.Float64()*0.4 // 0.2-0.6 (difficult to extract)
+	resource.Value = 300 + rng.Intn(500)              // 300-800 credits/unit (very valuable)
+	resource.Rarity = []string{"Uncommon", "Rare"}[rng.Intn(2)]
+	resource.Size = 3 + rng.Intn(3)      // 3-5 pixels (smaller deposits)
+	resource.Quality = 40 + rng.Intn(50) // 40-90% quality
 
 	return resource
 }
@@ -3,7 +3,6 @@ package resource
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,17 +26,19 @@ func (g *WaterGenerator) GetSubType() string {
 }
 
 func (g *WaterGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*100000 + rand.Intn(10000)
+	id := params.SystemID*100000 + rng.Intn(10000)
 
 	// Generate name
-	name := fmt.Sprintf("Water Deposit %d", rand.Intn(100)+1)
+	name := fmt.Sprintf("Water Deposit %d", rng.Intn(100)+1)
 
 	// Water color (blue/cyan)
 	resourceColor := color.RGBA{
-		R: uint8(60 + rand.Intn(100)),
-		G: uint8(140 + rand.Intn(100)),
-		B: uint8(220 + rand.Intn(35)),
+		R: uint8(60 + rng.Intn(100)),
+		G: uint8(140 + rng.Intn(100)),
+		B: uint8(220 + rng.Intn(35)),
 		A: 255,
 	}
 
@@ -52,12 +53,12 @@ func (g *WaterGenerator) Generate(params entities.GenerationParams) entities.Ent
 	)
 
 	// Set water-specific properties
-	resource.Abundance = 30 + rand.Intn(60)             // 30-90% abundance
-	resource.ExtractionRate = 0.7 + rand.Float64()*0.25 // 0.7-0.95 (easy to extract)
-	resource.Value = 80 + rand.Intn(70)                 // 80-150 credits/unit (valuable for life support)
+	resource.Abundance = 30 + rng.Intn(60)             // 30-90% abundance
+	resource.ExtractionRate = 0.7 + rng.Float64()*0.25 // 0.7-0.95 (easy to extract)
+	resource.Value = 80 + rng.Intn(70)                 // 80-150 credits/unit (valuable for life support)
 	resource.Rarity = "Common"
-	resource.Size = 5 + rand.Intn(4)      // 5-8 pixels
-	resource.Quality = 60 + rand.Intn(35) // 60-95% quality
+	resource.Size = 5 + rng.Intn(4)      // 5-8 pixels
+	resource.Quality = 60 + rng.Intn(35) // 60-95% quality
 
 	return resource
 }
@@ -3,7 +3,6 @@ package star
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,18 +26,20 @@ func (g *MainSequenceGenerator) GetSubType() string {
 }
 
 func (g *MainSequenceGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID (stars use system ID directly)
 	id := params.SystemID
 
 	// Generate name
 	names := []string{"Sol", "Alpha", "Beta", "Gamma", "Delta", "Epsilon", "Zeta", "Eta", "Theta"}
-	name := fmt.Sprintf("%s-%d", names[rand.Intn(len(names))], params.SystemID)
+	name := fmt.Sprintf("%s-%d", names[rng.Intn(len(names))], params.SystemID)
 
 	// Main sequence star color (yellow/white tones like our Sun)
 	starColor := color.RGBA{
 		R: uint8(255),
-		G: uint8(245 + rand.Intn(10)),
-		B: uint8(200 + rand.Intn(55)),
+		G: uint8(245 + rng.Intn(10)),
+		B: uint8(200 + rng.Intn(55)),
 		A: 255,
 	}
 
@@ -51,28 +52,28 @@ func (g *MainSequenceGenerator) Generate(params entities.GenerationParams) entit
 	)
 
 	// Set main sequence-specific properties
-	star.Temperature = 5200 + rand.Intn(1200)   // 5200-6400K (G to F class range)
-	star.Mass = 0.8 + rand.Float64()*0.6        // 0.8-1.4 solar masses
-	star.Radius = 20 + rand.Intn(10)            // 20-29 pixels (base size, will be scaled)
-	star.Luminosity = 0.5 + rand.Float64()*2.0  // 0.5-2.5 solar luminosity
-	star.Age = 1.0 + rand.Float64()*8.0         // 1-9 billion years
-	star.Metallicity = 0.5 + rand.Float64()*1.0 // 0.5-1.5 (Sun = 1.0)
+	star.Temperature = 5200 + rng.Intn(1200)   // 5200-6400K (G to F class range)
+	star.Mass = 0.8 + rng.Float64()*0.6        // 0.8-1.4 solar masses
+	star.Radius = 20 + rng.Intn(10)            // 20-29 pixels (base size, will be scaled)
+	star.Luminosity = 0.5 + rng.Float64()*2.0  // 0.5-2.5 solar luminosity
+	star.Age = 1.0 + rng.Float64()*8.0         // 1-9 billion years
+	star.Metallicity = 0.5 + rng.Float64()*1.0 // 0.5-1.5 (Sun = 1.0)
 
 	// 5% chance of having flares
-	star.Flares = rand.Float32() < 0.05
+	star.Flares = rng.Float32() < 0.05
 
 	// Very rare chance (1%) of being a binary system
-	star.IsBinary = rand.Float32() < 0.01
+	star.IsBinary = rng.Float32() < 0.01
 
 	// Adjust color based on temperature
 	if star.Temperature > 6000 {
 		// Hotter = more white/blue
-		star.Color.B = uint8(220 + rand.Intn(35))
+		star.Color.B = uint8(220 + rng.Intn(35))
 	} else if star.Temperature < 5500 {
 		// Cooler = more orange/red
 		star.Color.R = uint8(255)
-		star.Color.G = uint8(200 + rand.Intn(40))
-		star.Color.B = uint8(150 + rand.Intn(50))
+		star.Color.G = uint8(200 + rng.Intn(40))
+		star.Color.B = uint8(150 + rng.Intn(50))
 	}
 
 	return star
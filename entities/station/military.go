@@ -3,7 +3,6 @@ package station
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,13 +26,15 @@ func (g *MilitaryGenerator) GetSubType() string {
 }
 
 func (g *MilitaryGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*10000 + rand.Intn(1000)
+	id := params.SystemID*10000 + rng.Intn(1000)
 
 	// Generate name
 	prefixes := []string{"Fortress", "Guardian", "Sentinel", "Bastion", "Aegis", "Citadel"}
 	suffixes := []string{"Alpha", "Prime", "One", "Station", "Outpost", "Command"}
-	name := fmt.Sprintf("%s %s", prefixes[rand.Intn(len(prefixes))], suffixes[rand.Intn(len(suffixes))])
+	name := fmt.Sprintf("%s %s", prefixes[rng.Intn(len(prefixes))], suffixes[rng.Intn(len(suffixes))])
 
 	// Military station color (red/grey tones)
 	stationColor := color.RGBA{
@@ -54,13 +55,13 @@ func (g *MilitaryGenerator) Generate(params entities.GenerationParams) entities.
 	)
 
 	// Set military-specific properties
-	station.Capacity = 1500 + rand.Intn(1000) // 1500-2500 capacity (smaller but more fortified)
-	station.CurrentPop = rand.Intn(station.Capacity)
-	station.DefenseLevel = 8 + rand.Intn(3) // 8-10 (very high defense)
+	station.Capacity = 1500 + rng.Intn(1000) // 1500-2500 capacity (smaller but more fortified)
+	station.CurrentPop = rng.Intn(station.Capacity)
+	station.DefenseLevel = 8 + rng.Intn(3) // 8-10 (very high defense)
 
 	// Military station owners
 	owners := []string{"Military Corp", "Defense Coalition", "Fleet Command", "Sector Defense Force"}
-	station.Owner = owners[rand.Intn(len(owners))]
+	station.Owner = owners[rng.Intn(len(owners))]
 
 	// Services typical for military stations
 	station.Services = []string{
@@ -86,8 +87,8 @@ func (g *MilitaryGenerator) Generate(params entities.GenerationParams) entities.
 		"Combat Drones",
 	}
 	// Select 3-5 random trade goods
-	numGoods := 3 + rand.Intn(3)
-	station.TradeGoods = selectRandomItems(tradeGoods, numGoods)
+	numGoods := 3 + rng.Intn(3)
+	station.TradeGoods = selectRandomItems(tradeGoods, numGoods, rng)
 
 	return station
 }
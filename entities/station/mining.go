@@ -3,7 +3,6 @@ package station
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,13 +26,15 @@ func (g *MiningGenerator) GetSubType() string {
 }
 
 func (g *MiningGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*10000 + rand.Intn(1000)
+	id := params.SystemID*10000 + rng.Intn(1000)
 
 	// Generate name
 	prefixes := []string{"Excavator", "Harvester", "Extractor", "Drill", "Prospector", "Miner"}
 	suffixes := []string{"Station", "Outpost", "Facility", "Complex", "Base", "Platform"}
-	name := fmt.Sprintf("%s %s", prefixes[rand.Intn(len(prefixes))], suffixes[rand.Intn(len(suffixes))])
+	name := fmt.Sprintf("%s %s", prefixes[rng.Intn(len(prefixes))], suffixes[rng.Intn(len(suffixes))])
 
 	// Mining station color (brown/grey metallic tones)
 	stationColor := color.RGBA{
@@ -54,13 +55,13 @@ func (g *MiningGenerator) Generate(params entities.GenerationParams) entities.En
 	)
 
 	// Set mining-specific properties
-	station.Capacity = 1200 + rand.Intn(800) // 1200-2000 capacity (industrial workforce)
-	station.CurrentPop = rand.Intn(station.Capacity)
-	station.DefenseLevel = 2 + rand.Intn(3) // 2-4 (low defense, industrial focus)
+	station.Capacity = 1200 + rng.Intn(800) // 1200-2000 capacity (industrial workforce)
+	station.CurrentPop = rng.Intn(station.Capacity)
+	station.DefenseLevel = 2 + rng.Intn(3) // 2-4 (low defense, industrial focus)
 
 	// Mining station owners
 	owners := []string{"Mining Consortium", "Independent Miners", "Resource Corp", "Asteroid Mining Guild", "Industrial Alliance"}
-	station.Owner = owners[rand.Intn(len(owners))]
+	station.Owner = owners[rng.Intn(len(owners))]
 
 	// Services typical for mining stations
 	station.Services = []string{
@@ -90,8 +91,8 @@ func (g *MiningGenerator) Generate(params entities.GenerationParams) entities.En
 		"Ore Containers",
 	}
 	// Select 4-6 random trade goods
-	numGoods := 4 + rand.Intn(3)
-	station.TradeGoods = selectRandomItems(tradeGoods, numGoods)
+	numGoods := 4 + rng.Intn(3)
+	station.TradeGoods = selectRandomItems(tradeGoods, numGoods, rng)
 
 	return station
 }
@@ -3,7 +3,6 @@ package station
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,13 +26,15 @@ func (g *ResearchGenerator) GetSubType() string {
 }
 
 func (g *ResearchGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*10000 + rand.Intn(1000)
+	id := params.SystemID*10000 + rng.Intn(1000)
 
 	// Generate name
 	prefixes := []string{"Discovery", "Insight", "Laboratory", "Observatory", "Science", "Research"}
 	suffixes := []string{"Station", "Complex", "Institute", "Facility", "Center", "Hub"}
-	name := fmt.Sprintf("%s %s", prefixes[rand.Intn(len(prefixes))], suffixes[rand.Intn(len(suffixes))])
+	name := fmt.Sprintf("%s %s", prefixes[rng.Intn(len(prefixes))], suffixes[rng.Intn(len(suffixes))])
 
 	// Research station color (blue/cyan tones)
 	stationColor := color.RGBA{
@@ -54,13 +55,13 @@ func (g *ResearchGenerator) Generate(params entities.GenerationParams) entities.
 	)
 
 	// Set research-specific properties
-	station.Capacity = 800 + rand.Intn(800) // 800-1600 capacity (smaller, focused on research)
-	station.CurrentPop = rand.Intn(station.Capacity)
-	station.DefenseLevel = 1 + rand.Intn(3) // 1-3 (low defense, focused on science)
+	station.Capacity = 800 + rng.Intn(800) // 800-1600 capacity (smaller, focused on research)
+	station.CurrentPop = rng.Intn(station.Capacity)
+	station.DefenseLevel = 1 + rng.Intn(3) // 1-3 (low defense, focused on science)
 
 	// Research station owners
 	owners := []string{"Research Guild", "Scientific Consortium", "Academy of Sciences", "Independent Researchers", "Tech Institute"}
-	station.Owner = owners[rand.Intn(len(owners))]
+	station.Owner = owners[rng.Intn(len(owners))]
 
 	// Services typical for research stations
 	station.Services = []string{
@@ -89,8 +90,8 @@ func (g *ResearchGenerator) Generate(params entities.GenerationParams) entities.
 		"Lab Supplies",
 	}
 	// Select 3-5 random trade goods
-	numGoods := 3 + rand.Intn(3)
-	station.TradeGoods = selectRandomItems(tradeGoods, numGoods)
+	numGoods := 3 + rng.Intn(3)
+	station.TradeGoods = selectRandomItems(tradeGoods, numGoods, rng)
 
 	return station
 }
@@ -3,7 +3,6 @@ package station
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,13 +26,15 @@ func (g *TradingGenerator) GetSubType() string {
 }
 
 func (g *TradingGenerator) Generate(params entities.GenerationParams) entities.Entity {
+	rng := params.Rng()
+
 	// Generate ID
-	id := params.SystemID*10000 + rand.Intn(1000)
+	id := params.SystemID*10000 + rng.Intn(1000)
 
 	// Generate name
 	prefixes := []string{"Commerce", "Trade", "Market", "Exchange"}
 	suffixes := []string{"Hub", "Central", "Prime", "Station", "Complex"}
-	name := fmt.Sprintf("%s %s", prefixes[rand.Intn(len(prefixes))], suffixes[rand.Intn(len(suffixes))])
+	name := fmt.Sprintf("%s %s", prefixes[rng.Intn(len(prefixes))], suffixes[rng.Intn(len(suffixes))])
 
 	// Trading station color (gold/yellow tones)
 	stationColor := color.RGBA{
@@ -54,13 +55,13 @@ func (g *TradingGenerator) Generate(params entities.GenerationParams) entities.E
 	)
 
 	// Set trading-specific properties
-	station.Capacity = 2000 + rand.Intn(1000) // 2000-3000 capacity
-	station.CurrentPop = rand.Intn(station.Capacity)
-	station.DefenseLevel = 3 + rand.Intn(3) // 3-5 (moderate defense)
+	station.Capacity = 2000 + rng.Intn(1000) // 2000-3000 capacity
+	station.CurrentPop = rng.Intn(station.Capacity)
+	station.DefenseLevel = 3 + rng.Intn(3) // 3-5 (moderate defense)
 
 	// Trading station owners
 	owners := []string{"Trade Union", "Independent", "Commerce Guild", "Merchant Alliance"}
-	station.Owner = owners[rand.Intn(len(owners))]
+	station.Owner = owners[rng.Intn(len(owners))]
 
 	// Services typical for trading stations
 	station.Services = []string{
@@ -88,8 +89,8 @@ func (g *TradingGenerator) Generate(params entities.GenerationParams) entities.E
 		"Raw Materials",
 	}
 	// Select 4-6 random trade goods
-	numGoods := 4 + rand.Intn(3)
-	station.TradeGoods = selectRandomItems(tradeGoods, numGoods)
+	numGoods := 4 + rng.Intn(3)
+	station.TradeGoods = selectRandomItems(tradeGoods, numGoods, rng)
 
 	return station
 }
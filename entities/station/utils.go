@@ -5,7 +5,7 @@ import (
 )
 
 // selectRandomItems selects n random items from a slice without duplicates
-func selectRandomItems(items []string, n int) []string {
+func selectRandomItems(items []string, n int, rng *rand.Rand) []string {
 	if n > len(items) {
 		n = len(items)
 	}
@@ -17,7 +17,7 @@ func selectRandomItems(items []string, n int) []string {
 	result := make([]string, 0, n)
 	for i := 0; i < n; i++ {
 		// Pick random index from remaining items
-		idx := rand.Intn(len(available))
+		idx := rng.Intn(len(available))
 		result = append(result, available[idx])
 
 		// Remove selected item by swapping with last element
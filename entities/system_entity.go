@@ -15,7 +15,7 @@ type System struct {
 	Name        string
 	Color       color.RGBA
 	Connections []int // IDs of connected systems
-	Entities    []Entity
+	Entities    EntityList
 }
 
 // Hyperlane represents a connection between two systems
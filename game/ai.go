@@ -5,7 +5,6 @@ import (
 	"image/color"
 	"math"
 	"math/rand"
-	"time"
 
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/utils"
@@ -15,46 +14,43 @@ const (
 	DefaultAIPlayerCount = 5
 )
 
-var (
-	AIPlayerNames = []string{
-		"Llama Logistics",      // Meta Llama
-		"DeepSeek Ventures",    // DeepSeek
-		"Gemini Exchange",      // Google Gemini
-		"Grok Industries",      // xAI Grok
-		"Opus Cartel",          // Anthropic Claude
-		"Mistral Trading Co.",  // Mistral AI
-	}
-)
+// DefaultAIFactions are the AI factions a default game seeds, in order.
+var DefaultAIFactions = []AIFaction{
+	{"Llama Logistics", "You are methodical and logistics-focused. Prioritize cargo ships, trade routes, and efficient supply chains. You prefer steady income over risky plays."},
+	{"DeepSeek Ventures", "You are analytical and data-driven. Focus on arbitrage opportunities — buy low, sell high. Upgrade buildings for maximum efficiency. You crunch numbers before every decision."},
+	{"Gemini Exchange", "You are a bold trader. Dominate the market by cornering scarce resources. Build Trading Posts and Factories for maximum credit generation. You're not afraid to speculate."},
+	{"Grok Industries", "You are an industrialist. Maximize production capacity — mines, refineries, factories. You build infrastructure first and trade second. Power and production are everything."},
+	{"Opus Cartel", "You are a strategic expansionist. Your TOP PRIORITY is colonization — build a Shipyard, then build_ship Colony ships, then move them to new systems and colonize unclaimed planets. You want to own the most planets. Always be expanding."},
+	{"Mistral Trading Co.", "You are a balanced diplomat. Diversify across all resource types. Build a little of everything. Avoid over-specialization and maintain healthy reserves."},
+}
 
-// InitializeAIPlayers seeds the galaxy with AI-controlled factions.
-func InitializeAIPlayers(state *State) {
+// InitializeAIPlayers seeds the galaxy with the AI factions in state.Config.
+// Homeworld picks and starting deposits are drawn from rng.
+func InitializeAIPlayers(state *State, rng *rand.Rand) {
 	availableColors := utils.GetAIPlayerColors()
 	if len(availableColors) == 0 {
 		availableColors = []color.RGBA{utils.PlayerGreen}
 	}
 
 	nextID := len(state.Players)
-	rand.Seed(time.Now().UnixNano())
 
-	aiCount := DefaultAIPlayerCount
+	aiCount := state.Config.AIPlayers
 	colorCount := len(availableColors)
 
 	for i := 0; i < aiCount; i++ {
-		name := AIPlayerNames[i%len(AIPlayerNames)]
-		if i >= len(AIPlayerNames) {
-			name = fmt.Sprintf("Frontier Syndicate %d", i+1)
-		}
+		name := state.Config.AIFactionAt(i).Name
 
 		playerColor := availableColors[i%colorCount]
 		aiPlayer := entities.NewPlayer(nextID+i, name, playerColor, entities.PlayerTypeAI)
+		aiPlayer.Credits = state.Config.StartingCredits
 
-		entities.InitializePlayer(aiPlayer, state.Systems)
+		entities.InitializePlayer(aiPlayer, state.Systems, rng)
 		if aiPlayer.HomePlanet == nil {
 			continue
 		}
 
 		state.Players = append(state.Players, aiPlayer)
-		PrepareHomeworld(aiPlayer, !aiPlayer.IsHuman())
+		PrepareHomeworld(aiPlayer, !aiPlayer.IsHuman(), rng)
 		if aiPlayer.HomePlanet != nil {
 			fmt.Printf("[AI] %s established trade hub on %s\n", aiPlayer.Name, aiPlayer.HomePlanet.Name)
 		}
@@ -62,7 +58,7 @@ func InitializeAIPlayers(state *State) {
 }
 
 // PrepareHomeworld gives a player Trading Post, initial commodities, and optionally mines + cargo ship.
-func PrepareHomeworld(player *entities.Player, buildMines bool) {
+func PrepareHomeworld(player *entities.Player, buildMines bool, rng *rand.Rand) {
 	if player == nil || player.HomePlanet == nil {
 		return
 	}
@@ -74,24 +70,24 @@ func PrepareHomeworld(player *entities.Player, buildMines bool) {
 	}
 
 	if !planet.HasBuilding(entities.BuildingTradingPost) {
-		AddBuildingToPlanet(planet, entities.BuildingTradingPost, player.Name, systemID)
+		AddBuildingToPlanet(planet, entities.BuildingTradingPost, player.Name, systemID, rng)
 	}
 
 	// Ensure home planet has key resources for production chains
-	EnsureResourceDeposit(planet, entities.ResRareMetals, player.Name) // Factory: RM+Iron → Electronics
-	EnsureResourceDeposit(planet, entities.ResHelium3, player.Name)   // Fusion Reactor: He-3 → 200MW
+	EnsureResourceDeposit(planet, entities.ResRareMetals, player.Name, rng) // Factory: RM+Iron → Electronics
+	EnsureResourceDeposit(planet, entities.ResHelium3, player.Name, rng)   // Fusion Reactor: He-3 → 200MW
 
-	SeedInitialCommodities(planet, player.Name)
+	SeedInitialCommodities(planet, player.Name, rng)
 
 	// Build mines on all owned resources (AI gets productive immediately)
 	if buildMines {
-		BuildMinesOnResources(planet, player.Name, systemID)
+		BuildMinesOnResources(planet, player.Name, systemID, rng)
 	}
 
 	// Give AI a starting refinery so they produce Fuel + a generator for power
 	if buildMines {
-		AddBuildingToPlanet(planet, entities.BuildingRefinery, player.Name, systemID)
-		AddBuildingToPlanet(planet, entities.BuildingGenerator, player.Name, systemID)
+		AddBuildingToPlanet(planet, entities.BuildingRefinery, player.Name, systemID, rng)
+		AddBuildingToPlanet(planet, entities.BuildingGenerator, player.Name, systemID, rng)
 	}
 
 	// Give non-human players a starting cargo ship for logistics
@@ -105,7 +101,7 @@ func PrepareHomeworld(player *entities.Player, buildMines bool) {
 			player.Color,
 		)
 		cargoShip.OrbitDistance = planet.GetOrbitDistance()
-		cargoShip.OrbitAngle = rand.Float64() * 2 * math.Pi
+		cargoShip.OrbitAngle = rng.Float64() * 2 * math.Pi
 		cargoShip.Status = entities.ShipStatusOrbiting
 		player.HomeSystem.AddEntity(cargoShip)
 		player.AddOwnedShip(cargoShip)
@@ -114,7 +110,7 @@ func PrepareHomeworld(player *entities.Player, buildMines bool) {
 
 // BuildMinesOnResources creates mines on Water and Iron deposits (the essentials).
 // Other resources get mined later by the AI building system when prices rise.
-func BuildMinesOnResources(planet *entities.Planet, owner string, systemID int) {
+func BuildMinesOnResources(planet *entities.Planet, owner string, systemID int, rng *rand.Rand) {
 	generators := entities.GetGeneratorsByType(entities.EntityTypeBuilding)
 	var mineGen entities.EntityGenerator
 	for _, gen := range generators {
@@ -143,7 +139,7 @@ func BuildMinesOnResources(planet *entities.Planet, owner string, systemID int)
 			SystemID:     systemID,
 			OrbitDistance: resource.GetOrbitDistance(),
 			OrbitAngle:   resource.GetOrbitAngle(),
-			SystemSeed:   time.Now().UnixNano(),
+			Rand:         rng,
 		}
 
 		buildingEntity := mineGen.Generate(params)
@@ -175,7 +171,7 @@ func GetBuildingCost(buildingType string) int {
 }
 
 // EnsureResourceDeposit adds a resource deposit to a planet if it doesn't have one of that type.
-func EnsureResourceDeposit(planet *entities.Planet, resType string, owner string) {
+func EnsureResourceDeposit(planet *entities.Planet, resType string, owner string, rng *rand.Rand) {
 	for _, res := range planet.Resources {
 		if r, ok := res.(*entities.Resource); ok && r.ResourceType == resType {
 			return // Already has this type
@@ -185,29 +181,29 @@ func EnsureResourceDeposit(planet *entities.Planet, resType string, owner string
 	// Create a new deposit
 	deposit := &entities.Resource{
 		BaseEntity: entities.BaseEntity{
-			ID:           rand.Intn(100000) + 900000,
+			ID:           rng.Intn(100000) + 900000,
 			Name:         fmt.Sprintf("%s Deposit", resType),
 			Type:         entities.EntityTypeResource,
 			SubType:      resType,
 			Color:        entities.ResourceColor(resType),
-			OrbitDistance: 6 + rand.Float64()*4,
-			OrbitAngle:   rand.Float64() * 2 * math.Pi,
+			OrbitDistance: 6 + rng.Float64()*4,
+			OrbitAngle:   rng.Float64() * 2 * math.Pi,
 		},
 		ResourceType:   resType,
-		Abundance:      40 + rand.Intn(30),
-		ExtractionRate: math.Round((0.5+rand.Float64()*0.5)*10) / 10,
+		Abundance:      40 + rng.Intn(30),
+		ExtractionRate: math.Round((0.5+rng.Float64()*0.5)*10) / 10,
 		Rarity:         "Uncommon",
 		Size:           3,
-		Quality:        50 + rand.Intn(40),
+		Quality:        50 + rng.Intn(40),
 		Owner:          owner,
-		NodePosition:   rand.Float64() * 2 * math.Pi,
+		NodePosition:   rng.Float64() * 2 * math.Pi,
 	}
 
 	planet.Resources = append(planet.Resources, deposit)
 }
 
 // AddBuildingToPlanet creates and attaches a building of the given type to a planet.
-func AddBuildingToPlanet(planet *entities.Planet, buildingType string, owner string, systemID int) {
+func AddBuildingToPlanet(planet *entities.Planet, buildingType string, owner string, systemID int, rng *rand.Rand) {
 	generators := entities.GetGeneratorsByType(entities.EntityTypeBuilding)
 	var gen entities.EntityGenerator
 	for _, g := range generators {
@@ -222,9 +218,9 @@ func AddBuildingToPlanet(planet *entities.Planet, buildingType string, owner str
 
 	params := entities.GenerationParams{
 		SystemID:     systemID,
-		OrbitDistance: 12 + rand.Float64()*6,
-		OrbitAngle:   rand.Float64() * 2 * math.Pi,
-		SystemSeed:   time.Now().UnixNano(),
+		OrbitDistance: 12 + rng.Float64()*6,
+		OrbitAngle:   rng.Float64() * 2 * math.Pi,
+		Rand:         rng,
 	}
 
 	buildingEntity := gen.Generate(params)
@@ -239,7 +235,7 @@ func AddBuildingToPlanet(planet *entities.Planet, buildingType string, owner str
 }
 
 // SeedInitialCommodities gives a planet modest starting resources.
-func SeedInitialCommodities(planet *entities.Planet, owner string) {
+func SeedInitialCommodities(planet *entities.Planet, owner string, rng *rand.Rand) {
 	if planet == nil {
 		return
 	}
@@ -250,24 +246,28 @@ func SeedInitialCommodities(planet *entities.Planet, owner string) {
 			if resource.Owner != owner {
 				continue
 			}
-			amount := 50 + rand.Intn(50)
+			amount := 50 + rng.Intn(50)
 			planet.AddStoredResource(resource.ResourceType, amount)
 		}
 	}
 
 	// Seed all resource types so consumption creates demand for everything.
 	// Rare resources get more seeding since they can't be produced on most planets.
-	essentials := map[string]int{
-		entities.ResWater:      150,
-		entities.ResIron:       80,
-		entities.ResOil:        60,
-		entities.ResFuel:       30,
-		entities.ResRareMetals: 100,
-		entities.ResHelium3:    80,
+	// (A slice, not a map: iteration order must be stable for seeded games.)
+	essentials := []struct {
+		res  string
+		base int
+	}{
+		{entities.ResWater, 150},
+		{entities.ResIron, 80},
+		{entities.ResOil, 60},
+		{entities.ResFuel, 30},
+		{entities.ResRareMetals, 100},
+		{entities.ResHelium3, 80},
 	}
-	for res, base := range essentials {
-		if planet.GetStoredAmount(res) < base/2 {
-			planet.AddStoredResource(res, base+rand.Intn(base/2))
+	for _, e := range essentials {
+		if planet.GetStoredAmount(e.res) < e.base/2 {
+			planet.AddStoredResource(e.res, e.base+rng.Intn(e.base/2))
 		}
 	}
 }
@@ -0,0 +1,289 @@
+package game
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// API key scopes. Every key can read; the other scopes each unlock one
+// group of actions, and ScopeAll unlocks everything (including managing keys).
+//
+// ScopeObserve is the spectator role: the whole galaxy, but only through the
+// delayed spectator feed. It isn't part of ScopeAll, can't be combined with
+// other scopes, and an observer key doesn't act as its account's player.
+const (
+	ScopeRead    = "read"
+	ScopeTrade   = "trade"
+	ScopeBuild   = "build"
+	ScopeFleet   = "fleet"
+	ScopeAll     = "all"
+	ScopeObserve = "observe"
+)
+
+// KeyScopes lists the scopes a key can be created with.
+var KeyScopes = []string{ScopeRead, ScopeTrade, ScopeBuild, ScopeFleet, ScopeAll, ScopeObserve}
+
+// DefaultKeyName is the account's full-access key, issued on registration
+// and on login.
+const DefaultKeyName = "default"
+
+// APIKey is one named key on an account. Only a SHA-256 of the key is kept:
+// keys are 192 random bits, so a fast hash is enough to make a leaked
+// accounts.json useless.
+type APIKey struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Hash      string    `json:"hash"`
+	Prefix    string    `json:"prefix"` // first characters of the key, to tell keys apart
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	ExpiresAt time.Time `json:"expires_at"` // zero = never
+}
+
+// Expired reports whether the key is past its expiry.
+func (k *APIKey) Expired(now time.Time) bool {
+	return !k.ExpiresAt.IsZero() && !now.Before(k.ExpiresAt)
+}
+
+// Allows reports whether the key grants scope.
+func (k *APIKey) Allows(scope string) bool {
+	return scopesAllow(k.Scopes, scope)
+}
+
+func scopesAllow(scopes []string, scope string) bool {
+	if scope == ScopeRead {
+		return true
+	}
+	for _, s := range scopes {
+		if s == scope || (s == ScopeAll && scope != ScopeObserve) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyGrant is what an authenticated key may do.
+type KeyGrant struct {
+	Player  string
+	Admin   bool // global admin key (all scopes, any player)
+	KeyID   string
+	KeyName string
+	Scopes  []string
+}
+
+// Allows reports whether the grant covers scope.
+func (g KeyGrant) Allows(scope string) bool {
+	return g.Admin || scopesAllow(g.Scopes, scope)
+}
+
+// Observer reports whether the grant is a spectator's: read-only, and not
+// acting as any player.
+func (g KeyGrant) Observer() bool {
+	return !g.Admin && scopesAllow(g.Scopes, ScopeObserve)
+}
+
+// hashAPIKey returns the stored form of a key.
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+// keyPrefix is the displayed start of a key: enough to tell keys apart,
+// never more than half of it.
+func keyPrefix(raw string) string {
+	return raw[:min(10, len(raw)/2)]
+}
+
+// normalizeScopes validates scopes, dropping duplicates. No scopes means read-only.
+func normalizeScopes(scopes []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var out []string
+	for _, s := range scopes {
+		s = strings.ToLower(strings.TrimSpace(s))
+		valid := false
+		for _, known := range KeyScopes {
+			if s == known {
+				valid = true
+				break
+			}
+		}
+		if !valid {
+			return nil, fmt.Errorf("unknown scope %q (valid: %s)", s, strings.Join(KeyScopes, ", "))
+		}
+		if !seen[s] {
+			seen[s] = true
+			out = append(out, s)
+		}
+	}
+	if len(out) == 0 {
+		out = []string{ScopeRead}
+	}
+	if seen[ScopeObserve] && len(out) > 1 {
+		return nil, fmt.Errorf("the %s scope can't be combined with other scopes", ScopeObserve)
+	}
+	return out, nil
+}
+
+// newAPIKey generates a key and its stored record.
+func newAPIKey(name string, scopes []string, expiresAt time.Time) (string, *APIKey) {
+	raw := generateAPIKey()
+	id := make([]byte, 4)
+	rand.Read(id)
+	return raw, &APIKey{
+		ID:        "k" + hex.EncodeToString(id),
+		Name:      name,
+		Hash:      hashAPIKey(raw),
+		Prefix:    keyPrefix(raw),
+		Scopes:    scopes,
+		CreatedAt: time.Now().UTC(),
+		ExpiresAt: expiresAt,
+	}
+}
+
+// addKeyLocked attaches a key to an account. Caller must hold the lock.
+func (pr *PlayerRegistry) addKeyLocked(acc *PlayerAccount, key *APIKey) {
+	acc.Keys = append(acc.Keys, key)
+	pr.keys[key.Hash] = acc
+}
+
+// removeKeyLocked detaches the key at index i. Caller must hold the lock.
+func (pr *PlayerRegistry) removeKeyLocked(acc *PlayerAccount, i int) {
+	delete(pr.keys, acc.Keys[i].Hash)
+	acc.Keys = append(acc.Keys[:i], acc.Keys[i+1:]...)
+}
+
+// issueDefaultKeyLocked replaces the account's default key with a fresh one
+// and keeps the raw key in memory so it can be handed to the player.
+// Caller must hold the lock.
+func (pr *PlayerRegistry) issueDefaultKeyLocked(acc *PlayerAccount) string {
+	for i, k := range acc.Keys {
+		if k.Name == DefaultKeyName {
+			pr.removeKeyLocked(acc, i)
+			break
+		}
+	}
+	raw, key := newAPIKey(DefaultKeyName, []string{ScopeAll}, time.Time{})
+	pr.addKeyLocked(acc, key)
+	acc.APIKey = raw
+	return raw
+}
+
+// LoginKey returns the account's default key for a login. Stored keys can't
+// be recovered from their hashes, so if this process hasn't issued the key
+// itself (e.g. after a restart) the default key is rotated and clients still
+// holding the old one must log in again. Other named keys are unaffected.
+func (pr *PlayerRegistry) LoginKey(acc *PlayerAccount) string {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	if acc.APIKey != "" {
+		if _, ok := pr.keys[hashAPIKey(acc.APIKey)]; ok {
+			return acc.APIKey
+		}
+	}
+	raw := pr.issueDefaultKeyLocked(acc)
+	pr.saveLocked()
+	return raw
+}
+
+// CreateKey adds a named key to a player's account and returns the raw key,
+// which is shown only this once. A zero expiresAt never expires.
+func (pr *PlayerRegistry) CreateKey(player, name string, scopes []string, expiresAt time.Time) (string, *APIKey, error) {
+	name = strings.TrimSpace(name)
+	if len(name) < 1 || len(name) > 32 {
+		return "", nil, fmt.Errorf("key name must be 1-32 characters")
+	}
+	scopes, err := normalizeScopes(scopes)
+	if err != nil {
+		return "", nil, err
+	}
+	if !expiresAt.IsZero() && !expiresAt.After(time.Now()) {
+		return "", nil, fmt.Errorf("expiry must be in the future")
+	}
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	acc, ok := pr.accounts[strings.ToLower(player)]
+	if !ok {
+		return "", nil, fmt.Errorf("no account for %q", player)
+	}
+	for _, k := range acc.Keys {
+		if strings.EqualFold(k.Name, name) {
+			return "", nil, fmt.Errorf("key %q already exists", name)
+		}
+	}
+	raw, key := newAPIKey(name, scopes, expiresAt.UTC())
+	pr.addKeyLocked(acc, key)
+	pr.saveLocked()
+	c := *key
+	return raw, &c, nil
+}
+
+// ListKeys returns copies of a player's keys, oldest first.
+func (pr *PlayerRegistry) ListKeys(player string) ([]APIKey, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	acc, ok := pr.accounts[strings.ToLower(player)]
+	if !ok {
+		return nil, fmt.Errorf("no account for %q", player)
+	}
+	out := make([]APIKey, 0, len(acc.Keys))
+	for _, k := range acc.Keys {
+		out = append(out, *k)
+	}
+	return out, nil
+}
+
+// RevokeKey deletes a player's key by ID or name.
+func (pr *PlayerRegistry) RevokeKey(player, idOrName string) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	acc, ok := pr.accounts[strings.ToLower(player)]
+	if !ok {
+		return fmt.Errorf("no account for %q", player)
+	}
+	for i, k := range acc.Keys {
+		if k.ID == idOrName || strings.EqualFold(k.Name, idOrName) {
+			if k.Name == DefaultKeyName {
+				acc.APIKey = ""
+			}
+			pr.removeKeyLocked(acc, i)
+			pr.saveLocked()
+			return nil
+		}
+	}
+	return fmt.Errorf("no key %q", idOrName)
+}
+
+// AuthenticateKey checks an API key and returns what it grants.
+func (pr *PlayerRegistry) AuthenticateKey(key string) (KeyGrant, bool) {
+	if key == "" {
+		return KeyGrant{}, false
+	}
+
+	// Check admin key first
+	if pr.adminKey != "" && key == pr.adminKey {
+		return KeyGrant{Admin: true, Scopes: []string{ScopeAll}}, true
+	}
+
+	hash := hashAPIKey(key)
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	acc, ok := pr.keys[hash]
+	if !ok {
+		return KeyGrant{}, false
+	}
+	for _, k := range acc.Keys {
+		if k.Hash != hash {
+			continue
+		}
+		if k.Expired(time.Now()) {
+			return KeyGrant{}, false
+		}
+		return KeyGrant{Player: acc.Name, KeyID: k.ID, KeyName: k.Name, Scopes: k.Scopes}, true
+	}
+	return KeyGrant{}, false
+}
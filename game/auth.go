@@ -9,29 +9,34 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 )
 
 // PlayerAccount stores credentials for a registered player.
 type PlayerAccount struct {
-	Name      string `json:"name"`
-	DiscordID string `json:"discord_id"`
-	APIKey    string `json:"api_key"`
-	PlayerID  int    `json:"player_id"`
+	Name      string     `json:"name"`
+	DiscordID string     `json:"discord_id"`
+	APIKey    string     `json:"-"` // raw default key, known only to the process that issued it
+	PlayerID  int        `json:"player_id"`
+	Keys      []*APIKey  `json:"keys"`
+	Webhooks  []*Webhook `json:"webhooks,omitempty"`
 }
 
 // savedAccount is the on-disk representation.
 type savedAccount struct {
-	Name      string `json:"name"`
-	DiscordID string `json:"discord_id"`
-	APIKey    string `json:"api_key"`
-	PlayerID  int    `json:"player_id"`
+	Name      string     `json:"name"`
+	DiscordID string     `json:"discord_id"`
+	APIKey    string     `json:"api_key,omitempty"` // legacy plaintext key, hashed on load
+	PlayerID  int        `json:"player_id"`
+	Keys      []*APIKey  `json:"keys,omitempty"`
+	Webhooks  []*Webhook `json:"webhooks,omitempty"`
 }
 
 // PlayerRegistry manages player accounts and API key authentication.
 type PlayerRegistry struct {
 	mu         sync.RWMutex
 	accounts   map[string]*PlayerAccount // lowercase name → account
-	keys       map[string]*PlayerAccount // api_key → account
+	keys       map[string]*PlayerAccount // api key hash → account
 	discordIDs map[string]*PlayerAccount // discord_id → account
 	adminKey   string                    // global admin key (XANDARIS_API_KEY)
 	filePath   string                    // path to accounts.json
@@ -41,8 +46,12 @@ type PlayerRegistry struct {
 // It loads any existing accounts from ~/.xandaris/accounts.json.
 func NewPlayerRegistry(adminKey string) *PlayerRegistry {
 	home, _ := os.UserHomeDir()
-	fp := filepath.Join(home, ".xandaris", "accounts.json")
+	return NewPlayerRegistryAt(adminKey, filepath.Join(home, ".xandaris", "accounts.json"))
+}
 
+// NewPlayerRegistryAt is NewPlayerRegistry backed by the accounts file at
+// fp, for games that keep their own accounts.
+func NewPlayerRegistryAt(adminKey, fp string) *PlayerRegistry {
 	pr := &PlayerRegistry{
 		accounts:   make(map[string]*PlayerAccount),
 		keys:       make(map[string]*PlayerAccount),
@@ -84,15 +93,13 @@ func (pr *PlayerRegistry) FindOrCreateByDiscord(discordID, discordUsername strin
 		name = "Player"
 	}
 
-	apiKey := generateAPIKey()
 	account := &PlayerAccount{
 		Name:      name,
 		DiscordID: discordID,
-		APIKey:    apiKey,
 	}
+	pr.issueDefaultKeyLocked(account)
 
 	pr.accounts[strings.ToLower(name)] = account
-	pr.keys[apiKey] = account
 	pr.discordIDs[discordID] = account
 
 	pr.saveLocked()
@@ -114,13 +121,9 @@ func (pr *PlayerRegistry) FindOrCreateByName(name string) (*PlayerAccount, bool,
 		return acc, false, nil
 	}
 
-	apiKey := generateAPIKey()
-	account := &PlayerAccount{
-		Name:   name,
-		APIKey: apiKey,
-	}
+	account := &PlayerAccount{Name: name}
+	pr.issueDefaultKeyLocked(account)
 	pr.accounts[strings.ToLower(name)] = account
-	pr.keys[apiKey] = account
 	pr.saveLocked()
 
 	return account, true, nil
@@ -128,24 +131,10 @@ func (pr *PlayerRegistry) FindOrCreateByName(name string) (*PlayerAccount, bool,
 
 // Authenticate checks an API key and returns the player name.
 // Returns ("", true) for admin key, (playerName, false) for player key.
+// See AuthenticateKey for the key's scopes.
 func (pr *PlayerRegistry) Authenticate(key string) (playerName string, isAdmin bool, ok bool) {
-	if key == "" {
-		return "", false, false
-	}
-
-	// Check admin key first
-	if pr.adminKey != "" && key == pr.adminKey {
-		return "", true, true
-	}
-
-	pr.mu.RLock()
-	defer pr.mu.RUnlock()
-
-	if account, exists := pr.keys[key]; exists {
-		return account.Name, false, true
-	}
-
-	return "", false, false
+	grant, ok := pr.AuthenticateKey(key)
+	return grant.Player, grant.Admin, ok
 }
 
 // RemoveAccount deletes an account by name and persists.
@@ -159,8 +148,8 @@ func (pr *PlayerRegistry) RemoveAccount(name string) {
 		return
 	}
 	delete(pr.accounts, key)
-	if acc.APIKey != "" {
-		delete(pr.keys, acc.APIKey)
+	for _, k := range acc.Keys {
+		delete(pr.keys, k.Hash)
 	}
 	if acc.DiscordID != "" {
 		delete(pr.discordIDs, acc.DiscordID)
@@ -217,8 +206,9 @@ func (pr *PlayerRegistry) saveLocked() {
 		saved = append(saved, savedAccount{
 			Name:      acc.Name,
 			DiscordID: acc.DiscordID,
-			APIKey:    acc.APIKey,
 			PlayerID:  acc.PlayerID,
+			Keys:      acc.Keys,
+			Webhooks:  acc.Webhooks,
 		})
 	}
 	data, err := json.MarshalIndent(saved, "", "  ")
@@ -246,22 +236,41 @@ func (pr *PlayerRegistry) load() {
 		fmt.Printf("[Auth] Failed to parse accounts file: %v\n", err)
 		return
 	}
+	migrated := 0
 	for _, s := range saved {
 		acc := &PlayerAccount{
 			Name:      s.Name,
 			DiscordID: s.DiscordID,
-			APIKey:    s.APIKey,
 			PlayerID:  s.PlayerID,
+			Webhooks:  s.Webhooks,
 		}
-		pr.accounts[strings.ToLower(s.Name)] = acc
+		for _, k := range s.Keys {
+			pr.addKeyLocked(acc, k)
+		}
+		// Plaintext keys from older files become the hashed default key
 		if s.APIKey != "" {
-			pr.keys[s.APIKey] = acc
+			key := &APIKey{
+				ID:        "k" + hashAPIKey(s.APIKey)[:8],
+				Name:      DefaultKeyName,
+				Hash:      hashAPIKey(s.APIKey),
+				Prefix:    keyPrefix(s.APIKey),
+				Scopes:    []string{ScopeAll},
+				CreatedAt: time.Now().UTC(),
+			}
+			pr.addKeyLocked(acc, key)
+			acc.APIKey = s.APIKey
+			migrated++
 		}
+		pr.accounts[strings.ToLower(s.Name)] = acc
 		if s.DiscordID != "" {
 			pr.discordIDs[s.DiscordID] = acc
 		}
 	}
 	fmt.Printf("[Auth] Loaded %d accounts from %s\n", len(pr.accounts), pr.filePath)
+	if migrated > 0 {
+		pr.saveLocked()
+		fmt.Printf("[Auth] Hashed %d plaintext API keys\n", migrated)
+	}
 }
 
 func generateAPIKey() string {
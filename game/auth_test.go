@@ -7,6 +7,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 )
 
 func newTestRegistry(t *testing.T, adminKey string) *PlayerRegistry {
@@ -265,7 +266,7 @@ func TestPersistenceFileFormat(t *testing.T) {
 		discordIDs: make(map[string]*PlayerAccount),
 		filePath:   fp,
 	}
-	pr.FindOrCreateByDiscord("123456", "Alice")
+	acc, _, _ := pr.FindOrCreateByDiscord("123456", "Alice")
 
 	data, err := os.ReadFile(fp)
 	if err != nil {
@@ -282,8 +283,11 @@ func TestPersistenceFileFormat(t *testing.T) {
 	if saved[0].DiscordID != "123456" {
 		t.Errorf("expected discord ID 123456, got %s", saved[0].DiscordID)
 	}
-	if !strings.HasPrefix(saved[0].APIKey, "xk-") {
-		t.Errorf("expected API key prefix xk-, got %s", saved[0].APIKey)
+	if saved[0].APIKey != "" || strings.Contains(string(data), acc.APIKey) {
+		t.Error("raw API key should never be written to disk")
+	}
+	if len(saved[0].Keys) != 1 || saved[0].Keys[0].Hash != hashAPIKey(acc.APIKey) {
+		t.Errorf("expected the hashed default key on disk, got %+v", saved[0].Keys)
 	}
 }
 
@@ -369,6 +373,139 @@ func TestPersistenceAutoSaveOnCreate(t *testing.T) {
 	}
 }
 
+// --- Scoped keys ---
+
+func TestScopedKeys(t *testing.T) {
+	pr := newTestRegistry(t, "")
+	pr.FindOrCreateByDiscord("123", "Alice")
+
+	raw, key, err := pr.CreateKey("alice", "dashboard", nil, time.Time{})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	if len(key.Scopes) != 1 || key.Scopes[0] != ScopeRead {
+		t.Errorf("expected a key with no scopes to be read-only, got %v", key.Scopes)
+	}
+	grant, ok := pr.AuthenticateKey(raw)
+	if !ok || grant.Player != "Alice" || grant.KeyName != "dashboard" {
+		t.Fatalf("expected dashboard key to authenticate as Alice, got %+v", grant)
+	}
+	if !grant.Allows(ScopeRead) || grant.Allows(ScopeTrade) {
+		t.Error("read-only key should allow read and nothing else")
+	}
+
+	if _, _, err := pr.CreateKey("alice", "Dashboard", nil, time.Time{}); err == nil {
+		t.Error("expected duplicate key names to be rejected")
+	}
+	if _, _, err := pr.CreateKey("alice", "bot", []string{"launch"}, time.Time{}); err == nil {
+		t.Error("expected unknown scopes to be rejected")
+	}
+
+	botRaw, _, err := pr.CreateKey("alice", "bot", []string{"trade", "fleet"}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("create bot key: %v", err)
+	}
+	bot, _ := pr.AuthenticateKey(botRaw)
+	if !bot.Allows(ScopeTrade) || !bot.Allows(ScopeFleet) || bot.Allows(ScopeBuild) || bot.Allows(ScopeAll) {
+		t.Errorf("unexpected bot grant %v", bot.Scopes)
+	}
+
+	// Expired keys stop authenticating
+	pr.mu.Lock()
+	pr.accounts["alice"].Keys[2].ExpiresAt = time.Now().Add(-time.Second)
+	pr.mu.Unlock()
+	if _, ok := pr.AuthenticateKey(botRaw); ok {
+		t.Error("expired key should not authenticate")
+	}
+
+	if err := pr.RevokeKey("alice", key.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, ok := pr.AuthenticateKey(raw); ok {
+		t.Error("revoked key should not authenticate")
+	}
+	keys, _ := pr.ListKeys("alice")
+	if len(keys) != 2 || keys[0].Name != DefaultKeyName || keys[1].Name != "bot" {
+		t.Errorf("expected default and bot keys to remain, got %+v", keys)
+	}
+}
+
+func TestObserverKeys(t *testing.T) {
+	pr := newTestRegistry(t, "")
+	pr.FindOrCreateByDiscord("123", "Alice")
+
+	if _, _, err := pr.CreateKey("alice", "caster", []string{"observe", "trade"}, time.Time{}); err == nil {
+		t.Error("expected observe combined with other scopes to be rejected")
+	}
+	raw, _, err := pr.CreateKey("alice", "caster", []string{"observe"}, time.Time{})
+	if err != nil {
+		t.Fatalf("create observer key: %v", err)
+	}
+	grant, _ := pr.AuthenticateKey(raw)
+	if !grant.Observer() || !grant.Allows(ScopeRead) || grant.Allows(ScopeTrade) {
+		t.Errorf("unexpected observer grant %v", grant.Scopes)
+	}
+
+	// Full access doesn't include spectating; the admin key does
+	full, _ := pr.AuthenticateKey(pr.GetAccount("Alice").APIKey)
+	if full.Observer() || full.Allows(ScopeObserve) {
+		t.Error("a full-access player key should not get the spectator feed")
+	}
+	if admin := (KeyGrant{Admin: true}); admin.Observer() || !admin.Allows(ScopeObserve) {
+		t.Error("admins should reach the spectator feed without being observers")
+	}
+}
+
+func TestLoginKeyRotatesAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "accounts.json")
+	pr1 := newTestRegistry(t, "")
+	pr1.filePath = fp
+	acc, _, _ := pr1.FindOrCreateByDiscord("123", "Alice")
+	first := acc.APIKey
+	if pr1.LoginKey(acc) != first {
+		t.Error("login in the same process should reuse the default key")
+	}
+	botRaw, _, _ := pr1.CreateKey("Alice", "bot", []string{ScopeTrade}, time.Time{})
+
+	pr2 := newTestRegistry(t, "")
+	pr2.filePath = fp
+	pr2.load()
+	if _, ok := pr2.AuthenticateKey(first); !ok {
+		t.Fatal("default key should survive a restart")
+	}
+	acc2 := pr2.GetAccount("alice")
+	second := pr2.LoginKey(acc2)
+	if second == first {
+		t.Fatal("expected a new default key after restart")
+	}
+	if _, ok := pr2.AuthenticateKey(first); ok {
+		t.Error("rotated default key should stop authenticating")
+	}
+	if _, ok := pr2.AuthenticateKey(botRaw); !ok {
+		t.Error("named keys should be unaffected by login")
+	}
+}
+
+func TestLegacyPlaintextKeysAreHashed(t *testing.T) {
+	dir := t.TempDir()
+	fp := filepath.Join(dir, "accounts.json")
+	legacy := []savedAccount{{Name: "Alice", DiscordID: "123", APIKey: "xk-legacy", PlayerID: 1}}
+	data, _ := json.Marshal(legacy)
+	os.WriteFile(fp, data, 0600)
+
+	pr := newTestRegistry(t, "")
+	pr.filePath = fp
+	pr.load()
+	if name, _, ok := pr.Authenticate("xk-legacy"); !ok || name != "Alice" {
+		t.Fatal("legacy key should still authenticate")
+	}
+	data, _ = os.ReadFile(fp)
+	if strings.Contains(string(data), "xk-legacy") {
+		t.Error("legacy key should be rewritten hashed")
+	}
+}
+
 // --- Concurrency ---
 
 func TestConcurrentOperations(t *testing.T) {
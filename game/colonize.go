@@ -2,6 +2,7 @@ package game
 
 import (
 	"fmt"
+	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,8 +28,8 @@ func TransferPlanetOwnership(planet *entities.Planet, oldOwner, newOwner *entiti
 // ColonizePlanet transfers ownership of an unclaimed planet to a player,
 // sets up colony infrastructure, and consumes the colony ship's colonists.
 // This is the single source of truth for colonization — used by both player
-// commands and AI logistics.
-func ColonizePlanet(planet *entities.Planet, ship *entities.Ship, player *entities.Player, systemID int) {
+// commands and AI logistics. New deposits and buildings are drawn from rng.
+func ColonizePlanet(planet *entities.Planet, ship *entities.Ship, player *entities.Player, systemID int, rng *rand.Rand) {
 	TransferPlanetOwnership(planet, nil, player)
 	planet.Population = int64(ship.Colonists)
 
@@ -43,18 +44,18 @@ func ColonizePlanet(planet *entities.Planet, ship *entities.Ship, player *entiti
 	planet.TechLevel = maxTech * 0.5
 
 	// Ensure essential deposits exist for self-sustaining colony
-	EnsureResourceDeposit(planet, entities.ResWater, player.Name)     // survival
-	EnsureResourceDeposit(planet, entities.ResIron, player.Name)      // construction
-	EnsureResourceDeposit(planet, entities.ResOil, player.Name)       // Refinery → Fuel → Generator
-	EnsureResourceDeposit(planet, entities.ResRareMetals, player.Name) // Factory
-	EnsureResourceDeposit(planet, entities.ResHelium3, player.Name)   // Fusion Reactor
+	EnsureResourceDeposit(planet, entities.ResWater, player.Name, rng)     // survival
+	EnsureResourceDeposit(planet, entities.ResIron, player.Name, rng)      // construction
+	EnsureResourceDeposit(planet, entities.ResOil, player.Name, rng)       // Refinery → Fuel → Generator
+	EnsureResourceDeposit(planet, entities.ResRareMetals, player.Name, rng) // Factory
+	EnsureResourceDeposit(planet, entities.ResHelium3, player.Name, rng)   // Fusion Reactor
 
 	// Build infrastructure
-	AddBuildingToPlanet(planet, entities.BuildingTradingPost, player.Name, systemID)
-	AddBuildingToPlanet(planet, entities.BuildingRefinery, player.Name, systemID)
-	AddBuildingToPlanet(planet, entities.BuildingGenerator, player.Name, systemID)
-	BuildMinesOnResources(planet, player.Name, systemID)
-	SeedInitialCommodities(planet, player.Name)
+	AddBuildingToPlanet(planet, entities.BuildingTradingPost, player.Name, systemID, rng)
+	AddBuildingToPlanet(planet, entities.BuildingRefinery, player.Name, systemID, rng)
+	AddBuildingToPlanet(planet, entities.BuildingGenerator, player.Name, systemID, rng)
+	BuildMinesOnResources(planet, player.Name, systemID, rng)
+	SeedInitialCommodities(planet, player.Name, rng)
 
 	// Starting resources — enough to bootstrap before mining kicks in
 	planet.AddStoredResource(entities.ResFuel, 100)
@@ -0,0 +1,131 @@
+package game
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// Defaults for a new game's setup.
+const (
+	DefaultSystemCount     = 40
+	DefaultHyperlanes      = 3
+	DefaultStartingCredits = 8000
+	DefaultTicksPerSecond  = 10.0
+)
+
+// Limits on a GameConfig, so a lobby request can't ask for a galaxy the
+// generator can't place or a tick rate the simulation can't keep up with.
+const (
+	minSystemCount    = 2
+	maxSystemCount    = 200
+	maxHyperlaneCount = 10
+	maxAIPlayers      = 32
+	maxTicksPerSecond = 100.0
+)
+
+// AIFaction names an AI faction and describes how it should play. The
+// personality is a brief for whatever drives the faction (e.g. cmd/agent).
+type AIFaction struct {
+	Name        string `json:"name"`
+	Personality string `json:"personality,omitempty"`
+}
+
+// GameConfig is the match setup for a new game. It's saved with the game
+// and shown in /api/game. Decoding JSON starts from DefaultGameConfig, so
+// a request only needs the fields it changes.
+type GameConfig struct {
+	Seed            int64       `json:"seed,omitempty"`             // galaxy seed (0 = time-based)
+	Systems         int         `json:"systems"`                    // star systems in the galaxy
+	Hyperlanes      int         `json:"hyperlanes"`                 // max hyperlanes opened from each system
+	AIPlayers       int         `json:"ai_players"`                 // AI factions seeded at start
+	AIFactions      []AIFaction `json:"ai_factions,omitempty"`      // names and personalities, in seeding order
+	StartingCredits int         `json:"starting_credits"`           // credits every faction starts with
+	DisabledSystems []string    `json:"disabled_systems,omitempty"` // tickable systems that don't run
+	Victory         []string    `json:"victory,omitempty"`          // victory conditions in play (empty = all)
+	TicksPerSecond  float64     `json:"ticks_per_second"`           // simulation ticks per second at 1x
+}
+
+// DefaultGameConfig returns the standard setup: a 40-system galaxy with
+// five AI factions and every tickable system and victory condition.
+func DefaultGameConfig() GameConfig {
+	return GameConfig{
+		Systems:         DefaultSystemCount,
+		Hyperlanes:      DefaultHyperlanes,
+		AIPlayers:       DefaultAIPlayerCount,
+		AIFactions:      append([]AIFaction(nil), DefaultAIFactions...),
+		StartingCredits: DefaultStartingCredits,
+		TicksPerSecond:  DefaultTicksPerSecond,
+	}
+}
+
+// UnmarshalJSON decodes over DefaultGameConfig, so omitted fields keep
+// their defaults while explicit zeros (e.g. "ai_players": 0) stick.
+func (c *GameConfig) UnmarshalJSON(data []byte) error {
+	type plain GameConfig
+	cfg := plain(DefaultGameConfig())
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	*c = GameConfig(cfg)
+	return nil
+}
+
+// Validate checks the config is within limits and only names tickable
+// systems and victory conditions that exist.
+func (c GameConfig) Validate() error {
+	if c.Systems < minSystemCount || c.Systems > maxSystemCount {
+		return fmt.Errorf("systems must be between %d and %d", minSystemCount, maxSystemCount)
+	}
+	if c.Hyperlanes < 1 || c.Hyperlanes > maxHyperlaneCount {
+		return fmt.Errorf("hyperlanes must be between 1 and %d", maxHyperlaneCount)
+	}
+	if c.AIPlayers < 0 || c.AIPlayers > maxAIPlayers {
+		return fmt.Errorf("ai_players must be between 0 and %d", maxAIPlayers)
+	}
+	if c.StartingCredits < 0 {
+		return fmt.Errorf("starting_credits can't be negative")
+	}
+	if c.TicksPerSecond <= 0 || c.TicksPerSecond > maxTicksPerSecond {
+		return fmt.Errorf("ticks_per_second must be above 0 and at most %g", maxTicksPerSecond)
+	}
+
+	names := make(map[string]bool)
+	for _, f := range c.AIFactions {
+		name := strings.TrimSpace(f.Name)
+		if name == "" {
+			return fmt.Errorf("AI faction names can't be blank")
+		}
+		if names[name] {
+			return fmt.Errorf("AI faction %q listed twice", name)
+		}
+		names[name] = true
+	}
+
+	for _, name := range c.DisabledSystems {
+		if tickable.GetSystemByName(name) == nil {
+			return fmt.Errorf("unknown tickable system %q", name)
+		}
+	}
+	known := make(map[string]bool)
+	for _, name := range tickable.VictoryConditions() {
+		known[name] = true
+	}
+	for _, name := range c.Victory {
+		if !known[name] {
+			return fmt.Errorf("unknown victory condition %q (have %s)", name, strings.Join(tickable.VictoryConditions(), ", "))
+		}
+	}
+	return nil
+}
+
+// AIFactionAt returns the ith AI faction to seed. Factions past the end
+// of AIFactions get a numbered name and no personality.
+func (c GameConfig) AIFactionAt(i int) AIFaction {
+	if i < len(c.AIFactions) {
+		return c.AIFactions[i]
+	}
+	return AIFaction{Name: fmt.Sprintf("Frontier Syndicate %d", i+1)}
+}
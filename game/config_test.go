@@ -0,0 +1,62 @@
+package game
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestGameConfigDecodesOverDefaults(t *testing.T) {
+	var cfg GameConfig
+	if err := json.Unmarshal([]byte(`{"systems": 12, "ai_players": 0}`), &cfg); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if cfg.Systems != 12 || cfg.AIPlayers != 0 {
+		t.Errorf("explicit fields not applied: %+v", cfg)
+	}
+	if cfg.Hyperlanes != DefaultHyperlanes || cfg.StartingCredits != DefaultStartingCredits ||
+		cfg.TicksPerSecond != DefaultTicksPerSecond || len(cfg.AIFactions) != len(DefaultAIFactions) {
+		t.Errorf("omitted fields lost their defaults: %+v", cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected valid config, got %v", err)
+	}
+}
+
+func TestGameConfigValidate(t *testing.T) {
+	cases := map[string]func(*GameConfig){
+		"too few systems":   func(c *GameConfig) { c.Systems = 1 },
+		"no hyperlanes":     func(c *GameConfig) { c.Hyperlanes = 0 },
+		"negative AI":       func(c *GameConfig) { c.AIPlayers = -1 },
+		"negative credits":  func(c *GameConfig) { c.StartingCredits = -5 },
+		"stopped clock":     func(c *GameConfig) { c.TicksPerSecond = 0 },
+		"blank faction":     func(c *GameConfig) { c.AIFactions = []AIFaction{{Name: " "}} },
+		"duplicate faction": func(c *GameConfig) { c.AIFactions = []AIFaction{{Name: "A"}, {Name: "A"}} },
+		"unknown system":    func(c *GameConfig) { c.DisabledSystems = []string{"NoSuchSystem"} },
+		"unknown victory":   func(c *GameConfig) { c.Victory = []string{"Karaoke"} },
+	}
+	for name, mutate := range cases {
+		cfg := DefaultGameConfig()
+		mutate(&cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	cfg := DefaultGameConfig()
+	cfg.DisabledSystems = []string{"Construction"}
+	cfg.Victory = []string{"Economic", "Trade"}
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("expected known names to pass, got %v", err)
+	}
+}
+
+func TestGameConfigAIFactionAt(t *testing.T) {
+	cfg := DefaultGameConfig()
+	cfg.AIFactions = []AIFaction{{Name: "Solo Traders", Personality: "cautious"}}
+	if f := cfg.AIFactionAt(0); f.Name != "Solo Traders" || f.Personality != "cautious" {
+		t.Errorf("unexpected first faction %+v", f)
+	}
+	if f := cfg.AIFactionAt(2); f.Name != "Frontier Syndicate 3" || f.Personality != "" {
+		t.Errorf("unexpected generated faction %+v", f)
+	}
+}
@@ -13,6 +13,7 @@ type ConstructionHandler struct {
 	systems     []*entities.System
 	players     []*entities.Player
 	tickManager TickManagerInterface
+	randSource  *tickable.RandSource
 }
 
 // TickManagerInterface provides access to tick information
@@ -20,12 +21,14 @@ type TickManagerInterface interface {
 	GetCurrentTick() int64
 }
 
-// NewConstructionHandler creates a new construction handler
-func NewConstructionHandler(systems []*entities.System, players []*entities.Player, tickManager TickManagerInterface) *ConstructionHandler {
+// NewConstructionHandler creates a new construction handler. New ship and
+// building IDs are drawn from the game's randSource.
+func NewConstructionHandler(systems []*entities.System, players []*entities.Player, tickManager TickManagerInterface, randSource *tickable.RandSource) *ConstructionHandler {
 	return &ConstructionHandler{
 		systems:     systems,
 		players:     players,
 		tickManager: tickManager,
+		randSource:  randSource,
 	}
 }
 
@@ -122,7 +125,7 @@ func (ch *ConstructionHandler) handleShipConstruction(completion tickable.Constr
 	shipType := entities.ShipType(completion.Item.Name)
 
 	// Generate unique ship ID
-	shipID := int(ch.tickManager.GetCurrentTick())*1000 + rand.Intn(1000)
+	shipID := int(ch.tickManager.GetCurrentTick())*1000 + ch.rng().Intn(1000)
 
 	// Find owner player
 	var owner *entities.Player
@@ -160,6 +163,12 @@ func (ch *ConstructionHandler) handleShipConstruction(completion tickable.Constr
 		ship.OrbitDistance, ship.OrbitAngle, targetPlanet.OrbitDistance, targetPlanet.OrbitAngle)
 }
 
+// rng returns the handler's stream from the game's RandSource for the
+// current tick.
+func (ch *ConstructionHandler) rng() *rand.Rand {
+	return ch.randSource.Get(ch.tickManager.GetCurrentTick(), "ConstructionHandler")
+}
+
 // createBuildingFromCompletion creates a building entity from a completion
 func (ch *ConstructionHandler) createBuildingFromCompletion(completion tickable.ConstructionCompletion, attachedTo entities.Entity) entities.Entity {
 	// Generate parameters for building
@@ -168,6 +177,7 @@ func (ch *ConstructionHandler) createBuildingFromCompletion(completion tickable.
 		OrbitDistance: 20.0 + float64(len(ch.systems))*5.0, // Position around planet
 		OrbitAngle:    float64(completion.Tick%628) / 100.0,
 		SystemSeed:    completion.Tick,
+		Rand:          ch.rng(),
 	}
 
 	// Get the appropriate building generator based on item name
@@ -28,6 +28,7 @@ type GameEvent struct {
 	Player    string    `json:"player"`
 	Message   string    `json:"message"`
 	Timestamp time.Time `json:"timestamp"`
+	RequestID string    `json:"request_id,omitempty"` // API request whose command caused the event
 }
 
 // EventLog is a thread-safe ring buffer of recent events with subscriber support.
@@ -36,6 +37,7 @@ type EventLog struct {
 	events    []GameEvent
 	max       int
 	listeners []func(GameEvent)
+	requestID string // stamped on events added while a command runs
 }
 
 // NewEventLog creates a new event log.
@@ -57,6 +59,14 @@ func (el *EventLog) Subscribe(fn func(GameEvent)) {
 	el.listeners = append(el.listeners, fn)
 }
 
+// SetRequestID tags every event added from now on with id, until it's
+// cleared with "". The server sets it around each command it executes.
+func (el *EventLog) SetRequestID(id string) {
+	el.mu.Lock()
+	el.requestID = id
+	el.mu.Unlock()
+}
+
 // Add records a new event and notifies subscribers.
 func (el *EventLog) Add(tick int64, gameTime string, eventType EventType, player string, msg string) {
 	el.mu.Lock()
@@ -67,6 +77,7 @@ func (el *EventLog) Add(tick int64, gameTime string, eventType EventType, player
 		Player:    player,
 		Message:   msg,
 		Timestamp: time.Now(),
+		RequestID: el.requestID,
 	}
 	el.events = append(el.events, ev)
 	if len(el.events) > el.max {
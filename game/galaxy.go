@@ -10,8 +10,6 @@ import (
 )
 
 const (
-	systemCount      = 40
-	maxHyperlanes    = 3
 	minDistance      = 60.0
 	maxDistance      = 180.0
 	minSystemSpacing = 45.0
@@ -19,26 +17,42 @@ const (
 
 // GalaxyGenerator handles galaxy and system generation
 type GalaxyGenerator struct {
-	screenWidth  int
-	screenHeight int
+	screenWidth   int
+	screenHeight  int
+	systemCount   int
+	maxHyperlanes int
 }
 
-// NewGalaxyGenerator creates a new galaxy generator
+// NewGalaxyGenerator creates a new galaxy generator for the default galaxy size
 func NewGalaxyGenerator(screenWidth, screenHeight int) *GalaxyGenerator {
 	return &GalaxyGenerator{
-		screenWidth:  screenWidth,
-		screenHeight: screenHeight,
+		screenWidth:   screenWidth,
+		screenHeight:  screenHeight,
+		systemCount:   DefaultSystemCount,
+		maxHyperlanes: DefaultHyperlanes,
 	}
 }
 
+// NewGalaxyGeneratorFor creates a galaxy generator sized by cfg
+func NewGalaxyGeneratorFor(screenWidth, screenHeight int, cfg GameConfig) *GalaxyGenerator {
+	gg := NewGalaxyGenerator(screenWidth, screenHeight)
+	if cfg.Systems > 0 {
+		gg.systemCount = cfg.Systems
+	}
+	if cfg.Hyperlanes > 0 {
+		gg.maxHyperlanes = cfg.Hyperlanes
+	}
+	return gg
+}
+
 // GenerateSystems creates systems at random coordinates
 func (gg *GalaxyGenerator) GenerateSystems(seed int64) []*entities.System {
 	rng := rand.New(rand.NewSource(seed))
-	systems := make([]*entities.System, 0, systemCount)
+	systems := make([]*entities.System, 0, gg.systemCount)
 	colors := getSystemColors()
 
 	// Generate systems with random positions
-	for i := 0; i < systemCount; i++ {
+	for i := 0; i < gg.systemCount; i++ {
 		var x, y float64
 		var validPosition bool
 		attempts := 0
@@ -117,8 +131,8 @@ func (gg *GalaxyGenerator) GenerateHyperlanes(systems []*entities.System) []enti
 		}
 
 		// Connect to closest systems (max connections per system)
-		connectionsToMake := maxHyperlanes
-		if len(nearbySystemsWithDistance) < maxHyperlanes {
+		connectionsToMake := gg.maxHyperlanes
+		if len(nearbySystemsWithDistance) < gg.maxHyperlanes {
 			connectionsToMake = len(nearbySystemsWithDistance)
 		}
 
@@ -26,6 +26,7 @@ type State struct {
 	Players        []*entities.Player
 	HumanPlayer    *entities.Player
 	Seed           int64
+	Config         GameConfig // match setup the game was created with
 	Market         *economy.Market
 	TradeExec      *economy.TradeExecutor
 	Commands       chan GameCommand
@@ -75,6 +76,7 @@ const (
 	CmdSave               CommandType = "save"
 	CmdSetSpeed           CommandType = "set_speed"
 	CmdTogglePause        CommandType = "toggle_pause"
+	CmdSetSchedule        CommandType = "set_schedule"
 	CmdTrade              CommandType = "trade"
 	CmdCargoLoad          CommandType = "cargo_load"
 	CmdCargoUnload        CommandType = "cargo_unload"
@@ -89,6 +91,10 @@ const (
 	CmdCancelConstruction CommandType = "cancel_construction"
 	CmdStandingOrder      CommandType = "standing_order"
 	CmdCancelOrder        CommandType = "cancel_order"
+	CmdLimitOrder         CommandType = "limit_order"
+	CmdCancelLimitOrder   CommandType = "cancel_limit_order"
+	CmdFuturesOpen        CommandType = "futures_open"
+	CmdFuturesClose       CommandType = "futures_close"
 	CmdFleetMove          CommandType = "fleet_move"
 	CmdFleetCreate        CommandType = "fleet_create"
 	CmdFleetDisband       CommandType = "fleet_disband"
@@ -100,8 +106,42 @@ const (
 	CmdBuyAtDock          CommandType = "buy_at_dock"
 	CmdDemolish           CommandType = "demolish"
 	CmdTransferFuel       CommandType = "transfer_fuel"
+	CmdEspionage          CommandType = "espionage"
+	CmdPostBounty         CommandType = "post_bounty"
+	CmdBlackMarket        CommandType = "black_market"
+	CmdBatch              CommandType = "batch"
 )
 
+// MaxBatchSteps caps how many commands one batch may carry.
+const MaxBatchSteps = 50
+
+// BatchCommandData is the payload for running several commands back to back
+// on the simulation goroutine, within the same tick.
+type BatchCommandData struct {
+	Steps  []BatchStep
+	Atomic bool // stop at the first failure and roll back the steps before it
+}
+
+// BatchStep is one command in a batch. It runs as the batch's player.
+type BatchStep struct {
+	Type CommandType
+	Data interface{}
+}
+
+// BatchResult is the result of a batch command: one entry per step, in order.
+type BatchResult struct {
+	Steps      []BatchStepResult
+	RolledBack bool // an atomic batch failed and its earlier steps were undone
+}
+
+// BatchStepResult is what one step returned. Steps after a failure in an
+// atomic batch don't run and have Skipped set.
+type BatchStepResult struct {
+	Result  interface{}
+	Err     error
+	Skipped bool
+}
+
 // TransferFuelCommandData is the payload for ship-to-ship fuel transfer.
 type TransferFuelCommandData struct {
 	FromShipID int // ship donating fuel
@@ -109,12 +149,27 @@ type TransferFuelCommandData struct {
 	Amount     int // fuel units to transfer (0 = fill up target)
 }
 
+// SetScheduleCommandData is the payload for changing a tickable system's
+// cadence.
+type SetScheduleCommandData struct {
+	System   string
+	Interval int64 // ticks between runs (0 or 1 = every tick)
+	Phase    int64 // offset within the interval; negative = let the registry choose
+}
+
 // GameCommand represents a command to be executed on the main goroutine.
 type GameCommand struct {
 	Type       CommandType
 	Data       interface{}
 	Result     chan interface{} // optional: for synchronous API responses
 	PlayerName string          // authenticated player name (for multiplayer scoping)
+
+	// RequestID identifies the API request that issued the command; it's
+	// stamped on the events the command logs.
+	RequestID string
+	// IdempotencyKey, if set, makes a resubmission by the same player return
+	// the first submission's result instead of running again.
+	IdempotencyKey string
 }
 
 // TradeCommandData is the shared trade command payload used by both API and UI.
@@ -206,6 +261,47 @@ type CancelOrderCommandData struct {
 	OrderID int
 }
 
+// LimitOrderCommandData is the payload for placing a limit order on a
+// system's order book.
+type LimitOrderCommandData struct {
+	SystemID int
+	PlanetID int // delivery planet for a buy, source planet for a sell
+	Resource string
+	Action   string // "buy" or "sell"
+	Quantity int
+	Price    int // limit price (for a stop buy, the most it will pay)
+
+	Type        string // economy.OrderLimit (default), OrderStop, OrderStopLimit, ...
+	StopPrice   int    // trigger price for stop and take-profit orders
+	TimeInForce string // "gtc" (default), "ioc" or "fok"
+	ExpiresAt   int64  // tick the order lapses at (0 = never)
+	Display     int    // iceberg: quantity shown at a time (0 = all)
+}
+
+// CancelLimitOrderCommandData is the payload for cancelling a limit order.
+type CancelLimitOrderCommandData struct {
+	OrderID int
+}
+
+// FuturesOpenCommandData is the payload for opening a futures or option
+// position on the futures exchange.
+type FuturesOpenCommandData struct {
+	Kind       string // economy.KindFuture, KindCall or KindPut
+	Side       string // futures: "long" or "short"
+	Resource   string
+	Quantity   int
+	Term       int64   // ticks until expiry
+	Strike     float64 // options only
+	Settlement string  // futures: economy.SettleCash (default) or SettlePhysical
+	SystemID   int     // physical settlement planet
+	PlanetID   int
+}
+
+// FuturesCloseCommandData is the payload for closing a position early.
+type FuturesCloseCommandData struct {
+	PositionID int
+}
+
 // FleetMoveCommandData is the payload for moving a fleet to another system.
 type FleetMoveCommandData struct {
 	FleetID        int // fleet to move
@@ -258,6 +354,40 @@ type DemolishCommandData struct {
 	BuildingIndex int // index in planet.Buildings array
 }
 
+// EspionageCommandData is the payload for launching a spy operation.
+type EspionageCommandData struct {
+	Target   string // faction to spy on
+	Type     string // "intel", "sabotage" or "steal_tech"
+	SystemID int
+}
+
+// PostBountyCommandData is the payload for posting a bounty. The reward is
+// escrowed from the poster's credits.
+type PostBountyCommandData struct {
+	Type        string
+	Description string
+	Reward      int
+	Resource    string
+	Quantity    int
+	PlanetID    int
+	SystemID    int
+}
+
+// BlackMarketCommandData is the payload for a black market trade.
+type BlackMarketCommandData struct {
+	Buy      bool
+	Resource string
+	Quantity int
+	PlanetID int // owned planet the goods come from or go to
+}
+
+// BlackMarketOutcome is the result of a black market command.
+type BlackMarketOutcome struct {
+	Seized  bool // a sale's goods were confiscated
+	Credits int  // sale proceeds
+	Cost    int  // purchase price
+}
+
 // NewState creates a new empty game state
 func NewState() *State {
 	return &State{
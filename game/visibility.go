@@ -0,0 +1,87 @@
+package game
+
+import "github.com/hunterjsb/xandaris/entities"
+
+// Visibility is what one player can observe of other factions. Sensors (an
+// owned planet, station or ship in a system) cover that system and its
+// hyperlane neighbours; a successful intel op reveals everything the target
+// owns, wherever it is. The galaxy itself — systems, lanes, planets and
+// their deposits — is charted and always visible.
+//
+// A nil *Visibility sees everything; it's what admins get.
+type Visibility struct {
+	Player   string
+	systems  map[int]bool
+	revealed map[string]bool
+}
+
+// ComputeVisibility works out player's sensor coverage and the factions
+// revealed to them (intel targets, as reported by the espionage manager).
+func ComputeVisibility(player string, systems []*entities.System, hyperlanes []entities.Hyperlane, players []*entities.Player, revealed []string) *Visibility {
+	v := &Visibility{
+		Player:   player,
+		systems:  make(map[int]bool),
+		revealed: map[string]bool{player: true},
+	}
+	for _, name := range revealed {
+		v.revealed[name] = true
+	}
+	if player == "" {
+		return v
+	}
+
+	present := make(map[int]bool)
+	for _, sys := range systems {
+		for _, e := range sys.Entities {
+			switch ent := e.(type) {
+			case *entities.Planet:
+				if ent.Owner == player {
+					present[sys.ID] = true
+				}
+			case *entities.Station:
+				if ent.Owner == player {
+					present[sys.ID] = true
+				}
+			}
+		}
+	}
+	for _, p := range players {
+		if p == nil || p.Name != player {
+			continue
+		}
+		for _, ship := range p.OwnedShips {
+			if ship != nil {
+				present[ship.CurrentSystem] = true
+			}
+		}
+	}
+
+	for id := range present {
+		v.systems[id] = true
+	}
+	for _, hl := range hyperlanes {
+		if present[hl.From] {
+			v.systems[hl.To] = true
+		}
+		if present[hl.To] {
+			v.systems[hl.From] = true
+		}
+	}
+	return v
+}
+
+// SeesSystem reports whether the player has sensor coverage of a system.
+func (v *Visibility) SeesSystem(systemID int) bool {
+	return v == nil || v.systems[systemID]
+}
+
+// Knows reports whether a faction's holdings are fully visible: the
+// player's own, or an intel target's. Unowned things have nothing to hide.
+func (v *Visibility) Knows(faction string) bool {
+	return v == nil || faction == "" || v.revealed[faction]
+}
+
+// Sees reports whether something owned by faction in a system is visible.
+func (v *Visibility) Sees(faction string, systemID int) bool {
+	return v.Knows(faction) || v.SeesSystem(systemID)
+}
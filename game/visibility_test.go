@@ -0,0 +1,57 @@
+package game
+
+import (
+	"testing"
+
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+// Four systems in a line: 1 - 2 - 3 - 4. Alpha owns a planet in 1 and has a
+// ship in 4; Beta owns planets in 2 and 3.
+func visibilityGalaxy() ([]*entities.System, []entities.Hyperlane, []*entities.Player) {
+	systems := []*entities.System{
+		{ID: 1, Entities: entities.EntityList{&entities.Planet{Owner: "Alpha"}}},
+		{ID: 2, Entities: entities.EntityList{&entities.Planet{Owner: "Beta"}}},
+		{ID: 3, Entities: entities.EntityList{&entities.Planet{Owner: "Beta"}}},
+		{ID: 4},
+		{ID: 5},
+	}
+	lanes := []entities.Hyperlane{{From: 1, To: 2}, {From: 2, To: 3}, {From: 3, To: 4}}
+	alpha := &entities.Player{Name: "Alpha", OwnedShips: []*entities.Ship{{CurrentSystem: 4}}}
+	return systems, lanes, []*entities.Player{alpha, {Name: "Beta"}}
+}
+
+func TestVisibilitySensorCoverage(t *testing.T) {
+	systems, lanes, players := visibilityGalaxy()
+	v := ComputeVisibility("Alpha", systems, lanes, players, nil)
+
+	for id, want := range map[int]bool{1: true, 2: true, 3: true, 4: true, 5: false} {
+		if got := v.SeesSystem(id); got != want {
+			t.Errorf("SeesSystem(%d) = %v, want %v", id, got, want)
+		}
+	}
+	if !v.Knows("Alpha") || v.Knows("Beta") || !v.Knows("") {
+		t.Error("Alpha should know only itself and unowned things")
+	}
+	if !v.Sees("Beta", 2) || v.Sees("Beta", 5) {
+		t.Error("Beta's holdings should be visible only in sensor range")
+	}
+}
+
+func TestVisibilityIntelAndAdmin(t *testing.T) {
+	systems, lanes, players := visibilityGalaxy()
+	v := ComputeVisibility("Alpha", systems, lanes, players, []string{"Beta"})
+	if !v.Knows("Beta") || !v.Sees("Beta", 5) {
+		t.Error("intel on Beta should reveal Beta's holdings anywhere")
+	}
+
+	anon := ComputeVisibility("", systems, lanes, players, nil)
+	if anon.SeesSystem(1) || anon.Knows("Alpha") {
+		t.Error("anonymous callers should see no faction holdings")
+	}
+
+	var admin *Visibility
+	if !admin.SeesSystem(5) || !admin.Knows("Beta") {
+		t.Error("nil visibility should see everything")
+	}
+}
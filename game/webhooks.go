@@ -0,0 +1,448 @@
+package game
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+const (
+	// MaxWebhooksPerPlayer caps the webhooks one account can register.
+	MaxWebhooksPerPlayer = 5
+	// webhookAttempts is how many times a delivery is tried before it's
+	// dead-lettered.
+	webhookAttempts = 5
+	// maxDeadLetters caps the failed deliveries kept for inspection; the
+	// oldest is dropped first.
+	maxDeadLetters   = 200
+	webhookQueueSize = 1024
+)
+
+// Webhook is a player's subscription to game events: each matching event
+// is POSTed to URL as JSON, signed with Secret (see SignWebhook). A webhook
+// receives the player's own events and galaxy-wide ones (no player).
+type Webhook struct {
+	ID        string      `json:"id"`
+	URL       string      `json:"url"`
+	Secret    string      `json:"secret"` // kept in full: it signs every delivery
+	Events    []EventType `json:"events"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Wants reports whether the webhook subscribes to an event type.
+func (h *Webhook) Wants(t EventType) bool {
+	for _, e := range h.Events {
+		if e == t {
+			return true
+		}
+	}
+	return false
+}
+
+// SignWebhook returns the X-Xandaris-Signature for a delivery body:
+// "sha256=" and the hex HMAC-SHA256 of the body under the webhook's secret.
+func SignWebhook(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// AllowPrivateWebhooks lets webhooks reach loopback, link-local and private
+// addresses. Webhook URLs are chosen by players, so by default the server
+// won't POST into its own network; set XANDARIS_WEBHOOK_ALLOW_PRIVATE=1 for
+// local development.
+var AllowPrivateWebhooks = os.Getenv("XANDARIS_WEBHOOK_ALLOW_PRIVATE") == "1"
+
+// sharedAddressSpace is the carrier-grade NAT range (RFC 6598), which
+// net.IP.IsPrivate doesn't cover.
+var sharedAddressSpace = &net.IPNet{IP: net.IPv4(100, 64, 0, 0), Mask: net.CIDRMask(10, 32)}
+
+// checkWebhookIP rejects addresses a webhook may not be delivered to.
+func checkWebhookIP(ip net.IP) error {
+	if AllowPrivateWebhooks {
+		return nil
+	}
+	if ip.IsLoopback() || ip.IsPrivate() || ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || sharedAddressSpace.Contains(ip) {
+		return fmt.Errorf("webhook address %s is not public", ip)
+	}
+	return nil
+}
+
+// validateWebhookURL only accepts absolute HTTPS URLs, and not ones naming
+// a non-public host outright. Hostnames are checked again on every
+// delivery, against the address they resolve to (see webhookDialer).
+func validateWebhookURL(raw string) (string, error) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil || u.Host == "" {
+		return "", fmt.Errorf("invalid webhook URL %q", raw)
+	}
+	if u.Scheme != "https" {
+		return "", fmt.Errorf("webhook URLs must use https")
+	}
+	host := strings.ToLower(u.Hostname())
+	if ip := net.ParseIP(host); ip != nil {
+		if err := checkWebhookIP(ip); err != nil {
+			return "", err
+		}
+	} else if !AllowPrivateWebhooks && (host == "localhost" || strings.HasSuffix(host, ".localhost")) {
+		return "", fmt.Errorf("webhook host %s is not public", host)
+	}
+	return u.String(), nil
+}
+
+// webhookDialer checks the address each delivery actually connects to, so a
+// hostname (or a redirect) can't point a webhook at the internal network.
+var webhookDialer = &net.Dialer{
+	Timeout: 10 * time.Second,
+	Control: func(network, address string, _ syscall.RawConn) error {
+		host, _, err := net.SplitHostPort(address)
+		if err != nil {
+			return err
+		}
+		ip := net.ParseIP(host)
+		if ip == nil {
+			return fmt.Errorf("webhook address %q is not public", host)
+		}
+		return checkWebhookIP(ip)
+	},
+}
+
+// CreateWebhook registers a webhook on a player's account. An empty secret
+// is generated; either way it's returned on the copy.
+func (pr *PlayerRegistry) CreateWebhook(player, rawURL string, events []EventType, secret string) (*Webhook, error) {
+	target, err := validateWebhookURL(rawURL)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[EventType]bool)
+	var types []EventType
+	for _, e := range events {
+		e = EventType(strings.ToLower(strings.TrimSpace(string(e))))
+		if e != "" && !seen[e] {
+			seen[e] = true
+			types = append(types, e)
+		}
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("at least one event type required")
+	}
+	if secret == "" {
+		b := make([]byte, 24)
+		rand.Read(b)
+		secret = "whsec-" + hex.EncodeToString(b)
+	} else if len(secret) < 16 {
+		return nil, fmt.Errorf("secret must be at least 16 characters")
+	}
+
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	acc, ok := pr.accounts[strings.ToLower(player)]
+	if !ok {
+		return nil, fmt.Errorf("no account for %q", player)
+	}
+	if len(acc.Webhooks) >= MaxWebhooksPerPlayer {
+		return nil, fmt.Errorf("at most %d webhooks per player", MaxWebhooksPerPlayer)
+	}
+	id := make([]byte, 4)
+	rand.Read(id)
+	hook := &Webhook{
+		ID:        "wh" + hex.EncodeToString(id),
+		URL:       target,
+		Secret:    secret,
+		Events:    types,
+		CreatedAt: time.Now().UTC(),
+	}
+	acc.Webhooks = append(acc.Webhooks, hook)
+	pr.saveLocked()
+	c := *hook
+	return &c, nil
+}
+
+// ListWebhooks returns copies of a player's webhooks, oldest first.
+func (pr *PlayerRegistry) ListWebhooks(player string) ([]Webhook, error) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	acc, ok := pr.accounts[strings.ToLower(player)]
+	if !ok {
+		return nil, fmt.Errorf("no account for %q", player)
+	}
+	out := make([]Webhook, 0, len(acc.Webhooks))
+	for _, h := range acc.Webhooks {
+		out = append(out, *h)
+	}
+	return out, nil
+}
+
+// DeleteWebhook removes a player's webhook by ID.
+func (pr *PlayerRegistry) DeleteWebhook(player, id string) error {
+	pr.mu.Lock()
+	defer pr.mu.Unlock()
+	acc, ok := pr.accounts[strings.ToLower(player)]
+	if !ok {
+		return fmt.Errorf("no account for %q", player)
+	}
+	for i, h := range acc.Webhooks {
+		if h.ID == id {
+			acc.Webhooks = append(acc.Webhooks[:i], acc.Webhooks[i+1:]...)
+			pr.saveLocked()
+			return nil
+		}
+	}
+	return fmt.Errorf("no webhook %q", id)
+}
+
+// webhook returns a copy of one of player's webhooks.
+func (pr *PlayerRegistry) webhook(player, id string) (Webhook, bool) {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	if acc, ok := pr.accounts[strings.ToLower(player)]; ok {
+		for _, h := range acc.Webhooks {
+			if h.ID == id {
+				return *h, true
+			}
+		}
+	}
+	return Webhook{}, false
+}
+
+// webhooksFor returns the webhooks an event goes to, keyed by owner.
+func (pr *PlayerRegistry) webhooksFor(ev GameEvent) map[string][]Webhook {
+	pr.mu.RLock()
+	defer pr.mu.RUnlock()
+	out := make(map[string][]Webhook)
+	for _, acc := range pr.accounts {
+		if ev.Player != "" && !strings.EqualFold(ev.Player, acc.Name) {
+			continue
+		}
+		for _, h := range acc.Webhooks {
+			if h.Wants(ev.Type) {
+				out[acc.Name] = append(out[acc.Name], *h)
+			}
+		}
+	}
+	return out
+}
+
+// WebhookPayload is the JSON body of a delivery.
+type WebhookPayload struct {
+	Delivery string    `json:"delivery"`
+	Webhook  string    `json:"webhook"`
+	Player   string    `json:"player"`
+	Event    GameEvent `json:"event"`
+}
+
+// WebhookDelivery is one event on its way to one webhook, or one that ran
+// out of attempts.
+type WebhookDelivery struct {
+	ID        string    `json:"id"`
+	WebhookID string    `json:"webhook_id"`
+	Player    string    `json:"player"`
+	URL       string    `json:"url"`
+	Event     GameEvent `json:"event"`
+	Attempts  int       `json:"attempts"`
+	LastError string    `json:"last_error,omitempty"`
+	FailedAt  time.Time `json:"failed_at,omitempty"`
+
+	secret string
+	body   []byte
+}
+
+// WebhookDispatcher delivers events to players' webhooks from a pool of
+// workers. A failed delivery is retried with exponential backoff; after
+// webhookAttempts tries it goes on the dead-letter list, from which the
+// player can redeliver it.
+type WebhookDispatcher struct {
+	Client    *http.Client
+	RetryBase time.Duration // delay before the first retry, doubled after each
+
+	registry *PlayerRegistry
+	queue    chan *WebhookDelivery
+	stop     chan struct{}
+	stopOnce sync.Once
+
+	mu   sync.Mutex
+	dead []*WebhookDelivery // oldest first
+}
+
+// NewWebhookDispatcher creates a dispatcher for the registry's webhooks.
+// Call Start to begin delivering.
+func NewWebhookDispatcher(registry *PlayerRegistry) *WebhookDispatcher {
+	return &WebhookDispatcher{
+		Client: &http.Client{
+			Timeout: 10 * time.Second,
+			// No proxy: the dialer has to see the receiver's own address
+			Transport: &http.Transport{DialContext: webhookDialer.DialContext, TLSHandshakeTimeout: 10 * time.Second},
+		},
+		RetryBase: 2 * time.Second,
+		registry:  registry,
+		queue:     make(chan *WebhookDelivery, webhookQueueSize),
+		stop:      make(chan struct{}),
+	}
+}
+
+// Start launches the delivery workers.
+func (d *WebhookDispatcher) Start(workers int) {
+	for i := 0; i < workers; i++ {
+		go func() {
+			for {
+				select {
+				case <-d.stop:
+					return
+				case del := <-d.queue:
+					d.attempt(del)
+				}
+			}
+		}()
+	}
+}
+
+// Stop halts the workers. Queued and pending retries are dropped.
+func (d *WebhookDispatcher) Stop() {
+	d.stopOnce.Do(func() { close(d.stop) })
+}
+
+// Notify queues an event for every webhook that wants it. It never blocks:
+// if the queue is full the delivery is dead-lettered. Safe to pass to
+// EventLog.Subscribe.
+func (d *WebhookDispatcher) Notify(ev GameEvent) {
+	for player, hooks := range d.registry.webhooksFor(ev) {
+		for _, h := range hooks {
+			id := make([]byte, 6)
+			rand.Read(id)
+			del := &WebhookDelivery{
+				ID:        "dl" + hex.EncodeToString(id),
+				WebhookID: h.ID,
+				Player:    player,
+				URL:       h.URL,
+				Event:     ev,
+				secret:    h.Secret,
+			}
+			del.body, _ = json.Marshal(WebhookPayload{Delivery: del.ID, Webhook: h.ID, Player: player, Event: ev})
+			d.enqueue(del)
+		}
+	}
+}
+
+func (d *WebhookDispatcher) enqueue(del *WebhookDelivery) {
+	select {
+	case <-d.stop:
+		return
+	default:
+	}
+	select {
+	case d.queue <- del:
+	default:
+		del.LastError = "delivery queue full"
+		d.deadLetter(del)
+	}
+}
+
+// attempt makes one delivery attempt and schedules what follows a failure.
+func (d *WebhookDispatcher) attempt(del *WebhookDelivery) {
+	del.Attempts++
+	err := d.post(del)
+	if err == nil {
+		return
+	}
+	del.LastError = err.Error()
+	if del.Attempts >= webhookAttempts {
+		d.deadLetter(del)
+		return
+	}
+	backoff := d.RetryBase << (del.Attempts - 1)
+	time.AfterFunc(backoff, func() { d.enqueue(del) })
+}
+
+func (d *WebhookDispatcher) post(del *WebhookDelivery) error {
+	req, err := http.NewRequest(http.MethodPost, del.URL, bytes.NewReader(del.body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Xandaris-Webhook/1")
+	req.Header.Set("X-Xandaris-Event", string(del.Event.Type))
+	req.Header.Set("X-Xandaris-Delivery", del.ID)
+	req.Header.Set("X-Xandaris-Signature", SignWebhook(del.secret, del.body))
+	resp, err := d.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64*1024))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("receiver returned %s", resp.Status)
+	}
+	return nil
+}
+
+func (d *WebhookDispatcher) deadLetter(del *WebhookDelivery) {
+	del.FailedAt = time.Now().UTC()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.dead = append(d.dead, del)
+	if len(d.dead) > maxDeadLetters {
+		d.dead = d.dead[len(d.dead)-maxDeadLetters:]
+	}
+	fmt.Printf("[Webhook] Gave up on %s to %s after %d attempts: %s\n", del.ID, del.URL, del.Attempts, del.LastError)
+}
+
+// DeadLetters returns copies of a player's failed deliveries, newest first.
+func (d *WebhookDispatcher) DeadLetters(player string) []WebhookDelivery {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	out := make([]WebhookDelivery, 0)
+	for i := len(d.dead) - 1; i >= 0; i-- {
+		if strings.EqualFold(d.dead[i].Player, player) {
+			out = append(out, *d.dead[i])
+		}
+	}
+	return out
+}
+
+// Redeliver takes a failed delivery off the dead-letter list and queues it
+// again, with a fresh set of attempts, to the webhook's current URL and secret.
+func (d *WebhookDispatcher) Redeliver(player, id string) error {
+	d.mu.Lock()
+	var del *WebhookDelivery
+	for _, dl := range d.dead {
+		if dl.ID == id && strings.EqualFold(dl.Player, player) {
+			del = dl
+			break
+		}
+	}
+	d.mu.Unlock()
+	if del == nil {
+		return fmt.Errorf("no failed delivery %q", id)
+	}
+	hook, ok := d.registry.webhook(del.Player, del.WebhookID)
+	if !ok {
+		return fmt.Errorf("webhook %s has been deleted", del.WebhookID)
+	}
+
+	d.mu.Lock()
+	for i, dl := range d.dead {
+		if dl == del {
+			d.dead = append(d.dead[:i], d.dead[i+1:]...)
+			break
+		}
+	}
+	d.mu.Unlock()
+	del.URL, del.secret = hook.URL, hook.Secret
+	del.Attempts, del.LastError, del.FailedAt = 0, "", time.Time{}
+	d.enqueue(del)
+	return nil
+}
@@ -0,0 +1,203 @@
+package game
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// webhookReceiver records deliveries, failing the first `failures` of them.
+type webhookReceiver struct {
+	mu        sync.Mutex
+	failures  int
+	attempts  int
+	delivered []WebhookPayload
+	sigOK     bool
+	secret    string
+	got       chan struct{}
+}
+
+func (wr *webhookReceiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	wr.mu.Lock()
+	defer wr.mu.Unlock()
+	wr.attempts++
+	if wr.attempts <= wr.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	var p WebhookPayload
+	json.Unmarshal(body, &p)
+	wr.delivered = append(wr.delivered, p)
+	wr.sigOK = r.Header.Get("X-Xandaris-Signature") == SignWebhook(wr.secret, body)
+	wr.got <- struct{}{}
+}
+
+func webhookTestSetup(t *testing.T, failures int) (*PlayerRegistry, *WebhookDispatcher, *webhookReceiver, string) {
+	t.Helper()
+	allowPrivateWebhooks(t) // the receiver listens on loopback
+	pr := newTestRegistry(t, "")
+	pr.FindOrCreateByDiscord("123", "Alice")
+	rcv := &webhookReceiver{failures: failures, secret: "0123456789abcdef", got: make(chan struct{}, 10)}
+	srv := httptest.NewTLSServer(rcv)
+	t.Cleanup(srv.Close)
+
+	d := NewWebhookDispatcher(pr)
+	d.Client = srv.Client()
+	d.RetryBase = time.Millisecond
+	d.Start(2)
+	t.Cleanup(d.Stop)
+	return pr, d, rcv, srv.URL
+}
+
+// allowPrivateWebhooks lifts the public-address check for one test.
+func allowPrivateWebhooks(t *testing.T) {
+	t.Helper()
+	prev := AllowPrivateWebhooks
+	AllowPrivateWebhooks = true
+	t.Cleanup(func() { AllowPrivateWebhooks = prev })
+}
+
+func waitDelivery(t *testing.T, rcv *webhookReceiver) {
+	t.Helper()
+	select {
+	case <-rcv.got:
+	case <-time.After(5 * time.Second):
+		t.Fatal("webhook was never delivered")
+	}
+}
+
+func TestWebhookDeliversSignedEvents(t *testing.T) {
+	pr, d, rcv, url := webhookTestSetup(t, 2) // succeeds on the third attempt
+	hook, err := pr.CreateWebhook("alice", url, []EventType{"military", "Event"}, rcv.secret)
+	if err != nil {
+		t.Fatalf("create webhook: %v", err)
+	}
+
+	d.Notify(GameEvent{Type: EventTrade, Player: "Alice", Message: "not subscribed"})
+	d.Notify(GameEvent{Type: "military", Player: "Bob", Message: "someone else's siege"})
+	d.Notify(GameEvent{Type: "military", Player: "Alice", Message: "siege!"})
+	waitDelivery(t, rcv)
+	d.Notify(GameEvent{Type: "event", Message: "auction ended"}) // galaxy-wide
+	waitDelivery(t, rcv)
+
+	rcv.mu.Lock()
+	defer rcv.mu.Unlock()
+	if len(rcv.delivered) != 2 || rcv.delivered[0].Event.Message != "siege!" || rcv.delivered[1].Event.Message != "auction ended" {
+		t.Fatalf("unexpected deliveries %+v", rcv.delivered)
+	}
+	if rcv.delivered[0].Webhook != hook.ID || rcv.delivered[0].Player != "Alice" {
+		t.Errorf("payload not addressed to the webhook: %+v", rcv.delivered[0])
+	}
+	if !rcv.sigOK {
+		t.Error("signature did not verify")
+	}
+	if rcv.attempts != 4 {
+		t.Errorf("expected 2 failed attempts then 2 deliveries, got %d attempts", rcv.attempts)
+	}
+}
+
+func TestWebhookDeadLetterAndRedeliver(t *testing.T) {
+	pr, d, rcv, url := webhookTestSetup(t, webhookAttempts)
+	pr.CreateWebhook("alice", url, []EventType{EventAlert}, rcv.secret)
+
+	d.Notify(GameEvent{Type: EventAlert, Player: "Alice", Message: "low fuel"})
+	var dead []WebhookDelivery
+	for deadline := time.Now().Add(5 * time.Second); len(dead) == 0; {
+		if time.Now().After(deadline) {
+			t.Fatal("delivery was never dead-lettered")
+		}
+		time.Sleep(5 * time.Millisecond)
+		dead = d.DeadLetters("Alice")
+	}
+	if dead[0].Attempts != webhookAttempts || dead[0].LastError == "" {
+		t.Errorf("unexpected dead letter %+v", dead[0])
+	}
+	if len(d.DeadLetters("Bob")) != 0 {
+		t.Error("dead letters leaked to another player")
+	}
+
+	if err := d.Redeliver("Alice", dead[0].ID); err != nil {
+		t.Fatalf("redeliver: %v", err)
+	}
+	waitDelivery(t, rcv)
+	if len(d.DeadLetters("Alice")) != 0 {
+		t.Error("redelivered letter is still on the list")
+	}
+}
+
+func TestWebhookValidation(t *testing.T) {
+	pr := newTestRegistry(t, "")
+	pr.FindOrCreateByDiscord("123", "Alice")
+
+	if _, err := pr.CreateWebhook("alice", "http://example.com/hook", []EventType{EventAlert}, ""); err == nil {
+		t.Error("expected plain http to be rejected")
+	}
+	if _, err := pr.CreateWebhook("alice", "https://example.com/hook", nil, ""); err == nil {
+		t.Error("expected a webhook without events to be rejected")
+	}
+	if _, err := pr.CreateWebhook("alice", "https://example.com/hook", []EventType{EventAlert}, "short"); err == nil {
+		t.Error("expected a short secret to be rejected")
+	}
+	hook, err := pr.CreateWebhook("alice", "https://example.com/hook", []EventType{EventAlert}, "")
+	if err != nil || hook.Secret == "" {
+		t.Fatalf("expected a generated secret, got %v", err)
+	}
+	for i := 1; i < MaxWebhooksPerPlayer; i++ {
+		pr.CreateWebhook("alice", "https://example.com/hook", []EventType{EventAlert}, "")
+	}
+	if _, err := pr.CreateWebhook("alice", "https://example.com/hook", []EventType{EventAlert}, ""); err == nil {
+		t.Error("expected the webhook cap to be enforced")
+	}
+	if err := pr.DeleteWebhook("alice", hook.ID); err != nil {
+		t.Errorf("delete: %v", err)
+	}
+}
+
+func TestWebhookRejectsPrivateAddresses(t *testing.T) {
+	pr := newTestRegistry(t, "")
+	pr.FindOrCreateByDiscord("123", "Alice")
+
+	for _, target := range []string{
+		"https://127.0.0.1/hook",
+		"https://localhost:8443/hook",
+		"https://[::1]/hook",
+		"https://10.1.2.3/hook",
+		"https://192.168.0.10/hook",
+		"https://169.254.169.254/latest/meta-data",
+		"https://100.64.0.1/hook",
+		"https://0.0.0.0/hook",
+	} {
+		if _, err := pr.CreateWebhook("alice", target, []EventType{EventAlert}, ""); err == nil {
+			t.Errorf("expected %s to be rejected", target)
+		}
+	}
+
+	// A hostname that passes registration is checked again, on the address
+	// it resolves to, when a delivery connects
+	for addr, public := range map[string]bool{
+		"127.0.0.1:443":     false,
+		"[::1]:443":         false,
+		"10.0.0.7:443":      false,
+		"[fe80::1]:443":     false,
+		"93.184.216.34:443": true,
+	} {
+		err := webhookDialer.Control("tcp", addr, nil)
+		if public && err != nil {
+			t.Errorf("expected %s to be dialable: %v", addr, err)
+		}
+		if !public && (err == nil || !strings.Contains(err.Error(), "not public")) {
+			t.Errorf("expected dialing %s to be refused, got %v", addr, err)
+		}
+	}
+
+	allowPrivateWebhooks(t)
+	if _, err := pr.CreateWebhook("alice", "https://127.0.0.1/hook", []EventType{EventAlert}, ""); err != nil {
+		t.Errorf("expected the opt-out to allow loopback: %v", err)
+	}
+}
@@ -1,27 +1,31 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"os/signal"
+	"path/filepath"
 	"runtime"
+	"strings"
 	"syscall"
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hunterjsb/xandaris/core"
+	"github.com/hunterjsb/xandaris/game"
 	"github.com/hunterjsb/xandaris/server"
+	"github.com/hunterjsb/xandaris/tickable"
 	"github.com/hunterjsb/xandaris/views"
 
-	// Register entity generators and tickable systems (side-effect imports)
+	// Register entity generators (side-effect imports)
 	_ "github.com/hunterjsb/xandaris/entities/building"
 	_ "github.com/hunterjsb/xandaris/entities/planet"
 	_ "github.com/hunterjsb/xandaris/entities/resource"
 	_ "github.com/hunterjsb/xandaris/entities/star"
 	_ "github.com/hunterjsb/xandaris/entities/station"
-	_ "github.com/hunterjsb/xandaris/tickable"
 )
 
 const (
@@ -30,6 +34,11 @@ const (
 )
 
 func main() {
+	// Offline save tools: xandaris save inspect|migrate ...
+	if len(os.Args) > 1 && os.Args[1] == "save" {
+		os.Exit(runSaveCommand(os.Args[2:]))
+	}
+
 	headless := flag.Bool("headless", false, "Run as headless server (no GUI)")
 	autoStart := flag.Bool("auto", false, "Skip menu and start new game immediately (GUI mode)")
 	startView := flag.String("view", "", "Start in specific view: market, players, galaxy (requires --auto)")
@@ -37,15 +46,30 @@ func main() {
 	loadPath := flag.String("load", "", "Path to save file to load")
 	connectURL := flag.String("connect", "", "Connect to remote server (e.g. https://api.xandaris.space)")
 	apiKeyFlag := flag.String("key", "", "API key for remote server authentication")
+	spectate := flag.Bool("spectate", false, "With --connect: watch read-only through the server's delayed spectator feed (needs an observer key)")
+	seed := flag.Int64("seed", 0, "Galaxy seed for a new headless game; same seed + same commands = same game (starts fresh instead of resuming the autosave)")
+	configPath := flag.String("config", "", "Match setup JSON for a new headless game: galaxy size, AI factions, starting credits, ... (see GET /api/lobby; starts fresh like --seed)")
+	journalPath := flag.String("journal", "", "Append every executed command to this journal file (headless)")
+	autosaves := flag.Int("autosaves", 3, "Autosaves to keep, rotated as autosave.1.xsave, autosave.2.xsave, ... (headless)")
+	tickBudget := flag.Duration("tick-budget", tickable.DefaultTickBudget, "Flag tickable systems whose OnTick takes longer than this (see /api/admin/tick-profile; 0 disables)")
+	gamesDir := flag.String("games", "", "Host more games alongside the main one, kept under this directory and managed via /api/admin/games (headless)")
+	replayPath := flag.String("replay", "", "Rebuild a headless game from a journal's seed and commands (combine with --load if the journal began from a save)")
 	flag.Parse()
 
+	tickable.SetTickBudget(*tickBudget)
+
+	if *replayPath != "" {
+		runReplay(*replayPath, *loadPath, *journalPath)
+		return
+	}
+
 	if *headless {
-		runHeadless(*playerName, *loadPath)
+		runHeadless(*playerName, *loadPath, *seed, *configPath, *journalPath, *autosaves, *gamesDir)
 		return
 	}
 
 	if *connectURL != "" {
-		runRemote(*connectURL, *playerName, *apiKeyFlag)
+		runRemote(*connectURL, *playerName, *apiKeyFlag, *spectate)
 		return
 	}
 
@@ -53,7 +77,7 @@ func main() {
 	if runtime.GOARCH == "wasm" {
 		serverURL, player, key := getWASMConnectParams()
 		if serverURL != "" && key != "" {
-			runRemote(serverURL, player, key)
+			runRemote(serverURL, player, key, false)
 			return
 		}
 	}
@@ -98,11 +122,26 @@ const autosavePath = "saves/autosave.xsave"
 
 // runHeadless starts a headless server with no GUI.
 // The game runs as a simulation with the REST API exposed on :8080.
-func runHeadless(playerName string, loadPath string) {
+func runHeadless(playerName string, loadPath string, seed int64, configPath string, journalPath string, autosaves int, gamesDir string) {
 	fmt.Println("=== Xandaris II — Headless Server ===")
 	fmt.Println("API available at http://localhost:8080")
 
+	cfg := game.DefaultGameConfig()
+	if configPath != "" {
+		data, err := os.ReadFile(configPath)
+		if err != nil {
+			log.Fatalf("Failed to read game config: %v", err)
+		}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			log.Fatalf("Failed to parse game config %s: %v", configPath, err)
+		}
+	}
+	if seed != 0 {
+		cfg.Seed = seed
+	}
+
 	gs := server.New(screenWidth, screenHeight)
+	gs.AutosaveKeep = autosaves
 
 	// Priority: explicit --load path > autosave > new game
 	loaded := false
@@ -112,10 +151,20 @@ func runHeadless(playerName string, loadPath string) {
 			log.Fatalf("Failed to load game: %v", err)
 		}
 		loaded = true
+	} else if seed != 0 {
+		fmt.Printf("Starting seeded game (seed %d)\n", seed)
+	} else if configPath != "" {
+		fmt.Printf("Starting game from %s\n", configPath)
 	} else if _, err := os.Stat(autosavePath); err == nil {
 		fmt.Printf("Loading autosave: %s\n", autosavePath)
 		if err := gs.LoadGame(autosavePath); err != nil {
-			fmt.Printf("Autosave corrupted, starting new game: %v\n", err)
+			// Keep the unreadable save around instead of overwriting it
+			aside := fmt.Sprintf("%s.unloadable-%s", autosavePath, time.Now().Format("2006-01-02_15-04-05"))
+			if rerr := os.Rename(autosavePath, aside); rerr == nil {
+				fmt.Printf("Autosave unloadable (%v); moved to %s, starting new game\n", err, aside)
+			} else {
+				fmt.Printf("Autosave corrupted, starting new game: %v\n", err)
+			}
 		} else {
 			loaded = true
 			fmt.Printf("[Server] Resumed from autosave (tick %d)\n", gs.TickManager.GetCurrentTick())
@@ -124,7 +173,7 @@ func runHeadless(playerName string, loadPath string) {
 
 	if !loaded {
 		fmt.Println("Starting new multiplayer game (no default player)")
-		if err := gs.NewHeadlessGame(); err != nil {
+		if err := gs.NewHeadlessGame(cfg); err != nil {
 			log.Fatalf("Failed to start new game: %v", err)
 		}
 		// Save immediately so deploys don't lose the fresh game
@@ -133,6 +182,21 @@ func runHeadless(playerName string, loadPath string) {
 		}
 	}
 
+	if journalPath != "" {
+		if err := gs.StartJournal(journalPath); err != nil {
+			log.Fatalf("Failed to start journal: %v", err)
+		}
+	}
+
+	// Extra games served under /api/games/{id}/, once the main game has started the API
+	var games *server.GameManager
+	if gamesDir != "" {
+		games = server.NewGameManager(gamesDir, screenWidth, screenHeight)
+		if err := games.Start(); err != nil {
+			log.Fatalf("Failed to start hosted games: %v", err)
+		}
+	}
+
 	// Periodic autosave every 2 minutes
 	go func() {
 		ticker := time.NewTicker(2 * time.Minute)
@@ -154,9 +218,129 @@ func runHeadless(playerName string, loadPath string) {
 		if err := gs.AutoSave(autosavePath); err != nil {
 			fmt.Printf("[Autosave] Shutdown save failed: %v\n", err)
 		}
+		if games != nil {
+			games.Shutdown()
+		}
+		gs.CloseJournal()
 		gs.Stop()
 	}()
 
 	// Run the simulation loop (blocks until Stop())
 	gs.Run()
 }
+
+// runReplay rebuilds a game from a command journal and serves it, paused,
+// on the REST API for inspection. Nothing is autosaved so a replay never
+// overwrites the live game's save.
+func runReplay(replayPath, loadPath, journalPath string) {
+	fmt.Println("=== Xandaris II — Journal Replay ===")
+	fmt.Println("API available at http://localhost:8080")
+
+	gs := server.New(screenWidth, screenHeight)
+	if _, err := gs.ReplayJournal(replayPath, loadPath); err != nil {
+		log.Fatalf("Replay failed: %v", err)
+	}
+
+	// Optionally keep journaling, so a replayed session can be extended and replayed again
+	if journalPath != "" {
+		if err := gs.StartJournal(journalPath); err != nil {
+			log.Fatalf("Failed to start journal: %v", err)
+		}
+	}
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		<-sigCh
+		fmt.Println("\nShutting down...")
+		gs.CloseJournal()
+		gs.Stop()
+	}()
+
+	gs.Run()
+}
+
+// runSaveCommand implements `xandaris save inspect <file>...` and
+// `xandaris save migrate <file> [out]` for checking saves offline.
+func runSaveCommand(args []string) int {
+	usage := "usage: xandaris save inspect <file>...\n" +
+		"       xandaris save migrate <file> [out]       (default: in place, original kept as .bak)\n" +
+		"       xandaris save export <file.xsave> [out]  (default: <file>.json)\n" +
+		"       xandaris save import <file.json> [out]   (default: <file>.xsave)"
+	if len(args) < 2 {
+		fmt.Println(usage)
+		return 2
+	}
+
+	switch args[0] {
+	case "inspect":
+		status := 0
+		for _, path := range args[1:] {
+			info, err := server.InspectSave(path)
+			if err != nil {
+				fmt.Printf("%s: %v\n", path, err)
+				status = 1
+				continue
+			}
+			fmt.Printf("%s\n", info.Path)
+			fmt.Printf("  version:   %s (current %s)\n", info.Version, server.SaveVersion)
+			if info.MigrationError != "" {
+				fmt.Printf("  loadable:  no — %s\n", info.MigrationError)
+				status = 1
+				continue
+			}
+			fmt.Printf("  saved:     %s by %s\n", info.SavedAt.Format(time.RFC3339), info.PlayerName)
+			fmt.Printf("  tick:      %d (%s), seed %d\n", info.Tick, info.GameTime, info.Seed)
+			fmt.Printf("  galaxy:    %d systems, %d players %v\n", info.Systems, len(info.Players), info.Players)
+			fmt.Printf("  tickables: %d systems with saved state\n", info.TickableStates)
+			if len(info.Migrations) == 0 {
+				fmt.Println("  loadable:  yes, current format")
+			} else {
+				fmt.Println("  loadable:  yes, after migrating:")
+				for _, m := range info.Migrations {
+					fmt.Printf("    %s\n", m)
+				}
+			}
+		}
+		return status
+
+	case "migrate":
+		in, out := args[1], args[1]
+		if len(args) > 2 {
+			out = args[2]
+		}
+		applied, err := server.MigrateSaveFile(in, out)
+		if err != nil {
+			fmt.Printf("%s: %v\n", in, err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Printf("%s is already version %s; wrote %s\n", in, server.SaveVersion, out)
+		} else {
+			fmt.Printf("Migrated %s (%s) → %s\n", in, strings.Join(applied, ", "), out)
+		}
+		return 0
+
+	case "export", "import":
+		in := args[1]
+		ext := ".json"
+		convert := server.ExportSaveJSON
+		if args[0] == "import" {
+			ext = ".xsave"
+			convert = server.ImportSaveJSON
+		}
+		out := strings.TrimSuffix(in, filepath.Ext(in)) + ext
+		if len(args) > 2 {
+			out = args[2]
+		}
+		if err := convert(in, out); err != nil {
+			fmt.Printf("%s: %v\n", in, err)
+			return 1
+		}
+		fmt.Printf("Wrote %s\n", out)
+		return 0
+	}
+
+	fmt.Println(usage)
+	return 2
+}
@@ -10,26 +10,37 @@ import (
 	"github.com/hunterjsb/xandaris/server"
 )
 
-// runRemote connects to a remote server and runs the GUI client.
-func runRemote(serverURL, playerName, apiKey string) {
+// runRemote connects to a remote server and runs the GUI client. A
+// spectating client is read-only and mirrors the server's delayed
+// snapshots instead of a player's live view.
+func runRemote(serverURL, playerName, apiKey string, spectate bool) {
 	if apiKey == "" {
+		if spectate {
+			log.Fatal("Observer key required to spectate. Ask the server admin for one.")
+		}
 		log.Fatal("API key required for remote play. Sign in at the web portal to get one.")
 	}
 
-	fmt.Printf("Connecting to %s as %s...\n", serverURL, playerName)
-
 	gs := server.New(screenWidth, screenHeight)
-	remote := server.NewRemoteSync(gs, serverURL, apiKey)
+	var remote *server.RemoteSync
+	if spectate {
+		playerName = "Spectator"
+		fmt.Printf("Connecting to %s as a spectator...\n", serverURL)
+		remote = server.NewSpectatorSync(gs, serverURL, apiKey)
+	} else {
+		fmt.Printf("Connecting to %s as %s...\n", serverURL, playerName)
+		remote = server.NewRemoteSync(gs, serverURL, apiKey)
+	}
 
-	// Fetch the remote galaxy seed so we generate the same universe
-	seed, err := remote.FetchSeed()
+	// Fetch the remote galaxy seed and setup so we generate the same universe
+	cfg, err := remote.FetchConfig()
 	if err != nil {
 		log.Fatalf("Failed to fetch galaxy seed: %v", err)
 	}
-	fmt.Printf("Galaxy seed: %d\n", seed)
+	fmt.Printf("Galaxy seed: %d\n", cfg.Seed)
 
 	// Generate the same galaxy as the remote server using the seed
-	if err := gs.NewGameWithSeed(playerName, seed); err != nil {
+	if err := gs.NewGameWithConfig(playerName, cfg); err != nil {
 		log.Fatalf("Failed to initialize: %v", err)
 	}
 
@@ -51,10 +62,16 @@ func runRemote(serverURL, playerName, apiKey string) {
 	// Skip InitializeNewGame (which calls NewGame with random seed) —
 	// the GameServer already has the remote seed and state.
 	app.InitializeClientViews()
-	app.ConfigureCommandBar(serverURL, apiKey)
+	if !spectate {
+		app.ConfigureCommandBar(serverURL, apiKey)
+	}
 	app.SwitchToGalaxyView()
 
-	fmt.Printf("Connected to %s! Playing as %s\n", serverURL, playerName)
+	if spectate {
+		fmt.Printf("Connected to %s! Spectating\n", serverURL)
+	} else {
+		fmt.Printf("Connected to %s! Playing as %s\n", serverURL, playerName)
+	}
 
 	if err := ebiten.RunGame(app); err != nil {
 		log.Fatal(err)
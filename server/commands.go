@@ -26,15 +26,32 @@ var remoteForwardedCommands = map[game.CommandType]bool{
 
 // executeCommand processes a single game command via the registry.
 func (gs *GameServer) executeCommand(cmd game.GameCommand) {
+	if cmd.IdempotencyKey != "" {
+		gs.executeIdempotent(cmd)
+		return
+	}
+	gs.runCommand(cmd)
+}
+
+// runCommand forwards or journals and executes cmd, tagging the events it
+// logs with its request ID.
+func (gs *GameServer) runCommand(cmd game.GameCommand) {
+	if cmd.RequestID != "" && gs.Events != nil {
+		gs.Events.SetRequestID(cmd.RequestID)
+		defer gs.Events.SetRequestID("")
+	}
+
 	// In remote mode, forward gameplay commands to the remote server
 	if gs.remoteSync != nil && remoteForwardedCommands[cmd.Type] {
 		gs.forwardCommandToRemote(cmd)
 		return
 	}
 
+	gs.journalCommand(cmd)
 	if err := gs.cmdRegistry.Execute(cmd); err != nil {
 		fmt.Printf("[Server] %v\n", err)
 	}
+	gs.reconcileCommandCredits(cmd.Type)
 }
 
 // initCommandRegistry registers all command handlers.
@@ -42,12 +59,21 @@ func (gs *GameServer) initCommandRegistry() {
 	cr := NewCommandRegistry()
 
 	cr.Register(game.CmdSave, func(cmd game.GameCommand) {
-		if playerName, ok := cmd.Data.(string); ok {
-			// Already under gs.mu from DrainCommands — use locked variant to avoid deadlock
-			if err := gs.saveGameLocked(playerName); err != nil {
+		playerName, ok := cmd.Data.(string)
+		if !ok {
+			sendResult(cmd, fmt.Errorf("invalid save data"))
+			return
+		}
+		// Already under gs.mu from DrainCommands — use locked variant to avoid
+		// deadlock. The reply comes once the file is written.
+		gs.saveGameLocked(playerName, func(path string, err error) {
+			if err != nil {
 				fmt.Printf("[Server] Save failed: %v\n", err)
+				sendResult(cmd, err)
+				return
 			}
-		}
+			sendSuccess(cmd, path)
+		})
 	})
 	cr.Register(game.CmdSetSpeed, func(cmd game.GameCommand) {
 		if speed, ok := cmd.Data.(systems.TickSpeed); ok {
@@ -57,6 +83,14 @@ func (gs *GameServer) initCommandRegistry() {
 	cr.Register(game.CmdTogglePause, func(cmd game.GameCommand) {
 		gs.TickManager.TogglePause()
 	})
+	cr.Register(game.CmdSetSchedule, func(cmd game.GameCommand) {
+		sd, ok := cmd.Data.(game.SetScheduleCommandData)
+		if !ok {
+			sendResult(cmd, fmt.Errorf("invalid schedule data"))
+			return
+		}
+		sendResult(cmd, gs.tickables().SetSchedule(sd.System, sd.Interval, sd.Phase))
+	})
 	cr.Register(game.CmdTrade, gs.handleTradeCommand)
 	cr.Register(game.CmdCargoLoad, gs.handleCargoCommand)
 	cr.Register(game.CmdCargoUnload, gs.handleCargoCommand)
@@ -71,6 +105,10 @@ func (gs *GameServer) initCommandRegistry() {
 	cr.Register(game.CmdCancelConstruction, gs.handleCancelConstructionCommand)
 	cr.Register(game.CmdStandingOrder, gs.handleStandingOrderCommand)
 	cr.Register(game.CmdCancelOrder, gs.handleCancelOrderCommand)
+	cr.Register(game.CmdLimitOrder, gs.handleLimitOrderCommand)
+	cr.Register(game.CmdCancelLimitOrder, gs.handleCancelLimitOrderCommand)
+	cr.Register(game.CmdFuturesOpen, gs.handleFuturesOpenCommand)
+	cr.Register(game.CmdFuturesClose, gs.handleFuturesCloseCommand)
 	cr.Register(game.CmdFleetMove, gs.handleFleetMoveCommand)
 	cr.Register(game.CmdFleetCreate, gs.handleFleetCreateCommand)
 	cr.Register(game.CmdFleetDisband, gs.handleFleetDisbandCommand)
@@ -82,6 +120,10 @@ func (gs *GameServer) initCommandRegistry() {
 	cr.Register(game.CmdBuyAtDock, gs.handleBuyAtDockCommand)
 	cr.Register(game.CmdDemolish, gs.handleDemolishCommand)
 	cr.Register(game.CmdTransferFuel, gs.handleTransferFuelCommand)
+	cr.Register(game.CmdEspionage, gs.handleEspionageCommand)
+	cr.Register(game.CmdPostBounty, gs.handlePostBountyCommand)
+	cr.Register(game.CmdBlackMarket, gs.handleBlackMarketCommand)
+	cr.Register(game.CmdBatch, gs.handleBatchCommand)
 
 	gs.cmdRegistry = cr
 }
@@ -173,7 +215,7 @@ func (gs *GameServer) handleBuildCommand(cmd game.GameCommand) {
 		Started:        gs.TickManager.GetCurrentTick(),
 	}
 
-	if cs := tickable.GetConstructionSystem(); cs != nil {
+	if cs := gs.tickables().ConstructionSystem(); cs != nil {
 		cs.AddToQueue(attachmentID, item)
 	}
 
@@ -259,7 +301,7 @@ func (gs *GameServer) handleBuildShipCommand(cmd game.GameCommand) {
 		Started:        gs.TickManager.GetCurrentTick(),
 	}
 
-	if cs := tickable.GetConstructionSystem(); cs != nil {
+	if cs := gs.tickables().ConstructionSystem(); cs != nil {
 		cs.AddToQueue(location, item)
 	}
 
@@ -525,7 +567,7 @@ func (gs *GameServer) handleColonizeCommand(cmd game.GameCommand) {
 	}
 
 	systemID := gs.CargoCommander.GetSystemForPlanet(planet)
-	game.ColonizePlanet(planet, ship, human, systemID)
+	game.ColonizePlanet(planet, ship, human, systemID, gs.rng("Colonize"))
 
 	// Log the event
 	gs.LogEvent("colonize", human.Name, fmt.Sprintf("%s colonized %s!", human.Name, planet.Name))
@@ -558,10 +600,11 @@ func (gs *GameServer) handleRegisterPlayerCommand(cmd game.GameCommand) {
 	colors := utils.GetAIPlayerColors()
 	playerColor := colors[playerID%len(colors)]
 	newPlayer := entities.NewPlayer(playerID, rd.Name, playerColor, entities.PlayerTypeHuman)
+	newPlayer.Credits = gs.State.Config.StartingCredits
 
 	// Initialize with a homeworld
-	entities.InitializePlayer(newPlayer, gs.State.Systems)
-	game.PrepareHomeworld(newPlayer, false)
+	entities.InitializePlayer(newPlayer, gs.State.Systems, gs.rng("Homeworld"))
+	game.PrepareHomeworld(newPlayer, false, gs.rng("Homeworld"))
 
 	// Extra starting resources
 	if newPlayer.HomePlanet != nil {
@@ -637,7 +680,7 @@ func (gs *GameServer) handleCancelConstructionCommand(cmd game.GameCommand) {
 		return
 	}
 
-	cs := tickable.GetConstructionSystem()
+	cs := gs.tickables().ConstructionSystem()
 	if cs == nil {
 		sendResult(cmd, fmt.Errorf("construction system not found"))
 		return
@@ -768,6 +811,7 @@ func (gs *GameServer) handleSellAtDockCommand(cmd game.GameCommand) {
 			for _, p := range gs.State.Players {
 				if p != nil && p.Name == planet.Owner {
 					p.Credits += dockingFee
+					gs.recordDockingFee(p, human.Name, sd.Resource, dockingFee)
 					break
 				}
 			}
@@ -777,21 +821,23 @@ func (gs *GameServer) handleSellAtDockCommand(cmd game.GameCommand) {
 
 	// Credit the ship owner (after fee deduction)
 	human.Credits += credits
+	gs.recordDockSale(human, sd.Resource, sold, buyPrice, credits)
 
 	// Log to trade history
+	if gs.Candles != nil {
+		gs.Candles.AddVolume(gs.TickManager.GetCurrentTick(), ship.CurrentSystem, sd.Resource, sold)
+	}
 	if gs.State.TradeExec != nil {
 		gs.State.Market.AddTradeVolume(sd.Resource, sold, false)
-		if gs.State.TradeExec.OnTrade != nil {
-			gs.State.TradeExec.OnTrade(economy.TradeRecord{
-				Tick:      gs.TickManager.GetCurrentTick(),
-				Player:    human.Name,
-				Resource:  sd.Resource,
-				Quantity:  sold,
-				Action:    "sell_at_dock",
-				UnitPrice: buyPrice,
-				Total:     credits,
-			})
-		}
+		gs.State.TradeExec.PublishTrade(economy.TradeRecord{
+			Tick:      gs.TickManager.GetCurrentTick(),
+			Player:    human.Name,
+			Resource:  sd.Resource,
+			Quantity:  sold,
+			Action:    "sell_at_dock",
+			UnitPrice: buyPrice,
+			Total:     credits,
+		})
 	}
 
 	sendSuccess(cmd, map[string]interface{}{
@@ -854,6 +900,20 @@ func (gs *GameServer) handleBuyAtDockCommand(cmd game.GameCommand) {
 	if gs.State.Market != nil {
 		gs.State.Market.AddTradeVolume(sd.Resource, bought, true)
 	}
+	if gs.Candles != nil {
+		gs.Candles.AddVolume(gs.TickManager.GetCurrentTick(), ship.CurrentSystem, sd.Resource, bought)
+	}
+	if gs.Ledger != nil {
+		gs.Ledger.AddTrade(economy.TradeRecord{
+			Tick:      gs.TickManager.GetCurrentTick(),
+			Player:    human.Name,
+			Resource:  sd.Resource,
+			Quantity:  bought,
+			Action:    "buy_at_dock",
+			UnitPrice: sellPrice,
+			Total:     cost,
+		})
+	}
 
 	sendSuccess(cmd, map[string]interface{}{
 		"ship_id":  sd.ShipID,
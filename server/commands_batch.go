@@ -0,0 +1,264 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// batchableCommands are the gameplay commands a batch may contain.
+var batchableCommands = map[game.CommandType]bool{
+	game.CmdTrade:              true,
+	game.CmdStandingOrder:      true,
+	game.CmdCancelOrder:        true,
+	game.CmdLimitOrder:         true,
+	game.CmdCancelLimitOrder:   true,
+	game.CmdFuturesOpen:        true,
+	game.CmdFuturesClose:       true,
+	game.CmdBuild:              true,
+	game.CmdUpgrade:            true,
+	game.CmdDemolish:           true,
+	game.CmdWorkforceAssign:    true,
+	game.CmdCancelConstruction: true,
+	game.CmdBuildShip:          true,
+	game.CmdMoveShip:           true,
+	game.CmdRefuel:             true,
+	game.CmdTransferFuel:       true,
+	game.CmdColonize:           true,
+	game.CmdCargoLoad:          true,
+	game.CmdCargoUnload:        true,
+	game.CmdDockShip:           true,
+	game.CmdUndockShip:         true,
+	game.CmdSellAtDock:         true,
+	game.CmdBuyAtDock:          true,
+	game.CmdFleetMove:          true,
+	game.CmdFleetCreate:        true,
+	game.CmdFleetDisband:       true,
+	game.CmdFleetAddShip:       true,
+	game.CmdFleetRemoveShip:    true,
+}
+
+// rollbackCommands only touch state a batchCheckpoint restores (credits,
+// stored resources, ship cargo/fuel/position and construction queues), so
+// they're the ones allowed in an atomic batch.
+var rollbackCommands = map[game.CommandType]bool{
+	game.CmdTrade:              true,
+	game.CmdBuild:              true,
+	game.CmdCancelConstruction: true,
+	game.CmdBuildShip:          true,
+	game.CmdMoveShip:           true,
+	game.CmdRefuel:             true,
+	game.CmdTransferFuel:       true,
+	game.CmdCargoLoad:          true,
+	game.CmdCargoUnload:        true,
+	game.CmdDockShip:           true,
+	game.CmdUndockShip:         true,
+	game.CmdSellAtDock:         true,
+	game.CmdBuyAtDock:          true,
+}
+
+// handleBatchCommand runs each step of a batch in order, as the batch's
+// player, without releasing the simulation lock in between. A plain batch
+// runs every step and reports each result; an atomic batch stops at the
+// first failure and restores the checkpoint taken before the first step.
+func (gs *GameServer) handleBatchCommand(cmd game.GameCommand) {
+	bd, ok := cmd.Data.(game.BatchCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid batch data"))
+		return
+	}
+	if err := validateBatch(bd); err != nil {
+		sendResult(cmd, err)
+		return
+	}
+	if bd.Atomic && gs.remoteSync != nil {
+		sendResult(cmd, fmt.Errorf("atomic batches aren't supported when connected to a remote server"))
+		return
+	}
+
+	var cp *batchCheckpoint
+	if bd.Atomic {
+		cp = gs.checkpoint()
+	}
+
+	result := game.BatchResult{Steps: make([]game.BatchStepResult, len(bd.Steps))}
+	for i, step := range bd.Steps {
+		if result.RolledBack {
+			result.Steps[i].Skipped = true
+			continue
+		}
+		out, err := gs.runBatchStep(cmd.PlayerName, step)
+		result.Steps[i] = game.BatchStepResult{Result: out, Err: err}
+		if err != nil && bd.Atomic {
+			cp.restore(gs)
+			result.RolledBack = true
+			gs.reconcileRollback()
+		}
+	}
+	sendSuccess(cmd, result)
+}
+
+// validateBatch rejects a batch before any of it runs.
+func validateBatch(bd game.BatchCommandData) error {
+	if len(bd.Steps) == 0 {
+		return fmt.Errorf("batch has no commands")
+	}
+	if len(bd.Steps) > game.MaxBatchSteps {
+		return fmt.Errorf("batch has %d commands (max %d)", len(bd.Steps), game.MaxBatchSteps)
+	}
+	for i, step := range bd.Steps {
+		if !batchableCommands[step.Type] {
+			return fmt.Errorf("command %d: %s can't be batched", i, step.Type)
+		}
+		if bd.Atomic && !rollbackCommands[step.Type] {
+			return fmt.Errorf("command %d: %s can't be rolled back; send it in a non-atomic batch", i, step.Type)
+		}
+	}
+	return nil
+}
+
+// runBatchStep executes one step synchronously and returns what its handler
+// reported.
+func (gs *GameServer) runBatchStep(player string, step game.BatchStep) (interface{}, error) {
+	sub := game.GameCommand{
+		Type:       step.Type,
+		Data:       step.Data,
+		Result:     make(chan interface{}, 1),
+		PlayerName: player,
+	}
+	if gs.remoteSync != nil {
+		gs.forwardCommandToRemote(sub)
+	} else if err := gs.cmdRegistry.Execute(sub); err != nil {
+		return nil, err
+	}
+	gs.reconcileCommandCredits(step.Type)
+	select {
+	case out := <-sub.Result:
+		if err, ok := out.(error); ok {
+			return nil, err
+		}
+		return out, nil
+	default:
+		return nil, nil
+	}
+}
+
+// batchCheckpoint is the state an atomic batch can roll back to.
+type batchCheckpoint struct {
+	credits map[*entities.Player]int
+	storage map[*entities.Planet]map[string]int
+	ships   map[*entities.Ship]shipState
+	queues  map[string][]*tickable.ConstructionItem
+
+	deliveryNextID int                       // deliveries from this ID on were made by the batch
+	outstanding    map[string]map[string]int // credit owed between empires
+}
+
+// shipState is the part of a ship that batchable commands change.
+type shipState struct {
+	status         entities.ShipStatus
+	currentSystem  int
+	targetSystem   int
+	travelProgress float64
+	fuel           int
+	cargo          map[string]int
+	routePath      []int
+	dockedAt       int
+}
+
+// checkpoint records every player's credits, planet storage and ships, plus
+// the construction queues, pending deliveries and outstanding credit. Trades move credits and goods between players, so
+// the whole galaxy is captured, not just the batch's player.
+func (gs *GameServer) checkpoint() *batchCheckpoint {
+	cp := &batchCheckpoint{
+		credits: make(map[*entities.Player]int),
+		storage: make(map[*entities.Planet]map[string]int),
+		ships:   make(map[*entities.Ship]shipState),
+	}
+	for _, p := range gs.State.Players {
+		if p == nil {
+			continue
+		}
+		cp.credits[p] = p.Credits
+		for _, planet := range p.OwnedPlanets {
+			if planet == nil {
+				continue
+			}
+			stored := make(map[string]int, len(planet.StoredResources))
+			for res, s := range planet.StoredResources {
+				stored[res] = s.Amount
+			}
+			cp.storage[planet] = stored
+		}
+		for _, ship := range p.OwnedShips {
+			if ship == nil {
+				continue
+			}
+			cargo := make(map[string]int, len(ship.CargoHold))
+			for res, qty := range ship.CargoHold {
+				cargo[res] = qty
+			}
+			cp.ships[ship] = shipState{
+				status:         ship.Status,
+				currentSystem:  ship.CurrentSystem,
+				targetSystem:   ship.TargetSystem,
+				travelProgress: ship.TravelProgress,
+				fuel:           ship.CurrentFuel,
+				cargo:          cargo,
+				routePath:      append([]int(nil), ship.RoutePath...),
+				dockedAt:       ship.DockedAtPlanet,
+			}
+		}
+	}
+	if cs := gs.tickables().ConstructionSystem(); cs != nil {
+		cp.queues = cs.GetAllQueues()
+	}
+	if gs.DeliveryMgr != nil {
+		cp.deliveryNextID = gs.DeliveryMgr.GetNextID()
+	}
+	if gs.CreditLedger != nil {
+		cp.outstanding = gs.CreditLedger.GetAllOutstanding()
+	}
+	return cp
+}
+
+// restore puts everything the checkpoint captured back. Event log entries,
+// trade history and market prices from the undone steps are left as is, and
+// the ledger books the credits put back as a rollback rather than erasing
+// the steps' entries.
+func (cp *batchCheckpoint) restore(gs *GameServer) {
+	for p, credits := range cp.credits {
+		p.Credits = credits
+	}
+	for planet, stored := range cp.storage {
+		for res, s := range planet.StoredResources {
+			if amount, ok := stored[res]; ok {
+				s.Amount = amount
+			} else {
+				delete(planet.StoredResources, res)
+			}
+		}
+	}
+	for ship, st := range cp.ships {
+		ship.Status = st.status
+		ship.CurrentSystem = st.currentSystem
+		ship.TargetSystem = st.targetSystem
+		ship.TravelProgress = st.travelProgress
+		ship.CurrentFuel = st.fuel
+		ship.CargoHold = st.cargo
+		ship.RoutePath = st.routePath
+		ship.DockedAtPlanet = st.dockedAt
+	}
+	if cs := gs.tickables().ConstructionSystem(); cs != nil && cp.queues != nil {
+		cs.RestoreQueues(cp.queues)
+	}
+	// Market trades leave a delivery behind that would otherwise still arrive
+	if gs.DeliveryMgr != nil && cp.deliveryNextID > 0 {
+		gs.DeliveryMgr.DiscardFrom(cp.deliveryNextID)
+	}
+	if gs.CreditLedger != nil && cp.outstanding != nil {
+		gs.CreditLedger.RestoreLedger(cp.outstanding, nil)
+	}
+}
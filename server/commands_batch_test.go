@@ -0,0 +1,175 @@
+package server
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/utils"
+)
+
+// batchTestServer has one player with a stocked planet and a ship, and stub
+// trade/cargo/build handlers: trade spends credits for Iron and, like a
+// market buy, books a delivery and outstanding credit; cargo moves Iron onto
+// the ship; build always fails.
+func batchTestServer() (*GameServer, *entities.Player, *entities.Planet, *entities.Ship) {
+	gs := New(1280, 720)
+	player := entities.NewPlayer(1, "Alpha", utils.PlayerGreen, entities.PlayerTypeHuman)
+	player.Credits = 1000
+	planet := &entities.Planet{StoredResources: map[string]*entities.ResourceStorage{
+		"Iron": {ResourceType: "Iron", Amount: 10, Capacity: 500},
+	}}
+	player.OwnedPlanets = append(player.OwnedPlanets, planet)
+	ship := &entities.Ship{Owner: "Alpha", CargoHold: map[string]int{}, CurrentFuel: 50}
+	player.OwnedShips = append(player.OwnedShips, ship)
+	gs.State.Players = []*entities.Player{player}
+	gs.DeliveryMgr = economy.NewDeliveryManager()
+	gs.CreditLedger = economy.NewCreditLedger()
+
+	cr := NewCommandRegistry()
+	cr.Register(game.CmdTrade, func(cmd game.GameCommand) {
+		td := cmd.Data.(game.TradeCommandData)
+		player.Credits -= td.Quantity * 10
+		planet.AddStoredResource(td.Resource, td.Quantity)
+		gs.DeliveryMgr.CreateLocalDelivery(0, "Alpha", "market", td.Resource, td.Quantity, 10, td.Quantity*10, 0, 0, economy.DeliveryDirectionBuy, 5)
+		gs.CreditLedger.AddOutstanding("Alpha", "market", td.Quantity*10)
+		sendSuccess(cmd, td.Quantity)
+	})
+	cr.Register(game.CmdCargoLoad, func(cmd game.GameCommand) {
+		cd := cmd.Data.(game.CargoCommandData)
+		ship.CargoHold[cd.Resource] += planet.RemoveStoredResource(cd.Resource, cd.Quantity)
+		ship.CurrentFuel -= 5
+		sendSuccess(cmd, cd.Quantity)
+	})
+	cr.Register(game.CmdBuild, func(cmd game.GameCommand) {
+		sendResult(cmd, fmt.Errorf("planet not found"))
+	})
+	gs.cmdRegistry = cr
+	return gs, player, planet, ship
+}
+
+func runBatch(gs *GameServer, bd game.BatchCommandData) interface{} {
+	cmd := game.GameCommand{Type: game.CmdBatch, Data: bd, Result: make(chan interface{}, 1), PlayerName: "Alpha"}
+	gs.handleBatchCommand(cmd)
+	return <-cmd.Result
+}
+
+func TestBatchRunsEveryStep(t *testing.T) {
+	gs, player, planet, ship := batchTestServer()
+
+	out := runBatch(gs, game.BatchCommandData{Steps: []game.BatchStep{
+		{Type: game.CmdTrade, Data: game.TradeCommandData{Resource: "Iron", Quantity: 20, Buy: true}},
+		{Type: game.CmdBuild, Data: game.BuildCommandData{PlanetID: 99, BuildingType: "Mine"}},
+		{Type: game.CmdCargoLoad, Data: game.CargoCommandData{Resource: "Iron", Quantity: 25, Load: true}},
+	}})
+	res, ok := out.(game.BatchResult)
+	if !ok {
+		t.Fatalf("expected BatchResult, got %#v", out)
+	}
+	if res.RolledBack || len(res.Steps) != 3 {
+		t.Fatalf("unexpected result %+v", res)
+	}
+	if res.Steps[0].Err != nil || res.Steps[1].Err == nil || res.Steps[2].Err != nil {
+		t.Errorf("expected only the build to fail, got %+v", res.Steps)
+	}
+	if player.Credits != 800 || planet.GetStoredAmount("Iron") != 5 || ship.CargoHold["Iron"] != 25 {
+		t.Errorf("expected trade and load applied, got %dcr, %d stored, %d in cargo",
+			player.Credits, planet.GetStoredAmount("Iron"), ship.CargoHold["Iron"])
+	}
+}
+
+func TestAtomicBatchRollsBack(t *testing.T) {
+	gs, player, planet, ship := batchTestServer()
+
+	out := runBatch(gs, game.BatchCommandData{Atomic: true, Steps: []game.BatchStep{
+		{Type: game.CmdTrade, Data: game.TradeCommandData{Resource: "Iron", Quantity: 20, Buy: true}},
+		{Type: game.CmdTrade, Data: game.TradeCommandData{Resource: "Water", Quantity: 5, Buy: true}},
+		{Type: game.CmdCargoLoad, Data: game.CargoCommandData{Resource: "Iron", Quantity: 25, Load: true}},
+		{Type: game.CmdBuild, Data: game.BuildCommandData{PlanetID: 99, BuildingType: "Mine"}},
+		{Type: game.CmdTrade, Data: game.TradeCommandData{Resource: "Iron", Quantity: 1, Buy: true}},
+	}})
+	res := out.(game.BatchResult)
+	if !res.RolledBack {
+		t.Fatal("expected the batch to roll back")
+	}
+	if res.Steps[3].Err == nil || !res.Steps[4].Skipped || res.Steps[0].Skipped {
+		t.Errorf("expected step 3 to fail and step 4 to be skipped, got %+v", res.Steps)
+	}
+	if player.Credits != 1000 {
+		t.Errorf("credits not restored: %d", player.Credits)
+	}
+	if planet.GetStoredAmount("Iron") != 10 {
+		t.Errorf("planet Iron not restored: %d", planet.GetStoredAmount("Iron"))
+	}
+	if _, ok := planet.StoredResources["Water"]; ok {
+		t.Error("Water bought in the rolled-back batch is still stored")
+	}
+	if ship.CargoHold["Iron"] != 0 || ship.CurrentFuel != 50 {
+		t.Errorf("ship not restored: cargo %v, fuel %d", ship.CargoHold, ship.CurrentFuel)
+	}
+	if pending := gs.DeliveryMgr.GetActiveDeliveries(); len(pending) != 0 {
+		t.Errorf("rolled-back trades left %d pending deliveries", len(pending))
+	}
+	if owed := gs.CreditLedger.GetOutstanding("Alpha", "market"); owed != 0 {
+		t.Errorf("rolled-back trades left %dcr outstanding", owed)
+	}
+}
+
+func TestBatchValidation(t *testing.T) {
+	gs, player, _, _ := batchTestServer()
+
+	cases := []game.BatchCommandData{
+		{},
+		{Steps: []game.BatchStep{{Type: game.CmdSave, Data: "Alpha"}}},
+		{Steps: []game.BatchStep{{Type: game.CmdBatch}}},
+		{Atomic: true, Steps: []game.BatchStep{{Type: game.CmdColonize, Data: game.ColonizeCommandData{ShipID: 1, PlanetID: 2}}}},
+		{Steps: make([]game.BatchStep, game.MaxBatchSteps+1)},
+	}
+	for i, bd := range cases {
+		if _, ok := runBatch(gs, bd).(error); !ok {
+			t.Errorf("case %d: expected the batch to be rejected", i)
+		}
+	}
+	if player.Credits != 1000 {
+		t.Errorf("rejected batches changed credits: %d", player.Credits)
+	}
+}
+
+func TestJournalBatchRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.journal")
+	j, err := OpenCommandJournal(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	j.WriteHeader(1, 0, nil, nil)
+	j.Append(4, game.GameCommand{Type: game.CmdBatch, PlayerName: "Alpha", Data: game.BatchCommandData{
+		Atomic: true,
+		Steps: []game.BatchStep{
+			{Type: game.CmdTrade, Data: game.TradeCommandData{Resource: "Iron", Quantity: 3, Buy: true}},
+			{Type: game.CmdMoveShip, Data: game.ShipMoveCommandData{ShipID: 7, TargetSystemID: 2}},
+		},
+	}})
+	j.Close()
+
+	_, entries, err := ReadJournal(path)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("read: %v (%d entries)", err, len(entries))
+	}
+	cmd, err := entries[0].Command()
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	bd, ok := cmd.Data.(game.BatchCommandData)
+	if !ok || !bd.Atomic || len(bd.Steps) != 2 {
+		t.Fatalf("batch not restored: %#v", cmd.Data)
+	}
+	if td, ok := bd.Steps[0].Data.(game.TradeCommandData); !ok || td.Quantity != 3 || !td.Buy {
+		t.Errorf("trade step not restored: %#v", bd.Steps[0].Data)
+	}
+	if md, ok := bd.Steps[1].Data.(game.ShipMoveCommandData); !ok || md.ShipID != 7 {
+		t.Errorf("move step not restored: %#v", bd.Steps[1].Data)
+	}
+}
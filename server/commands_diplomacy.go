@@ -0,0 +1,83 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// spyOpCosts and spyOpDurations are what each espionage operation costs up
+// front and how many ticks it runs.
+var (
+	spyOpCosts     = map[string]int{"intel": 500, "sabotage": 2000, "steal_tech": 5000}
+	spyOpDurations = map[string]int{"intel": 200, "sabotage": 500, "steal_tech": 1000}
+)
+
+func (gs *GameServer) handleEspionageCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.EspionageCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid espionage data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.EspionageMgr == nil {
+		sendResult(cmd, fmt.Errorf("espionage not initialized"))
+		return
+	}
+	cost, ok := spyOpCosts[data.Type]
+	if !ok {
+		sendResult(cmd, fmt.Errorf("type must be 'intel', 'sabotage', or 'steal_tech'"))
+		return
+	}
+	if human.Credits < cost {
+		sendResult(cmd, fmt.Errorf("need %d credits", cost))
+		return
+	}
+
+	human.Credits -= cost
+	gs.recordCredits(human, economy.LedgerEntry{
+		Amount: -cost, Reason: economy.ReasonEspionage, Source: "espionage",
+		Counterparty: data.Target, Detail: data.Type,
+	})
+	sendSuccess(cmd, gs.EspionageMgr.LaunchOperation(human.Name, data.Target, data.Type, data.SystemID, cost, spyOpDurations[data.Type]))
+}
+
+func (gs *GameServer) handlePostBountyCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.PostBountyCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid bounty data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.BountyBoard == nil {
+		sendResult(cmd, fmt.Errorf("bounty board not initialized"))
+		return
+	}
+	if data.Reward <= 0 {
+		sendResult(cmd, fmt.Errorf("reward must be positive"))
+		return
+	}
+	if human.Credits < data.Reward {
+		sendResult(cmd, fmt.Errorf("insufficient credits"))
+		return
+	}
+
+	human.Credits -= data.Reward
+	gs.recordCredits(human, economy.LedgerEntry{
+		Amount: -data.Reward, Reason: economy.ReasonBounty, Source: "bounties",
+		Detail: "reward escrowed: " + data.Description,
+	})
+	sendSuccess(cmd, gs.BountyBoard.PostBounty(human.Name, data.Type, data.Description, data.Reward,
+		data.Resource, data.Quantity, data.PlanetID, data.SystemID))
+}
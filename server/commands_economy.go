@@ -167,3 +167,194 @@ func (gs *GameServer) handleCancelOrderCommand(cmd game.GameCommand) {
 		sendResult(cmd, fmt.Errorf("order #%d not found", data.OrderID))
 	}
 }
+
+func (gs *GameServer) handleLimitOrderCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.LimitOrderCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid limit order data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.OrderBook == nil {
+		sendResult(cmd, fmt.Errorf("order book not initialized"))
+		return
+	}
+
+	order, err := gs.OrderBook.PlaceOrderWithOptions(data.SystemID, data.PlanetID, human.Name,
+		data.Resource, data.Action, data.Quantity, data.Price, economy.OrderOptions{
+			Type:        data.Type,
+			StopPrice:   data.StopPrice,
+			TimeInForce: data.TimeInForce,
+			ExpiresAt:   data.ExpiresAt,
+			Display:     data.Display,
+		})
+	if err != nil {
+		sendResult(cmd, err)
+		return
+	}
+	sendSuccess(cmd, order)
+}
+
+func (gs *GameServer) handleCancelLimitOrderCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.CancelLimitOrderCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid cancel limit order data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.OrderBook == nil {
+		sendResult(cmd, fmt.Errorf("order book not initialized"))
+		return
+	}
+
+	if !gs.OrderBook.CancelOrder(data.OrderID, human.Name) {
+		sendResult(cmd, fmt.Errorf("limit order #%d not found", data.OrderID))
+		return
+	}
+	sendSuccess(cmd, map[string]interface{}{"cancelled": data.OrderID})
+}
+
+func (gs *GameServer) handleFuturesOpenCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.FuturesOpenCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid futures order data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.Futures == nil || gs.State.Market == nil {
+		sendResult(cmd, fmt.Errorf("futures exchange not initialized"))
+		return
+	}
+
+	pos, err := gs.Futures.Open(gs.TickManager.GetCurrentTick(), gs.State.Market, economy.FuturesOrder{
+		Player:     human.Name,
+		Kind:       data.Kind,
+		Side:       data.Side,
+		Resource:   data.Resource,
+		Quantity:   data.Quantity,
+		Term:       data.Term,
+		Strike:     data.Strike,
+		Settlement: data.Settlement,
+		SystemID:   data.SystemID,
+		PlanetID:   data.PlanetID,
+	})
+	if err != nil {
+		sendResult(cmd, err)
+		return
+	}
+	sendSuccess(cmd, pos)
+}
+
+func (gs *GameServer) handleFuturesCloseCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.FuturesCloseCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid futures close data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.Futures == nil || gs.State.Market == nil {
+		sendResult(cmd, fmt.Errorf("futures exchange not initialized"))
+		return
+	}
+
+	// An expired position stays open until the next price update settles
+	// it; it can't be closed at a model value with no time left.
+	tick := gs.TickManager.GetCurrentTick()
+	for _, p := range gs.Futures.GetPositions(human.Name, true) {
+		if p.ID == data.PositionID && tick >= p.ExpiryTick {
+			sendResult(cmd, fmt.Errorf("position #%d expired at tick %d and settles at the next price update", p.ID, p.ExpiryTick))
+			return
+		}
+	}
+
+	pos, err := gs.Futures.Close(tick, gs.State.Market, data.PositionID, human.Name)
+	if err != nil {
+		sendResult(cmd, fmt.Errorf("position #%d: %w", data.PositionID, err))
+		return
+	}
+	sendSuccess(cmd, pos)
+}
+
+func (gs *GameServer) handleBlackMarketCommand(cmd game.GameCommand) {
+	data, ok := cmd.Data.(game.BlackMarketCommandData)
+	if !ok {
+		sendResult(cmd, fmt.Errorf("invalid black market data"))
+		return
+	}
+
+	human := gs.resolvePlayer(cmd)
+	if human == nil {
+		sendResult(cmd, fmt.Errorf("no player"))
+		return
+	}
+	if gs.BlackMarket == nil || gs.State.Market == nil {
+		sendResult(cmd, fmt.Errorf("black market not initialized"))
+		return
+	}
+
+	var planet *entities.Planet
+	for _, p := range human.OwnedPlanets {
+		if p != nil && p.GetID() == data.PlanetID {
+			planet = p
+			break
+		}
+	}
+	if planet == nil {
+		sendResult(cmd, fmt.Errorf("planet not found or not owned"))
+		return
+	}
+
+	if !data.Buy {
+		stored := planet.GetStoredAmount(data.Resource)
+		if stored < data.Quantity {
+			sendResult(cmd, fmt.Errorf("need %d %s, have %d", data.Quantity, data.Resource, stored))
+			return
+		}
+		planet.RemoveStoredResource(data.Resource, data.Quantity)
+		credits, seized := gs.BlackMarket.BlackMarketSell(gs.State.Market.GetSellPrice(data.Resource), data.Quantity, gs.rng("BlackMarket"))
+		if !seized {
+			human.Credits += credits
+			gs.recordCredits(human, economy.LedgerEntry{
+				Amount: credits, Reason: economy.ReasonBlackMarket, Source: "black_market",
+				Resource: data.Resource, Quantity: data.Quantity,
+			})
+			gs.recordTrade(human, data.Resource, data.Quantity, "sell", credits)
+		}
+		sendSuccess(cmd, game.BlackMarketOutcome{Seized: seized, Credits: credits})
+		return
+	}
+
+	cost := gs.BlackMarket.BlackMarketBuy(gs.State.Market.GetBuyPrice(data.Resource), data.Quantity)
+	if human.Credits < cost {
+		sendResult(cmd, fmt.Errorf("need %d credits (1.5x market price)", cost))
+		return
+	}
+	human.Credits -= cost
+	planet.AddStoredResource(data.Resource, data.Quantity)
+	gs.recordCredits(human, economy.LedgerEntry{
+		Amount: -cost, Reason: economy.ReasonBlackMarket, Source: "black_market",
+		Resource: data.Resource, Quantity: data.Quantity,
+	})
+	gs.recordTrade(human, data.Resource, data.Quantity, "buy", cost)
+	sendSuccess(cmd, game.BlackMarketOutcome{Cost: cost})
+}
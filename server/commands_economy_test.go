@@ -0,0 +1,89 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+	"github.com/hunterjsb/xandaris/utils"
+)
+
+// blackMarketTestServer has one player with a planet holding 100 Iron.
+func blackMarketTestServer(seed int64) (*GameServer, *entities.Player, *entities.Planet) {
+	gs := New(1280, 720)
+	gs.State.Seed = seed
+	gs.randSource = tickable.NewRandSource(seed)
+	gs.State.Market = economy.NewMarket()
+	gs.BlackMarket = economy.NewBlackMarket()
+	gs.Ledger = economy.NewLedger()
+	player := entities.NewPlayer(1, "Alpha", utils.PlayerGreen, entities.PlayerTypeHuman)
+	player.Credits = 10000
+	planet := &entities.Planet{StoredResources: map[string]*entities.ResourceStorage{
+		"Iron": {ResourceType: "Iron", Amount: 100, Capacity: 500},
+	}}
+	player.OwnedPlanets = append(player.OwnedPlanets, planet)
+	gs.State.Players = []*entities.Player{player}
+	gs.initCommandRegistry()
+	return gs, player, planet
+}
+
+func runBlackMarket(gs *GameServer, data game.BlackMarketCommandData) interface{} {
+	cmd := game.GameCommand{Type: game.CmdBlackMarket, Data: data, Result: make(chan interface{}, 1), PlayerName: "Alpha"}
+	gs.runCommand(cmd)
+	return <-cmd.Result
+}
+
+func TestBlackMarketCommand(t *testing.T) {
+	sells := func(seed int64) []game.BlackMarketOutcome {
+		gs, player, planet := blackMarketTestServer(seed)
+		var outcomes []game.BlackMarketOutcome
+		credits := player.Credits
+		for range 20 {
+			out, ok := runBlackMarket(gs, game.BlackMarketCommandData{Resource: "Iron", Quantity: 1}).(game.BlackMarketOutcome)
+			if !ok {
+				t.Fatalf("expected a BlackMarketOutcome")
+			}
+			outcomes = append(outcomes, out)
+			credits += out.Credits
+		}
+		if planet.GetStoredAmount("Iron") != 80 || player.Credits != credits {
+			t.Errorf("expected 20 Iron sold for %dcr, have %d Iron and %dcr", credits, planet.GetStoredAmount("Iron"), player.Credits)
+		}
+		entries := gs.Ledger.Entries(player.Name, 0, 0)
+		if len(entries) == 0 || entries[len(entries)-1].Balance != player.Credits {
+			t.Fatalf("expected the ledger to end at %dcr, got %+v", player.Credits, entries)
+		}
+		for _, e := range entries {
+			if e.Reason != economy.ReasonOpening && e.Reason != economy.ReasonBlackMarket {
+				t.Errorf("expected black market entries, got %+v", e)
+			}
+		}
+		return outcomes
+	}
+
+	a := sells(7)
+	if !reflect.DeepEqual(a, sells(7)) {
+		t.Error("the same seed seized different sales")
+	}
+	seized := 0
+	for _, out := range a {
+		if out.Seized {
+			seized++
+		}
+	}
+	if seized == 0 || seized == len(a) {
+		t.Errorf("expected some of %d sales seized, got %d", len(a), seized)
+	}
+
+	gs, player, planet := blackMarketTestServer(7)
+	if _, ok := runBlackMarket(gs, game.BlackMarketCommandData{Resource: "Iron", Quantity: 500}).(error); !ok {
+		t.Error("expected selling more than is stored to fail")
+	}
+	out := runBlackMarket(gs, game.BlackMarketCommandData{Buy: true, Resource: "Iron", Quantity: 10}).(game.BlackMarketOutcome)
+	if out.Cost <= 0 || player.Credits != 10000-out.Cost || planet.GetStoredAmount("Iron") != 110 {
+		t.Errorf("expected 10 Iron bought, got %+v with %dcr and %d Iron", out, player.Credits, planet.GetStoredAmount("Iron"))
+	}
+}
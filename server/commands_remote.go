@@ -28,6 +28,7 @@ var commandEndpoints = map[game.CommandType]string{
 	game.CmdCancelConstruction: "/api/construction/cancel",
 	game.CmdDemolish:           "/api/demolish",
 	game.CmdTransferFuel:       "/api/ships/transfer-fuel",
+	game.CmdLimitOrder:         "/api/orders/limit",
 }
 
 // convertCommandToAPI converts a game command's data to API-compatible JSON.
@@ -115,6 +116,20 @@ func convertCommandToAPI(cmd game.GameCommand) ([]byte, error) {
 			"ship_id":   d.ShipID,
 			"planet_id": d.PlanetID,
 		})
+	case game.LimitOrderCommandData:
+		return json.Marshal(map[string]interface{}{
+			"system_id":        d.SystemID,
+			"planet_id":        d.PlanetID,
+			"resource":         d.Resource,
+			"action":           d.Action,
+			"quantity":         d.Quantity,
+			"price":            d.Price,
+			"type":             d.Type,
+			"stop_price":       d.StopPrice,
+			"time_in_force":    d.TimeInForce,
+			"expires_at":       d.ExpiresAt,
+			"display_quantity": d.Display,
+		})
 	default:
 		// Try generic marshal as fallback
 		return json.Marshal(cmd.Data)
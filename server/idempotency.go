@@ -0,0 +1,105 @@
+package server
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+const (
+	// idempotencyTTL is how long a command's result is remembered for
+	// resubmissions with the same Idempotency-Key.
+	idempotencyTTL = 10 * time.Minute
+	// idempotencyMaxKeys caps the results remembered per player; the oldest
+	// is forgotten first.
+	idempotencyMaxKeys = 256
+)
+
+// idempotentResult is the remembered outcome of a keyed command.
+type idempotentResult struct {
+	cmdType game.CommandType
+	data    interface{}
+	result  interface{} // what the handler replied, error included
+	at      time.Time
+}
+
+// idempotencyCache remembers recent results per player and key. It's only
+// touched from executeCommand, under gs.mu, so it has no lock of its own.
+type idempotencyCache struct {
+	players map[string]map[string]*idempotentResult
+}
+
+func newIdempotencyCache() *idempotencyCache {
+	return &idempotencyCache{players: make(map[string]map[string]*idempotentResult)}
+}
+
+func (c *idempotencyCache) get(player, key string, now time.Time) (*idempotentResult, bool) {
+	res, ok := c.players[player][key]
+	if !ok || now.Sub(res.at) > idempotencyTTL {
+		return nil, false
+	}
+	return res, true
+}
+
+// put remembers res, dropping expired entries and, past the cap, the oldest.
+func (c *idempotencyCache) put(player, key string, res *idempotentResult) {
+	keys, ok := c.players[player]
+	if !ok {
+		keys = make(map[string]*idempotentResult)
+		c.players[player] = keys
+	}
+	for k, old := range keys {
+		if res.at.Sub(old.at) > idempotencyTTL {
+			delete(keys, k)
+		}
+	}
+	for len(keys) >= idempotencyMaxKeys {
+		oldest := ""
+		for k, old := range keys {
+			if oldest == "" || old.at.Before(keys[oldest].at) {
+				oldest = k
+			}
+		}
+		delete(keys, oldest)
+	}
+	keys[key] = res
+}
+
+// executeIdempotent runs a command that carries an Idempotency-Key. The
+// first submission runs normally and its reply is remembered; a later one
+// from the same player with the same key gets that reply without running.
+// Reusing a key for a different command is rejected.
+func (gs *GameServer) executeIdempotent(cmd game.GameCommand) {
+	if gs.idempotency == nil {
+		gs.idempotency = newIdempotencyCache()
+	}
+	now := time.Now()
+	if prev, ok := gs.idempotency.get(cmd.PlayerName, cmd.IdempotencyKey, now); ok {
+		if prev.cmdType != cmd.Type || !reflect.DeepEqual(prev.data, cmd.Data) {
+			sendResult(cmd, fmt.Errorf("Idempotency-Key %q was already used for a different request", cmd.IdempotencyKey))
+			return
+		}
+		sendSuccess(cmd, prev.result)
+		return
+	}
+
+	// Capture the handler's reply, then pass it on to the caller.
+	reply := cmd.Result
+	cmd.Result = make(chan interface{}, 1)
+	gs.runCommand(cmd)
+	var result interface{}
+	select {
+	case result = <-cmd.Result:
+	default: // the handler doesn't reply
+	}
+	gs.idempotency.put(cmd.PlayerName, cmd.IdempotencyKey, &idempotentResult{
+		cmdType: cmd.Type,
+		data:    cmd.Data,
+		result:  result,
+		at:      now,
+	})
+	cmd.Result = reply
+	sendSuccess(cmd, result)
+}
@@ -0,0 +1,102 @@
+package server
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/xandaris/game"
+)
+
+// idempotencyTestServer counts trade executions and logs an event for each.
+func idempotencyTestServer() (*GameServer, *int) {
+	gs := New(1280, 720)
+	gs.Events = game.NewEventLog(10)
+	runs := 0
+	cr := NewCommandRegistry()
+	cr.Register(game.CmdTrade, func(cmd game.GameCommand) {
+		runs++
+		gs.Events.Add(0, "", game.EventTrade, cmd.PlayerName, "traded")
+		sendSuccess(cmd, runs)
+	})
+	cr.Register(game.CmdBuild, func(cmd game.GameCommand) {
+		runs++
+		sendResult(cmd, fmt.Errorf("insufficient credits"))
+	})
+	gs.cmdRegistry = cr
+	return gs, &runs
+}
+
+func submit(gs *GameServer, cmdType game.CommandType, data interface{}, player, key, reqID string) interface{} {
+	cmd := game.GameCommand{
+		Type: cmdType, Data: data, Result: make(chan interface{}, 1),
+		PlayerName: player, RequestID: reqID, IdempotencyKey: key,
+	}
+	gs.executeCommand(cmd)
+	return <-cmd.Result
+}
+
+func TestIdempotentResubmission(t *testing.T) {
+	gs, runs := idempotencyTestServer()
+	trade := game.TradeCommandData{Resource: "Iron", Quantity: 5, Buy: true}
+
+	first := submit(gs, game.CmdTrade, trade, "Alpha", "k1", "req-1")
+	again := submit(gs, game.CmdTrade, trade, "Alpha", "k1", "req-2")
+	if *runs != 1 || first != 1 || again != 1 {
+		t.Fatalf("expected one run replayed, got %d runs, results %v and %v", *runs, first, again)
+	}
+
+	// Keys are per player, and commands without a key always run.
+	submit(gs, game.CmdTrade, trade, "Beta", "k1", "req-3")
+	submit(gs, game.CmdTrade, trade, "Alpha", "", "req-4")
+	if *runs != 3 {
+		t.Errorf("expected 3 runs, got %d", *runs)
+	}
+
+	// Failures are remembered too.
+	submit(gs, game.CmdBuild, game.BuildCommandData{PlanetID: 1}, "Alpha", "k2", "req-5")
+	if _, ok := submit(gs, game.CmdBuild, game.BuildCommandData{PlanetID: 1}, "Alpha", "k2", "req-6").(error); !ok || *runs != 4 {
+		t.Errorf("expected the build error to be replayed, got %d runs", *runs)
+	}
+}
+
+func TestIdempotencyKeyReuseRejected(t *testing.T) {
+	gs, runs := idempotencyTestServer()
+	submit(gs, game.CmdTrade, game.TradeCommandData{Resource: "Iron", Quantity: 5, Buy: true}, "Alpha", "k1", "")
+	out := submit(gs, game.CmdTrade, game.TradeCommandData{Resource: "Iron", Quantity: 50, Buy: true}, "Alpha", "k1", "")
+	if _, ok := out.(error); !ok || *runs != 1 {
+		t.Errorf("expected reuse with different data to be rejected, got %v after %d runs", out, *runs)
+	}
+}
+
+func TestCommandEventsCarryRequestID(t *testing.T) {
+	gs, _ := idempotencyTestServer()
+	submit(gs, game.CmdTrade, game.TradeCommandData{Resource: "Iron", Quantity: 1}, "Alpha", "", "req-7")
+	gs.Events.Add(1, "", game.EventAlert, "", "tick event")
+
+	events := gs.Events.Recent(2)
+	if events[1].RequestID != "req-7" {
+		t.Errorf("trade event request ID = %q, want req-7", events[1].RequestID)
+	}
+	if events[0].RequestID != "" {
+		t.Errorf("event outside a command tagged with %q", events[0].RequestID)
+	}
+}
+
+func TestIdempotencyCacheEvictsOldest(t *testing.T) {
+	c := newIdempotencyCache()
+	base := time.Now()
+	for i := 0; i <= idempotencyMaxKeys; i++ {
+		c.put("Alpha", fmt.Sprint(i), &idempotentResult{at: base.Add(time.Duration(i) * time.Second)})
+	}
+	now := base.Add(idempotencyMaxKeys * time.Second)
+	if _, ok := c.get("Alpha", "0", now); ok {
+		t.Error("oldest key survived past the cap")
+	}
+	if _, ok := c.get("Alpha", "1", now); !ok {
+		t.Error("second-oldest key was evicted")
+	}
+	if _, ok := c.get("Alpha", "1", now.Add(idempotencyTTL)); ok {
+		t.Error("expired key still returned")
+	}
+}
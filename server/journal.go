@@ -0,0 +1,345 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/systems"
+)
+
+// journalStart marks the header line written when a journal session opens.
+const journalStart game.CommandType = "journal_start"
+
+// JournalEntry is one line of a command journal. The first line of every
+// session is a header (Type journalStart) carrying the seed, game config,
+// starting tick and the account players that existed when journaling began.
+type JournalEntry struct {
+	Tick     int64            `json:"tick"`
+	Time     time.Time        `json:"time"`
+	Type     game.CommandType `json:"type"`
+	Player   string           `json:"player,omitempty"`
+	Request  string           `json:"request,omitempty"` // API request ID, for auditing
+	Data     json.RawMessage  `json:"data,omitempty"`
+	Seed     int64            `json:"seed,omitempty"`
+	Config   *game.GameConfig `json:"config,omitempty"` // nil in journals from before game configs
+	Accounts []string         `json:"accounts,omitempty"`
+}
+
+// journalPayloads maps each command type to the concrete type of its Data
+// field so replayed entries decode back into what the handlers expect.
+// Types missing here carry no payload, except batches (see decodePayload).
+var journalPayloads = map[game.CommandType]reflect.Type{
+	game.CmdSetSpeed:           reflect.TypeOf(systems.TickSpeed(0)),
+	game.CmdSetSchedule:        reflect.TypeOf(game.SetScheduleCommandData{}),
+	game.CmdTrade:              reflect.TypeOf(game.TradeCommandData{}),
+	game.CmdCargoLoad:          reflect.TypeOf(game.CargoCommandData{}),
+	game.CmdCargoUnload:        reflect.TypeOf(game.CargoCommandData{}),
+	game.CmdBuild:              reflect.TypeOf(game.BuildCommandData{}),
+	game.CmdBuildShip:          reflect.TypeOf(game.ShipBuildCommandData{}),
+	game.CmdMoveShip:           reflect.TypeOf(game.ShipMoveCommandData{}),
+	game.CmdUpgrade:            reflect.TypeOf(game.UpgradeCommandData{}),
+	game.CmdRefuel:             reflect.TypeOf(game.ShipRefuelCommandData{}),
+	game.CmdColonize:           reflect.TypeOf(game.ColonizeCommandData{}),
+	game.CmdRegisterPlayer:     reflect.TypeOf(game.RegisterPlayerCommandData{}),
+	game.CmdWorkforceAssign:    reflect.TypeOf(game.WorkforceAssignCommandData{}),
+	game.CmdCancelConstruction: reflect.TypeOf(game.CancelConstructionCommandData{}),
+	game.CmdStandingOrder:      reflect.TypeOf(game.StandingOrderCommandData{}),
+	game.CmdCancelOrder:        reflect.TypeOf(game.CancelOrderCommandData{}),
+	game.CmdLimitOrder:         reflect.TypeOf(game.LimitOrderCommandData{}),
+	game.CmdCancelLimitOrder:   reflect.TypeOf(game.CancelLimitOrderCommandData{}),
+	game.CmdFuturesOpen:        reflect.TypeOf(game.FuturesOpenCommandData{}),
+	game.CmdFuturesClose:       reflect.TypeOf(game.FuturesCloseCommandData{}),
+	game.CmdFleetMove:          reflect.TypeOf(game.FleetMoveCommandData{}),
+	game.CmdFleetCreate:        reflect.TypeOf(game.FleetCreateCommandData{}),
+	game.CmdFleetDisband:       reflect.TypeOf(game.FleetDisbandCommandData{}),
+	game.CmdFleetAddShip:       reflect.TypeOf(game.FleetAddShipCommandData{}),
+	game.CmdFleetRemoveShip:    reflect.TypeOf(game.FleetRemoveShipCommandData{}),
+	game.CmdDockShip:           reflect.TypeOf(game.DockShipCommandData{}),
+	game.CmdUndockShip:         reflect.TypeOf(game.UndockShipCommandData{}),
+	game.CmdSellAtDock:         reflect.TypeOf(game.SellAtDockCommandData{}),
+	game.CmdBuyAtDock:          reflect.TypeOf(game.SellAtDockCommandData{}),
+	game.CmdDemolish:           reflect.TypeOf(game.DemolishCommandData{}),
+	game.CmdTransferFuel:       reflect.TypeOf(game.TransferFuelCommandData{}),
+	game.CmdEspionage:          reflect.TypeOf(game.EspionageCommandData{}),
+	game.CmdPostBounty:         reflect.TypeOf(game.PostBountyCommandData{}),
+	game.CmdBlackMarket:        reflect.TypeOf(game.BlackMarketCommandData{}),
+}
+
+// unjournaledCommands don't mutate the simulation and are left out.
+var unjournaledCommands = map[game.CommandType]bool{
+	game.CmdSave: true,
+}
+
+// CommandJournal appends executed commands to a JSON-lines file. It doubles
+// as an audit trail: every line records who issued what, at which tick.
+type CommandJournal struct {
+	mu   sync.Mutex
+	file *os.File
+	enc  *json.Encoder
+}
+
+// OpenCommandJournal opens (or creates) a journal file for appending.
+func OpenCommandJournal(path string) (*CommandJournal, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+	return &CommandJournal{file: f, enc: json.NewEncoder(f)}, nil
+}
+
+// WriteHeader starts a new journal session. config may be nil.
+func (j *CommandJournal) WriteHeader(seed, tick int64, config *game.GameConfig, accounts []string) error {
+	return j.write(JournalEntry{Tick: tick, Time: time.Now(), Type: journalStart, Seed: seed, Config: config, Accounts: accounts})
+}
+
+// Append records a command executed at the given tick.
+func (j *CommandJournal) Append(tick int64, cmd game.GameCommand) error {
+	data := cmd.Data
+	if rd, ok := data.(game.RegisterPlayerCommandData); ok {
+		rd.AccountKey = "" // never write credentials to the audit trail
+		data = rd
+	}
+	entry := JournalEntry{Tick: tick, Time: time.Now(), Type: cmd.Type, Player: cmd.PlayerName, Request: cmd.RequestID}
+	if data != nil {
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return fmt.Errorf("journal %s: %w", cmd.Type, err)
+		}
+		entry.Data = raw
+	}
+	return j.write(entry)
+}
+
+func (j *CommandJournal) write(entry JournalEntry) error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.enc.Encode(entry)
+}
+
+// Close flushes and closes the journal file.
+func (j *CommandJournal) Close() error {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	return j.file.Close()
+}
+
+// Command rebuilds the GameCommand recorded by this entry.
+func (e JournalEntry) Command() (game.GameCommand, error) {
+	cmd := game.GameCommand{Type: e.Type, PlayerName: e.Player, RequestID: e.Request}
+	data, err := decodePayload(e.Type, e.Data)
+	if err != nil {
+		return cmd, err
+	}
+	cmd.Data = data
+	return cmd, nil
+}
+
+// decodePayload decodes a journaled command payload into the type its
+// handler expects. Batches are decoded step by step.
+func decodePayload(t game.CommandType, raw json.RawMessage) (interface{}, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+	if t == game.CmdBatch {
+		var batch struct {
+			Steps []struct {
+				Type game.CommandType
+				Data json.RawMessage
+			}
+			Atomic bool
+		}
+		if err := json.Unmarshal(raw, &batch); err != nil {
+			return nil, fmt.Errorf("decode %s payload: %w", t, err)
+		}
+		bd := game.BatchCommandData{Atomic: batch.Atomic}
+		for _, step := range batch.Steps {
+			data, err := decodePayload(step.Type, step.Data)
+			if err != nil {
+				return nil, err
+			}
+			bd.Steps = append(bd.Steps, game.BatchStep{Type: step.Type, Data: data})
+		}
+		return bd, nil
+	}
+	pt, ok := journalPayloads[t]
+	if !ok {
+		return nil, nil
+	}
+	v := reflect.New(pt)
+	if err := json.Unmarshal(raw, v.Interface()); err != nil {
+		return nil, fmt.Errorf("decode %s payload: %w", t, err)
+	}
+	return v.Elem().Interface(), nil
+}
+
+// ReadJournal loads the most recent session from a journal file: its header
+// and the commands recorded after it.
+func ReadJournal(path string) (*JournalEntry, []JournalEntry, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("open journal: %w", err)
+	}
+	defer f.Close()
+
+	var header *JournalEntry
+	var entries []JournalEntry
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		var e JournalEntry
+		if err := json.Unmarshal(scanner.Bytes(), &e); err != nil {
+			return nil, nil, fmt.Errorf("journal line %d: %w", line, err)
+		}
+		if e.Type == journalStart {
+			header = &e
+			entries = nil
+			continue
+		}
+		entries = append(entries, e)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("read journal: %w", err)
+	}
+	if header == nil {
+		return nil, nil, fmt.Errorf("journal %s has no session header", path)
+	}
+	return header, entries, nil
+}
+
+// StartJournal begins recording executed commands to path. Call it once the
+// game has been created or loaded so the header captures the starting point.
+func (gs *GameServer) StartJournal(path string) error {
+	j, err := OpenCommandJournal(path)
+	if err != nil {
+		return err
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	var accounts []string
+	for _, p := range gs.State.Players {
+		if p != nil && p.Type == entities.PlayerTypeHuman {
+			accounts = append(accounts, p.Name)
+		}
+	}
+	config := gs.State.Config
+	if err := j.WriteHeader(gs.State.Seed, gs.TickManager.GetCurrentTick(), &config, accounts); err != nil {
+		j.Close()
+		return fmt.Errorf("write journal header: %w", err)
+	}
+	gs.journal = j
+	fmt.Printf("[Journal] Recording commands to %s (seed %d, tick %d)\n",
+		path, gs.State.Seed, gs.TickManager.GetCurrentTick())
+	return nil
+}
+
+// CloseJournal stops recording and closes the journal file.
+func (gs *GameServer) CloseJournal() {
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+	if gs.journal != nil {
+		gs.journal.Close()
+		gs.journal = nil
+	}
+}
+
+// journalCommand appends cmd to the journal, if one is open.
+func (gs *GameServer) journalCommand(cmd game.GameCommand) {
+	if gs.journal == nil || unjournaledCommands[cmd.Type] {
+		return
+	}
+	if err := gs.journal.Append(gs.TickManager.GetCurrentTick(), cmd); err != nil {
+		fmt.Printf("[Journal] %v\n", err)
+	}
+}
+
+// ReplayJournal rebuilds a game from a journal: a fresh galaxy from the
+// journal's seed (or loadPath, for sessions that began from a save), then
+// every recorded command re-executed on the tick it originally ran. The
+// simulation is left paused at the last replayed tick. Returns the number
+// of commands replayed.
+func (gs *GameServer) ReplayJournal(path, loadPath string) (int, error) {
+	header, entries, err := ReadJournal(path)
+	if err != nil {
+		return 0, err
+	}
+
+	gs.replaying = true
+	defer func() { gs.replaying = false }()
+
+	if loadPath != "" {
+		if err := gs.LoadGame(loadPath); err != nil {
+			return 0, fmt.Errorf("load %s: %w", loadPath, err)
+		}
+		if gs.TickManager.GetCurrentTick() != header.Tick {
+			return 0, fmt.Errorf("save is at tick %d but journal starts at tick %d",
+				gs.TickManager.GetCurrentTick(), header.Tick)
+		}
+	} else {
+		if header.Tick != 0 {
+			return 0, fmt.Errorf("journal starts at tick %d; load the save it began from", header.Tick)
+		}
+		config := game.DefaultGameConfig()
+		if header.Config != nil {
+			config = *header.Config
+		}
+		config.Seed = header.Seed
+		if err := gs.NewHeadlessGame(config); err != nil {
+			return 0, err
+		}
+	}
+
+	gs.mu.Lock()
+	defer gs.mu.Unlock()
+
+	gs.spawnJournalAccounts(header.Accounts)
+
+	replayed := 0
+	for _, e := range entries {
+		for gs.TickManager.GetCurrentTick() < e.Tick {
+			gs.TickManager.Step()
+		}
+		cmd, err := e.Command()
+		if err != nil {
+			fmt.Printf("[Replay] Skipping tick %d %s: %v\n", e.Tick, e.Type, err)
+			continue
+		}
+		gs.executeCommand(cmd)
+		replayed++
+	}
+	gs.TickManager.Pause()
+
+	fmt.Printf("[Replay] Replayed %d commands from %s (seed %d, now tick %d)\n",
+		replayed, path, header.Seed, gs.TickManager.GetCurrentTick())
+	return replayed, nil
+}
+
+// spawnJournalAccounts recreates, in their original order, the account
+// players that existed when the journal started but are missing now.
+func (gs *GameServer) spawnJournalAccounts(names []string) {
+	for _, name := range names {
+		exists := false
+		for _, p := range gs.State.Players {
+			if p != nil && p.Name == name {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			gs.spawnAccountPlayer(name)
+		}
+	}
+}
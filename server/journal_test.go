@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/xandaris/api"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/systems"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+func TestJournalRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.journal")
+
+	j, err := OpenCommandJournal(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	j.WriteHeader(7, 0, nil, nil)
+	j.Append(3, game.GameCommand{Type: game.CmdTrade, PlayerName: "Stale"})
+	j.Close()
+
+	// A second session in the same file supersedes the first
+	gs := New(1280, 720)
+	gs.journal, err = OpenCommandJournal(path)
+	if err != nil {
+		t.Fatalf("reopen: %v", err)
+	}
+	gs.journal.WriteHeader(42, 0, nil, []string{"Alpha"})
+	gs.TickManager.SetCurrentTick(5)
+	gs.journalCommand(game.GameCommand{Type: game.CmdSave, Data: "Alpha"})
+	gs.journalCommand(game.GameCommand{Type: game.CmdTrade, PlayerName: "Alpha",
+		Data: game.TradeCommandData{Resource: "Iron", Quantity: 20, Buy: true}})
+	gs.TickManager.SetCurrentTick(9)
+	gs.journalCommand(game.GameCommand{Type: game.CmdRegisterPlayer,
+		Data: game.RegisterPlayerCommandData{Name: "Beta", AccountKey: "secret"}})
+	gs.journalCommand(game.GameCommand{Type: game.CmdSetSpeed, Data: systems.TickSpeed4x})
+	gs.journalCommand(game.GameCommand{Type: game.CmdTogglePause})
+	gs.journal.Close()
+
+	header, entries, err := ReadJournal(path)
+	if err != nil {
+		t.Fatalf("read: %v", err)
+	}
+	if header.Seed != 42 || len(header.Accounts) != 1 || header.Accounts[0] != "Alpha" {
+		t.Errorf("expected latest session header, got %+v", header)
+	}
+	if len(entries) != 4 {
+		t.Fatalf("expected 4 entries (save skipped), got %d", len(entries))
+	}
+
+	trade, err := entries[0].Command()
+	if err != nil {
+		t.Fatalf("decode trade: %v", err)
+	}
+	td, ok := trade.Data.(game.TradeCommandData)
+	if entries[0].Tick != 5 || trade.PlayerName != "Alpha" || !ok || td.Resource != "Iron" || td.Quantity != 20 || !td.Buy {
+		t.Errorf("trade not restored: tick %d, %+v", entries[0].Tick, trade)
+	}
+
+	reg, _ := entries[1].Command()
+	if rd, ok := reg.Data.(game.RegisterPlayerCommandData); !ok || rd.Name != "Beta" || rd.AccountKey != "" {
+		t.Errorf("expected register payload with key redacted, got %+v", reg.Data)
+	}
+
+	speed, _ := entries[2].Command()
+	if s, ok := speed.Data.(systems.TickSpeed); !ok || s != systems.TickSpeed4x {
+		t.Errorf("expected tick speed 4x, got %#v", speed.Data)
+	}
+
+	pause, _ := entries[3].Command()
+	if pause.Type != game.CmdTogglePause || pause.Data != nil {
+		t.Errorf("expected bare pause toggle, got %+v", pause)
+	}
+}
+
+// TestSeededReplayIsDeterministic plays the same seed and command stream
+// through two servers and expects them to end in identical state.
+func TestSeededReplayIsDeterministic(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "game.journal")
+	j, err := OpenCommandJournal(path)
+	if err != nil {
+		t.Fatalf("open: %v", err)
+	}
+	config := game.DefaultGameConfig()
+	config.Systems = 16
+	j.WriteHeader(42, 0, &config, []string{"Alpha"})
+	j.Append(5, game.GameCommand{Type: game.CmdTrade, PlayerName: "Alpha",
+		Data: game.TradeCommandData{Resource: "Iron", Quantity: 20, Buy: true}})
+	j.Append(40, game.GameCommand{Type: game.CmdRegisterPlayer,
+		Data: game.RegisterPlayerCommandData{Name: "Beta"}})
+	j.Append(60, game.GameCommand{Type: game.CmdSetSchedule,
+		Data: game.SetScheduleCommandData{System: "EconomicEvents", Interval: 100, Phase: -1}})
+	j.Append(120, game.GameCommand{Type: game.CmdTrade, PlayerName: "Beta",
+		Data: game.TradeCommandData{Resource: "Water", Quantity: 30}})
+	j.Append(130, game.GameCommand{Type: game.CmdEspionage, PlayerName: "Alpha",
+		Data: game.EspionageCommandData{Target: "Beta", Type: "intel"}})
+	j.Append(140, game.GameCommand{Type: game.CmdPostBounty, PlayerName: "Beta",
+		Data: game.PostBountyCommandData{Type: "deliver", Description: "Iron wanted", Reward: 300, Resource: "Iron", Quantity: 50}})
+	j.Close()
+
+	run := func(id string) []byte {
+		gs := New(1280, 720)
+		gs.GameID = id
+		gs.systems = tickable.NewRegistry()
+		gs.TickManager.SetSystems(gs.systems)
+		t.Cleanup(func() { api.UnhostGame(id) })
+		if _, err := gs.ReplayJournal(path, ""); err != nil {
+			t.Fatalf("replay: %v", err)
+		}
+		for gs.TickManager.GetCurrentTick() < 600 {
+			gs.TickManager.Step()
+		}
+		sf := gs.buildSaveFile("")
+		sf.SavedAt = time.Time{}
+		if sched := sf.ScheduleOverrides["EconomicEvents"]; sched.Interval != 100 {
+			t.Errorf("expected the replayed schedule change in the save, got %+v", sf.ScheduleOverrides)
+		}
+		if len(sf.SpyOps) != 1 || len(sf.Bounties) != 1 {
+			t.Errorf("expected the replayed spy op and bounty in the save, got %d and %d", len(sf.SpyOps), len(sf.Bounties))
+		}
+		data, err := encodeSaveJSON(sf)
+		if err != nil {
+			t.Fatalf("encode: %v", err)
+		}
+		return data
+	}
+
+	a, b := run("replay-a"), run("replay-b")
+	if !bytes.Equal(a, b) {
+		t.Error("two runs from the same seed and commands diverged")
+	}
+}
@@ -0,0 +1,152 @@
+package server
+
+import (
+	"fmt"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// systemLedgerReasons says why a tickable system moves credits, for the
+// ledger entries it gets when its balance changes are reconciled. Systems
+// not listed are booked as economy.ReasonEvent.
+var systemLedgerReasons = map[string]string{
+	"CreditProduction":  economy.ReasonIncome,
+	"Market":            economy.ReasonMaintenance, // building upkeep and wealth drain
+	"ShipMaintenance":   economy.ReasonMaintenance,
+	"WealthTax":         economy.ReasonTax,
+	"InterstellarBank":  economy.ReasonLoan,
+	"BountyHunters":     economy.ReasonBounty,
+	"ContractExecution": economy.ReasonContract,
+	"FreightContracts":  economy.ReasonContract,
+	"LocalExchange":     economy.ReasonLocalExchange,
+	"Tariffs":           economy.ReasonTariff,
+	"DockingRevenue":    economy.ReasonTariff,
+	"Delivery":          economy.ReasonMarketTrade,
+	"AutoOrders":        economy.ReasonMarketTrade,
+	"StandingOrders":    economy.ReasonMarketTrade,
+	"OrderTriggers":     economy.ReasonMarketTrade,
+	"Construction":      economy.ReasonConstruction,
+	"AutoCargoShip":     economy.ReasonConstruction, // buys cargo ships
+	"Smuggling":         economy.ReasonBlackMarket,
+	"CommodityFutures":  economy.ReasonFutures,
+}
+
+// commandLedgerReasons is the same for player commands; the rest are
+// booked as economy.ReasonCommand.
+var commandLedgerReasons = map[game.CommandType]string{
+	game.CmdTrade:              economy.ReasonMarketTrade,
+	game.CmdStandingOrder:      economy.ReasonMarketTrade,
+	game.CmdCancelOrder:        economy.ReasonMarketTrade,
+	game.CmdLimitOrder:         economy.ReasonMarketTrade,
+	game.CmdCancelLimitOrder:   economy.ReasonMarketTrade,
+	game.CmdFuturesOpen:        economy.ReasonFutures,
+	game.CmdFuturesClose:       economy.ReasonFutures,
+	game.CmdSellAtDock:         economy.ReasonDockSale,
+	game.CmdBuyAtDock:          economy.ReasonDockSale,
+	game.CmdBuild:              economy.ReasonConstruction,
+	game.CmdBuildShip:          economy.ReasonConstruction,
+	game.CmdUpgrade:            economy.ReasonConstruction,
+	game.CmdCancelConstruction: economy.ReasonConstruction,
+	game.CmdDemolish:           economy.ReasonConstruction,
+	game.CmdEspionage:          economy.ReasonEspionage,
+	game.CmdPostBounty:         economy.ReasonBounty,
+	game.CmdBlackMarket:        economy.ReasonBlackMarket,
+}
+
+// reconcileSystemCredits books whatever a tickable system just did to the
+// factions' credits. It runs after every system's OnTick.
+func (gs *GameServer) reconcileSystemCredits(system tickable.TickableSystem, tick int64) {
+	if gs.Ledger == nil || gs.remoteSync != nil {
+		return
+	}
+	reason, ok := systemLedgerReasons[system.GetName()]
+	if !ok {
+		reason = economy.ReasonEvent
+	}
+	gs.Ledger.SetTick(tick)
+	gs.Ledger.Reconcile(gs.State.Players, system.GetName(), reason)
+}
+
+// reconcileCommandCredits books whatever cmd just did to the factions'
+// credits.
+func (gs *GameServer) reconcileCommandCredits(cmdType game.CommandType) {
+	if gs.Ledger == nil || gs.remoteSync != nil {
+		return
+	}
+	reason, ok := commandLedgerReasons[cmdType]
+	if !ok {
+		reason = economy.ReasonCommand
+	}
+	gs.Ledger.SetTick(gs.TickManager.GetCurrentTick())
+	gs.Ledger.Reconcile(gs.State.Players, string(cmdType), reason)
+}
+
+// reconcileRollback books the credits an atomic batch's rollback put back.
+func (gs *GameServer) reconcileRollback() {
+	if gs.Ledger == nil {
+		return
+	}
+	gs.Ledger.Reconcile(gs.State.Players, string(game.CmdBatch), economy.ReasonRollback)
+}
+
+// recordDockingFee books a docking fee paid to the planet's owner by a
+// ship selling cargo there.
+func (gs *GameServer) recordDockingFee(owner *entities.Player, payer, resource string, fee int) {
+	if gs.Ledger == nil {
+		return
+	}
+	gs.Ledger.Record(owner, economy.LedgerEntry{
+		Amount:       fee,
+		Reason:       economy.ReasonTariff,
+		Source:       "dock",
+		Counterparty: payer,
+		Resource:     resource,
+		Detail:       "docking fee",
+	})
+}
+
+// recordDockSale books what a ship's owner was paid for cargo sold at a
+// dock, after the docking fee.
+func (gs *GameServer) recordDockSale(seller *entities.Player, resource string, qty int, unitPrice float64, credits int) {
+	if gs.Ledger == nil {
+		return
+	}
+	gs.Ledger.Record(seller, economy.LedgerEntry{
+		Amount:   credits,
+		Reason:   economy.ReasonDockSale,
+		Source:   "dock",
+		Resource: resource,
+		Quantity: qty,
+		Detail:   fmt.Sprintf("sold %d %s @ %.0f", qty, resource, unitPrice),
+	})
+}
+
+// recordCredits books a movement of credits a command has just applied to
+// player, when it should carry more detail than reconciliation gives it.
+func (gs *GameServer) recordCredits(player *entities.Player, e economy.LedgerEntry) {
+	if gs.Ledger == nil {
+		return
+	}
+	gs.Ledger.SetTick(gs.TickManager.GetCurrentTick())
+	gs.Ledger.Record(player, e)
+}
+
+// recordTrade adds a trade made outside the market (e.g. on the black
+// market) to player's trade history and cost basis.
+func (gs *GameServer) recordTrade(player *entities.Player, resource string, qty int, action string, total int) {
+	if gs.Ledger == nil || qty <= 0 {
+		return
+	}
+	gs.Ledger.AddTrade(economy.TradeRecord{
+		Tick:      gs.TickManager.GetCurrentTick(),
+		Player:    player.Name,
+		Resource:  resource,
+		Quantity:  qty,
+		Action:    action,
+		UnitPrice: float64(total) / float64(qty),
+		Total:     total,
+	})
+}
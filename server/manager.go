@@ -0,0 +1,345 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hunterjsb/xandaris/api"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+const (
+	// gamesManifest lists a GameManager's games, under its root directory.
+	gamesManifest = "games.json"
+	// hostedAutosaveInterval is how often each hosted game autosaves.
+	hostedAutosaveInterval = 2 * time.Minute
+)
+
+// gameIDPattern keeps game IDs usable as URL segments and directory names.
+var gameIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+
+// GameManager hosts games alongside the process's default one, e.g. a
+// ladder season next to a sandbox. Each game has its own tick loop,
+// tickable systems, account registry and saves under <root>/<id>/, and is
+// served under /api/games/<id>/. The list of games is kept in
+// <root>/games.json so they come back after a restart.
+type GameManager struct {
+	mu     sync.Mutex
+	root   string
+	width  int
+	height int
+	games  map[string]*managedGame
+}
+
+// managedGame is one hosted game. server and stop are nil once archived.
+type managedGame struct {
+	record gameRecord
+	server *GameServer
+	stop   chan struct{} // ends the autosave loop
+}
+
+// gameRecord is a game's entry in games.json.
+type gameRecord struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Seed      int64            `json:"seed"`
+	Config    *game.GameConfig `json:"config,omitempty"` // setup for a game that hasn't saved yet
+	Status    string           `json:"status"`
+	CreatedAt time.Time        `json:"created_at"`
+}
+
+// NewGameManager creates a manager keeping its games under root.
+func NewGameManager(root string, screenWidth, screenHeight int) *GameManager {
+	return &GameManager{
+		root:   root,
+		width:  screenWidth,
+		height: screenHeight,
+		games:  make(map[string]*managedGame),
+	}
+}
+
+// Start resumes every game in the manifest that wasn't archived and
+// enables the /api/admin/games endpoints. Games that fail to start are
+// logged and skipped.
+func (m *GameManager) Start() error {
+	data, err := os.ReadFile(filepath.Join(m.root, gamesManifest))
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	var records []gameRecord
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &records); err != nil {
+			return fmt.Errorf("read %s: %w", gamesManifest, err)
+		}
+	}
+
+	m.mu.Lock()
+	for _, rec := range records {
+		mg := &managedGame{record: rec}
+		m.games[rec.ID] = mg
+		if rec.Status == api.GameArchived {
+			continue
+		}
+		if err := m.launch(mg); err != nil {
+			fmt.Printf("[Games] %s: %v\n", rec.ID, err)
+		}
+	}
+	m.mu.Unlock()
+
+	api.SetGameHost(m)
+	fmt.Printf("[Games] Hosting %d games from %s\n", len(records), m.root)
+	return nil
+}
+
+// dir returns the directory holding game id's saves and accounts.
+func (m *GameManager) dir(id string) string {
+	return filepath.Join(m.root, id)
+}
+
+// autosavePath returns where game id autosaves.
+func (m *GameManager) autosavePath(id string) string {
+	return filepath.Join(m.dir(id), saveDirectory, "autosave"+saveExtension)
+}
+
+// launch builds mg's server, resuming its autosave if there is one, and
+// starts its tick and autosave loops. Caller must hold m.mu.
+func (m *GameManager) launch(mg *managedGame) error {
+	id := mg.record.ID
+	gs := New(m.width, m.height)
+	gs.GameID = id
+	gs.SaveDir = filepath.Join(m.dir(id), saveDirectory)
+	gs.Registry = game.NewPlayerRegistryAt(os.Getenv("XANDARIS_API_KEY"), filepath.Join(m.dir(id), "accounts.json"))
+	gs.systems = tickable.NewRegistry()
+	gs.TickManager.SetSystems(gs.systems)
+
+	autosave := m.autosavePath(id)
+	if _, err := os.Stat(autosave); err == nil {
+		if err := gs.LoadGame(autosave); err != nil {
+			return fmt.Errorf("load %s: %w", autosave, err)
+		}
+	} else {
+		cfg := game.DefaultGameConfig()
+		if mg.record.Config != nil {
+			cfg = *mg.record.Config
+		}
+		cfg.Seed = mg.record.Seed
+		if err := gs.NewHeadlessGame(cfg); err != nil {
+			return err
+		}
+		config := gs.State.Config
+		mg.record.Seed, mg.record.Config = config.Seed, &config
+		if err := gs.AutoSave(autosave); err != nil {
+			fmt.Printf("[Games] %s: initial save failed: %v\n", id, err)
+		}
+	}
+	if mg.record.Status == api.GamePaused {
+		gs.TickManager.Pause()
+	}
+
+	mg.server = gs
+	mg.stop = make(chan struct{})
+	go gs.Run()
+	go func(stop chan struct{}) {
+		ticker := time.NewTicker(hostedAutosaveInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-ticker.C:
+				if err := gs.AutoSave(autosave); err != nil {
+					fmt.Printf("[Games] %s: autosave failed: %v\n", id, err)
+				}
+			}
+		}
+	}(mg.stop)
+	return nil
+}
+
+// halt saves mg and stops its loops. Caller must hold m.mu.
+func (m *GameManager) halt(mg *managedGame) {
+	if mg.server == nil {
+		return
+	}
+	close(mg.stop)
+	if err := mg.server.AutoSave(m.autosavePath(mg.record.ID)); err != nil {
+		fmt.Printf("[Games] %s: final save failed: %v\n", mg.record.ID, err)
+	}
+	mg.server.Stop()
+	if mg.server.Webhooks != nil {
+		mg.server.Webhooks.Stop()
+	}
+	mg.server, mg.stop = nil, nil
+}
+
+// saveManifestLocked writes games.json. Caller must hold m.mu.
+func (m *GameManager) saveManifestLocked() error {
+	records := make([]gameRecord, 0, len(m.games))
+	for _, mg := range m.games {
+		records = append(records, mg.record)
+	}
+	sort.Slice(records, func(i, j int) bool { return records[i].CreatedAt.Before(records[j].CreatedAt) })
+	data, err := json.MarshalIndent(records, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.root, 0755); err != nil {
+		return err
+	}
+	path := filepath.Join(m.root, gamesManifest)
+	if err := os.WriteFile(path+".tmp", data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(path+".tmp", path)
+}
+
+// infoLocked describes mg. Caller must hold m.mu.
+func (m *GameManager) infoLocked(mg *managedGame) api.HostedGame {
+	info := api.HostedGame{
+		ID:        mg.record.ID,
+		Name:      mg.record.Name,
+		Status:    mg.record.Status,
+		Seed:      mg.record.Seed,
+		CreatedAt: mg.record.CreatedAt.Format(time.RFC3339),
+	}
+	if gs := mg.server; gs != nil {
+		gs.mu.Lock()
+		info.Tick = gs.TickManager.GetCurrentTick()
+		info.GameTime = gs.TickManager.GetGameTimeFormatted()
+		info.Players = len(gs.State.Players)
+		gs.mu.Unlock()
+	}
+	return info
+}
+
+// ListGames describes every game, oldest first.
+func (m *GameManager) ListGames() []api.HostedGame {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	out := make([]api.HostedGame, 0, len(m.games))
+	for _, mg := range m.games {
+		out = append(out, m.infoLocked(mg))
+	}
+	sort.Slice(out, func(i, j int) bool {
+		return out[i].CreatedAt < out[j].CreatedAt || (out[i].CreatedAt == out[j].CreatedAt && out[i].ID < out[j].ID)
+	})
+	return out
+}
+
+// CreateGame generates a new galaxy and starts serving it. IDs are never
+// reused, even once a game is archived, since its directory is kept.
+func (m *GameManager) CreateGame(req api.CreateGameRequest) (api.HostedGame, error) {
+	if !gameIDPattern.MatchString(req.ID) {
+		return api.HostedGame{}, fmt.Errorf("game ID must be 1-32 lowercase letters, digits or dashes")
+	}
+	name := req.Name
+	if name == "" {
+		name = req.ID
+	}
+	cfg := game.DefaultGameConfig()
+	if req.Config != nil {
+		cfg = *req.Config
+	}
+	if req.Seed != 0 {
+		cfg.Seed = req.Seed
+	}
+	if err := cfg.Validate(); err != nil {
+		return api.HostedGame{}, err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, exists := m.games[req.ID]; exists {
+		return api.HostedGame{}, fmt.Errorf("game %q already exists", req.ID)
+	}
+	if _, err := os.Stat(m.dir(req.ID)); err == nil {
+		return api.HostedGame{}, fmt.Errorf("directory for game %q already exists", req.ID)
+	}
+	mg := &managedGame{record: gameRecord{
+		ID:        req.ID,
+		Name:      name,
+		Seed:      cfg.Seed,
+		Config:    &cfg,
+		Status:    api.GameRunning,
+		CreatedAt: time.Now().UTC(),
+	}}
+	if err := m.launch(mg); err != nil {
+		return api.HostedGame{}, err
+	}
+	m.games[req.ID] = mg
+	if err := m.saveManifestLocked(); err != nil {
+		fmt.Printf("[Games] Manifest save failed: %v\n", err)
+	}
+	fmt.Printf("[Games] Created %s (%s, seed %d)\n", req.ID, name, mg.record.Seed)
+	return m.infoLocked(mg), nil
+}
+
+// PauseGame stops or restarts a game's clock. Commands still run while
+// it's paused, as with /api/game/speed.
+func (m *GameManager) PauseGame(id string, paused bool) (api.HostedGame, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mg, ok := m.games[id]
+	if !ok {
+		return api.HostedGame{}, fmt.Errorf("%w %q", api.ErrUnknownGame, id)
+	}
+	if mg.server == nil {
+		return api.HostedGame{}, fmt.Errorf("game %q is not loaded", id)
+	}
+
+	mg.server.mu.Lock()
+	if paused {
+		mg.server.TickManager.Pause()
+		mg.record.Status = api.GamePaused
+	} else {
+		mg.server.TickManager.Resume()
+		mg.record.Status = api.GameRunning
+	}
+	mg.server.mu.Unlock()
+
+	if err := m.saveManifestLocked(); err != nil {
+		fmt.Printf("[Games] Manifest save failed: %v\n", err)
+	}
+	return m.infoLocked(mg), nil
+}
+
+// ArchiveGame saves a game one last time and shuts it down. Its directory
+// is kept, but it's no longer served.
+func (m *GameManager) ArchiveGame(id string) (api.HostedGame, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	mg, ok := m.games[id]
+	if !ok {
+		return api.HostedGame{}, fmt.Errorf("%w %q", api.ErrUnknownGame, id)
+	}
+	if mg.record.Status == api.GameArchived {
+		return api.HostedGame{}, errors.New("game already archived")
+	}
+
+	api.UnhostGame(id)
+	m.halt(mg)
+	mg.record.Status = api.GameArchived
+	if err := m.saveManifestLocked(); err != nil {
+		fmt.Printf("[Games] Manifest save failed: %v\n", err)
+	}
+	fmt.Printf("[Games] Archived %s\n", id)
+	return m.infoLocked(mg), nil
+}
+
+// Shutdown saves and stops every running game, leaving their status as is
+// so the next Start resumes them.
+func (m *GameManager) Shutdown() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, mg := range m.games {
+		m.halt(mg)
+	}
+}
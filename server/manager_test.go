@@ -0,0 +1,143 @@
+package server
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hunterjsb/xandaris/api"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+func TestGameManagerHostsIndependentGames(t *testing.T) {
+	root := t.TempDir()
+	m := NewGameManager(root, 1280, 720)
+	if err := m.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	t.Cleanup(m.Shutdown)
+
+	if _, err := m.CreateGame(api.CreateGameRequest{ID: "Bad ID"}); err == nil {
+		t.Error("expected an invalid ID to be rejected")
+	}
+	ladder, err := m.CreateGame(api.CreateGameRequest{ID: "ladder", Name: "Season 1", Seed: 42})
+	if err != nil {
+		t.Fatalf("create ladder: %v", err)
+	}
+	if _, err := m.CreateGame(api.CreateGameRequest{ID: "sandbox", Seed: 7}); err != nil {
+		t.Fatalf("create sandbox: %v", err)
+	}
+	if _, err := m.CreateGame(api.CreateGameRequest{ID: "ladder"}); err == nil {
+		t.Error("expected a duplicate ID to be rejected")
+	}
+	if ladder.Seed != 42 || ladder.Status != api.GameRunning || ladder.Players == 0 {
+		t.Errorf("unexpected ladder info %+v", ladder)
+	}
+
+	a, b := m.games["ladder"].server, m.games["sandbox"].server
+	if a.tickables() == b.tickables() || a.tickables() == tickable.DefaultRegistry() {
+		t.Fatal("hosted games share tickable systems")
+	}
+	if a.tickables().ConstructionSystem() == b.tickables().ConstructionSystem() {
+		t.Error("hosted games share a construction system")
+	}
+	if a.Registry == b.Registry || a.SaveDir == b.SaveDir {
+		t.Error("hosted games share accounts or saves")
+	}
+
+	if _, err := m.PauseGame("sandbox", true); err != nil {
+		t.Fatalf("pause: %v", err)
+	}
+	b.mu.Lock()
+	paused := b.TickManager.IsPaused()
+	b.mu.Unlock()
+	if !paused {
+		t.Error("sandbox clock still running")
+	}
+	if _, err := m.PauseGame("nope", true); err == nil {
+		t.Error("expected an unknown game to be rejected")
+	}
+
+	archived, err := m.ArchiveGame("ladder")
+	if err != nil || archived.Status != api.GameArchived {
+		t.Fatalf("archive: %+v, %v", archived, err)
+	}
+	if _, err := os.Stat(m.autosavePath("ladder")); err != nil {
+		t.Errorf("archived game left no save: %v", err)
+	}
+	if _, err := m.PauseGame("ladder", false); err == nil {
+		t.Error("expected an archived game to stay down")
+	}
+	m.Shutdown()
+
+	// A restart brings back the sandbox, still paused, but not the archived ladder
+	again := NewGameManager(root, 1280, 720)
+	if err := again.Start(); err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	t.Cleanup(again.Shutdown)
+	games := again.ListGames()
+	if len(games) != 2 || games[0].ID != "ladder" || games[1].ID != "sandbox" {
+		t.Fatalf("unexpected games after restart %+v", games)
+	}
+	if games[0].Status != api.GameArchived || again.games["ladder"].server != nil {
+		t.Error("archived game was restarted")
+	}
+	if games[1].Status != api.GamePaused || games[1].Seed != 7 || !again.games["sandbox"].server.TickManager.IsPaused() {
+		t.Errorf("sandbox not resumed as it was: %+v", games[1])
+	}
+}
+
+func TestHostedGameUsesConfig(t *testing.T) {
+	root := t.TempDir()
+	m := NewGameManager(root, 1280, 720)
+	if err := m.Start(); err != nil {
+		t.Fatalf("start: %v", err)
+	}
+	t.Cleanup(m.Shutdown)
+
+	cfg := game.DefaultGameConfig()
+	cfg.Systems = 12
+	cfg.AIPlayers = 2
+	cfg.AIFactions = []game.AIFaction{{Name: "Solo Traders", Personality: "cautious"}}
+	cfg.StartingCredits = 1234
+	cfg.DisabledSystems = []string{"Wormholes"}
+	cfg.Victory = []string{"Economic"}
+	cfg.TicksPerSecond = 20
+	bad := cfg
+	bad.Systems = 0
+	if _, err := m.CreateGame(api.CreateGameRequest{ID: "broken", Config: &bad}); err == nil {
+		t.Error("expected an invalid config to be rejected")
+	}
+	if _, err := m.CreateGame(api.CreateGameRequest{ID: "small", Seed: 9, Config: &cfg}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	gs := m.games["small"].server
+	gs.mu.Lock()
+	if len(gs.State.Systems) != 12 || len(gs.State.Players) != 2 {
+		t.Errorf("expected 12 systems and 2 factions, got %d and %d", len(gs.State.Systems), len(gs.State.Players))
+	}
+	if p := gs.State.Players[0]; p.Name != "Solo Traders" || p.Credits != 1234 {
+		t.Errorf("unexpected first faction %s with %d credits", p.Name, p.Credits)
+	}
+	if gs.tickables().SystemByName("Wormholes").IsEnabled() || !gs.tickables().SystemByName("Construction").IsEnabled() {
+		t.Error("disabled_systems not applied")
+	}
+	if gs.TickManager.GetTicksPerSecond() != 20 || gs.GetGameConfig().Seed != 9 {
+		t.Errorf("unexpected tick rate %g or config %+v", gs.TickManager.GetTicksPerSecond(), gs.GetGameConfig())
+	}
+	gs.mu.Unlock()
+	m.Shutdown()
+
+	// The config comes back with the save
+	again := NewGameManager(root, 1280, 720)
+	if err := again.Start(); err != nil {
+		t.Fatalf("restart: %v", err)
+	}
+	t.Cleanup(again.Shutdown)
+	loaded := again.games["small"].server.GetGameConfig()
+	if loaded.Systems != 12 || loaded.StartingCredits != 1234 || len(loaded.Victory) != 1 || loaded.Seed != 9 {
+		t.Errorf("config not restored from save: %+v", loaded)
+	}
+}
@@ -0,0 +1,255 @@
+package server
+
+import (
+	"bytes"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// saveMigration upgrades a save from one format version to the next.
+//
+// Gob matches fields by name, so most format changes need no Decode: the old
+// file decodes straight into saveFile with new fields zeroed, and Migrate
+// fills them in. When a field later changes type, older steps whose files no
+// longer decode into saveFile set Decode to read their own layout; it is
+// only used when the file is at that step's From version.
+type saveMigration struct {
+	From, To string
+	Note     string
+	Decode   func(data []byte) (*saveFile, error) // optional: legacy layout
+	Migrate  func(sf *saveFile) error             // optional: fix up decoded data
+}
+
+// saveMigrations maps a version to the step that upgrades it.
+var saveMigrations = map[string]saveMigration{}
+
+// registerSaveMigration adds a step to the migration chain.
+func registerSaveMigration(m saveMigration) {
+	if _, dup := saveMigrations[m.From]; dup {
+		panic(fmt.Sprintf("save migration from %s registered twice", m.From))
+	}
+	saveMigrations[m.From] = m
+}
+
+func init() {
+	registerSaveMigration(saveMigration{
+		From: "2.4.0", To: "3.0.0",
+		Note: "pre-logistics: deliveries, credit ledger and shipping routes start empty",
+	})
+}
+
+// saveHeader decodes just the version of a save whose layout no longer
+// matches saveFile.
+type saveHeader struct {
+	Version string
+}
+
+// migrationPath lists the steps needed to bring version up to SaveVersion.
+func migrationPath(version string) ([]saveMigration, error) {
+	var path []saveMigration
+	seen := map[string]bool{}
+	for v := version; v != SaveVersion; {
+		m, ok := saveMigrations[v]
+		if !ok || seen[v] {
+			return nil, fmt.Errorf("no migration from save version %q (this build writes %q)", version, SaveVersion)
+		}
+		seen[v] = true
+		path = append(path, m)
+		v = m.To
+	}
+	return path, nil
+}
+
+// peekSave finds the version of a save. Files that still decode into
+// saveFile are returned whole; gob can't reliably skip the interface-typed
+// entity slices, so the header-only decode is the fallback for legacy
+// layouts rather than the first step.
+func peekSave(data []byte) (string, *saveFile, error) {
+	sf := &saveFile{}
+	err := gob.NewDecoder(bytes.NewReader(data)).Decode(sf)
+	if err == nil {
+		return sf.Version, sf, nil
+	}
+	var header saveHeader
+	if gob.NewDecoder(bytes.NewReader(data)).Decode(&header) != nil || header.Version == "" {
+		return "", nil, fmt.Errorf("failed to decode save data: %w", err)
+	}
+	return header.Version, nil, nil
+}
+
+// decodeSave decodes a save of any known version and migrates it to
+// SaveVersion. Returns the migration steps applied (empty if current).
+func decodeSave(data []byte) (*saveFile, []saveMigration, error) {
+	version, sf, err := peekSave(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	path, err := migrationPath(version)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The first step may read the file in its own legacy layout
+	if len(path) > 0 && path[0].Decode != nil {
+		if sf, err = path[0].Decode(data); err != nil {
+			return nil, nil, fmt.Errorf("decode %s save: %w", version, err)
+		}
+	} else if sf == nil {
+		return nil, nil, fmt.Errorf("save version %s no longer decodes and has no legacy decoder", version)
+	}
+
+	if err := applySaveMigrations(sf, path); err != nil {
+		return nil, nil, err
+	}
+	return sf, path, nil
+}
+
+// applySaveMigrations runs each step's Migrate on an already decoded save.
+func applySaveMigrations(sf *saveFile, path []saveMigration) error {
+	for _, m := range path {
+		if m.Migrate != nil {
+			if err := m.Migrate(sf); err != nil {
+				return fmt.Errorf("migrate %s → %s: %w", m.From, m.To, err)
+			}
+		}
+		sf.Version = m.To
+	}
+	return nil
+}
+
+// readSaveFile reads and migrates the save at path.
+func readSaveFile(path string) (*saveFile, []saveMigration, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to open save file: %w", err)
+	}
+	return decodeSave(data)
+}
+
+// writeSaveFile encodes sf to path via a temp file and atomic rename.
+func writeSaveFile(path string, sf *saveFile) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create save directory: %w", err)
+		}
+	}
+	tmpPath := path + ".tmp"
+	file, err := os.Create(tmpPath)
+	if err != nil {
+		return fmt.Errorf("failed to create temp file: %w", err)
+	}
+	if err := gob.NewEncoder(file).Encode(sf); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to encode: %w", err)
+	}
+	// Flush to disk before the rename so a crash can't leave an empty file
+	if err := file.Sync(); err != nil {
+		file.Close()
+		os.Remove(tmpPath)
+		return fmt.Errorf("failed to sync: %w", err)
+	}
+	file.Close()
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to rename: %w", err)
+	}
+	return nil
+}
+
+// backupSave copies a save aside before it is overwritten in a newer format.
+func backupSave(path, version string) (string, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+	backup := fmt.Sprintf("%s.v%s.bak", path, version)
+	dst, err := os.Create(backup)
+	if err != nil {
+		return "", err
+	}
+	defer dst.Close()
+	if _, err := io.Copy(dst, src); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// SaveInfo describes a save file without loading it into a game.
+type SaveInfo struct {
+	Path           string
+	Version        string
+	SavedAt        time.Time
+	PlayerName     string
+	Tick           int64
+	GameTime       string
+	Seed           int64
+	Systems        int
+	Players        []string
+	TickableStates int
+	Migrations     []string // steps needed to reach SaveVersion, e.g. "2.4.0 → 3.0.0"
+	MigrationError string   // set if the save can't be brought up to date
+}
+
+// InspectSave reads a save file offline and reports what it contains and
+// which migrations it would need.
+func InspectSave(path string) (*SaveInfo, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open save file: %w", err)
+	}
+	version, _, err := peekSave(data)
+	if err != nil {
+		return nil, fmt.Errorf("not a save file: %w", err)
+	}
+	info := &SaveInfo{Path: path, Version: version}
+
+	sf, steps, err := decodeSave(data)
+	if err != nil {
+		info.MigrationError = err.Error()
+		return info, nil
+	}
+	for _, m := range steps {
+		info.Migrations = append(info.Migrations, fmt.Sprintf("%s → %s (%s)", m.From, m.To, m.Note))
+	}
+	info.SavedAt = sf.SavedAt
+	info.PlayerName = sf.PlayerName
+	info.Tick = sf.Tick
+	info.GameTime = sf.GameTime
+	info.Seed = sf.Seed
+	info.Systems = len(sf.Systems)
+	for _, p := range sf.Players {
+		if p != nil {
+			info.Players = append(info.Players, p.Name)
+		}
+	}
+	info.TickableStates = len(sf.TickableState)
+	return info, nil
+}
+
+// MigrateSaveFile upgrades the save at in to SaveVersion and writes it to
+// out (which may equal in; the original is then kept as a .bak copy).
+// Returns the steps applied.
+func MigrateSaveFile(in, out string) ([]string, error) {
+	sf, steps, err := readSaveFile(in)
+	if err != nil {
+		return nil, err
+	}
+	var applied []string
+	for _, m := range steps {
+		applied = append(applied, m.From+" → "+m.To)
+	}
+	if len(steps) > 0 && in == out {
+		if _, err := backupSave(in, steps[0].From); err != nil {
+			return nil, fmt.Errorf("failed to back up %s: %w", in, err)
+		}
+	}
+	if err := writeSaveFile(out, sf); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
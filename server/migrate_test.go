@@ -0,0 +1,94 @@
+package server
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+func encodeForTest(t *testing.T, v interface{}) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(v); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestSaveMigrationChain(t *testing.T) {
+	// 1.0.0 stored Tick as a string, so it needs its own decoder
+	type legacySave struct {
+		Version    string
+		PlayerName string
+		Tick       string
+	}
+	registerSaveMigration(saveMigration{
+		From: "1.0.0", To: "2.4.0", Note: "tick as integer",
+		Decode: func(data []byte) (*saveFile, error) {
+			var old legacySave
+			if err := gob.NewDecoder(bytes.NewReader(data)).Decode(&old); err != nil {
+				return nil, err
+			}
+			tick, err := strconv.ParseInt(old.Tick, 10, 64)
+			return &saveFile{Version: old.Version, PlayerName: old.PlayerName, Tick: tick}, err
+		},
+		Migrate: func(sf *saveFile) error {
+			sf.GameTime = "migrated"
+			return nil
+		},
+	})
+	defer delete(saveMigrations, "1.0.0")
+
+	data := encodeForTest(t, legacySave{Version: "1.0.0", PlayerName: "Alpha", Tick: "1234"})
+	sf, steps, err := decodeSave(data)
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if len(steps) != 2 || sf.Version != SaveVersion {
+		t.Fatalf("expected 2 steps to %s, got %d to %s", SaveVersion, len(steps), sf.Version)
+	}
+	if sf.Tick != 1234 || sf.PlayerName != "Alpha" || sf.GameTime != "migrated" {
+		t.Errorf("legacy fields not carried through: %+v", sf)
+	}
+
+	if _, _, err := decodeSave(encodeForTest(t, saveFile{Version: "0.9.0"})); err == nil {
+		t.Error("expected unknown version to fail")
+	}
+	if _, steps, err := decodeSave(encodeForTest(t, saveFile{Version: SaveVersion})); err != nil || len(steps) != 0 {
+		t.Errorf("expected current save to need no migration, got %d steps, %v", len(steps), err)
+	}
+}
+
+func TestMigrateSaveFileInPlace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "old.xsave")
+	sys := &entities.System{ID: 1}
+	for _, e := range entities.GenerateEntitiesForSystem(1, 77) {
+		sys.AddEntity(e)
+	}
+	old := saveFile{Version: "2.4.0", Tick: 77, Systems: []*entities.System{sys}}
+	if err := os.WriteFile(path, encodeForTest(t, old), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := InspectSave(path)
+	if err != nil || info.Version != "2.4.0" || len(info.Migrations) != 1 || info.MigrationError != "" {
+		t.Fatalf("unexpected inspect result %+v, %v", info, err)
+	}
+
+	applied, err := MigrateSaveFile(path, path)
+	if err != nil || len(applied) != 1 {
+		t.Fatalf("migrate: %v %v", applied, err)
+	}
+	if _, err := os.Stat(path + ".v2.4.0.bak"); err != nil {
+		t.Errorf("expected backup of original: %v", err)
+	}
+	info, _ = InspectSave(path)
+	if info.Version != SaveVersion || info.Tick != 77 || info.Systems != 1 || len(info.Migrations) != 0 {
+		t.Errorf("expected migrated save at %s tick 77, got %+v", SaveVersion, info)
+	}
+}
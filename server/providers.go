@@ -10,6 +10,7 @@ package server
 
 import (
 	"fmt"
+	"math/rand"
 
 	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
@@ -23,11 +24,11 @@ import (
 var _ tickable.GameProvider = (*GameServer)(nil)
 
 func (gs *GameServer) AIBuildOnPlanet(planet *entities.Planet, buildingType string, owner string, systemID int) {
-	game.AddBuildingToPlanet(planet, buildingType, owner, systemID)
+	game.AddBuildingToPlanet(planet, buildingType, owner, systemID, gs.rng("AIBuild"))
 }
 
 func (gs *GameServer) ColonizePlanet(planet *entities.Planet, ship *entities.Ship, player *entities.Player, systemID int) {
-	game.ColonizePlanet(planet, ship, player, systemID)
+	game.ColonizePlanet(planet, ship, player, systemID, gs.rng("Colonize"))
 }
 
 func (gs *GameServer) GetMarketEngine() *economy.Market { return gs.State.Market }
@@ -165,6 +166,7 @@ func (gs *GameServer) SellAtDock(ship *entities.Ship, resource string, qty int)
 			for _, p := range gs.State.Players {
 				if p != nil && p.Name == planet.Owner {
 					p.Credits += dockingFee
+					gs.recordDockingFee(p, ship.Owner, resource, dockingFee)
 					break
 				}
 			}
@@ -176,12 +178,27 @@ func (gs *GameServer) SellAtDock(ship *entities.Ship, resource string, qty int)
 	for _, p := range gs.State.Players {
 		if p != nil && p.Name == ship.Owner {
 			p.Credits += credits
+			gs.recordDockSale(p, resource, sold, buyPrice, credits)
 			break
 		}
 	}
 	if gs.State.Market != nil {
 		gs.State.Market.AddTradeVolume(resource, sold, false)
 	}
+	if gs.Candles != nil {
+		gs.Candles.AddVolume(gs.TickManager.GetCurrentTick(), ship.CurrentSystem, resource, sold)
+	}
+	if gs.Ledger != nil {
+		gs.Ledger.AddTrade(economy.TradeRecord{
+			Tick:      gs.TickManager.GetCurrentTick(),
+			Player:    ship.Owner,
+			Resource:  resource,
+			Quantity:  sold,
+			Action:    "sell_at_dock",
+			UnitPrice: buyPrice,
+			Total:     credits,
+		})
+	}
 	return sold, credits, nil
 }
 
@@ -189,10 +206,22 @@ func (gs *GameServer) GetCreditLedger() *economy.CreditLedger {
 	return gs.CreditLedger
 }
 
+func (gs *GameServer) GetLedger() *economy.Ledger {
+	return gs.Ledger
+}
+
+func (gs *GameServer) GetCandleHistory() *economy.CandleHistory {
+	return gs.Candles
+}
+
 func (gs *GameServer) GetOrderBook() *economy.OrderBook {
 	return gs.OrderBook
 }
 
+func (gs *GameServer) GetFuturesExchange() *economy.FuturesExchange {
+	return gs.Futures
+}
+
 func (gs *GameServer) GetContractManager() *economy.ContractManager {
 	return gs.ContractMgr
 }
@@ -268,6 +297,7 @@ func (gs *GameServer) GetHyperlanes() []entities.Hyperlane { return gs.State.Hyp
 func (gs *GameServer) GetPlayers() []*entities.Player      { return gs.State.Players }
 func (gs *GameServer) GetHumanPlayer() *entities.Player    { return gs.State.HumanPlayer }
 func (gs *GameServer) GetSeed() int64                      { return gs.State.Seed }
+func (gs *GameServer) GetGameConfig() game.GameConfig      { return gs.State.Config }
 func (gs *GameServer) GetMarket() *economy.Market          { return gs.State.Market }
 func (gs *GameServer) GetTradeExecutor() *economy.TradeExecutor {
 	return gs.State.TradeExec
@@ -284,6 +314,12 @@ func (gs *GameServer) GetEventLog() *game.EventLog {
 func (gs *GameServer) GetRegistry() *game.PlayerRegistry {
 	return gs.Registry
 }
+func (gs *GameServer) GetWebhooks() *game.WebhookDispatcher {
+	return gs.Webhooks
+}
+func (gs *GameServer) GetTickableSystems() *tickable.Registry {
+	return gs.tickables()
+}
 func (gs *GameServer) GetChatLog() *game.ChatLog {
 	return gs.Chat
 }
@@ -302,6 +338,9 @@ func (gs *GameServer) GetTickInfo() (tick int64, gameTime string, speed string,
 		gs.TickManager.GetSpeedString(),
 		gs.TickManager.IsPaused()
 }
+func (gs *GameServer) AddTickCallback(fn func(tick int64)) {
+	gs.TickManager.AddTickCallback(fn)
+}
 
 // --- Fleet delegation ---
 
@@ -365,6 +404,59 @@ func (gs *GameServer) FindPath(fromID, toID int) []int {
 	return helper.FindPath(fromID, toID)
 }
 
+// --- economy.OrderAccounts ---
+
+func (gs *GameServer) FindPlayer(name string) *entities.Player {
+	for _, p := range gs.State.Players {
+		if p != nil && p.Name == name {
+			return p
+		}
+	}
+	return nil
+}
+
+func (gs *GameServer) FindPlanet(systemID, planetID int) *entities.Planet {
+	sys := gs.State.GetSystemsMap()[systemID]
+	if sys == nil {
+		return nil
+	}
+	for _, e := range sys.Entities {
+		if p, ok := e.(*entities.Planet); ok && p.GetID() == planetID {
+			return p
+		}
+	}
+	return nil
+}
+
+// onOrderFill logs a limit order fill, counts it toward trade volume and
+// the system's price candles, and adds both sides to the ledger's trade
+// history and cost basis.
+func (gs *GameServer) onOrderFill(f economy.OrderFill) {
+	gs.LogEvent("trade", f.Seller, fmt.Sprintf("%s sold %d %s @ %dcr to %s (limit order)",
+		f.Seller, f.Quantity, f.Resource, f.Price, f.Buyer))
+	if gs.State.Market != nil {
+		gs.State.Market.AddTradeVolume(f.Resource, f.Quantity, true)
+	}
+	if gs.Candles != nil {
+		gs.Candles.AddVolume(gs.TickManager.GetCurrentTick(), f.SystemID, f.Resource, f.Quantity)
+	}
+	if gs.Ledger != nil {
+		tick := gs.TickManager.GetCurrentTick()
+		total := f.Quantity * f.Price
+		for _, side := range []struct{ player, action string }{{f.Buyer, "buy"}, {f.Seller, "sell"}} {
+			gs.Ledger.AddTrade(economy.TradeRecord{
+				Tick:      tick,
+				Player:    side.player,
+				Resource:  f.Resource,
+				Quantity:  f.Quantity,
+				Action:    side.action,
+				UnitPrice: float64(f.Price),
+				Total:     total,
+			})
+		}
+	}
+}
+
 // --- Admin operations ---
 
 // RemovePlayer fully removes a player: releases planets, removes ships from systems, compacts the players slice.
@@ -428,3 +520,6 @@ func (ssc *serverSystemContext) GetPlayers() []*entities.Player {
 func (ssc *serverSystemContext) GetTick() int64 {
 	return ssc.server.TickManager.GetCurrentTick()
 }
+func (ssc *serverSystemContext) GetRand(system string) *rand.Rand {
+	return ssc.server.rng(system)
+}
@@ -1,15 +1,20 @@
 package server
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
 	"fmt"
 	"image/color"
 	"io"
 	"net/http"
 	"strings"
+	"sync/atomic"
 	"time"
 
+	"github.com/hunterjsb/xandaris/api"
 	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
 	"github.com/hunterjsb/xandaris/utils"
 )
 
@@ -21,6 +26,15 @@ type RemoteSync struct {
 	playerName string
 	gs         *GameServer
 	stopCh     chan struct{}
+
+	streaming   atomic.Bool // push feed connected: skip the fast poll
+	playerDirty atomic.Bool // a pushed trade/event touched our empire
+
+	// Spectator mode: everything comes from the server's delayed snapshots.
+	// Only the sync goroutine touches these.
+	spectator     bool
+	snapshot      map[string]json.RawMessage // endpoint → its section of the latest snapshot
+	lastEventTick int64
 }
 
 // NewRemoteSync creates a sync client for the remote server.
@@ -33,8 +47,28 @@ func NewRemoteSync(gs *GameServer, serverURL, apiKey string) *RemoteSync {
 	}
 }
 
+// NewSpectatorSync creates a read-only sync client that mirrors the server's
+// delayed spectator snapshots (GET /api/spectate) with an observer key.
+func NewSpectatorSync(gs *GameServer, serverURL, observerKey string) *RemoteSync {
+	rs := NewRemoteSync(gs, serverURL, observerKey)
+	rs.spectator = true
+	return rs
+}
+
+// spectatorPoll is how often a spectator checks for a new snapshot; the
+// server takes one every 10s.
+const spectatorPoll = 10 * time.Second
+
 // Start begins periodic syncing in a goroutine.
+// While the server's push stream is connected, credits arrive with tick
+// summaries and the player is only re-fetched when a pushed trade or event
+// concerns it (or on the slow cycle); the 2s poll resumes if the stream drops.
 func (rs *RemoteSync) Start() {
+	if rs.spectator {
+		go rs.runSpectator()
+		return
+	}
+	go rs.consumeStream()
 	go func() {
 		rs.syncAll()
 		fastTicker := time.NewTicker(2 * time.Second)
@@ -46,8 +80,13 @@ func (rs *RemoteSync) Start() {
 			case <-rs.stopCh:
 				return
 			case <-fastTicker.C:
-				rs.syncPlayer()
+				if !rs.streaming.Load() || rs.playerDirty.Swap(false) {
+					rs.syncPlayer()
+				}
 			case <-slowTicker.C:
+				if rs.streaming.Load() {
+					rs.syncPlayer()
+				}
 				rs.syncEconomy()
 				rs.SyncOwnership()
 				rs.syncFactions()
@@ -61,12 +100,43 @@ func (rs *RemoteSync) Stop() {
 	close(rs.stopCh)
 }
 
+// runSpectator mirrors each new snapshot. There's no push stream: it would
+// be live, and spectators only get the delayed view.
+func (rs *RemoteSync) runSpectator() {
+	ticker := time.NewTicker(spectatorPoll)
+	defer ticker.Stop()
+	synced := false
+	for {
+		if err := rs.refreshSnapshot(); err != nil {
+			fmt.Printf("[Sync] Spectator snapshot unavailable: %v\n", err)
+		} else if !synced {
+			rs.syncAll()
+			synced = true
+		} else {
+			rs.SyncOwnership()
+			rs.syncFactions()
+			rs.syncEconomy()
+			rs.syncShips()
+		}
+		if synced {
+			rs.syncSnapshotEvents()
+		}
+		select {
+		case <-rs.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // syncAll fetches everything from the remote server.
 func (rs *RemoteSync) syncAll() {
 	rs.syncGalaxyPositions()
 	rs.syncFactions()
 	rs.SyncOwnership() // before syncPlayer so local planets are linked first
-	rs.syncPlayer()
+	if !rs.spectator {
+		rs.syncPlayer()
+	}
 	rs.syncEconomy()
 	rs.syncShips()
 }
@@ -74,7 +144,7 @@ func (rs *RemoteSync) syncAll() {
 // syncGalaxyPositions updates local system X,Y from the remote server
 // so the galaxy map matches what the spectator/server shows.
 func (rs *RemoteSync) syncGalaxyPositions() {
-	data, err := rs.apiGet("/api/galaxy")
+	data, err := rs.fetch("/api/galaxy")
 	if err != nil {
 		return
 	}
@@ -123,7 +193,7 @@ func (rs *RemoteSync) syncGalaxyPositions() {
 
 // syncEconomy updates market prices from the remote server.
 func (rs *RemoteSync) syncEconomy() {
-	data, err := rs.apiGet("/api/economy")
+	data, err := rs.fetch("/api/economy")
 	if err != nil {
 		return
 	}
@@ -359,28 +429,36 @@ func (rs *RemoteSync) ForwardBuild(planetID int, buildingType string, resourceID
 	return rs.apiPost("/api/build", body)
 }
 
-// FetchSeed gets the galaxy seed from the remote server.
-func (rs *RemoteSync) FetchSeed() (int64, error) {
+// FetchConfig gets the galaxy seed and game config from the remote
+// server. Servers from before game configs only report a seed; their
+// galaxies use the default setup.
+func (rs *RemoteSync) FetchConfig() (game.GameConfig, error) {
 	data, err := rs.apiGet("/api/game")
 	if err != nil {
-		return 0, err
+		return game.GameConfig{}, err
 	}
 	var resp struct {
 		OK   bool `json:"ok"`
 		Data struct {
-			Seed int64 `json:"seed"`
+			Seed   int64            `json:"seed"`
+			Config *game.GameConfig `json:"config"`
 		} `json:"data"`
 	}
 	if err := json.Unmarshal(data, &resp); err != nil || !resp.OK {
-		return 0, fmt.Errorf("failed to fetch game info")
+		return game.GameConfig{}, fmt.Errorf("failed to fetch game info")
+	}
+	cfg := game.DefaultGameConfig()
+	if resp.Data.Config != nil {
+		cfg = *resp.Data.Config
 	}
-	return resp.Data.Seed, nil
+	cfg.Seed = resp.Data.Seed
+	return cfg, nil
 }
 
 // SyncOwnership updates planet ownership from the remote galaxy and links
 // planets to their local Player objects.
 func (rs *RemoteSync) SyncOwnership() {
-	data, err := rs.apiGet("/api/galaxy")
+	data, err := rs.fetch("/api/galaxy")
 	if err != nil {
 		return
 	}
@@ -446,7 +524,7 @@ func (rs *RemoteSync) SyncOwnership() {
 // syncFactions fetches the player list from the remote server and creates local
 // Player objects for AI factions so the UI can display their names and colors.
 func (rs *RemoteSync) syncFactions() {
-	data, err := rs.apiGet("/api/players")
+	data, err := rs.fetch("/api/players")
 	if err != nil {
 		return
 	}
@@ -537,7 +615,7 @@ type remoteShipInfo struct {
 // syncShips fetches ship data from the remote server and updates local state.
 // This lets the remote client see other players' ships moving around the galaxy.
 func (rs *RemoteSync) syncShips() {
-	data, err := rs.apiGet("/api/ships")
+	data, err := rs.fetch("/api/ships")
 	if err != nil {
 		return
 	}
@@ -610,6 +688,189 @@ func (rs *RemoteSync) syncShips() {
 	}
 }
 
+// consumeStream keeps the server's /api/stream push feed open, reconnecting
+// with exponential backoff. Servers without the endpoint leave us polling.
+func (rs *RemoteSync) consumeStream() {
+	backoff := 2 * time.Second
+	for {
+		connected, err := rs.readStream()
+		rs.streaming.Store(false)
+		select {
+		case <-rs.stopCh:
+			return
+		default:
+		}
+		if connected {
+			backoff = 2 * time.Second
+		}
+		fmt.Printf("[Sync] Push stream unavailable, polling (retry in %v): %v\n", backoff, err)
+
+		select {
+		case <-rs.stopCh:
+			return
+		case <-time.After(backoff):
+		}
+		if backoff < time.Minute {
+			backoff *= 2
+		}
+	}
+}
+
+// readStream reads one stream connection until it ends. Reports whether the
+// connection was established.
+func (rs *RemoteSync) readStream() (bool, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		select {
+		case <-rs.stopCh:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", rs.serverURL+"/api/stream", nil)
+	if err != nil {
+		return false, err
+	}
+	if rs.apiKey != "" {
+		req.Header.Set("X-API-Key", rs.apiKey)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/event-stream") {
+		return false, fmt.Errorf("stream endpoint returned %s", resp.Status)
+	}
+
+	rs.streaming.Store(true)
+	fmt.Println("[Sync] Connected to push stream")
+
+	var kind string
+	var data []byte
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "":
+			if kind != "" && len(data) > 0 {
+				rs.handleStreamMessage(kind, data)
+			}
+			kind, data = "", nil
+		case strings.HasPrefix(line, "event: "):
+			kind = strings.TrimPrefix(line, "event: ")
+		case strings.HasPrefix(line, "data: "):
+			data = append(data, strings.TrimPrefix(line, "data: ")...)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return true, err
+	}
+	return true, fmt.Errorf("stream closed by server")
+}
+
+// handleStreamMessage applies one pushed message to the local mirror.
+func (rs *RemoteSync) handleStreamMessage(kind string, data []byte) {
+	switch kind {
+	case api.StreamTick:
+		var summary api.TickSummary
+		if json.Unmarshal(data, &summary) == nil && rs.gs.State.HumanPlayer != nil {
+			rs.gs.State.HumanPlayer.Credits = summary.Credits
+		}
+	case api.StreamTrade:
+		// The server only pushes our own trades
+		rs.playerDirty.Store(true)
+	case api.StreamEvent:
+		var ev game.GameEvent
+		if json.Unmarshal(data, &ev) != nil {
+			return
+		}
+		if rs.gs.Events != nil {
+			rs.gs.Events.Add(ev.Tick, ev.Time, ev.Type, ev.Player, ev.Message)
+		}
+		if hp := rs.gs.State.HumanPlayer; hp != nil && ev.Player == hp.Name {
+			rs.playerDirty.Store(true)
+		}
+	case api.StreamChat:
+		var msg game.ChatMsg
+		if json.Unmarshal(data, &msg) == nil && rs.gs.Chat != nil {
+			rs.gs.Chat.Send(msg.Tick, msg.Time, msg.Player, msg.Message)
+		}
+	}
+}
+
+// spectatorSections maps the endpoints the sync reads to their section of
+// a spectator snapshot, which has the same shape.
+var spectatorSections = map[string]string{
+	"/api/galaxy":  "galaxy",
+	"/api/players": "players",
+	"/api/ships":   "ships",
+	"/api/economy": "economy",
+	"/api/events":  "events",
+}
+
+// fetch reads a galaxy-wide endpoint: live, or in spectator mode from the
+// latest snapshot, wrapped like the live response.
+func (rs *RemoteSync) fetch(endpoint string) ([]byte, error) {
+	if !rs.spectator {
+		return rs.apiGet(endpoint)
+	}
+	section, ok := rs.snapshot[endpoint]
+	if !ok {
+		return nil, fmt.Errorf("%s is not in the spectator snapshot", endpoint)
+	}
+	return json.Marshal(api.APIResponse{OK: true, Data: section})
+}
+
+// refreshSnapshot fetches the server's current spectator snapshot.
+func (rs *RemoteSync) refreshSnapshot() error {
+	data, err := rs.apiGet("/api/spectate")
+	if err != nil {
+		return err
+	}
+	var resp struct {
+		OK    bool                       `json:"ok"`
+		Error string                     `json:"error"`
+		Data  map[string]json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return err
+	}
+	if !resp.OK {
+		return fmt.Errorf("%s", resp.Error)
+	}
+	snapshot := make(map[string]json.RawMessage, len(spectatorSections))
+	for endpoint, section := range spectatorSections {
+		if raw, ok := resp.Data[section]; ok {
+			snapshot[endpoint] = raw
+		}
+	}
+	rs.snapshot = snapshot
+	return nil
+}
+
+// syncSnapshotEvents adds the snapshot's events that are newer than the
+// last ones added to the local event log.
+func (rs *RemoteSync) syncSnapshotEvents() {
+	var events []game.GameEvent
+	if json.Unmarshal(rs.snapshot["/api/events"], &events) != nil || rs.gs.Events == nil {
+		return
+	}
+	last := rs.lastEventTick
+	for i := len(events) - 1; i >= 0; i-- { // oldest first
+		ev := events[i]
+		if ev.Tick <= rs.lastEventTick {
+			continue
+		}
+		rs.gs.Events.Add(ev.Tick, ev.Time, ev.Type, ev.Player, ev.Message)
+		last = max(last, ev.Tick)
+	}
+	rs.lastEventTick = last
+}
+
 func (rs *RemoteSync) apiGet(endpoint string) ([]byte, error) {
 	req, err := http.NewRequest("GET", rs.serverURL+endpoint, nil)
 	if err != nil {
@@ -0,0 +1,137 @@
+package server
+
+import (
+	"bytes"
+	"encoding/gob"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// gobRoundTrip returns sf as a load would see it.
+func gobRoundTrip(t *testing.T, sf *saveFile) *saveFile {
+	t.Helper()
+	var out saveFile
+	if err := gob.NewDecoder(bytes.NewReader(encodeForTest(t, sf))).Decode(&out); err != nil {
+		t.Fatalf("gob decode: %v", err)
+	}
+	return &out
+}
+
+func TestSaveJSONRoundTrip(t *testing.T) {
+	var systems []*entities.System
+	for id := 1; id <= 3; id++ {
+		sys := &entities.System{ID: id, Name: "Sys", Connections: []int{id % 3}}
+		for _, e := range entities.GenerateEntitiesForSystem(id, int64(id)*77) {
+			sys.AddEntity(e)
+		}
+		systems = append(systems, sys)
+	}
+	var home *entities.Planet
+	for _, e := range systems[0].Entities {
+		if p, ok := e.(*entities.Planet); ok {
+			home = p
+			break
+		}
+	}
+	if home == nil {
+		t.Fatal("generated system has no planet")
+	}
+	home.Owner = "Alpha"
+	home.StoredResources = map[string]*entities.ResourceStorage{"Iron": {ResourceType: "Iron", Amount: 40, Capacity: 100}}
+	home.Buildings = append(home.Buildings, &entities.Building{BuildingType: "Mine", Level: 2, IsOperational: true})
+	ship := &entities.Ship{ShipType: "Cargo", Owner: "Alpha", CargoHold: map[string]int{"Water": 12}, RoutePath: []int{1, 2}}
+	ship.ID = 900
+	systems[1].AddEntity(ship)
+	bank, err := tickable.NewRegistry().SystemByName("InterstellarBank").(tickable.Snapshotter).Snapshot()
+	if err != nil {
+		t.Fatalf("snapshot bank: %v", err)
+	}
+
+	src := &saveFile{
+		Version:    SaveVersion,
+		SavedAt:    time.Date(2026, 3, 1, 12, 30, 0, 123456789, time.UTC),
+		PlayerName: "Alpha",
+		Tick:       4321,
+		Seed:       99,
+		Systems:    systems,
+		Hyperlanes: []entities.Hyperlane{{From: 1, To: 2}},
+		Players: []*entities.Player{{
+			ID: 1, Name: "Alpha", Type: entities.PlayerTypeHuman, Credits: 5000,
+			HomeSystem: systems[0], HomePlanet: home,
+			OwnedPlanets: []*entities.Planet{home}, OwnedShips: []*entities.Ship{ship},
+			SyncedPopulation: 123,
+		}},
+		ConstructionQueues: map[string][]*tickable.ConstructionItem{"planet_1": {{ID: "c1", Type: "Building", RemainingTicks: 7}}},
+		MarketSnapshot:     &economy.MarketSnapshot{Resources: map[string]economy.ResourceMarket{"Iron": {BasePrice: 10, CurrentPrice: 12.345678901234, PriceHistory: []float64{1.1, 2.2}}}},
+		CreditLimits:       map[string]map[string]int{"Alpha": {"Beta": 1000}},
+		CouncilProposals:   []*economy.CouncilProposal{{ID: 1, Votes: map[string]bool{"Beta": false}}},
+		TickableState:      map[string][]byte{"InterstellarBank": bank, "Legacy": {1, 2, 3, 0, 255}},
+	}
+	want := gobRoundTrip(t, src)
+
+	data, err := encodeSaveJSON(want)
+	if err != nil {
+		t.Fatalf("export: %v", err)
+	}
+	if !strings.Contains(string(data), `"Kind": "Planet"`) {
+		t.Error("expected entities to carry their kind in JSON")
+	}
+	imported, steps, err := decodeSaveJSON(data)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if len(steps) != 0 {
+		t.Errorf("expected no migrations for a current export, got %d", len(steps))
+	}
+	if got := gobRoundTrip(t, imported); !reflect.DeepEqual(want, got) {
+		t.Error("gob → JSON → gob changed the save")
+	}
+	if again, _ := encodeSaveJSON(imported); !bytes.Equal(data, again) {
+		t.Error("expected re-export to be byte-identical")
+	}
+
+	// Tickable state is plain JSON, so hand edits reach the system
+	edited := strings.Replace(string(data), `"InterestRate": 0,`, `"InterestRate": 0.25,`, 1)
+	if edited == string(data) {
+		t.Fatal("expected the bank's interest rate in the export")
+	}
+	imported, _, err = decodeSaveJSON([]byte(edited))
+	if err != nil {
+		t.Fatalf("import edited: %v", err)
+	}
+	restored := tickable.NewRegistry().SystemByName("InterstellarBank").(tickable.Snapshotter)
+	if err := restored.Restore(imported.TickableState["InterstellarBank"]); err != nil {
+		t.Fatalf("restore bank: %v", err)
+	}
+	if snap, _ := restored.Snapshot(); !strings.Contains(string(snap), `"InterestRate":0.25`) {
+		t.Errorf("expected the edited interest rate to survive import, got %s", snap)
+	}
+
+	// File paths: export then import yields a loadable .xsave
+	dir := t.TempDir()
+	xsave, jsonPath := filepath.Join(dir, "a.xsave"), filepath.Join(dir, "a.json")
+	if err := writeSaveFile(xsave, want); err != nil {
+		t.Fatalf("write: %v", err)
+	}
+	if err := ExportSaveJSON(xsave, jsonPath); err != nil {
+		t.Fatalf("ExportSaveJSON: %v", err)
+	}
+	back := filepath.Join(dir, "b.xsave")
+	if err := ImportSaveJSON(jsonPath, back); err != nil {
+		t.Fatalf("ImportSaveJSON: %v", err)
+	}
+	if sf, _, err := readSaveFile(back); err != nil || sf.Players[0].SyncedPopulation != 123 {
+		t.Errorf("expected imported save to load with player state intact, err %v", err)
+	}
+
+	if _, _, err := decodeSaveJSON([]byte(`{"Version":"3.0.0"}`)); err == nil {
+		t.Error("expected JSON without the format marker to be rejected")
+	}
+}
@@ -1,13 +1,15 @@
 package server
 
 import (
+	"bytes"
 	"encoding/gob"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"time"
 
-	"github.com/hunterjsb/xandaris/api"
 	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/entities/planet"
@@ -19,8 +21,8 @@ import (
 const (
 	saveDirectory = "saves"
 	saveExtension = ".xsave"
-	// SaveVersion — bump this when save format is incompatible.
-	// The autosave loader will discard saves with a different version.
+	// SaveVersion — bump this when the save format changes and register a
+	// migration from the previous version in migrate.go.
 	// 3.0.0: logistics-driven trade (deliveries, credit ledger, shipping routes, docking)
 	SaveVersion = "3.0.0"
 )
@@ -46,56 +48,65 @@ func init() {
 	gob.Register(entities.Composition{})
 }
 
-// SaveGame saves the current game state.
-// Safe to call from outside the tick loop (acquires gs.mu).
-func (gs *GameServer) SaveGame(playerName string) error {
-	gs.mu.Lock()
-	defer gs.mu.Unlock()
-	return gs.saveGameLocked(playerName)
+// saveFile is the on-disk layout of a .xsave file. Gob matches fields by
+// name, so new fields can be appended freely: older saves decode with the
+// new fields zeroed and older builds ignore fields they don't know.
+type saveFile struct {
+	Version            string
+	SavedAt            time.Time
+	PlayerName         string
+	GameTime           string
+	Tick               int64
+	Seed               int64
+	TickSpeed          systems.TickSpeed
+	Systems            []*entities.System
+	Hyperlanes         []entities.Hyperlane
+	Players            []*entities.Player
+	ConstructionQueues map[string][]*tickable.ConstructionItem
+	MarketSnapshot     *economy.MarketSnapshot
+	StandingOrders     []*game.StandingOrder
+	Deliveries         []*economy.PendingDelivery
+	ShippingRoutes     []*game.ShippingRoute
+	CreditOutstanding  map[string]map[string]int
+	CreditLimits       map[string]map[string]int
+	MarketOrders       []*economy.MarketOrder
+	MarketOrderNextID  int
+	Contracts          []*economy.TradeContract
+	DiplomacyRelations map[string]map[string]int
+	TickableState      map[string][]byte
+	// Economy managers
+	SpyOps                  []*economy.SpyOperation
+	SpyNextID               int
+	Bounties                []*economy.Bounty
+	BountyNextID            int
+	Auctions                []*economy.Auction
+	AuctionNextID           int
+	CouncilProposals        []*economy.CouncilProposal
+	CouncilNextID           int
+	BlackMarketTransactions int
+	BlackMarketSeizures     int
+	// Every credit movement and the factions' cost basis (nil in saves
+	// from before the ledger: it opens at each faction's current balance)
+	Ledger *economy.LedgerSnapshot
+	// Match setup (nil in saves from before game configs: the defaults)
+	Config *game.GameConfig
+	// OHLCV price candles, global and per system
+	Candles []economy.CandleSeries
+	// Futures and options positions and margin accounts
+	Futures *economy.FuturesSnapshot
+	// Tickable cadences changed at runtime, by system name
+	ScheduleOverrides map[string]tickable.Schedule
 }
 
-// saveGameLocked does the actual save work. Caller must hold gs.mu.
-func (gs *GameServer) saveGameLocked(playerName string) error {
-	if err := os.MkdirAll(saveDirectory, 0755); err != nil {
-		return fmt.Errorf("failed to create save directory: %w", err)
-	}
-
-	timestamp := time.Now().Format("2006-01-02_15-04-05")
-	filename := filepath.Join(saveDirectory, fmt.Sprintf("%s_%s%s", playerName, timestamp, saveExtension))
-
-	file, err := os.Create(filename)
-	if err != nil {
-		return fmt.Errorf("failed to create save file: %w", err)
-	}
-	defer file.Close()
-
+// buildSaveFile captures the current game state. Caller must hold gs.mu.
+func (gs *GameServer) buildSaveFile(playerName string) *saveFile {
 	var constructionQueues map[string][]*tickable.ConstructionItem
-	if cs := tickable.GetConstructionSystem(); cs != nil {
+	if cs := gs.tickables().ConstructionSystem(); cs != nil {
 		constructionQueues = cs.GetAllQueues()
 	}
+	config := gs.State.Config
 
-	saveData := struct {
-		Version            string
-		SavedAt            time.Time
-		PlayerName         string
-		GameTime           string
-		Tick               int64
-		Seed               int64
-		TickSpeed          systems.TickSpeed
-		Systems            []*entities.System
-		Hyperlanes         []entities.Hyperlane
-		Players            []*entities.Player
-		ConstructionQueues map[string][]*tickable.ConstructionItem
-		MarketSnapshot     *economy.MarketSnapshot
-		StandingOrders     []*game.StandingOrder
-		Deliveries         []*economy.PendingDelivery
-		ShippingRoutes     []*game.ShippingRoute
-		CreditOutstanding  map[string]map[string]int
-		CreditLimits       map[string]map[string]int
-		MarketOrders       []*economy.MarketOrder
-		Contracts          []*economy.TradeContract
-		DiplomacyRelations map[string]map[string]int
-	}{
+	sf := &saveFile{
 		Version:            SaveVersion,
 		SavedAt:            time.Now(),
 		PlayerName:         playerName,
@@ -114,102 +125,200 @@ func (gs *GameServer) saveGameLocked(playerName string) error {
 		CreditOutstanding:  gs.getCreditOutstanding(),
 		CreditLimits:       gs.getCreditLimits(),
 		MarketOrders:       gs.getMarketOrders(),
+		MarketOrderNextID:  gs.getMarketOrderNextID(),
 		Contracts:          gs.getContracts(),
 		DiplomacyRelations: gs.getDiplomacyRelations(),
+		TickableState:      gs.tickables().Snapshot(),
+		Config:             &config,
+		ScheduleOverrides:  gs.tickables().ScheduleOverrides(),
 	}
+	gs.captureEconomyManagers(sf)
+	return sf
+}
 
-	if err := gob.NewEncoder(file).Encode(saveData); err != nil {
-		return fmt.Errorf("failed to encode save data: %w", err)
-	}
-
-	fmt.Printf("[Server] Game saved to: %s\n", filename)
-	return nil
+// captureSave builds a save and detaches it from live state so it can be
+// encoded without holding gs.mu. Caller must hold gs.mu.
+func (gs *GameServer) captureSave(playerName string) *saveFile {
+	return cloneSaveFile(gs.buildSaveFile(playerName))
 }
 
-// AutoSave saves to a fixed path, overwriting the previous autosave.
-// Acquires gs.mu to prevent race with the tick loop modifying state.
-func (gs *GameServer) AutoSave(path string) error {
+// SaveGame saves the current game state to a new timestamped file.
+// Safe to call from outside the tick loop: gs.mu is held only while the
+// state is captured, and the file is written on the calling goroutine.
+func (gs *GameServer) SaveGame(playerName string) error {
 	gs.mu.Lock()
-	defer gs.mu.Unlock()
-	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
-		return fmt.Errorf("failed to create save directory: %w", err)
-	}
+	sf := gs.captureSave(playerName)
+	gs.mu.Unlock()
+	_, err := gs.writeTimestampedSave(sf)
+	return err
+}
 
-	// Write to temp file first, then rename (atomic)
-	tmpPath := path + ".tmp"
-	file, err := os.Create(tmpPath)
-	if err != nil {
-		return fmt.Errorf("failed to create temp file: %w", err)
+// saveGameLocked captures the state and writes it in the background, so a
+// save issued from the tick loop doesn't stall it. done is called from the
+// writing goroutine with the file written or the error. Caller must hold
+// gs.mu.
+func (gs *GameServer) saveGameLocked(playerName string, done func(path string, err error)) {
+	sf := gs.captureSave(playerName)
+	go func() {
+		done(gs.writeTimestampedSave(sf))
+	}()
+}
+
+// writeTimestampedSave writes sf to saves/<player>_<timestamp>.xsave
+// (or under SaveDir if set) and returns the file's path.
+func (gs *GameServer) writeTimestampedSave(sf *saveFile) (string, error) {
+	dir := saveDirectory
+	if gs.SaveDir != "" {
+		dir = gs.SaveDir
 	}
+	timestamp := sf.SavedAt.Format("2006-01-02_15-04-05")
+	filename := filepath.Join(dir, fmt.Sprintf("%s_%s%s", sf.PlayerName, timestamp, saveExtension))
 
-	var constructionQueues map[string][]*tickable.ConstructionItem
-	if cs := tickable.GetConstructionSystem(); cs != nil {
-		constructionQueues = cs.GetAllQueues()
+	gs.saveMu.Lock()
+	defer gs.saveMu.Unlock()
+	if err := writeSaveFile(filename, sf); err != nil {
+		return "", err
 	}
 
+	fmt.Printf("[Server] Game saved to: %s\n", filename)
+	return filename, nil
+}
+
+// AutoSave saves to a fixed path. The previous autosaves are rotated to
+// path.1 … path.N-1 (see AutosaveKeep) and the new file replaces path by
+// atomic rename, so a crash mid-write never leaves path half-written.
+// gs.mu is held only while the state is captured.
+func (gs *GameServer) AutoSave(path string) error {
+	gs.mu.Lock()
 	playerName := "Server"
 	if gs.State.HumanPlayer != nil {
 		playerName = gs.State.HumanPlayer.Name
 	}
+	start := time.Now()
+	sf := gs.captureSave(playerName)
+	captured := time.Since(start)
+	gs.mu.Unlock()
 
-	saveData := struct {
-		Version            string
-		SavedAt            time.Time
-		PlayerName         string
-		GameTime           string
-		Tick               int64
-		Seed               int64
-		TickSpeed          systems.TickSpeed
-		Systems            []*entities.System
-		Hyperlanes         []entities.Hyperlane
-		Players            []*entities.Player
-		ConstructionQueues map[string][]*tickable.ConstructionItem
-		MarketSnapshot     *economy.MarketSnapshot
-		StandingOrders     []*game.StandingOrder
-		Deliveries         []*economy.PendingDelivery
-		ShippingRoutes     []*game.ShippingRoute
-		CreditOutstanding  map[string]map[string]int
-		CreditLimits       map[string]map[string]int
-		MarketOrders       []*economy.MarketOrder
-		Contracts          []*economy.TradeContract
-		DiplomacyRelations map[string]map[string]int
-	}{
-		Version:            SaveVersion,
-		SavedAt:            time.Now(),
-		PlayerName:         playerName,
-		GameTime:           gs.TickManager.GetGameTimeFormatted(),
-		Tick:               gs.TickManager.GetCurrentTick(),
-		Seed:               gs.State.Seed,
-		TickSpeed:          gs.TickManager.GetSpeed().(systems.TickSpeed),
-		Systems:            gs.State.Systems,
-		Hyperlanes:         gs.State.Hyperlanes,
-		Players:            gs.State.Players,
-		ConstructionQueues: constructionQueues,
-		MarketSnapshot:     gs.getMarketSnapshot(),
-		StandingOrders:     gs.State.StandingOrders,
-		Deliveries:         gs.getDeliveries(),
-		ShippingRoutes:     gs.getShippingRoutes(),
-		CreditOutstanding:  gs.getCreditOutstanding(),
-		CreditLimits:       gs.getCreditLimits(),
-		MarketOrders:       gs.getMarketOrders(),
-		Contracts:          gs.getContracts(),
-		DiplomacyRelations: gs.getDiplomacyRelations(),
+	gs.saveMu.Lock()
+	defer gs.saveMu.Unlock()
+	if err := rotateSaves(path, gs.AutosaveKeep); err != nil {
+		fmt.Printf("[Autosave] Rotation failed, overwriting %s: %v\n", path, err)
+	}
+	if err := writeSaveFile(path, sf); err != nil {
+		return err
 	}
 
-	if err := gob.NewEncoder(file).Encode(saveData); err != nil {
-		file.Close()
-		os.Remove(tmpPath)
-		return fmt.Errorf("failed to encode: %w", err)
+	fmt.Printf("[Autosave] Saved tick %d to %s (captured in %v, written in %v)\n",
+		sf.Tick, path, captured.Round(time.Microsecond), time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// rotatedSavePath returns the path of the n-th older copy of a save:
+// saves/autosave.xsave → saves/autosave.2.xsave.
+func rotatedSavePath(path string, n int) string {
+	ext := filepath.Ext(path)
+	return fmt.Sprintf("%s.%d%s", strings.TrimSuffix(path, ext), n, ext)
+}
+
+// rotateSaves shifts path.1 … path.keep-2 up by one and makes path.1 a copy
+// of the current path, leaving path itself in place until the new save is
+// renamed over it. keep counts path itself; keep <= 1 disables rotation.
+func rotateSaves(path string, keep int) error {
+	if keep <= 1 {
+		return nil
 	}
-	file.Close()
+	if _, err := os.Stat(path); err != nil {
+		return nil // nothing to rotate yet
+	}
+	for n := keep - 1; n > 1; n-- {
+		if err := os.Rename(rotatedSavePath(path, n-1), rotatedSavePath(path, n)); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	first := rotatedSavePath(path, 1)
+	os.Remove(first)
+	if err := os.Link(path, first); err == nil {
+		return nil
+	}
+	// Filesystems without hard links get a copy
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(first, data, 0644)
+}
 
-	// Atomic rename
-	if err := os.Rename(tmpPath, path); err != nil {
-		return fmt.Errorf("failed to rename: %w", err)
+// captureEconomyManagers copies espionage, bounty, auction, council, black
+// market, ledger, price candle and futures state (with ID counters) into the save.
+func (gs *GameServer) captureEconomyManagers(sf *saveFile) {
+	if gs.EspionageMgr != nil {
+		sf.SpyOps = gs.EspionageMgr.GetAllOps()
+		sf.SpyNextID = gs.EspionageMgr.GetNextID()
+	}
+	if gs.BountyBoard != nil {
+		sf.Bounties = gs.BountyBoard.GetAllBounties()
+		sf.BountyNextID = gs.BountyBoard.GetNextID()
 	}
+	if gs.AuctionHouse != nil {
+		sf.Auctions = gs.AuctionHouse.GetAllAuctions()
+		sf.AuctionNextID = gs.AuctionHouse.GetNextID()
+	}
+	if gs.Council != nil {
+		sf.CouncilProposals = gs.Council.GetAllProposals()
+		sf.CouncilNextID = gs.Council.GetNextID()
+	}
+	if gs.BlackMarket != nil {
+		sf.BlackMarketTransactions, sf.BlackMarketSeizures = gs.BlackMarket.GetStats()
+	}
+	if gs.Ledger != nil {
+		ledger := gs.Ledger.Snapshot()
+		sf.Ledger = &ledger
+	}
+	if gs.Candles != nil {
+		sf.Candles = gs.Candles.Snapshot()
+	}
+	if gs.Futures != nil {
+		futures := gs.Futures.Snapshot()
+		sf.Futures = &futures
+	}
+}
 
-	fmt.Printf("[Autosave] Saved tick %d to %s\n", gs.TickManager.GetCurrentTick(), path)
-	return nil
+// restoreEconomyManagers loads espionage, bounty, auction, council, black
+// market, ledger and price candle state into the freshly initialized
+// managers. Saves from before these fields existed decode with them zeroed
+// and restore nothing.
+func (gs *GameServer) restoreEconomyManagers(sf *saveFile) {
+	if sf.SpyOps != nil && gs.EspionageMgr != nil {
+		gs.EspionageMgr.RestoreOps(sf.SpyOps, sf.SpyNextID)
+		fmt.Printf("[Load] Restored %d spy operations\n", len(sf.SpyOps))
+	}
+	if sf.Bounties != nil && gs.BountyBoard != nil {
+		gs.BountyBoard.RestoreBounties(sf.Bounties, sf.BountyNextID)
+		fmt.Printf("[Load] Restored %d bounties\n", len(sf.Bounties))
+	}
+	if sf.Auctions != nil && gs.AuctionHouse != nil {
+		gs.AuctionHouse.RestoreAuctions(sf.Auctions, sf.AuctionNextID)
+		fmt.Printf("[Load] Restored %d auctions\n", len(sf.Auctions))
+	}
+	if sf.CouncilProposals != nil && gs.Council != nil {
+		gs.Council.RestoreProposals(sf.CouncilProposals, sf.CouncilNextID)
+		fmt.Printf("[Load] Restored %d council proposals\n", len(sf.CouncilProposals))
+	}
+	if gs.BlackMarket != nil {
+		gs.BlackMarket.RestoreStats(sf.BlackMarketTransactions, sf.BlackMarketSeizures)
+	}
+	if sf.Ledger != nil && gs.Ledger != nil {
+		gs.Ledger.Restore(*sf.Ledger)
+		fmt.Printf("[Load] Restored %d ledger entries\n", len(sf.Ledger.Entries))
+	}
+	if sf.Candles != nil && gs.Candles != nil {
+		gs.Candles.Restore(sf.Candles)
+		fmt.Printf("[Load] Restored %d price candle series\n", len(sf.Candles))
+	}
+	if sf.Futures != nil && gs.Futures != nil {
+		gs.Futures.Restore(*sf.Futures)
+		fmt.Printf("[Load] Restored %d futures positions\n", len(sf.Futures.Positions))
+	}
 }
 
 func (gs *GameServer) getMarketSnapshot() *economy.MarketSnapshot {
@@ -255,6 +364,13 @@ func (gs *GameServer) getMarketOrders() []*economy.MarketOrder {
 	return gs.OrderBook.GetAllOrders()
 }
 
+func (gs *GameServer) getMarketOrderNextID() int {
+	if gs.OrderBook == nil {
+		return 0
+	}
+	return gs.OrderBook.GetNextID()
+}
+
 func (gs *GameServer) getContracts() []*economy.TradeContract {
 	if gs.ContractMgr == nil {
 		return nil
@@ -273,53 +389,34 @@ func (gs *GameServer) getDiplomacyRelations() map[string]map[string]int {
 func (gs *GameServer) LoadGame(path string) error {
 	fmt.Printf("[Server] Loading game from: %s\n", path)
 
-	file, err := os.Open(path)
+	// Decode and upgrade older formats step by step (see migrate.go)
+	saveData, migrations, err := readSaveFile(path)
 	if err != nil {
-		return fmt.Errorf("failed to open save file: %w", err)
-	}
-	defer file.Close()
-
-	var saveData struct {
-		Version            string
-		SavedAt            time.Time
-		PlayerName         string
-		GameTime           string
-		Tick               int64
-		Seed               int64
-		TickSpeed          systems.TickSpeed
-		Systems            []*entities.System
-		Hyperlanes         []entities.Hyperlane
-		Players            []*entities.Player
-		ConstructionQueues map[string][]*tickable.ConstructionItem
-		MarketSnapshot     *economy.MarketSnapshot
-		StandingOrders     []*game.StandingOrder
-		Deliveries         []*economy.PendingDelivery
-		ShippingRoutes     []*game.ShippingRoute
-		CreditOutstanding  map[string]map[string]int
-		CreditLimits       map[string]map[string]int
-		MarketOrders       []*economy.MarketOrder
-		Contracts          []*economy.TradeContract
-		DiplomacyRelations map[string]map[string]int
-	}
-
-	if err := gob.NewDecoder(file).Decode(&saveData); err != nil {
-		return fmt.Errorf("failed to decode save data: %w", err)
-	}
-
-	// Version check — accept compatible save versions
-	compatibleVersions := map[string]bool{
-		SaveVersion: true,
-		"2.4.0":     true, // pre-logistics saves (missing delivery/shipping/credit fields — zeroed by gob)
-	}
-	if !compatibleVersions[saveData.Version] {
-		return fmt.Errorf("save version mismatch: got %q, need %q", saveData.Version, SaveVersion)
+		return err
+	}
+	if len(migrations) > 0 {
+		// The post-load autosave rewrites this file in the current format
+		backup, err := backupSave(path, migrations[0].From)
+		if err != nil {
+			return fmt.Errorf("failed to back up save before migrating: %w", err)
+		}
+		for _, m := range migrations {
+			fmt.Printf("[Load] Migrated save %s → %s: %s\n", m.From, m.To, m.Note)
+		}
+		fmt.Printf("[Load] Original save kept at %s\n", backup)
 	}
 
 	// Restore state
 	gs.State.Systems = saveData.Systems
 	gs.State.Hyperlanes = saveData.Hyperlanes
 	gs.State.Seed = saveData.Seed
+	gs.randSource = tickable.NewRandSource(gs.State.Seed)
 	gs.State.Players = saveData.Players
+	gs.State.Config = game.DefaultGameConfig()
+	if saveData.Config != nil {
+		gs.State.Config = *saveData.Config
+	}
+	gs.State.Config.Seed = saveData.Seed
 
 	gs.State.Market = economy.RestoreMarket(saveData.MarketSnapshot)
 	gs.State.TradeExec = economy.NewTradeExecutor(gs.State.Market)
@@ -442,10 +539,11 @@ func (gs *GameServer) LoadGame(path string) error {
 
 	// Initialize simulation
 	gs.initSimulation()
+	gs.applyConfig()
 
 	// Restore construction queues
 	if saveData.ConstructionQueues != nil {
-		if cs := tickable.GetConstructionSystem(); cs != nil {
+		if cs := gs.tickables().ConstructionSystem(); cs != nil {
 			cs.RestoreQueues(saveData.ConstructionQueues)
 		}
 	}
@@ -473,7 +571,7 @@ func (gs *GameServer) LoadGame(path string) error {
 
 	// Restore market orders
 	if saveData.MarketOrders != nil && gs.OrderBook != nil {
-		gs.OrderBook.RestoreOrders(saveData.MarketOrders)
+		gs.OrderBook.RestoreOrders(saveData.MarketOrders, saveData.MarketOrderNextID)
 	}
 
 	// Restore contracts
@@ -491,6 +589,17 @@ func (gs *GameServer) LoadGame(path string) error {
 		fmt.Printf("[Load] Restored diplomacy relations for %d factions\n", len(saveData.DiplomacyRelations))
 	}
 
+	// Restore tickable system state (loans, federations, plagues, ...).
+	// Saves from before per-system snapshots simply have no sections.
+	if saveData.TickableState != nil {
+		n := gs.tickables().Restore(saveData.TickableState)
+		fmt.Printf("[Load] Restored state for %d tickable systems\n", n)
+	}
+	gs.tickables().RestoreScheduleOverrides(saveData.ScheduleOverrides)
+
+	// Restore espionage, bounties, auctions, council and black market
+	gs.restoreEconomyManagers(saveData)
+
 	// Retrofit formation physics onto legacy planets (Mass=0)
 	retrofitted := 0
 	for _, sys := range gs.State.Systems {
@@ -516,7 +625,7 @@ func (gs *GameServer) LoadGame(path string) error {
 	}
 
 	// Start API
-	api.StartServer(gs)
+	gs.serveAPI()
 
 	// Reconcile registered accounts that don't have in-game players
 	gs.reconcileRegisteredPlayers()
@@ -537,3 +646,181 @@ func (gs *GameServer) LoadGame(path string) error {
 
 	return nil
 }
+
+// saveJSONFormat marks a JSON save export.
+const saveJSONFormat = "xandaris-save"
+
+// jsonSave is the JSON layout of a save: every saveFile field under its Go
+// name, plus a Format marker. Entity slices tag each element with its
+// "Kind" (see entities.EntityList) so they decode back to the same types.
+type jsonSave struct {
+	Format string
+	*saveFile
+	Players       []*jsonPlayer              // shadows saveFile.Players
+	TickableState map[string]json.RawMessage // shadows saveFile.TickableState
+}
+
+// jsonPlayer adds back the remote-sync counters entities.Player keeps out
+// of JSON, so an export carries everything gob does.
+type jsonPlayer struct {
+	*entities.Player
+	SyncedPopulation int64
+	SyncedPlanets    int
+	SyncedMines      int
+	SyncedBuildings  int
+	SyncedStock      int
+}
+
+// tickableSectionsJSON inlines each tickable section as JSON. Sections
+// still in gob (from saves before snapshots were JSON) go in as base64
+// strings so the export stays lossless.
+func tickableSectionsJSON(sections map[string][]byte) (map[string]json.RawMessage, error) {
+	if sections == nil {
+		return nil, nil
+	}
+	out := make(map[string]json.RawMessage, len(sections))
+	for name, data := range sections {
+		if json.Valid(data) {
+			out[name] = data
+			continue
+		}
+		blob, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		out[name] = blob
+	}
+	return out, nil
+}
+
+// tickableSectionsFromJSON reverses tickableSectionsJSON.
+func tickableSectionsFromJSON(raw map[string]json.RawMessage) (map[string][]byte, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	out := make(map[string][]byte, len(raw))
+	for name, msg := range raw {
+		if len(msg) > 0 && msg[0] == '"' {
+			var blob []byte
+			if err := json.Unmarshal(msg, &blob); err != nil {
+				return nil, fmt.Errorf("tickable state %s: %w", name, err)
+			}
+			out[name] = blob
+			continue
+		}
+		// Compact to the bytes the system itself would have written
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, msg); err != nil {
+			return nil, fmt.Errorf("tickable state %s: %w", name, err)
+		}
+		out[name] = buf.Bytes()
+	}
+	return out, nil
+}
+
+// encodeSaveJSON renders sf as indented JSON. Map keys are sorted, so two
+// exports of the same state are byte-identical and diff cleanly.
+func encodeSaveJSON(sf *saveFile) ([]byte, error) {
+	sections, err := tickableSectionsJSON(sf.TickableState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode save as JSON: %w", err)
+	}
+	js := jsonSave{Format: saveJSONFormat, saveFile: sf, TickableState: sections}
+	for _, p := range sf.Players {
+		if p == nil {
+			js.Players = append(js.Players, nil)
+			continue
+		}
+		js.Players = append(js.Players, &jsonPlayer{
+			Player:           p,
+			SyncedPopulation: p.SyncedPopulation,
+			SyncedPlanets:    p.SyncedPlanets,
+			SyncedMines:      p.SyncedMines,
+			SyncedBuildings:  p.SyncedBuildings,
+			SyncedStock:      p.SyncedStock,
+		})
+	}
+	data, err := json.MarshalIndent(js, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode save as JSON: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// decodeSaveJSON parses a JSON export and migrates it to SaveVersion.
+func decodeSaveJSON(data []byte) (*saveFile, []saveMigration, error) {
+	var header struct{ Format, Version string }
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JSON save: %w", err)
+	}
+	if header.Format != saveJSONFormat {
+		return nil, nil, fmt.Errorf("not a JSON save export (format %q)", header.Format)
+	}
+	path, err := migrationPath(header.Version)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(path) > 0 && path[0].Decode != nil {
+		// The legacy layout is only known in gob form
+		return nil, nil, fmt.Errorf("save version %s can't be imported from JSON; migrate the .xsave first", header.Version)
+	}
+
+	js := jsonSave{saveFile: &saveFile{}}
+	if err := json.Unmarshal(data, &js); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JSON save: %w", err)
+	}
+	sf := js.saveFile
+	sf.Players = nil
+	for _, jp := range js.Players {
+		if jp == nil || jp.Player == nil {
+			sf.Players = append(sf.Players, nil)
+			continue
+		}
+		p := jp.Player
+		p.SyncedPopulation = jp.SyncedPopulation
+		p.SyncedPlanets = jp.SyncedPlanets
+		p.SyncedMines = jp.SyncedMines
+		p.SyncedBuildings = jp.SyncedBuildings
+		p.SyncedStock = jp.SyncedStock
+		sf.Players = append(sf.Players, p)
+	}
+	if sf.TickableState, err = tickableSectionsFromJSON(js.TickableState); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode JSON save: %w", err)
+	}
+
+	if err := applySaveMigrations(sf, path); err != nil {
+		return nil, nil, err
+	}
+	return sf, path, nil
+}
+
+// ExportSaveJSON writes the .xsave at savePath as human-readable JSON to
+// jsonPath, migrating it to the current format first.
+func ExportSaveJSON(savePath, jsonPath string) error {
+	sf, _, err := readSaveFile(savePath)
+	if err != nil {
+		return err
+	}
+	data, err := encodeSaveJSON(sf)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(jsonPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON save: %w", err)
+	}
+	return nil
+}
+
+// ImportSaveJSON converts a JSON export (possibly hand-edited) back into a
+// loadable .xsave at savePath.
+func ImportSaveJSON(jsonPath, savePath string) error {
+	data, err := os.ReadFile(jsonPath)
+	if err != nil {
+		return fmt.Errorf("failed to open JSON save: %w", err)
+	}
+	sf, _, err := decodeSaveJSON(data)
+	if err != nil {
+		return err
+	}
+	return writeSaveFile(savePath, sf)
+}
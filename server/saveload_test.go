@@ -0,0 +1,248 @@
+package server
+
+import (
+	"bytes"
+	"encoding/gob"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+	"github.com/hunterjsb/xandaris/game"
+	"github.com/hunterjsb/xandaris/tickable"
+)
+
+// newEconomyTestServer creates a server with fresh economy managers and no galaxy.
+func newEconomyTestServer() *GameServer {
+	gs := New(1280, 720)
+	gs.EspionageMgr = economy.NewEspionageManager()
+	gs.BountyBoard = economy.NewBountyBoard()
+	gs.AuctionHouse = economy.NewAuctionHouse()
+	gs.Council = economy.NewGalacticCouncil()
+	gs.BlackMarket = economy.NewBlackMarket()
+	return gs
+}
+
+func TestEconomyManagersSaveRoundTrip(t *testing.T) {
+	src := newEconomyTestServer()
+	src.EspionageMgr.LaunchOperation("Alpha", "Beta", "intel", 3, 500, 200)
+	src.EspionageMgr.LaunchOperation("Beta", "Alpha", "sabotage", 4, 2000, 500)
+	src.BountyBoard.PostBounty("Alpha", "deliver", "Bring iron", 1500, "Iron", 100, 42, 3)
+	src.BountyBoard.ClaimBounty(1, "Gamma")
+	src.AuctionHouse.CreateAuction("Blueprint", "Dyson swarm plans", "", 5000, 1000)
+	src.AuctionHouse.PlaceBid(1, "Beta", 6000)
+	src.Council.Propose("Alpha", "Tariff cap", "Cap tariffs at 10%", "tariff_cap", 2000)
+	src.Council.Vote(1, "Beta", false)
+	src.Council.Propose("Gamma", "Empty ballot", "", "none", 2000)
+	src.BlackMarket.RestoreStats(12, 3)
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(src.buildSaveFile("Test")); err != nil {
+		t.Fatalf("encode: %v", err)
+	}
+	var decoded saveFile
+	if err := gob.NewDecoder(&buf).Decode(&decoded); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+
+	dst := newEconomyTestServer()
+	dst.restoreEconomyManagers(&decoded)
+
+	ops := dst.EspionageMgr.GetActiveOps("Beta")
+	if len(ops) != 1 || ops[0].Type != "sabotage" || ops[0].TicksLeft != 500 {
+		t.Errorf("expected Beta's sabotage op restored, got %+v", ops)
+	}
+	if next := dst.EspionageMgr.LaunchOperation("Alpha", "Beta", "intel", 3, 500, 200); next.ID != 3 {
+		t.Errorf("expected next spy op ID 3, got %d", next.ID)
+	}
+
+	bounties := dst.BountyBoard.GetActiveBounties()
+	if len(bounties) != 1 || bounties[0].Claimant != "Gamma" || bounties[0].Reward != 1500 {
+		t.Errorf("expected claimed bounty restored, got %+v", bounties)
+	}
+	if reward := dst.BountyBoard.CompleteBounty(1, "Gamma"); reward != 1500 {
+		t.Errorf("expected restored bounty to pay 1500, got %d", reward)
+	}
+
+	auctions := dst.AuctionHouse.GetActiveAuctions()
+	if len(auctions) != 1 || auctions[0].Bidder != "Beta" || auctions[0].CurrentBid != 6000 {
+		t.Errorf("expected auction bid restored, got %+v", auctions)
+	}
+	if next := dst.AuctionHouse.CreateAuction("Cache", "", "", 100, 10); next.ID != 2 {
+		t.Errorf("expected next auction ID 2, got %d", next.ID)
+	}
+
+	proposals := dst.Council.GetActiveProposals()
+	if len(proposals) != 2 {
+		t.Fatalf("expected 2 proposals restored, got %d", len(proposals))
+	}
+	if yes, ok := proposals[0].Votes["Beta"]; !ok || yes {
+		t.Errorf("expected Beta's no vote restored, got %v", proposals[0].Votes)
+	}
+	if !dst.Council.Vote(2, "Alpha", true) {
+		t.Error("expected vote on restored proposal to succeed")
+	}
+
+	if tx, seized := dst.BlackMarket.GetStats(); tx != 12 || seized != 3 {
+		t.Errorf("expected black market stats 12/3, got %d/%d", tx, seized)
+	}
+}
+
+func TestEconomyManagersLegacySave(t *testing.T) {
+	gs := newEconomyTestServer()
+	gs.restoreEconomyManagers(&saveFile{Version: "2.4.0"})
+
+	if b := gs.BountyBoard.PostBounty("Alpha", "custom", "x", 10, "", 0, 0, 0); b.ID != 1 {
+		t.Errorf("expected fresh bounty board after legacy load, got ID %d", b.ID)
+	}
+}
+
+func TestCloneSaveFileDetachesState(t *testing.T) {
+	planet := &entities.Planet{Population: 100, StoredResources: map[string]*entities.ResourceStorage{"Iron": {Amount: 5}}}
+	planet.Buildings = entities.EntityList{&entities.Building{Level: 1}}
+	sys := &entities.System{ID: 1, Entities: entities.EntityList{planet}}
+	player := &entities.Player{Name: "Alpha", HomeSystem: sys, OwnedPlanets: []*entities.Planet{planet}}
+	item := &tickable.ConstructionItem{ID: "c1"}
+	item.Mutex.Lock()
+	defer item.Mutex.Unlock()
+
+	sf := &saveFile{
+		Systems:            []*entities.System{sys},
+		Players:            []*entities.Player{player},
+		ConstructionQueues: map[string][]*tickable.ConstructionItem{"p1": {item}},
+	}
+	c := cloneSaveFile(sf)
+
+	// Mutating live state after the capture must not leak into the save
+	planet.Population = 999
+	planet.StoredResources["Iron"].Amount = 50
+	planet.Buildings[0].(*entities.Building).Level = 3
+
+	cp := c.Systems[0].Entities[0].(*entities.Planet)
+	if cp == planet || cp.Population != 100 || cp.StoredResources["Iron"].Amount != 5 {
+		t.Errorf("expected a detached planet copy, got pop %d iron %d", cp.Population, cp.StoredResources["Iron"].Amount)
+	}
+	if lvl := cp.Buildings[0].(*entities.Building).Level; lvl != 1 {
+		t.Errorf("expected building level 1 in copy, got %d", lvl)
+	}
+	if c.Players[0].OwnedPlanets[0] != cp || c.Players[0].HomeSystem != c.Systems[0] {
+		t.Error("expected shared pointers to stay shared within the copy")
+	}
+	ci := c.ConstructionQueues["p1"][0]
+	if ci.ID != "c1" || !ci.Mutex.TryLock() {
+		t.Error("expected construction item copied without its lock state")
+	}
+}
+
+func TestAutoSaveRotation(t *testing.T) {
+	gs := newEconomyTestServer()
+	gs.AutosaveKeep = 3
+	path := filepath.Join(t.TempDir(), "autosave.xsave")
+
+	for tick := int64(1); tick <= 4; tick++ {
+		gs.TickManager.SetCurrentTick(tick)
+		if err := gs.AutoSave(path); err != nil {
+			t.Fatalf("autosave %d: %v", tick, err)
+		}
+	}
+
+	for n, want := range []int64{4, 3, 2} {
+		p := path
+		if n > 0 {
+			p = rotatedSavePath(path, n)
+		}
+		sf, _, err := readSaveFile(p)
+		if err != nil || sf.Tick != want {
+			t.Errorf("expected %s at tick %d, got %v", filepath.Base(p), want, err)
+		}
+	}
+	if _, err := os.Stat(rotatedSavePath(path, 3)); !os.IsNotExist(err) {
+		t.Error("expected only 3 autosaves kept")
+	}
+	if _, err := os.Stat(path + ".tmp"); !os.IsNotExist(err) {
+		t.Error("expected temp file renamed away")
+	}
+}
+
+// TestSaveCommandReportsWriteResult verifies that CmdSave replies once the
+// file is written, with its path or the write error.
+func TestSaveCommandReportsWriteResult(t *testing.T) {
+	gs := newEconomyTestServer()
+	gs.initCommandRegistry()
+	gs.SaveDir = t.TempDir()
+	save := func() interface{} {
+		cmd := game.GameCommand{Type: game.CmdSave, Data: "Alpha", Result: make(chan interface{}, 1)}
+		gs.mu.Lock()
+		gs.executeCommand(cmd)
+		gs.mu.Unlock()
+		select {
+		case result := <-cmd.Result:
+			return result
+		case <-time.After(10 * time.Second):
+			t.Fatal("save never replied")
+			return nil
+		}
+	}
+
+	path, ok := save().(string)
+	if !ok {
+		t.Fatalf("expected the saved file's path")
+	}
+	if _, _, err := readSaveFile(path); err != nil {
+		t.Errorf("expected a loadable save at %s: %v", path, err)
+	}
+
+	// A save directory that can't be created fails the command
+	blocker := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(blocker, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	gs.SaveDir = filepath.Join(blocker, "saves")
+	if _, ok := save().(error); !ok {
+		t.Error("expected the write error as the command's result")
+	}
+}
+
+// TestStatefulSystemsAreSaved verifies that every tickable system with
+// fields of its own either implements Snapshotter or is listed as unsaved in
+// docs/SAVE_SYSTEM.md, and that each one's snapshot restores.
+func TestStatefulSystemsAreSaved(t *testing.T) {
+	doc, err := os.ReadFile("../docs/SAVE_SYSTEM.md")
+	if err != nil {
+		t.Fatal(err)
+	}
+	unsaved := make(map[string]bool)
+	for _, m := range regexp.MustCompile("(?m)^\\s*\\| `(\\w+)` \\|").FindAllStringSubmatch(string(doc), -1) {
+		unsaved[m[1]] = true
+	}
+	if len(unsaved) == 0 {
+		t.Fatal("no unsaved systems listed in docs/SAVE_SYSTEM.md")
+	}
+
+	for _, system := range tickable.GetAllSystems() {
+		name := system.GetName()
+		snap, ok := system.(tickable.Snapshotter)
+		if !ok {
+			// Anything beyond the embedded *BaseSystem is state
+			if reflect.ValueOf(system).Elem().NumField() > 1 && !unsaved[name] {
+				t.Errorf("%s holds state but isn't a Snapshotter or listed as unsaved", name)
+			}
+			continue
+		}
+		if unsaved[name] {
+			t.Errorf("%s is listed as unsaved but implements Snapshotter", name)
+		}
+		data, err := snap.Snapshot()
+		if err != nil {
+			t.Errorf("%s: snapshot: %v", name, err)
+			continue
+		}
+		if err := snap.Restore(data); err != nil {
+			t.Errorf("%s: restore: %v", name, err)
+		}
+	}
+}
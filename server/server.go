@@ -2,6 +2,7 @@ package server
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
 	"sync"
 	"time"
@@ -27,6 +28,7 @@ type GameServer struct {
 	Events           *game.EventLog
 	Chat             *game.ChatLog
 	Registry         *game.PlayerRegistry
+	Webhooks         *game.WebhookDispatcher
 	DeliveryMgr      *economy.DeliveryManager
 	ShippingMgr      *game.ShippingManager
 	CreditLedger     *economy.CreditLedger
@@ -38,8 +40,27 @@ type GameServer struct {
 	BlackMarket      *economy.BlackMarket
 	AuctionHouse     *economy.AuctionHouse
 	Council          *economy.GalacticCouncil
+	Ledger           *economy.Ledger
+	Candles          *economy.CandleHistory
+	Futures          *economy.FuturesExchange
 	cmdRegistry      *CommandRegistry
-	mu               sync.Mutex // protects State during save (held by tick loop + autosave)
+	randSource       *tickable.RandSource // per-tick, per-system RNGs derived from State.Seed
+	journal          *CommandJournal      // optional append-only log of executed commands
+	replaying        bool                 // rebuilding from a journal: skip account reconciliation
+	idempotency      *idempotencyCache    // recent results of commands sent with an Idempotency-Key
+	mu               sync.Mutex           // protects State during save (held by tick loop + autosave)
+	saveMu           sync.Mutex           // serializes save file writes (encoding happens outside mu)
+
+	// AutosaveKeep is how many autosaves AutoSave keeps: the latest plus
+	// AutosaveKeep-1 rotated older copies.
+	AutosaveKeep int
+	// GameID is set for games hosted by a GameManager, which are served
+	// under /api/games/{id}/ instead of /api/.
+	GameID string
+	// SaveDir is where SaveGame writes timestamped saves ("" = saves/).
+	SaveDir string
+	// systems are this game's tickable systems (nil = the default registry)
+	systems *tickable.Registry
 	// Remote is set when connected to a remote server (desktop only, not WASM)
 	remoteSync interface{}
 
@@ -54,6 +75,7 @@ func New(screenWidth, screenHeight int) *GameServer {
 	return &GameServer{
 		State:        game.NewState(),
 		TickManager:  systems.NewTickManager(10.0),
+		AutosaveKeep: 3,
 		screenWidth:  screenWidth,
 		screenHeight: screenHeight,
 		stopCh:       make(chan struct{}),
@@ -62,24 +84,36 @@ func New(screenWidth, screenHeight int) *GameServer {
 
 // NewGame initializes a new game with the given player name.
 func (gs *GameServer) NewGame(playerName string) error {
-	return gs.newGame(playerName, false)
+	return gs.newGame(playerName, false, game.DefaultGameConfig())
 }
 
-// NewHeadlessGame initializes a multiplayer game with no default human player.
-// Players join via Discord OAuth or admin registration.
-func (gs *GameServer) NewHeadlessGame() error {
-	return gs.newGame("", true)
+// NewHeadlessGame initializes a multiplayer game with no default human
+// player, set up by cfg (see game.DefaultGameConfig). Players join via
+// Discord OAuth or admin registration. With a fixed cfg.Seed, every random
+// draw in the simulation derives from the seed, so two runs with the same
+// config and command stream evolve identically.
+func (gs *GameServer) NewHeadlessGame(cfg game.GameConfig) error {
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid game config: %w", err)
+	}
+	return gs.newGame("", true, cfg)
 }
 
-func (gs *GameServer) newGame(playerName string, headless bool) error {
+func (gs *GameServer) newGame(playerName string, headless bool, cfg game.GameConfig) error {
 	gs.State.Reset()
-	gs.State.Seed = time.Now().UnixNano()
+	gs.State.Seed = cfg.Seed
+	if gs.State.Seed == 0 {
+		gs.State.Seed = time.Now().UnixNano()
+	}
+	cfg.Seed = gs.State.Seed
+	gs.State.Config = cfg
+	gs.randSource = tickable.NewRandSource(gs.State.Seed)
 
 	// Reset tick manager
 	gs.TickManager.Reset()
 
 	// Generate galaxy
-	galaxyGen := game.NewGalaxyGenerator(gs.screenWidth, gs.screenHeight)
+	galaxyGen := game.NewGalaxyGeneratorFor(gs.screenWidth, gs.screenHeight, cfg)
 	gs.State.Systems = galaxyGen.GenerateSystems(gs.State.Seed)
 	gs.State.Hyperlanes = galaxyGen.GenerateHyperlanes(gs.State.Systems)
 
@@ -87,13 +121,14 @@ func (gs *GameServer) newGame(playerName string, headless bool) error {
 		// Create human player (singleplayer / GUI mode)
 		playerColor := utils.PlayerGreen
 		gs.State.HumanPlayer = entities.NewPlayer(0, playerName, playerColor, entities.PlayerTypeHuman)
+		gs.State.HumanPlayer.Credits = cfg.StartingCredits
 		gs.State.Players = append(gs.State.Players, gs.State.HumanPlayer)
 
 		// Initialize player with starting planet
-		entities.InitializePlayer(gs.State.HumanPlayer, gs.State.Systems)
+		entities.InitializePlayer(gs.State.HumanPlayer, gs.State.Systems, gs.rng("Homeworld"))
 
 		// Prepare human homeworld (Trading Post + seeded commodities, no auto-mines)
-		game.PrepareHomeworld(gs.State.HumanPlayer, false)
+		game.PrepareHomeworld(gs.State.HumanPlayer, false, gs.rng("Homeworld"))
 
 		// Extra starting resources for human player — enough for early infrastructure
 		if gs.State.HumanPlayer.HomePlanet != nil {
@@ -107,13 +142,14 @@ func (gs *GameServer) newGame(playerName string, headless bool) error {
 	gs.State.TradeExec = economy.NewTradeExecutor(gs.State.Market)
 
 	// Seed AI factions
-	game.InitializeAIPlayers(gs.State)
+	game.InitializeAIPlayers(gs.State, gs.rng("AISetup"))
 
 	// Initialize simulation components
 	gs.initSimulation()
+	gs.applyConfig()
 
 	// Start API server
-	api.StartServer(gs)
+	gs.serveAPI()
 
 	// Reconcile: create Player objects for registered accounts that don't have one
 	gs.reconcileRegisteredPlayers()
@@ -131,7 +167,7 @@ func (gs *GameServer) newGame(playerName string, headless bool) error {
 // reconcileRegisteredPlayers creates in-game Player objects for any registered
 // accounts (from accounts.json) that don't already have a matching player.
 func (gs *GameServer) reconcileRegisteredPlayers() {
-	if gs.Registry == nil {
+	if gs.Registry == nil || gs.replaying {
 		return
 	}
 
@@ -149,33 +185,43 @@ func (gs *GameServer) reconcileRegisteredPlayers() {
 			continue
 		}
 
-		// Create a new player for this account
-		playerID := len(gs.State.Players)
-		colors := utils.GetAIPlayerColors()
-		playerColor := colors[playerID%len(colors)]
-		newPlayer := entities.NewPlayer(playerID, acc.Name, playerColor, entities.PlayerTypeHuman)
-
-		entities.InitializePlayer(newPlayer, gs.State.Systems)
-		if newPlayer.HomePlanet == nil {
-			fmt.Printf("[Server] WARNING: Could not find homeworld for %s\n", acc.Name)
+		newPlayer := gs.spawnAccountPlayer(acc.Name)
+		if newPlayer == nil {
 			continue
 		}
-
-		game.PrepareHomeworld(newPlayer, true) // auto-mines + refinery + generator
-		if newPlayer.HomePlanet != nil {
-			newPlayer.HomePlanet.AddStoredResource(entities.ResFuel, 200)
-			newPlayer.HomePlanet.AddStoredResource(entities.ResOil, 150)
-		}
-
-		gs.State.Players = append(gs.State.Players, newPlayer)
-		acc.PlayerID = playerID
+		acc.PlayerID = newPlayer.ID
 		gs.Registry.Save()
 
 		fmt.Printf("[Server] Reconciled player: %s (id=%d, planet=%s)\n",
-			acc.Name, playerID, newPlayer.HomePlanet.Name)
+			acc.Name, newPlayer.ID, newPlayer.HomePlanet.Name)
 	}
 }
 
+// spawnAccountPlayer creates a human player with a prepared homeworld for a
+// registered account. Returns nil if no homeworld could be found.
+func (gs *GameServer) spawnAccountPlayer(name string) *entities.Player {
+	playerID := len(gs.State.Players)
+	colors := utils.GetAIPlayerColors()
+	playerColor := colors[playerID%len(colors)]
+	newPlayer := entities.NewPlayer(playerID, name, playerColor, entities.PlayerTypeHuman)
+	newPlayer.Credits = gs.State.Config.StartingCredits
+
+	entities.InitializePlayer(newPlayer, gs.State.Systems, gs.rng("Homeworld"))
+	if newPlayer.HomePlanet == nil {
+		fmt.Printf("[Server] WARNING: Could not find homeworld for %s\n", name)
+		return nil
+	}
+
+	game.PrepareHomeworld(newPlayer, true, gs.rng("Homeworld")) // auto-mines + refinery + generator
+	if newPlayer.HomePlanet != nil {
+		newPlayer.HomePlanet.AddStoredResource(entities.ResFuel, 200)
+		newPlayer.HomePlanet.AddStoredResource(entities.ResOil, 150)
+	}
+
+	gs.State.Players = append(gs.State.Players, newPlayer)
+	return newPlayer
+}
+
 // cleanupBotPlayers removes players that were erroneously created by AI agents.
 // This is a one-time migration — once the save is clean, this is a no-op.
 func (gs *GameServer) cleanupBotPlayers() {
@@ -195,6 +241,17 @@ func (gs *GameServer) initSimulation() {
 	if gs.Registry == nil {
 		gs.Registry = game.NewPlayerRegistry(os.Getenv("XANDARIS_API_KEY"))
 	}
+	if gs.Webhooks == nil {
+		gs.Webhooks = game.NewWebhookDispatcher(gs.Registry)
+		gs.Webhooks.Start(4)
+	}
+	// Players' webhooks only hear about live play: not a replayed journal,
+	// and not a client mirroring a remote server
+	gs.Events.Subscribe(func(ev game.GameEvent) {
+		if !gs.replaying && gs.remoteSync == nil {
+			gs.Webhooks.Notify(ev)
+		}
+	})
 
 	// Wire trade event logging — throttled to prevent trade spam flooding the event feed.
 	// Only log trades above a minimum value or at most once per player per 500 ticks.
@@ -229,6 +286,8 @@ func (gs *GameServer) initSimulation() {
 	gs.ShippingMgr = game.NewShippingManager()
 	gs.CreditLedger = economy.NewCreditLedger()
 	gs.OrderBook = economy.NewOrderBook()
+	gs.OrderBook.Accounts = gs
+	gs.OrderBook.OnFill = gs.onOrderFill
 	gs.ContractMgr = economy.NewContractManager()
 	gs.DiplomacyMgr = economy.NewDiplomacyManager()
 	gs.EspionageMgr = economy.NewEspionageManager()
@@ -236,25 +295,76 @@ func (gs *GameServer) initSimulation() {
 	gs.BlackMarket = economy.NewBlackMarket()
 	gs.AuctionHouse = economy.NewAuctionHouse()
 	gs.Council = economy.NewGalacticCouncil()
+	gs.Ledger = economy.NewLedger()
+	gs.Candles = economy.NewCandleHistory()
+	gs.Futures = economy.NewFuturesExchange()
+	gs.Futures.Accounts = gs
 
 	if gs.State.TradeExec != nil {
 		gs.State.TradeExec.SetSystems(gs.State.Systems)
 		gs.State.TradeExec.Deliveries = gs.DeliveryMgr
 		gs.State.TradeExec.Dispatcher = gs
 		gs.State.TradeExec.Credits = gs.CreditLedger
+		gs.State.TradeExec.Ledger = gs.Ledger
+		gs.State.TradeExec.Candles = gs.Candles
 	}
 
 	// Initialize tickable systems
 	ctx := &serverSystemContext{server: gs}
-	tickable.InitializeAllSystems(ctx)
+	gs.tickables().Initialize(ctx)
+	gs.tickables().SetAfterTick(gs.reconcileSystemCredits)
 
 	// Register construction handler
-	handler := game.NewConstructionHandler(gs.State.Systems, gs.State.Players, gs.TickManager)
-	if cs := tickable.GetConstructionSystem(); cs != nil {
+	handler := game.NewConstructionHandler(gs.State.Systems, gs.State.Players, gs.TickManager, gs.randSource)
+	if cs := gs.tickables().ConstructionSystem(); cs != nil {
 		cs.RegisterCompletionHandler(handler.HandleConstructionComplete)
 	}
 }
 
+// rng returns the game's seeded RNG for purpose at the current tick, for
+// setup and commands that run outside a tickable system (see
+// tickable.RandSource).
+func (gs *GameServer) rng(purpose string) *rand.Rand {
+	return gs.randSource.Get(gs.TickManager.GetCurrentTick(), purpose)
+}
+
+// applyConfig puts the game's config into effect on the simulation: its
+// tick rate, which tickable systems run and which victories count. Called
+// after initSimulation for new and loaded games alike.
+func (gs *GameServer) applyConfig() {
+	cfg := gs.State.Config
+	gs.TickManager.SetTicksPerSecond(cfg.TicksPerSecond)
+
+	disabled := make(map[string]bool, len(cfg.DisabledSystems))
+	for _, name := range cfg.DisabledSystems {
+		disabled[name] = true
+	}
+	for _, system := range gs.tickables().Systems() {
+		system.SetEnabled(!disabled[system.GetName()])
+	}
+	if vs := gs.tickables().VictorySystem(); vs != nil {
+		vs.SetConditions(cfg.Victory)
+	}
+}
+
+// tickables returns the game's tickable systems.
+func (gs *GameServer) tickables() *tickable.Registry {
+	if gs.systems != nil {
+		return gs.systems
+	}
+	return tickable.DefaultRegistry()
+}
+
+// serveAPI publishes the game on the REST API: as the default game, or
+// under its ID if a GameManager hosts it.
+func (gs *GameServer) serveAPI() {
+	if gs.GameID != "" {
+		api.HostGame(gs.GameID, gs)
+		return
+	}
+	api.StartServer(gs)
+}
+
 // Run starts the headless game loop. Blocks until Stop() is called.
 func (gs *GameServer) Run() {
 	ticker := time.NewTicker(16 * time.Millisecond) // ~60fps simulation
@@ -303,14 +413,17 @@ func (gs *GameServer) DrainCommands() {
 // Interface implementations (GameProvider, GameStateProvider, ShipDispatcher)
 // are in providers.go to keep server.go focused on lifecycle.
 
-// NewGameWithSeed initializes a game using a specific seed (for remote sync).
-func (gs *GameServer) NewGameWithSeed(playerName string, seed int64) error {
+// NewGameWithConfig initializes a game from a remote server's config (for
+// remote sync): the same seed and galaxy size generate the same universe.
+func (gs *GameServer) NewGameWithConfig(playerName string, cfg game.GameConfig) error {
 	gs.State.Reset()
-	gs.State.Seed = seed
+	gs.State.Seed = cfg.Seed
+	gs.State.Config = cfg
+	gs.randSource = tickable.NewRandSource(gs.State.Seed)
 
 	gs.TickManager.Reset()
 
-	galaxyGen := game.NewGalaxyGenerator(gs.screenWidth, gs.screenHeight)
+	galaxyGen := game.NewGalaxyGeneratorFor(gs.screenWidth, gs.screenHeight, cfg)
 	gs.State.Systems = galaxyGen.GenerateSystems(gs.State.Seed)
 	gs.State.Hyperlanes = galaxyGen.GenerateHyperlanes(gs.State.Systems)
 
@@ -325,9 +438,10 @@ func (gs *GameServer) NewGameWithSeed(playerName string, seed int64) error {
 
 	// Don't seed AI factions — they exist on the remote server
 	gs.initSimulation()
+	gs.applyConfig()
 
 	fmt.Printf("[Server] Game initialized with remote seed %d (%d systems)\n",
-		seed, len(gs.State.Systems))
+		cfg.Seed, len(gs.State.Systems))
 
 	return nil
 }
@@ -0,0 +1,148 @@
+package server
+
+import (
+	"reflect"
+	"sync"
+)
+
+var lockerType = reflect.TypeOf((*sync.Locker)(nil)).Elem()
+
+// cloneSaveFile deep-copies a save so it can be encoded after gs.mu is
+// released. buildSaveFile hands out live systems, players and entities;
+// the tick loop only waits for this in-memory copy, while the gob encode
+// and disk write run without the lock.
+//
+// Like gob, the copy follows exported fields only: unexported references
+// (entity attachments) and lock state are left zero. Pointers shared
+// within the save stay shared in the copy.
+func cloneSaveFile(sf *saveFile) *saveFile {
+	c := &cloner{seen: make(map[clonedPtr]reflect.Value), types: make(map[reflect.Type]*cloneType)}
+	return c.clone(reflect.ValueOf(sf)).Interface().(*saveFile)
+}
+
+type cloner struct {
+	seen  map[clonedPtr]reflect.Value
+	types map[reflect.Type]*cloneType
+}
+
+type clonedPtr struct {
+	addr uintptr
+	typ  reflect.Type
+}
+
+// cloneType is the cached per-type copy plan.
+type cloneType struct {
+	plain  bool  // holds no references: copy by value
+	skip   bool  // lock state: leave zero
+	fields []int // exported fields to copy (structs)
+}
+
+// typeOf returns (and caches) the copy plan for t.
+func (c *cloner) typeOf(t reflect.Type) *cloneType {
+	if ct, ok := c.types[t]; ok {
+		return ct
+	}
+	ct := &cloneType{}
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Slice, reflect.Map,
+		reflect.Chan, reflect.Func, reflect.UnsafePointer:
+	case reflect.Array:
+		ct.plain = c.typeOf(t.Elem()).plain
+	case reflect.Struct:
+		ct.skip = reflect.PointerTo(t).Implements(lockerType)
+		ct.plain = !ct.skip
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			if f.IsExported() {
+				ct.fields = append(ct.fields, i)
+			}
+			if fct := c.typeOf(f.Type); !fct.plain || fct.skip {
+				ct.plain = false
+			}
+		}
+	default:
+		ct.plain = true
+	}
+	c.types[t] = ct
+	return ct
+}
+
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	out := reflect.New(v.Type()).Elem()
+	c.copyInto(out, v)
+	return out
+}
+
+func (c *cloner) copyInto(dst, src reflect.Value) {
+	ct := c.typeOf(src.Type())
+	if ct.plain {
+		dst.Set(src)
+		return
+	}
+	switch src.Kind() {
+	case reflect.Ptr:
+		if src.IsNil() {
+			return
+		}
+		key := clonedPtr{src.Pointer(), src.Type()}
+		if p, ok := c.seen[key]; ok {
+			dst.Set(p)
+			return
+		}
+		p := reflect.New(src.Type().Elem())
+		c.seen[key] = p
+		c.copyInto(p.Elem(), src.Elem())
+		dst.Set(p)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+		dst.Set(c.clone(src.Elem()))
+
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+		s := reflect.MakeSlice(src.Type(), src.Len(), src.Len())
+		if c.typeOf(src.Type().Elem()).plain {
+			reflect.Copy(s, src)
+		} else {
+			for i := 0; i < src.Len(); i++ {
+				c.copyInto(s.Index(i), src.Index(i))
+			}
+		}
+		dst.Set(s)
+
+	case reflect.Array:
+		for i := 0; i < src.Len(); i++ {
+			c.copyInto(dst.Index(i), src.Index(i))
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+		m := reflect.MakeMapWithSize(src.Type(), src.Len())
+		iter := src.MapRange()
+		for iter.Next() {
+			m.SetMapIndex(c.clone(iter.Key()), c.clone(iter.Value()))
+		}
+		dst.Set(m)
+
+	case reflect.Struct:
+		if ct.skip {
+			return // never copy lock state
+		}
+		if len(ct.fields) == 0 {
+			// Opaque values such as time.Time are copied whole
+			dst.Set(src)
+			return
+		}
+		for _, i := range ct.fields {
+			c.copyInto(dst.Field(i), src.Field(i))
+		}
+
+		// Chan, Func, UnsafePointer: not part of a save
+	}
+}
@@ -32,6 +32,7 @@ type TickManager struct {
 	lastUpdateTime time.Time
 	listeners      []TickListener
 	tickCallbacks  []func(tick int64)
+	systems        *tickable.Registry // nil = the default registry
 }
 
 // NewTickManager creates a new tick manager
@@ -80,10 +81,21 @@ func (tm *TickManager) Update() {
 	}
 }
 
+// Step advances exactly one tick, ignoring pause state and wall-clock time
+// (used for journal replay)
+func (tm *TickManager) Step() {
+	tm.currentTick++
+	tm.processTick()
+}
+
 // processTick notifies all listeners about a new tick
 func (tm *TickManager) processTick() {
 	// Update all tickable systems sequentially (priority order ensures correct data dependencies)
-	tickable.UpdateAllSystemsSequential(tm.currentTick)
+	if tm.systems != nil {
+		tm.systems.UpdateSequential(tm.currentTick)
+	} else {
+		tickable.UpdateAllSystemsSequential(tm.currentTick)
+	}
 
 	// Notify all listeners
 	for _, listener := range tm.listeners {
@@ -96,6 +108,12 @@ func (tm *TickManager) processTick() {
 	}
 }
 
+// SetSystems makes the tick manager run r's systems instead of the default
+// registry's (used when several games share a process).
+func (tm *TickManager) SetSystems(r *tickable.Registry) {
+	tm.systems = r
+}
+
 // GetCurrentTick returns the current tick number
 func (tm *TickManager) GetCurrentTick() int64 {
 	return tm.currentTick
@@ -201,6 +219,13 @@ func (tm *TickManager) GetTicksPerSecond() float64 {
 	return tm.ticksPerSecond
 }
 
+// SetTicksPerSecond changes the base ticks per second (non-positive values are ignored)
+func (tm *TickManager) SetTicksPerSecond(ticksPerSecond float64) {
+	if ticksPerSecond > 0 {
+		tm.ticksPerSecond = ticksPerSecond
+	}
+}
+
 // GetEffectiveTicksPerSecond returns the current effective ticks per second (base * speed)
 func (tm *TickManager) GetEffectiveTicksPerSecond() float64 {
 	if tm.isPaused {
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AdvancedProductionSystem{
-		BaseSystem: NewBaseSystem("AdvancedProduction", 16),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AdvancedProductionSystem{
+			BaseSystem: NewBaseSystem("AdvancedProduction", 16).Every(10),
+		}
 	})
 }
 
@@ -27,10 +29,6 @@ type AdvancedProductionSystem struct {
 }
 
 func (aps *AdvancedProductionSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := aps.GetContext()
 	if ctx == nil {
 		return
@@ -7,9 +7,11 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AlertSystem{
-		BaseSystem:  NewBaseSystem("Alerts", 55),
-		lastAlerted: make(map[string]int64),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AlertSystem{
+			BaseSystem:  NewBaseSystem("Alerts", 55).Every(50),
+			lastAlerted: make(map[string]int64),
+		}
 	})
 }
 
@@ -23,10 +25,6 @@ type AlertSystem struct {
 const alertCooldown = 3000 // ticks between repeated alerts (~5 minutes, was 500)
 
 func (as *AlertSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := as.GetContext()
 	if ctx == nil {
 		return
@@ -143,3 +141,28 @@ func (as *AlertSystem) alert(player, alertType string, tick int64, logger GamePr
 	as.lastAlerted[key] = tick
 	logger.LogEvent("alert", player, message)
 }
+
+// alertState is the persisted form of AlertSystem.
+type alertState struct {
+	LastAlerted map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (as *AlertSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(alertState{
+		LastAlerted: as.lastAlerted,
+	})
+}
+
+// Restore implements Snapshotter.
+func (as *AlertSystem) Restore(data []byte) error {
+	var state alertState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	as.lastAlerted = state.LastAlerted
+	if as.lastAlerted == nil {
+		as.lastAlerted = make(map[string]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&AlienEncounterSystem{
-		BaseSystem: NewBaseSystem("AlienEncounters", 74),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AlienEncounterSystem{
+			BaseSystem: NewBaseSystem("AlienEncounters", 74),
+		}
 	})
 }
 
@@ -54,17 +55,17 @@ func (aes *AlienEncounterSystem) OnTick(tick int64) {
 	}
 
 	if aes.nextEncounter == 0 {
-		aes.nextEncounter = tick + 5000 + int64(rand.Intn(10000))
+		aes.nextEncounter = tick + 5000 + int64(aes.Rand().Intn(10000))
 	}
 	if tick < aes.nextEncounter {
 		return
 	}
-	aes.nextEncounter = tick + 10000 + int64(rand.Intn(15000))
+	aes.nextEncounter = tick + 10000 + int64(aes.Rand().Intn(15000))
 
 	players := ctx.GetPlayers()
 	systems := game.GetSystems()
 
-	encounterType := rand.Intn(4)
+	encounterType := aes.Rand().Intn(4)
 	switch encounterType {
 	case 0:
 		aes.tradersOfZyl(players, systems, game)
@@ -91,8 +92,8 @@ func (aes *AlienEncounterSystem) tradersOfZyl(players []*entities.Player, system
 		return
 	}
 
-	sys := systems[rand.Intn(len(systems))]
-	cost := 3000 + rand.Intn(5000)
+	sys := systems[aes.Rand().Intn(len(systems))]
+	cost := 3000 + aes.Rand().Intn(5000)
 
 	// Find buyer's planet to deposit resources
 	for _, e := range sys.Entities {
@@ -100,9 +101,9 @@ func (aes *AlienEncounterSystem) tradersOfZyl(players []*entities.Player, system
 			if buyer.Credits >= cost {
 				buyer.Credits -= cost
 				// Deliver exotic resource bundle
-				planet.AddStoredResource(entities.ResRareMetals, 50+rand.Intn(50))
-				planet.AddStoredResource(entities.ResElectronics, 20+rand.Intn(30))
-				planet.AddStoredResource(entities.ResHelium3, 30+rand.Intn(30))
+				planet.AddStoredResource(entities.ResRareMetals, 50+aes.Rand().Intn(50))
+				planet.AddStoredResource(entities.ResElectronics, 20+aes.Rand().Intn(30))
+				planet.AddStoredResource(entities.ResHelium3, 30+aes.Rand().Intn(30))
 
 				game.LogEvent("event", buyer.Name,
 					fmt.Sprintf("👽 Traders of Zyl appeared in %s! %s purchased an exotic resource bundle for %dcr (+RM, +Electronics, +He-3)",
@@ -118,7 +119,7 @@ func (aes *AlienEncounterSystem) tradersOfZyl(players []*entities.Player, system
 }
 
 func (aes *AlienEncounterSystem) theWatchers(players []*entities.Player, systems []*entities.System, game GameProvider) {
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[aes.Rand().Intn(len(systems))]
 
 	// Check for peaceful faction in this system
 	for _, e := range sys.Entities {
@@ -165,7 +166,7 @@ func (aes *AlienEncounterSystem) theWatchers(players []*entities.Player, systems
 }
 
 func (aes *AlienEncounterSystem) voidBorn(players []*entities.Player, systems []*entities.System, game GameProvider) {
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[aes.Rand().Intn(len(systems))]
 
 	// Find military ships to challenge
 	for _, p := range players {
@@ -188,9 +189,9 @@ func (aes *AlienEncounterSystem) voidBorn(players []*entities.Player, systems []
 				winChance = 60
 			}
 
-			if rand.Intn(100) < winChance {
+			if aes.Rand().Intn(100) < winChance {
 				// Victory — alien tech salvage
-				p.Credits += 3000 + rand.Intn(5000)
+				p.Credits += 3000 + aes.Rand().Intn(5000)
 				for _, s := range systems {
 					for _, e := range s.Entities {
 						if planet, ok := e.(*entities.Planet); ok && planet.Owner == p.Name {
@@ -248,3 +249,31 @@ func (aes *AlienEncounterSystem) theAncients(players []*entities.Player, systems
 	game.LogEvent("event", "",
 		"🌌 The Ancients briefly stirred in the void, but found no civilization worthy of their attention...")
 }
+
+// alienEncounterState is the persisted form of AlienEncounterSystem.
+type alienEncounterState struct {
+	NextEncounter int64
+	Encountered   map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (aes *AlienEncounterSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(alienEncounterState{
+		NextEncounter: aes.nextEncounter,
+		Encountered:   aes.encountered,
+	})
+}
+
+// Restore implements Snapshotter.
+func (aes *AlienEncounterSystem) Restore(data []byte) error {
+	var state alienEncounterState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	aes.nextEncounter = state.NextEncounter
+	aes.encountered = state.Encountered
+	if aes.encountered == nil {
+		aes.encountered = make(map[string]int)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&AncientRelicSystem{
-		BaseSystem: NewBaseSystem("AncientRelics", 72),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AncientRelicSystem{
+			BaseSystem: NewBaseSystem("AncientRelics", 72).Every(300),
+		}
 	})
 }
 
@@ -63,10 +64,6 @@ var relicDefs = []struct {
 }
 
 func (ars *AncientRelicSystem) OnTick(tick int64) {
-	if tick%300 != 0 {
-		return
-	}
-
 	ctx := ars.GetContext()
 	if ctx == nil {
 		return
@@ -78,7 +75,7 @@ func (ars *AncientRelicSystem) OnTick(tick int64) {
 	}
 
 	if ars.nextSpawn == 0 {
-		ars.nextSpawn = tick + 5000 + int64(rand.Intn(10000))
+		ars.nextSpawn = tick + 5000 + int64(ars.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
@@ -148,7 +145,7 @@ func (ars *AncientRelicSystem) OnTick(tick int64) {
 
 	// Spawn new relic
 	if tick >= ars.nextSpawn {
-		ars.nextSpawn = tick + 20000 + int64(rand.Intn(20000))
+		ars.nextSpawn = tick + 20000 + int64(ars.Rand().Intn(20000))
 
 		// Find an unclaimed relic type
 		claimed := make(map[string]bool)
@@ -166,8 +163,8 @@ func (ars *AncientRelicSystem) OnTick(tick int64) {
 			return
 		}
 
-		def := available[rand.Intn(len(available))]
-		sys := systems[rand.Intn(len(systems))]
+		def := available[ars.Rand().Intn(len(available))]
+		sys := systems[ars.Rand().Intn(len(systems))]
 
 		relic := &Relic{
 			Name:     def.name,
@@ -213,9 +210,34 @@ func (ars *AncientRelicSystem) applyRelicBonus(relic *Relic, players []*entities
 	case "Eye of the Void":
 		// Reveal resources on 5 random systems
 		for i := 0; i < 5 && i < len(systems); i++ {
-			sys := systems[rand.Intn(len(systems))]
+			sys := systems[ars.Rand().Intn(len(systems))]
 			game.LogEvent("explore", relic.ClaimedBy,
 				fmt.Sprintf("🔍 Eye of the Void reveals resources in %s!", sys.Name))
 		}
 	}
 }
+
+// ancientRelicState is the persisted form of AncientRelicSystem.
+type ancientRelicState struct {
+	Relics    []*Relic
+	NextSpawn int64
+}
+
+// Snapshot implements Snapshotter.
+func (ars *AncientRelicSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(ancientRelicState{
+		Relics:    ars.relics,
+		NextSpawn: ars.nextSpawn,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ars *AncientRelicSystem) Restore(data []byte) error {
+	var state ancientRelicState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ars.relics = state.Relics
+	ars.nextSpawn = state.NextSpawn
+	return nil
+}
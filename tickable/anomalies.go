@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&AnomalySystem{
-		BaseSystem: NewBaseSystem("Anomalies", 41),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AnomalySystem{
+			BaseSystem: NewBaseSystem("Anomalies", 41),
+		}
 	})
 }
 
@@ -71,8 +72,8 @@ func (as *AnomalySystem) OnTick(tick int64) {
 	systems := game.GetSystems()
 
 	for _, sys := range systems {
-		if rand.Intn(100) < 30 { // 30% chance
-			anomaly := anomalyTypes[rand.Intn(len(anomalyTypes))]
+		if as.Rand().Intn(100) < 30 { // 30% chance
+			anomaly := anomalyTypes[as.Rand().Intn(len(anomalyTypes))]
 			as.anomalies[sys.ID] = anomaly
 			game.LogEvent("event", "",
 				fmt.Sprintf("🔮 Anomaly detected in %s: %s (%s)",
@@ -138,3 +139,31 @@ func (as *AnomalySystem) GetAnomaly(systemID int) (string, string) {
 	}
 	return "", ""
 }
+
+// anomalyState is the persisted form of AnomalySystem.
+type anomalyState struct {
+	Anomalies map[int]string
+	Assigned  bool
+}
+
+// Snapshot implements Snapshotter.
+func (as *AnomalySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(anomalyState{
+		Anomalies: as.anomalies,
+		Assigned:  as.assigned,
+	})
+}
+
+// Restore implements Snapshotter.
+func (as *AnomalySystem) Restore(data []byte) error {
+	var state anomalyState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	as.anomalies = state.Anomalies
+	if as.anomalies == nil {
+		as.anomalies = make(map[int]string)
+	}
+	as.assigned = state.Assigned
+	return nil
+}
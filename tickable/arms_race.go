@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ArmsRaceSystem{
-		BaseSystem: NewBaseSystem("ArmsRace", 73),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ArmsRaceSystem{
+			BaseSystem: NewBaseSystem("ArmsRace", 73).Every(1000),
+		}
 	})
 }
 
@@ -50,10 +53,6 @@ type ArmsRace struct {
 }
 
 func (ars *ArmsRaceSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := ars.GetContext()
 	if ctx == nil {
 		return
@@ -65,7 +64,7 @@ func (ars *ArmsRaceSystem) OnTick(tick int64) {
 	}
 
 	if ars.nextScan == 0 {
-		ars.nextScan = tick + 5000 + int64(rand.Intn(5000))
+		ars.nextScan = tick + 5000 + int64(ars.Rand().Intn(5000))
 	}
 
 	players := ctx.GetPlayers()
@@ -81,7 +80,7 @@ func (ars *ArmsRaceSystem) OnTick(tick int64) {
 
 	// Scan for new arms races
 	if tick >= ars.nextScan {
-		ars.nextScan = tick + 10000 + int64(rand.Intn(10000))
+		ars.nextScan = tick + 10000 + int64(ars.Rand().Intn(10000))
 		ars.scanForRaces(players, systems, game)
 	}
 }
@@ -196,7 +195,8 @@ func (ars *ArmsRaceSystem) scanForRaces(players []*entities.Player, systems []*e
 	}
 
 	// Find systems with 2+ factions with military presence
-	for sysID, presences := range systemMilitary {
+	for _, sysID := range slices.Sorted(maps.Keys(systemMilitary)) {
+		presences := systemMilitary[sysID]
 		if len(presences) < 2 {
 			continue
 		}
@@ -251,3 +251,28 @@ func (ars *ArmsRaceSystem) scanForRaces(players []*entities.Player, systems []*e
 				a.faction, a.ships, b.faction, b.ships, sysName))
 	}
 }
+
+// armsRaceState is the persisted form of ArmsRaceSystem.
+type armsRaceState struct {
+	Races    []*ArmsRace
+	NextScan int64
+}
+
+// Snapshot implements Snapshotter.
+func (ars *ArmsRaceSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(armsRaceState{
+		Races:    ars.races,
+		NextScan: ars.nextScan,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ars *ArmsRaceSystem) Restore(data []byte) error {
+	var state armsRaceState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ars.races = state.Races
+	ars.nextScan = state.NextScan
+	return nil
+}
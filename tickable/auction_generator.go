@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&AuctionGeneratorSystem{
-		BaseSystem: NewBaseSystem("AuctionGenerator", 42),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AuctionGeneratorSystem{
+			BaseSystem: NewBaseSystem("AuctionGenerator", 42),
+		}
 	})
 }
 
@@ -40,13 +41,13 @@ var auctionItems = []auctionTemplate{
 
 func (ags *AuctionGeneratorSystem) OnTick(tick int64) {
 	if ags.nextAuction == 0 {
-		ags.nextAuction = tick + 500 + int64(rand.Intn(1500)) // first auction soon
+		ags.nextAuction = tick + 500 + int64(ags.Rand().Intn(1500)) // first auction soon
 	}
 
 	if tick < ags.nextAuction {
 		return
 	}
-	ags.nextAuction = tick + 5000 + int64(rand.Intn(8000))
+	ags.nextAuction = tick + 5000 + int64(ags.Rand().Intn(8000))
 
 	ctx := ags.GetContext()
 	if ctx == nil {
@@ -70,8 +71,8 @@ func (ags *AuctionGeneratorSystem) OnTick(tick int64) {
 	}
 
 	// Pick a random item
-	template := auctionItems[rand.Intn(len(auctionItems))]
-	duration := 3000 + rand.Intn(3000) // 3000-6000 ticks (~5-10 min)
+	template := auctionItems[ags.Rand().Intn(len(auctionItems))]
+	duration := 3000 + ags.Rand().Intn(3000) // 3000-6000 ticks (~5-10 min)
 
 	ah.CreateAuction(template.item, template.description, "", template.minBid, duration)
 
@@ -85,3 +86,25 @@ func ProcessCompletedAuctions(completed []*entities.Player, game GameProvider) {
 	// This would be called from a tick handler with the auction results
 	// For now, auctions just transfer credits — item effects are logged
 }
+
+// auctionGeneratorState is the persisted form of AuctionGeneratorSystem.
+type auctionGeneratorState struct {
+	NextAuction int64
+}
+
+// Snapshot implements Snapshotter.
+func (ags *AuctionGeneratorSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(auctionGeneratorState{
+		NextAuction: ags.nextAuction,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ags *AuctionGeneratorSystem) Restore(data []byte) error {
+	var state auctionGeneratorState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ags.nextAuction = state.NextAuction
+	return nil
+}
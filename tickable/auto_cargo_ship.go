@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoCargoShipSystem{
-		BaseSystem: NewBaseSystem("AutoCargoShip", 134),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoCargoShipSystem{
+			BaseSystem: NewBaseSystem("AutoCargoShip", 134).Every(3000),
+		}
 	})
 }
 
@@ -28,10 +30,6 @@ type AutoCargoShipSystem struct {
 }
 
 func (acss *AutoCargoShipSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := acss.GetContext()
 	if ctx == nil {
 		return
@@ -111,3 +109,28 @@ func (acss *AutoCargoShipSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// autoCargoShipState is the persisted form of AutoCargoShipSystem.
+type autoCargoShipState struct {
+	LastBuild map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (acss *AutoCargoShipSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(autoCargoShipState{
+		LastBuild: acss.lastBuild,
+	})
+}
+
+// Restore implements Snapshotter.
+func (acss *AutoCargoShipSystem) Restore(data []byte) error {
+	var state autoCargoShipState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	acss.lastBuild = state.LastBuild
+	if acss.lastBuild == nil {
+		acss.lastBuild = make(map[string]int64)
+	}
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoGeneratorSystem{
-		BaseSystem: NewBaseSystem("AutoGenerator", 125),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoGeneratorSystem{
+			BaseSystem: NewBaseSystem("AutoGenerator", 125).Every(2000),
+		}
 	})
 }
 
@@ -31,10 +33,6 @@ type AutoGeneratorSystem struct {
 }
 
 func (ags *AutoGeneratorSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := ags.GetContext()
 	if ctx == nil {
 		return
@@ -118,3 +116,28 @@ func (ags *AutoGeneratorSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// autoGeneratorState is the persisted form of AutoGeneratorSystem.
+type autoGeneratorState struct {
+	LastBuild map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ags *AutoGeneratorSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(autoGeneratorState{
+		LastBuild: ags.lastBuild,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ags *AutoGeneratorSystem) Restore(data []byte) error {
+	var state autoGeneratorState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ags.lastBuild = state.LastBuild
+	if ags.lastBuild == nil {
+		ags.lastBuild = make(map[int]int64)
+	}
+	return nil
+}
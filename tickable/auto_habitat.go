@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoHabitatSystem{
-		BaseSystem: NewBaseSystem("AutoHabitat", 122),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoHabitatSystem{
+			BaseSystem: NewBaseSystem("AutoHabitat", 122).Every(2000),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type AutoHabitatSystem struct {
 }
 
 func (ahs *AutoHabitatSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := ahs.GetContext()
 	if ctx == nil {
 		return
@@ -102,3 +100,28 @@ func (ahs *AutoHabitatSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// autoHabitatState is the persisted form of AutoHabitatSystem.
+type autoHabitatState struct {
+	LastBuild map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ahs *AutoHabitatSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(autoHabitatState{
+		LastBuild: ahs.lastBuild,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ahs *AutoHabitatSystem) Restore(data []byte) error {
+	var state autoHabitatState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ahs.lastBuild = state.LastBuild
+	if ahs.lastBuild == nil {
+		ahs.lastBuild = make(map[int]int64)
+	}
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoOilMineSystem{
-		BaseSystem: NewBaseSystem("AutoOilMine", 140),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoOilMineSystem{
+			BaseSystem: NewBaseSystem("AutoOilMine", 140).Every(3000),
+		}
 	})
 }
 
@@ -29,10 +31,6 @@ type AutoOilMineSystem struct {
 }
 
 func (aoms *AutoOilMineSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := aoms.GetContext()
 	if ctx == nil {
 		return
@@ -104,3 +102,28 @@ func (aoms *AutoOilMineSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// autoOilMineState is the persisted form of AutoOilMineSystem.
+type autoOilMineState struct {
+	LastBuild map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (aoms *AutoOilMineSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(autoOilMineState{
+		LastBuild: aoms.lastBuild,
+	})
+}
+
+// Restore implements Snapshotter.
+func (aoms *AutoOilMineSystem) Restore(data []byte) error {
+	var state autoOilMineState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	aoms.lastBuild = state.LastBuild
+	if aoms.lastBuild == nil {
+		aoms.lastBuild = make(map[string]int64)
+	}
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoOrderSystem{
-		BaseSystem: NewBaseSystem("AutoOrders", 29),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoOrderSystem{
+			BaseSystem: NewBaseSystem("AutoOrders", 29),
+		}
 	})
 }
 
@@ -70,9 +72,6 @@ func (aos *AutoOrderSystem) OnTick(tick int64) {
 		entities.ResElectronics,
 	}
 
-	// Prune dead orders first
-	ob.PruneExpired()
-
 	// Track which players we've cleared per system (so we clear once, place fresh)
 	cleared := make(map[string]bool)
 
@@ -196,3 +195,25 @@ func (aos *AutoOrderSystem) OnTick(tick int64) {
 		fmt.Printf("[AutoOrders] %d active limit orders on the book\n", active)
 	}
 }
+
+// autoOrderState is the persisted form of AutoOrderSystem.
+type autoOrderState struct {
+	LastRun int64
+}
+
+// Snapshot implements Snapshotter.
+func (aos *AutoOrderSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(autoOrderState{
+		LastRun: aos.lastRun,
+	})
+}
+
+// Restore implements Snapshotter.
+func (aos *AutoOrderSystem) Restore(data []byte) error {
+	var state autoOrderState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	aos.lastRun = state.LastRun
+	return nil
+}
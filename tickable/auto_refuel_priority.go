@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoRefuelPrioritySystem{
-		BaseSystem: NewBaseSystem("AutoRefuelPriority", 21),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoRefuelPrioritySystem{
+			BaseSystem: NewBaseSystem("AutoRefuelPriority", 21).Every(10),
+		}
 	})
 }
 
@@ -27,10 +29,6 @@ type AutoRefuelPrioritySystem struct {
 }
 
 func (arps *AutoRefuelPrioritySystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := arps.GetContext()
 	if ctx == nil {
 		return
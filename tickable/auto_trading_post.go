@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&AutoTradingPostSystem{
-		BaseSystem: NewBaseSystem("AutoTradingPost", 151),
+	RegisterSystemFunc(func() TickableSystem {
+		return &AutoTradingPostSystem{
+			BaseSystem: NewBaseSystem("AutoTradingPost", 151).Every(2000),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type AutoTradingPostSystem struct {
 }
 
 func (atps *AutoTradingPostSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := atps.GetContext()
 	if ctx == nil {
 		return
@@ -96,3 +94,28 @@ func (atps *AutoTradingPostSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// autoTradingPostState is the persisted form of AutoTradingPostSystem.
+type autoTradingPostState struct {
+	LastBuild map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (atps *AutoTradingPostSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(autoTradingPostState{
+		LastBuild: atps.lastBuild,
+	})
+}
+
+// Restore implements Snapshotter.
+func (atps *AutoTradingPostSystem) Restore(data []byte) error {
+	var state autoTradingPostState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	atps.lastBuild = state.LastBuild
+	if atps.lastBuild == nil {
+		atps.lastBuild = make(map[string]int64)
+	}
+	return nil
+}
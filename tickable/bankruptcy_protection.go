@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&BankruptcyProtectionSystem{
-		BaseSystem: NewBaseSystem("BankruptcyProtection", 6),
+	RegisterSystemFunc(func() TickableSystem {
+		return &BankruptcyProtectionSystem{
+			BaseSystem: NewBaseSystem("BankruptcyProtection", 6).Every(100),
+		}
 	})
 }
 
@@ -34,10 +36,6 @@ type BankruptcyProtectionSystem struct {
 }
 
 func (bps *BankruptcyProtectionSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := bps.GetContext()
 	if ctx == nil {
 		return
@@ -150,3 +148,34 @@ func (bps *BankruptcyProtectionSystem) IsTradeFrozen(playerName string, tick int
 	freezeUntil, exists := bps.tradeFrozen[playerName]
 	return exists && tick < freezeUntil
 }
+
+// bankruptcyProtectionState is the persisted form of BankruptcyProtectionSystem.
+type bankruptcyProtectionState struct {
+	LastBailout map[string]int64
+	TradeFrozen map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (bps *BankruptcyProtectionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(bankruptcyProtectionState{
+		LastBailout: bps.lastBailout,
+		TradeFrozen: bps.tradeFrozen,
+	})
+}
+
+// Restore implements Snapshotter.
+func (bps *BankruptcyProtectionSystem) Restore(data []byte) error {
+	var state bankruptcyProtectionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	bps.lastBailout = state.LastBailout
+	if bps.lastBailout == nil {
+		bps.lastBailout = make(map[string]int64)
+	}
+	bps.tradeFrozen = state.TradeFrozen
+	if bps.tradeFrozen == nil {
+		bps.tradeFrozen = make(map[string]int64)
+	}
+	return nil
+}
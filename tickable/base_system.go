@@ -1,6 +1,7 @@
 package tickable
 
 import (
+	"math/rand"
 	"sync"
 )
 
@@ -9,6 +10,7 @@ type BaseSystem struct {
 	name     string
 	priority int
 	enabled  bool
+	schedule Schedule
 	context  SystemContext
 	mutex    sync.RWMutex
 }
@@ -46,6 +48,55 @@ func (bs *BaseSystem) SetEnabled(enabled bool) {
 	bs.enabled = enabled
 }
 
+// Every declares that the system runs once every interval ticks. The
+// registry chooses the phase, spreading systems that share an interval.
+func (bs *BaseSystem) Every(interval int64) *BaseSystem {
+	bs.SetSchedule(Schedule{Interval: interval})
+	return bs
+}
+
+// At pins the phase of an Every schedule, for systems that must run on the
+// same tick as the systems they depend on.
+func (bs *BaseSystem) At(phase int64) *BaseSystem {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+	if bs.schedule.Interval > 1 {
+		bs.schedule.Phase = phase % bs.schedule.Interval
+	}
+	bs.schedule.Fixed = true
+	return bs
+}
+
+// From holds the system back until tick, for systems that need the game to
+// warm up before their first run.
+func (bs *BaseSystem) From(tick int64) *BaseSystem {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+	bs.schedule.Start = tick
+	return bs
+}
+
+// GetSchedule returns the system's cadence
+func (bs *BaseSystem) GetSchedule() Schedule {
+	bs.mutex.RLock()
+	defer bs.mutex.RUnlock()
+	return bs.schedule
+}
+
+// SetSchedule replaces the system's cadence
+func (bs *BaseSystem) SetSchedule(s Schedule) {
+	bs.mutex.Lock()
+	defer bs.mutex.Unlock()
+	bs.schedule = s
+}
+
+// DueEvery reports whether tick is a multiple of every counted from the
+// system's phase, for work a scheduled system only does on some runs
+// (every must be a multiple of the interval).
+func (bs *BaseSystem) DueEvery(tick, every int64) bool {
+	return Schedule{Interval: every, Phase: bs.GetSchedule().Phase}.Due(tick)
+}
+
 // Initialize stores the system context
 func (bs *BaseSystem) Initialize(context SystemContext) {
 	bs.mutex.Lock()
@@ -60,6 +111,20 @@ func (bs *BaseSystem) GetContext() SystemContext {
 	return bs.context
 }
 
+// fallbackRand serves systems that have no context yet (e.g. in tests).
+var fallbackRand = NewRandSource(0)
+
+// Rand returns this system's RNG for the current tick. All randomness in
+// tickable systems must come from here rather than the global math/rand
+// functions, or seeded runs stop being reproducible.
+func (bs *BaseSystem) Rand() *rand.Rand {
+	ctx := bs.GetContext()
+	if ctx == nil {
+		return fallbackRand.Get(0, bs.name)
+	}
+	return ctx.GetRand(bs.name)
+}
+
 // ProcessConcurrent processes a slice of items concurrently using worker pool
 // This is the main helper for parallel processing
 func ProcessConcurrent[T any](items []T, workerCount int, processFn func(T)) {
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&BlackHoleSystem{
-		BaseSystem: NewBaseSystem("BlackHoles", 82),
+	RegisterSystemFunc(func() TickableSystem {
+		return &BlackHoleSystem{
+			BaseSystem: NewBaseSystem("BlackHoles", 82),
+		}
 	})
 }
 
@@ -63,7 +64,7 @@ func (bhs *BlackHoleSystem) OnTick(tick int64) {
 
 	if !bhs.warned {
 		// 0.1% chance per 5000 ticks to trigger warning
-		if tick%5000 != 0 || rand.Intn(1000) != 0 {
+		if tick%5000 != 0 || bhs.Rand().Intn(1000) != 0 {
 			return
 		}
 
@@ -85,7 +86,7 @@ func (bhs *BlackHoleSystem) OnTick(tick int64) {
 			return // no safe target
 		}
 
-		bhs.targetSys = candidates[rand.Intn(len(candidates))]
+		bhs.targetSys = candidates[bhs.Rand().Intn(len(candidates))]
 		bhs.warned = true
 		bhs.warnTick = tick
 
@@ -155,3 +156,34 @@ func (bhs *BlackHoleSystem) OnTick(tick int64) {
 		fmt.Sprintf("🕳️ BLACK HOLE! %s has been consumed! %d ships lost. The void left behind connects to distant systems — new shortcuts available!",
 			sysName, shipsLost))
 }
+
+// blackHoleState is the persisted form of BlackHoleSystem.
+type blackHoleState struct {
+	Warned    bool
+	Collapsed bool
+	TargetSys int
+	WarnTick  int64
+}
+
+// Snapshot implements Snapshotter.
+func (bhs *BlackHoleSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(blackHoleState{
+		Warned:    bhs.warned,
+		Collapsed: bhs.collapsed,
+		TargetSys: bhs.targetSys,
+		WarnTick:  bhs.warnTick,
+	})
+}
+
+// Restore implements Snapshotter.
+func (bhs *BlackHoleSystem) Restore(data []byte) error {
+	var state blackHoleState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	bhs.warned = state.Warned
+	bhs.collapsed = state.Collapsed
+	bhs.targetSys = state.TargetSys
+	bhs.warnTick = state.WarnTick
+	return nil
+}
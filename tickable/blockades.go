@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&BlockadeSystem{
-		BaseSystem: NewBaseSystem("Blockades", 36),
+	RegisterSystemFunc(func() TickableSystem {
+		return &BlockadeSystem{
+			BaseSystem: NewBaseSystem("Blockades", 36).Every(300),
+		}
 	})
 }
 
@@ -42,10 +45,6 @@ type Blockade struct {
 }
 
 func (bs *BlockadeSystem) OnTick(tick int64) {
-	if tick%300 != 0 {
-		return
-	}
-
 	ctx := bs.GetContext()
 	if ctx == nil {
 		return
@@ -118,13 +117,14 @@ func (bs *BlockadeSystem) evaluateBlockade(tick int64, sys *entities.System, pla
 	// Need 2+ military ships in a system where an enemy has planets
 	existing := bs.blockades[sys.ID]
 
-	for factionName, fleet := range factions {
+	for _, factionName := range slices.Sorted(maps.Keys(factions)) {
+		fleet := factions[factionName]
 		if fleet.ships < 2 {
 			continue
 		}
 
 		// Check if there's an enemy faction with planets here
-		for owner := range systemOwners {
+		for _, owner := range slices.Sorted(maps.Keys(systemOwners)) {
 			if owner == factionName {
 				continue
 			}
@@ -187,12 +187,12 @@ func (bs *BlockadeSystem) interceptCargo(game GameProvider, players []*entities.
 					continue
 				}
 				// 15% chance per tick to intercept
-				if rand.Intn(7) != 0 {
+				if bs.Rand().Intn(7) != 0 {
 					continue
 				}
 
 				// Seize 20-40% of cargo
-				seizureRate := 0.2 + rand.Float64()*0.2
+				seizureRate := 0.2 + bs.Rand().Float64()*0.2
 				totalSeized := 0
 				for res, amt := range ship.CargoHold {
 					seized := int(float64(amt) * seizureRate)
@@ -249,3 +249,25 @@ func (bs *BlockadeSystem) IsBlockaded(systemID int, faction string) bool {
 	b, exists := bs.blockades[systemID]
 	return exists && b.Active && b.TargetOwner == faction
 }
+
+// blockadeState is the persisted form of BlockadeSystem.
+type blockadeState struct {
+	Blockades map[int]*Blockade
+}
+
+// Snapshot implements Snapshotter.
+func (bs *BlockadeSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(blockadeState{
+		Blockades: bs.blockades,
+	})
+}
+
+// Restore implements Snapshotter.
+func (bs *BlockadeSystem) Restore(data []byte) error {
+	var state blockadeState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	bs.blockades = state.Blockades
+	return nil
+}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&BountyHunterSystem{
-		BaseSystem: NewBaseSystem("BountyHunters", 103),
+	RegisterSystemFunc(func() TickableSystem {
+		return &BountyHunterSystem{
+			BaseSystem: NewBaseSystem("BountyHunters", 103).Every(500),
+		}
 	})
 }
 
@@ -42,10 +45,6 @@ type BountyHunter struct {
 }
 
 func (bhs *BountyHunterSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := bhs.GetContext()
 	if ctx == nil {
 		return
@@ -81,9 +80,10 @@ func (bhs *BountyHunterSystem) OnTick(tick int64) {
 		bhs.nextSpawn = tick + 10000
 	}
 	if tick >= bhs.nextSpawn {
-		bhs.nextSpawn = tick + 15000 + int64(rand.Intn(10000))
+		bhs.nextSpawn = tick + 15000 + int64(bhs.Rand().Intn(10000))
 
-		for name, count := range bhs.infractions {
+		for _, name := range slices.Sorted(maps.Keys(bhs.infractions)) {
+			count := bhs.infractions[name]
 			if count < 3 {
 				continue
 			}
@@ -168,7 +168,7 @@ func (bhs *BountyHunterSystem) processHunter(hunter *BountyHunter, players []*en
 			}
 
 			// Hunter catches unescorted ship
-			if ship.ShipType == entities.ShipTypeCargo && rand.Intn(3) == 0 {
+			if ship.ShipType == entities.ShipTypeCargo && bhs.Rand().Intn(3) == 0 {
 				ship.CurrentHealth = ship.MaxHealth / 4
 				// Steal cargo
 				for res, amt := range ship.CargoHold {
@@ -190,7 +190,7 @@ func (bhs *BountyHunterSystem) processHunter(hunter *BountyHunter, players []*en
 			if ship != nil && ship.CurrentSystem != hunter.CurrentSystem {
 				connected := game.GetConnectedSystems(hunter.CurrentSystem)
 				if len(connected) > 0 {
-					hunter.CurrentSystem = connected[rand.Intn(len(connected))]
+					hunter.CurrentSystem = connected[bhs.Rand().Intn(len(connected))]
 				}
 				break
 			}
@@ -217,3 +217,31 @@ func (bhs *BountyHunterSystem) PayClearance(faction string) bool {
 	}
 	return false
 }
+
+// bountyHunterState is the persisted form of BountyHunterSystem.
+type bountyHunterState struct {
+	Hunters     []*BountyHunter
+	Infractions map[string]int
+	NextSpawn   int64
+}
+
+// Snapshot implements Snapshotter.
+func (bhs *BountyHunterSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(bountyHunterState{
+		Hunters:     bhs.hunters,
+		Infractions: bhs.infractions,
+		NextSpawn:   bhs.nextSpawn,
+	})
+}
+
+// Restore implements Snapshotter.
+func (bhs *BountyHunterSystem) Restore(data []byte) error {
+	var state bountyHunterState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	bhs.hunters = state.Hunters
+	bhs.infractions = state.Infractions
+	bhs.nextSpawn = state.NextSpawn
+	return nil
+}
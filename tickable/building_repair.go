@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&BuildingRepairSystem{
-		BaseSystem: NewBaseSystem("BuildingRepair", 50),
+	RegisterSystemFunc(func() TickableSystem {
+		return &BuildingRepairSystem{
+			BaseSystem: NewBaseSystem("BuildingRepair", 50).Every(500),
+		}
 	})
 }
 
@@ -21,10 +23,6 @@ type BuildingRepairSystem struct {
 }
 
 func (brs *BuildingRepairSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := brs.GetContext()
 	if ctx == nil {
 		return
@@ -88,3 +86,28 @@ func (brs *BuildingRepairSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// buildingRepairState is the persisted form of BuildingRepairSystem.
+type buildingRepairState struct {
+	DamagedAt map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (brs *BuildingRepairSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(buildingRepairState{
+		DamagedAt: brs.damagedAt,
+	})
+}
+
+// Restore implements Snapshotter.
+func (brs *BuildingRepairSystem) Restore(data []byte) error {
+	var state buildingRepairState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	brs.damagedAt = state.DamagedAt
+	if brs.damagedAt == nil {
+		brs.damagedAt = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&CaravanSystem{
-		BaseSystem: NewBaseSystem("Caravans", 89),
+	RegisterSystemFunc(func() TickableSystem {
+		return &CaravanSystem{
+			BaseSystem: NewBaseSystem("Caravans", 89).Every(200),
+		}
 	})
 }
 
@@ -34,10 +35,6 @@ type CaravanSystem struct {
 }
 
 func (cs *CaravanSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := cs.GetContext()
 	if ctx == nil {
 		return
@@ -64,6 +61,7 @@ func (cs *CaravanSystem) OnTick(tick int64) {
 			from, to int
 		}
 		groups := make(map[routeKey][]*entities.Ship)
+		var routes []routeKey // in fleet order, for seeded replays
 
 		for _, ship := range player.OwnedShips {
 			if ship == nil || ship.ShipType != entities.ShipTypeCargo {
@@ -71,6 +69,9 @@ func (cs *CaravanSystem) OnTick(tick int64) {
 			}
 			if ship.Status == entities.ShipStatusMoving && ship.TargetSystem != -1 {
 				key := routeKey{ship.CurrentSystem, ship.TargetSystem}
+				if groups[key] == nil {
+					routes = append(routes, key)
+				}
 				groups[key] = append(groups[key], ship)
 			}
 		}
@@ -100,9 +101,8 @@ func (cs *CaravanSystem) OnTick(tick int64) {
 		cs.activeCaravans[player.Name] = caravanCount
 
 		// Announce large caravans
-		for key, ships := range groups {
-			if len(ships) >= 3 && rand.Intn(10) == 0 {
-				_ = key
+		for _, key := range routes {
+			if ships := groups[key]; len(ships) >= 3 && cs.Rand().Intn(10) == 0 {
 				game.LogEvent("logistics", player.Name,
 					fmt.Sprintf("🐪 %s caravan: %d cargo ships traveling together (+fuel efficiency, pirate immunity)",
 						player.Name, len(ships)))
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&CargoInsuranceSystem{
-		BaseSystem: NewBaseSystem("CargoInsurance", 51),
+	RegisterSystemFunc(func() TickableSystem {
+		return &CargoInsuranceSystem{
+			BaseSystem: NewBaseSystem("CargoInsurance", 51).Every(1000),
+		}
 	})
 }
 
@@ -33,10 +35,6 @@ type CargoInsuranceSystem struct {
 }
 
 func (cis *CargoInsuranceSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := cis.GetContext()
 	if ctx == nil {
 		return
@@ -147,3 +145,43 @@ func (cis *CargoInsuranceSystem) GetCoverage(playerName string) float64 {
 	}
 	return cis.coverage[playerName]
 }
+
+// cargoInsuranceState is the persisted form of CargoInsuranceSystem.
+type cargoInsuranceState struct {
+	Losses      map[string]int
+	PremiumPaid map[string]int
+	Coverage    map[string]float64
+	NextPayout  int64
+}
+
+// Snapshot implements Snapshotter.
+func (cis *CargoInsuranceSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(cargoInsuranceState{
+		Losses:      cis.losses,
+		PremiumPaid: cis.premiumPaid,
+		Coverage:    cis.coverage,
+		NextPayout:  cis.nextPayout,
+	})
+}
+
+// Restore implements Snapshotter.
+func (cis *CargoInsuranceSystem) Restore(data []byte) error {
+	var state cargoInsuranceState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	cis.losses = state.Losses
+	if cis.losses == nil {
+		cis.losses = make(map[string]int)
+	}
+	cis.premiumPaid = state.PremiumPaid
+	if cis.premiumPaid == nil {
+		cis.premiumPaid = make(map[string]int)
+	}
+	cis.coverage = state.Coverage
+	if cis.coverage == nil {
+		cis.coverage = make(map[string]float64)
+	}
+	cis.nextPayout = state.NextPayout
+	return nil
+}
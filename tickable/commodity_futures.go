@@ -2,41 +2,46 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"math"
 
+	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&CommodityFuturesSystem{
-		BaseSystem: NewBaseSystem("CommodityFutures", 68),
+	RegisterSystemFunc(func() TickableSystem {
+		return &CommodityFuturesSystem{
+			BaseSystem: NewBaseSystem("CommodityFutures", 68).Every(10).At(0),
+		}
 	})
 }
 
-// CommodityFuturesSystem lets factions speculate on resource prices.
-// A futures contract locks in today's price for delivery later.
-//
-// Every ~5000 ticks, the system generates futures opportunities:
-//   "Iron futures at 15cr/unit for delivery in 8000 ticks"
+// CommodityFuturesSystem runs the clearing cycle of the futures exchange
+// (economy.FuturesExchange). Factions open positions through the
+// futures_open command; this system keeps them honest.
 //
-// A faction can buy a futures contract:
-//   - Pay a deposit (10% of contract value)
-//   - If at maturity the market price is HIGHER → profit (price diff * qty)
-//   - If at maturity the market price is LOWER → loss (deposit forfeited)
+// Every price update (10 ticks, after the Market system reprices):
+//   - Marks every open future to the new mid price, moving the gain or
+//     loss between the faction's margin account and the exchange
+//   - Margin calls: an account below 10% of its notional is topped back up
+//     to 15% from the faction's credits, or its futures are liquidated
+//   - Settles what has expired: cash futures pay out their margin, physical
+//     ones deliver goods to (or collect them from) the nominated planet,
+//     and options pay their intrinsic value
 //
-// This creates a financial layer:
-//   - Factions who understand supply/demand cycles can profit
-//   - Hedging: lock in prices before a predicted scarcity
-//   - Risk: if you're wrong about the market direction, lose deposit
+// Margin, premiums and settlements are booked to the ledger as futures;
+// physical deliveries also enter the trade history and cost basis.
 //
-// Futures contracts are auto-resolved at maturity. No physical delivery.
+// Saves from before the exchange hold the old deposit-only contracts. They
+// can't be turned into margined positions, so the deposits of any bought
+// and unsettled ones are refunded on the first tick after loading.
 type CommodityFuturesSystem struct {
 	*BaseSystem
-	futures    []*FuturesContract
-	nextOffer  int64
+	legacy []*FuturesContract // old contracts awaiting a deposit refund
 }
 
-// FuturesContract represents a price bet.
+// FuturesContract is a contract from the system's old deposit-only
+// futures, kept so older saves decode.
 type FuturesContract struct {
 	ID           int
 	Resource     string
@@ -50,10 +55,6 @@ type FuturesContract struct {
 }
 
 func (cfs *CommodityFuturesSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := cfs.GetContext()
 	if ctx == nil {
 		return
@@ -64,108 +65,119 @@ func (cfs *CommodityFuturesSystem) OnTick(tick int64) {
 		return
 	}
 
-	if cfs.nextOffer == 0 {
-		cfs.nextOffer = tick + 3000 + int64(rand.Intn(5000))
+	if len(cfs.legacy) > 0 {
+		cfs.refundLegacy(game, ctx.GetPlayers())
 	}
 
-	players := ctx.GetPlayers()
+	fx := game.GetFuturesExchange()
 	market := game.GetMarketEngine()
-	if market == nil {
+	if fx == nil || market == nil {
 		return
 	}
 
-	// Settle mature contracts
-	for _, f := range cfs.futures {
-		if f.Settled || f.Buyer == "" {
-			continue
-		}
-		if tick < f.MaturityTick {
-			continue
+	for _, ev := range fx.MarkToMarket(tick, market) {
+		switch ev.Type {
+		case economy.FuturesMarginCall:
+			game.LogEvent("trade", ev.Player,
+				fmt.Sprintf("⚠️ Margin call: %s topped up its futures margin with %dcr", ev.Player, ev.Amount))
+		case economy.FuturesLiquidated:
+			p := ev.Position
+			game.LogEvent("trade", ev.Player,
+				fmt.Sprintf("💥 %s couldn't meet a margin call: %s %d %s futures #%d liquidated at %.0f (%+dcr)",
+					ev.Player, p.Side, p.Quantity, p.Resource, p.ID, p.Mark, p.Realized))
+		case economy.FuturesSettled:
+			cfs.logSettlement(game, tick, ev)
 		}
+	}
+}
 
-		// Settlement: compare current price to locked price
-		currentPrice := market.GetSellPrice(f.Resource)
-		priceDiff := currentPrice - f.LockedPrice
-		f.Profit = int(priceDiff * float64(f.Quantity))
-		f.Settled = true
-
-		for _, p := range players {
-			if p == nil || p.Name != f.Buyer {
-				continue
-			}
+// logSettlement announces an expired position and records physical
+// deliveries as trades.
+func (cfs *CommodityFuturesSystem) logSettlement(game GameProvider, tick int64, ev economy.FuturesEvent) {
+	p := ev.Position
+	if p.Kind != economy.KindFuture {
+		game.LogEvent("trade", ev.Player,
+			fmt.Sprintf("📊 %s's %s %s option #%d (strike %.0f) expired at %.0f: paid %dcr for a %dcr premium",
+				ev.Player, p.Resource, p.Kind, p.ID, p.Price, p.Mark, ev.Amount, p.Premium))
+		return
+	}
 
-			if f.Profit > 0 {
-				// Winner: return deposit + profit
-				p.Credits += f.Deposit + f.Profit
-				game.LogEvent("trade", f.Buyer,
-					fmt.Sprintf("📈 Futures contract settled! %s %s — price went from %.0f to %.0f. Profit: %dcr (+%dcr deposit)",
-						f.Resource, f.Buyer, f.LockedPrice, currentPrice, f.Profit, f.Deposit))
-			} else {
-				// Loser: deposit forfeited
-				game.LogEvent("trade", f.Buyer,
-					fmt.Sprintf("📉 Futures contract expired! %s %s — price went from %.0f to %.0f. Lost %dcr deposit",
-						f.Resource, f.Buyer, f.LockedPrice, currentPrice, f.Deposit))
-			}
-			break
-		}
+	switch {
+	case ev.Quantity > 0:
+		game.LogEvent("trade", ev.Player,
+			fmt.Sprintf("📦 %s took delivery of %d %s at %.0f on futures #%d (%+dcr on the contract)",
+				ev.Player, ev.Quantity, p.Resource, p.Mark, p.ID, p.Realized))
+	case p.Settlement == economy.SettlePhysical && p.Outcome == economy.OutcomeSettled:
+		game.LogEvent("trade", ev.Player,
+			fmt.Sprintf("📦 %s delivered %d/%d %s at %.0f on futures #%d (%+dcr on the contract)",
+				ev.Player, -ev.Quantity, p.Quantity, p.Resource, p.Mark, p.ID, p.Realized))
+	default:
+		game.LogEvent("trade", ev.Player,
+			fmt.Sprintf("📈 %s's %s %d %s futures #%d settled at %.0f: %+dcr",
+				ev.Player, p.Side, p.Quantity, p.Resource, p.ID, p.Mark, p.Realized))
 	}
 
-	// Generate new futures offerings
-	if tick >= cfs.nextOffer {
-		cfs.nextOffer = tick + 5000 + int64(rand.Intn(8000))
-		cfs.generateOffering(tick, market, game)
+	ledger := game.GetLedger()
+	if ledger == nil || ev.Quantity == 0 {
+		return
+	}
+	action, qty := "buy_futures", ev.Quantity
+	if qty < 0 {
+		action, qty = "sell_futures", -qty
 	}
+	total := int(math.Round(p.Mark * float64(qty)))
+	ledger.AddTrade(economy.TradeRecord{
+		Tick:      tick,
+		Player:    ev.Player,
+		Resource:  p.Resource,
+		Quantity:  qty,
+		Action:    action,
+		UnitPrice: p.Mark,
+		Total:     total,
+	})
+}
 
-	// Auto-buy for AI factions that can afford it
-	for _, f := range cfs.futures {
-		if f.Settled || f.Buyer != "" {
-			continue
-		}
-		if tick > f.MaturityTick-2000 {
-			continue // too close to maturity
-		}
-		// 5% chance per tick per AI faction to buy
+// refundLegacy pays back the deposits of old contracts restored from a save.
+func (cfs *CommodityFuturesSystem) refundLegacy(game GameProvider, players []*entities.Player) {
+	for _, f := range cfs.legacy {
 		for _, p := range players {
-			if p == nil || p.Credits < f.Deposit*3 {
-				continue
-			}
-			if rand.Intn(20) != 0 {
+			if p == nil || p.Name != f.Buyer {
 				continue
 			}
-			f.Buyer = p.Name
-			p.Credits -= f.Deposit
-			game.LogEvent("trade", p.Name,
-				fmt.Sprintf("📊 %s bought %s futures contract: %d units at %.0fcr, matures in ~%d min. Deposit: %dcr",
-					p.Name, f.Resource, f.Quantity, f.LockedPrice, (f.MaturityTick-tick)/600, f.Deposit))
+			p.Credits += f.Deposit
+			game.LogEvent("trade", f.Buyer,
+				fmt.Sprintf("📊 Futures exchange reopened: %s's old %s futures contract was cancelled and its %dcr deposit refunded",
+					f.Buyer, f.Resource, f.Deposit))
 			break
 		}
 	}
+	cfs.legacy = nil
+}
+
+// commodityFuturesState is the persisted form of CommodityFuturesSystem:
+// the old system's format, now only carrying contracts still owed a refund.
+type commodityFuturesState struct {
+	Futures   []*FuturesContract
+	NextOffer int64
 }
 
-func (cfs *CommodityFuturesSystem) generateOffering(tick int64, market interface{ GetSellPrice(string) float64 }, game GameProvider) {
-	resources := []string{entities.ResIron, entities.ResOil, entities.ResWater,
-		entities.ResRareMetals, entities.ResHelium3}
-	res := resources[rand.Intn(len(resources))]
+// Snapshot implements Snapshotter.
+func (cfs *CommodityFuturesSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(commodityFuturesState{Futures: cfs.legacy})
+}
 
-	price := market.GetSellPrice(res)
-	qty := 100 + rand.Intn(400)
-	deposit := int(price * float64(qty) * 0.1) // 10% deposit
-	if deposit < 100 {
-		deposit = 100
+// Restore implements Snapshotter. Bought, unsettled contracts from an old
+// save are queued for a refund; the rest are dropped.
+func (cfs *CommodityFuturesSystem) Restore(data []byte) error {
+	var state commodityFuturesState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
 	}
-	maturity := tick + 5000 + int64(rand.Intn(8000))
-
-	f := &FuturesContract{
-		ID:           len(cfs.futures) + 1,
-		Resource:     res,
-		Quantity:     qty,
-		LockedPrice:  price,
-		Deposit:      deposit,
-		MaturityTick: maturity,
+	cfs.legacy = nil
+	for _, f := range state.Futures {
+		if f != nil && f.Buyer != "" && !f.Settled && f.Deposit > 0 {
+			cfs.legacy = append(cfs.legacy, f)
+		}
 	}
-	cfs.futures = append(cfs.futures, f)
-
-	game.LogEvent("trade", "",
-		fmt.Sprintf("📊 FUTURES AVAILABLE: %d %s at %.0fcr/unit, matures in ~%d min. Deposit: %dcr. Will the price go up?",
-			qty, res, price, (maturity-tick)/600, deposit))
+	return nil
 }
@@ -1,14 +1,14 @@
 package tickable
 
 import (
-	"math/rand"
-
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&CompositionMiningSystem{
-		BaseSystem: NewBaseSystem("CompositionMining", 17),
+	RegisterSystemFunc(func() TickableSystem {
+		return &CompositionMiningSystem{
+			BaseSystem: NewBaseSystem("CompositionMining", 17).Every(100),
+		}
 	})
 }
 
@@ -31,10 +31,6 @@ type CompositionMiningSystem struct {
 }
 
 func (cms *CompositionMiningSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := cms.GetContext()
 	if ctx == nil {
 		return
@@ -98,7 +94,7 @@ func (cms *CompositionMiningSystem) OnTick(tick int64) {
 				// Composition bonus: inject extra resources proportional to fraction
 				// 0.30 fraction = 30% of base rate as bonus ≈ 1 extra unit per 100 ticks
 				bonusQty := int(b.fraction * 3)
-				if bonusQty < 1 && rand.Intn(3) == 0 {
+				if bonusQty < 1 && cms.Rand().Intn(3) == 0 {
 					bonusQty = 1
 				}
 				if bonusQty > 0 {
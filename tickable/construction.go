@@ -10,10 +10,12 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ConstructionSystem{
-		BaseSystem:  NewBaseSystem("Construction", 20),
-		queues:      NewSafeMap[string, *ConstructionQueue](),
-		completions: make(chan ConstructionCompletion, 1000),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ConstructionSystem{
+			BaseSystem:  NewBaseSystem("Construction", 20),
+			queues:      NewSafeMap[string, *ConstructionQueue](),
+			completions: make(chan ConstructionCompletion, 1000),
+		}
 	})
 }
 
@@ -29,7 +31,7 @@ type ConstructionItem struct {
 	RemainingTicks int          // Ticks remaining
 	Cost           int          // Credit cost
 	Started        int64        // Tick when started
-	Mutex          sync.RWMutex `gob:"-"` // Don't serialize mutex
+	Mutex          sync.RWMutex `gob:"-" json:"-"` // Don't serialize mutex
 }
 
 // GobEncode implements gob.GobEncoder to exclude Mutex from serialization
@@ -447,7 +449,12 @@ func (cs *ConstructionSystem) ClearQueue(location string) {
 // GetConstructionSystem returns the singleton construction system, or nil if not registered.
 // Use this instead of GetSystemByName("Construction") + type assertion.
 func GetConstructionSystem() *ConstructionSystem {
-	if sys := GetSystemByName("Construction"); sys != nil {
+	return registry.ConstructionSystem()
+}
+
+// ConstructionSystem returns the registry's construction system, or nil.
+func (r *Registry) ConstructionSystem() *ConstructionSystem {
+	if sys := r.SystemByName("Construction"); sys != nil {
 		if cs, ok := sys.(*ConstructionSystem); ok {
 			return cs
 		}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ContractExecutionSystem{
-		BaseSystem: NewBaseSystem("ContractExecution", 28),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ContractExecutionSystem{
+			BaseSystem: NewBaseSystem("ContractExecution", 28).Every(10),
+		}
 	})
 }
 
@@ -20,10 +22,6 @@ type ContractExecutionSystem struct {
 }
 
 func (ces *ContractExecutionSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := ces.GetContext()
 	if ctx == nil {
 		return
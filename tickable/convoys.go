@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ConvoySystem{
-		BaseSystem: NewBaseSystem("Convoys", 35),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ConvoySystem{
+			BaseSystem: NewBaseSystem("Convoys", 35).Every(200),
+		}
 	})
 }
 
@@ -48,10 +49,6 @@ type ConvoyContract struct {
 }
 
 func (cs *ConvoySystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := cs.GetContext()
 	if ctx == nil {
 		return
@@ -75,7 +72,7 @@ func (cs *ConvoySystem) OnTick(tick int64) {
 	}
 
 	// Generate escort contracts periodically
-	if tick%5000 == 0 && len(systems) > 5 {
+	if cs.DueEvery(tick, 5000) && len(systems) > 5 {
 		cs.generateContract(game, systems)
 	}
 }
@@ -133,13 +130,13 @@ func (cs *ConvoySystem) processConvoys(tick int64, sys *entities.System, players
 
 func (cs *ConvoySystem) generateContract(game GameProvider, systems []*entities.System) {
 	// Pick two systems far apart
-	a := rand.Intn(len(systems))
-	b := rand.Intn(len(systems))
+	a := cs.Rand().Intn(len(systems))
+	b := cs.Rand().Intn(len(systems))
 	for b == a || abs(a-b) < 3 {
-		b = rand.Intn(len(systems))
+		b = cs.Rand().Intn(len(systems))
 	}
 
-	reward := 3000 + rand.Intn(7000)
+	reward := 3000 + cs.Rand().Intn(7000)
 	game.LogEvent("event", "",
 		fmt.Sprintf("🛡️ ESCORT CONTRACT: Transport needed from %s to %s! Reward: %d credits. Send a convoy!",
 			systems[a].Name, systems[b].Name, reward))
@@ -172,3 +169,28 @@ func HasEscort(ship *entities.Ship, players []*entities.Player) bool {
 	}
 	return false
 }
+
+// convoyState is the persisted form of ConvoySystem.
+type convoyState struct {
+	ConvoyBonuses map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (cs *ConvoySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(convoyState{
+		ConvoyBonuses: cs.convoyBonuses,
+	})
+}
+
+// Restore implements Snapshotter.
+func (cs *ConvoySystem) Restore(data []byte) error {
+	var state convoyState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	cs.convoyBonuses = state.ConvoyBonuses
+	if cs.convoyBonuses == nil {
+		cs.convoyBonuses = make(map[string]int64)
+	}
+	return nil
+}
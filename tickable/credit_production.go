@@ -6,8 +6,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&CreditProductionSystem{
-		BaseSystem: NewBaseSystem("CreditProduction", 10),
+	RegisterSystemFunc(func() TickableSystem {
+		return &CreditProductionSystem{
+			BaseSystem: NewBaseSystem("CreditProduction", 10).Every(10),
+		}
 	})
 }
 
@@ -39,10 +41,6 @@ var domesticPrices = map[string]float64{
 }
 
 func (cps *CreditProductionSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	context := cps.GetContext()
 	if context == nil {
 		return
@@ -147,3 +145,25 @@ func (cps *CreditProductionSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// creditProductionState is the persisted form of CreditProductionSystem.
+type creditProductionState struct {
+	TickCounter int64
+}
+
+// Snapshot implements Snapshotter.
+func (cps *CreditProductionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(creditProductionState{
+		TickCounter: cps.tickCounter,
+	})
+}
+
+// Restore implements Snapshotter.
+func (cps *CreditProductionSystem) Restore(data []byte) error {
+	var state creditProductionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	cps.tickCounter = state.TickCounter
+	return nil
+}
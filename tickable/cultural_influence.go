@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&CulturalInfluenceSystem{
-		BaseSystem: NewBaseSystem("CulturalInfluence", 92),
+	RegisterSystemFunc(func() TickableSystem {
+		return &CulturalInfluenceSystem{
+			BaseSystem: NewBaseSystem("CulturalInfluence", 92).Every(2000),
+		}
 	})
 }
 
@@ -40,10 +43,6 @@ type CulturalInfluenceSystem struct {
 }
 
 func (cis *CulturalInfluenceSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := cis.GetContext()
 	if ctx == nil {
 		return
@@ -95,8 +94,8 @@ func (cis *CulturalInfluenceSystem) OnTick(tick int64) {
 	// Cultural drift: unclaimed habitable planets near top influencer
 	topFaction := ""
 	topScore := 0
-	for name, score := range cis.influence {
-		if score > topScore {
+	for _, name := range slices.Sorted(maps.Keys(cis.influence)) {
+		if score := cis.influence[name]; score > topScore {
 			topScore = score
 			topFaction = name
 		}
@@ -163,7 +162,7 @@ func (cis *CulturalInfluenceSystem) OnTick(tick int64) {
 	}
 
 	// Announce influence rankings periodically
-	if rand.Intn(5) == 0 && topScore > 50 {
+	if cis.Rand().Intn(5) == 0 && topScore > 50 {
 		game.LogEvent("intel", "",
 			fmt.Sprintf("🎭 Cultural Influence: %s leads with %d influence. High influence attracts settlers to unclaimed worlds!",
 				topFaction, topScore))
@@ -177,3 +176,43 @@ func (cis *CulturalInfluenceSystem) GetInfluence(faction string) int {
 	}
 	return cis.influence[faction]
 }
+
+// culturalInfluenceState is the persisted form of CulturalInfluenceSystem.
+type culturalInfluenceState struct {
+	Influence    map[string]int
+	DriftTargets map[int]string
+	DriftTicks   map[int]int64
+	NextCalc     int64
+}
+
+// Snapshot implements Snapshotter.
+func (cis *CulturalInfluenceSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(culturalInfluenceState{
+		Influence:    cis.influence,
+		DriftTargets: cis.driftTargets,
+		DriftTicks:   cis.driftTicks,
+		NextCalc:     cis.nextCalc,
+	})
+}
+
+// Restore implements Snapshotter.
+func (cis *CulturalInfluenceSystem) Restore(data []byte) error {
+	var state culturalInfluenceState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	cis.influence = state.Influence
+	cis.driftTargets = state.DriftTargets
+	cis.driftTicks = state.DriftTicks
+	cis.nextCalc = state.NextCalc
+	if cis.influence == nil {
+		cis.influence = make(map[string]int)
+	}
+	if cis.driftTargets == nil {
+		cis.driftTargets = make(map[int]string)
+	}
+	if cis.driftTicks == nil {
+		cis.driftTicks = make(map[int]int64)
+	}
+	return nil
+}
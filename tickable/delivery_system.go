@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&DeliverySystem{
-		BaseSystem: NewBaseSystem("Delivery", 28),
+	RegisterSystemFunc(func() TickableSystem {
+		return &DeliverySystem{
+			BaseSystem: NewBaseSystem("Delivery", 28).Every(10),
+		}
 	})
 }
 
@@ -20,10 +22,6 @@ type DeliverySystem struct {
 }
 
 func (ds *DeliverySystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	context := ds.GetContext()
 	if context == nil {
 		return
@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&DiplomaticGiftSystem{
-		BaseSystem: NewBaseSystem("DiplomaticGifts", 143),
+	RegisterSystemFunc(func() TickableSystem {
+		return &DiplomaticGiftSystem{
+			BaseSystem: NewBaseSystem("DiplomaticGifts", 143).Every(5000),
+		}
 	})
 }
 
@@ -31,10 +32,6 @@ type DiplomaticGiftSystem struct {
 }
 
 func (dgs *DiplomaticGiftSystem) OnTick(tick int64) {
-	if tick%5000 != 0 {
-		return
-	}
-
 	ctx := dgs.GetContext()
 	if ctx == nil {
 		return
@@ -82,7 +79,7 @@ func (dgs *DiplomaticGiftSystem) OnTick(tick int64) {
 			}
 
 			// 20% chance per check
-			if rand.Intn(5) != 0 {
+			if dgs.Rand().Intn(5) != 0 {
 				continue
 			}
 
@@ -97,3 +94,28 @@ func (dgs *DiplomaticGiftSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// diplomaticGiftState is the persisted form of DiplomaticGiftSystem.
+type diplomaticGiftState struct {
+	LastGift map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (dgs *DiplomaticGiftSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(diplomaticGiftState{
+		LastGift: dgs.lastGift,
+	})
+}
+
+// Restore implements Snapshotter.
+func (dgs *DiplomaticGiftSystem) Restore(data []byte) error {
+	var state diplomaticGiftState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	dgs.lastGift = state.LastGift
+	if dgs.lastGift == nil {
+		dgs.lastGift = make(map[string]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&DiplomaticIncidentSystem{
-		BaseSystem: NewBaseSystem("DiplomaticIncidents", 100),
+	RegisterSystemFunc(func() TickableSystem {
+		return &DiplomaticIncidentSystem{
+			BaseSystem: NewBaseSystem("DiplomaticIncidents", 100),
+		}
 	})
 }
 
@@ -55,12 +56,12 @@ func (dis *DiplomaticIncidentSystem) OnTick(tick int64) {
 	}
 
 	if dis.nextIncident == 0 {
-		dis.nextIncident = tick + 8000 + int64(rand.Intn(10000))
+		dis.nextIncident = tick + 8000 + int64(dis.Rand().Intn(10000))
 	}
 	if tick < dis.nextIncident {
 		return
 	}
-	dis.nextIncident = tick + 10000 + int64(rand.Intn(15000))
+	dis.nextIncident = tick + 10000 + int64(dis.Rand().Intn(15000))
 
 	players := ctx.GetPlayers()
 	if len(players) < 2 {
@@ -78,13 +79,13 @@ func (dis *DiplomaticIncidentSystem) OnTick(tick int64) {
 		return
 	}
 
-	a := validPlayers[rand.Intn(len(validPlayers))]
-	b := validPlayers[rand.Intn(len(validPlayers))]
+	a := validPlayers[dis.Rand().Intn(len(validPlayers))]
+	b := validPlayers[dis.Rand().Intn(len(validPlayers))]
 	for b.Name == a.Name {
-		b = validPlayers[rand.Intn(len(validPlayers))]
+		b = validPlayers[dis.Rand().Intn(len(validPlayers))]
 	}
 
-	incidentType := rand.Intn(5)
+	incidentType := dis.Rand().Intn(5)
 	switch incidentType {
 	case 0: // Border dispute
 		a.Credits -= 500
@@ -120,3 +121,25 @@ func (dis *DiplomaticIncidentSystem) OnTick(tick int64) {
 				a.Name, b.Name))
 	}
 }
+
+// diplomaticIncidentState is the persisted form of DiplomaticIncidentSystem.
+type diplomaticIncidentState struct {
+	NextIncident int64
+}
+
+// Snapshot implements Snapshotter.
+func (dis *DiplomaticIncidentSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(diplomaticIncidentState{
+		NextIncident: dis.nextIncident,
+	})
+}
+
+// Restore implements Snapshotter.
+func (dis *DiplomaticIncidentSystem) Restore(data []byte) error {
+	var state diplomaticIncidentState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	dis.nextIncident = state.NextIncident
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&DistressSignalSystem{
-		BaseSystem: NewBaseSystem("DistressSignals", 64),
+	RegisterSystemFunc(func() TickableSystem {
+		return &DistressSignalSystem{
+			BaseSystem: NewBaseSystem("DistressSignals", 64).Every(200),
+		}
 	})
 }
 
@@ -54,10 +55,6 @@ type DistressSignal struct {
 }
 
 func (dss *DistressSignalSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := dss.GetContext()
 	if ctx == nil {
 		return
@@ -69,7 +66,7 @@ func (dss *DistressSignalSystem) OnTick(tick int64) {
 	}
 
 	if dss.nextSignal == 0 {
-		dss.nextSignal = tick + 2000 + int64(rand.Intn(3000))
+		dss.nextSignal = tick + 2000 + int64(dss.Rand().Intn(3000))
 	}
 
 	players := ctx.GetPlayers()
@@ -101,7 +98,7 @@ func (dss *DistressSignalSystem) OnTick(tick int64) {
 
 	// Generate new signals
 	if tick >= dss.nextSignal {
-		dss.nextSignal = tick + 5000 + int64(rand.Intn(8000))
+		dss.nextSignal = tick + 5000 + int64(dss.Rand().Intn(8000))
 
 		// Max 3 active signals
 		activeCount := 0
@@ -130,8 +127,8 @@ func (dss *DistressSignalSystem) checkResponse(sig *DistressSignal, players []*e
 			case "freighter":
 				// Any ship can claim stranded cargo
 				resources := []string{entities.ResIron, entities.ResOil, entities.ResRareMetals, entities.ResWater}
-				res := resources[rand.Intn(len(resources))]
-				qty := 200 + rand.Intn(300)
+				res := resources[dss.Rand().Intn(len(resources))]
+				qty := 200 + dss.Rand().Intn(300)
 				loaded := ship.AddCargo(res, qty)
 				if loaded > 0 {
 					sig.Claimed = true
@@ -201,16 +198,16 @@ func (dss *DistressSignalSystem) generateSignal(game GameProvider, systems []*en
 		return
 	}
 
-	sys := systems[rand.Intn(len(systems))]
-	signalType := rand.Intn(4)
+	sys := systems[dss.Rand().Intn(len(systems))]
+	signalType := dss.Rand().Intn(4)
 
 	var sig *DistressSignal
 	switch signalType {
 	case 0:
 		sig = &DistressSignal{
 			ID: len(dss.signals) + 1, SystemID: sys.ID, SysName: sys.Name,
-			Type: "freighter", Reward: 1000 + rand.Intn(2000),
-			TicksLeft: 5000 + rand.Intn(3000), Active: true,
+			Type: "freighter", Reward: 1000 + dss.Rand().Intn(2000),
+			TicksLeft: 5000 + dss.Rand().Intn(3000), Active: true,
 		}
 		game.LogEvent("event", "",
 			fmt.Sprintf("📡 DISTRESS: Stranded freighter in %s! Send any ship to salvage cargo and earn %dcr",
@@ -218,11 +215,11 @@ func (dss *DistressSignalSystem) generateSignal(game GameProvider, systems []*en
 
 	case 1:
 		resources := []string{entities.ResWater, entities.ResIron}
-		res := resources[rand.Intn(len(resources))]
+		res := resources[dss.Rand().Intn(len(resources))]
 		sig = &DistressSignal{
 			ID: len(dss.signals) + 1, SystemID: sys.ID, SysName: sys.Name,
-			Type: "colony", Resource: res, Reward: 2000 + rand.Intn(3000),
-			TicksLeft: 6000 + rand.Intn(4000), Active: true,
+			Type: "colony", Resource: res, Reward: 2000 + dss.Rand().Intn(3000),
+			TicksLeft: 6000 + dss.Rand().Intn(4000), Active: true,
 		}
 		game.LogEvent("event", "",
 			fmt.Sprintf("📡 DISTRESS: Colony in %s running out of %s! Deliver 50+ units for %dcr reward",
@@ -231,8 +228,8 @@ func (dss *DistressSignalSystem) generateSignal(game GameProvider, systems []*en
 	case 2:
 		sig = &DistressSignal{
 			ID: len(dss.signals) + 1, SystemID: sys.ID, SysName: sys.Name,
-			Type: "science", Reward: 1500 + rand.Intn(2500),
-			TicksLeft: 4000 + rand.Intn(3000), Active: true,
+			Type: "science", Reward: 1500 + dss.Rand().Intn(2500),
+			TicksLeft: 4000 + dss.Rand().Intn(3000), Active: true,
 		}
 		game.LogEvent("event", "",
 			fmt.Sprintf("📡 DISTRESS: Science vessel in %s needs military escort! Send a warship for tech data + %dcr",
@@ -241,8 +238,8 @@ func (dss *DistressSignalSystem) generateSignal(game GameProvider, systems []*en
 	case 3:
 		sig = &DistressSignal{
 			ID: len(dss.signals) + 1, SystemID: sys.ID, SysName: sys.Name,
-			Type: "envoy", Reward: 2000 + rand.Intn(3000),
-			TicksLeft: 5000 + rand.Intn(3000), Active: true,
+			Type: "envoy", Reward: 2000 + dss.Rand().Intn(3000),
+			TicksLeft: 5000 + dss.Rand().Intn(3000), Active: true,
 		}
 		game.LogEvent("event", "",
 			fmt.Sprintf("📡 DISTRESS: Diplomatic envoy in %s requests escort! Send a warship for %dcr + reputation",
@@ -264,3 +261,28 @@ func (dss *DistressSignalSystem) GetActiveSignals() []*DistressSignal {
 	}
 	return result
 }
+
+// distressSignalState is the persisted form of DistressSignalSystem.
+type distressSignalState struct {
+	Signals    []*DistressSignal
+	NextSignal int64
+}
+
+// Snapshot implements Snapshotter.
+func (dss *DistressSignalSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(distressSignalState{
+		Signals:    dss.signals,
+		NextSignal: dss.nextSignal,
+	})
+}
+
+// Restore implements Snapshotter.
+func (dss *DistressSignalSystem) Restore(data []byte) error {
+	var state distressSignalState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	dss.signals = state.Signals
+	dss.nextSignal = state.NextSignal
+	return nil
+}
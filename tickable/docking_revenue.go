@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&DockingRevenueSystem{
-		BaseSystem: NewBaseSystem("DockingRevenue", 28),
+	RegisterSystemFunc(func() TickableSystem {
+		return &DockingRevenueSystem{
+			BaseSystem: NewBaseSystem("DockingRevenue", 28).Every(500),
+		}
 	})
 }
 
@@ -32,10 +34,6 @@ type DockingRevenueSystem struct {
 }
 
 func (drs *DockingRevenueSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := drs.GetContext()
 	if ctx == nil {
 		return
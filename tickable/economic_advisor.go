@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&EconomicAdvisorSystem{
-		BaseSystem: NewBaseSystem("EconomicAdvisor", 121),
+	RegisterSystemFunc(func() TickableSystem {
+		return &EconomicAdvisorSystem{
+			BaseSystem: NewBaseSystem("EconomicAdvisor", 121).Every(5000),
+		}
 	})
 }
 
@@ -31,10 +32,6 @@ type EconomicAdvisorSystem struct {
 }
 
 func (eas *EconomicAdvisorSystem) OnTick(tick int64) {
-	if tick%5000 != 0 {
-		return
-	}
-
 	ctx := eas.GetContext()
 	if ctx == nil {
 		return
@@ -152,5 +149,30 @@ func (eas *EconomicAdvisorSystem) generateAdvice(player *entities.Player, system
 		return ""
 	}
 
-	return advices[rand.Intn(len(advices))]
+	return advices[eas.Rand().Intn(len(advices))]
+}
+
+// economicAdvisorState is the persisted form of EconomicAdvisorSystem.
+type economicAdvisorState struct {
+	LastAdvice map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (eas *EconomicAdvisorSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(economicAdvisorState{
+		LastAdvice: eas.lastAdvice,
+	})
+}
+
+// Restore implements Snapshotter.
+func (eas *EconomicAdvisorSystem) Restore(data []byte) error {
+	var state economicAdvisorState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	eas.lastAdvice = state.LastAdvice
+	if eas.lastAdvice == nil {
+		eas.lastAdvice = make(map[string]int64)
+	}
+	return nil
 }
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&EconomicCycleSystem{
-		BaseSystem: NewBaseSystem("EconomicCycles", 63),
+	RegisterSystemFunc(func() TickableSystem {
+		return &EconomicCycleSystem{
+			BaseSystem: NewBaseSystem("EconomicCycles", 63).Every(500),
+		}
 	})
 }
 
@@ -39,10 +40,6 @@ type EconomicCycleSystem struct {
 }
 
 func (ecs *EconomicCycleSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := ecs.GetContext()
 	if ctx == nil {
 		return
@@ -140,13 +137,13 @@ func (ecs *EconomicCycleSystem) applyEffects(multiplier float64, phase string, g
 	}
 
 	// Population growth modifier during expansion
-	if phase == "Expansion" && rand.Intn(3) == 0 {
+	if phase == "Expansion" && ecs.Rand().Intn(3) == 0 {
 		for _, sys := range systems {
 			for _, e := range sys.Entities {
 				if planet, ok := e.(*entities.Planet); ok && planet.Owner != "" && planet.Population > 100 {
 					cap := planet.GetTotalPopulationCapacity()
 					if cap > 0 && planet.Population < cap {
-						bonus := int64(50 + rand.Intn(100))
+						bonus := int64(50 + ecs.Rand().Intn(100))
 						if planet.Population+bonus > cap {
 							bonus = cap - planet.Population
 						}
@@ -167,3 +164,28 @@ func (ecs *EconomicCycleSystem) GetEconomicMultiplier() float64 {
 func (ecs *EconomicCycleSystem) GetPhase() string {
 	return ecs.getPhase()
 }
+
+// economicCycleState is the persisted form of EconomicCycleSystem.
+type economicCycleState struct {
+	CyclePosition float64
+	LastPhase     string
+}
+
+// Snapshot implements Snapshotter.
+func (ecs *EconomicCycleSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(economicCycleState{
+		CyclePosition: ecs.cyclePosition,
+		LastPhase:     ecs.lastPhase,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ecs *EconomicCycleSystem) Restore(data []byte) error {
+	var state economicCycleState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ecs.cyclePosition = state.CyclePosition
+	ecs.lastPhase = state.LastPhase
+	return nil
+}
@@ -2,14 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&EconomicEventSystem{
-		BaseSystem: NewBaseSystem("EconomicEvents", 50),
+	RegisterSystemFunc(func() TickableSystem {
+		return &EconomicEventSystem{
+			// Roughly every 500 ticks (~50 seconds at 1x speed), not before the first 500
+			BaseSystem: NewBaseSystem("EconomicEvents", 50).Every(500).From(500),
+		}
 	})
 }
 
@@ -40,13 +42,8 @@ type economicEvent struct {
 }
 
 func (ees *EconomicEventSystem) OnTick(tick int64) {
-	// Events fire roughly every 500 ticks (~50 seconds at 1x speed)
-	if tick%500 != 0 || tick < 500 {
-		return
-	}
-
 	// 30% chance per check — keeps events unpredictable
-	if rand.Intn(100) > 30 {
+	if ees.Rand().Intn(100) > 30 {
 		return
 	}
 
@@ -86,7 +83,7 @@ func (ees *EconomicEventSystem) OnTick(tick int64) {
 		return
 	}
 
-	planet := ownedPlanets[rand.Intn(len(ownedPlanets))]
+	planet := ownedPlanets[ees.Rand().Intn(len(ownedPlanets))]
 	player := playerMap[planet.Owner]
 	if player == nil {
 		return
@@ -111,21 +108,21 @@ func (ees *EconomicEventSystem) OnTick(tick int64) {
 // trading hubs attract caravans; explored systems find deposits.
 func (ees *EconomicEventSystem) generateEvent(planet *entities.Planet, player *entities.Player) *economicEvent {
 	resources := []string{entities.ResIron, entities.ResWater, entities.ResOil, entities.ResRareMetals, entities.ResHelium3, entities.ResFuel, entities.ResElectronics}
-	res := resources[rand.Intn(len(resources))]
+	res := resources[ees.Rand().Intn(len(resources))]
 
 	// Weight events based on planet conditions
 	happiness := planet.Happiness
 	powerRatio := planet.GetPowerRatio()
 	hasTradingPost := planet.HasOperationalBuilding(entities.BuildingTradingPost)
 
-	roll := rand.Intn(100)
+	roll := ees.Rand().Intn(100)
 	switch {
 	case roll < 20:
 		// Resource discovery — more likely on planets with low resource diversity
 		if len(planet.Resources) >= 5 {
 			return nil // already resource-rich
 		}
-		amount := 50 + rand.Intn(150)
+		amount := 50 + ees.Rand().Intn(150)
 		return &economicEvent{
 			Type:     EventBoom,
 			Name:     "Resource Surge",
@@ -143,7 +140,7 @@ func (ees *EconomicEventSystem) generateEvent(planet *entities.Planet, player *e
 		if happiness > 0.7 {
 			accidentChance -= 30 // happy workers make fewer mistakes
 		}
-		if rand.Intn(100) >= accidentChance {
+		if ees.Rand().Intn(100) >= accidentChance {
 			return nil // good conditions prevented the accident
 		}
 		stored := planet.GetStoredAmount(res)
@@ -169,7 +166,7 @@ func (ees *EconomicEventSystem) generateEvent(planet *entities.Planet, player *e
 		}
 		// Windfall scales with planet population (bigger market = bigger payoff)
 		base := 200 + int(planet.Population/50)
-		amount := base + rand.Intn(base)
+		amount := base + ees.Rand().Intn(base)
 		return &economicEvent{
 			Type:     EventWindfall,
 			Name:     "Trade Windfall",
@@ -199,7 +196,7 @@ func (ees *EconomicEventSystem) generateEvent(planet *entities.Planet, player *e
 		// Deposit enrichment — more likely on planets with depleted deposits
 		for _, resEntity := range planet.Resources {
 			if r, ok := resEntity.(*entities.Resource); ok && r.Abundance < 40 {
-				amount := 5 + rand.Intn(15)
+				amount := 5 + ees.Rand().Intn(15)
 				return &economicEvent{
 					Type:     EventBoom,
 					Name:     "Deposit Enrichment",
@@ -243,3 +240,25 @@ func (ees *EconomicEventSystem) applyEvent(event *economicEvent, planet *entitie
 		}
 	}
 }
+
+// economicEventState is the persisted form of EconomicEventSystem.
+type economicEventState struct {
+	LastEventTick int64
+}
+
+// Snapshot implements Snapshotter.
+func (ees *EconomicEventSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(economicEventState{
+		LastEventTick: ees.lastEventTick,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ees *EconomicEventSystem) Restore(data []byte) error {
+	var state economicEventState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ees.lastEventTick = state.LastEventTick
+	return nil
+}
@@ -2,14 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&EmbargoSystem{
-		BaseSystem: NewBaseSystem("Embargo", 117),
+	RegisterSystemFunc(func() TickableSystem {
+		return &EmbargoSystem{
+			BaseSystem: NewBaseSystem("Embargo", 117).Every(3000),
+		}
 	})
 }
 
@@ -46,10 +48,6 @@ type Embargo struct {
 }
 
 func (es *EmbargoSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := es.GetContext()
 	if ctx == nil {
 		return
@@ -88,7 +86,7 @@ func (es *EmbargoSystem) OnTick(tick int64) {
 	if tick < es.nextCheck {
 		return
 	}
-	es.nextCheck = tick + 10000 + int64(rand.Intn(10000))
+	es.nextCheck = tick + 10000 + int64(es.Rand().Intn(10000))
 
 	// Max 3 active embargoes
 	activeCount := 0
@@ -113,6 +111,7 @@ func (es *EmbargoSystem) OnTick(tick int64) {
 		for f := range factions {
 			factionList = append(factionList, f)
 		}
+		sort.Strings(factionList)
 
 		for i := 0; i < len(factionList); i++ {
 			for j := i + 1; j < len(factionList); j++ {
@@ -139,7 +138,7 @@ func (es *EmbargoSystem) OnTick(tick int64) {
 				// Pick a resource to embargo
 				resources := []string{entities.ResIron, entities.ResWater, entities.ResOil,
 					entities.ResFuel, entities.ResRareMetals}
-				res := resources[rand.Intn(len(resources))]
+				res := resources[es.Rand().Intn(len(resources))]
 
 				es.embargoes = append(es.embargoes, &Embargo{
 					Enforcer: a, Target: b,
@@ -155,3 +154,28 @@ func (es *EmbargoSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// embargoState is the persisted form of EmbargoSystem.
+type embargoState struct {
+	Embargoes []*Embargo
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (es *EmbargoSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(embargoState{
+		Embargoes: es.embargoes,
+		NextCheck: es.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (es *EmbargoSystem) Restore(data []byte) error {
+	var state embargoState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	es.embargoes = state.Embargoes
+	es.nextCheck = state.NextCheck
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&EmergencySupplySystem{
-		BaseSystem: NewBaseSystem("EmergencySupply", 12),
+	RegisterSystemFunc(func() TickableSystem {
+		return &EmergencySupplySystem{
+			BaseSystem: NewBaseSystem("EmergencySupply", 12).Every(500),
+		}
 	})
 }
 
@@ -34,10 +35,6 @@ type EmergencySupplySystem struct {
 }
 
 func (ess *EmergencySupplySystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := ess.GetContext()
 	if ctx == nil {
 		return
@@ -99,7 +96,7 @@ func (ess *EmergencySupplySystem) OnTick(tick int64) {
 			if supplied {
 				ess.lastEmergency[pid] = tick
 				// Only log occasionally to avoid spam
-				if rand.Intn(5) == 0 {
+				if ess.Rand().Intn(5) == 0 {
 					game.LogEvent("alert", planet.Owner,
 						fmt.Sprintf("🆘 Emergency supplies on %s — critical resources at zero. Import supplies ASAP!",
 							planet.Name))
@@ -108,3 +105,28 @@ func (ess *EmergencySupplySystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// emergencySupplyState is the persisted form of EmergencySupplySystem.
+type emergencySupplyState struct {
+	LastEmergency map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ess *EmergencySupplySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(emergencySupplyState{
+		LastEmergency: ess.lastEmergency,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ess *EmergencySupplySystem) Restore(data []byte) error {
+	var state emergencySupplyState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ess.lastEmergency = state.LastEmergency
+	if ess.lastEmergency == nil {
+		ess.lastEmergency = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&EnergyCrisisResponseSystem{
-		BaseSystem: NewBaseSystem("EnergyCrisisResponse", 10),
+	RegisterSystemFunc(func() TickableSystem {
+		return &EnergyCrisisResponseSystem{
+			BaseSystem: NewBaseSystem("EnergyCrisisResponse", 10).Every(100),
+		}
 	})
 }
 
@@ -35,10 +36,6 @@ type EnergyCrisisResponseSystem struct {
 }
 
 func (ecrs *EnergyCrisisResponseSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := ecrs.GetContext()
 	if ctx == nil {
 		return
@@ -109,10 +106,35 @@ func (ecrs *EnergyCrisisResponseSystem) OnTick(tick int64) {
 	// Periodic crisis alerts
 	if ecrs.crisisActive && tick-ecrs.lastAlert > 5000 {
 		ecrs.lastAlert = tick
-		if rand.Intn(2) == 0 {
+		if ecrs.Rand().Intn(2) == 0 {
 			game.LogEvent("alert", "",
 				fmt.Sprintf("⚡ Energy crisis ongoing: %d/%d planets in power crisis. Build Generators + Refineries! Import Oil!",
 					inCrisis, totalOwned))
 		}
 	}
 }
+
+// energyCrisisResponseState is the persisted form of EnergyCrisisResponseSystem.
+type energyCrisisResponseState struct {
+	CrisisActive bool
+	LastAlert    int64
+}
+
+// Snapshot implements Snapshotter.
+func (ecrs *EnergyCrisisResponseSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(energyCrisisResponseState{
+		CrisisActive: ecrs.crisisActive,
+		LastAlert:    ecrs.lastAlert,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ecrs *EnergyCrisisResponseSystem) Restore(data []byte) error {
+	var state energyCrisisResponseState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ecrs.crisisActive = state.CrisisActive
+	ecrs.lastAlert = state.LastAlert
+	return nil
+}
@@ -0,0 +1,129 @@
+package tickable
+
+import (
+	"github.com/hunterjsb/xandaris/economy"
+	"github.com/hunterjsb/xandaris/entities"
+)
+
+func init() {
+	RegisterSystemFunc(func() TickableSystem {
+		return &EspionageSystem{
+			BaseSystem: NewBaseSystem("Espionage", 104).Every(10),
+		}
+	})
+}
+
+// EspionageSystem counts down spy operations and resolves the ones that
+// finish. A successful op takes effect immediately:
+//   - intel: the target's holdings become visible to the operator for
+//     economy.IntelDuration ticks (see game.Visibility)
+//   - sabotage: a building on the target's planet loses a level, or goes
+//     offline if it's already level 1
+//   - steal_tech: 0.5 tech moves from the target's planet to the operator's
+//     most advanced one
+//
+// The target planet is the target's planet in the op's system, or their
+// first planet if they have none there.
+type EspionageSystem struct {
+	*BaseSystem
+}
+
+func (es *EspionageSystem) OnTick(tick int64) {
+	ctx := es.GetContext()
+	if ctx == nil {
+		return
+	}
+	game := ctx.GetGame()
+	if game == nil {
+		return
+	}
+	em := game.GetEspionageManager()
+	if em == nil {
+		return
+	}
+
+	elapsed := int(es.GetSchedule().Interval)
+	if elapsed < 1 {
+		elapsed = 1
+	}
+	for _, op := range em.TickOperations(elapsed) {
+		target := spyTargetPlanet(game.GetSystems(), op)
+		result := economy.ResolveOperation(op, target != nil && hasPlanetShield(target), es.Rand())
+		if result.Success && target != nil {
+			es.applyOperation(op, target, ctx.GetPlayers())
+		}
+		em.RecordOutcome(op, result.Success, tick)
+		game.LogEvent("intel", op.Operator, result.Message)
+	}
+}
+
+func (es *EspionageSystem) applyOperation(op *economy.SpyOperation, target *entities.Planet, players []*entities.Player) {
+	switch op.Type {
+	case "sabotage":
+		var buildings []*entities.Building
+		for _, be := range target.Buildings {
+			if b, ok := be.(*entities.Building); ok && b.IsOperational {
+				buildings = append(buildings, b)
+			}
+		}
+		if len(buildings) == 0 {
+			return
+		}
+		b := buildings[es.Rand().Intn(len(buildings))]
+		if b.Level > 1 {
+			b.Level--
+		} else {
+			b.IsOperational = false
+		}
+	case "steal_tech":
+		var best *entities.Planet
+		for _, p := range players {
+			if p == nil || p.Name != op.Operator {
+				continue
+			}
+			for _, planet := range p.OwnedPlanets {
+				if planet != nil && (best == nil || planet.TechLevel > best.TechLevel) {
+					best = planet
+				}
+			}
+		}
+		if best == nil {
+			return
+		}
+		stolen := 0.5
+		if target.TechLevel < stolen {
+			stolen = target.TechLevel
+		}
+		target.TechLevel -= stolen
+		best.TechLevel += stolen
+	}
+}
+
+// spyTargetPlanet picks the planet an operation acts on.
+func spyTargetPlanet(systems []*entities.System, op *economy.SpyOperation) *entities.Planet {
+	var fallback *entities.Planet
+	for _, sys := range systems {
+		for _, e := range sys.Entities {
+			planet, ok := e.(*entities.Planet)
+			if !ok || planet.Owner != op.Target {
+				continue
+			}
+			if sys.ID == op.SystemID {
+				return planet
+			}
+			if fallback == nil {
+				fallback = planet
+			}
+		}
+	}
+	return fallback
+}
+
+func hasPlanetShield(planet *entities.Planet) bool {
+	for _, be := range planet.Buildings {
+		if b, ok := be.(*entities.Building); ok && b.BuildingType == entities.BuildingPlanetShield && b.IsOperational {
+			return true
+		}
+	}
+	return false
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ExcessShipScrapSystem{
-		BaseSystem: NewBaseSystem("ExcessShipScrap", 130),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ExcessShipScrapSystem{
+			BaseSystem: NewBaseSystem("ExcessShipScrap", 130).Every(3000),
+		}
 	})
 }
 
@@ -30,10 +31,6 @@ type ExcessShipScrapSystem struct {
 }
 
 func (esss *ExcessShipScrapSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := esss.GetContext()
 	if ctx == nil {
 		return
@@ -134,10 +131,35 @@ func (esss *ExcessShipScrapSystem) OnTick(tick int64) {
 			scrapped++
 		}
 
-		if scrapped > 0 && rand.Intn(3) == 0 {
+		if scrapped > 0 && esss.Rand().Intn(3) == 0 {
 			game.LogEvent("logistics", player.Name,
 				fmt.Sprintf("♻️ %s scrapped %d excess/stranded ships for credits + materials",
 					player.Name, scrapped))
 		}
 	}
 }
+
+// excessShipScrapState is the persisted form of ExcessShipScrapSystem.
+type excessShipScrapState struct {
+	StrandedSince map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (esss *ExcessShipScrapSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(excessShipScrapState{
+		StrandedSince: esss.strandedSince,
+	})
+}
+
+// Restore implements Snapshotter.
+func (esss *ExcessShipScrapSystem) Restore(data []byte) error {
+	var state excessShipScrapState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	esss.strandedSince = state.StrandedSince
+	if esss.strandedSince == nil {
+		esss.strandedSince = make(map[int]int64)
+	}
+	return nil
+}
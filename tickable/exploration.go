@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ExplorationSystem{
-		BaseSystem: NewBaseSystem("Exploration", 33),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ExplorationSystem{
+			BaseSystem: NewBaseSystem("Exploration", 33).Every(50),
+		}
 	})
 }
 
@@ -31,10 +32,6 @@ type ExplorationSystem struct {
 }
 
 func (es *ExplorationSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := es.GetContext()
 	if ctx == nil {
 		return
@@ -68,7 +65,7 @@ func (es *ExplorationSystem) OnTick(tick int64) {
 			}
 
 			// 10% chance per tick to discover something
-			if rand.Intn(10) != 0 {
+			if es.Rand().Intn(10) != 0 {
 				continue
 			}
 
@@ -79,11 +76,11 @@ func (es *ExplorationSystem) OnTick(tick int64) {
 }
 
 func (es *ExplorationSystem) discover(game GameProvider, player *entities.Player, ship *entities.Ship, systems []*entities.System) {
-	discoveryType := rand.Intn(6)
+	discoveryType := es.Rand().Intn(6)
 
 	switch discoveryType {
 	case 0: // Ancient Ruins
-		credits := 3000 + rand.Intn(7000)
+		credits := 3000 + es.Rand().Intn(7000)
 		player.Credits += credits
 		game.LogEvent("explore", player.Name,
 			fmt.Sprintf("🏛️ %s discovered Ancient Ruins in SYS-%d! +%d credits from salvaged tech",
@@ -91,8 +88,8 @@ func (es *ExplorationSystem) discover(game GameProvider, player *entities.Player
 
 	case 1: // Derelict Ship
 		resources := []string{entities.ResRareMetals, entities.ResElectronics, entities.ResFuel}
-		res := resources[rand.Intn(len(resources))]
-		qty := 50 + rand.Intn(200)
+		res := resources[es.Rand().Intn(len(resources))]
+		qty := 50 + es.Rand().Intn(200)
 		loaded := ship.AddCargo(res, qty)
 		if loaded > 0 {
 			game.LogEvent("explore", player.Name,
@@ -108,9 +105,9 @@ func (es *ExplorationSystem) discover(game GameProvider, player *entities.Player
 			for _, e := range sys.Entities {
 				if planet, ok := e.(*entities.Planet); ok && planet.Owner == player.Name {
 					if len(planet.Resources) > 0 {
-						idx := rand.Intn(len(planet.Resources))
+						idx := es.Rand().Intn(len(planet.Resources))
 						if res, ok := planet.Resources[idx].(*entities.Resource); ok {
-							bonus := 15 + rand.Intn(25)
+							bonus := 15 + es.Rand().Intn(25)
 							res.Abundance += bonus
 							game.LogEvent("explore", player.Name,
 								fmt.Sprintf("⛏️ %s discovered a rich mineral vein! %s on %s +%d abundance",
@@ -124,7 +121,7 @@ func (es *ExplorationSystem) discover(game GameProvider, player *entities.Player
 		}
 
 	case 3: // Wormhole — nothing for now, just a lore event
-		targetSys := rand.Intn(len(systems))
+		targetSys := es.Rand().Intn(len(systems))
 		game.LogEvent("explore", player.Name,
 			fmt.Sprintf("🌀 %s detected an unstable wormhole in SYS-%d! It leads toward SYS-%d but is too dangerous to enter... for now",
 				ship.Name, ship.CurrentSystem+1, targetSys+1))
@@ -136,7 +133,7 @@ func (es *ExplorationSystem) discover(game GameProvider, player *entities.Player
 			}
 			for _, e := range sys.Entities {
 				if planet, ok := e.(*entities.Planet); ok && planet.Owner == player.Name {
-					planet.TechLevel += 0.3 + rand.Float64()*0.4
+					planet.TechLevel += 0.3 + es.Rand().Float64()*0.4
 					game.LogEvent("explore", player.Name,
 						fmt.Sprintf("💾 %s recovered an alien data cache! %s tech level boosted to %.1f",
 							ship.Name, planet.Name, planet.TechLevel))
@@ -147,10 +144,35 @@ func (es *ExplorationSystem) discover(game GameProvider, player *entities.Player
 		}
 
 	case 5: // Void Crystal — instant credits
-		credits := 5000 + rand.Intn(10000)
+		credits := 5000 + es.Rand().Intn(10000)
 		player.Credits += credits
 		game.LogEvent("explore", player.Name,
 			fmt.Sprintf("💎 %s found a Void Crystal worth %d credits!",
 				ship.Name, credits))
 	}
 }
+
+// explorationState is the persisted form of ExplorationSystem.
+type explorationState struct {
+	Explored map[int]bool
+}
+
+// Snapshot implements Snapshotter.
+func (es *ExplorationSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(explorationState{
+		Explored: es.explored,
+	})
+}
+
+// Restore implements Snapshotter.
+func (es *ExplorationSystem) Restore(data []byte) error {
+	var state explorationState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	es.explored = state.Explored
+	if es.explored == nil {
+		es.explored = make(map[int]bool)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ExtinctionEventSystem{
-		BaseSystem: NewBaseSystem("ExtinctionEvent", 166),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ExtinctionEventSystem{
+			BaseSystem: NewBaseSystem("ExtinctionEvent", 166),
+		}
 	})
 }
 
@@ -75,7 +76,7 @@ func (ees *ExtinctionEventSystem) OnTick(tick int64) {
 	}
 
 	// 0.05% chance per 10,000 ticks after 200K
-	if tick%10000 != 0 || rand.Intn(2000) != 0 {
+	if tick%10000 != 0 || ees.Rand().Intn(2000) != 0 {
 		return
 	}
 
@@ -190,3 +191,34 @@ func (ees *ExtinctionEventSystem) executeEvent(game GameProvider, ctx SystemCont
 	game.LogEvent("event", "",
 		"🌟 The galaxy survived an extinction event! +2000cr to all factions. Build defenses for the next one!")
 }
+
+// extinctionEventState is the persisted form of ExtinctionEventSystem.
+type extinctionEventState struct {
+	FiredGamma  bool
+	FiredPlague bool
+	WarningTick int64
+	EventType   string
+}
+
+// Snapshot implements Snapshotter.
+func (ees *ExtinctionEventSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(extinctionEventState{
+		FiredGamma:  ees.firedGamma,
+		FiredPlague: ees.firedPlague,
+		WarningTick: ees.warningTick,
+		EventType:   ees.eventType,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ees *ExtinctionEventSystem) Restore(data []byte) error {
+	var state extinctionEventState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ees.firedGamma = state.FiredGamma
+	ees.firedPlague = state.FiredPlague
+	ees.warningTick = state.WarningTick
+	ees.eventType = state.EventType
+	return nil
+}
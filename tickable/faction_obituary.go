@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&FactionObituarySystem{
-		BaseSystem: NewBaseSystem("FactionObituary", 164),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FactionObituarySystem{
+			BaseSystem: NewBaseSystem("FactionObituary", 164).Every(2000),
+		}
 	})
 }
 
@@ -33,10 +35,6 @@ type FactionObituarySystem struct {
 }
 
 func (fos *FactionObituarySystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := fos.GetContext()
 	if ctx == nil {
 		return
@@ -107,3 +105,34 @@ func (fos *FactionObituarySystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// factionObituaryState is the persisted form of FactionObituarySystem.
+type factionObituaryState struct {
+	Obituaries map[string]bool
+	Phoenix    map[string]bool
+}
+
+// Snapshot implements Snapshotter.
+func (fos *FactionObituarySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(factionObituaryState{
+		Obituaries: fos.obituaries,
+		Phoenix:    fos.phoenix,
+	})
+}
+
+// Restore implements Snapshotter.
+func (fos *FactionObituarySystem) Restore(data []byte) error {
+	var state factionObituaryState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	fos.obituaries = state.Obituaries
+	if fos.obituaries == nil {
+		fos.obituaries = make(map[string]bool)
+	}
+	fos.phoenix = state.Phoenix
+	if fos.phoenix == nil {
+		fos.phoenix = make(map[string]bool)
+	}
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&FactionPerkSystem{
-		BaseSystem: NewBaseSystem("FactionPerks", 8),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FactionPerkSystem{
+			BaseSystem: NewBaseSystem("FactionPerks", 8).Every(500),
+		}
 	})
 }
 
@@ -42,10 +44,6 @@ var factionPerks = map[string]struct {
 }
 
 func (fps *FactionPerkSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := fps.GetContext()
 	if ctx == nil {
 		return
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&FactionRivalrySystem{
-		BaseSystem: NewBaseSystem("FactionRivalry", 111),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FactionRivalrySystem{
+			BaseSystem: NewBaseSystem("FactionRivalry", 111).Every(3000),
+		}
 	})
 }
 
@@ -44,10 +45,6 @@ type Rivalry struct {
 }
 
 func (frs *FactionRivalrySystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := frs.GetContext()
 	if ctx == nil {
 		return
@@ -101,7 +98,7 @@ func (frs *FactionRivalrySystem) OnTick(tick int64) {
 		}
 
 		// Rivalry commentary
-		if r.Active && rand.Intn(5) == 0 {
+		if r.Active && frs.Rand().Intn(5) == 0 {
 			diff := math.Abs(float64(r.PowerA - r.PowerB))
 			pct := diff / float64(max(r.PowerA, r.PowerB)) * 100
 			if pct < 5 {
@@ -116,7 +113,7 @@ func (frs *FactionRivalrySystem) OnTick(tick int64) {
 	if tick < frs.nextCheck {
 		return
 	}
-	frs.nextCheck = tick + 15000 + int64(rand.Intn(10000))
+	frs.nextCheck = tick + 15000 + int64(frs.Rand().Intn(10000))
 
 	activeCount := 0
 	for _, r := range frs.rivalries {
@@ -201,3 +198,28 @@ func max(a, b int) int {
 	}
 	return b
 }
+
+// factionRivalryState is the persisted form of FactionRivalrySystem.
+type factionRivalryState struct {
+	Rivalries []*Rivalry
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (frs *FactionRivalrySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(factionRivalryState{
+		Rivalries: frs.rivalries,
+		NextCheck: frs.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (frs *FactionRivalrySystem) Restore(data []byte) error {
+	var state factionRivalryState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	frs.rivalries = state.Rivalries
+	frs.nextCheck = state.NextCheck
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&FactoryProductionSystem{
-		BaseSystem: NewBaseSystem("FactoryProduction", 16),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FactoryProductionSystem{
+			BaseSystem: NewBaseSystem("FactoryProduction", 16).Every(10),
+		}
 	})
 }
 
@@ -17,10 +19,6 @@ type FactoryProductionSystem struct {
 
 // OnTick processes factory production each tick.
 func (fps *FactoryProductionSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	context := fps.GetContext()
 	if context == nil {
 		return
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&FederationSystem{
-		BaseSystem: NewBaseSystem("Federations", 77),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FederationSystem{
+			BaseSystem: NewBaseSystem("Federations", 77).Every(2000),
+		}
 	})
 }
 
@@ -47,10 +48,6 @@ type Federation struct {
 }
 
 func (fs *FederationSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := fs.GetContext()
 	if ctx == nil {
 		return
@@ -67,7 +64,7 @@ func (fs *FederationSystem) OnTick(tick int64) {
 	}
 
 	if fs.nextCheck == 0 {
-		fs.nextCheck = tick + 10000 + int64(rand.Intn(10000))
+		fs.nextCheck = tick + 10000 + int64(fs.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
@@ -80,7 +77,7 @@ func (fs *FederationSystem) OnTick(tick int64) {
 
 	// Check for federation formation
 	if tick >= fs.nextCheck {
-		fs.nextCheck = tick + 15000 + int64(rand.Intn(10000))
+		fs.nextCheck = tick + 15000 + int64(fs.Rand().Intn(10000))
 		fs.checkFormation(tick, players, dm, game)
 	}
 }
@@ -201,3 +198,28 @@ func (fs *FederationSystem) IsFederationMember(faction string) bool {
 	}
 	return false
 }
+
+// federationState is the persisted form of FederationSystem.
+type federationState struct {
+	Federation *Federation
+	NextCheck  int64
+}
+
+// Snapshot implements Snapshotter.
+func (fs *FederationSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(federationState{
+		Federation: fs.federation,
+		NextCheck:  fs.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (fs *FederationSystem) Restore(data []byte) error {
+	var state federationState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	fs.federation = state.Federation
+	fs.nextCheck = state.NextCheck
+	return nil
+}
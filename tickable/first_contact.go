@@ -2,14 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&FirstContactSystem{
-		BaseSystem: NewBaseSystem("FirstContact", 170),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FirstContactSystem{
+			BaseSystem: NewBaseSystem("FirstContact", 170).Every(1000),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type FirstContactSystem struct {
 }
 
 func (fcs *FirstContactSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := fcs.GetContext()
 	if ctx == nil {
 		return
@@ -77,6 +75,7 @@ func (fcs *FirstContactSystem) OnTick(tick int64) {
 		for name := range factionsPresent {
 			factions = append(factions, name)
 		}
+		sort.Strings(factions)
 
 		for i := 0; i < len(factions); i++ {
 			for j := i + 1; j < len(factions); j++ {
@@ -104,6 +103,29 @@ func (fcs *FirstContactSystem) OnTick(tick int64) {
 			}
 		}
 	}
+}
+
+// firstContactState is the persisted form of FirstContactSystem.
+type firstContactState struct {
+	Contacted map[string]bool
+}
 
-	_ = rand.Intn
+// Snapshot implements Snapshotter.
+func (fcs *FirstContactSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(firstContactState{
+		Contacted: fcs.contacted,
+	})
+}
+
+// Restore implements Snapshotter.
+func (fcs *FirstContactSystem) Restore(data []byte) error {
+	var state firstContactState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	fcs.contacted = state.Contacted
+	if fcs.contacted == nil {
+		fcs.contacted = make(map[string]bool)
+	}
+	return nil
 }
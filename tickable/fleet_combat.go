@@ -2,14 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&FleetCombatSystem{
-		BaseSystem: NewBaseSystem("FleetCombat", 37),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FleetCombatSystem{
+			BaseSystem: NewBaseSystem("FleetCombat", 37).Every(200),
+		}
 	})
 }
 
@@ -33,11 +35,6 @@ type FleetCombatSystem struct {
 }
 
 func (fcs *FleetCombatSystem) OnTick(tick int64) {
-	// Combat resolves every 200 ticks (~20 seconds)
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := fcs.GetContext()
 	if ctx == nil {
 		return
@@ -103,6 +100,7 @@ func (fcs *FleetCombatSystem) resolveSystemCombat(sys *entities.System, players
 	for name := range fleets {
 		factionNames = append(factionNames, name)
 	}
+	sort.Strings(factionNames)
 
 	for i := 0; i < len(factionNames); i++ {
 		for j := i + 1; j < len(factionNames); j++ {
@@ -155,11 +153,11 @@ func (fcs *FleetCombatSystem) dealDamage(attacker, defender *factionFleet) {
 	}
 
 	// Add some randomness (80-120% of base damage)
-	totalDamage = int(float64(totalDamage) * (0.8 + rand.Float64()*0.4))
+	totalDamage = int(float64(totalDamage) * (0.8 + fcs.Rand().Float64()*0.4))
 
 	// Distribute damage across defender's ships
 	for totalDamage > 0 && len(defender.ships) > 0 {
-		target := defender.ships[rand.Intn(len(defender.ships))]
+		target := defender.ships[fcs.Rand().Intn(len(defender.ships))]
 		dmg := totalDamage
 		if dmg > target.AttackPower*2 {
 			dmg = target.AttackPower * 2 // don't overkill a single ship
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&FleetManagerSystem{
-		BaseSystem: NewBaseSystem("FleetManager", 52),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FleetManagerSystem{
+			BaseSystem: NewBaseSystem("FleetManager", 52).Every(2000),
+		}
 	})
 }
 
@@ -29,10 +31,6 @@ type FleetManagerSystem struct {
 }
 
 func (fms *FleetManagerSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := fms.GetContext()
 	if ctx == nil {
 		return
@@ -138,3 +136,28 @@ func (fms *FleetManagerSystem) analyzeFleet(tick int64, player *entities.Player,
 		game.LogEvent("logistics", player.Name, msg)
 	}
 }
+
+// fleetManagerState is the persisted form of FleetManagerSystem.
+type fleetManagerState struct {
+	LastReport map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (fms *FleetManagerSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(fleetManagerState{
+		LastReport: fms.lastReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (fms *FleetManagerSystem) Restore(data []byte) error {
+	var state fleetManagerState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	fms.lastReport = state.LastReport
+	if fms.lastReport == nil {
+		fms.lastReport = make(map[string]int64)
+	}
+	return nil
+}
@@ -2,13 +2,17 @@ package tickable
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&FleetRedistributionSystem{
-		BaseSystem: NewBaseSystem("FleetRedistribution", 126),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FleetRedistributionSystem{
+			BaseSystem: NewBaseSystem("FleetRedistribution", 126).Every(2000),
+		}
 	})
 }
 
@@ -28,10 +32,6 @@ type FleetRedistributionSystem struct {
 }
 
 func (frs *FleetRedistributionSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := frs.GetContext()
 	if ctx == nil {
 		return
@@ -70,8 +70,8 @@ func (frs *FleetRedistributionSystem) OnTick(tick int64) {
 		var crowdedSys int
 		var crowdedShips []*entities.Ship
 		maxShips := 0
-		for sysID, ships := range shipsBySystem {
-			if len(ships) > maxShips {
+		for _, sysID := range slices.Sorted(maps.Keys(shipsBySystem)) {
+			if ships := shipsBySystem[sysID]; len(ships) > maxShips {
 				maxShips = len(ships)
 				crowdedSys = sysID
 				crowdedShips = ships
@@ -94,7 +94,7 @@ func (frs *FleetRedistributionSystem) OnTick(tick int64) {
 
 		leastCrowdedSys := -1
 		leastCount := 999999
-		for sysID := range ownedSystems {
+		for _, sysID := range slices.Sorted(maps.Keys(ownedSystems)) {
 			if sysID == crowdedSys {
 				continue
 			}
@@ -158,3 +158,28 @@ func (frs *FleetRedistributionSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// fleetRedistributionState is the persisted form of FleetRedistributionSystem.
+type fleetRedistributionState struct {
+	LastRedist map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (frs *FleetRedistributionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(fleetRedistributionState{
+		LastRedist: frs.lastRedist,
+	})
+}
+
+// Restore implements Snapshotter.
+func (frs *FleetRedistributionSystem) Restore(data []byte) error {
+	var state fleetRedistributionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	frs.lastRedist = state.LastRedist
+	if frs.lastRedist == nil {
+		frs.lastRedist = make(map[string]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&FreightContractSystem{
-		BaseSystem: NewBaseSystem("FreightContracts", 66),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FreightContractSystem{
+			BaseSystem: NewBaseSystem("FreightContracts", 66).Every(500),
+		}
 	})
 }
 
@@ -55,10 +56,6 @@ type FreightContract struct {
 }
 
 func (fcs *FreightContractSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := fcs.GetContext()
 	if ctx == nil {
 		return
@@ -70,7 +67,7 @@ func (fcs *FreightContractSystem) OnTick(tick int64) {
 	}
 
 	if fcs.nextContract == 0 {
-		fcs.nextContract = tick + 2000 + int64(rand.Intn(3000))
+		fcs.nextContract = tick + 2000 + int64(fcs.Rand().Intn(3000))
 	}
 
 	players := ctx.GetPlayers()
@@ -110,7 +107,7 @@ func (fcs *FreightContractSystem) OnTick(tick int64) {
 
 	// Generate new contracts
 	if tick >= fcs.nextContract {
-		fcs.nextContract = tick + 5000 + int64(rand.Intn(8000))
+		fcs.nextContract = tick + 5000 + int64(fcs.Rand().Intn(8000))
 
 		activeCount := 0
 		for _, c := range fcs.contracts {
@@ -143,7 +140,7 @@ func (fcs *FreightContractSystem) checkClaim(tick int64, contract *FreightContra
 
 			contract.ClaimedBy = player.Name
 			player.Credits -= contract.Deposit
-			contract.Deadline = tick + 8000 + int64(rand.Intn(5000))
+			contract.Deadline = tick + 8000 + int64(fcs.Rand().Intn(5000))
 
 			game.LogEvent("logistics", player.Name,
 				fmt.Sprintf("📋 %s claimed freight contract: deliver %d %s from %s to %s. Reward: %dcr (deposit: %dcr)",
@@ -189,17 +186,17 @@ func (fcs *FreightContractSystem) generateContract(tick int64, game GameProvider
 	}
 
 	// Pick two different systems
-	a := rand.Intn(len(systems))
-	b := rand.Intn(len(systems))
+	a := fcs.Rand().Intn(len(systems))
+	b := fcs.Rand().Intn(len(systems))
 	for b == a {
-		b = rand.Intn(len(systems))
+		b = fcs.Rand().Intn(len(systems))
 	}
 
 	resources := []string{entities.ResIron, entities.ResWater, entities.ResOil,
 		entities.ResFuel, entities.ResRareMetals}
-	res := resources[rand.Intn(len(resources))]
+	res := resources[fcs.Rand().Intn(len(resources))]
 
-	qty := 50 + rand.Intn(250)
+	qty := 50 + fcs.Rand().Intn(250)
 	market := game.GetMarketEngine()
 	baseValue := 0
 	if market != nil {
@@ -208,7 +205,7 @@ func (fcs *FreightContractSystem) generateContract(tick int64, game GameProvider
 	if baseValue < 500 {
 		baseValue = 500
 	}
-	reward := baseValue*2 + rand.Intn(baseValue)
+	reward := baseValue*2 + fcs.Rand().Intn(baseValue)
 	deposit := reward / 4
 
 	contract := &FreightContract{
@@ -240,3 +237,28 @@ func (fcs *FreightContractSystem) GetActiveContracts() []*FreightContract {
 	}
 	return result
 }
+
+// freightContractState is the persisted form of FreightContractSystem.
+type freightContractState struct {
+	Contracts    []*FreightContract
+	NextContract int64
+}
+
+// Snapshot implements Snapshotter.
+func (fcs *FreightContractSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(freightContractState{
+		Contracts:    fcs.contracts,
+		NextContract: fcs.nextContract,
+	})
+}
+
+// Restore implements Snapshotter.
+func (fcs *FreightContractSystem) Restore(data []byte) error {
+	var state freightContractState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	fcs.contracts = state.Contracts
+	fcs.nextContract = state.NextContract
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&FuelReserveSystem{
-		BaseSystem: NewBaseSystem("FuelReserve", 11),
+	RegisterSystemFunc(func() TickableSystem {
+		return &FuelReserveSystem{
+			BaseSystem: NewBaseSystem("FuelReserve", 11).Every(50),
+		}
 	})
 }
 
@@ -32,10 +34,6 @@ type FuelReserveSystem struct {
 }
 
 func (frs *FuelReserveSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := frs.GetContext()
 	if ctx == nil {
 		return
@@ -107,3 +105,28 @@ func (frs *FuelReserveSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// fuelReserveState is the persisted form of FuelReserveSystem.
+type fuelReserveState struct {
+	LastWarning map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (frs *FuelReserveSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(fuelReserveState{
+		LastWarning: frs.lastWarning,
+	})
+}
+
+// Restore implements Snapshotter.
+func (frs *FuelReserveSystem) Restore(data []byte) error {
+	var state fuelReserveState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	frs.lastWarning = state.LastWarning
+	if frs.lastWarning == nil {
+		frs.lastWarning = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GalacticAwardsSystem{
-		BaseSystem: NewBaseSystem("GalacticAwards", 141),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticAwardsSystem{
+			BaseSystem: NewBaseSystem("GalacticAwards", 141),
+		}
 	})
 }
 
@@ -51,12 +54,12 @@ func (gas *GalacticAwardsSystem) OnTick(tick int64) {
 	}
 
 	if gas.nextCeremony == 0 {
-		gas.nextCeremony = tick + 10000 + int64(rand.Intn(5000))
+		gas.nextCeremony = tick + 10000 + int64(gas.Rand().Intn(5000))
 	}
 	if tick < gas.nextCeremony {
 		return
 	}
-	gas.nextCeremony = tick + 15000 + int64(rand.Intn(10000))
+	gas.nextCeremony = tick + 15000 + int64(gas.Rand().Intn(10000))
 
 	players := ctx.GetPlayers()
 	systems := game.GetSystems()
@@ -96,8 +99,8 @@ func (gas *GalacticAwardsSystem) OnTick(tick int64) {
 	}
 	bestHappy := ""
 	bestHappyScore := 0.0
-	for _, fh := range happyMap {
-		if fh.count > 0 {
+	for _, name := range slices.Sorted(maps.Keys(happyMap)) {
+		if fh := happyMap[name]; fh.count > 0 {
 			avg := fh.avgHappy / float64(fh.count)
 			if avg > bestHappyScore {
 				bestHappyScore = avg
@@ -160,3 +163,28 @@ func (gas *GalacticAwardsSystem) OnTick(tick int64) {
 		game.LogEvent("event", "", msg)
 	}
 }
+
+// galacticAwardsState is the persisted form of GalacticAwardsSystem.
+type galacticAwardsState struct {
+	NextCeremony int64
+	PrevCredits  map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (gas *GalacticAwardsSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(galacticAwardsState{
+		NextCeremony: gas.nextCeremony,
+		PrevCredits:  gas.prevCredits,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gas *GalacticAwardsSystem) Restore(data []byte) error {
+	var state galacticAwardsState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gas.nextCeremony = state.NextCeremony
+	gas.prevCredits = state.PrevCredits
+	return nil
+}
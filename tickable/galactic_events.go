@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&GalacticEventSystem{
-		BaseSystem: NewBaseSystem("GalacticEvents", 32),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticEventSystem{
+			BaseSystem: NewBaseSystem("GalacticEvents", 32),
+		}
 	})
 }
 
@@ -32,12 +34,12 @@ type GalacticEventSystem struct {
 
 func (ges *GalacticEventSystem) OnTick(tick int64) {
 	if ges.nextEvent == 0 {
-		ges.nextEvent = tick + 200 + int64(rand.Intn(500)) // first event soon after startup
+		ges.nextEvent = tick + 200 + int64(ges.Rand().Intn(500)) // first event soon after startup
 	}
 	if tick < ges.nextEvent {
 		return
 	}
-	ges.nextEvent = tick + 1500 + int64(rand.Intn(2000))
+	ges.nextEvent = tick + 1500 + int64(ges.Rand().Intn(2000))
 
 	ctx := ges.GetContext()
 	if ctx == nil {
@@ -53,7 +55,7 @@ func (ges *GalacticEventSystem) OnTick(tick int64) {
 	systems := game.GetSystems()
 
 	// Pick a random event
-	eventType := rand.Intn(7)
+	eventType := ges.Rand().Intn(7)
 	switch eventType {
 	case 0:
 		ges.resourceDiscovery(game, systems)
@@ -74,14 +76,14 @@ func (ges *GalacticEventSystem) OnTick(tick int64) {
 
 // resourceDiscovery: a random owned planet gains abundance on an existing deposit
 func (ges *GalacticEventSystem) resourceDiscovery(game GameProvider, systems []*entities.System) {
-	planet := randomOwnedPlanet(systems)
+	planet := randomOwnedPlanet(systems, ges.Rand())
 	if planet == nil || len(planet.Resources) == 0 {
 		return
 	}
 	// Boost a random resource's abundance
-	idx := rand.Intn(len(planet.Resources))
+	idx := ges.Rand().Intn(len(planet.Resources))
 	if res, ok := planet.Resources[idx].(*entities.Resource); ok {
-		bonus := 10 + rand.Intn(20)
+		bonus := 10 + ges.Rand().Intn(20)
 		res.Abundance += bonus
 		game.LogEvent("event", planet.Owner,
 			fmt.Sprintf("🔍 Resource discovery on %s! %s abundance increased by %d",
@@ -94,7 +96,7 @@ func (ges *GalacticEventSystem) solarFlare(game GameProvider, systems []*entitie
 	if len(systems) == 0 {
 		return
 	}
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[ges.Rand().Intn(len(systems))]
 	affected := 0
 	shielded := 0
 	for _, e := range sys.Entities {
@@ -140,11 +142,11 @@ func (ges *GalacticEventSystem) pirateRaid(game GameProvider, players []*entitie
 				continue
 			}
 			// 20% chance per loaded cargo ship
-			if rand.Intn(5) != 0 {
+			if ges.Rand().Intn(5) != 0 {
 				continue
 			}
 			// Lose 10-30% of cargo
-			lossRate := 0.1 + rand.Float64()*0.2
+			lossRate := 0.1 + ges.Rand().Float64()*0.2
 			for res, amt := range ship.CargoHold {
 				loss := int(float64(amt) * lossRate)
 				if loss > 0 {
@@ -164,7 +166,7 @@ func (ges *GalacticEventSystem) pirateRaid(game GameProvider, players []*entitie
 
 // populationBoom: a happy planet gets bonus population
 func (ges *GalacticEventSystem) populationBoom(game GameProvider, systems []*entities.System) {
-	planet := randomOwnedPlanet(systems)
+	planet := randomOwnedPlanet(systems, ges.Rand())
 	if planet == nil || planet.Happiness < 0.6 {
 		return // only happy planets get booms
 	}
@@ -172,7 +174,7 @@ func (ges *GalacticEventSystem) populationBoom(game GameProvider, systems []*ent
 	if cap <= 0 || planet.Population >= cap {
 		return
 	}
-	bonus := int64(500 + rand.Intn(2000))
+	bonus := int64(500 + ges.Rand().Intn(2000))
 	if planet.Population+bonus > cap {
 		bonus = cap - planet.Population
 	}
@@ -190,10 +192,10 @@ func (ges *GalacticEventSystem) tradeBoom(game GameProvider, players []*entities
 	}
 	resources := []string{entities.ResOil, entities.ResHelium3, entities.ResElectronics,
 		entities.ResRareMetals, entities.ResWater}
-	res := resources[rand.Intn(len(resources))]
+	res := resources[ges.Rand().Intn(len(resources))]
 
 	// Spike demand
-	market.AddTradeVolume(res, 500+rand.Intn(1000), true)
+	market.AddTradeVolume(res, 500+ges.Rand().Intn(1000), true)
 
 	// Reward factions who have this resource stocked — galactic buyers pay premium
 	price := market.GetSellPrice(res)
@@ -232,7 +234,7 @@ func (ges *GalacticEventSystem) tradeBoom(game GameProvider, players []*entities
 
 // asteroidImpact: a planet loses some stored resources (reduced by shield)
 func (ges *GalacticEventSystem) asteroidImpact(game GameProvider, systems []*entities.System) {
-	planet := randomOwnedPlanet(systems)
+	planet := randomOwnedPlanet(systems, ges.Rand())
 	if planet == nil {
 		return
 	}
@@ -248,14 +250,14 @@ func (ges *GalacticEventSystem) asteroidImpact(game GameProvider, systems []*ent
 
 	resources := []string{entities.ResIron, entities.ResWater, entities.ResOil,
 		entities.ResRareMetals}
-	res := resources[rand.Intn(len(resources))]
+	res := resources[ges.Rand().Intn(len(resources))]
 	stored := planet.GetStoredAmount(res)
 	if stored <= 10 {
 		return
 	}
 
 	// Base loss 20-40%, reduced 15% per shield level (L5 = 75% reduction)
-	baseLoss := 0.2 + rand.Float64()*0.2
+	baseLoss := 0.2 + ges.Rand().Float64()*0.2
 	shieldReduction := float64(shieldLevel) * 0.15
 	if shieldReduction > 0.90 {
 		shieldReduction = 0.90
@@ -294,15 +296,15 @@ func (ges *GalacticEventSystem) refugeeWave(game GameProvider, systems []*entiti
 	if len(candidates) == 0 {
 		return
 	}
-	planet := candidates[rand.Intn(len(candidates))]
-	pop := int64(1000 + rand.Intn(5000))
+	planet := candidates[ges.Rand().Intn(len(candidates))]
+	pop := int64(1000 + ges.Rand().Intn(5000))
 	planet.Population += pop
 	game.LogEvent("event", "",
 		fmt.Sprintf("🚀 Refugee wave! %d settlers arrived on unclaimed %s",
 			pop, planet.Name))
 }
 
-func randomOwnedPlanet(systems []*entities.System) *entities.Planet {
+func randomOwnedPlanet(systems []*entities.System, rng *rand.Rand) *entities.Planet {
 	var owned []*entities.Planet
 	for _, sys := range systems {
 		for _, e := range sys.Entities {
@@ -314,5 +316,27 @@ func randomOwnedPlanet(systems []*entities.System) *entities.Planet {
 	if len(owned) == 0 {
 		return nil
 	}
-	return owned[rand.Intn(len(owned))]
+	return owned[rng.Intn(len(owned))]
+}
+
+// galacticEventState is the persisted form of GalacticEventSystem.
+type galacticEventState struct {
+	NextEvent int64
+}
+
+// Snapshot implements Snapshotter.
+func (ges *GalacticEventSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(galacticEventState{
+		NextEvent: ges.nextEvent,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ges *GalacticEventSystem) Restore(data []byte) error {
+	var state galacticEventState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ges.nextEvent = state.NextEvent
+	return nil
 }
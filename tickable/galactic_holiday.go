@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GalacticHolidaySystem{
-		BaseSystem: NewBaseSystem("GalacticHoliday", 161),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticHolidaySystem{
+			BaseSystem: NewBaseSystem("GalacticHoliday", 161).Every(500),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type GalacticHolidaySystem struct {
 }
 
 func (ghs *GalacticHolidaySystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := ghs.GetContext()
 	if ctx == nil {
 		return
@@ -48,7 +45,7 @@ func (ghs *GalacticHolidaySystem) OnTick(tick int64) {
 	}
 
 	if ghs.nextHoliday == 0 {
-		ghs.nextHoliday = tick + 10000 + int64(rand.Intn(10000))
+		ghs.nextHoliday = tick + 10000 + int64(ghs.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
@@ -69,7 +66,7 @@ func (ghs *GalacticHolidaySystem) OnTick(tick int64) {
 
 	// Start new holiday
 	if tick >= ghs.nextHoliday {
-		ghs.nextHoliday = tick + 20000 + int64(rand.Intn(15000))
+		ghs.nextHoliday = tick + 20000 + int64(ghs.Rand().Intn(15000))
 		ghs.startHoliday(players, systems, game)
 	}
 }
@@ -87,7 +84,7 @@ func (ghs *GalacticHolidaySystem) startHoliday(players []*entities.Player, syste
 		{"Explorers Week", 3000, "doubled discovery chances"},
 	}
 
-	h := holidays[rand.Intn(len(holidays))]
+	h := holidays[ghs.Rand().Intn(len(holidays))]
 	ghs.activeHoliday = h.name
 	ghs.holidayTicks = h.duration
 
@@ -133,7 +130,7 @@ func (ghs *GalacticHolidaySystem) applyHolidayEffects(players []*entities.Player
 		}
 	case "Harvest Festival":
 		// Tiny resource bonus
-		if rand.Intn(5) == 0 {
+		if ghs.Rand().Intn(5) == 0 {
 			for _, sys := range systems {
 				for _, e := range sys.Entities {
 					if planet, ok := e.(*entities.Planet); ok && planet.Owner != "" {
@@ -155,3 +152,31 @@ func (ghs *GalacticHolidaySystem) applyHolidayEffects(players []*entities.Player
 		}
 	}
 }
+
+// galacticHolidayState is the persisted form of GalacticHolidaySystem.
+type galacticHolidayState struct {
+	NextHoliday   int64
+	ActiveHoliday string
+	HolidayTicks  int
+}
+
+// Snapshot implements Snapshotter.
+func (ghs *GalacticHolidaySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(galacticHolidayState{
+		NextHoliday:   ghs.nextHoliday,
+		ActiveHoliday: ghs.activeHoliday,
+		HolidayTicks:  ghs.holidayTicks,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ghs *GalacticHolidaySystem) Restore(data []byte) error {
+	var state galacticHolidayState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ghs.nextHoliday = state.NextHoliday
+	ghs.activeHoliday = state.ActiveHoliday
+	ghs.holidayTicks = state.HolidayTicks
+	return nil
+}
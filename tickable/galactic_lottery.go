@@ -2,13 +2,14 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 )
 
 func init() {
-	RegisterSystem(&GalacticLotterySystem{
-		BaseSystem: NewBaseSystem("GalacticLottery", 104),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticLotterySystem{
+			BaseSystem: NewBaseSystem("GalacticLottery", 104).Every(1000),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type GalacticLotterySystem struct {
 }
 
 func (gls *GalacticLotterySystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := gls.GetContext()
 	if ctx == nil {
 		return
@@ -48,7 +45,7 @@ func (gls *GalacticLotterySystem) OnTick(tick int64) {
 	}
 
 	if gls.nextDraw == 0 {
-		gls.nextDraw = tick + 8000 + int64(rand.Intn(5000))
+		gls.nextDraw = tick + 8000 + int64(gls.Rand().Intn(5000))
 	}
 
 	players := ctx.GetPlayers()
@@ -81,7 +78,7 @@ func (gls *GalacticLotterySystem) OnTick(tick int64) {
 
 	// Drawing
 	if tick >= gls.nextDraw {
-		gls.nextDraw = tick + 10000 + int64(rand.Intn(8000))
+		gls.nextDraw = tick + 10000 + int64(gls.Rand().Intn(8000))
 		gls.lotteryNum++
 
 		if len(gls.entrants) < 2 {
@@ -94,7 +91,7 @@ func (gls *GalacticLotterySystem) OnTick(tick int64) {
 		gls.pool += 500 // base prize addition
 
 		// Draw winner
-		winner := gls.entrants[rand.Intn(len(gls.entrants))]
+		winner := gls.entrants[gls.Rand().Intn(len(gls.entrants))]
 		prize := gls.pool
 
 		for _, p := range players {
@@ -114,7 +111,7 @@ func (gls *GalacticLotterySystem) OnTick(tick int64) {
 	}
 
 	// Announce when pool is building
-	if len(gls.entrants) > 0 && rand.Intn(5) == 0 {
+	if len(gls.entrants) > 0 && gls.Rand().Intn(5) == 0 {
 		ticksLeft := gls.nextDraw - tick
 		if ticksLeft > 0 {
 			game.LogEvent("event", "",
@@ -123,3 +120,34 @@ func (gls *GalacticLotterySystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// lotteryState is the persisted form of GalacticLotterySystem.
+type lotteryState struct {
+	Pool       int
+	Entrants   []string
+	NextDraw   int64
+	LotteryNum int
+}
+
+// Snapshot implements Snapshotter.
+func (gls *GalacticLotterySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(lotteryState{
+		Pool:       gls.pool,
+		Entrants:   gls.entrants,
+		NextDraw:   gls.nextDraw,
+		LotteryNum: gls.lotteryNum,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gls *GalacticLotterySystem) Restore(data []byte) error {
+	var state lotteryState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gls.pool = state.Pool
+	gls.entrants = state.Entrants
+	gls.nextDraw = state.NextDraw
+	gls.lotteryNum = state.LotteryNum
+	return nil
+}
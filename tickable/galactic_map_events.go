@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GalacticMapEventSystem{
-		BaseSystem: NewBaseSystem("GalacticMapEvents", 91),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticMapEventSystem{
+			BaseSystem: NewBaseSystem("GalacticMapEvents", 91),
+		}
 	})
 }
 
@@ -46,16 +47,16 @@ func (gmes *GalacticMapEventSystem) OnTick(tick int64) {
 	}
 
 	if gmes.nextEvent == 0 {
-		gmes.nextEvent = tick + 15000 + int64(rand.Intn(20000))
+		gmes.nextEvent = tick + 15000 + int64(gmes.Rand().Intn(20000))
 	}
 	if tick < gmes.nextEvent {
 		return
 	}
-	gmes.nextEvent = tick + 25000 + int64(rand.Intn(20000))
+	gmes.nextEvent = tick + 25000 + int64(gmes.Rand().Intn(20000))
 
 	systems := game.GetSystems()
 
-	eventType := rand.Intn(3)
+	eventType := gmes.Rand().Intn(3)
 	switch eventType {
 	case 0:
 		gmes.stellarNursery(systems, game)
@@ -71,7 +72,7 @@ func (gmes *GalacticMapEventSystem) stellarNursery(systems []*entities.System, g
 		return
 	}
 
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[gmes.Rand().Intn(len(systems))]
 	boosted := 0
 
 	for _, e := range sys.Entities {
@@ -101,9 +102,31 @@ func (gmes *GalacticMapEventSystem) roguePlanetArrival(systems []*entities.Syste
 		return
 	}
 
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[gmes.Rand().Intn(len(systems))]
 
 	game.LogEvent("event", "",
 		fmt.Sprintf("🌍 ROGUE PLANET detected entering %s! A wandering world with unknown resources has been captured by the star's gravity. Send a Colony Ship to claim it!",
 			sys.Name))
 }
+
+// galacticMapEventState is the persisted form of GalacticMapEventSystem.
+type galacticMapEventState struct {
+	NextEvent int64
+}
+
+// Snapshot implements Snapshotter.
+func (gmes *GalacticMapEventSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(galacticMapEventState{
+		NextEvent: gmes.nextEvent,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gmes *GalacticMapEventSystem) Restore(data []byte) error {
+	var state galacticMapEventState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gmes.nextEvent = state.NextEvent
+	return nil
+}
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GalacticStockExchangeSystem{
-		BaseSystem: NewBaseSystem("GalacticStockExchange", 154),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticStockExchangeSystem{
+			BaseSystem: NewBaseSystem("GalacticStockExchange", 154).Every(3000),
+		}
 	})
 }
 
@@ -35,10 +36,6 @@ type GalacticStockExchangeSystem struct {
 }
 
 func (gses *GalacticStockExchangeSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := gses.GetContext()
 	if ctx == nil {
 		return
@@ -111,7 +108,7 @@ func (gses *GalacticStockExchangeSystem) OnTick(tick int64) {
 	if tick < gses.nextTick {
 		return
 	}
-	gses.nextTick = tick + 10000 + int64(rand.Intn(5000))
+	gses.nextTick = tick + 10000 + int64(gses.Rand().Intn(5000))
 
 	for _, investor := range players {
 		if investor == nil || investor.Credits < 50000 {
@@ -149,7 +146,7 @@ func (gses *GalacticStockExchangeSystem) OnTick(tick int64) {
 			shares := float64(investment) / price
 			gses.holdings[investor.Name][target.Name] += shares
 
-			if rand.Intn(3) == 0 {
+			if gses.Rand().Intn(3) == 0 {
 				game.LogEvent("trade", investor.Name,
 					fmt.Sprintf("📈 %s bought %.1f shares of %s stock for %dcr (price: %.0f)",
 						investor.Name, shares, target.Name, investment, price))
@@ -159,7 +156,7 @@ func (gses *GalacticStockExchangeSystem) OnTick(tick int64) {
 	}
 
 	// Report top stocks
-	if rand.Intn(5) == 0 {
+	if gses.Rand().Intn(5) == 0 {
 		bestStock := ""
 		bestPrice := 0.0
 		for name, price := range gses.stockPrices {
@@ -177,3 +174,37 @@ func (gses *GalacticStockExchangeSystem) OnTick(tick int64) {
 
 	_ = math.Abs
 }
+
+// stockExchangeState is the persisted form of GalacticStockExchangeSystem.
+type stockExchangeState struct {
+	StockPrices map[string]float64
+	Holdings    map[string]map[string]float64
+	NextTick    int64
+}
+
+// Snapshot implements Snapshotter.
+func (gses *GalacticStockExchangeSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(stockExchangeState{
+		StockPrices: gses.stockPrices,
+		Holdings:    gses.holdings,
+		NextTick:    gses.nextTick,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gses *GalacticStockExchangeSystem) Restore(data []byte) error {
+	var state stockExchangeState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gses.stockPrices = state.StockPrices
+	gses.holdings = state.Holdings
+	gses.nextTick = state.NextTick
+	if gses.stockPrices == nil {
+		gses.stockPrices = make(map[string]float64)
+	}
+	if gses.holdings == nil {
+		gses.holdings = make(map[string]map[string]float64)
+	}
+	return nil
+}
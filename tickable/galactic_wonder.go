@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GalacticWonderSystem{
-		BaseSystem: NewBaseSystem("GalacticWonder", 169),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalacticWonderSystem{
+			BaseSystem: NewBaseSystem("GalacticWonder", 169).Every(2000),
+		}
 	})
 }
 
@@ -52,10 +53,6 @@ var wonderDefs = []struct {
 }
 
 func (gws *GalacticWonderSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := gws.GetContext()
 	if ctx == nil {
 		return
@@ -67,7 +64,7 @@ func (gws *GalacticWonderSystem) OnTick(tick int64) {
 	}
 
 	if gws.nextSpawn == 0 {
-		gws.nextSpawn = tick + 15000 + int64(rand.Intn(15000))
+		gws.nextSpawn = tick + 15000 + int64(gws.Rand().Intn(15000))
 	}
 
 	systems := game.GetSystems()
@@ -97,13 +94,13 @@ func (gws *GalacticWonderSystem) OnTick(tick int64) {
 				case "Dark Star":
 					planet.PowerGenerated += 5 // small per-tick contribution
 				case "Living Asteroid":
-					if rand.Intn(10) == 0 {
+					if gws.Rand().Intn(10) == 0 {
 						planet.AddStoredResource(entities.ResRareMetals, 1)
 					}
 				case "Singing Void":
 					planet.TechLevel += 0.001
 				case "Crystal Nebula":
-					if rand.Intn(10) == 0 {
+					if gws.Rand().Intn(10) == 0 {
 						planet.AddStoredResource(entities.ResElectronics, 1)
 					}
 				}
@@ -114,7 +111,7 @@ func (gws *GalacticWonderSystem) OnTick(tick int64) {
 
 	// Spawn new wonder
 	if tick >= gws.nextSpawn {
-		gws.nextSpawn = tick + 30000 + int64(rand.Intn(20000))
+		gws.nextSpawn = tick + 30000 + int64(gws.Rand().Intn(20000))
 
 		// Pick an unspawned wonder
 		spawned := make(map[string]bool)
@@ -132,8 +129,8 @@ func (gws *GalacticWonderSystem) OnTick(tick int64) {
 			return
 		}
 
-		def := available[rand.Intn(len(available))]
-		sys := systems[rand.Intn(len(systems))]
+		def := available[gws.Rand().Intn(len(available))]
+		sys := systems[gws.Rand().Intn(len(systems))]
 
 		gws.wonders = append(gws.wonders, &GalacticNaturalWonder{
 			Name:     def.name,
@@ -148,3 +145,28 @@ func (gws *GalacticWonderSystem) OnTick(tick int64) {
 				def.name, sys.Name, def.effect))
 	}
 }
+
+// galacticWonderState is the persisted form of GalacticWonderSystem.
+type galacticWonderState struct {
+	Wonders   []*GalacticNaturalWonder
+	NextSpawn int64
+}
+
+// Snapshot implements Snapshotter.
+func (gws *GalacticWonderSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(galacticWonderState{
+		Wonders:   gws.wonders,
+		NextSpawn: gws.nextSpawn,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gws *GalacticWonderSystem) Restore(data []byte) error {
+	var state galacticWonderState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gws.wonders = state.Wonders
+	gws.nextSpawn = state.NextSpawn
+	return nil
+}
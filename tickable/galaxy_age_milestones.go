@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&GalaxyAgeMilestoneSystem{
-		BaseSystem: NewBaseSystem("GalaxyAgeMilestones", 144),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GalaxyAgeMilestoneSystem{
+			BaseSystem: NewBaseSystem("GalaxyAgeMilestones", 144).Every(1000),
+		}
 	})
 }
 
@@ -42,10 +44,6 @@ var ageMilestones = []struct {
 }
 
 func (gams *GalaxyAgeMilestoneSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := gams.GetContext()
 	if ctx == nil {
 		return
@@ -77,3 +75,28 @@ func (gams *GalaxyAgeMilestoneSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// galaxyAgeMilestoneState is the persisted form of GalaxyAgeMilestoneSystem.
+type galaxyAgeMilestoneState struct {
+	Announced map[int64]bool
+}
+
+// Snapshot implements Snapshotter.
+func (gams *GalaxyAgeMilestoneSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(galaxyAgeMilestoneState{
+		Announced: gams.announced,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gams *GalaxyAgeMilestoneSystem) Restore(data []byte) error {
+	var state galaxyAgeMilestoneState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gams.announced = state.Announced
+	if gams.announced == nil {
+		gams.announced = make(map[int64]bool)
+	}
+	return nil
+}
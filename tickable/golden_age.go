@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GoldenAgeSystem{
-		BaseSystem: NewBaseSystem("GoldenAge", 60),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GoldenAgeSystem{
+			BaseSystem: NewBaseSystem("GoldenAge", 60).Every(500),
+		}
 	})
 }
 
@@ -41,10 +42,6 @@ type GoldenAgeSystem struct {
 }
 
 func (gas *GoldenAgeSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := gas.GetContext()
 	if ctx == nil {
 		return
@@ -153,7 +150,7 @@ func (gas *GoldenAgeSystem) applyBonuses(planet *entities.Planet, players []*ent
 	// Population growth bonus
 	cap := planet.GetTotalPopulationCapacity()
 	if cap > 0 && planet.Population < cap {
-		bonus := int64(200 + rand.Intn(300))
+		bonus := int64(200 + gas.Rand().Intn(300))
 		if planet.Population+bonus > cap {
 			bonus = cap - planet.Population
 		}
@@ -161,7 +158,7 @@ func (gas *GoldenAgeSystem) applyBonuses(planet *entities.Planet, players []*ent
 	}
 
 	// Slow tech growth
-	if rand.Intn(10) == 0 {
+	if gas.Rand().Intn(10) == 0 {
 		planet.TechLevel += 0.02
 	}
 }
@@ -173,3 +170,40 @@ func (gas *GoldenAgeSystem) IsGoldenAge(planetID int) bool {
 	}
 	return gas.activeAges[planetID]
 }
+
+// goldenAgeState is the persisted form of GoldenAgeSystem.
+type goldenAgeState struct {
+	Candidates map[int]int64
+	ActiveAges map[int]bool
+	FactionAge map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (gas *GoldenAgeSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(goldenAgeState{
+		Candidates: gas.candidates,
+		ActiveAges: gas.activeAges,
+		FactionAge: gas.factionAge,
+	})
+}
+
+// Restore implements Snapshotter.
+func (gas *GoldenAgeSystem) Restore(data []byte) error {
+	var state goldenAgeState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	gas.candidates = state.Candidates
+	if gas.candidates == nil {
+		gas.candidates = make(map[int]int64)
+	}
+	gas.activeAges = state.ActiveAges
+	if gas.activeAges == nil {
+		gas.activeAges = make(map[int]bool)
+	}
+	gas.factionAge = state.FactionAge
+	if gas.factionAge == nil {
+		gas.factionAge = make(map[string]int)
+	}
+	return nil
+}
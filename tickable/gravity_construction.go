@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&GravityConstructionSystem{
-		BaseSystem: NewBaseSystem("GravityConstruction", 18),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GravityConstructionSystem{
+			BaseSystem: NewBaseSystem("GravityConstruction", 18).Every(2000),
+		}
 	})
 }
 
@@ -36,10 +37,6 @@ type GravityConstructionSystem struct {
 }
 
 func (gcs *GravityConstructionSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := gcs.GetContext()
 	if ctx == nil {
 		return
@@ -64,7 +61,7 @@ func (gcs *GravityConstructionSystem) OnTick(tick int64) {
 			g := planet.Gravity
 
 			// Only announce notable gravity effects occasionally
-			if rand.Intn(20) != 0 {
+			if gcs.Rand().Intn(20) != 0 {
 				continue
 			}
 
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&GravityEffectsSystem{
-		BaseSystem: NewBaseSystem("GravityEffects", 5),
+	RegisterSystemFunc(func() TickableSystem {
+		return &GravityEffectsSystem{
+			BaseSystem: NewBaseSystem("GravityEffects", 5).Every(50),
+		}
 	})
 }
 
@@ -39,10 +41,6 @@ type GravityEffectsSystem struct {
 }
 
 func (ges *GravityEffectsSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := ges.GetContext()
 	if ctx == nil {
 		return
@@ -71,7 +69,7 @@ func (ges *GravityEffectsSystem) OnTick(tick int64) {
 
 func applyCompositionBonus(planet *entities.Planet) {
 	// Only apply bonus every ~500 ticks (called every 50, so 1/10 chance)
-	// This is handled by the caller's tick%50 check — we add small amounts
+	// The caller is scheduled every 50 ticks — we add small amounts
 
 	comp := planet.Comp
 
@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&HappinessSystem{
-		BaseSystem: NewBaseSystem("Happiness", 8),
+	RegisterSystemFunc(func() TickableSystem {
+		return &HappinessSystem{
+			BaseSystem: NewBaseSystem("Happiness", 8).Every(10).At(0),
+		}
 	})
 }
 
@@ -31,10 +33,6 @@ type HappinessSystem struct {
 }
 
 func (hs *HappinessSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := hs.GetContext()
 	if ctx == nil {
 		return
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&HappinessInterventionSystem{
-		BaseSystem: NewBaseSystem("HappinessIntervention", 133),
+	RegisterSystemFunc(func() TickableSystem {
+		return &HappinessInterventionSystem{
+			BaseSystem: NewBaseSystem("HappinessIntervention", 133).Every(500),
+		}
 	})
 }
 
@@ -32,10 +34,6 @@ type HappinessInterventionSystem struct {
 }
 
 func (his *HappinessInterventionSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := his.GetContext()
 	if ctx == nil {
 		return
@@ -114,3 +112,28 @@ func (his *HappinessInterventionSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// happinessInterventionState is the persisted form of HappinessInterventionSystem.
+type happinessInterventionState struct {
+	LastIntervention map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (his *HappinessInterventionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(happinessInterventionState{
+		LastIntervention: his.lastIntervention,
+	})
+}
+
+// Restore implements Snapshotter.
+func (his *HappinessInterventionSystem) Restore(data []byte) error {
+	var state happinessInterventionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	his.lastIntervention = state.LastIntervention
+	if his.lastIntervention == nil {
+		his.lastIntervention = make(map[int]int64)
+	}
+	return nil
+}
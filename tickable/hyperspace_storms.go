@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&HyperspaceStormSystem{
-		BaseSystem: NewBaseSystem("HyperspaceStorms", 19),
+	RegisterSystemFunc(func() TickableSystem {
+		return &HyperspaceStormSystem{
+			BaseSystem: NewBaseSystem("HyperspaceStorms", 19).Every(100),
+		}
 	})
 }
 
@@ -46,10 +47,6 @@ type HyperspaceStorm struct {
 }
 
 func (hss *HyperspaceStormSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := hss.GetContext()
 	if ctx == nil {
 		return
@@ -61,7 +58,7 @@ func (hss *HyperspaceStormSystem) OnTick(tick int64) {
 	}
 
 	if hss.nextStorm == 0 {
-		hss.nextStorm = tick + 3000 + int64(rand.Intn(5000))
+		hss.nextStorm = tick + 3000 + int64(hss.Rand().Intn(5000))
 	}
 
 	players := ctx.GetPlayers()
@@ -92,7 +89,7 @@ func (hss *HyperspaceStormSystem) OnTick(tick int64) {
 
 	// Spawn new storm
 	if tick >= hss.nextStorm && len(hyperlanes) > 0 {
-		hss.nextStorm = tick + 10000 + int64(rand.Intn(10000))
+		hss.nextStorm = tick + 10000 + int64(hss.Rand().Intn(10000))
 
 		// Max 2 active storms
 		activeCount := 0
@@ -147,7 +144,7 @@ func (hss *HyperspaceStormSystem) applyStormEffects(storm *HyperspaceStorm, play
 }
 
 func (hss *HyperspaceStormSystem) spawnStorm(game GameProvider, systems []*entities.System, hyperlanes []entities.Hyperlane) {
-	lane := hyperlanes[rand.Intn(len(hyperlanes))]
+	lane := hyperlanes[hss.Rand().Intn(len(hyperlanes))]
 	sysMap := game.GetSystemsMap()
 
 	nameA := fmt.Sprintf("SYS-%d", lane.From+1)
@@ -160,8 +157,8 @@ func (hss *HyperspaceStormSystem) spawnStorm(game GameProvider, systems []*entit
 	}
 
 	stormTypes := []string{"ion", "gravity", "radiation"}
-	stormType := stormTypes[rand.Intn(len(stormTypes))]
-	duration := 3000 + rand.Intn(5000)
+	stormType := stormTypes[hss.Rand().Intn(len(stormTypes))]
+	duration := 3000 + hss.Rand().Intn(5000)
 
 	storm := &HyperspaceStorm{
 		SystemA:   lane.From,
@@ -214,3 +211,28 @@ func (hss *HyperspaceStormSystem) IsStormActive(sysA, sysB int) bool {
 	}
 	return false
 }
+
+// hyperspaceStormState is the persisted form of HyperspaceStormSystem.
+type hyperspaceStormState struct {
+	Storms    []*HyperspaceStorm
+	NextStorm int64
+}
+
+// Snapshot implements Snapshotter.
+func (hss *HyperspaceStormSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(hyperspaceStormState{
+		Storms:    hss.storms,
+		NextStorm: hss.nextStorm,
+	})
+}
+
+// Restore implements Snapshotter.
+func (hss *HyperspaceStormSystem) Restore(data []byte) error {
+	var state hyperspaceStormState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	hss.storms = state.Storms
+	hss.nextStorm = state.NextStorm
+	return nil
+}
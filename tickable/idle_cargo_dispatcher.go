@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&IdleCargoDispatcherSystem{
-		BaseSystem: NewBaseSystem("IdleCargoDispatcher", 28),
+	RegisterSystemFunc(func() TickableSystem {
+		return &IdleCargoDispatcherSystem{
+			BaseSystem: NewBaseSystem("IdleCargoDispatcher", 28).Every(100),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type IdleCargoDispatcherSystem struct {
 }
 
 func (icds *IdleCargoDispatcherSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := icds.GetContext()
 	if ctx == nil {
 		return
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&IdleShipRecallSystem{
-		BaseSystem: NewBaseSystem("IdleShipRecall", 31),
+	RegisterSystemFunc(func() TickableSystem {
+		return &IdleShipRecallSystem{
+			BaseSystem: NewBaseSystem("IdleShipRecall", 31).Every(100),
+		}
 	})
 }
 
@@ -24,10 +26,6 @@ type IdleShipRecallSystem struct {
 }
 
 func (isrs *IdleShipRecallSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := isrs.GetContext()
 	if ctx == nil {
 		return
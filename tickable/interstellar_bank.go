@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&InterstellarBankSystem{
-		BaseSystem: NewBaseSystem("InterstellarBank", 94),
+	RegisterSystemFunc(func() TickableSystem {
+		return &InterstellarBankSystem{
+			BaseSystem: NewBaseSystem("InterstellarBank", 94).Every(1000),
+		}
 	})
 }
 
@@ -39,10 +40,6 @@ type Loan struct {
 }
 
 func (ibs *InterstellarBankSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := ibs.GetContext()
 	if ctx == nil {
 		return
@@ -98,7 +95,7 @@ func (ibs *InterstellarBankSystem) OnTick(tick int64) {
 			}
 		} else if player.Credits < 5000 && player.Credits > 0 {
 			// Offer loan
-			if rand.Intn(5) == 0 { // don't spam
+			if ibs.Rand().Intn(5) == 0 { // don't spam
 				loanAmount := 10000
 				ibs.loans[player.Name] = &Loan{
 					Principal:   loanAmount,
@@ -118,9 +115,9 @@ func (ibs *InterstellarBankSystem) OnTick(tick int64) {
 		ibs.nextUpdate = tick + 20000
 	}
 	if tick >= ibs.nextUpdate {
-		ibs.nextUpdate = tick + 20000 + int64(rand.Intn(10000))
+		ibs.nextUpdate = tick + 20000 + int64(ibs.Rand().Intn(10000))
 		// Fluctuate rates slightly
-		ibs.interestRate = 0.0005 + rand.Float64()*0.001
+		ibs.interestRate = 0.0005 + ibs.Rand().Float64()*0.001
 		game.LogEvent("intel", "",
 			fmt.Sprintf("🏦 Interstellar Bank rate update: savings %.2f%% per interval. Deposits over 100K earn passive income!",
 				ibs.interestRate*100))
@@ -137,3 +134,31 @@ func (ibs *InterstellarBankSystem) GetDebt(faction string) int {
 	}
 	return 0
 }
+
+// interstellarBankState is the persisted form of InterstellarBankSystem.
+type interstellarBankState struct {
+	Loans        map[string]*Loan
+	InterestRate float64
+	NextUpdate   int64
+}
+
+// Snapshot implements Snapshotter.
+func (ibs *InterstellarBankSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(interstellarBankState{
+		Loans:        ibs.loans,
+		InterestRate: ibs.interestRate,
+		NextUpdate:   ibs.nextUpdate,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ibs *InterstellarBankSystem) Restore(data []byte) error {
+	var state interstellarBankState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ibs.loans = state.Loans
+	ibs.interestRate = state.InterestRate
+	ibs.nextUpdate = state.NextUpdate
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&InvestmentFundSystem{
-		BaseSystem: NewBaseSystem("InvestmentFund", 108),
+	RegisterSystemFunc(func() TickableSystem {
+		return &InvestmentFundSystem{
+			BaseSystem: NewBaseSystem("InvestmentFund", 108).Every(1000),
+		}
 	})
 }
 
@@ -44,10 +45,6 @@ type Investment struct {
 }
 
 func (ifs *InvestmentFundSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := ifs.GetContext()
 	if ctx == nil {
 		return
@@ -122,7 +119,7 @@ func (ifs *InvestmentFundSystem) OnTick(tick int64) {
 	if tick < ifs.nextCheck {
 		return
 	}
-	ifs.nextCheck = tick + 15000 + int64(rand.Intn(10000))
+	ifs.nextCheck = tick + 15000 + int64(ifs.Rand().Intn(10000))
 
 	// Find wealthy factions to invest
 	for _, investor := range players {
@@ -177,3 +174,28 @@ func (ifs *InvestmentFundSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// investmentFundState is the persisted form of InvestmentFundSystem.
+type investmentFundState struct {
+	Investments []*Investment
+	NextCheck   int64
+}
+
+// Snapshot implements Snapshotter.
+func (ifs *InvestmentFundSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(investmentFundState{
+		Investments: ifs.investments,
+		NextCheck:   ifs.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ifs *InvestmentFundSystem) Restore(data []byte) error {
+	var state investmentFundState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ifs.investments = state.Investments
+	ifs.nextCheck = state.NextCheck
+	return nil
+}
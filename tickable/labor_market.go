@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&LaborMarketSystem{
-		BaseSystem: NewBaseSystem("LaborMarket", 118),
+	RegisterSystemFunc(func() TickableSystem {
+		return &LaborMarketSystem{
+			BaseSystem: NewBaseSystem("LaborMarket", 118).Every(1000),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type LaborMarketSystem struct {
 }
 
 func (lms *LaborMarketSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := lms.GetContext()
 	if ctx == nil {
 		return
@@ -100,7 +97,7 @@ func (lms *LaborMarketSystem) OnTick(tick int64) {
 				}
 
 				// Transfer workers
-				transfer := int64(100 + rand.Intn(200))
+				transfer := int64(100 + lms.Rand().Intn(200))
 				if transfer > src.Population/10 {
 					transfer = src.Population / 10
 				}
@@ -116,7 +113,7 @@ func (lms *LaborMarketSystem) OnTick(tick int64) {
 				src.Population -= transfer
 				dst.Population += transfer
 
-				if rand.Intn(5) == 0 {
+				if lms.Rand().Intn(5) == 0 {
 					game.LogEvent("logistics", src.Owner,
 						fmt.Sprintf("👷 %d workers relocated from %s to %s (internal labor rebalancing)",
 							transfer, src.Name, dst.Name))
@@ -126,3 +123,28 @@ func (lms *LaborMarketSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// laborMarketState is the persisted form of LaborMarketSystem.
+type laborMarketState struct {
+	LastAlert map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (lms *LaborMarketSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(laborMarketState{
+		LastAlert: lms.lastAlert,
+	})
+}
+
+// Restore implements Snapshotter.
+func (lms *LaborMarketSystem) Restore(data []byte) error {
+	var state laborMarketState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	lms.lastAlert = state.LastAlert
+	if lms.lastAlert == nil {
+		lms.lastAlert = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&LastStandSystem{
-		BaseSystem: NewBaseSystem("LastStand", 171),
+	RegisterSystemFunc(func() TickableSystem {
+		return &LastStandSystem{
+			BaseSystem: NewBaseSystem("LastStand", 171).Every(2000),
+		}
 	})
 }
 
@@ -30,10 +31,6 @@ type LastStandSystem struct {
 }
 
 func (lss *LastStandSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := lss.GetContext()
 	if ctx == nil {
 		return
@@ -78,7 +75,7 @@ func (lss *LastStandSystem) OnTick(tick int64) {
 			}
 
 			// Small production bonus (extra resources)
-			if rand.Intn(3) == 0 {
+			if lss.Rand().Intn(3) == 0 {
 				for _, re := range planet.Resources {
 					if r, ok := re.(*entities.Resource); ok && r.Abundance > 0 {
 						planet.AddStoredResource(r.ResourceType, 2)
@@ -101,3 +98,28 @@ func (lss *LastStandSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// lastStandState is the persisted form of LastStandSystem.
+type lastStandState struct {
+	LastStanders map[string]bool
+}
+
+// Snapshot implements Snapshotter.
+func (lss *LastStandSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(lastStandState{
+		LastStanders: lss.lastStanders,
+	})
+}
+
+// Restore implements Snapshotter.
+func (lss *LastStandSystem) Restore(data []byte) error {
+	var state lastStandState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	lss.lastStanders = state.LastStanders
+	if lss.lastStanders == nil {
+		lss.lastStanders = make(map[string]bool)
+	}
+	return nil
+}
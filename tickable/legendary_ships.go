@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&LegendaryShipSystem{
-		BaseSystem: NewBaseSystem("LegendaryShips", 44),
+	RegisterSystemFunc(func() TickableSystem {
+		return &LegendaryShipSystem{
+			BaseSystem: NewBaseSystem("LegendaryShips", 44),
+		}
 	})
 }
 
@@ -50,12 +51,12 @@ var legendaries = []legendaryDef{
 
 func (lss *LegendaryShipSystem) OnTick(tick int64) {
 	if lss.nextCheck == 0 {
-		lss.nextCheck = tick + 2000 + int64(rand.Intn(3000)) // first check sooner
+		lss.nextCheck = tick + 2000 + int64(lss.Rand().Intn(3000)) // first check sooner
 	}
 	if tick < lss.nextCheck {
 		return
 	}
-	lss.nextCheck = tick + 15000 + int64(rand.Intn(15000))
+	lss.nextCheck = tick + 15000 + int64(lss.Rand().Intn(15000))
 
 	ctx := lss.GetContext()
 	if ctx == nil {
@@ -85,8 +86,8 @@ func (lss *LegendaryShipSystem) OnTick(tick int64) {
 		return // all legendaries already spawned
 	}
 
-	legend := available[rand.Intn(len(available))]
-	targetSys := systems[rand.Intn(len(systems))]
+	legend := available[lss.Rand().Intn(len(available))]
+	targetSys := systems[lss.Rand().Intn(len(systems))]
 
 	// Check if any player has a ship in this system to claim it
 	var claimer *entities.Player
@@ -120,7 +121,7 @@ func (lss *LegendaryShipSystem) OnTick(tick int64) {
 	// Instant claim!
 	lss.spawned[legend.name] = true
 	ship := entities.NewShip(
-		rand.Intn(900000000)+100000000,
+		lss.Rand().Intn(900000000)+100000000,
 		legend.name,
 		legend.shipType,
 		targetSys.ID,
@@ -141,3 +142,31 @@ func (lss *LegendaryShipSystem) OnTick(tick int64) {
 		fmt.Sprintf("⭐ %s claimed the legendary %s in %s! \"%s\"",
 			claimer.Name, legend.name, targetSys.Name, legend.desc))
 }
+
+// legendaryShipState is the persisted form of LegendaryShipSystem.
+type legendaryShipState struct {
+	Spawned   map[string]bool
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (lss *LegendaryShipSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(legendaryShipState{
+		Spawned:   lss.spawned,
+		NextCheck: lss.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (lss *LegendaryShipSystem) Restore(data []byte) error {
+	var state legendaryShipState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	lss.spawned = state.Spawned
+	if lss.spawned == nil {
+		lss.spawned = make(map[string]bool)
+	}
+	lss.nextCheck = state.NextCheck
+	return nil
+}
@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&LocalExchangeSystem{
-		BaseSystem: NewBaseSystem("LocalExchange", 26),
+	RegisterSystemFunc(func() TickableSystem {
+		return &LocalExchangeSystem{
+			BaseSystem: NewBaseSystem("LocalExchange", 26).Every(100),
+		}
 	})
 }
 
@@ -31,11 +33,6 @@ type LocalExchangeSystem struct {
 }
 
 func (les *LocalExchangeSystem) OnTick(tick int64) {
-	// Run every 100 ticks (~10 seconds)
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := les.GetContext()
 	if ctx == nil {
 		return
@@ -56,7 +53,7 @@ func (les *LocalExchangeSystem) OnTick(tick int64) {
 
 	// For each system, find planets with TPs and match surplus to shortage
 	for _, sys := range systems {
-		les.processSystem(sys, players, market, game)
+		les.processSystem(tick, sys, players, market, game)
 	}
 }
 
@@ -66,7 +63,7 @@ type planetInfo struct {
 	tpLevel int
 }
 
-func (les *LocalExchangeSystem) processSystem(sys *entities.System, players []*entities.Player, market *economy.Market, game GameProvider) {
+func (les *LocalExchangeSystem) processSystem(tick int64, sys *entities.System, players []*entities.Player, market *economy.Market, game GameProvider) {
 	// Find all owned planets with Trading Posts in this system
 	var planets []planetInfo
 	for _, e := range sys.Entities {
@@ -178,6 +175,9 @@ func (les *LocalExchangeSystem) processSystem(sys *entities.System, players []*e
 
 				// Bump market trade volume
 				market.AddTradeVolume(res, qty, false)
+				if candles := game.GetCandleHistory(); candles != nil {
+					candles.AddVolume(tick, sys.ID, res, qty)
+				}
 
 				surplus -= qty
 				if surplus <= 0 {
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&LogisticsBottleneckSystem{
-		BaseSystem: NewBaseSystem("LogisticsBottleneck", 167),
+	RegisterSystemFunc(func() TickableSystem {
+		return &LogisticsBottleneckSystem{
+			BaseSystem: NewBaseSystem("LogisticsBottleneck", 167),
+		}
 	})
 }
 
@@ -41,12 +42,12 @@ func (lbs *LogisticsBottleneckSystem) OnTick(tick int64) {
 	}
 
 	if lbs.nextCheck == 0 {
-		lbs.nextCheck = tick + 5000 + int64(rand.Intn(5000))
+		lbs.nextCheck = tick + 5000 + int64(lbs.Rand().Intn(5000))
 	}
 	if tick < lbs.nextCheck {
 		return
 	}
-	lbs.nextCheck = tick + 8000 + int64(rand.Intn(5000))
+	lbs.nextCheck = tick + 8000 + int64(lbs.Rand().Intn(5000))
 
 	players := ctx.GetPlayers()
 	systems := game.GetSystems()
@@ -111,7 +112,7 @@ func (lbs *LogisticsBottleneckSystem) OnTick(tick int64) {
 			bottleneck = "🔧 #1 bottleneck: ROUTES NOT COMPLETING. Check source planet stock and ship fuel!"
 		default:
 			// Healthy
-			if rand.Intn(5) == 0 {
+			if lbs.Rand().Intn(5) == 0 {
 				bottleneck = fmt.Sprintf("✅ Logistics healthy: %d cargo ships, %d TPs, %d routes (%d trips)",
 					cargoShips, tpCount, factionRoutes, factionTrips)
 			}
@@ -123,3 +124,25 @@ func (lbs *LogisticsBottleneckSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// logisticsBottleneckState is the persisted form of LogisticsBottleneckSystem.
+type logisticsBottleneckState struct {
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (lbs *LogisticsBottleneckSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(logisticsBottleneckState{
+		NextCheck: lbs.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (lbs *LogisticsBottleneckSystem) Restore(data []byte) error {
+	var state logisticsBottleneckState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	lbs.nextCheck = state.NextCheck
+	return nil
+}
@@ -5,36 +5,35 @@ import (
 )
 
 func init() {
-	RegisterSystem(&LossPreventionSystem{
-		BaseSystem: NewBaseSystem("LossPrevention", 22),
+	RegisterSystemFunc(func() TickableSystem {
+		return &LossPreventionSystem{
+			BaseSystem: NewBaseSystem("LossPrevention", 22).Every(1000),
+		}
 	})
 }
 
-// LossPreventionSystem directly intercepts the auto-order system
-// by checking if a faction's recent trades are net-negative. If a
-// faction has lost more credits from trading than earned in the
-// last 5000 ticks, their auto-trading is suspended.
+// LossPreventionSystem suspends a faction's auto-trading when its trades
+// are losing money. Every 1000 ticks it reads each faction's realized P&L
+// over the last 5000 ticks from the ledger (sale proceeds less the FIFO
+// cost of what was sold); a faction that has lost more than 5000 credits
+// has its resting orders cleared and auto-trading suspended for 5000 ticks.
 //
 // This is more aggressive than the trade guard (which only blocks
 // individual resources). This blocks ALL auto-trading for the faction
 // until they're profitable again.
 //
-// Works by tracking credit snapshots: if credits dropped AND the
-// faction has active standing orders or auto-orders, suspend them.
-//
 // Priority 22: runs before smart auto trade (23), standing orders
 // (25), and auto orders (29).
 type LossPreventionSystem struct {
 	*BaseSystem
-	creditHistory map[string][]int // playerName → last 3 credit snapshots
-	suspended     map[string]int64 // playerName → suspend until tick
+	suspended map[string]int64 // playerName → suspend until tick
 }
 
-func (lps *LossPreventionSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
+// lossWindow is how far back realized trading losses are counted, and
+// how long a suspension lasts.
+const lossWindow = 5000
 
+func (lps *LossPreventionSystem) OnTick(tick int64) {
 	ctx := lps.GetContext()
 	if ctx == nil {
 		return
@@ -45,62 +44,38 @@ func (lps *LossPreventionSystem) OnTick(tick int64) {
 		return
 	}
 
-	if lps.creditHistory == nil {
-		lps.creditHistory = make(map[string][]int)
-		lps.suspended = make(map[string]int64)
+	ledger := game.GetLedger()
+	if ledger == nil {
+		return
 	}
 
-	players := ctx.GetPlayers()
+	if lps.suspended == nil {
+		lps.suspended = make(map[string]int64)
+	}
 
-	for _, p := range players {
-		if p == nil {
+	for _, p := range ctx.GetPlayers() {
+		if p == nil || tick < lps.suspended[p.Name] {
 			continue
 		}
 
-		// Track credits
-		lps.creditHistory[p.Name] = append(lps.creditHistory[p.Name], p.Credits)
-		if len(lps.creditHistory[p.Name]) > 5 {
-			lps.creditHistory[p.Name] = lps.creditHistory[p.Name][1:]
-		}
-
-		history := lps.creditHistory[p.Name]
-		if len(history) < 3 {
+		loss := -ledger.RealizedSince(p.Name, tick-lossWindow)
+		if loss <= 5000 {
 			continue
 		}
 
-		// Check if credits declining over last 3 snapshots
-		allDecline := true
-		totalLoss := 0
-		for i := 1; i < len(history); i++ {
-			if history[i] >= history[i-1] {
-				allDecline = false
-				break
-			}
-			totalLoss += history[i-1] - history[i]
-		}
+		lps.suspended[p.Name] = tick + lossWindow
 
-		// If faction is losing credits consistently AND has > some minimum,
-		// it might be from bad trades. Suspend auto-trading temporarily.
-		if allDecline && totalLoss > 5000 && p.Credits > 5000 {
-			// Check if already suspended
-			if tick < lps.suspended[p.Name] {
-				continue
+		// Clear their auto-orders
+		ob := game.GetOrderBook()
+		if ob != nil {
+			for _, sys := range game.GetSystems() {
+				ob.ClearPlayerOrders(p.Name, sys.ID)
 			}
-
-			lps.suspended[p.Name] = tick + 5000
-
-			// Clear their auto-orders
-			ob := game.GetOrderBook()
-			if ob != nil {
-				for _, sys := range game.GetSystems() {
-					ob.ClearPlayerOrders(p.Name, sys.ID)
-				}
-			}
-
-			game.LogEvent("alert", p.Name,
-				fmt.Sprintf("🛑 Loss prevention: %s auto-trading suspended for 5000 ticks! Lost %dcr over last intervals. Orders cleared. Manual trades still allowed.",
-					p.Name, totalLoss))
 		}
+
+		game.LogEvent("alert", p.Name,
+			fmt.Sprintf("🛑 Loss prevention: %s auto-trading suspended for %d ticks! Realized %dcr of trading losses over the last %d ticks. Orders cleared. Manual trades still allowed.",
+				p.Name, lossWindow, loss, lossWindow))
 	}
 }
 
@@ -111,3 +86,28 @@ func (lps *LossPreventionSystem) IsSuspended(playerName string, tick int64) bool
 	}
 	return tick < lps.suspended[playerName]
 }
+
+// lossPreventionState is the persisted form of LossPreventionSystem.
+type lossPreventionState struct {
+	Suspended map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (lps *LossPreventionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(lossPreventionState{
+		Suspended: lps.suspended,
+	})
+}
+
+// Restore implements Snapshotter.
+func (lps *LossPreventionSystem) Restore(data []byte) error {
+	var state lossPreventionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	lps.suspended = state.Suspended
+	if lps.suspended == nil {
+		lps.suspended = make(map[string]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MarketCrashSystem{
-		BaseSystem: NewBaseSystem("MarketCrash", 78),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MarketCrashSystem{
+			BaseSystem: NewBaseSystem("MarketCrash", 78).Every(1000),
+		}
 	})
 }
 
@@ -44,10 +45,6 @@ type MarketCrashSystem struct {
 }
 
 func (mcs *MarketCrashSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := mcs.GetContext()
 	if ctx == nil {
 		return
@@ -146,10 +143,10 @@ func (mcs *MarketCrashSystem) OnTick(tick int64) {
 func (mcs *MarketCrashSystem) triggerCrash(tick int64, reason string, players []*entities.Player, game GameProvider) {
 	mcs.lastCrash = tick
 	mcs.crashActive = true
-	mcs.crashTicksLeft = 5000 + rand.Intn(3000)
+	mcs.crashTicksLeft = 5000 + mcs.Rand().Intn(3000)
 
 	// Crash effect: 10-30% credit loss for all factions
-	lossRate := 0.10 + rand.Float64()*0.20
+	lossRate := 0.10 + mcs.Rand().Float64()*0.20
 	for _, p := range players {
 		if p == nil {
 			continue
@@ -180,3 +177,40 @@ func (mcs *MarketCrashSystem) triggerCrash(tick int64, reason string, players []
 func (mcs *MarketCrashSystem) IsCrashActive() bool {
 	return mcs.crashActive
 }
+
+// marketCrashState is the persisted form of MarketCrashSystem.
+type marketCrashState struct {
+	LastCrash      int64
+	CrashActive    bool
+	CrashTicksLeft int
+	CreditSnapshot map[string]int
+	NextSnapshot   int64
+}
+
+// Snapshot implements Snapshotter.
+func (mcs *MarketCrashSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(marketCrashState{
+		LastCrash:      mcs.lastCrash,
+		CrashActive:    mcs.crashActive,
+		CrashTicksLeft: mcs.crashTicksLeft,
+		CreditSnapshot: mcs.creditSnapshot,
+		NextSnapshot:   mcs.nextSnapshot,
+	})
+}
+
+// Restore implements Snapshotter.
+func (mcs *MarketCrashSystem) Restore(data []byte) error {
+	var state marketCrashState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	mcs.lastCrash = state.LastCrash
+	mcs.crashActive = state.CrashActive
+	mcs.crashTicksLeft = state.CrashTicksLeft
+	mcs.creditSnapshot = state.CreditSnapshot
+	if mcs.creditSnapshot == nil {
+		mcs.creditSnapshot = make(map[string]int)
+	}
+	mcs.nextSnapshot = state.NextSnapshot
+	return nil
+}
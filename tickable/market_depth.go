@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MarketDepthSystem{
-		BaseSystem: NewBaseSystem("MarketDepth", 204),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MarketDepthSystem{
+			BaseSystem: NewBaseSystem("MarketDepth", 204),
+		}
 	})
 }
 
@@ -45,12 +46,12 @@ func (mds *MarketDepthSystem) OnTick(tick int64) {
 	}
 
 	if mds.nextReport == 0 {
-		mds.nextReport = tick + 5000 + int64(rand.Intn(5000))
+		mds.nextReport = tick + 5000 + int64(mds.Rand().Intn(5000))
 	}
 	if tick < mds.nextReport {
 		return
 	}
-	mds.nextReport = tick + 8000 + int64(rand.Intn(5000))
+	mds.nextReport = tick + 8000 + int64(mds.Rand().Intn(5000))
 
 	systems := game.GetSystems()
 
@@ -98,3 +99,25 @@ func (mds *MarketDepthSystem) OnTick(tick int64) {
 		game.LogEvent("intel", "", msg)
 	}
 }
+
+// marketDepthState is the persisted form of MarketDepthSystem.
+type marketDepthState struct {
+	NextReport int64
+}
+
+// Snapshot implements Snapshotter.
+func (mds *MarketDepthSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(marketDepthState{
+		NextReport: mds.nextReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (mds *MarketDepthSystem) Restore(data []byte) error {
+	var state marketDepthState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	mds.nextReport = state.NextReport
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&MarketMakerSystem{
-		BaseSystem: NewBaseSystem("MarketMaker", 27),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MarketMakerSystem{
+			BaseSystem: NewBaseSystem("MarketMaker", 27).Every(200),
+		}
 	})
 }
 
@@ -48,10 +50,6 @@ var conversions = []conversion{
 }
 
 func (mms *MarketMakerSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := mms.GetContext()
 	if ctx == nil {
 		return
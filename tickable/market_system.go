@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&MarketSystem{
-		BaseSystem: NewBaseSystem("Market", 25),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MarketSystem{
+			BaseSystem: NewBaseSystem("Market", 25),
+		}
 	})
 }
 
@@ -2,15 +2,18 @@ package tickable
 
 import (
 	"fmt"
+	"maps"
 	"math"
-	"math/rand"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MarketVolatilitySystem{
-		BaseSystem: NewBaseSystem("MarketVolatility", 123),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MarketVolatilitySystem{
+			BaseSystem: NewBaseSystem("MarketVolatility", 123).Every(1000),
+		}
 	})
 }
 
@@ -47,10 +50,6 @@ var resourceVolatility = map[string]float64{
 }
 
 func (mvs *MarketVolatilitySystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := mvs.GetContext()
 	if ctx == nil {
 		return
@@ -79,16 +78,18 @@ func (mvs *MarketVolatilitySystem) OnTick(tick int64) {
 
 	// Shift factors periodically
 	if tick >= mvs.nextShift {
-		mvs.nextShift = tick + 2000 + int64(rand.Intn(1000))
+		mvs.nextShift = tick + 2000 + int64(mvs.Rand().Intn(1000))
 
 		// Announce significant shifts
 		var bigMoves []string
 
-		for res, vol := range resourceVolatility {
+		// In name order, so a seeded game draws the same noise per resource
+		for _, res := range slices.Sorted(maps.Keys(resourceVolatility)) {
+			vol := resourceVolatility[res]
 			// Random walk: current factor drifts toward 1.0 with noise
 			current := mvs.factors[res]
 			drift := (1.0 - current) * 0.3 // mean reversion
-			noise := (rand.Float64()*2 - 1) * vol
+			noise := (mvs.Rand().Float64()*2 - 1) * vol
 			newFactor := current + drift + noise
 			newFactor = math.Max(1.0-vol*1.5, math.Min(1.0+vol*1.5, newFactor))
 			mvs.factors[res] = newFactor
@@ -112,7 +113,7 @@ func (mvs *MarketVolatilitySystem) OnTick(tick int64) {
 		}
 
 		// Announce big market moves
-		if len(bigMoves) > 0 && rand.Intn(3) == 0 {
+		if len(bigMoves) > 0 && mvs.Rand().Intn(3) == 0 {
 			msg := "📊 Market moves: "
 			for _, m := range bigMoves {
 				msg += m + " "
@@ -121,3 +122,28 @@ func (mvs *MarketVolatilitySystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// marketVolatilityState is the persisted form of MarketVolatilitySystem.
+type marketVolatilityState struct {
+	Factors   map[string]float64
+	NextShift int64
+}
+
+// Snapshot implements Snapshotter.
+func (mvs *MarketVolatilitySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(marketVolatilityState{
+		Factors:   mvs.factors,
+		NextShift: mvs.nextShift,
+	})
+}
+
+// Restore implements Snapshotter.
+func (mvs *MarketVolatilitySystem) Restore(data []byte) error {
+	var state marketVolatilityState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	mvs.factors = state.Factors
+	mvs.nextShift = state.NextShift
+	return nil
+}
@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&MegaprojectSystem{
-		BaseSystem: NewBaseSystem("Megaprojects", 79),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MegaprojectSystem{
+			BaseSystem: NewBaseSystem("Megaprojects", 79).Every(2000),
+		}
 	})
 }
 
@@ -58,10 +59,6 @@ var megaprojectDefs = []struct {
 }
 
 func (ms *MegaprojectSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := ms.GetContext()
 	if ctx == nil {
 		return
@@ -73,7 +70,7 @@ func (ms *MegaprojectSystem) OnTick(tick int64) {
 	}
 
 	if ms.nextCheck == 0 {
-		ms.nextCheck = tick + 10000 + int64(rand.Intn(10000))
+		ms.nextCheck = tick + 10000 + int64(ms.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
@@ -128,7 +125,7 @@ func (ms *MegaprojectSystem) OnTick(tick int64) {
 		}
 
 		// Progress update every 10 intervals
-		if project.Active && project.Funded > 0 && rand.Intn(10) == 0 {
+		if project.Active && project.Funded > 0 && ms.Rand().Intn(10) == 0 {
 			pct := float64(project.Funded) / float64(project.TotalCost) * 100
 			game.LogEvent("event", "",
 				fmt.Sprintf("🏗️ %s: %.0f%% funded (%d/%d credits). All factions can contribute!",
@@ -138,7 +135,7 @@ func (ms *MegaprojectSystem) OnTick(tick int64) {
 
 	// Launch new megaproject
 	if tick >= ms.nextCheck {
-		ms.nextCheck = tick + 30000 + int64(rand.Intn(20000))
+		ms.nextCheck = tick + 30000 + int64(ms.Rand().Intn(20000))
 
 		// Check if any project is already active
 		for _, p := range ms.projects {
@@ -165,7 +162,7 @@ func (ms *MegaprojectSystem) OnTick(tick int64) {
 			return
 		}
 
-		def := available[rand.Intn(len(available))]
+		def := available[ms.Rand().Intn(len(available))]
 		project := &Megaproject{
 			Name:         def.name,
 			Description:  def.desc,
@@ -192,3 +189,28 @@ func (ms *MegaprojectSystem) GetActiveProject() *Megaproject {
 	}
 	return nil
 }
+
+// megaprojectState is the persisted form of MegaprojectSystem.
+type megaprojectState struct {
+	Projects  []*Megaproject
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (ms *MegaprojectSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(megaprojectState{
+		Projects:  ms.projects,
+		NextCheck: ms.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ms *MegaprojectSystem) Restore(data []byte) error {
+	var state megaprojectState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ms.projects = state.Projects
+	ms.nextCheck = state.NextCheck
+	return nil
+}
@@ -4,13 +4,16 @@ import (
 	"fmt"
 	"image/color"
 	"math/rand"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MercenarySystem{
-		BaseSystem: NewBaseSystem("Mercenaries", 43),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MercenarySystem{
+			BaseSystem: NewBaseSystem("Mercenaries", 43).Every(100),
+		}
 	})
 }
 
@@ -31,13 +34,12 @@ type mercContract struct {
 	owner     string
 	systemID  int
 	ships     []*entities.Ship
+	shipIDs   []int // from a save, until the ships are found in the owner's fleet
 	ticksLeft int
 }
 
 func (ms *MercenarySystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
+	ms.relinkShips()
 
 	// Decay active contracts
 	for i := len(ms.contracts) - 1; i >= 0; i-- {
@@ -66,19 +68,19 @@ func (ms *MercenarySystem) HireMercenaries(player *entities.Player, mercType str
 	switch mercType {
 	case "escort":
 		cost = 2000
-		ships = append(ships, createMercShip(entities.ShipTypeFrigate, player.Name, systemID))
+		ships = append(ships, createMercShip(entities.ShipTypeFrigate, player.Name, systemID, ms.Rand()))
 	case "strike_force":
 		cost = 5000
 		ships = append(ships,
-			createMercShip(entities.ShipTypeFrigate, player.Name, systemID),
-			createMercShip(entities.ShipTypeFrigate, player.Name, systemID))
+			createMercShip(entities.ShipTypeFrigate, player.Name, systemID, ms.Rand()),
+			createMercShip(entities.ShipTypeFrigate, player.Name, systemID, ms.Rand()))
 	case "armada":
 		cost = 15000
 		duration = 5000
 		ships = append(ships,
-			createMercShip(entities.ShipTypeDestroyer, player.Name, systemID),
-			createMercShip(entities.ShipTypeFrigate, player.Name, systemID),
-			createMercShip(entities.ShipTypeFrigate, player.Name, systemID))
+			createMercShip(entities.ShipTypeDestroyer, player.Name, systemID, ms.Rand()),
+			createMercShip(entities.ShipTypeFrigate, player.Name, systemID, ms.Rand()),
+			createMercShip(entities.ShipTypeFrigate, player.Name, systemID, ms.Rand()))
 	default:
 		return 0, fmt.Errorf("unknown merc type (use escort, strike_force, or armada)")
 	}
@@ -107,11 +109,86 @@ func (ms *MercenarySystem) HireMercenaries(player *entities.Player, mercType str
 	return cost, nil
 }
 
-func createMercShip(shipType entities.ShipType, owner string, systemID int) *entities.Ship {
-	id := rand.Intn(900000000) + 100000000
+func createMercShip(shipType entities.ShipType, owner string, systemID int, rng *rand.Rand) *entities.Ship {
+	id := rng.Intn(900000000) + 100000000
 	name := fmt.Sprintf("Merc %s %d", shipType, id%1000)
 	ship := entities.NewShip(id, name, shipType, systemID, owner,
 		color.RGBA{255, 100, 100, 255}) // red for mercenaries
 	ship.Status = entities.ShipStatusOrbiting
 	return ship
 }
+
+// relinkShips finds the ships of contracts restored from a save. The ships
+// themselves are saved with their owner's fleet.
+func (ms *MercenarySystem) relinkShips() {
+	ctx := ms.GetContext()
+	if ctx == nil {
+		return
+	}
+	for i := range ms.contracts {
+		c := &ms.contracts[i]
+		if c.shipIDs == nil {
+			continue
+		}
+		for _, p := range ctx.GetPlayers() {
+			if p == nil || p.Name != c.owner {
+				continue
+			}
+			for _, ship := range p.OwnedShips {
+				if ship != nil && slices.Contains(c.shipIDs, ship.GetID()) {
+					c.ships = append(c.ships, ship)
+				}
+			}
+		}
+		c.shipIDs = nil
+	}
+}
+
+// mercContractState is the persisted form of a mercContract.
+type mercContractState struct {
+	Owner     string
+	SystemID  int
+	ShipIDs   []int
+	TicksLeft int
+}
+
+// mercenaryState is the persisted form of MercenarySystem.
+type mercenaryState struct {
+	Contracts []mercContractState
+}
+
+// Snapshot implements Snapshotter.
+func (ms *MercenarySystem) Snapshot() ([]byte, error) {
+	var state mercenaryState
+	for _, c := range ms.contracts {
+		ids := c.shipIDs
+		for _, ship := range c.ships {
+			ids = append(ids, ship.GetID())
+		}
+		state.Contracts = append(state.Contracts, mercContractState{
+			Owner:     c.owner,
+			SystemID:  c.systemID,
+			ShipIDs:   ids,
+			TicksLeft: c.ticksLeft,
+		})
+	}
+	return encodeSnapshot(state)
+}
+
+// Restore implements Snapshotter. Ships are linked back up on the next tick.
+func (ms *MercenarySystem) Restore(data []byte) error {
+	var state mercenaryState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ms.contracts = nil
+	for _, c := range state.Contracts {
+		ms.contracts = append(ms.contracts, mercContract{
+			owner:     c.Owner,
+			systemID:  c.SystemID,
+			shipIDs:   append([]int{}, c.ShipIDs...),
+			ticksLeft: c.TicksLeft,
+		})
+	}
+	return nil
+}
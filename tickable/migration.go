@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&MigrationSystem{
-		BaseSystem: NewBaseSystem("Migration", 12),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MigrationSystem{
+			BaseSystem: NewBaseSystem("Migration", 12).Every(100),
+		}
 	})
 }
 
@@ -29,11 +31,6 @@ type MigrationSystem struct {
 }
 
 func (ms *MigrationSystem) OnTick(tick int64) {
-	// Run every 100 ticks (~10 seconds)
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := ms.GetContext()
 	if ctx == nil {
 		return
@@ -2,14 +2,18 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
+	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MilitarySystem{
-		BaseSystem: NewBaseSystem("Military", 35),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MilitarySystem{
+			BaseSystem: NewBaseSystem("Military", 35).Every(100),
+		}
 	})
 }
 
@@ -24,11 +28,6 @@ type MilitarySystem struct {
 }
 
 func (ms *MilitarySystem) OnTick(tick int64) {
-	// Run every 100 ticks (~10 seconds)
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := ms.GetContext()
 	if ctx == nil {
 		return
@@ -49,7 +48,7 @@ func (ms *MilitarySystem) OnTick(tick int64) {
 	}
 
 	// Piracy events on undefended systems (affects all cargo ships)
-	if tick%500 == 0 {
+	if ms.DueEvery(tick, 500) {
 		ms.processPiracyRisk(players, game, tick)
 	}
 }
@@ -80,6 +79,7 @@ func (ms *MilitarySystem) processPlayerMilitary(player *entities.Player, game Ga
 	for id := range ownedSystems {
 		systemIDs = append(systemIDs, id)
 	}
+	sort.Ints(systemIDs)
 	if len(systemIDs) < 2 {
 		return
 	}
@@ -106,9 +106,9 @@ func (ms *MilitarySystem) processPlayerMilitary(player *entities.Player, game Ga
 		}
 
 		// Pick a random owned system that isn't this one
-		target := systemIDs[rand.Intn(len(systemIDs))]
+		target := systemIDs[ms.Rand().Intn(len(systemIDs))]
 		if target == ship.CurrentSystem {
-			target = systemIDs[(rand.Intn(len(systemIDs)-1)+1)%len(systemIDs)]
+			target = systemIDs[(ms.Rand().Intn(len(systemIDs)-1)+1)%len(systemIDs)]
 			if target == ship.CurrentSystem && len(systemIDs) > 1 {
 				// Just pick the first one that isn't current
 				for _, id := range systemIDs {
@@ -172,25 +172,29 @@ func (ms *MilitarySystem) processPiracyRisk(players []*entities.Player, game Gam
 			if chance <= 0 {
 				continue
 			}
-			if rand.Intn(100) >= chance {
+			if ms.Rand().Intn(100) >= chance {
 				continue // lucky this time
 			}
 
 			// Pirates steal 10-30% of one random cargo type
-			for resType, amount := range ship.CargoHold {
-				if amount <= 0 {
-					continue
+			var held []string
+			for _, resType := range slices.Sorted(maps.Keys(ship.CargoHold)) {
+				if ship.CargoHold[resType] > 0 {
+					held = append(held, resType)
 				}
-				stolen := amount * (10 + rand.Intn(20)) / 100
-				if stolen < 1 {
-					stolen = 1
-				}
-				ship.RemoveCargo(resType, stolen)
-				game.LogEvent("alert", player.Name,
-					fmt.Sprintf("Pirates raided %s at SYS-%d! Lost %d %s",
-						ship.Name, ship.CurrentSystem, stolen, resType))
-				break // only steal one resource type per raid
 			}
+			if len(held) == 0 {
+				continue
+			}
+			resType := held[ms.Rand().Intn(len(held))]
+			stolen := ship.CargoHold[resType] * (10 + ms.Rand().Intn(20)) / 100
+			if stolen < 1 {
+				stolen = 1
+			}
+			ship.RemoveCargo(resType, stolen)
+			game.LogEvent("alert", player.Name,
+				fmt.Sprintf("Pirates raided %s at SYS-%d! Lost %d %s",
+					ship.Name, ship.CurrentSystem, stolen, resType))
 		}
 	}
 }
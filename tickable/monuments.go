@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MonumentSystem{
-		BaseSystem: NewBaseSystem("Monuments", 62),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MonumentSystem{
+			BaseSystem: NewBaseSystem("Monuments", 62).Every(5000),
+		}
 	})
 }
 
@@ -52,10 +53,6 @@ var monuments = []monumentDef{
 }
 
 func (ms *MonumentSystem) OnTick(tick int64) {
-	if tick%5000 != 0 {
-		return
-	}
-
 	ctx := ms.GetContext()
 	if ctx == nil {
 		return
@@ -165,7 +162,7 @@ func (ms *MonumentSystem) applyBonuses(players []*entities.Player, systems []*en
 				for _, sys := range systems {
 					for _, e := range sys.Entities {
 						if planet, ok := e.(*entities.Planet); ok && planet.Owner == player.Name {
-							if planet.TechLevel < 5.0 && rand.Intn(100) == 0 {
+							if planet.TechLevel < 5.0 && ms.Rand().Intn(100) == 0 {
 								planet.TechLevel += 0.01
 							}
 						}
@@ -201,3 +198,31 @@ func (ms *MonumentSystem) GetBuiltMonuments() map[string]string {
 	}
 	return result
 }
+
+// monumentState is the persisted form of MonumentSystem.
+type monumentState struct {
+	Built     map[string]string
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (ms *MonumentSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(monumentState{
+		Built:     ms.built,
+		NextCheck: ms.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ms *MonumentSystem) Restore(data []byte) error {
+	var state monumentState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ms.built = state.Built
+	if ms.built == nil {
+		ms.built = make(map[string]string)
+	}
+	ms.nextCheck = state.NextCheck
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MostWantedSystem{
-		BaseSystem: NewBaseSystem("MostWanted", 205),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MostWantedSystem{
+			BaseSystem: NewBaseSystem("MostWanted", 205),
+		}
 	})
 }
 
@@ -41,12 +42,12 @@ func (mws *MostWantedSystem) OnTick(tick int64) {
 	}
 
 	if mws.nextUpdate == 0 {
-		mws.nextUpdate = tick + 3000 + int64(rand.Intn(3000))
+		mws.nextUpdate = tick + 3000 + int64(mws.Rand().Intn(3000))
 	}
 	if tick < mws.nextUpdate {
 		return
 	}
-	mws.nextUpdate = tick + 6000 + int64(rand.Intn(4000))
+	mws.nextUpdate = tick + 6000 + int64(mws.Rand().Intn(4000))
 
 	systems := game.GetSystems()
 	market := game.GetMarketEngine()
@@ -98,3 +99,28 @@ func (mws *MostWantedSystem) OnTick(tick int64) {
 		fmt.Sprintf("🎯 MOST WANTED: %s! Only %d units galaxy-wide, %d planets in need. Current price: %.0fcr. Produce and profit!",
 			scarcest, scarcestTotal, needCount, price))
 }
+
+// mostWantedState is the persisted form of MostWantedSystem.
+type mostWantedState struct {
+	CurrentWanted string
+	NextUpdate    int64
+}
+
+// Snapshot implements Snapshotter.
+func (mws *MostWantedSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(mostWantedState{
+		CurrentWanted: mws.currentWanted,
+		NextUpdate:    mws.nextUpdate,
+	})
+}
+
+// Restore implements Snapshotter.
+func (mws *MostWantedSystem) Restore(data []byte) error {
+	var state mostWantedState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	mws.currentWanted = state.CurrentWanted
+	mws.nextUpdate = state.NextUpdate
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&MysterySignalSystem{
-		BaseSystem: NewBaseSystem("MysterySignal", 153),
+	RegisterSystemFunc(func() TickableSystem {
+		return &MysterySignalSystem{
+			BaseSystem: NewBaseSystem("MysterySignal", 153).Every(500),
+		}
 	})
 }
 
@@ -40,10 +41,6 @@ type MysterySignal struct {
 }
 
 func (mss *MysterySignalSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := mss.GetContext()
 	if ctx == nil {
 		return
@@ -55,7 +52,7 @@ func (mss *MysterySignalSystem) OnTick(tick int64) {
 	}
 
 	if mss.nextSignal == 0 {
-		mss.nextSignal = tick + 5000 + int64(rand.Intn(8000))
+		mss.nextSignal = tick + 5000 + int64(mss.Rand().Intn(8000))
 	}
 
 	players := ctx.GetPlayers()
@@ -103,7 +100,7 @@ func (mss *MysterySignalSystem) OnTick(tick int64) {
 
 	// Spawn new signals
 	if tick >= mss.nextSignal {
-		mss.nextSignal = tick + 8000 + int64(rand.Intn(12000))
+		mss.nextSignal = tick + 8000 + int64(mss.Rand().Intn(12000))
 
 		activeCount := 0
 		for _, s := range mss.signals {
@@ -118,22 +115,22 @@ func (mss *MysterySignalSystem) OnTick(tick int64) {
 		if len(systems) == 0 {
 			return
 		}
-		sys := systems[rand.Intn(len(systems))]
+		sys := systems[mss.Rand().Intn(len(systems))]
 		mss.signals = append(mss.signals, &MysterySignal{
 			SystemID:  sys.ID,
 			SysName:   sys.Name,
-			TicksLeft: 8000 + rand.Intn(5000),
+			TicksLeft: 8000 + mss.Rand().Intn(5000),
 			Active:    true,
 		})
 
 		game.LogEvent("event", "",
 			fmt.Sprintf("📡 MYSTERY SIGNAL detected from %s! Send any ship to investigate. Signal will fade in ~%d min",
-				sys.Name, (8000+rand.Intn(5000))/600))
+				sys.Name, (8000+mss.Rand().Intn(5000))/600))
 	}
 }
 
 func (mss *MysterySignalSystem) decodeSignal(player *entities.Player, ship *entities.Ship, sig *MysterySignal, systems []*entities.System, game GameProvider) {
-	outcome := rand.Intn(5)
+	outcome := mss.Rand().Intn(5)
 
 	switch outcome {
 	case 0: // Ancient coordinates
@@ -143,7 +140,7 @@ func (mss *MysterySignalSystem) decodeSignal(player *entities.Player, ship *enti
 				ship.Name, sig.SysName))
 
 	case 1: // Distress rescue
-		reward := 3000 + rand.Intn(5000)
+		reward := 3000 + mss.Rand().Intn(5000)
 		player.Credits += reward
 		game.LogEvent("explore", player.Name,
 			fmt.Sprintf("📡 %s decoded signal in %s: Distress beacon! Rescued survivors. +%dcr reward",
@@ -179,3 +176,28 @@ func (mss *MysterySignalSystem) decodeSignal(player *entities.Player, ship *enti
 				ship.Name, sig.SysName))
 	}
 }
+
+// mysterySignalState is the persisted form of MysterySignalSystem.
+type mysterySignalState struct {
+	Signals    []*MysterySignal
+	NextSignal int64
+}
+
+// Snapshot implements Snapshotter.
+func (mss *MysterySignalSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(mysterySignalState{
+		Signals:    mss.signals,
+		NextSignal: mss.nextSignal,
+	})
+}
+
+// Restore implements Snapshotter.
+func (mss *MysterySignalSystem) Restore(data []byte) error {
+	var state mysterySignalState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	mss.signals = state.Signals
+	mss.nextSignal = state.NextSignal
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&NaturalDisasterSystem{
-		BaseSystem: NewBaseSystem("NaturalDisasters", 65),
+	RegisterSystemFunc(func() TickableSystem {
+		return &NaturalDisasterSystem{
+			BaseSystem: NewBaseSystem("NaturalDisasters", 65),
+		}
 	})
 }
 
@@ -49,12 +50,12 @@ func (nds *NaturalDisasterSystem) OnTick(tick int64) {
 	}
 
 	if nds.nextDisaster == 0 {
-		nds.nextDisaster = tick + 8000 + int64(rand.Intn(10000))
+		nds.nextDisaster = tick + 8000 + int64(nds.Rand().Intn(10000))
 	}
 	if tick < nds.nextDisaster {
 		return
 	}
-	nds.nextDisaster = tick + 12000 + int64(rand.Intn(10000))
+	nds.nextDisaster = tick + 12000 + int64(nds.Rand().Intn(10000))
 
 	systems := game.GetSystems()
 
@@ -71,7 +72,7 @@ func (nds *NaturalDisasterSystem) OnTick(tick int64) {
 		return
 	}
 
-	planet := candidates[rand.Intn(len(candidates))]
+	planet := candidates[nds.Rand().Intn(len(candidates))]
 
 	// Check for Planetary Shield (reduces disaster severity)
 	hasShield := false
@@ -82,7 +83,7 @@ func (nds *NaturalDisasterSystem) OnTick(tick int64) {
 		}
 	}
 
-	disasterType := rand.Intn(4)
+	disasterType := nds.Rand().Intn(4)
 	switch disasterType {
 	case 0: // Volcanic Eruption
 		nds.volcanicEruption(planet, hasShield, game)
@@ -107,8 +108,8 @@ func (nds *NaturalDisasterSystem) volcanicEruption(planet *entities.Planet, hasS
 			}
 			b.IsOperational = false
 			// But leave mineral deposits
-			planet.AddStoredResource(entities.ResIron, 100+rand.Intn(200))
-			planet.AddStoredResource(entities.ResRareMetals, 20+rand.Intn(50))
+			planet.AddStoredResource(entities.ResIron, 100+nds.Rand().Intn(200))
+			planet.AddStoredResource(entities.ResRareMetals, 20+nds.Rand().Intn(50))
 			game.LogEvent("event", planet.Owner,
 				fmt.Sprintf("🌋 Volcanic eruption on %s! Mine damaged, but rich mineral deposits uncovered (+Iron, +Rare Metals)",
 					planet.Name))
@@ -116,7 +117,7 @@ func (nds *NaturalDisasterSystem) volcanicEruption(planet *entities.Planet, hasS
 		}
 	}
 	// No mine to damage
-	planet.AddStoredResource(entities.ResIron, 50+rand.Intn(100))
+	planet.AddStoredResource(entities.ResIron, 50+nds.Rand().Intn(100))
 	game.LogEvent("event", planet.Owner,
 		fmt.Sprintf("🌋 Minor volcanic activity on %s. Mineral deposits revealed (+Iron)",
 			planet.Name))
@@ -185,8 +186,8 @@ func (nds *NaturalDisasterSystem) magneticStorm(planet *entities.Planet, game Ga
 func (nds *NaturalDisasterSystem) cometImpact(planet *entities.Planet, hasShield bool, game GameProvider) {
 	if hasShield {
 		// Shield absorbs impact
-		planet.AddStoredResource(entities.ResRareMetals, 30+rand.Intn(40))
-		planet.AddStoredResource(entities.ResHelium3, 10+rand.Intn(20))
+		planet.AddStoredResource(entities.ResRareMetals, 30+nds.Rand().Intn(40))
+		planet.AddStoredResource(entities.ResHelium3, 10+nds.Rand().Intn(20))
 		game.LogEvent("event", planet.Owner,
 			fmt.Sprintf("☄️ Comet intercepted by %s's Planetary Shield! Debris collected (+Rare Metals, +Helium-3)",
 				planet.Name))
@@ -206,10 +207,32 @@ func (nds *NaturalDisasterSystem) cometImpact(planet *entities.Planet, hasShield
 	}
 
 	// But deposit rare materials
-	planet.AddStoredResource(entities.ResRareMetals, 50+rand.Intn(80))
-	planet.AddStoredResource(entities.ResHelium3, 20+rand.Intn(40))
+	planet.AddStoredResource(entities.ResRareMetals, 50+nds.Rand().Intn(80))
+	planet.AddStoredResource(entities.ResHelium3, 20+nds.Rand().Intn(40))
 
 	game.LogEvent("event", planet.Owner,
 		fmt.Sprintf("☄️ COMET IMPACT on %s! %d casualties, building destroyed — but rare materials deposited (+RM, +He-3). Build a Planetary Shield!",
 			planet.Name, popLoss))
 }
+
+// naturalDisasterState is the persisted form of NaturalDisasterSystem.
+type naturalDisasterState struct {
+	NextDisaster int64
+}
+
+// Snapshot implements Snapshotter.
+func (nds *NaturalDisasterSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(naturalDisasterState{
+		NextDisaster: nds.nextDisaster,
+	})
+}
+
+// Restore implements Snapshotter.
+func (nds *NaturalDisasterSystem) Restore(data []byte) error {
+	var state naturalDisasterState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	nds.nextDisaster = state.NextDisaster
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&OilToFuelChainSystem{
-		BaseSystem: NewBaseSystem("OilToFuelChain", 15),
+	RegisterSystemFunc(func() TickableSystem {
+		return &OilToFuelChainSystem{
+			BaseSystem: NewBaseSystem("OilToFuelChain", 15).Every(100),
+		}
 	})
 }
 
@@ -37,10 +38,6 @@ type OilToFuelChainSystem struct {
 }
 
 func (otfc *OilToFuelChainSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := otfc.GetContext()
 	if ctx == nil {
 		return
@@ -89,7 +86,7 @@ func (otfc *OilToFuelChainSystem) OnTick(tick int64) {
 			planet.RemoveStoredResource(entities.ResOil, 5)
 			planet.AddStoredResource(entities.ResFuel, 2)
 
-			if rand.Intn(20) == 0 {
+			if otfc.Rand().Intn(20) == 0 {
 				game.LogEvent("logistics", planet.Owner,
 					fmt.Sprintf("⛽ %s: Generator performing crude Oil→Fuel conversion (5→2). Build a Refinery for better rates!",
 						planet.Name))
@@ -0,0 +1,53 @@
+package tickable
+
+import (
+	"fmt"
+)
+
+func init() {
+	RegisterSystemFunc(func() TickableSystem {
+		return &OrderTriggerSystem{
+			BaseSystem: NewBaseSystem("OrderTriggers", 27),
+		}
+	})
+}
+
+// OrderTriggerSystem runs the order book's clock. Every tick it cancels and
+// refunds good-till-tick orders that have expired; after every price update
+// (10 ticks) it fires stop and take-profit orders whose trigger price has
+// been reached.
+type OrderTriggerSystem struct {
+	*BaseSystem
+}
+
+func (ots *OrderTriggerSystem) OnTick(tick int64) {
+	ctx := ots.GetContext()
+	if ctx == nil {
+		return
+	}
+
+	game := ctx.GetGame()
+	if game == nil {
+		return
+	}
+
+	ob := game.GetOrderBook()
+	if ob == nil {
+		return
+	}
+
+	if n := ob.ExpireOrders(tick); n > 0 {
+		fmt.Printf("[OrderTriggers] %d limit orders expired at tick %d\n", n, tick)
+	}
+
+	// Prices move every 10 ticks (MarketSystem runs first)
+	market := game.GetMarketEngine()
+	if market == nil || tick%10 != 0 {
+		return
+	}
+	for _, o := range ob.Trigger(market.GetCurrentPrice) {
+		game.LogEvent("trade", o.Player,
+			fmt.Sprintf("%s's %s %s order #%d for %s triggered at %dcr (%d filled)",
+				o.Player, o.Type, o.Action, o.ID, o.Resource, o.StopPrice, o.Filled))
+	}
+}
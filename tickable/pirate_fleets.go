@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PirateFleetSystem{
-		BaseSystem: NewBaseSystem("PirateFleets", 34),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PirateFleetSystem{
+			BaseSystem: NewBaseSystem("PirateFleets", 34).Every(500),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type PirateFleet struct {
 }
 
 func (pfs *PirateFleetSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := pfs.GetContext()
 	if ctx == nil {
 		return
@@ -61,7 +58,7 @@ func (pfs *PirateFleetSystem) OnTick(tick int64) {
 			activeCount++
 		}
 	}
-	if activeCount < 3 && rand.Intn(3) == 0 {
+	if activeCount < 3 && pfs.Rand().Intn(3) == 0 {
 		pfs.spawnPirates(game, systems)
 	}
 
@@ -79,15 +76,15 @@ func (pfs *PirateFleetSystem) spawnPirates(game GameProvider, systems []*entitie
 	if len(systems) == 0 {
 		return
 	}
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[pfs.Rand().Intn(len(systems))]
 
 	// Don't spawn in systems that already have pirates
 	if _, exists := pfs.pirates[sys.ID]; exists && pfs.pirates[sys.ID].Active {
 		return
 	}
 
-	strength := 1 + rand.Intn(3)
-	bounty := strength * (2000 + rand.Intn(3000))
+	strength := 1 + pfs.Rand().Intn(3)
+	bounty := strength * (2000 + pfs.Rand().Intn(3000))
 
 	pfs.pirates[sys.ID] = &PirateFleet{
 		SystemID: sys.ID,
@@ -120,7 +117,7 @@ func (pfs *PirateFleetSystem) pirateRaid(game GameProvider, pirate *PirateFleet,
 			// Pirates steal cargo based on strength
 			// Strength 1: 5%, Strength 3: 15%
 			stealRate := float64(pirate.Strength) * 0.05
-			if rand.Intn(3) != 0 {
+			if pfs.Rand().Intn(3) != 0 {
 				continue // 33% chance per tick
 			}
 			totalStolen := 0
@@ -182,3 +179,25 @@ func (pfs *PirateFleetSystem) checkDefeat(game GameProvider, pirate *PirateFleet
 				defeater.Name, pirate.SystemID+1, pirate.Bounty))
 	}
 }
+
+// pirateFleetState is the persisted form of PirateFleetSystem.
+type pirateFleetState struct {
+	Pirates map[int]*PirateFleet
+}
+
+// Snapshot implements Snapshotter.
+func (pfs *PirateFleetSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(pirateFleetState{
+		Pirates: pfs.pirates,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pfs *PirateFleetSystem) Restore(data []byte) error {
+	var state pirateFleetState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pfs.pirates = state.Pirates
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PirateKingSystem{
-		BaseSystem: NewBaseSystem("PirateKing", 86),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PirateKingSystem{
+			BaseSystem: NewBaseSystem("PirateKing", 86).Every(500),
+		}
 	})
 }
 
@@ -44,10 +45,6 @@ type PirateKingSystem struct {
 }
 
 func (pks *PirateKingSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := pks.GetContext()
 	if ctx == nil {
 		return
@@ -75,7 +72,7 @@ func (pks *PirateKingSystem) OnTick(tick int64) {
 	if tick < 50000 {
 		return
 	}
-	if rand.Intn(100) != 0 { // 1% per 500-tick check after 50K ticks
+	if pks.Rand().Intn(100) != 0 { // 1% per 500-tick check after 50K ticks
 		return
 	}
 
@@ -114,7 +111,7 @@ func (pks *PirateKingSystem) processKing(tick int64, players []*entities.Player,
 			lastPaid := pks.tributePaid[p.Name]
 			if tick-lastPaid > 5000 {
 				// Didn't pay — raid them
-				if rand.Intn(3) == 0 {
+				if pks.Rand().Intn(3) == 0 {
 					raided := p.Credits / 50 // 2% raid
 					if raided > 2000 {
 						raided = 2000
@@ -199,3 +196,40 @@ func (pks *PirateKingSystem) PayTribute(playerName string, tick int64) {
 func (pks *PirateKingSystem) IsActive() bool {
 	return pks.active
 }
+
+// pirateKingState is the persisted form of PirateKingSystem.
+type pirateKingState struct {
+	Active       bool
+	BaseSystemID int
+	BasePlanetID int
+	HP           int
+	TributePaid  map[string]int64
+	NextDemand   int64
+}
+
+// Snapshot implements Snapshotter.
+func (pks *PirateKingSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(pirateKingState{
+		Active:       pks.active,
+		BaseSystemID: pks.baseSystemID,
+		BasePlanetID: pks.basePlanetID,
+		HP:           pks.hp,
+		TributePaid:  pks.tributePaid,
+		NextDemand:   pks.nextDemand,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pks *PirateKingSystem) Restore(data []byte) error {
+	var state pirateKingState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pks.active = state.Active
+	pks.baseSystemID = state.BaseSystemID
+	pks.basePlanetID = state.BasePlanetID
+	pks.hp = state.HP
+	pks.tributePaid = state.TributePaid
+	pks.nextDemand = state.NextDemand
+	return nil
+}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PlagueSystem{
-		BaseSystem: NewBaseSystem("Plague", 43),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PlagueSystem{
+			BaseSystem: NewBaseSystem("Plague", 43).Every(300),
+		}
 	})
 }
 
@@ -46,10 +49,6 @@ type Infection struct {
 }
 
 func (ps *PlagueSystem) OnTick(tick int64) {
-	if tick%300 != 0 {
-		return
-	}
-
 	ctx := ps.GetContext()
 	if ctx == nil {
 		return
@@ -65,14 +64,15 @@ func (ps *PlagueSystem) OnTick(tick int64) {
 	}
 
 	if ps.nextCheck == 0 {
-		ps.nextCheck = tick + 5000 + int64(rand.Intn(10000))
+		ps.nextCheck = tick + 5000 + int64(ps.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
 	systems := game.GetSystems()
 
 	// Process existing infections
-	for pid, inf := range ps.infections {
+	for _, pid := range slices.Sorted(maps.Keys(ps.infections)) {
+		inf := ps.infections[pid]
 		inf.TicksLeft -= 300
 		if inf.TicksLeft <= 0 {
 			delete(ps.infections, pid)
@@ -96,7 +96,7 @@ func (ps *PlagueSystem) OnTick(tick int64) {
 
 	// Random new outbreak
 	if tick >= ps.nextCheck {
-		ps.nextCheck = tick + 15000 + int64(rand.Intn(20000))
+		ps.nextCheck = tick + 15000 + int64(ps.Rand().Intn(20000))
 		ps.randomOutbreak(systems, game)
 	}
 }
@@ -179,7 +179,7 @@ func (ps *PlagueSystem) spreadViaTrade(players []*entities.Player, systems []*en
 					}
 
 					// 10% chance to spread per tick
-					if rand.Intn(10) != 0 {
+					if ps.Rand().Intn(10) != 0 {
 						continue
 					}
 
@@ -191,7 +191,7 @@ func (ps *PlagueSystem) spreadViaTrade(players []*entities.Player, systems []*en
 							break
 						}
 					}
-					if hasShield && rand.Intn(2) == 0 {
+					if hasShield && ps.Rand().Intn(2) == 0 {
 						game.LogEvent("event", planet.Owner,
 							fmt.Sprintf("🛡️ Planetary Shield on %s blocked an incoming plague!", planet.Name))
 						continue
@@ -199,8 +199,8 @@ func (ps *PlagueSystem) spreadViaTrade(players []*entities.Player, systems []*en
 
 					ps.infections[planet.GetID()] = &Infection{
 						PlanetID:  planet.GetID(),
-						Severity:  0.005 + rand.Float64()*0.015,
-						TicksLeft: 3000 + rand.Intn(3000),
+						Severity:  0.005 + ps.Rand().Float64()*0.015,
+						TicksLeft: 3000 + ps.Rand().Intn(3000),
 						Source:    "trade",
 					}
 					game.LogEvent("alert", planet.Owner,
@@ -245,11 +245,11 @@ func (ps *PlagueSystem) randomOutbreak(systems []*entities.System, game GameProv
 		return
 	}
 
-	planet := candidates[rand.Intn(len(candidates))]
+	planet := candidates[ps.Rand().Intn(len(candidates))]
 	ps.infections[planet.GetID()] = &Infection{
 		PlanetID:  planet.GetID(),
-		Severity:  0.005 + rand.Float64()*0.01,
-		TicksLeft: 4000 + rand.Intn(3000),
+		Severity:  0.005 + ps.Rand().Float64()*0.01,
+		TicksLeft: 4000 + ps.Rand().Intn(3000),
 		Source:    "outbreak",
 	}
 
@@ -268,3 +268,28 @@ func (ps *PlagueSystem) IsInfected(planetID int) bool {
 	_, ok := ps.infections[planetID]
 	return ok
 }
+
+// plagueState is the persisted form of PlagueSystem.
+type plagueState struct {
+	Infections map[int]*Infection
+	NextCheck  int64
+}
+
+// Snapshot implements Snapshotter.
+func (ps *PlagueSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(plagueState{
+		Infections: ps.infections,
+		NextCheck:  ps.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ps *PlagueSystem) Restore(data []byte) error {
+	var state plagueState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ps.infections = state.Infections
+	ps.nextCheck = state.NextCheck
+	return nil
+}
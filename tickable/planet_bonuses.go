@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&PlanetBonusSystem{
-		BaseSystem: NewBaseSystem("PlanetBonuses", 16),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PlanetBonusSystem{
+			BaseSystem: NewBaseSystem("PlanetBonuses", 16).Every(200),
+		}
 	})
 }
 
@@ -43,10 +45,6 @@ type PlanetBonusSystem struct {
 }
 
 func (pbs *PlanetBonusSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := pbs.GetContext()
 	if ctx == nil {
 		return
@@ -66,23 +64,23 @@ func (pbs *PlanetBonusSystem) OnTick(tick int64) {
 				continue
 			}
 
-			applyOceanBonus(planet)
-			applyVolcanicBonus(planet)
-			applyPressureEffects(planet)
+			applyOceanBonus(planet, pbs.Rand())
+			applyVolcanicBonus(planet, pbs.Rand())
+			applyPressureEffects(planet, pbs.Rand())
 			applyGravityEffects2(planet)
 			applyTectonicBonus(planet)
 		}
 	}
 }
 
-func applyOceanBonus(planet *entities.Planet) {
+func applyOceanBonus(planet *entities.Planet, rng *rand.Rand) {
 	if planet.OceanCoverage < 0.2 {
 		return
 	}
 
 	// Water production bonus: ocean evaporation/collection
 	waterBonus := int(planet.OceanCoverage * 3)
-	if waterBonus > 0 && rand.Intn(3) == 0 {
+	if waterBonus > 0 && rng.Intn(3) == 0 {
 		planet.AddStoredResource(entities.ResWater, waterBonus)
 	}
 
@@ -93,16 +91,16 @@ func applyOceanBonus(planet *entities.Planet) {
 	}
 }
 
-func applyVolcanicBonus(planet *entities.Planet) {
+func applyVolcanicBonus(planet *entities.Planet, rng *rand.Rand) {
 	if planet.VolcanicLevel < 0.3 {
 		return
 	}
 
 	// Mining bonus: volcanic activity exposes deposits
-	if rand.Intn(5) == 0 {
+	if rng.Intn(5) == 0 {
 		planet.AddStoredResource(entities.ResIron, int(planet.VolcanicLevel*2))
 	}
-	if planet.Comp.RareEarth > 0.03 && rand.Intn(10) == 0 {
+	if planet.Comp.RareEarth > 0.03 && rng.Intn(10) == 0 {
 		planet.AddStoredResource(entities.ResRareMetals, 1)
 	}
 
@@ -116,17 +114,17 @@ func applyVolcanicBonus(planet *entities.Planet) {
 	}
 }
 
-func applyPressureEffects(planet *entities.Planet) {
+func applyPressureEffects(planet *entities.Planet, rng *rand.Rand) {
 	if planet.AtmoPressure < 5 {
 		return
 	}
 
 	// High pressure: corrosion penalty on buildings
 	// Very subtle — only affects extreme pressure worlds (Venus-like)
-	if planet.AtmoPressure > 20 && rand.Intn(100) == 0 {
+	if planet.AtmoPressure > 20 && rng.Intn(100) == 0 {
 		// Random building takes minor damage
 		for _, be := range planet.Buildings {
-			if b, ok := be.(*entities.Building); ok && b.IsOperational && rand.Intn(5) == 0 {
+			if b, ok := be.(*entities.Building); ok && b.IsOperational && rng.Intn(5) == 0 {
 				b.IsOperational = false
 				break
 			}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PlanetSurveySystem{
-		BaseSystem: NewBaseSystem("PlanetSurvey", 170),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PlanetSurveySystem{
+			BaseSystem: NewBaseSystem("PlanetSurvey", 170),
+		}
 	})
 }
 
@@ -46,12 +49,12 @@ func (pss *PlanetSurveySystem) OnTick(tick int64) {
 	}
 
 	if pss.nextSurvey == 0 {
-		pss.nextSurvey = tick + 3000 + int64(rand.Intn(3000))
+		pss.nextSurvey = tick + 3000 + int64(pss.Rand().Intn(3000))
 	}
 	if tick < pss.nextSurvey {
 		return
 	}
-	pss.nextSurvey = tick + 5000 + int64(rand.Intn(3000))
+	pss.nextSurvey = tick + 5000 + int64(pss.Rand().Intn(3000))
 
 	systems := game.GetSystems()
 
@@ -88,7 +91,7 @@ func (pss *PlanetSurveySystem) OnTick(tick int64) {
 		return
 	}
 
-	idx := rand.Intn(len(candidates))
+	idx := pss.Rand().Intn(len(candidates))
 	planet := candidates[idx]
 	sysName := candidateSystems[idx]
 	pss.surveyed[planet.GetID()] = true
@@ -144,8 +147,8 @@ func (pss *PlanetSurveySystem) OnTick(tick int64) {
 		"water": planet.Comp.Water, "gas": planet.Comp.Gas,
 		"organics": planet.Comp.Organics, "rare earth": planet.Comp.RareEarth,
 	}
-	for name, val := range compMap {
-		if val > maxVal {
+	for _, name := range slices.Sorted(maps.Keys(compMap)) {
+		if val := compMap[name]; val > maxVal {
 			maxVal = val
 			maxComp = name
 		}
@@ -173,3 +176,31 @@ func (pss *PlanetSurveySystem) OnTick(tick int64) {
 
 	game.LogEvent("explore", planet.Owner, msg)
 }
+
+// planetSurveyState is the persisted form of PlanetSurveySystem.
+type planetSurveyState struct {
+	Surveyed   map[int]bool
+	NextSurvey int64
+}
+
+// Snapshot implements Snapshotter.
+func (pss *PlanetSurveySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(planetSurveyState{
+		Surveyed:   pss.surveyed,
+		NextSurvey: pss.nextSurvey,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pss *PlanetSurveySystem) Restore(data []byte) error {
+	var state planetSurveyState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pss.surveyed = state.Surveyed
+	if pss.surveyed == nil {
+		pss.surveyed = make(map[int]bool)
+	}
+	pss.nextSurvey = state.NextSurvey
+	return nil
+}
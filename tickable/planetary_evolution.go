@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PlanetaryEvolutionSystem{
-		BaseSystem: NewBaseSystem("PlanetaryEvolution", 4),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PlanetaryEvolutionSystem{
+			BaseSystem: NewBaseSystem("PlanetaryEvolution", 4).Every(500),
+		}
 	})
 }
 
@@ -38,10 +39,6 @@ type PlanetaryEvolutionSystem struct {
 }
 
 func (pes *PlanetaryEvolutionSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := pes.GetContext()
 	if ctx == nil {
 		return
@@ -111,12 +108,12 @@ func (pes *PlanetaryEvolutionSystem) evolve(planet *entities.Planet) {
 	}
 
 	// Resource regeneration from tectonic activity
-	if planet.TectonicActive && rand.Intn(10000) == 0 {
+	if planet.TectonicActive && pes.Rand().Intn(10000) == 0 {
 		// Tectonics expose new deposits — boost existing resource abundance
 		for _, re := range planet.Resources {
 			if r, ok := re.(*entities.Resource); ok {
 				if r.Abundance < 100 {
-					r.Abundance += 1 + rand.Intn(3)
+					r.Abundance += 1 + pes.Rand().Intn(3)
 					if r.Abundance > 100 {
 						r.Abundance = 100
 					}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PopulationEventSystem{
-		BaseSystem: NewBaseSystem("PopulationEvents", 58),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PopulationEventSystem{
+			BaseSystem: NewBaseSystem("PopulationEvents", 58).Every(500),
+		}
 	})
 }
 
@@ -34,10 +35,6 @@ type PopulationEventSystem struct {
 }
 
 func (pes *PopulationEventSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := pes.GetContext()
 	if ctx == nil {
 		return
@@ -54,7 +51,7 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 	}
 
 	if pes.nextEvent == 0 {
-		pes.nextEvent = tick + 1000 + int64(rand.Intn(3000))
+		pes.nextEvent = tick + 1000 + int64(pes.Rand().Intn(3000))
 	}
 
 	players := ctx.GetPlayers()
@@ -91,7 +88,7 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 	if tick < pes.nextEvent {
 		return
 	}
-	pes.nextEvent = tick + 2000 + int64(rand.Intn(4000))
+	pes.nextEvent = tick + 2000 + int64(pes.Rand().Intn(4000))
 
 	// Collect all owned planets
 	var owned []*entities.Planet
@@ -106,8 +103,8 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 		return
 	}
 
-	eventType := rand.Intn(6)
-	planet := owned[rand.Intn(len(owned))]
+	eventType := pes.Rand().Intn(6)
+	planet := owned[pes.Rand().Intn(len(owned))]
 
 	switch eventType {
 	case 0: // Baby boom
@@ -118,7 +115,7 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 		if cap <= 0 || planet.Population >= cap {
 			return
 		}
-		bonus := int64(1000 + rand.Intn(5000))
+		bonus := int64(1000 + pes.Rand().Intn(5000))
 		if planet.Population+bonus > cap {
 			bonus = cap - planet.Population
 		}
@@ -157,7 +154,7 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 				planet.Name, lost))
 
 	case 2: // Cultural festival
-		credits := 200 + rand.Intn(800)
+		credits := 200 + pes.Rand().Intn(800)
 		planet.Happiness += 0.05
 		if planet.Happiness > 1.0 {
 			planet.Happiness = 1.0
@@ -179,7 +176,7 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 		if _, striking := pes.strikes[planet.GetID()]; striking {
 			return
 		}
-		pes.strikes[planet.GetID()] = tick + 2000 + int64(rand.Intn(2000))
+		pes.strikes[planet.GetID()] = tick + 2000 + int64(pes.Rand().Intn(2000))
 		game.LogEvent("alert", planet.Owner,
 			fmt.Sprintf("✊ LABOR STRIKE on %s! Workers demand better conditions. Production halved until resolved (improve happiness!)",
 				planet.Name))
@@ -188,7 +185,7 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 		if planet.TechLevel < 2.0 {
 			return
 		}
-		bonus := 0.1 + rand.Float64()*0.2
+		bonus := 0.1 + pes.Rand().Float64()*0.2
 		planet.TechLevel += bonus
 		game.LogEvent("event", planet.Owner,
 			fmt.Sprintf("💡 Innovation on %s! Scientists made a breakthrough — tech level +%.1f (now %.1f)",
@@ -208,3 +205,37 @@ func (pes *PopulationEventSystem) OnTick(tick int64) {
 				planet.Name, planet.Population, cap))
 	}
 }
+
+// populationEventState is the persisted form of PopulationEventSystem.
+type populationEventState struct {
+	NextEvent int64
+	Strikes   map[int]int64
+	Festivals map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (pes *PopulationEventSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(populationEventState{
+		NextEvent: pes.nextEvent,
+		Strikes:   pes.strikes,
+		Festivals: pes.festivals,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pes *PopulationEventSystem) Restore(data []byte) error {
+	var state populationEventState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pes.nextEvent = state.NextEvent
+	pes.strikes = state.Strikes
+	if pes.strikes == nil {
+		pes.strikes = make(map[int]int64)
+	}
+	pes.festivals = state.Festivals
+	if pes.festivals == nil {
+		pes.festivals = make(map[int]int64)
+	}
+	return nil
+}
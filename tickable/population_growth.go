@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&PopulationGrowthSystem{
-		BaseSystem: NewBaseSystem("PopulationGrowth", 10),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PopulationGrowthSystem{
+			BaseSystem: NewBaseSystem("PopulationGrowth", 10),
+		}
 	})
 }
 
@@ -142,3 +144,25 @@ func (pgs *PopulationGrowthSystem) updatePopulation(planet *entities.Planet) {
 
 	planet.Population += growth
 }
+
+// populationGrowthState is the persisted form of PopulationGrowthSystem.
+type populationGrowthState struct {
+	TickCounter int64
+}
+
+// Snapshot implements Snapshotter.
+func (pgs *PopulationGrowthSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(populationGrowthState{
+		TickCounter: pgs.tickCounter,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pgs *PopulationGrowthSystem) Restore(data []byte) error {
+	var state populationGrowthState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pgs.tickCounter = state.TickCounter
+	return nil
+}
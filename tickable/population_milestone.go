@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&PopulationMilestoneSystem{
-		BaseSystem: NewBaseSystem("PopulationMilestones", 106),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PopulationMilestoneSystem{
+			BaseSystem: NewBaseSystem("PopulationMilestones", 106).Every(1000),
+		}
 	})
 }
 
@@ -50,10 +52,6 @@ var popMilestones = []struct {
 }
 
 func (pms *PopulationMilestoneSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := pms.GetContext()
 	if ctx == nil {
 		return
@@ -134,3 +132,34 @@ func (pms *PopulationMilestoneSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// populationMilestoneState is the persisted form of PopulationMilestoneSystem.
+type populationMilestoneState struct {
+	PlanetMilestones  map[int]int64
+	FactionMilestones map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (pms *PopulationMilestoneSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(populationMilestoneState{
+		PlanetMilestones:  pms.planetMilestones,
+		FactionMilestones: pms.factionMilestones,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pms *PopulationMilestoneSystem) Restore(data []byte) error {
+	var state populationMilestoneState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pms.planetMilestones = state.PlanetMilestones
+	if pms.planetMilestones == nil {
+		pms.planetMilestones = make(map[int]int64)
+	}
+	pms.factionMilestones = state.FactionMilestones
+	if pms.factionMilestones == nil {
+		pms.factionMilestones = make(map[string]int64)
+	}
+	return nil
+}
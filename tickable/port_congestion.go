@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&PortCongestionSystem{
-		BaseSystem: NewBaseSystem("PortCongestion", 112),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PortCongestionSystem{
+			BaseSystem: NewBaseSystem("PortCongestion", 112).Every(500),
+		}
 	})
 }
 
@@ -34,10 +36,6 @@ type PortCongestionSystem struct {
 }
 
 func (pcs *PortCongestionSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := pcs.GetContext()
 	if ctx == nil {
 		return
@@ -106,3 +104,28 @@ func (pcs *PortCongestionSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// portCongestionState is the persisted form of PortCongestionSystem.
+type portCongestionState struct {
+	LastReport map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (pcs *PortCongestionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(portCongestionState{
+		LastReport: pcs.lastReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pcs *PortCongestionSystem) Restore(data []byte) error {
+	var state portCongestionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pcs.lastReport = state.LastReport
+	if pcs.lastReport == nil {
+		pcs.lastReport = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PowerGridSystem{
-		BaseSystem: NewBaseSystem("PowerGrid", 105),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PowerGridSystem{
+			BaseSystem: NewBaseSystem("PowerGrid", 105).Every(50),
+		}
 	})
 }
 
@@ -32,10 +33,6 @@ type PowerGridSystem struct {
 }
 
 func (pgs *PowerGridSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := pgs.GetContext()
 	if ctx == nil {
 		return
@@ -110,7 +107,7 @@ func (pgs *PowerGridSystem) OnTick(tick int64) {
 					src.RemoveStoredResource(entities.ResFuel, fuelCost)
 					dst.PowerGenerated += transfer
 
-					if rand.Intn(20) == 0 {
+					if pgs.Rand().Intn(20) == 0 {
 						game.LogEvent("logistics", src.Owner,
 							fmt.Sprintf("⚡ Power grid: %.0f MW transferred from %s to %s (cost: %d Fuel)",
 								transfer, src.Name, dst.Name, fuelCost))
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&PowerSystem{
-		BaseSystem: NewBaseSystem("Power", 7),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PowerSystem{
+			BaseSystem: NewBaseSystem("Power", 7).Every(10).At(0),
+		}
 	})
 }
 
@@ -35,10 +37,6 @@ var buildingPowerDraw = map[string]float64{
 }
 
 func (ps *PowerSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := ps.GetContext()
 	if ctx == nil {
 		return
@@ -2,14 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
+	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&PriceHistorySystem{
-		BaseSystem: NewBaseSystem("PriceHistory", 70),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PriceHistorySystem{
+			BaseSystem: NewBaseSystem("PriceHistory", 70).Every(10).At(0),
+		}
 	})
 }
 
@@ -17,6 +19,10 @@ func init() {
 // market analysis events. This gives factions (especially LLM agents)
 // data to make better trading decisions.
 //
+// Every price update (10 ticks, same phase as the Market system) it
+// samples mid prices into the game's OHLCV candles: the global price of
+// every resource, and the local price in every system with owned planets.
+//
 // Every ~3000 ticks, it records current prices and compares to previous.
 // Announces:
 //   - Trending up: price increased >10% since last snapshot
@@ -49,18 +55,22 @@ func (phs *PriceHistorySystem) OnTick(tick int64) {
 		return
 	}
 
+	if candles := game.GetCandleHistory(); candles != nil {
+		sampleCandles(candles, market, tick)
+	}
+
 	if phs.history == nil {
 		phs.history = make(map[string][]float64)
 	}
 
 	if phs.nextReport == 0 {
-		phs.nextReport = tick + 2000 + int64(rand.Intn(2000))
+		phs.nextReport = tick + 2000 + int64(phs.Rand().Intn(2000))
 	}
 
 	if tick < phs.nextReport {
 		return
 	}
-	phs.nextReport = tick + 3000 + int64(rand.Intn(3000))
+	phs.nextReport = tick + 3000 + int64(phs.Rand().Intn(3000))
 
 	resources := []string{entities.ResIron, entities.ResWater, entities.ResOil,
 		entities.ResFuel, entities.ResRareMetals, entities.ResHelium3, entities.ResElectronics}
@@ -173,6 +183,19 @@ func (phs *PriceHistorySystem) generateReport(resources []string, game GameProvi
 	game.LogEvent("intel", "", msg)
 }
 
+// sampleCandles records the current global and local mid prices of every
+// resource.
+func sampleCandles(candles *economy.CandleHistory, market *economy.Market, tick int64) {
+	systems := market.SupplySystems()
+	for _, res := range market.ResourceNames() {
+		candles.Sample(tick, economy.GlobalCandles, res, (market.GetBuyPrice(res)+market.GetSellPrice(res))/2)
+		for _, sysID := range systems {
+			mid := (market.GetLocalBuyPrice(res, sysID) + market.GetLocalSellPrice(res, sysID)) / 2
+			candles.Sample(tick, sysID, res, mid)
+		}
+	}
+}
+
 // GetPriceHistory returns price history for a resource.
 func (phs *PriceHistorySystem) GetPriceHistory(resource string) []float64 {
 	if phs.history == nil {
@@ -180,3 +203,31 @@ func (phs *PriceHistorySystem) GetPriceHistory(resource string) []float64 {
 	}
 	return phs.history[resource]
 }
+
+// priceHistoryState is the persisted form of PriceHistorySystem.
+type priceHistoryState struct {
+	History    map[string][]float64
+	NextReport int64
+}
+
+// Snapshot implements Snapshotter.
+func (phs *PriceHistorySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(priceHistoryState{
+		History:    phs.history,
+		NextReport: phs.nextReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (phs *PriceHistorySystem) Restore(data []byte) error {
+	var state priceHistoryState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	phs.history = state.History
+	if phs.history == nil {
+		phs.history = make(map[string][]float64)
+	}
+	phs.nextReport = state.NextReport
+	return nil
+}
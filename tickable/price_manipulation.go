@@ -2,12 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 )
 
 func init() {
-	RegisterSystem(&PriceManipulationSystem{
-		BaseSystem: NewBaseSystem("PriceManipulation", 98),
+	RegisterSystemFunc(func() TickableSystem {
+		return &PriceManipulationSystem{
+			BaseSystem: NewBaseSystem("PriceManipulation", 98).Every(500),
+		}
 	})
 }
 
@@ -33,10 +36,6 @@ type PriceManipulationSystem struct {
 }
 
 func (pms *PriceManipulationSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := pms.GetContext()
 	if ctx == nil {
 		return
@@ -85,13 +84,15 @@ func (pms *PriceManipulationSystem) OnTick(tick int64) {
 	}
 
 	// Detect manipulation: sell then buy same resource within 2000 ticks
-	for playerName, sells := range pms.recentSells {
+	for _, playerName := range slices.Sorted(maps.Keys(pms.recentSells)) {
+		sells := pms.recentSells[playerName]
 		buys := pms.recentBuys[playerName]
 		if buys == nil {
 			continue
 		}
 
-		for res, sellTick := range sells {
+		for _, res := range slices.Sorted(maps.Keys(sells)) {
+			sellTick := sells[res]
 			buyTick, bought := buys[res]
 			if !bought {
 				continue
@@ -137,6 +138,41 @@ func (pms *PriceManipulationSystem) OnTick(tick int64) {
 			}
 		}
 	}
+}
+
+// priceManipulationState is the persisted form of PriceManipulationSystem.
+type priceManipulationState struct {
+	RecentSells map[string]map[string]int64
+	RecentBuys  map[string]map[string]int64
+	Offenses    map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (pms *PriceManipulationSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(priceManipulationState{
+		RecentSells: pms.recentSells,
+		RecentBuys:  pms.recentBuys,
+		Offenses:    pms.offenses,
+	})
+}
 
-	_ = rand.Intn // suppress
+// Restore implements Snapshotter.
+func (pms *PriceManipulationSystem) Restore(data []byte) error {
+	var state priceManipulationState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	pms.recentSells = state.RecentSells
+	if pms.recentSells == nil {
+		pms.recentSells = make(map[string]map[string]int64)
+	}
+	pms.recentBuys = state.RecentBuys
+	if pms.recentBuys == nil {
+		pms.recentBuys = make(map[string]map[string]int64)
+	}
+	pms.offenses = state.Offenses
+	if pms.offenses == nil {
+		pms.offenses = make(map[string]int)
+	}
+	return nil
 }
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ProductionEfficiencySystem{
-		BaseSystem: NewBaseSystem("ProductionEfficiency", 146),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ProductionEfficiencySystem{
+			BaseSystem: NewBaseSystem("ProductionEfficiency", 146).Every(3000),
+		}
 	})
 }
 
@@ -31,10 +32,6 @@ type ProductionEfficiencySystem struct {
 }
 
 func (pes *ProductionEfficiencySystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := pes.GetContext()
 	if ctx == nil {
 		return
@@ -107,7 +104,7 @@ func (pes *ProductionEfficiencySystem) OnTick(tick int64) {
 			}
 
 			// Report high/low efficiency periodically
-			if tick >= pes.nextReport && rand.Intn(5) == 0 {
+			if tick >= pes.nextReport && pes.Rand().Intn(5) == 0 {
 				if efficiency >= 0.95 {
 					game.LogEvent("logistics", planet.Owner,
 						fmt.Sprintf("⭐ %s is a Model Colony! %.0f%% efficiency (%.0f%% operational, %.0f%% staffed, %.0f%% powered)",
@@ -122,6 +119,28 @@ func (pes *ProductionEfficiencySystem) OnTick(tick int64) {
 	}
 
 	if tick >= pes.nextReport {
-		pes.nextReport = tick + 10000 + int64(rand.Intn(5000))
+		pes.nextReport = tick + 10000 + int64(pes.Rand().Intn(5000))
+	}
+}
+
+// productionEfficiencyState is the persisted form of ProductionEfficiencySystem.
+type productionEfficiencyState struct {
+	NextReport int64
+}
+
+// Snapshot implements Snapshotter.
+func (pes *ProductionEfficiencySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(productionEfficiencyState{
+		NextReport: pes.nextReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (pes *ProductionEfficiencySystem) Restore(data []byte) error {
+	var state productionEfficiencyState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
 	}
+	pes.nextReport = state.NextReport
+	return nil
 }
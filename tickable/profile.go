@@ -0,0 +1,408 @@
+package tickable
+
+import (
+	"fmt"
+	"runtime/metrics"
+	"sort"
+	"sync"
+	"time"
+)
+
+// profileWindow is how many recent ticks the rolling profile covers
+// (one minute at 1x speed).
+const profileWindow = 600
+
+// DefaultTickBudget is the wall time a single system may take in one tick
+// before it is flagged.
+const DefaultTickBudget = 5 * time.Millisecond
+
+// ProfileBuckets are the upper bounds of the wall-time histogram; a final
+// extra bucket collects everything slower.
+var ProfileBuckets = []time.Duration{
+	10 * time.Microsecond,
+	50 * time.Microsecond,
+	100 * time.Microsecond,
+	500 * time.Microsecond,
+	time.Millisecond,
+	5 * time.Millisecond,
+	10 * time.Millisecond,
+	50 * time.Millisecond,
+}
+
+// Allocation counters read around OnTick on every profileAllocEvery-th tick
+// (reading them costs more than most systems' OnTick, so not every tick).
+// runtime/metrics avoids the stop-the-world of ReadMemStats; the runtime
+// flushes small allocations a span at a time, so per-call counts are
+// approximate but rank systems reliably over the window.
+var profileMetrics = []string{"/gc/heap/allocs:objects", "/gc/heap/allocs:bytes"}
+
+const profileAllocEvery = 10
+
+type profileSample struct {
+	tick    int64
+	wall    time.Duration
+	sampled bool // allocs/bytes were measured
+	allocs  uint64
+	bytes   uint64
+}
+
+// sampleRing keeps the last profileWindow samples.
+type sampleRing struct {
+	buf  [profileWindow]profileSample
+	next int
+	n    int
+}
+
+func (r *sampleRing) add(s profileSample) {
+	r.buf[r.next] = s
+	r.next = (r.next + 1) % profileWindow
+	if r.n < profileWindow {
+		r.n++
+	}
+}
+
+// since returns the samples at or after tick, oldest first.
+func (r *sampleRing) since(tick int64) []profileSample {
+	out := make([]profileSample, 0, r.n)
+	for i := 0; i < r.n; i++ {
+		s := r.buf[(r.next-r.n+i+profileWindow)%profileWindow]
+		if s.tick >= tick {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+type systemProfile struct {
+	priority        int
+	schedule        Schedule
+	calls           int64
+	overruns        int64
+	lastOverrunTick int64
+	lastWarnTick    int64
+	ring            sampleRing
+}
+
+// profileMark is the clock and allocation counters at the start of a call.
+type profileMark struct {
+	at      time.Time
+	sampled bool
+	allocs  uint64
+	bytes   uint64
+}
+
+// tickProfiler records how long each tickable system's OnTick takes. Every
+// Registry has its own, so each hosted game is profiled separately.
+type tickProfiler struct {
+	mu      sync.Mutex
+	enabled bool
+	budget  time.Duration
+	systems map[string]*systemProfile
+	ticks   sampleRing // whole UpdateAllSystemsSequential passes
+	samples []metrics.Sample
+}
+
+// tickBudget is the budget profilers start with (see SetTickBudget).
+var tickBudget = DefaultTickBudget
+
+func newTickProfiler() *tickProfiler {
+	p := &tickProfiler{
+		enabled: true,
+		budget:  tickBudget,
+		systems: make(map[string]*systemProfile),
+	}
+	for _, name := range profileMetrics {
+		p.samples = append(p.samples, metrics.Sample{Name: name})
+	}
+	return p
+}
+
+func (p *tickProfiler) isEnabled() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.enabled
+}
+
+// mark reads the clock, and the allocation counters if allocs is set.
+func (p *tickProfiler) mark(allocs bool) profileMark {
+	if !allocs {
+		return profileMark{at: time.Now()}
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	metrics.Read(p.samples)
+	return profileMark{
+		at:      time.Now(),
+		sampled: true,
+		allocs:  p.samples[0].Value.Uint64(),
+		bytes:   p.samples[1].Value.Uint64(),
+	}
+}
+
+// sample closes a measurement started by mark.
+func (p *tickProfiler) sample(tick int64, start profileMark) profileSample {
+	end := p.mark(start.sampled)
+	s := profileSample{tick: tick, wall: end.at.Sub(start.at), sampled: start.sampled}
+	if s.sampled {
+		s.allocs = end.allocs - start.allocs
+		s.bytes = end.bytes - start.bytes
+	}
+	return s
+}
+
+// record stores one OnTick call and flags it if it blew the budget.
+func (p *tickProfiler) record(system TickableSystem, tick int64, start profileMark) {
+	s := p.sample(tick, start)
+	name := system.GetName()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	sp, ok := p.systems[name]
+	if !ok {
+		sp = &systemProfile{lastWarnTick: -profileWindow}
+		p.systems[name] = sp
+	}
+	sp.priority = system.GetPriority()
+	if sched, ok := system.(Scheduled); ok {
+		sp.schedule = sched.GetSchedule()
+	}
+	sp.calls++
+	sp.ring.add(s)
+	if p.budget > 0 && s.wall > p.budget {
+		sp.overruns++
+		sp.lastOverrunTick = tick
+		// Warn at most once a window per system
+		if tick-sp.lastWarnTick >= profileWindow {
+			sp.lastWarnTick = tick
+			fmt.Printf("[Profile] %s took %v at tick %d (budget %v)\n",
+				name, s.wall.Round(time.Microsecond), tick, p.budget)
+		}
+	}
+}
+
+// begin starts a tick. A tick at or before the last one profiled means a
+// new game or a load, so the old samples are dropped.
+func (p *tickProfiler) begin(tick int64) profileMark {
+	p.mu.Lock()
+	if p.ticks.n > 0 && tick <= p.ticks.buf[(p.ticks.next-1+profileWindow)%profileWindow].tick {
+		p.systems = make(map[string]*systemProfile)
+		p.ticks = sampleRing{}
+	}
+	p.mu.Unlock()
+	return p.mark(tick%profileAllocEvery == 0)
+}
+
+func (p *tickProfiler) recordTick(tick int64, start profileMark) {
+	s := p.sample(tick, start)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ticks.add(s)
+}
+
+// SetProfiling turns the default registry's tick profiling on or off.
+func SetProfiling(enabled bool) {
+	registry.SetProfiling(enabled)
+}
+
+// SetProfiling turns per-system tick profiling on or off.
+func (r *Registry) SetProfiling(enabled bool) {
+	r.profiler.mu.Lock()
+	defer r.profiler.mu.Unlock()
+	r.profiler.enabled = enabled
+}
+
+// SetTickBudget sets the per-system, per-tick wall time budget
+// (0 disables overrun flagging) for the default registry and for every
+// registry created after it.
+func SetTickBudget(budget time.Duration) {
+	tickBudget = budget
+	registry.SetTickBudget(budget)
+}
+
+// SetTickBudget sets the registry's per-system, per-tick wall time budget
+// (0 disables overrun flagging).
+func (r *Registry) SetTickBudget(budget time.Duration) {
+	r.profiler.mu.Lock()
+	defer r.profiler.mu.Unlock()
+	r.profiler.budget = budget
+}
+
+// ResetProfile discards the default registry's recorded samples.
+func ResetProfile() {
+	registry.ResetProfile()
+}
+
+// ResetProfile discards all recorded samples.
+func (r *Registry) ResetProfile() {
+	r.profiler.mu.Lock()
+	defer r.profiler.mu.Unlock()
+	r.profiler.systems = make(map[string]*systemProfile)
+	r.profiler.ticks = sampleRing{}
+}
+
+// SystemProfile summarizes one tickable system over the rolling window.
+type SystemProfile struct {
+	Name            string  `json:"name"`
+	Priority        int     `json:"priority"`
+	Interval        int64   `json:"interval"` // ticks between runs (see Schedule)
+	Phase           int64   `json:"phase"`
+	Calls           int64   `json:"calls"`          // lifetime invocations
+	WindowCalls     int     `json:"window_calls"`   // invocations in the window
+	CallsPerTick    float64 `json:"calls_per_tick"` // invocation frequency over the window
+	TotalMs         float64 `json:"total_ms"`       // wall time spent in the window
+	Share           float64 `json:"share"`          // fraction of all system time in the window
+	MeanUs          float64 `json:"mean_us"`
+	P50Us           float64 `json:"p50_us"`
+	P95Us           float64 `json:"p95_us"`
+	P99Us           float64 `json:"p99_us"`
+	MaxUs           float64 `json:"max_us"`
+	AllocsPerCall   float64 `json:"allocs_per_call"` // averaged over sampled ticks
+	BytesPerCall    float64 `json:"bytes_per_call"`
+	Histogram       []int   `json:"histogram"`         // calls per ProfileBuckets bucket
+	Overruns        int     `json:"overruns"`          // calls over budget in the window
+	TotalOverruns   int64   `json:"total_overruns"`    // lifetime
+	LastOverrunTick int64   `json:"last_overrun_tick"` // 0 if never over budget
+}
+
+// TickProfile is the rolling per-system profile of the tick loop.
+type TickProfile struct {
+	Enabled     bool            `json:"enabled"`
+	BudgetMs    float64         `json:"budget_ms"`
+	WindowTicks int             `json:"window_ticks"` // ticks covered by the window
+	Buckets     []string        `json:"buckets"`      // histogram bucket labels
+	TickMeanMs  float64         `json:"tick_mean_ms"` // all systems, per tick
+	TickP95Ms   float64         `json:"tick_p95_ms"`
+	TickMaxMs   float64         `json:"tick_max_ms"`
+	Systems     []SystemProfile `json:"systems"`     // most total time first
+	OverBudget  []string        `json:"over_budget"` // systems with overruns in the window
+}
+
+// GetTickProfile summarizes the default registry's last profileWindow ticks.
+func GetTickProfile() TickProfile {
+	return registry.TickProfile()
+}
+
+// TickProfile summarizes the registry's last profileWindow ticks.
+func (r *Registry) TickProfile() TickProfile {
+	profiler := r.profiler
+	profiler.mu.Lock()
+	defer profiler.mu.Unlock()
+
+	tp := TickProfile{
+		Enabled:    profiler.enabled,
+		BudgetMs:   toMs(profiler.budget),
+		Systems:    []SystemProfile{},
+		OverBudget: []string{},
+	}
+	for i, b := range ProfileBuckets {
+		if i == 0 {
+			tp.Buckets = append(tp.Buckets, "<"+b.String())
+		} else {
+			tp.Buckets = append(tp.Buckets, ProfileBuckets[i-1].String()+"-"+b.String())
+		}
+	}
+	tp.Buckets = append(tp.Buckets, ">"+ProfileBuckets[len(ProfileBuckets)-1].String())
+
+	ticks := profiler.ticks.since(0)
+	if len(ticks) == 0 {
+		return tp
+	}
+	tp.WindowTicks = len(ticks)
+	tickWalls := sortedWalls(ticks)
+	tp.TickMeanMs = toMs(meanWall(tickWalls))
+	tp.TickP95Ms = toMs(percentileWall(tickWalls, 0.95))
+	tp.TickMaxMs = toMs(tickWalls[len(tickWalls)-1])
+	windowStart := ticks[0].tick
+
+	var allSystems time.Duration
+	for name, sp := range profiler.systems {
+		samples := sp.ring.since(windowStart)
+		if len(samples) == 0 {
+			continue
+		}
+		sw := sortedWalls(samples)
+		var total time.Duration
+		var allocs, bytes uint64
+		sampled := 0
+		hist := make([]int, len(ProfileBuckets)+1)
+		overruns := 0
+		for _, s := range samples {
+			total += s.wall
+			if s.sampled {
+				sampled++
+				allocs += s.allocs
+				bytes += s.bytes
+			}
+			hist[sort.Search(len(ProfileBuckets), func(i int) bool { return s.wall < ProfileBuckets[i] })]++
+			if profiler.budget > 0 && s.wall > profiler.budget {
+				overruns++
+			}
+		}
+		allSystems += total
+		n := float64(len(samples))
+		perCall := func(v uint64) float64 {
+			if sampled == 0 {
+				return 0
+			}
+			return float64(v) / float64(sampled)
+		}
+		tp.Systems = append(tp.Systems, SystemProfile{
+			Name:            name,
+			Priority:        sp.priority,
+			Interval:        sp.schedule.Interval,
+			Phase:           sp.schedule.Phase,
+			Calls:           sp.calls,
+			WindowCalls:     len(samples),
+			CallsPerTick:    n / float64(tp.WindowTicks),
+			TotalMs:         toMs(total),
+			MeanUs:          toUs(meanWall(sw)),
+			P50Us:           toUs(percentileWall(sw, 0.50)),
+			P95Us:           toUs(percentileWall(sw, 0.95)),
+			P99Us:           toUs(percentileWall(sw, 0.99)),
+			MaxUs:           toUs(sw[len(sw)-1]),
+			AllocsPerCall:   perCall(allocs),
+			BytesPerCall:    perCall(bytes),
+			Histogram:       hist,
+			Overruns:        overruns,
+			TotalOverruns:   sp.overruns,
+			LastOverrunTick: sp.lastOverrunTick,
+		})
+		if overruns > 0 {
+			tp.OverBudget = append(tp.OverBudget, name)
+		}
+	}
+
+	sort.Slice(tp.Systems, func(i, j int) bool { return tp.Systems[i].TotalMs > tp.Systems[j].TotalMs })
+	sort.Strings(tp.OverBudget)
+	if allSystems > 0 {
+		for i := range tp.Systems {
+			tp.Systems[i].Share = tp.Systems[i].TotalMs / toMs(allSystems)
+		}
+	}
+	return tp
+}
+
+// sortedWalls returns the sorted wall times of samples.
+func sortedWalls(samples []profileSample) []time.Duration {
+	out := make([]time.Duration, len(samples))
+	for i, s := range samples {
+		out[i] = s.wall
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i] < out[j] })
+	return out
+}
+
+func meanWall(sorted []time.Duration) time.Duration {
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	return total / time.Duration(len(sorted))
+}
+
+func percentileWall(sorted []time.Duration, q float64) time.Duration {
+	return sorted[int(q*float64(len(sorted)-1))]
+}
+
+func toMs(d time.Duration) float64 { return float64(d) / float64(time.Millisecond) }
+func toUs(d time.Duration) float64 { return float64(d) / float64(time.Microsecond) }
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ProspectingSystem{
-		BaseSystem: NewBaseSystem("Prospecting", 57),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ProspectingSystem{
+			BaseSystem: NewBaseSystem("Prospecting", 57).Every(1000),
+		}
 	})
 }
 
@@ -38,10 +39,6 @@ type ProspectingSystem struct {
 }
 
 func (ps *ProspectingSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := ps.GetContext()
 	if ctx == nil {
 		return
@@ -67,12 +64,12 @@ func (ps *ProspectingSystem) OnTick(tick int64) {
 
 			pid := planet.GetID()
 			if ps.nextCheck[pid] == 0 {
-				ps.nextCheck[pid] = tick + 3000 + int64(rand.Intn(5000))
+				ps.nextCheck[pid] = tick + 3000 + int64(ps.Rand().Intn(5000))
 			}
 			if tick < ps.nextCheck[pid] {
 				continue
 			}
-			ps.nextCheck[pid] = tick + 5000 + int64(rand.Intn(8000))
+			ps.nextCheck[pid] = tick + 5000 + int64(ps.Rand().Intn(8000))
 
 			// Must have at least one mine (mining infrastructure)
 			hasMine := false
@@ -100,7 +97,7 @@ func (ps *ProspectingSystem) OnTick(tick int64) {
 				chance += 5
 			}
 
-			if rand.Intn(100) >= chance {
+			if ps.Rand().Intn(100) >= chance {
 				continue // no discovery this time
 			}
 
@@ -160,7 +157,7 @@ func (ps *ProspectingSystem) discoverResource(planet *entities.Planet, game Game
 	for _, c := range candidates {
 		totalWeight += c.weight
 	}
-	roll := rand.Intn(totalWeight)
+	roll := ps.Rand().Intn(totalWeight)
 	selected := candidates[0].resType
 	for _, c := range candidates {
 		roll -= c.weight
@@ -171,17 +168,17 @@ func (ps *ProspectingSystem) discoverResource(planet *entities.Planet, game Game
 	}
 
 	// Create the new resource deposit
-	abundance := 10 + rand.Intn(20) // starts small
+	abundance := 10 + ps.Rand().Intn(20) // starts small
 	newRes := &entities.Resource{
 		BaseEntity: entities.BaseEntity{
-			ID:   rand.Intn(900000000) + 100000000,
+			ID:   ps.Rand().Intn(900000000) + 100000000,
 			Name: fmt.Sprintf("%s Deposit", selected),
 			Type: entities.EntityTypeResource,
 			Color: resourceColor(selected),
 		},
 		ResourceType:   selected,
 		Abundance:      abundance,
-		ExtractionRate: 0.5 + rand.Float64()*0.3,
+		ExtractionRate: 0.5 + ps.Rand().Float64()*0.3,
 		Value:          resourceValue(selected),
 		Rarity:         prospectRarity(selected),
 		Owner:          planet.Owner,
@@ -242,3 +239,28 @@ func prospectRarity(res string) string {
 		return "Common"
 	}
 }
+
+// prospectingState is the persisted form of ProspectingSystem.
+type prospectingState struct {
+	NextCheck map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ps *ProspectingSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(prospectingState{
+		NextCheck: ps.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ps *ProspectingSystem) Restore(data []byte) error {
+	var state prospectingState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ps.nextCheck = state.NextCheck
+	if ps.nextCheck == nil {
+		ps.nextCheck = make(map[int]int64)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package tickable
+
+import (
+	"hash/fnv"
+	"math/rand"
+	"sync"
+)
+
+// RandSource hands out the per-tick, per-system RNGs exposed through
+// SystemContext.GetRand. Every system gets its own stream seeded from
+// (galaxy seed, tick, system name), so the numbers a system draws don't
+// depend on how many numbers other systems drew, on registry order, or on
+// wall-clock time. Two runs from the same seed and command stream draw
+// identical numbers.
+type RandSource struct {
+	mu   sync.Mutex
+	seed int64
+	tick int64
+	rngs map[string]*rand.Rand
+}
+
+// NewRandSource creates a RandSource for the given galaxy seed.
+func NewRandSource(seed int64) *RandSource {
+	return &RandSource{
+		seed: seed,
+		tick: -1,
+		rngs: make(map[string]*rand.Rand),
+	}
+}
+
+// Get returns the RNG for a system at a tick. Repeated calls within the
+// same tick return the same *rand.Rand so the stream continues rather
+// than restarting; the cache is dropped when the tick advances.
+func (rs *RandSource) Get(tick int64, system string) *rand.Rand {
+	rs.mu.Lock()
+	defer rs.mu.Unlock()
+
+	if tick != rs.tick {
+		rs.tick = tick
+		clear(rs.rngs)
+	}
+	rng, ok := rs.rngs[system]
+	if !ok {
+		rng = rand.New(&splitMix64{state: uint64(DeriveSeed(rs.seed, tick, system))})
+		rs.rngs[system] = rng
+	}
+	return rng
+}
+
+// DeriveSeed mixes the galaxy seed, a tick and a system name into a
+// well-distributed 64-bit seed.
+func DeriveSeed(seed, tick int64, system string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(system))
+	x := mix64(uint64(seed) ^ h.Sum64())
+	x = mix64(x ^ uint64(tick))
+	return int64(x)
+}
+
+// mix64 is the splitmix64 finalizer.
+func mix64(z uint64) uint64 {
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// splitMix64 is a tiny rand.Source64. The stdlib source carries ~5KB of
+// state, too heavy to create for every system on every tick.
+type splitMix64 struct {
+	state uint64
+}
+
+func (s *splitMix64) Seed(seed int64) { s.state = uint64(seed) }
+
+func (s *splitMix64) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	return mix64(s.state)
+}
+
+func (s *splitMix64) Int63() int64 { return int64(s.Uint64() >> 1) }
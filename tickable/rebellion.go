@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&RebellionSystem{
-		BaseSystem: NewBaseSystem("Rebellion", 48),
+	RegisterSystemFunc(func() TickableSystem {
+		return &RebellionSystem{
+			BaseSystem: NewBaseSystem("Rebellion", 48).Every(500),
+		}
 	})
 }
 
@@ -35,10 +36,6 @@ type RebellionSystem struct {
 }
 
 func (rs *RebellionSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := rs.GetContext()
 	if ctx == nil {
 		return
@@ -128,10 +125,10 @@ func (rs *RebellionSystem) evaluatePlanet(tick int64, planet *entities.Planet, s
 
 	// Stage 2: Riots (5000+ ticks) — damage buildings, population flees
 	if unrestDuration >= 5000 && unrestDuration < 8000 {
-		if rand.Intn(3) == 0 {
+		if rs.Rand().Intn(3) == 0 {
 			// Damage a random building (never the Base)
 			if len(planet.Buildings) > 0 {
-				idx := rand.Intn(len(planet.Buildings))
+				idx := rs.Rand().Intn(len(planet.Buildings))
 				if b, ok := planet.Buildings[idx].(*entities.Building); ok && b.IsOperational && b.BuildingType != entities.BuildingBase {
 					b.IsOperational = false
 					game.LogEvent("alert", planet.Owner,
@@ -182,3 +179,34 @@ func (rs *RebellionSystem) evaluatePlanet(tick int64, planet *entities.Planet, s
 				planet.Name, oldOwner))
 	}
 }
+
+// rebellionState is the persisted form of RebellionSystem.
+type rebellionState struct {
+	UnrestTimers map[int]int64
+	Warned       map[int]bool
+}
+
+// Snapshot implements Snapshotter.
+func (rs *RebellionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(rebellionState{
+		UnrestTimers: rs.unrestTimers,
+		Warned:       rs.warned,
+	})
+}
+
+// Restore implements Snapshotter.
+func (rs *RebellionSystem) Restore(data []byte) error {
+	var state rebellionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	rs.unrestTimers = state.UnrestTimers
+	if rs.unrestTimers == nil {
+		rs.unrestTimers = make(map[int]int64)
+	}
+	rs.warned = state.Warned
+	if rs.warned == nil {
+		rs.warned = make(map[int]bool)
+	}
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&RefineryProductionSystem{
-		BaseSystem: NewBaseSystem("RefineryProduction", 15),
+	RegisterSystemFunc(func() TickableSystem {
+		return &RefineryProductionSystem{
+			BaseSystem: NewBaseSystem("RefineryProduction", 15).Every(10),
+		}
 	})
 }
 
@@ -17,11 +19,6 @@ type RefineryProductionSystem struct {
 
 // OnTick processes refinery production each tick
 func (rps *RefineryProductionSystem) OnTick(tick int64) {
-	// Process refineries every 10 ticks (once per second) to match resource accumulation rate
-	if tick%10 != 0 {
-		return
-	}
-
 	context := rps.GetContext()
 	if context == nil {
 		return
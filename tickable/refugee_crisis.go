@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&RefugeeCrisisSystem{
-		BaseSystem: NewBaseSystem("RefugeeCrisis", 83),
+	RegisterSystemFunc(func() TickableSystem {
+		return &RefugeeCrisisSystem{
+			BaseSystem: NewBaseSystem("RefugeeCrisis", 83).Every(1000),
+		}
 	})
 }
 
@@ -36,10 +37,6 @@ type RefugeeCrisisSystem struct {
 }
 
 func (rcs *RefugeeCrisisSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := rcs.GetContext()
 	if ctx == nil {
 		return
@@ -74,7 +71,7 @@ func (rcs *RefugeeCrisisSystem) OnTick(tick int64) {
 			}
 
 			// 10% chance per check when conditions met
-			if rand.Intn(10) != 0 {
+			if rcs.Rand().Intn(10) != 0 {
 				continue
 			}
 
@@ -86,7 +83,7 @@ func (rcs *RefugeeCrisisSystem) OnTick(tick int64) {
 
 func (rcs *RefugeeCrisisSystem) generateCrisis(source *entities.Planet, sourceSys *entities.System, systems []*entities.System, game GameProvider) {
 	// 10-20% of population flees
-	fleeRate := 0.10 + rand.Float64()*0.10
+	fleeRate := 0.10 + rcs.Rand().Float64()*0.10
 	refugees := int64(float64(source.Population) * fleeRate)
 	if refugees < 500 {
 		return
@@ -141,3 +138,28 @@ func (rcs *RefugeeCrisisSystem) generateCrisis(source *entities.Planet, sourceSy
 	}
 	game.LogEvent("event", source.Owner, msg)
 }
+
+// refugeeCrisisState is the persisted form of RefugeeCrisisSystem.
+type refugeeCrisisState struct {
+	LastCrisis map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (rcs *RefugeeCrisisSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(refugeeCrisisState{
+		LastCrisis: rcs.lastCrisis,
+	})
+}
+
+// Restore implements Snapshotter.
+func (rcs *RefugeeCrisisSystem) Restore(data []byte) error {
+	var state refugeeCrisisState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	rcs.lastCrisis = state.LastCrisis
+	if rcs.lastCrisis == nil {
+		rcs.lastCrisis = make(map[int]int64)
+	}
+	return nil
+}
@@ -1,6 +1,8 @@
 package tickable
 
 import (
+	"math/rand"
+
 	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
 )
@@ -27,6 +29,18 @@ type TickableSystem interface {
 	Initialize(context SystemContext)
 }
 
+// Snapshotter is optionally implemented by tickable systems that keep
+// in-memory state (loans, active events, timers) which must survive a
+// save/load cycle. SaveGame collects every Snapshot keyed by system name
+// and LoadGame hands each section back to Restore.
+type Snapshotter interface {
+	// Snapshot serializes the system's persistent state
+	Snapshot() ([]byte, error)
+
+	// Restore replaces the system's state with a previously taken snapshot
+	Restore(data []byte) error
+}
+
 // GameProvider is the typed interface that tickable systems use to access game functionality.
 // It replaces the previous interface{}-based GetGame() pattern, giving compile-time safety.
 type GameProvider interface {
@@ -60,11 +74,17 @@ type GameProvider interface {
 	SellAtDock(ship *entities.Ship, resource string, qty int) (int, int, error)
 	// Credit limits
 	GetCreditLedger() *economy.CreditLedger
-	// Order book + contracts + diplomacy + auctions
+	// Credit movements and trading P&L
+	GetLedger() *economy.Ledger
+	// OHLCV price candles
+	GetCandleHistory() *economy.CandleHistory
+	// Order book + futures + contracts + diplomacy + auctions + espionage
 	GetOrderBook() *economy.OrderBook
+	GetFuturesExchange() *economy.FuturesExchange
 	GetContractManager() *economy.ContractManager
 	GetDiplomacyManager() *economy.DiplomacyManager
 	GetAuctionHouse() *economy.AuctionHouse
+	GetEspionageManager() *economy.EspionageManager
 	// Shipping routes
 	GetShippingRoutes() []ShippingRouteInfo
 	CompleteShippingTrip(routeID int)
@@ -90,39 +110,87 @@ type SystemContext interface {
 	GetGame() GameProvider
 	GetPlayers() []*entities.Player
 	GetTick() int64
+	// GetRand returns the named system's RNG for the current tick, seeded
+	// from the galaxy seed so simulation runs are reproducible.
+	GetRand(system string) *rand.Rand
 }
 
-// Registry holds all registered tickable systems
-var registry []TickableSystem
+// Registry is one game's set of tickable systems, in priority order. Each
+// system keeps per-game state, so every simulation running in the process
+// needs its own Registry (see NewRegistry).
+type Registry struct {
+	systems   []TickableSystem
+	afterTick func(system TickableSystem, tick int64)
+	overrides map[string]Schedule // schedules changed at runtime, by system name
+	profiler  *tickProfiler
+}
+
+// registry holds the default game's systems. The package-level functions
+// below operate on it; the GUI and a single headless server only ever use it.
+var registry = &Registry{profiler: newTickProfiler()}
+
+// factories build a fresh instance of each system for NewRegistry.
+var factories []func() TickableSystem
 
-// RegisterSystem adds a system to the registry
+// RegisterSystemFunc registers a system by constructor, adding one instance
+// to the default registry and a fresh one to every NewRegistry.
 // This should be called in init() functions of system files
+func RegisterSystemFunc(newSystem func() TickableSystem) {
+	factories = append(factories, newSystem)
+	RegisterSystem(newSystem())
+}
+
+// RegisterSystem adds a system instance to the default registry only
 func RegisterSystem(system TickableSystem) {
-	registry = append(registry, system)
-	sortRegistryByPriority()
+	registry.Add(system)
 }
 
-// sortRegistryByPriority sorts systems by their priority
-func sortRegistryByPriority() {
+// NewRegistry builds a registry with a fresh instance of every system
+// registered with RegisterSystemFunc, for hosting another game alongside
+// the default one.
+func NewRegistry() *Registry {
+	r := &Registry{profiler: newTickProfiler()}
+	for _, newSystem := range factories {
+		r.systems = append(r.systems, newSystem())
+	}
+	r.sortByPriority()
+	r.spreadPhases()
+	return r
+}
+
+// DefaultRegistry returns the default game's systems.
+func DefaultRegistry() *Registry {
+	return registry
+}
+
+// Add adds a system to the registry
+func (r *Registry) Add(system TickableSystem) {
+	r.systems = append(r.systems, system)
+	r.sortByPriority()
+	r.spreadPhases()
+}
+
+// sortByPriority sorts systems by their priority
+func (r *Registry) sortByPriority() {
 	// Simple bubble sort (fine for small number of systems)
-	n := len(registry)
+	n := len(r.systems)
 	for i := 0; i < n-1; i++ {
 		for j := 0; j < n-i-1; j++ {
-			if registry[j].GetPriority() > registry[j+1].GetPriority() {
-				registry[j], registry[j+1] = registry[j+1], registry[j]
+			if r.systems[j].GetPriority() > r.systems[j+1].GetPriority() {
+				r.systems[j], r.systems[j+1] = r.systems[j+1], r.systems[j]
 			}
 		}
 	}
 }
 
-// GetAllSystems returns all registered systems
-func GetAllSystems() []TickableSystem {
-	return registry
+// Systems returns all systems in run order
+func (r *Registry) Systems() []TickableSystem {
+	return r.systems
 }
 
-// GetSystemByName finds a system by name
-func GetSystemByName(name string) TickableSystem {
-	for _, system := range registry {
+// SystemByName finds a system by name
+func (r *Registry) SystemByName(name string) TickableSystem {
+	for _, system := range r.systems {
 		if system.GetName() == name {
 			return system
 		}
@@ -130,52 +198,103 @@ func GetSystemByName(name string) TickableSystem {
 	return nil
 }
 
-// UpdateAllSystemsSequential updates systems one by one in priority order.
+// SetAfterTick sets fn to run after each system's OnTick, e.g. to see what
+// the system changed.
+func (r *Registry) SetAfterTick(fn func(system TickableSystem, tick int64)) {
+	r.afterTick = fn
+}
+
+// UpdateSequential updates systems one by one in priority order.
 // Sequential execution ensures correct data dependencies (Power→Happiness→Resources→Population).
-func UpdateAllSystemsSequential(tick int64) {
-	for _, system := range registry {
-		if system.IsEnabled() {
+// Systems are skipped on ticks their Schedule isn't due (see schedule.go).
+// Each call is timed for the registry's tick profile (see profile.go)
+// unless profiling is off.
+func (r *Registry) UpdateSequential(tick int64) {
+	profiler := r.profiler
+	if profiler == nil || !profiler.isEnabled() {
+		for _, system := range r.systems {
+			if system.IsEnabled() && isDue(system, tick) {
+				system.OnTick(tick)
+				if r.afterTick != nil {
+					r.afterTick(system, tick)
+				}
+			}
+		}
+		return
+	}
+
+	tickStart := profiler.begin(tick)
+	for _, system := range r.systems {
+		if system.IsEnabled() && isDue(system, tick) {
+			start := profiler.mark(tickStart.sampled)
 			system.OnTick(tick)
+			profiler.record(system, tick, start)
+			if r.afterTick != nil {
+				r.afterTick(system, tick)
+			}
 		}
 	}
+	profiler.recordTick(tick, tickStart)
 }
 
-// InitializeAllSystems initializes all registered systems with context
-func InitializeAllSystems(context SystemContext) {
-	for _, system := range registry {
+// Initialize initializes every system with context
+func (r *Registry) Initialize(context SystemContext) {
+	for _, system := range r.systems {
 		system.Initialize(context)
 	}
 }
 
+// SetEnabled enables or disables a system by name. Returns false if the
+// registry has no such system.
+func (r *Registry) SetEnabled(name string, enabled bool) bool {
+	system := r.SystemByName(name)
+	if system == nil {
+		return false
+	}
+	system.SetEnabled(enabled)
+	return true
+}
+
+// GetAllSystems returns all registered systems
+func GetAllSystems() []TickableSystem {
+	return registry.Systems()
+}
+
+// GetSystemByName finds a system by name
+func GetSystemByName(name string) TickableSystem {
+	return registry.SystemByName(name)
+}
+
+// UpdateAllSystemsSequential updates the default registry's systems (see
+// Registry.UpdateSequential).
+func UpdateAllSystemsSequential(tick int64) {
+	registry.UpdateSequential(tick)
+}
+
+// InitializeAllSystems initializes all registered systems with context
+func InitializeAllSystems(context SystemContext) {
+	registry.Initialize(context)
+}
+
 // EnableSystem enables a system by name
 func EnableSystem(name string) bool {
-	system := GetSystemByName(name)
-	if system != nil {
-		system.SetEnabled(true)
-		return true
-	}
-	return false
+	return registry.SetEnabled(name, true)
 }
 
 // DisableSystem disables a system by name
 func DisableSystem(name string) bool {
-	system := GetSystemByName(name)
-	if system != nil {
-		system.SetEnabled(false)
-		return true
-	}
-	return false
+	return registry.SetEnabled(name, false)
 }
 
 // GetSystemCount returns the number of registered systems
 func GetSystemCount() int {
-	return len(registry)
+	return len(registry.systems)
 }
 
 // GetEnabledSystemCount returns the number of enabled systems
 func GetEnabledSystemCount() int {
 	count := 0
-	for _, system := range registry {
+	for _, system := range registry.systems {
 		if system.IsEnabled() {
 			count++
 		}
@@ -185,5 +304,6 @@ func GetEnabledSystemCount() int {
 
 // ClearRegistry clears all registered systems (useful for testing)
 func ClearRegistry() {
-	registry = make([]TickableSystem, 0)
+	registry = &Registry{profiler: newTickProfiler()}
+	factories = nil
 }
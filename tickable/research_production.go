@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ResearchProductionSystem{
-		BaseSystem: NewBaseSystem("ResearchProduction", 17),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResearchProductionSystem{
+			BaseSystem: NewBaseSystem("ResearchProduction", 17).Every(10),
+		}
 	})
 }
 
@@ -19,10 +21,6 @@ type ResearchProductionSystem struct {
 }
 
 func (rps *ResearchProductionSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := rps.GetContext()
 	if ctx == nil {
 		return
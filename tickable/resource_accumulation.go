@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ResourceAccumulationSystem{
-		BaseSystem: NewBaseSystem("ResourceAccumulation", 10),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResourceAccumulationSystem{
+			BaseSystem: NewBaseSystem("ResourceAccumulation", 10).Every(10).At(0),
+		}
 	})
 }
 
@@ -17,10 +19,6 @@ type ResourceAccumulationSystem struct {
 }
 
 func (ras *ResourceAccumulationSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	context := ras.GetContext()
 	if context == nil {
 		return
@@ -53,7 +51,7 @@ func (ras *ResourceAccumulationSystem) OnTick(tick int64) {
 
 					// Depletion: lose 1 abundance per 10,000 ticks (~17 min at 1x).
 					// Deposits bottom out at 10 (still produce, just slower).
-					if resource.Abundance > 10 && tick%10000 == 0 {
+					if resource.Abundance > 10 && ras.DueEvery(tick, 10000) {
 						resource.Abundance--
 					}
 				}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ResourceConversionSystem{
-		BaseSystem: NewBaseSystem("ResourceConversion", 96),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResourceConversionSystem{
+			BaseSystem: NewBaseSystem("ResourceConversion", 96).Every(1000),
+		}
 	})
 }
 
@@ -52,10 +53,6 @@ var advConversions = []advConversion{
 }
 
 func (rcs *ResourceConversionSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := rcs.GetContext()
 	if ctx == nil {
 		return
@@ -106,7 +103,7 @@ func (rcs *ResourceConversionSystem) OnTick(tick int64) {
 				planet.RemoveStoredResource(conv.inputB, conv.inputQty)
 				planet.AddStoredResource(conv.output, conv.outputQty)
 
-				if rand.Intn(5) == 0 { // don't spam
+				if rcs.Rand().Intn(5) == 0 { // don't spam
 					game.LogEvent("trade", planet.Owner,
 						fmt.Sprintf("🏭 %s: Factory converted %d %s + %d %s → %d %s",
 							planet.Name, conv.inputQty, conv.inputA,
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ResourceDepletionSystem{
-		BaseSystem: NewBaseSystem("ResourceDepletion", 14),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResourceDepletionSystem{
+			BaseSystem: NewBaseSystem("ResourceDepletion", 14).Every(200),
+		}
 	})
 }
 
@@ -41,10 +42,6 @@ type ResourceDepletionSystem struct {
 }
 
 func (rds *ResourceDepletionSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := rds.GetContext()
 	if ctx == nil {
 		return
@@ -126,9 +123,34 @@ func (rds *ResourceDepletionSystem) processDepletion(tick int64, planet *entitie
 			}
 		} else if !hasMine && res.Abundance > 0 && res.Abundance < 100 {
 			// Slow natural recovery when not being mined
-			if rand.Intn(5) == 0 {
+			if rds.Rand().Intn(5) == 0 {
 				res.Abundance++
 			}
 		}
 	}
 }
+
+// resourceDepletionState is the persisted form of ResourceDepletionSystem.
+type resourceDepletionState struct {
+	DepletionLog map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (rds *ResourceDepletionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(resourceDepletionState{
+		DepletionLog: rds.depletionLog,
+	})
+}
+
+// Restore implements Snapshotter.
+func (rds *ResourceDepletionSystem) Restore(data []byte) error {
+	var state resourceDepletionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	rds.depletionLog = state.DepletionLog
+	if rds.depletionLog == nil {
+		rds.depletionLog = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ResourceDiscoveryBonusSystem{
-		BaseSystem: NewBaseSystem("ResourceDiscoveryBonus", 156),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResourceDiscoveryBonusSystem{
+			BaseSystem: NewBaseSystem("ResourceDiscoveryBonus", 156).Every(2000),
+		}
 	})
 }
 
@@ -46,10 +47,6 @@ var discoveryBonuses = map[string]int{
 }
 
 func (rdbs *ResourceDiscoveryBonusSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := rdbs.GetContext()
 	if ctx == nil {
 		return
@@ -109,6 +106,35 @@ func (rdbs *ResourceDiscoveryBonusSystem) OnTick(tick int64) {
 					player.Name))
 		}
 	}
+}
+
+// resourceDiscoveryBonusState is the persisted form of ResourceDiscoveryBonusSystem.
+type resourceDiscoveryBonusState struct {
+	Discovered map[string]map[string]bool
+	AllSeven   map[string]bool
+}
+
+// Snapshot implements Snapshotter.
+func (rdbs *ResourceDiscoveryBonusSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(resourceDiscoveryBonusState{
+		Discovered: rdbs.discovered,
+		AllSeven:   rdbs.allSeven,
+	})
+}
 
-	_ = rand.Intn // suppress
+// Restore implements Snapshotter.
+func (rdbs *ResourceDiscoveryBonusSystem) Restore(data []byte) error {
+	var state resourceDiscoveryBonusState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	rdbs.discovered = state.Discovered
+	if rdbs.discovered == nil {
+		rdbs.discovered = make(map[string]map[string]bool)
+	}
+	rdbs.allSeven = state.AllSeven
+	if rdbs.allSeven == nil {
+		rdbs.allSeven = make(map[string]bool)
+	}
+	return nil
 }
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ResourceForecastSystem{
-		BaseSystem: NewBaseSystem("ResourceForecast", 139),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResourceForecastSystem{
+			BaseSystem: NewBaseSystem("ResourceForecast", 139).Every(1000),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type ResourceForecastSystem struct {
 }
 
 func (rfs *ResourceForecastSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := rfs.GetContext()
 	if ctx == nil {
 		return
@@ -103,7 +100,7 @@ func (rfs *ResourceForecastSystem) OnTick(tick int64) {
 					minutesLeft = 1
 				}
 
-				if rand.Intn(2) == 0 { // don't spam every check
+				if rfs.Rand().Intn(2) == 0 { // don't spam every check
 					game.LogEvent("alert", planet.Owner,
 						fmt.Sprintf("⚠️ %s: %s depleting! %d remaining, ~%d min until zero at current rate. Import or reduce consumption!",
 							planet.Name, res, current, minutesLeft))
@@ -112,3 +109,34 @@ func (rfs *ResourceForecastSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// resourceForecastState is the persisted form of ResourceForecastSystem.
+type resourceForecastState struct {
+	PrevStored map[int]map[string]int
+	LastWarn   map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (rfs *ResourceForecastSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(resourceForecastState{
+		PrevStored: rfs.prevStored,
+		LastWarn:   rfs.lastWarn,
+	})
+}
+
+// Restore implements Snapshotter.
+func (rfs *ResourceForecastSystem) Restore(data []byte) error {
+	var state resourceForecastState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	rfs.prevStored = state.PrevStored
+	if rfs.prevStored == nil {
+		rfs.prevStored = make(map[int]map[string]int)
+	}
+	rfs.lastWarn = state.LastWarn
+	if rfs.lastWarn == nil {
+		rfs.lastWarn = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ResourceScarcitySystem{
-		BaseSystem: NewBaseSystem("ResourceScarcity", 45),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ResourceScarcitySystem{
+			BaseSystem: NewBaseSystem("ResourceScarcity", 45).Every(500),
+		}
 	})
 }
 
@@ -45,10 +46,6 @@ type ScarcityCycle struct {
 }
 
 func (rss *ResourceScarcitySystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := rss.GetContext()
 	if ctx == nil {
 		return
@@ -60,7 +57,7 @@ func (rss *ResourceScarcitySystem) OnTick(tick int64) {
 	}
 
 	if rss.nextCycle == 0 {
-		rss.nextCycle = tick + 5000 + int64(rand.Intn(10000))
+		rss.nextCycle = tick + 5000 + int64(rss.Rand().Intn(10000))
 	}
 
 	// Process active cycle
@@ -77,7 +74,7 @@ func (rss *ResourceScarcitySystem) OnTick(tick int64) {
 					Resource:  rss.activeCycle.Resource,
 					Phase:     "boom",
 					Intensity: 1.5,
-					TicksLeft: 5000 + rand.Intn(5000),
+					TicksLeft: 5000 + rss.Rand().Intn(5000),
 				}
 			} else {
 				// Boom ends
@@ -91,7 +88,7 @@ func (rss *ResourceScarcitySystem) OnTick(tick int64) {
 
 	// Start new cycle
 	if rss.activeCycle == nil && tick >= rss.nextCycle {
-		rss.nextCycle = tick + 20000 + int64(rand.Intn(15000))
+		rss.nextCycle = tick + 20000 + int64(rss.Rand().Intn(15000))
 		rss.startNewCycle(game)
 	}
 
@@ -104,13 +101,13 @@ func (rss *ResourceScarcitySystem) OnTick(tick int64) {
 func (rss *ResourceScarcitySystem) startNewCycle(game GameProvider) {
 	resources := []string{entities.ResOil, entities.ResIron, entities.ResWater,
 		entities.ResRareMetals, entities.ResHelium3}
-	res := resources[rand.Intn(len(resources))]
+	res := resources[rss.Rand().Intn(len(resources))]
 
 	rss.activeCycle = &ScarcityCycle{
 		Resource:  res,
 		Phase:     "scarcity",
-		Intensity: 0.5 + rand.Float64()*0.3, // 50-80% of normal production
-		TicksLeft: 5000 + rand.Intn(5000),
+		Intensity: 0.5 + rss.Rand().Float64()*0.3, // 50-80% of normal production
+		TicksLeft: 5000 + rss.Rand().Intn(5000),
 	}
 
 	market := game.GetMarketEngine()
@@ -155,3 +152,31 @@ func (rss *ResourceScarcitySystem) GetProductionMultiplier(resource string) floa
 	}
 	return 1.0
 }
+
+// resourceScarcityState is the persisted form of ResourceScarcitySystem.
+type resourceScarcityState struct {
+	ActiveCycle *ScarcityCycle
+	NextCycle   int64
+	History     []string
+}
+
+// Snapshot implements Snapshotter.
+func (rss *ResourceScarcitySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(resourceScarcityState{
+		ActiveCycle: rss.activeCycle,
+		NextCycle:   rss.nextCycle,
+		History:     rss.history,
+	})
+}
+
+// Restore implements Snapshotter.
+func (rss *ResourceScarcitySystem) Restore(data []byte) error {
+	var state resourceScarcityState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	rss.activeCycle = state.ActiveCycle
+	rss.nextCycle = state.NextCycle
+	rss.history = state.History
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&RouteAutoCreateSystem{
-		BaseSystem: NewBaseSystem("RouteAutoCreate", 159),
+	RegisterSystemFunc(func() TickableSystem {
+		return &RouteAutoCreateSystem{
+			BaseSystem: NewBaseSystem("RouteAutoCreate", 159).Every(5000),
+		}
 	})
 }
 
@@ -31,10 +33,6 @@ type RouteAutoCreateSystem struct {
 }
 
 func (racs *RouteAutoCreateSystem) OnTick(tick int64) {
-	if tick%5000 != 0 {
-		return
-	}
-
 	ctx := racs.GetContext()
 	if ctx == nil {
 		return
@@ -143,3 +141,28 @@ func (racs *RouteAutoCreateSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// routeAutoCreateState is the persisted form of RouteAutoCreateSystem.
+type routeAutoCreateState struct {
+	LastCreate map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (racs *RouteAutoCreateSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(routeAutoCreateState{
+		LastCreate: racs.lastCreate,
+	})
+}
+
+// Restore implements Snapshotter.
+func (racs *RouteAutoCreateSystem) Restore(data []byte) error {
+	var state routeAutoCreateState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	racs.lastCreate = state.LastCreate
+	if racs.lastCreate == nil {
+		racs.lastCreate = make(map[string]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&RouteOptimizerSystem{
-		BaseSystem: NewBaseSystem("RouteOptimizer", 31),
+	RegisterSystemFunc(func() TickableSystem {
+		return &RouteOptimizerSystem{
+			BaseSystem: NewBaseSystem("RouteOptimizer", 31).Every(2000),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type RouteOptimizerSystem struct {
 }
 
 func (ros *RouteOptimizerSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := ros.GetContext()
 	if ctx == nil {
 		return
@@ -52,7 +49,7 @@ func (ros *RouteOptimizerSystem) OnTick(tick int64) {
 	}
 
 	if ros.nextSuggestion == 0 {
-		ros.nextSuggestion = tick + 5000 + int64(rand.Intn(5000))
+		ros.nextSuggestion = tick + 5000 + int64(ros.Rand().Intn(5000))
 	}
 
 	players := ctx.GetPlayers()
@@ -117,7 +114,7 @@ func (ros *RouteOptimizerSystem) OnTick(tick int64) {
 
 	// Generate route suggestions
 	if tick >= ros.nextSuggestion {
-		ros.nextSuggestion = tick + 8000 + int64(rand.Intn(5000))
+		ros.nextSuggestion = tick + 8000 + int64(ros.Rand().Intn(5000))
 		ros.suggestRoutes(players, systems, game)
 	}
 }
@@ -186,3 +183,31 @@ func (ros *RouteOptimizerSystem) suggestRoutes(players []*entities.Player, syste
 		}
 	}
 }
+
+// routeOptimizerState is the persisted form of RouteOptimizerSystem.
+type routeOptimizerState struct {
+	RouteAge       map[int]int64
+	NextSuggestion int64
+}
+
+// Snapshot implements Snapshotter.
+func (ros *RouteOptimizerSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(routeOptimizerState{
+		RouteAge:       ros.routeAge,
+		NextSuggestion: ros.nextSuggestion,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ros *RouteOptimizerSystem) Restore(data []byte) error {
+	var state routeOptimizerState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ros.routeAge = state.RouteAge
+	if ros.routeAge == nil {
+		ros.routeAge = make(map[int]int64)
+	}
+	ros.nextSuggestion = state.NextSuggestion
+	return nil
+}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SalvageSystem{
-		BaseSystem: NewBaseSystem("Salvage", 39),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SalvageSystem{
+			BaseSystem: NewBaseSystem("Salvage", 39).Every(100),
+		}
 	})
 }
 
@@ -40,10 +43,6 @@ type Wreckage struct {
 }
 
 func (ss *SalvageSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := ss.GetContext()
 	if ctx == nil {
 		return
@@ -117,8 +116,8 @@ func (ss *SalvageSystem) claimSalvage(game GameProvider, player *entities.Player
 
 	// Load resources into ship's cargo
 	totalLoaded := 0
-	for res, amt := range w.Resources {
-		loaded := ship.AddCargo(res, amt)
+	for _, res := range slices.Sorted(maps.Keys(w.Resources)) {
+		loaded := ship.AddCargo(res, w.Resources[res])
 		totalLoaded += loaded
 	}
 
@@ -166,7 +165,7 @@ func (ss *SalvageSystem) generateBattleSalvage(tick int64, game GameProvider, pl
 			}
 
 			// 5% chance to leave wreckage this tick
-			if rand.Intn(20) != 0 {
+			if ss.Rand().Intn(20) != 0 {
 				continue
 			}
 
@@ -180,11 +179,11 @@ func (ss *SalvageSystem) generateBattleSalvage(tick int64, game GameProvider, pl
 				SystemID:  ship.CurrentSystem,
 				Source:    "battle",
 				Resources: map[string]int{
-					entities.ResIron:       20 + rand.Intn(50),
-					entities.ResRareMetals: 5 + rand.Intn(20),
+					entities.ResIron:       20 + ss.Rand().Intn(50),
+					entities.ResRareMetals: 5 + ss.Rand().Intn(20),
 				},
-				Credits:   500 + rand.Intn(2000),
-				TicksLeft: 5000 + rand.Intn(5000),
+				Credits:   500 + ss.Rand().Intn(2000),
+				TicksLeft: 5000 + ss.Rand().Intn(5000),
 			}
 			ss.wreckage[ship.CurrentSystem] = append(ss.wreckage[ship.CurrentSystem], w)
 
@@ -211,7 +210,7 @@ func (ss *SalvageSystem) AddWreckage(systemID int, source string, resources map[
 		Source:    source,
 		Resources: resources,
 		Credits:   credits,
-		TicksLeft: 8000 + rand.Intn(4000),
+		TicksLeft: 8000 + ss.Rand().Intn(4000),
 	})
 }
 
@@ -227,3 +226,25 @@ func (ss *SalvageSystem) GetWreckageCount() int {
 	}
 	return total
 }
+
+// salvageState is the persisted form of SalvageSystem.
+type salvageState struct {
+	Wreckage map[int][]*Wreckage
+}
+
+// Snapshot implements Snapshotter.
+func (ss *SalvageSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(salvageState{
+		Wreckage: ss.wreckage,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ss *SalvageSystem) Restore(data []byte) error {
+	var state salvageState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ss.wreckage = state.Wreckage
+	return nil
+}
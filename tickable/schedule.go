@@ -0,0 +1,200 @@
+package tickable
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Schedule is the cadence a system runs at: the registry calls OnTick on
+// every tick from Start where (tick - Phase) is a multiple of Interval. The
+// zero Schedule runs every tick.
+type Schedule struct {
+	Interval int64 `json:"interval"` // ticks between runs (0 or 1 = every tick)
+	Phase    int64 `json:"phase"`    // offset within the interval
+	Fixed    bool  `json:"fixed"`    // phase set explicitly rather than spread by the registry
+	Start    int64 `json:"start"`    // first tick the system may run (warm-up)
+}
+
+// Due reports whether the schedule runs on tick.
+func (s Schedule) Due(tick int64) bool {
+	if tick < s.Start {
+		return false
+	}
+	if s.Interval <= 1 {
+		return true
+	}
+	return (tick-s.Phase)%s.Interval == 0
+}
+
+// Scheduled is implemented by systems that declare a cadence (every system
+// embedding BaseSystem does). Systems that don't are run every tick.
+type Scheduled interface {
+	GetSchedule() Schedule
+	SetSchedule(s Schedule)
+}
+
+// isDue reports whether system should run on tick.
+func isDue(system TickableSystem, tick int64) bool {
+	if s, ok := system.(Scheduled); ok {
+		return s.GetSchedule().Due(tick)
+	}
+	return true
+}
+
+// spreadPhases staggers systems that share an interval and haven't pinned
+// a phase, so e.g. every 500-tick system doesn't land on the same tick.
+// Phases depend only on the set of system names, so a given build always
+// runs systems on the same ticks and seeded games stay reproducible.
+//
+// Systems whose schedule was changed at runtime keep it.
+func (r *Registry) spreadPhases() {
+	groups := make(map[int64][]Scheduled)
+	names := make(map[Scheduled]string)
+	for _, system := range r.systems {
+		s, ok := system.(Scheduled)
+		if !ok {
+			continue
+		}
+		if _, overridden := r.overrides[system.GetName()]; overridden {
+			continue
+		}
+		sched := s.GetSchedule()
+		if sched.Interval <= 1 || sched.Fixed {
+			continue
+		}
+		groups[sched.Interval] = append(groups[sched.Interval], s)
+		names[s] = system.GetName()
+	}
+	for interval, group := range groups {
+		sort.Slice(group, func(i, j int) bool { return names[group[i]] < names[group[j]] })
+		for i, s := range group {
+			sched := s.GetSchedule()
+			sched.Phase = int64(i) * interval / int64(len(group))
+			s.SetSchedule(sched)
+		}
+	}
+}
+
+// ScheduleInfo describes one registered system's cadence.
+type ScheduleInfo struct {
+	Name     string `json:"name"`
+	Priority int    `json:"priority"`
+	Enabled  bool   `json:"enabled"`
+	Schedule
+}
+
+// GetSchedules returns every registered system's cadence in run order.
+func GetSchedules() []ScheduleInfo {
+	return registry.Schedules()
+}
+
+// Schedules returns every system's cadence in run order.
+func (r *Registry) Schedules() []ScheduleInfo {
+	out := make([]ScheduleInfo, 0, len(r.systems))
+	for _, system := range r.systems {
+		info := ScheduleInfo{Name: system.GetName(), Priority: system.GetPriority(), Enabled: system.IsEnabled()}
+		if s, ok := system.(Scheduled); ok {
+			info.Schedule = s.GetSchedule()
+		}
+		out = append(out, info)
+	}
+	return out
+}
+
+// SetSystemSchedule changes a system's cadence at runtime. A negative phase
+// leaves the phase to the registry; otherwise it is pinned.
+func SetSystemSchedule(name string, interval, phase int64) error {
+	return registry.SetSchedule(name, interval, phase)
+}
+
+// SetSchedule changes a system's cadence at runtime (see SetSystemSchedule).
+// Only the named system moves: with no pinned phase it takes the middle of
+// the widest gap left by the other systems on that interval. The change is
+// kept as an override so saves carry it (see ScheduleOverrides). Like
+// OnTick, it must run on the simulation goroutine.
+func (r *Registry) SetSchedule(name string, interval, phase int64) error {
+	system := r.SystemByName(name)
+	if system == nil {
+		return fmt.Errorf("unknown system %q", name)
+	}
+	s, ok := system.(Scheduled)
+	if !ok {
+		return fmt.Errorf("system %q does not support scheduling", name)
+	}
+	if interval < 0 {
+		return fmt.Errorf("interval must be >= 0")
+	}
+	sched := Schedule{Interval: interval, Fixed: phase >= 0, Start: s.GetSchedule().Start}
+	if interval > 1 {
+		if phase >= 0 {
+			sched.Phase = phase % interval
+		} else {
+			sched.Phase = r.freePhase(system, interval)
+		}
+	}
+	s.SetSchedule(sched)
+	if r.overrides == nil {
+		r.overrides = make(map[string]Schedule)
+	}
+	r.overrides[name] = sched
+	return nil
+}
+
+// freePhase picks a phase for system on interval: the middle of the widest
+// gap between the phases the registry's other systems on it already use.
+func (r *Registry) freePhase(system TickableSystem, interval int64) int64 {
+	var used []int64
+	for _, other := range r.systems {
+		s, ok := other.(Scheduled)
+		if !ok || other == system {
+			continue
+		}
+		if sched := s.GetSchedule(); sched.Interval == interval {
+			used = append(used, sched.Phase)
+		}
+	}
+	if len(used) == 0 {
+		return 0
+	}
+	sort.Slice(used, func(i, j int) bool { return used[i] < used[j] })
+	best, widest := int64(0), int64(-1)
+	for i, p := range used {
+		next := used[0] + interval // wrap around to the first phase
+		if i+1 < len(used) {
+			next = used[i+1]
+		}
+		if gap := next - p; gap > widest {
+			best, widest = (p+gap/2)%interval, gap
+		}
+	}
+	return best
+}
+
+// ScheduleOverrides returns the schedules changed at runtime, keyed by
+// system name.
+func (r *Registry) ScheduleOverrides() map[string]Schedule {
+	if len(r.overrides) == 0 {
+		return nil
+	}
+	out := make(map[string]Schedule, len(r.overrides))
+	for name, sched := range r.overrides {
+		out[name] = sched
+	}
+	return out
+}
+
+// RestoreScheduleOverrides reapplies saved overrides exactly as they were.
+// Overrides for systems that no longer exist are ignored.
+func (r *Registry) RestoreScheduleOverrides(overrides map[string]Schedule) {
+	for name, sched := range overrides {
+		s, ok := r.SystemByName(name).(Scheduled)
+		if !ok {
+			continue
+		}
+		s.SetSchedule(sched)
+		if r.overrides == nil {
+			r.overrides = make(map[string]Schedule)
+		}
+		r.overrides[name] = sched
+	}
+}
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ScoutSurveySystem{
-		BaseSystem: NewBaseSystem("ScoutSurvey", 45),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ScoutSurveySystem{
+			BaseSystem: NewBaseSystem("ScoutSurvey", 45).Every(200),
+		}
 	})
 }
 
@@ -21,11 +22,6 @@ type ScoutSurveySystem struct {
 }
 
 func (sss *ScoutSurveySystem) OnTick(tick int64) {
-	// Check every 200 ticks (~20 seconds)
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := sss.GetContext()
 	if ctx == nil {
 		return
@@ -63,7 +59,7 @@ func (sss *ScoutSurveySystem) OnTick(tick int64) {
 			// Discovery chance: 15% base + 1% per tech level of player's best planet
 			maxTech := playerMaxTech(player)
 			discoveryChance := 15 + int(maxTech)
-			if rand.Intn(100) > discoveryChance {
+			if sss.Rand().Intn(100) > discoveryChance {
 				continue
 			}
 
@@ -117,7 +113,7 @@ func (sss *ScoutSurveySystem) surveySystem(sys *entities.System, player *entitie
 		for _, r := range surveyableResources {
 			totalWeight += r.weight
 		}
-		roll := rand.Intn(totalWeight)
+		roll := sss.Rand().Intn(totalWeight)
 		cumulative := 0
 		selectedType := entities.ResIron
 		for _, r := range surveyableResources {
@@ -141,22 +137,22 @@ func (sss *ScoutSurveySystem) surveySystem(sys *entities.System, player *entitie
 		}
 
 		// Create the new deposit — tech improves quality
-		baseAbundance := 30 + rand.Intn(40) // 30-70
+		baseAbundance := 30 + sss.Rand().Intn(40) // 30-70
 		abundance := baseAbundance + int(techLevel*3) // +3 abundance per tech level
 		if abundance > 100 {
 			abundance = 100
 		}
-		extractionRate := 0.5 + rand.Float64()*0.5 + techLevel*0.05 // +0.05 rate per tech level
-		nodePos := rand.Float64() * 2 * math.Pi
+		extractionRate := 0.5 + sss.Rand().Float64()*0.5 + techLevel*0.05 // +0.05 rate per tech level
+		nodePos := sss.Rand().Float64() * 2 * math.Pi
 
 		deposit := &entities.Resource{
 			BaseEntity: entities.BaseEntity{
-				ID:           sys.ID*100000 + rand.Intn(10000),
+				ID:           sys.ID*100000 + sss.Rand().Intn(10000),
 				Name:         fmt.Sprintf("%s Deposit", selectedType),
 				Type:         entities.EntityTypeResource,
 				SubType:      selectedType,
 				Color:        entities.ResourceColor(selectedType),
-				OrbitDistance: 8 + rand.Float64()*4,
+				OrbitDistance: 8 + sss.Rand().Float64()*4,
 				OrbitAngle:   nodePos,
 			},
 			ResourceType:   selectedType,
@@ -164,7 +160,7 @@ func (sss *ScoutSurveySystem) surveySystem(sys *entities.System, player *entitie
 			ExtractionRate: math.Round(extractionRate*10) / 10,
 			Rarity:         resourceRarity(selectedType),
 			Size:           3,
-			Quality:        50 + rand.Intn(50),
+			Quality:        50 + sss.Rand().Intn(50),
 			Owner:          planet.Owner,
 			NodePosition:   nodePos,
 		}
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SeasonalDemandSystem{
-		BaseSystem: NewBaseSystem("SeasonalDemand", 93),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SeasonalDemandSystem{
+			BaseSystem: NewBaseSystem("SeasonalDemand", 93).Every(500),
+		}
 	})
 }
 
@@ -37,10 +38,6 @@ type SeasonalDemandSystem struct {
 }
 
 func (sds *SeasonalDemandSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := sds.GetContext()
 	if ctx == nil {
 		return
@@ -74,7 +71,7 @@ func (sds *SeasonalDemandSystem) OnTick(tick int64) {
 		// Water demand surge
 		market.AddTradeVolume(entities.ResWater, 20, true)
 		// Population bonus
-		if rand.Intn(5) == 0 {
+		if sds.Rand().Intn(5) == 0 {
 			for _, sys := range systems {
 				for _, e := range sys.Entities {
 					if planet, ok := e.(*entities.Planet); ok && planet.Owner != "" {
@@ -182,3 +179,25 @@ func (sds *SeasonalDemandSystem) announceSeason(season string, game GameProvider
 
 // Suppress unused import warning
 var _ = math.Pi
+
+// seasonalDemandState is the persisted form of SeasonalDemandSystem.
+type seasonalDemandState struct {
+	LastSeason string
+}
+
+// Snapshot implements Snapshotter.
+func (sds *SeasonalDemandSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(seasonalDemandState{
+		LastSeason: sds.lastSeason,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sds *SeasonalDemandSystem) Restore(data []byte) error {
+	var state seasonalDemandState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sds.lastSeason = state.LastSeason
+	return nil
+}
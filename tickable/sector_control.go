@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&SectorControlSystem{
-		BaseSystem: NewBaseSystem("SectorControl", 36),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SectorControlSystem{
+			BaseSystem: NewBaseSystem("SectorControl", 36).Every(500),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type SectorControlSystem struct {
 }
 
 func (scs *SectorControlSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := scs.GetContext()
 	if ctx == nil {
 		return
@@ -133,3 +131,25 @@ func (scs *SectorControlSystem) checkControl(sys *entities.System) string {
 
 	return owner
 }
+
+// sectorControlState is the persisted form of SectorControlSystem.
+type sectorControlState struct {
+	Controllers map[int]string
+}
+
+// Snapshot implements Snapshotter.
+func (scs *SectorControlSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(sectorControlState{
+		Controllers: scs.controllers,
+	})
+}
+
+// Restore implements Snapshotter.
+func (scs *SectorControlSystem) Restore(data []byte) error {
+	var state sectorControlState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	scs.controllers = state.Controllers
+	return nil
+}
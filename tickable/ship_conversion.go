@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"image/color"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ShipConversionSystem{
-		BaseSystem: NewBaseSystem("ShipConversion", 56),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShipConversionSystem{
+			BaseSystem: NewBaseSystem("ShipConversion", 56).Every(100),
+		}
 	})
 }
 
@@ -36,10 +37,6 @@ type ShipConversionSystem struct {
 }
 
 func (scs *ShipConversionSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := scs.GetContext()
 	if ctx == nil {
 		return
@@ -124,8 +121,8 @@ func (scs *ShipConversionSystem) OnTick(tick int64) {
 			delete(scs.converting, ship.GetID())
 
 			newShip := entities.NewShip(
-				rand.Intn(900000000)+100000000,
-				fmt.Sprintf("Converted-%d", rand.Intn(999)),
+				scs.Rand().Intn(900000000)+100000000,
+				fmt.Sprintf("Converted-%d", scs.Rand().Intn(999)),
 				entities.ShipTypeCargo,
 				ship.CurrentSystem,
 				player.Name,
@@ -163,3 +160,28 @@ func (scs *ShipConversionSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// shipConversionState is the persisted form of ShipConversionSystem.
+type shipConversionState struct {
+	Converting map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (scs *ShipConversionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(shipConversionState{
+		Converting: scs.converting,
+	})
+}
+
+// Restore implements Snapshotter.
+func (scs *ShipConversionSystem) Restore(data []byte) error {
+	var state shipConversionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	scs.converting = state.Converting
+	if scs.converting == nil {
+		scs.converting = make(map[int]int64)
+	}
+	return nil
+}
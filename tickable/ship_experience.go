@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ShipExperienceSystem{
-		BaseSystem: NewBaseSystem("ShipExperience", 49),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShipExperienceSystem{
+			BaseSystem: NewBaseSystem("ShipExperience", 49).Every(300),
+		}
 	})
 }
 
@@ -56,10 +57,6 @@ var xpRanks = []xpRank{
 }
 
 func (ses *ShipExperienceSystem) OnTick(tick int64) {
-	if tick%300 != 0 {
-		return
-	}
-
 	ctx := ses.GetContext()
 	if ctx == nil {
 		return
@@ -128,8 +125,8 @@ func (ses *ShipExperienceSystem) accumulateXP(ship *entities.Ship, player *entit
 	}
 
 	// Small random bonus
-	if rand.Intn(5) == 0 {
-		xp += rand.Intn(5)
+	if ses.Rand().Intn(5) == 0 {
+		xp += ses.Rand().Intn(5)
 	}
 
 	ses.shipXP[sid] += xp
@@ -242,3 +239,40 @@ func (ses *ShipExperienceSystem) GetShipRank(shipID int) string {
 	}
 	return r
 }
+
+// shipExperienceState is the persisted form of ShipExperienceSystem.
+type shipExperienceState struct {
+	ShipXP       map[int]int
+	ShipRank     map[int]string
+	LastPromoted map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ses *ShipExperienceSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(shipExperienceState{
+		ShipXP:       ses.shipXP,
+		ShipRank:     ses.shipRank,
+		LastPromoted: ses.lastPromoted,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ses *ShipExperienceSystem) Restore(data []byte) error {
+	var state shipExperienceState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ses.shipXP = state.ShipXP
+	ses.shipRank = state.ShipRank
+	ses.lastPromoted = state.LastPromoted
+	if ses.shipXP == nil {
+		ses.shipXP = make(map[int]int)
+	}
+	if ses.shipRank == nil {
+		ses.shipRank = make(map[int]string)
+	}
+	if ses.lastPromoted == nil {
+		ses.lastPromoted = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ShipGraveyardSystem{
-		BaseSystem: NewBaseSystem("ShipGraveyard", 168),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShipGraveyardSystem{
+			BaseSystem: NewBaseSystem("ShipGraveyard", 168).Every(3000),
+		}
 	})
 }
 
@@ -30,10 +33,6 @@ type ShipGraveyardSystem struct {
 }
 
 func (sgs *ShipGraveyardSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := sgs.GetContext()
 	if ctx == nil {
 		return
@@ -69,7 +68,8 @@ func (sgs *ShipGraveyardSystem) OnTick(tick int64) {
 	}
 
 	// Check for new graveyards
-	for sysID, deaths := range sgs.shipDeaths {
+	for _, sysID := range slices.Sorted(maps.Keys(sgs.shipDeaths)) {
+		deaths := sgs.shipDeaths[sysID]
 		if deaths >= 5 && !sgs.graveyards[sysID] {
 			sgs.graveyards[sysID] = true
 
@@ -88,8 +88,8 @@ func (sgs *ShipGraveyardSystem) OnTick(tick int64) {
 	}
 
 	// Graveyard salvage events
-	for sysID := range sgs.graveyards {
-		if rand.Intn(10) != 0 {
+	for _, sysID := range slices.Sorted(maps.Keys(sgs.graveyards)) {
+		if sgs.Rand().Intn(10) != 0 {
 			continue
 		}
 
@@ -103,7 +103,7 @@ func (sgs *ShipGraveyardSystem) OnTick(tick int64) {
 					continue
 				}
 				if ship.ShipType == entities.ShipTypeScout || ship.ShipType == entities.ShipTypeCargo {
-					loaded := ship.AddCargo(entities.ResIron, 10+rand.Intn(20))
+					loaded := ship.AddCargo(entities.ResIron, 10+sgs.Rand().Intn(20))
 					if loaded > 0 {
 						sysName := ""
 						for _, sys := range systems {
@@ -112,7 +112,7 @@ func (sgs *ShipGraveyardSystem) OnTick(tick int64) {
 								break
 							}
 						}
-						if rand.Intn(3) == 0 {
+						if sgs.Rand().Intn(3) == 0 {
 							game.LogEvent("explore", p.Name,
 								fmt.Sprintf("⚰️ %s salvaged %d Iron from wreckage in %s graveyard",
 									ship.Name, loaded, sysName))
@@ -132,3 +132,37 @@ func (sgs *ShipGraveyardSystem) RecordDeath(systemID int) {
 	}
 	sgs.shipDeaths[systemID]++
 }
+
+// shipGraveyardState is the persisted form of ShipGraveyardSystem.
+type shipGraveyardState struct {
+	ShipDeaths map[int]int
+	Graveyards map[int]bool
+	NextCheck  int64
+}
+
+// Snapshot implements Snapshotter.
+func (sgs *ShipGraveyardSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(shipGraveyardState{
+		ShipDeaths: sgs.shipDeaths,
+		Graveyards: sgs.graveyards,
+		NextCheck:  sgs.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sgs *ShipGraveyardSystem) Restore(data []byte) error {
+	var state shipGraveyardState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sgs.shipDeaths = state.ShipDeaths
+	if sgs.shipDeaths == nil {
+		sgs.shipDeaths = make(map[int]int)
+	}
+	sgs.graveyards = state.Graveyards
+	if sgs.graveyards == nil {
+		sgs.graveyards = make(map[int]bool)
+	}
+	sgs.nextCheck = state.NextCheck
+	return nil
+}
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ShipMaintenanceSystem{
-		BaseSystem: NewBaseSystem("ShipMaintenance", 30),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShipMaintenanceSystem{
+			BaseSystem: NewBaseSystem("ShipMaintenance", 30).Every(300),
+		}
 	})
 }
 
@@ -38,10 +40,6 @@ var shipMaintenanceCost = map[entities.ShipType]int{
 }
 
 func (sms *ShipMaintenanceSystem) OnTick(tick int64) {
-	if tick%300 != 0 {
-		return
-	}
-
 	ctx := sms.GetContext()
 	if ctx == nil {
 		return
@@ -91,3 +89,28 @@ func (sms *ShipMaintenanceSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// shipMaintenanceState is the persisted form of ShipMaintenanceSystem.
+type shipMaintenanceState struct {
+	LastWarning map[string]int64
+}
+
+// Snapshot implements Snapshotter.
+func (sms *ShipMaintenanceSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(shipMaintenanceState{
+		LastWarning: sms.lastWarning,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sms *ShipMaintenanceSystem) Restore(data []byte) error {
+	var state shipMaintenanceState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sms.lastWarning = state.LastWarning
+	if sms.lastWarning == nil {
+		sms.lastWarning = make(map[string]int64)
+	}
+	return nil
+}
@@ -1,14 +1,18 @@
 package tickable
 
 import (
+	"maps"
 	"math"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ShipMovementSystem{
-		BaseSystem: NewBaseSystem("ShipMovement", 20),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShipMovementSystem{
+			BaseSystem: NewBaseSystem("ShipMovement", 20),
+		}
 	})
 }
 
@@ -20,18 +24,18 @@ type ShipMovementSystem struct {
 	MovingFound   int   // diagnostic: moving ships processed in last tick
 }
 
-// GetShipMovementDiag returns diagnostic counters
-func GetShipMovementDiag() (ticks int64, shipsFound, movingFound int) {
-	sys := GetSystemByName("ShipMovement")
+// ShipMovementDiag returns diagnostic counters
+func (r *Registry) ShipMovementDiag() (ticks int64, shipsFound, movingFound int) {
+	sys := r.SystemByName("ShipMovement")
 	if sms, ok := sys.(*ShipMovementSystem); ok {
 		return sms.TickCount, sms.ShipsFound, sms.MovingFound
 	}
 	return -1, -1, -1
 }
 
-// GetShipMovementPlayers returns player ship moving count from the system's perspective
-func GetShipMovementPlayers() int {
-	sys := GetSystemByName("ShipMovement")
+// ShipMovementPlayers returns player ship moving count from the system's perspective
+func (r *Registry) ShipMovementPlayers() int {
+	sys := r.SystemByName("ShipMovement")
 	if sms, ok := sys.(*ShipMovementSystem); ok {
 		ctx := sms.GetContext()
 		if ctx == nil {
@@ -76,7 +80,8 @@ func (sms *ShipMovementSystem) OnTick(tick int64) {
 	// to catch ships that aren't in a system's entity list (e.g. after API creation)
 	seen := make(map[int]bool)
 
-	for _, system := range systemsMap {
+	for _, id := range slices.Sorted(maps.Keys(systemsMap)) {
+		system := systemsMap[id]
 		for _, entity := range system.Entities {
 			if ship, ok := entity.(*entities.Ship); ok {
 				seen[ship.GetID()] = true
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ShipRefuelingSystem{
-		BaseSystem: NewBaseSystem("ShipRefueling", 20),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShipRefuelingSystem{
+			BaseSystem: NewBaseSystem("ShipRefueling", 20).Every(10),
+		}
 	})
 }
 
@@ -17,10 +19,6 @@ type ShipRefuelingSystem struct {
 }
 
 func (srs *ShipRefuelingSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := srs.GetContext()
 	if ctx == nil {
 		return
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&ShippingMilestoneSystem{
-		BaseSystem: NewBaseSystem("ShippingMilestones", 114),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShippingMilestoneSystem{
+			BaseSystem: NewBaseSystem("ShippingMilestones", 114).Every(2000),
+		}
 	})
 }
 
@@ -34,10 +36,6 @@ type ShippingMilestoneSystem struct {
 }
 
 func (sms *ShippingMilestoneSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := sms.GetContext()
 	if ctx == nil {
 		return
@@ -108,3 +106,34 @@ func (sms *ShippingMilestoneSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// shippingMilestoneState is the persisted form of ShippingMilestoneSystem.
+type shippingMilestoneState struct {
+	FactionDeliveries map[string]int
+	GalaxyAnnounced   map[int]bool
+}
+
+// Snapshot implements Snapshotter.
+func (sms *ShippingMilestoneSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(shippingMilestoneState{
+		FactionDeliveries: sms.factionDeliveries,
+		GalaxyAnnounced:   sms.galaxyAnnounced,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sms *ShippingMilestoneSystem) Restore(data []byte) error {
+	var state shippingMilestoneState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sms.factionDeliveries = state.FactionDeliveries
+	if sms.factionDeliveries == nil {
+		sms.factionDeliveries = make(map[string]int)
+	}
+	sms.galaxyAnnounced = state.GalaxyAnnounced
+	if sms.galaxyAnnounced == nil {
+		sms.galaxyAnnounced = make(map[int]bool)
+	}
+	return nil
+}
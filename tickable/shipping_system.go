@@ -2,13 +2,17 @@ package tickable
 
 import (
 	"fmt"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&ShippingSystem{
-		BaseSystem: NewBaseSystem("Shipping", 29),
+	RegisterSystemFunc(func() TickableSystem {
+		return &ShippingSystem{
+			BaseSystem: NewBaseSystem("Shipping", 29).Every(20),
+		}
 	})
 }
 
@@ -19,10 +23,6 @@ type ShippingSystem struct {
 }
 
 func (ss *ShippingSystem) OnTick(tick int64) {
-	if tick%20 != 0 {
-		return
-	}
-
 	ctx := ss.GetContext()
 	if ctx == nil {
 		return
@@ -256,8 +256,8 @@ func (ss *ShippingSystem) processRoute(route ShippingRouteInfo, ship *entities.S
 				route.ID, ship.Name, unloaded, route.Resource, destPlanet.Name)
 		}
 		// Unload any extra cargo
-		for res, amt := range ship.CargoHold {
-			if res != route.Resource && amt > 0 {
+		for _, res := range slices.Sorted(maps.Keys(ship.CargoHold)) {
+			if amt := ship.CargoHold[res]; res != route.Resource && amt > 0 {
 				gp.UnloadCargo(ship, destPlanet, res, amt)
 			}
 		}
@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SiegeSystem{
-		BaseSystem: NewBaseSystem("Siege", 38),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SiegeSystem{
+			BaseSystem: NewBaseSystem("Siege", 38).Every(400),
+		}
 	})
 }
 
@@ -46,10 +49,6 @@ type Siege struct {
 }
 
 func (ss *SiegeSystem) OnTick(tick int64) {
-	if tick%400 != 0 {
-		return
-	}
-
 	ctx := ss.GetContext()
 	if ctx == nil {
 		return
@@ -118,7 +117,8 @@ func (ss *SiegeSystem) evaluateSieges(tick int64, sys *entities.System, players
 		defenderFleet := factions[planet.Owner]
 
 		// Check all attacker factions
-		for attackerName, fleet := range factions {
+		for _, attackerName := range slices.Sorted(maps.Keys(factions)) {
+			fleet := factions[attackerName]
 			if attackerName == planet.Owner {
 				continue
 			}
@@ -182,7 +182,7 @@ func (ss *SiegeSystem) applySiegeDamage(planet *entities.Planet, siege *Siege, p
 	for _, be := range planet.Buildings {
 		if b, ok := be.(*entities.Building); ok && b.BuildingType == entities.BuildingPlanetShield && b.IsOperational {
 			// Shield absorbs bombardment
-			if rand.Intn(3) == 0 {
+			if ss.Rand().Intn(3) == 0 {
 				b.IsOperational = false
 				game.LogEvent("military", planet.Owner,
 					fmt.Sprintf("💥 Planetary Shield on %s destroyed by bombardment!", planet.Name))
@@ -195,7 +195,7 @@ func (ss *SiegeSystem) applySiegeDamage(planet *entities.Planet, siege *Siege, p
 	}
 
 	// Damage a random operational building (never the Base)
-	if len(planet.Buildings) > 0 && rand.Intn(2) == 0 {
+	if len(planet.Buildings) > 0 && ss.Rand().Intn(2) == 0 {
 		// Find operational buildings (excluding Base)
 		var operational []int
 		for i, be := range planet.Buildings {
@@ -204,7 +204,7 @@ func (ss *SiegeSystem) applySiegeDamage(planet *entities.Planet, siege *Siege, p
 			}
 		}
 		if len(operational) > 0 {
-			idx := operational[rand.Intn(len(operational))]
+			idx := operational[ss.Rand().Intn(len(operational))]
 			if b, ok := planet.Buildings[idx].(*entities.Building); ok {
 				b.IsOperational = false
 				game.LogEvent("military", planet.Owner,
@@ -279,3 +279,25 @@ func (ss *SiegeSystem) GetActiveSieges() []*Siege {
 	}
 	return result
 }
+
+// siegeState is the persisted form of SiegeSystem.
+type siegeState struct {
+	Sieges map[int]*Siege
+}
+
+// Snapshot implements Snapshotter.
+func (ss *SiegeSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(siegeState{
+		Sieges: ss.sieges,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ss *SiegeSystem) Restore(data []byte) error {
+	var state siegeState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ss.sieges = state.Sieges
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SmugglingSystem{
-		BaseSystem: NewBaseSystem("Smuggling", 55),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SmugglingSystem{
+			BaseSystem: NewBaseSystem("Smuggling", 55).Every(400),
+		}
 	})
 }
 
@@ -38,10 +39,6 @@ type SmugglingSystem struct {
 }
 
 func (ss *SmugglingSystem) OnTick(tick int64) {
-	if tick%400 != 0 {
-		return
-	}
-
 	ctx := ss.GetContext()
 	if ctx == nil {
 		return
@@ -150,7 +147,7 @@ func (ss *SmugglingSystem) OnTick(tick int64) {
 				}
 			}
 
-			if rand.Intn(100) < catchChance {
+			if ss.Rand().Intn(100) < catchChance {
 				// CAUGHT! Cargo seized, fine applied
 				cargoValue := 0
 				for res, amt := range ship.CargoHold {
@@ -182,7 +179,7 @@ func (ss *SmugglingSystem) OnTick(tick int64) {
 				player.Credits += bonus
 				ss.smuggleAttempts[player.Name]++
 
-				if rand.Intn(3) == 0 { // don't spam events
+				if ss.Rand().Intn(3) == 0 { // don't spam events
 					game.LogEvent("trade", player.Name,
 						fmt.Sprintf("🤫 %s's %s successfully ran cargo past patrols in %s (+%d cr bonus)",
 							player.Name, ship.Name, sysName, bonus))
@@ -199,3 +196,34 @@ func (ss *SmugglingSystem) GetSmugglingStats(playerName string) (successes, caug
 	}
 	return ss.smuggleAttempts[playerName], ss.caughtCount[playerName]
 }
+
+// smugglingState is the persisted form of SmugglingSystem.
+type smugglingState struct {
+	SmuggleAttempts map[string]int
+	CaughtCount     map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (ss *SmugglingSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(smugglingState{
+		SmuggleAttempts: ss.smuggleAttempts,
+		CaughtCount:     ss.caughtCount,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ss *SmugglingSystem) Restore(data []byte) error {
+	var state smugglingState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ss.smuggleAttempts = state.SmuggleAttempts
+	if ss.smuggleAttempts == nil {
+		ss.smuggleAttempts = make(map[string]int)
+	}
+	ss.caughtCount = state.CaughtCount
+	if ss.caughtCount == nil {
+		ss.caughtCount = make(map[string]int)
+	}
+	return nil
+}
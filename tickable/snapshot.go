@@ -0,0 +1,79 @@
+package tickable
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+)
+
+// SnapshotAllSystems collects the state of every registered system that
+// implements Snapshotter, keyed by system name. Systems that fail to
+// encode are logged and skipped so one bad section can't block a save.
+func SnapshotAllSystems() map[string][]byte {
+	return registry.Snapshot()
+}
+
+// Snapshot collects the registry's system state (see SnapshotAllSystems).
+func (r *Registry) Snapshot() map[string][]byte {
+	sections := make(map[string][]byte)
+	for _, system := range r.systems {
+		snap, ok := system.(Snapshotter)
+		if !ok {
+			continue
+		}
+		data, err := snap.Snapshot()
+		if err != nil {
+			fmt.Printf("[Snapshot] %s: %v\n", system.GetName(), err)
+			continue
+		}
+		sections[system.GetName()] = data
+	}
+	return sections
+}
+
+// RestoreAllSystems hands each saved section back to the matching system.
+// Sections for systems that no longer exist are ignored, and systems with
+// no saved section keep their freshly initialized state, so older saves
+// still load. Returns the number of systems restored.
+func RestoreAllSystems(sections map[string][]byte) int {
+	return registry.Restore(sections)
+}
+
+// Restore hands saved sections back to the registry's systems (see
+// RestoreAllSystems).
+func (r *Registry) Restore(sections map[string][]byte) int {
+	restored := 0
+	for name, data := range sections {
+		system := r.SystemByName(name)
+		if system == nil {
+			fmt.Printf("[Snapshot] Ignoring state for unknown system %q\n", name)
+			continue
+		}
+		snap, ok := system.(Snapshotter)
+		if !ok {
+			continue
+		}
+		if err := snap.Restore(data); err != nil {
+			fmt.Printf("[Snapshot] %s: restore failed: %v\n", name, err)
+			continue
+		}
+		restored++
+	}
+	return restored
+}
+
+// encodeSnapshot JSON-encodes a system's exported state struct, so the
+// section stays readable (and editable) in a JSON save export.
+func encodeSnapshot(state interface{}) ([]byte, error) {
+	return json.Marshal(state)
+}
+
+// decodeSnapshot decodes data produced by encodeSnapshot into state.
+// Sections written before snapshots switched to JSON are gob.
+func decodeSnapshot(data []byte, state interface{}) error {
+	if json.Valid(data) {
+		return json.Unmarshal(data, state)
+	}
+	return gob.NewDecoder(bytes.NewReader(data)).Decode(state)
+}
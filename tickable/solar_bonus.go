@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&SolarBonusSystem{
-		BaseSystem: NewBaseSystem("SolarBonus", 7),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SolarBonusSystem{
+			BaseSystem: NewBaseSystem("SolarBonus", 7).Every(10),
+		}
 	})
 }
 
@@ -31,10 +33,6 @@ type SolarBonusSystem struct {
 }
 
 func (sbs *SolarBonusSystem) OnTick(tick int64) {
-	if tick%10 != 0 {
-		return
-	}
-
 	ctx := sbs.GetContext()
 	if ctx == nil {
 		return
@@ -8,8 +8,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&SpaceStationSystem{
-		BaseSystem: NewBaseSystem("SpaceStations", 39),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SpaceStationSystem{
+			BaseSystem: NewBaseSystem("SpaceStations", 39).Every(50),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type SpaceStationSystem struct {
 }
 
 func (sss *SpaceStationSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := sss.GetContext()
 	if ctx == nil {
 		return
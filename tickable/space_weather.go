@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SpaceWeatherSystem{
-		BaseSystem: NewBaseSystem("SpaceWeather", 80),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SpaceWeatherSystem{
+			BaseSystem: NewBaseSystem("SpaceWeather", 80).Every(500),
+		}
 	})
 }
 
@@ -53,10 +56,6 @@ var weatherTypes = []struct {
 }
 
 func (sws *SpaceWeatherSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := sws.GetContext()
 	if ctx == nil {
 		return
@@ -72,7 +71,7 @@ func (sws *SpaceWeatherSystem) OnTick(tick int64) {
 	}
 
 	if sws.nextShift == 0 {
-		sws.nextShift = tick + 3000 + int64(rand.Intn(5000))
+		sws.nextShift = tick + 3000 + int64(sws.Rand().Intn(5000))
 	}
 
 	systems := game.GetSystems()
@@ -89,13 +88,13 @@ func (sws *SpaceWeatherSystem) OnTick(tick int64) {
 	}
 
 	// Apply weather effects
-	for _, w := range sws.weather {
-		sws.applyWeatherEffects(w, systems, game)
+	for _, sysID := range slices.Sorted(maps.Keys(sws.weather)) {
+		sws.applyWeatherEffects(sws.weather[sysID], systems, game)
 	}
 
 	// Generate new weather
 	if tick >= sws.nextShift {
-		sws.nextShift = tick + 5000 + int64(rand.Intn(8000))
+		sws.nextShift = tick + 5000 + int64(sws.Rand().Intn(8000))
 
 		// Max 3 systems with active weather
 		if len(sws.weather) >= 3 {
@@ -121,17 +120,17 @@ func (sws *SpaceWeatherSystem) applyWeatherEffects(w *SystemWeather, systems []*
 			switch w.Type {
 			case "Solar Wind":
 				// Bonus production applied via small resource injection
-				if rand.Intn(5) == 0 {
+				if sws.Rand().Intn(5) == 0 {
 					planet.AddStoredResource(entities.ResIron, 2)
 				}
 			case "Cosmic Ray Burst":
 				// Electronics bonus
-				if rand.Intn(8) == 0 {
+				if sws.Rand().Intn(8) == 0 {
 					planet.AddStoredResource(entities.ResElectronics, 1)
 				}
 			case "Ion Cloud":
 				// Helium-3 bonus
-				if rand.Intn(5) == 0 {
+				if sws.Rand().Intn(5) == 0 {
 					planet.AddStoredResource(entities.ResHelium3, 1)
 				}
 			}
@@ -146,13 +145,13 @@ func (sws *SpaceWeatherSystem) generateWeather(game GameProvider, systems []*ent
 	}
 
 	// Pick a system without active weather
-	sys := systems[rand.Intn(len(systems))]
+	sys := systems[sws.Rand().Intn(len(systems))]
 	if _, exists := sws.weather[sys.ID]; exists {
 		return
 	}
 
-	wt := weatherTypes[rand.Intn(len(weatherTypes))]
-	duration := 3000 + rand.Intn(5000)
+	wt := weatherTypes[sws.Rand().Intn(len(weatherTypes))]
+	duration := 3000 + sws.Rand().Intn(5000)
 
 	sws.weather[sys.ID] = &SystemWeather{
 		SystemID:  sys.ID,
@@ -195,3 +194,28 @@ func (sws *SpaceWeatherSystem) GetAllWeather() []*SystemWeather {
 	}
 	return result
 }
+
+// spaceWeatherState is the persisted form of SpaceWeatherSystem.
+type spaceWeatherState struct {
+	Weather   map[int]*SystemWeather
+	NextShift int64
+}
+
+// Snapshot implements Snapshotter.
+func (sws *SpaceWeatherSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(spaceWeatherState{
+		Weather:   sws.weather,
+		NextShift: sws.nextShift,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sws *SpaceWeatherSystem) Restore(data []byte) error {
+	var state spaceWeatherState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sws.weather = state.Weather
+	sws.nextShift = state.NextShift
+	return nil
+}
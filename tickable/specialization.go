@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&SpecializationSystem{
-		BaseSystem: NewBaseSystem("Specialization", 9),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SpecializationSystem{
+			BaseSystem: NewBaseSystem("Specialization", 9).Every(200),
+		}
 	})
 }
 
@@ -31,11 +33,6 @@ type SpecializationSystem struct {
 }
 
 func (ss *SpecializationSystem) OnTick(tick int64) {
-	// Evolve slowly — every 200 ticks (~20 seconds)
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := ss.GetContext()
 	if ctx == nil {
 		return
@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&StandingOrderSystem{
-		BaseSystem: NewBaseSystem("StandingOrders", 25),
+	RegisterSystemFunc(func() TickableSystem {
+		return &StandingOrderSystem{
+			BaseSystem: NewBaseSystem("StandingOrders", 25).Every(30),
+		}
 	})
 }
 
@@ -30,10 +32,6 @@ type StandingOrderInfo struct {
 }
 
 func (sos *StandingOrderSystem) OnTick(tick int64) {
-	if tick%30 != 0 {
-		return
-	}
-
 	ctx := sos.GetContext()
 	if ctx == nil {
 		return
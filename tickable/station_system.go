@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&StationSystem{
-		BaseSystem: NewBaseSystem("Stations", 26),
+	RegisterSystemFunc(func() TickableSystem {
+		return &StationSystem{
+			BaseSystem: NewBaseSystem("Stations", 26).Every(50),
+		}
 	})
 }
 
@@ -20,11 +22,6 @@ type StationSystem struct {
 }
 
 func (ss *StationSystem) OnTick(tick int64) {
-	// Run every 50 ticks (~5 seconds)
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := ss.GetContext()
 	if ctx == nil {
 		return
@@ -109,7 +106,7 @@ func (ss *StationSystem) processStation(station *entities.Station, sys *entities
 	}
 
 	// Station population slowly decays if no activity (attrition)
-	if tick%500 == 0 && station.CurrentPop > 100 {
+	if ss.DueEvery(tick, 500) && station.CurrentPop > 100 {
 		station.CurrentPop -= station.CurrentPop / 50 // lose 2%
 		if station.CurrentPop < 100 {
 			station.CurrentPop = 100 // minimum skeleton crew
@@ -117,7 +114,7 @@ func (ss *StationSystem) processStation(station *entities.Station, sys *entities
 	}
 
 	// Trading stations periodically log market activity
-	if station.StationType == "Trading" && tick%1000 == 0 && station.CurrentPop > 200 {
+	if station.StationType == "Trading" && ss.DueEvery(tick, 1000) && station.CurrentPop > 200 {
 		game.LogEvent("trade", station.Owner,
 			fmt.Sprintf("%s reports active commerce (%d residents)", station.Name, station.CurrentPop))
 	}
@@ -3,14 +3,15 @@ package tickable
 import (
 	"fmt"
 	"math"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&StellarEvolutionSystem{
-		BaseSystem: NewBaseSystem("StellarEvolution", 3),
+	RegisterSystemFunc(func() TickableSystem {
+		return &StellarEvolutionSystem{
+			BaseSystem: NewBaseSystem("StellarEvolution", 3).Every(1000),
+		}
 	})
 }
 
@@ -36,10 +37,6 @@ type StellarEvolutionSystem struct {
 }
 
 func (ses *StellarEvolutionSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := ses.GetContext()
 	if ctx == nil {
 		return
@@ -69,7 +66,7 @@ func (ses *StellarEvolutionSystem) OnTick(tick int64) {
 }
 
 func (ses *StellarEvolutionSystem) evolveStar(tick int64, star *entities.Star, sys *entities.System, game GameProvider) {
-	// Age the star (1 Myr per 100,000 ticks = 0.00001 Gyr per tick at tick%1000)
+	// Age the star (1 Myr per 100,000 ticks = 0.00001 Gyr per 1000-tick run)
 	ageRate := 0.00001
 	star.Age += ageRate
 
@@ -81,19 +78,19 @@ func (ses *StellarEvolutionSystem) evolveStar(tick int64, star *entities.Star, s
 	case "Red Dwarf":
 		// Extremely stable — almost no change
 		// But occasional flares
-		if star.Flares && rand.Intn(5000) == 0 {
+		if star.Flares && ses.Rand().Intn(5000) == 0 {
 			ses.stellarFlare(tick, star, sys, game)
 		}
 
 	case "Red Giant":
 		// Luminosity fluctuations + mass loss
-		star.Luminosity *= 1.0 + (rand.Float64()-0.5)*0.00001
+		star.Luminosity *= 1.0 + (ses.Rand().Float64()-0.5)*0.00001
 		star.Mass *= 1.0 - 0.0000001 // slow mass loss
 
 	case "Blue Giant":
 		// Fast evolution, frequent flares
 		star.Luminosity *= 1.0 + 0.000001
-		if star.Flares && rand.Intn(1000) == 0 {
+		if star.Flares && ses.Rand().Intn(1000) == 0 {
 			ses.stellarFlare(tick, star, sys, game)
 		}
 
@@ -168,3 +165,28 @@ func (ses *StellarEvolutionSystem) stellarFlare(tick int64, star *entities.Star,
 
 	_ = math.Abs // suppress
 }
+
+// stellarEvolutionState is the persisted form of StellarEvolutionSystem.
+type stellarEvolutionState struct {
+	LastFlare map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ses *StellarEvolutionSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(stellarEvolutionState{
+		LastFlare: ses.lastFlare,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ses *StellarEvolutionSystem) Restore(data []byte) error {
+	var state stellarEvolutionState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ses.lastFlare = state.LastFlare
+	if ses.lastFlare == nil {
+		ses.lastFlare = make(map[int]int64)
+	}
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&StellarPhenomenaSystem{
-		BaseSystem: NewBaseSystem("StellarPhenomena", 102),
+	RegisterSystemFunc(func() TickableSystem {
+		return &StellarPhenomenaSystem{
+			BaseSystem: NewBaseSystem("StellarPhenomena", 102),
+		}
 	})
 }
 
@@ -51,20 +52,20 @@ func (sps *StellarPhenomenaSystem) OnTick(tick int64) {
 	}
 
 	if sps.nextPhenomenon == 0 {
-		sps.nextPhenomenon = tick + 10000 + int64(rand.Intn(15000))
+		sps.nextPhenomenon = tick + 10000 + int64(sps.Rand().Intn(15000))
 	}
 	if tick < sps.nextPhenomenon {
 		return
 	}
-	sps.nextPhenomenon = tick + 15000 + int64(rand.Intn(20000))
+	sps.nextPhenomenon = tick + 15000 + int64(sps.Rand().Intn(20000))
 
 	systems := game.GetSystems()
 	if len(systems) == 0 {
 		return
 	}
 
-	phenomenon := rand.Intn(5)
-	sys := systems[rand.Intn(len(systems))]
+	phenomenon := sps.Rand().Intn(5)
+	sys := systems[sps.Rand().Intn(len(systems))]
 
 	switch phenomenon {
 	case 0: // Supernova Remnant
@@ -123,3 +124,25 @@ func (sps *StellarPhenomenaSystem) OnTick(tick int64) {
 			"🌌 GALACTIC ALIGNMENT! A rare alignment of star systems creates a sense of cosmic harmony. All planets experience a wave of contentment (+happiness galaxy-wide)!")
 	}
 }
+
+// stellarPhenomenaState is the persisted form of StellarPhenomenaSystem.
+type stellarPhenomenaState struct {
+	NextPhenomenon int64
+}
+
+// Snapshot implements Snapshotter.
+func (sps *StellarPhenomenaSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(stellarPhenomenaState{
+		NextPhenomenon: sps.nextPhenomenon,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sps *StellarPhenomenaSystem) Restore(data []byte) error {
+	var state stellarPhenomenaState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sps.nextPhenomenon = state.NextPhenomenon
+	return nil
+}
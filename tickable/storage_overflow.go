@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&StorageOverflowSystem{
-		BaseSystem: NewBaseSystem("StorageOverflow", 107),
+	RegisterSystemFunc(func() TickableSystem {
+		return &StorageOverflowSystem{
+			BaseSystem: NewBaseSystem("StorageOverflow", 107).Every(200),
+		}
 	})
 }
 
@@ -33,10 +36,6 @@ type StorageOverflowSystem struct {
 }
 
 func (sos *StorageOverflowSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := sos.GetContext()
 	if ctx == nil {
 		return
@@ -64,7 +63,8 @@ func (sos *StorageOverflowSystem) OnTick(tick int64) {
 			}
 		}
 
-		for owner, planets := range ownerPlanets {
+		for _, owner := range slices.Sorted(maps.Keys(ownerPlanets)) {
+			planets := ownerPlanets[owner]
 			var player *entities.Player
 			for _, p := range players {
 				if p != nil && p.Name == owner {
@@ -77,7 +77,8 @@ func (sos *StorageOverflowSystem) OnTick(tick int64) {
 			}
 
 			for _, planet := range planets {
-				for res, storage := range planet.StoredResources {
+				for _, res := range slices.Sorted(maps.Keys(planet.StoredResources)) {
+					storage := planet.StoredResources[res]
 					if storage == nil || storage.Capacity <= 0 {
 						continue
 					}
@@ -125,7 +126,7 @@ func (sos *StorageOverflowSystem) OnTick(tick int64) {
 						player.Credits += credits
 						market.AddTradeVolume(res, remaining, false)
 
-						if rand.Intn(5) == 0 {
+						if sos.Rand().Intn(5) == 0 {
 							game.LogEvent("trade", owner,
 								fmt.Sprintf("📦 %s auto-sold %d overflow %s for %dcr (storage full)",
 									planet.Name, remaining, res, credits))
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SupplyChainScoreSystem{
-		BaseSystem: NewBaseSystem("SupplyChainScore", 145),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SupplyChainScoreSystem{
+			BaseSystem: NewBaseSystem("SupplyChainScore", 145),
+		}
 	})
 }
 
@@ -42,12 +43,12 @@ func (scss *SupplyChainScoreSystem) OnTick(tick int64) {
 	}
 
 	if scss.nextReport == 0 {
-		scss.nextReport = tick + 8000 + int64(rand.Intn(5000))
+		scss.nextReport = tick + 8000 + int64(scss.Rand().Intn(5000))
 	}
 	if tick < scss.nextReport {
 		return
 	}
-	scss.nextReport = tick + 12000 + int64(rand.Intn(8000))
+	scss.nextReport = tick + 12000 + int64(scss.Rand().Intn(8000))
 
 	players := ctx.GetPlayers()
 	systems := game.GetSystems()
@@ -165,3 +166,25 @@ func (scss *SupplyChainScoreSystem) OnTick(tick int64) {
 		game.LogEvent("logistics", player.Name, msg)
 	}
 }
+
+// supplyChainScoreState is the persisted form of SupplyChainScoreSystem.
+type supplyChainScoreState struct {
+	NextReport int64
+}
+
+// Snapshot implements Snapshotter.
+func (scss *SupplyChainScoreSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(supplyChainScoreState{
+		NextReport: scss.nextReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (scss *SupplyChainScoreSystem) Restore(data []byte) error {
+	var state supplyChainScoreState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	scss.nextReport = state.NextReport
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SupplyCrisisSystem{
-		BaseSystem: NewBaseSystem("SupplyCrisis", 41),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SupplyCrisisSystem{
+			BaseSystem: NewBaseSystem("SupplyCrisis", 41).Every(200),
+		}
 	})
 }
 
@@ -45,10 +46,6 @@ type SupplyCrisis struct {
 }
 
 func (scs *SupplyCrisisSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := scs.GetContext()
 	if ctx == nil {
 		return
@@ -62,7 +59,7 @@ func (scs *SupplyCrisisSystem) OnTick(tick int64) {
 	systems := game.GetSystems()
 
 	if scs.nextCrisis == 0 {
-		scs.nextCrisis = tick + 3000 + int64(rand.Intn(5000))
+		scs.nextCrisis = tick + 3000 + int64(scs.Rand().Intn(5000))
 	}
 
 	// Decay existing crises
@@ -87,7 +84,7 @@ func (scs *SupplyCrisisSystem) OnTick(tick int64) {
 
 	// Spawn new crisis
 	if tick >= scs.nextCrisis {
-		scs.nextCrisis = tick + 8000 + int64(rand.Intn(10000))
+		scs.nextCrisis = tick + 8000 + int64(scs.Rand().Intn(10000))
 		scs.spawnCrisis(game, systems)
 	}
 }
@@ -143,16 +140,16 @@ func (scs *SupplyCrisisSystem) spawnCrisis(game GameProvider, systems []*entitie
 		return
 	}
 
-	sys := candidates[rand.Intn(len(candidates))]
+	sys := candidates[scs.Rand().Intn(len(candidates))]
 	resources := []string{entities.ResWater, entities.ResFuel, entities.ResIron, entities.ResOil}
-	res := resources[rand.Intn(len(resources))]
+	res := resources[scs.Rand().Intn(len(resources))]
 
 	crisis := &SupplyCrisis{
 		SystemID:   sys.ID,
 		SystemName: sys.Name,
 		Resource:   res,
-		Severity:   1.5 + rand.Float64()*1.5,
-		TicksLeft:  3000 + rand.Intn(3000),
+		Severity:   1.5 + scs.Rand().Float64()*1.5,
+		TicksLeft:  3000 + scs.Rand().Intn(3000),
 		Active:     true,
 	}
 	scs.crises = append(scs.crises, crisis)
@@ -172,3 +169,28 @@ func (scs *SupplyCrisisSystem) GetActiveCrises() []*SupplyCrisis {
 	}
 	return result
 }
+
+// supplyCrisisState is the persisted form of SupplyCrisisSystem.
+type supplyCrisisState struct {
+	Crises     []*SupplyCrisis
+	NextCrisis int64
+}
+
+// Snapshot implements Snapshotter.
+func (scs *SupplyCrisisSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(supplyCrisisState{
+		Crises:     scs.crises,
+		NextCrisis: scs.nextCrisis,
+	})
+}
+
+// Restore implements Snapshotter.
+func (scs *SupplyCrisisSystem) Restore(data []byte) error {
+	var state supplyCrisisState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	scs.crises = state.Crises
+	scs.nextCrisis = state.NextCrisis
+	return nil
+}
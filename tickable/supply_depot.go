@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SupplyDepotSystem{
-		BaseSystem: NewBaseSystem("SupplyDepot", 13),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SupplyDepotSystem{
+			BaseSystem: NewBaseSystem("SupplyDepot", 13).Every(100),
+		}
 	})
 }
 
@@ -46,10 +49,6 @@ type SupplyDepot struct {
 }
 
 func (sds *SupplyDepotSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := sds.GetContext()
 	if ctx == nil {
 		return
@@ -138,7 +137,8 @@ func (sds *SupplyDepotSystem) OnTick(tick int64) {
 	sds.nextCheck = tick + 5000
 
 	// Form depots in high-traffic systems without owned planets
-	for sysID, factions := range sds.traffic {
+	for _, sysID := range slices.Sorted(maps.Keys(sds.traffic)) {
+		factions := sds.traffic[sysID]
 		if _, hasDepot := sds.depots[sysID]; hasDepot {
 			continue
 		}
@@ -183,7 +183,7 @@ func (sds *SupplyDepotSystem) OnTick(tick int64) {
 			Active:    true,
 		}
 
-		if rand.Intn(2) == 0 {
+		if sds.Rand().Intn(2) == 0 {
 			game.LogEvent("logistics", "",
 				fmt.Sprintf("⛽ Supply depot established in %s! Ships can refuel here (2cr/unit). No more stranded fleets!",
 					sysName))
@@ -201,3 +201,37 @@ func (sds *SupplyDepotSystem) GetDepotCount() int {
 	}
 	return count
 }
+
+// supplyDepotState is the persisted form of SupplyDepotSystem.
+type supplyDepotState struct {
+	Depots    map[int]*SupplyDepot
+	Traffic   map[int]map[string]int64
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (sds *SupplyDepotSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(supplyDepotState{
+		Depots:    sds.depots,
+		Traffic:   sds.traffic,
+		NextCheck: sds.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sds *SupplyDepotSystem) Restore(data []byte) error {
+	var state supplyDepotState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sds.depots = state.Depots
+	sds.traffic = state.Traffic
+	sds.nextCheck = state.NextCheck
+	if sds.depots == nil {
+		sds.depots = make(map[int]*SupplyDepot)
+	}
+	if sds.traffic == nil {
+		sds.traffic = make(map[int]map[string]int64)
+	}
+	return nil
+}
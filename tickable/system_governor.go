@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SystemGovernorSystem{
-		BaseSystem: NewBaseSystem("SystemGovernor", 101),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SystemGovernorSystem{
+			BaseSystem: NewBaseSystem("SystemGovernor", 101).Every(3000),
+		}
 	})
 }
 
@@ -37,10 +40,6 @@ type SystemGovernorSystem struct {
 }
 
 func (sgs *SystemGovernorSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := sgs.GetContext()
 	if ctx == nil {
 		return
@@ -82,8 +81,8 @@ func (sgs *SystemGovernorSystem) OnTick(tick int64) {
 		// Find faction with most planets
 		bestFaction := ""
 		bestCount := 0
-		for name, count := range factionCount {
-			if count > bestCount {
+		for _, name := range slices.Sorted(maps.Keys(factionCount)) {
+			if count := factionCount[name]; count > bestCount {
 				bestCount = count
 				bestFaction = name
 			}
@@ -125,7 +124,7 @@ func (sgs *SystemGovernorSystem) OnTick(tick int64) {
 				if p.Credits < 0 {
 					p.Credits = 0
 				}
-				if rand.Intn(5) == 0 {
+				if sgs.Rand().Intn(5) == 0 {
 					game.LogEvent("alert", p.Name,
 						fmt.Sprintf("🏛️ Governor %s: %s system happiness at %.0f%% — administrative costs -500cr!",
 							p.Name, sys.Name, avgHappiness*100))
@@ -143,3 +142,28 @@ func (sgs *SystemGovernorSystem) GetGovernor(systemID int) string {
 	}
 	return sgs.governors[systemID]
 }
+
+// systemGovernorState is the persisted form of SystemGovernorSystem.
+type systemGovernorState struct {
+	Governors map[int]string
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (sgs *SystemGovernorSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(systemGovernorState{
+		Governors: sgs.governors,
+		NextCheck: sgs.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sgs *SystemGovernorSystem) Restore(data []byte) error {
+	var state systemGovernorState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sgs.governors = state.Governors
+	sgs.nextCheck = state.NextCheck
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&SystemProsperitySystem{
-		BaseSystem: NewBaseSystem("SystemProsperity", 142),
+	RegisterSystemFunc(func() TickableSystem {
+		return &SystemProsperitySystem{
+			BaseSystem: NewBaseSystem("SystemProsperity", 142).Every(3000),
+		}
 	})
 }
 
@@ -38,10 +39,6 @@ type SystemProsperitySystem struct {
 }
 
 func (sps *SystemProsperitySystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := sps.GetContext()
 	if ctx == nil {
 		return
@@ -124,7 +121,7 @@ func (sps *SystemProsperitySystem) OnTick(tick int64) {
 		sps.nextReport = tick + 10000
 	}
 	if tick >= sps.nextReport {
-		sps.nextReport = tick + 12000 + int64(rand.Intn(8000))
+		sps.nextReport = tick + 12000 + int64(sps.Rand().Intn(8000))
 
 		// Find best and worst systems
 		bestSys := ""
@@ -151,3 +148,31 @@ func (sps *SystemProsperitySystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// systemProsperityState is the persisted form of SystemProsperitySystem.
+type systemProsperityState struct {
+	Prosperity map[int]int
+	NextReport int64
+}
+
+// Snapshot implements Snapshotter.
+func (sps *SystemProsperitySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(systemProsperityState{
+		Prosperity: sps.prosperity,
+		NextReport: sps.nextReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (sps *SystemProsperitySystem) Restore(data []byte) error {
+	var state systemProsperityState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	sps.prosperity = state.Prosperity
+	if sps.prosperity == nil {
+		sps.prosperity = make(map[int]int)
+	}
+	sps.nextReport = state.NextReport
+	return nil
+}
@@ -2,14 +2,18 @@ package tickable
 
 import (
 	"fmt"
+	"maps"
 	"math/rand"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TariffSystem{
-		BaseSystem: NewBaseSystem("Tariffs", 27),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TariffSystem{
+			BaseSystem: NewBaseSystem("Tariffs", 27).Every(500),
+		}
 	})
 }
 
@@ -39,10 +43,6 @@ type TariffSystem struct {
 }
 
 func (ts *TariffSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := ts.GetContext()
 	if ctx == nil {
 		return
@@ -81,8 +81,8 @@ func (ts *TariffSystem) evaluateAutoTariffs(tick int64, sys *entities.System, ga
 
 	controller := ""
 	maxPlanets := 0
-	for name, count := range ownerCount {
-		if count > maxPlanets {
+	for _, name := range slices.Sorted(maps.Keys(ownerCount)) {
+		if count := ownerCount[name]; count > maxPlanets {
 			maxPlanets = count
 			controller = name
 		}
@@ -194,17 +194,54 @@ func (ts *TariffSystem) GenerateTariffRevenue(systemID int, controller string, t
 		return 0
 	}
 	totalRate := 0.0
-	for _, rate := range m {
-		totalRate += rate
+	for _, res := range slices.Sorted(maps.Keys(m)) {
+		totalRate += m[res]
 	}
 	avgRate := totalRate / float64(len(m))
 	return int(float64(tradeValue) * avgRate)
 }
 
 // init auto-generates moderate tariffs for AI factions
-func generateRandomTariff() float64 {
-	return 0.05 + rand.Float64()*0.15 // 5-20%
+func generateRandomTariff(rng *rand.Rand) float64 {
+	return 0.05 + rng.Float64()*0.15 // 5-20%
 }
 
 // Unused but keeping for API integration
 var _ = generateRandomTariff
+
+// tariffState is the persisted form of TariffSystem.
+type tariffState struct {
+	ImportTariffs map[int]map[string]float64
+	ExportTariffs map[int]map[string]float64
+	AutoTariffLog map[int]int64
+}
+
+// Snapshot implements Snapshotter.
+func (ts *TariffSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tariffState{
+		ImportTariffs: ts.importTariffs,
+		ExportTariffs: ts.exportTariffs,
+		AutoTariffLog: ts.autoTariffLog,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ts *TariffSystem) Restore(data []byte) error {
+	var state tariffState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ts.importTariffs = state.ImportTariffs
+	ts.exportTariffs = state.ExportTariffs
+	ts.autoTariffLog = state.AutoTariffLog
+	if ts.importTariffs == nil {
+		ts.importTariffs = make(map[int]map[string]float64)
+	}
+	if ts.exportTariffs == nil {
+		ts.exportTariffs = make(map[int]map[string]float64)
+	}
+	if ts.autoTariffLog == nil {
+		ts.autoTariffLog = make(map[int]int64)
+	}
+	return nil
+}
@@ -8,9 +8,11 @@ import (
 )
 
 func init() {
-	RegisterSystem(&TechLevelSystem{
-		BaseSystem:    NewBaseSystem("TechLevel", 9),
-		prevTechLevel: make(map[int]float64),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TechLevelSystem{
+			BaseSystem:    NewBaseSystem("TechLevel", 9).Every(50),
+			prevTechLevel: make(map[int]float64),
+		}
 	})
 }
 
@@ -39,10 +41,6 @@ var techMilestones = []struct {
 }
 
 func (tls *TechLevelSystem) OnTick(tick int64) {
-	if tick%50 != 0 {
-		return
-	}
-
 	ctx := tls.GetContext()
 	if ctx == nil {
 		return
@@ -123,3 +121,28 @@ func updateTechLevel(planet *entities.Planet) {
 		planet.TechLevel = 5
 	}
 }
+
+// techLevelState is the persisted form of TechLevelSystem.
+type techLevelState struct {
+	PrevTechLevel map[int]float64
+}
+
+// Snapshot implements Snapshotter.
+func (tls *TechLevelSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(techLevelState{
+		PrevTechLevel: tls.prevTechLevel,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tls *TechLevelSystem) Restore(data []byte) error {
+	var state techLevelState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tls.prevTechLevel = state.PrevTechLevel
+	if tls.prevTechLevel == nil {
+		tls.prevTechLevel = make(map[int]float64)
+	}
+	return nil
+}
@@ -2,13 +2,16 @@ package tickable
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TechSharingSystem{
-		BaseSystem: NewBaseSystem("TechSharing", 61),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TechSharingSystem{
+			BaseSystem: NewBaseSystem("TechSharing", 61).Every(200),
+		}
 	})
 }
 
@@ -35,10 +38,6 @@ type TechSharingSystem struct {
 }
 
 func (tss *TechSharingSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := tss.GetContext()
 	if ctx == nil {
 		return
@@ -90,6 +89,7 @@ func (tss *TechSharingSystem) OnTick(tick int64) {
 		for name := range factionPlanets {
 			factions = append(factions, name)
 		}
+		sort.Strings(factions)
 
 		for i := 0; i < len(factions); i++ {
 			for j := i + 1; j < len(factions); j++ {
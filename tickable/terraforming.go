@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TerraformingSystem{
-		BaseSystem: NewBaseSystem("Terraforming", 35),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TerraformingSystem{
+			BaseSystem: NewBaseSystem("Terraforming", 35).Every(5000),
+		}
 	})
 }
 
@@ -26,10 +27,6 @@ type TerraformingSystem struct {
 }
 
 func (ts *TerraformingSystem) OnTick(tick int64) {
-	if tick%5000 != 0 {
-		return
-	}
-
 	ctx := ts.GetContext()
 	if ctx == nil {
 		return
@@ -76,7 +73,7 @@ func (ts *TerraformingSystem) OnTick(tick int64) {
 			}
 
 			// At high habitability, chance to generate a Water deposit
-			if planet.Habitability > 80 && rand.Intn(5) == 0 {
+			if planet.Habitability > 80 && ts.Rand().Intn(5) == 0 {
 				for _, res := range planet.Resources {
 					if r, ok := res.(*entities.Resource); ok && r.ResourceType == entities.ResWater {
 						r.Abundance += 5
@@ -1,8 +1,12 @@
 package tickable
 
 import (
+	"bytes"
+	"encoding/gob"
 	"image/color"
+	"math/rand"
 	"testing"
+	"time"
 
 	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
@@ -61,10 +65,14 @@ func (m *mockGameProvider) SellAtDock(ship *entities.Ship, resource string, qty
 	return 0, 0, nil
 }
 func (m *mockGameProvider) GetCreditLedger() *economy.CreditLedger { return nil }
+func (m *mockGameProvider) GetLedger() *economy.Ledger                   { return nil }
+func (m *mockGameProvider) GetCandleHistory() *economy.CandleHistory     { return nil }
 func (m *mockGameProvider) GetOrderBook() *economy.OrderBook              { return nil }
+func (m *mockGameProvider) GetFuturesExchange() *economy.FuturesExchange { return nil }
 func (m *mockGameProvider) GetContractManager() *economy.ContractManager  { return nil }
 func (m *mockGameProvider) GetDiplomacyManager() *economy.DiplomacyManager { return nil }
 func (m *mockGameProvider) GetAuctionHouse() *economy.AuctionHouse        { return nil }
+func (m *mockGameProvider) GetEspionageManager() *economy.EspionageManager { return nil }
 func (m *mockGameProvider) GetShippingRoutes() []ShippingRouteInfo  { return nil }
 func (m *mockGameProvider) CompleteShippingTrip(routeID int)        {}
 func (m *mockGameProvider) AssignShipToRoute(routeID, shipID int)  {}
@@ -75,11 +83,18 @@ type mockSystemContext struct {
 	game    GameProvider
 	players []*entities.Player
 	tick    int64
+	rng     *RandSource
 }
 
 func (m *mockSystemContext) GetGame() GameProvider         { return m.game }
 func (m *mockSystemContext) GetPlayers() []*entities.Player { return m.players }
 func (m *mockSystemContext) GetTick() int64                { return m.tick }
+func (m *mockSystemContext) GetRand(system string) *rand.Rand {
+	if m.rng == nil {
+		m.rng = NewRandSource(1)
+	}
+	return m.rng.Get(m.tick, system)
+}
 
 var white = color.RGBA{255, 255, 255, 255}
 
@@ -282,3 +297,347 @@ type orderTrackingSystem struct {
 func (o *orderTrackingSystem) OnTick(tick int64) {
 	*o.order = append(*o.order, o.GetName())
 }
+
+// TestSnapshotRoundTrip verifies that registered Snapshotter systems survive
+// a snapshot/restore cycle and that unknown sections are ignored.
+func TestSnapshotRoundTrip(t *testing.T) {
+	ClearRegistry()
+	bank := &InterstellarBankSystem{BaseSystem: NewBaseSystem("InterstellarBank", 94)}
+	bank.loans = map[string]*Loan{"Alpha": {Principal: 10000, Outstanding: 12500, TakenAt: 4000}}
+	bank.interestRate = 0.0012
+	bank.nextUpdate = 24000
+	RegisterSystem(bank)
+	RegisterSystem(&orderTrackingSystem{BaseSystem: NewBaseSystem("NoState", 1), order: new([]string)})
+
+	sections := SnapshotAllSystems()
+	if len(sections) != 1 {
+		t.Fatalf("expected 1 snapshot section, got %d", len(sections))
+	}
+	sections["RemovedSystem"] = []byte("stale")
+
+	bank.loans = nil
+	bank.interestRate = 0
+	bank.nextUpdate = 0
+
+	if restored := RestoreAllSystems(sections); restored != 1 {
+		t.Fatalf("expected 1 system restored, got %d", restored)
+	}
+	if bank.GetDebt("Alpha") != 12500 {
+		t.Errorf("expected restored debt 12500, got %d", bank.GetDebt("Alpha"))
+	}
+	if bank.interestRate != 0.0012 || bank.nextUpdate != 24000 {
+		t.Errorf("expected rate 0.0012 / next 24000, got %f / %d", bank.interestRate, bank.nextUpdate)
+	}
+
+	// Sections from saves before snapshots were JSON are gob
+	var legacy bytes.Buffer
+	if err := gob.NewEncoder(&legacy).Encode(interstellarBankState{InterestRate: 0.002, NextUpdate: 5000}); err != nil {
+		t.Fatal(err)
+	}
+	if err := bank.Restore(legacy.Bytes()); err != nil {
+		t.Fatalf("restore gob section: %v", err)
+	}
+	if bank.interestRate != 0.002 || bank.nextUpdate != 5000 {
+		t.Errorf("expected rate 0.002 / next 5000 from gob, got %f / %d", bank.interestRate, bank.nextUpdate)
+	}
+}
+
+// TestCommodityFuturesLegacyRefund verifies that deposits on contracts from
+// the old deposit-only futures are refunded after loading an older save.
+func TestCommodityFuturesLegacyRefund(t *testing.T) {
+	ClearRegistry()
+	cfs := &CommodityFuturesSystem{BaseSystem: NewBaseSystem("CommodityFutures", 68)}
+	data, err := encodeSnapshot(commodityFuturesState{
+		Futures: []*FuturesContract{
+			{ID: 1, Resource: "Iron", Deposit: 500, Buyer: "Alpha"},
+			{ID: 2, Resource: "Oil", Deposit: 300, Buyer: "Alpha", Settled: true},
+			{ID: 3, Resource: "Water", Deposit: 200},
+		},
+		NextOffer: 9000,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cfs.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+
+	// Still owed after a save before the next tick
+	if data, err = cfs.Snapshot(); err != nil {
+		t.Fatal(err)
+	}
+	cfs = &CommodityFuturesSystem{BaseSystem: NewBaseSystem("CommodityFutures", 68)}
+	if err := cfs.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+
+	player := &entities.Player{Name: "Alpha", Credits: 1000}
+	game := &mockGameProvider{players: []*entities.Player{player}}
+	cfs.Initialize(&mockSystemContext{game: game, players: game.players, tick: 10})
+	cfs.OnTick(10)
+	cfs.OnTick(20)
+	if player.Credits != 1500 {
+		t.Errorf("credits after refund = %d, want 1500", player.Credits)
+	}
+	if len(game.events) != 1 {
+		t.Errorf("expected 1 refund event, got %d", len(game.events))
+	}
+}
+
+// TestRandSourceDeterminism verifies per-tick, per-system RNGs are derived
+// purely from (seed, tick, system) and continue within a tick.
+func TestRandSourceDeterminism(t *testing.T) {
+	draw := func(rs *RandSource, tick int64, system string) [3]int {
+		var out [3]int
+		for i := range out {
+			out[i] = rs.Get(tick, system).Intn(1 << 30)
+		}
+		return out
+	}
+
+	a := draw(NewRandSource(42), 100, "Plague")
+	// Another system drawing first must not shift Plague's stream
+	other := NewRandSource(42)
+	draw(other, 100, "Wormholes")
+	b := draw(other, 100, "Plague")
+	if a != b {
+		t.Errorf("same seed/tick/system diverged: %v vs %v", a, b)
+	}
+	if a[0] == a[1] && a[1] == a[2] {
+		t.Errorf("expected stream to continue within a tick, got %v", a)
+	}
+	if c := draw(NewRandSource(42), 101, "Plague"); c == a {
+		t.Error("expected different draws on a different tick")
+	}
+	if d := draw(NewRandSource(43), 100, "Plague"); d == a {
+		t.Error("expected different draws for a different seed")
+	}
+}
+
+// funcSystem runs fn whenever the registry ticks it.
+type funcSystem struct {
+	*BaseSystem
+	fn func(tick int64)
+}
+
+func (f *funcSystem) OnTick(tick int64) { f.fn(tick) }
+
+var profileSink [][]byte
+
+// TestTickProfile verifies per-system timing, allocation counts and budget flagging.
+func TestTickProfile(t *testing.T) {
+	ClearRegistry()
+	ResetProfile()
+	SetTickBudget(2 * time.Millisecond)
+	defer SetTickBudget(DefaultTickBudget)
+
+	RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem("Idle", 1), fn: func(int64) {}})
+	RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem("Laggy", 2), fn: func(tick int64) {
+		if tick%5 == 0 {
+			time.Sleep(3 * time.Millisecond)
+		}
+	}})
+	RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem("Allocator", 3), fn: func(int64) {
+		profileSink = profileSink[:0]
+		for i := 0; i < 2000; i++ {
+			profileSink = append(profileSink, make([]byte, 64))
+		}
+	}})
+
+	for tick := int64(1); tick <= 20; tick++ {
+		UpdateAllSystemsSequential(tick)
+	}
+
+	tp := GetTickProfile()
+	if tp.WindowTicks != 20 || len(tp.Systems) != 3 {
+		t.Fatalf("expected 3 systems over 20 ticks, got %d over %d", len(tp.Systems), tp.WindowTicks)
+	}
+	if tp.Systems[0].Name != "Laggy" || tp.Systems[0].Overruns != 4 {
+		t.Errorf("expected Laggy first with 4 overruns, got %s with %d", tp.Systems[0].Name, tp.Systems[0].Overruns)
+	}
+	if len(tp.OverBudget) != 1 || tp.OverBudget[0] != "Laggy" {
+		t.Errorf("expected only Laggy over budget, got %v", tp.OverBudget)
+	}
+	for _, sp := range tp.Systems {
+		hist := 0
+		for _, n := range sp.Histogram {
+			hist += n
+		}
+		if sp.WindowCalls != 20 || sp.CallsPerTick != 1 || hist != 20 {
+			t.Errorf("%s: expected 20 calls every tick in the histogram, got %d (%.2f/tick, %d bucketed)",
+				sp.Name, sp.WindowCalls, sp.CallsPerTick, hist)
+		}
+		if sp.Name == "Allocator" && sp.AllocsPerCall < 1000 {
+			t.Errorf("expected Allocator to show ~2000 allocs per call, got %.0f", sp.AllocsPerCall)
+		}
+		if sp.Name == "Idle" && sp.AllocsPerCall > 100 {
+			t.Errorf("expected Idle to allocate little, got %.0f per call", sp.AllocsPerCall)
+		}
+	}
+
+	// A tick counter going backwards (new game or load) starts a fresh window
+	UpdateAllSystemsSequential(1)
+	if tp := GetTickProfile(); tp.WindowTicks != 1 {
+		t.Errorf("expected profile reset on tick rewind, got %d ticks", tp.WindowTicks)
+	}
+}
+
+// TestRegistriesProfileSeparately verifies that each game's registry keeps
+// its own tick profile.
+func TestRegistriesProfileSeparately(t *testing.T) {
+	ClearRegistry()
+	hosted := NewRegistry()
+	hosted.Add(&funcSystem{BaseSystem: NewBaseSystem("Hosted", 1), fn: func(int64) {}})
+	other := NewRegistry()
+	other.Add(&funcSystem{BaseSystem: NewBaseSystem("Other", 1), fn: func(int64) {}})
+
+	for tick := int64(1); tick <= 5; tick++ {
+		hosted.UpdateSequential(tick)
+	}
+	if tp := hosted.TickProfile(); tp.WindowTicks != 5 || len(tp.Systems) != 1 || tp.Systems[0].Name != "Hosted" {
+		t.Errorf("expected 5 ticks of Hosted in its own profile, got %+v", tp)
+	}
+	if tp := other.TickProfile(); tp.WindowTicks != 0 {
+		t.Errorf("expected the other game's profile untouched, got %d ticks", tp.WindowTicks)
+	}
+	if tp := GetTickProfile(); tp.WindowTicks != 0 {
+		t.Errorf("expected the default profile untouched, got %d ticks", tp.WindowTicks)
+	}
+}
+
+// TestSchedule verifies that systems sharing an interval are spread across
+// it, pinned phases hold, and schedules can be changed at runtime.
+func TestSchedule(t *testing.T) {
+	ClearRegistry()
+
+	runs := map[string][]int64{}
+	perTick := map[int64]int{}
+	track := func(name string) func(int64) {
+		return func(tick int64) {
+			runs[name] = append(runs[name], tick)
+			perTick[tick]++
+		}
+	}
+	for _, name := range []string{"A", "B", "C", "D"} {
+		RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem(name, 1).Every(100), fn: track(name)})
+	}
+	RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem("Pinned", 2).Every(100).At(0), fn: track("Pinned")})
+	RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem("Always", 3), fn: func(int64) {}})
+	RegisterSystem(&funcSystem{BaseSystem: NewBaseSystem("Late", 4).Every(100).From(500), fn: track("Late")})
+
+	for tick := int64(0); tick < 1000; tick++ {
+		UpdateAllSystemsSequential(tick)
+	}
+
+	phases := map[int64]bool{}
+	for _, name := range []string{"A", "B", "C", "D"} {
+		if len(runs[name]) != 10 {
+			t.Fatalf("%s: expected 10 runs in 1000 ticks, got %d", name, len(runs[name]))
+		}
+		phases[runs[name][0]] = true
+	}
+	if len(phases) != 4 {
+		t.Errorf("expected 4 distinct phases for systems sharing an interval, got %v", phases)
+	}
+	if len(runs["Late"]) != 5 || runs["Late"][0] < 500 {
+		t.Errorf("expected Late to start at tick 500 once spread, got %v", runs["Late"])
+	}
+	if len(runs["Pinned"]) != 10 || runs["Pinned"][0] != 0 {
+		t.Errorf("expected Pinned to run at tick 0 every 100, got %v", runs["Pinned"])
+	}
+	for tick, n := range perTick {
+		if n > 2 {
+			t.Errorf("tick %d ran %d scheduled systems; expected the load spread out", tick, n)
+		}
+	}
+
+	phaseOf := func(name string) int64 {
+		return registry.SystemByName(name).(Scheduled).GetSchedule().Phase
+	}
+	before := map[string]int64{"B": phaseOf("B"), "C": phaseOf("C"), "D": phaseOf("D")}
+	if err := SetSystemSchedule("A", 50, 7); err != nil {
+		t.Fatal(err)
+	}
+	runs["A"] = nil
+	for tick := int64(1000); tick < 1100; tick++ {
+		UpdateAllSystemsSequential(tick)
+	}
+	if len(runs["A"]) != 2 || runs["A"][0] != 1007 || runs["A"][1] != 1057 {
+		t.Errorf("expected A at 1007 and 1057 after rescheduling, got %v", runs["A"])
+	}
+	for name, phase := range before {
+		if phaseOf(name) != phase {
+			t.Errorf("%s: rescheduling A moved its phase from %d to %d", name, phase, phaseOf(name))
+		}
+	}
+
+	// An unpinned change only places the changed system, in a free slot
+	if err := SetSystemSchedule("B", 100, -1); err != nil {
+		t.Fatal(err)
+	}
+	for _, other := range []string{"C", "D", "Pinned"} {
+		if phaseOf("B") == phaseOf(other) {
+			t.Errorf("expected B placed away from %s, both at phase %d", other, phaseOf("B"))
+		}
+	}
+	if phaseOf("C") != before["C"] || phaseOf("D") != before["D"] {
+		t.Error("expected only B to move")
+	}
+
+	overrides := DefaultRegistry().ScheduleOverrides()
+	if len(overrides) != 2 || overrides["A"].Phase != 7 || !overrides["A"].Fixed {
+		t.Errorf("expected overrides for A and B, got %+v", overrides)
+	}
+	restored := &Registry{}
+	restored.Add(&funcSystem{BaseSystem: NewBaseSystem("A", 1).Every(100), fn: func(int64) {}})
+	restored.RestoreScheduleOverrides(overrides)
+	if got := restored.Schedules()[0].Schedule; got != overrides["A"] {
+		t.Errorf("expected A's override restored, got %+v", got)
+	}
+	if err := SetSystemSchedule("Missing", 10, -1); err == nil {
+		t.Error("expected an error for an unknown system")
+	}
+
+	for _, info := range GetSchedules() {
+		if info.Name == "Always" && info.Interval != 0 {
+			t.Errorf("expected Always to run every tick, got %+v", info)
+		}
+	}
+
+	sys := NewBaseSystem("Sub", 1).Every(100).At(30)
+	if !sys.DueEvery(1030, 500) || sys.DueEvery(1000, 500) {
+		t.Error("expected DueEvery to count from the system's phase")
+	}
+}
+
+// TestMercenarySnapshotRelinksShips verifies that restored mercenary
+// contracts find their ships in the owner's fleet and still dismiss them.
+func TestMercenarySnapshotRelinksShips(t *testing.T) {
+	player := &entities.Player{Name: "Alpha", Credits: 10000}
+	ms := &MercenarySystem{BaseSystem: NewBaseSystem("Mercenaries", 43)}
+	if _, err := ms.HireMercenaries(player, "strike_force", 3, nil); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ms.Snapshot()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	restored := &MercenarySystem{BaseSystem: NewBaseSystem("Mercenaries", 43)}
+	if err := restored.Restore(data); err != nil {
+		t.Fatal(err)
+	}
+	game := &mockGameProvider{players: []*entities.Player{player}}
+	restored.Initialize(&mockSystemContext{game: game, players: game.players})
+	restored.contracts[0].ticksLeft = 100
+	restored.OnTick(100)
+
+	if len(restored.contracts) != 0 {
+		t.Fatalf("expected the contract to expire, %d left", len(restored.contracts))
+	}
+	for _, ship := range player.OwnedShips {
+		if ship.CurrentHealth != 0 {
+			t.Errorf("%s was not dismissed after the restored contract expired", ship.Name)
+		}
+	}
+}
@@ -2,14 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeAgreementSystem{
-		BaseSystem: NewBaseSystem("TradeAgreements", 69),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeAgreementSystem{
+			BaseSystem: NewBaseSystem("TradeAgreements", 69).Every(1000),
+		}
 	})
 }
 
@@ -49,10 +51,6 @@ type TradeAgreement struct {
 }
 
 func (tas *TradeAgreementSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := tas.GetContext()
 	if ctx == nil {
 		return
@@ -69,7 +67,7 @@ func (tas *TradeAgreementSystem) OnTick(tick int64) {
 	}
 
 	if tas.nextCheck == 0 {
-		tas.nextCheck = tick + 3000 + int64(rand.Intn(5000))
+		tas.nextCheck = tick + 3000 + int64(tas.Rand().Intn(5000))
 	}
 
 	players := ctx.GetPlayers()
@@ -97,7 +95,7 @@ func (tas *TradeAgreementSystem) OnTick(tick int64) {
 
 	// Form new agreements
 	if tick >= tas.nextCheck {
-		tas.nextCheck = tick + 8000 + int64(rand.Intn(10000))
+		tas.nextCheck = tick + 8000 + int64(tas.Rand().Intn(10000))
 		tas.formNewAgreements(players, systems, dm, game)
 	}
 }
@@ -206,6 +204,7 @@ func (tas *TradeAgreementSystem) formNewAgreements(players []*entities.Player, s
 		for name := range factionPlanets {
 			factions = append(factions, name)
 		}
+		sort.Strings(factions)
 
 		for i := 0; i < len(factions); i++ {
 			for j := i + 1; j < len(factions); j++ {
@@ -229,7 +228,7 @@ func (tas *TradeAgreementSystem) formNewAgreements(players []*entities.Player, s
 				}
 
 				// 10% chance to form
-				if rand.Intn(10) != 0 {
+				if tas.Rand().Intn(10) != 0 {
 					continue
 				}
 
@@ -293,3 +292,28 @@ func findBestSwap(planetA, planetB *entities.Planet) (string, string) {
 
 	return bestA, bestB
 }
+
+// tradeAgreementState is the persisted form of TradeAgreementSystem.
+type tradeAgreementState struct {
+	Agreements []*TradeAgreement
+	NextCheck  int64
+}
+
+// Snapshot implements Snapshotter.
+func (tas *TradeAgreementSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeAgreementState{
+		Agreements: tas.agreements,
+		NextCheck:  tas.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tas *TradeAgreementSystem) Restore(data []byte) error {
+	var state tradeAgreementState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tas.agreements = state.Agreements
+	tas.nextCheck = state.NextCheck
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeFestivalSystem{
-		BaseSystem: NewBaseSystem("TradeFestival", 90),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeFestivalSystem{
+			BaseSystem: NewBaseSystem("TradeFestival", 90).Every(500),
+		}
 	})
 }
 
@@ -42,10 +43,6 @@ type TradeFestival struct {
 }
 
 func (tfs *TradeFestivalSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := tfs.GetContext()
 	if ctx == nil {
 		return
@@ -57,7 +54,7 @@ func (tfs *TradeFestivalSystem) OnTick(tick int64) {
 	}
 
 	if tfs.nextFestival == 0 {
-		tfs.nextFestival = tick + 10000 + int64(rand.Intn(10000))
+		tfs.nextFestival = tick + 10000 + int64(tfs.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
@@ -79,7 +76,7 @@ func (tfs *TradeFestivalSystem) OnTick(tick int64) {
 
 	// Start new festival
 	if tick >= tfs.nextFestival {
-		tfs.nextFestival = tick + 15000 + int64(rand.Intn(10000))
+		tfs.nextFestival = tick + 15000 + int64(tfs.Rand().Intn(10000))
 		tfs.startFestival(game, systems)
 	}
 }
@@ -153,7 +150,7 @@ func (tfs *TradeFestivalSystem) startFestival(game GameProvider, systems []*enti
 	tfs.festival = &TradeFestival{
 		SystemID:  bestSys,
 		SysName:   bestName,
-		TicksLeft: 3000 + rand.Intn(2000),
+		TicksLeft: 3000 + tfs.Rand().Intn(2000),
 		Active:    true,
 	}
 
@@ -174,3 +171,28 @@ func (tfs *TradeFestivalSystem) GetFestivalSystem() int {
 	}
 	return -1
 }
+
+// tradeFestivalState is the persisted form of TradeFestivalSystem.
+type tradeFestivalState struct {
+	Festival     *TradeFestival
+	NextFestival int64
+}
+
+// Snapshot implements Snapshotter.
+func (tfs *TradeFestivalSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeFestivalState{
+		Festival:     tfs.festival,
+		NextFestival: tfs.nextFestival,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tfs *TradeFestivalSystem) Restore(data []byte) error {
+	var state tradeFestivalState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tfs.festival = state.Festival
+	tfs.nextFestival = state.NextFestival
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&TradeGuardSystem{
-		BaseSystem: NewBaseSystem("TradeGuard", 24),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeGuardSystem{
+			BaseSystem: NewBaseSystem("TradeGuard", 24).Every(200),
+		}
 	})
 }
 
@@ -33,17 +35,13 @@ type TradeGuardSystem struct {
 }
 
 type tradeEntry struct {
-	resource string
-	action   string // "buy" or "sell"
-	price    float64
-	tick     int64
+	Resource string
+	Action   string // "buy" or "sell"
+	Price    float64
+	Tick     int64
 }
 
 func (tgs *TradeGuardSystem) OnTick(tick int64) {
-	if tick%200 != 0 {
-		return
-	}
-
 	ctx := tgs.GetContext()
 	if ctx == nil {
 		return
@@ -84,11 +82,11 @@ func (tgs *TradeGuardSystem) OnTick(tick int64) {
 		// Check for buy-then-sell-at-loss
 		if record.Action == "sell" {
 			for _, prev := range playerTrades {
-				if prev.resource == record.Resource && prev.action == "buy" &&
-					record.Tick-prev.tick < 1000 {
-					if record.UnitPrice < prev.price {
+				if prev.Resource == record.Resource && prev.Action == "buy" &&
+					record.Tick-prev.Tick < 1000 {
+					if record.UnitPrice < prev.Price {
 						// LOSING TRADE DETECTED
-						loss := prev.price - record.UnitPrice
+						loss := prev.Price - record.UnitPrice
 						if tgs.lossCount[record.Player] == nil {
 							tgs.lossCount[record.Player] = make(map[string]int)
 						}
@@ -104,7 +102,7 @@ func (tgs *TradeGuardSystem) OnTick(tick int64) {
 
 							game.LogEvent("alert", record.Player,
 								fmt.Sprintf("🛑 Trade guard: %s auto-trading of %s BLOCKED! Detected %d consecutive losing trades (buying@%.0f, selling@%.0f, loss: %.0f/unit). Review strategy!",
-									record.Player, record.Resource, count, prev.price, record.UnitPrice, loss))
+									record.Player, record.Resource, count, prev.Price, record.UnitPrice, loss))
 
 							tgs.lossCount[record.Player][record.Resource] = 0
 						}
@@ -115,10 +113,10 @@ func (tgs *TradeGuardSystem) OnTick(tick int64) {
 
 		// Track this trade
 		tgs.recentTrades[record.Player] = append(tgs.recentTrades[record.Player], tradeEntry{
-			resource: record.Resource,
-			action:   record.Action,
-			price:    record.UnitPrice,
-			tick:     record.Tick,
+			Resource: record.Resource,
+			Action:   record.Action,
+			Price:    record.UnitPrice,
+			Tick:     record.Tick,
 		})
 
 		// Keep only last 20 trades per player
@@ -152,3 +150,40 @@ func (tgs *TradeGuardSystem) IsBlocked(playerName, resource string, tick int64)
 	unblockTick, exists := blocks[resource]
 	return exists && tick < unblockTick
 }
+
+// tradeGuardState is the persisted form of TradeGuardSystem.
+type tradeGuardState struct {
+	RecentTrades map[string][]tradeEntry
+	Blocked      map[string]map[string]int64
+	LossCount    map[string]map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (tgs *TradeGuardSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeGuardState{
+		RecentTrades: tgs.recentTrades,
+		Blocked:      tgs.blocked,
+		LossCount:    tgs.lossCount,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tgs *TradeGuardSystem) Restore(data []byte) error {
+	var state tradeGuardState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tgs.recentTrades = state.RecentTrades
+	if tgs.recentTrades == nil {
+		tgs.recentTrades = make(map[string][]tradeEntry)
+	}
+	tgs.blocked = state.Blocked
+	if tgs.blocked == nil {
+		tgs.blocked = make(map[string]map[string]int64)
+	}
+	tgs.lossCount = state.LossCount
+	if tgs.lossCount == nil {
+		tgs.lossCount = make(map[string]map[string]int)
+	}
+	return nil
+}
@@ -2,15 +2,18 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeHubSystem{
-		BaseSystem: NewBaseSystem("TradeHubs", 67),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeHubSystem{
+			BaseSystem: NewBaseSystem("TradeHubs", 67).Every(2000),
+		}
 	})
 }
 
@@ -36,10 +39,6 @@ type TradeHubSystem struct {
 }
 
 func (ths *TradeHubSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := ths.GetContext()
 	if ctx == nil {
 		return
@@ -62,8 +61,8 @@ func (ths *TradeHubSystem) OnTick(tick int64) {
 	systems := game.GetSystems()
 
 	// Apply hub bonuses
-	for sysID, score := range ths.hubs {
-		if score < 50 {
+	for _, sysID := range slices.Sorted(maps.Keys(ths.hubs)) {
+		if ths.hubs[sysID] < 50 {
 			continue // not a hub
 		}
 		ths.applyHubBonuses(sysID, players, systems, game)
@@ -173,7 +172,7 @@ func (ths *TradeHubSystem) recalculateHubs(systems []*entities.System, players [
 		return candidates[i].score > candidates[j].score
 	})
 
-	if rand.Intn(3) == 0 { // don't announce every time
+	if ths.Rand().Intn(3) == 0 { // don't announce every time
 		msg := "🏗️ Trade Hub Rankings: "
 		for i, c := range candidates {
 			if i >= 3 {
@@ -206,3 +205,31 @@ func (ths *TradeHubSystem) GetHubScore(systemID int) int {
 	}
 	return ths.hubs[systemID]
 }
+
+// tradeHubState is the persisted form of TradeHubSystem.
+type tradeHubState struct {
+	Hubs       map[int]int
+	NextUpdate int64
+}
+
+// Snapshot implements Snapshotter.
+func (ths *TradeHubSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeHubState{
+		Hubs:       ths.hubs,
+		NextUpdate: ths.nextUpdate,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ths *TradeHubSystem) Restore(data []byte) error {
+	var state tradeHubState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ths.hubs = state.Hubs
+	if ths.hubs == nil {
+		ths.hubs = make(map[int]int)
+	}
+	ths.nextUpdate = state.NextUpdate
+	return nil
+}
@@ -2,15 +2,16 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeIntelSystem{
-		BaseSystem: NewBaseSystem("TradeIntel", 47),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeIntelSystem{
+			BaseSystem: NewBaseSystem("TradeIntel", 47),
+		}
 	})
 }
 
@@ -53,12 +54,12 @@ func (tis *TradeIntelSystem) OnTick(tick int64) {
 	}
 
 	if tis.nextScan == 0 {
-		tis.nextScan = tick + 1000 + int64(rand.Intn(2000))
+		tis.nextScan = tick + 1000 + int64(tis.Rand().Intn(2000))
 	}
 	if tick < tis.nextScan {
 		return
 	}
-	tis.nextScan = tick + 5000 + int64(rand.Intn(5000))
+	tis.nextScan = tick + 5000 + int64(tis.Rand().Intn(5000))
 
 	systems := game.GetSystems()
 	market := game.GetMarketEngine()
@@ -158,3 +159,25 @@ func (tis *TradeIntelSystem) OnTick(tick int64) {
 				opp.resource, opp.fromSys, opp.surplus, opp.toSys, opp.deficit, opp.profit))
 	}
 }
+
+// tradeIntelState is the persisted form of TradeIntelSystem.
+type tradeIntelState struct {
+	NextScan int64
+}
+
+// Snapshot implements Snapshotter.
+func (tis *TradeIntelSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeIntelState{
+		NextScan: tis.nextScan,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tis *TradeIntelSystem) Restore(data []byte) error {
+	var state tradeIntelState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tis.nextScan = state.NextScan
+	return nil
+}
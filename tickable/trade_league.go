@@ -2,15 +2,18 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 	"sort"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeLeagueSystem{
-		BaseSystem: NewBaseSystem("TradeLeague", 138),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeLeagueSystem{
+			BaseSystem: NewBaseSystem("TradeLeague", 138).Every(2000),
+		}
 	})
 }
 
@@ -34,10 +37,6 @@ type TradeLeagueSystem struct {
 }
 
 func (tls *TradeLeagueSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := tls.GetContext()
 	if ctx == nil {
 		return
@@ -86,14 +85,14 @@ func (tls *TradeLeagueSystem) OnTick(tick int64) {
 			score int
 		}
 		var standings []entry
-		for name, score := range tls.seasonScores {
-			if score > 0 {
+		for _, name := range slices.Sorted(maps.Keys(tls.seasonScores)) {
+			if score := tls.seasonScores[name]; score > 0 {
 				standings = append(standings, entry{name, score})
 			}
 		}
-		sort.Slice(standings, func(i, j int) bool { return standings[i].score > standings[j].score })
+		sort.SliceStable(standings, func(i, j int) bool { return standings[i].score > standings[j].score })
 
-		if len(standings) > 0 && rand.Intn(2) == 0 {
+		if len(standings) > 0 && tls.Rand().Intn(2) == 0 {
 			msg := fmt.Sprintf("🏆 Trade League Season %d — Halftime: ", tls.seasonNum)
 			for i, s := range standings {
 				if i >= 3 {
@@ -112,8 +111,8 @@ func (tls *TradeLeagueSystem) endSeason(game GameProvider, players []*entities.P
 		score int
 	}
 	var standings []entry
-	for name, score := range tls.seasonScores {
-		if score > 0 {
+	for _, name := range slices.Sorted(maps.Keys(tls.seasonScores)) {
+		if score := tls.seasonScores[name]; score > 0 {
 			standings = append(standings, entry{name, score})
 		}
 	}
@@ -121,7 +120,7 @@ func (tls *TradeLeagueSystem) endSeason(game GameProvider, players []*entities.P
 		return
 	}
 
-	sort.Slice(standings, func(i, j int) bool { return standings[i].score > standings[j].score })
+	sort.SliceStable(standings, func(i, j int) bool { return standings[i].score > standings[j].score })
 
 	prizes := []int{5000, 2000, 1000}
 	msg := fmt.Sprintf("🏆 TRADE LEAGUE SEASON %d RESULTS: ", tls.seasonNum)
@@ -153,3 +152,35 @@ func (tls *TradeLeagueSystem) endSeason(game GameProvider, players []*entities.P
 
 	game.LogEvent("event", "", msg)
 }
+
+// tradeLeagueState is the persisted form of TradeLeagueSystem.
+type tradeLeagueState struct {
+	SeasonScores map[string]int
+	SeasonNum    int
+	SeasonEnd    int64
+}
+
+// Snapshot implements Snapshotter.
+func (tls *TradeLeagueSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeLeagueState{
+		SeasonScores: tls.seasonScores,
+		SeasonNum:    tls.seasonNum,
+		SeasonEnd:    tls.seasonEnd,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tls *TradeLeagueSystem) Restore(data []byte) error {
+	var state tradeLeagueState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tls.seasonScores = state.SeasonScores
+	tls.seasonNum = state.SeasonNum
+	tls.seasonEnd = state.SeasonEnd
+	// gob drops empty maps; a nil map would make OnTick restart the season.
+	if tls.seasonScores == nil && tls.seasonNum > 0 {
+		tls.seasonScores = make(map[string]int)
+	}
+	return nil
+}
@@ -5,8 +5,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&TradeMilestoneRewardSystem{
-		BaseSystem: NewBaseSystem("TradeMilestoneRewards", 132),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeMilestoneRewardSystem{
+			BaseSystem: NewBaseSystem("TradeMilestoneRewards", 132).Every(3000),
+		}
 	})
 }
 
@@ -41,10 +43,6 @@ var tradeMilestones = []struct {
 }
 
 func (tmrs *TradeMilestoneRewardSystem) OnTick(tick int64) {
-	if tick%3000 != 0 {
-		return
-	}
-
 	ctx := tmrs.GetContext()
 	if ctx == nil {
 		return
@@ -98,3 +96,28 @@ func (tmrs *TradeMilestoneRewardSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// tradeMilestoneRewardState is the persisted form of TradeMilestoneRewardSystem.
+type tradeMilestoneRewardState struct {
+	MilestoneReached map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (tmrs *TradeMilestoneRewardSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeMilestoneRewardState{
+		MilestoneReached: tmrs.milestoneReached,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tmrs *TradeMilestoneRewardSystem) Restore(data []byte) error {
+	var state tradeMilestoneRewardState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tmrs.milestoneReached = state.MilestoneReached
+	if tmrs.milestoneReached == nil {
+		tmrs.milestoneReached = make(map[string]int)
+	}
+	return nil
+}
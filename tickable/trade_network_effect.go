@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeNetworkEffectSystem{
-		BaseSystem: NewBaseSystem("TradeNetworkEffect", 165),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeNetworkEffectSystem{
+			BaseSystem: NewBaseSystem("TradeNetworkEffect", 165).Every(2000),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type TradeNetworkEffectSystem struct {
 }
 
 func (tnes *TradeNetworkEffectSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := tnes.GetContext()
 	if ctx == nil {
 		return
@@ -112,6 +109,29 @@ func (tnes *TradeNetworkEffectSystem) OnTick(tick int64) {
 					player.Name, networkSize, title))
 		}
 	}
+}
+
+// tradeNetworkEffectState is the persisted form of TradeNetworkEffectSystem.
+type tradeNetworkEffectState struct {
+	Titles map[string]string
+}
 
-	_ = rand.Intn
+// Snapshot implements Snapshotter.
+func (tnes *TradeNetworkEffectSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeNetworkEffectState{
+		Titles: tnes.titles,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tnes *TradeNetworkEffectSystem) Restore(data []byte) error {
+	var state tradeNetworkEffectState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tnes.titles = state.Titles
+	if tnes.titles == nil {
+		tnes.titles = make(map[string]string)
+	}
+	return nil
 }
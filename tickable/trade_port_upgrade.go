@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradePortUpgradeSystem{
-		BaseSystem: NewBaseSystem("TradePortUpgrade", 115),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradePortUpgradeSystem{
+			BaseSystem: NewBaseSystem("TradePortUpgrade", 115).Every(2000),
+		}
 	})
 }
 
@@ -42,10 +43,6 @@ var tpUpgradeThresholds = map[int]int{
 }
 
 func (tpus *TradePortUpgradeSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := tpus.GetContext()
 	if ctx == nil {
 		return
@@ -129,7 +126,7 @@ func (tpus *TradePortUpgradeSystem) OnTick(tick int64) {
 
 	// Port of the Year award
 	if tick >= tpus.nextAward && bestTraffic > 5 {
-		tpus.nextAward = tick + 15000 + int64(rand.Intn(10000))
+		tpus.nextAward = tick + 15000 + int64(tpus.Rand().Intn(10000))
 
 		// Bonus to port owner
 		for _, p := range players {
@@ -144,3 +141,31 @@ func (tpus *TradePortUpgradeSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// tradePortUpgradeState is the persisted form of TradePortUpgradeSystem.
+type tradePortUpgradeState struct {
+	PortRevenue map[int]int
+	NextAward   int64
+}
+
+// Snapshot implements Snapshotter.
+func (tpus *TradePortUpgradeSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradePortUpgradeState{
+		PortRevenue: tpus.portRevenue,
+		NextAward:   tpus.nextAward,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tpus *TradePortUpgradeSystem) Restore(data []byte) error {
+	var state tradePortUpgradeState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tpus.portRevenue = state.PortRevenue
+	if tpus.portRevenue == nil {
+		tpus.portRevenue = make(map[int]int)
+	}
+	tpus.nextAward = state.NextAward
+	return nil
+}
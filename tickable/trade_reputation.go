@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeReputationSystem{
-		BaseSystem: NewBaseSystem("TradeReputation", 46),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeReputationSystem{
+			BaseSystem: NewBaseSystem("TradeReputation", 46).Every(500),
+		}
 	})
 }
 
@@ -48,10 +49,6 @@ var reputationTiers = []struct {
 }
 
 func (trs *TradeReputationSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := trs.GetContext()
 	if ctx == nil {
 		return
@@ -83,7 +80,7 @@ func (trs *TradeReputationSystem) OnTick(tick int64) {
 	}
 
 	// Passive decay for inactive traders (keeps reputation relevant)
-	if tick%5000 == 0 {
+	if trs.DueEvery(tick, 5000) {
 		for name, rep := range trs.reputation {
 			if rep > 100 {
 				decay := rep / 50 // 2% decay
@@ -132,8 +129,8 @@ func (trs *TradeReputationSystem) accumulateReputation(player *entities.Player,
 	rep += planetCount * 2
 
 	// Small random factor
-	if rand.Intn(3) == 0 {
-		rep += rand.Intn(5)
+	if trs.Rand().Intn(3) == 0 {
+		rep += trs.Rand().Intn(5)
 	}
 
 	trs.reputation[player.Name] += rep
@@ -203,3 +200,40 @@ func (trs *TradeReputationSystem) ModifyReputation(playerName string, delta int)
 		trs.reputation[playerName] = 0
 	}
 }
+
+// tradeReputationState is the persisted form of TradeReputationSystem.
+type tradeReputationState struct {
+	Reputation   map[string]int
+	LastAnnounce map[string]string
+	TradeCount   map[string]int
+}
+
+// Snapshot implements Snapshotter.
+func (trs *TradeReputationSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeReputationState{
+		Reputation:   trs.reputation,
+		LastAnnounce: trs.lastAnnounce,
+		TradeCount:   trs.tradeCount,
+	})
+}
+
+// Restore implements Snapshotter.
+func (trs *TradeReputationSystem) Restore(data []byte) error {
+	var state tradeReputationState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	trs.reputation = state.Reputation
+	trs.lastAnnounce = state.LastAnnounce
+	trs.tradeCount = state.TradeCount
+	if trs.reputation == nil {
+		trs.reputation = make(map[string]int)
+	}
+	if trs.lastAnnounce == nil {
+		trs.lastAnnounce = make(map[string]string)
+	}
+	if trs.tradeCount == nil {
+		trs.tradeCount = make(map[string]int)
+	}
+	return nil
+}
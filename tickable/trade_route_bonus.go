@@ -2,13 +2,14 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 )
 
 func init() {
-	RegisterSystem(&TradeRouteBonusSystem{
-		BaseSystem: NewBaseSystem("TradeRouteBonus", 109),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeRouteBonusSystem{
+			BaseSystem: NewBaseSystem("TradeRouteBonus", 109).Every(500),
+		}
 	})
 }
 
@@ -34,10 +35,6 @@ type TradeRouteBonusSystem struct {
 }
 
 func (trbs *TradeRouteBonusSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := trbs.GetContext()
 	if ctx == nil {
 		return
@@ -108,7 +105,7 @@ func (trbs *TradeRouteBonusSystem) OnTick(tick int64) {
 	}
 
 	// Periodic summary of best routes
-	if rand.Intn(20) == 0 {
+	if trbs.Rand().Intn(20) == 0 {
 		bestRoute := 0
 		bestTrips := 0
 		bestOwner := ""
@@ -126,3 +123,28 @@ func (trbs *TradeRouteBonusSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// tradeRouteBonusState is the persisted form of TradeRouteBonusSystem.
+type tradeRouteBonusState struct {
+	LastTrips map[int]int
+}
+
+// Snapshot implements Snapshotter.
+func (trbs *TradeRouteBonusSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeRouteBonusState{
+		LastTrips: trbs.lastTrips,
+	})
+}
+
+// Restore implements Snapshotter.
+func (trbs *TradeRouteBonusSystem) Restore(data []byte) error {
+	var state tradeRouteBonusState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	trbs.lastTrips = state.LastTrips
+	if trbs.lastTrips == nil {
+		trbs.lastTrips = make(map[int]int)
+	}
+	return nil
+}
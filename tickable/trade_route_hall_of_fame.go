@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&TradeRouteHallOfFameSystem{
-		BaseSystem: NewBaseSystem("TradeRouteHallOfFame", 157),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeRouteHallOfFameSystem{
+			BaseSystem: NewBaseSystem("TradeRouteHallOfFame", 157),
+		}
 	})
 }
 
@@ -50,7 +51,7 @@ func (trhof *TradeRouteHallOfFameSystem) OnTick(tick int64) {
 	if tick < trhof.nextCheck {
 		return
 	}
-	trhof.nextCheck = tick + 5000 + int64(rand.Intn(3000))
+	trhof.nextCheck = tick + 5000 + int64(trhof.Rand().Intn(3000))
 
 	players := game.GetPlayers()
 	routes := game.GetShippingRoutes()
@@ -92,3 +93,31 @@ func (trhof *TradeRouteHallOfFameSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// tradeRouteHallOfFameState is the persisted form of TradeRouteHallOfFameSystem.
+type tradeRouteHallOfFameState struct {
+	Milestones map[int]int
+	NextCheck  int64
+}
+
+// Snapshot implements Snapshotter.
+func (trhof *TradeRouteHallOfFameSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeRouteHallOfFameState{
+		Milestones: trhof.milestones,
+		NextCheck:  trhof.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (trhof *TradeRouteHallOfFameSystem) Restore(data []byte) error {
+	var state tradeRouteHallOfFameState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	trhof.milestones = state.Milestones
+	if trhof.milestones == nil {
+		trhof.milestones = make(map[int]int)
+	}
+	trhof.nextCheck = state.NextCheck
+	return nil
+}
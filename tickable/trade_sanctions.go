@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&TradeSanctionsSystem{
-		BaseSystem: NewBaseSystem("TradeSanctions", 40),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeSanctionsSystem{
+			BaseSystem: NewBaseSystem("TradeSanctions", 40).Every(1000),
+		}
 	})
 }
 
@@ -44,10 +45,6 @@ type Sanction struct {
 }
 
 func (tss *TradeSanctionsSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := tss.GetContext()
 	if ctx == nil {
 		return
@@ -109,7 +106,7 @@ func (tss *TradeSanctionsSystem) OnTick(tick int64) {
 			tss.sanctions[player.Name] = &Sanction{
 				Target:    player.Name,
 				Reason:    "galactic aggression",
-				TicksLeft: 10000 + rand.Intn(10000),
+				TicksLeft: 10000 + tss.Rand().Intn(10000),
 				Severity:  severity,
 			}
 			tss.aggression[player.Name] = 0
@@ -160,3 +157,37 @@ func (tss *TradeSanctionsSystem) GetSanctions() []*Sanction {
 	}
 	return result
 }
+
+// tradeSanctionsState is the persisted form of TradeSanctionsSystem.
+type tradeSanctionsState struct {
+	Sanctions  map[string]*Sanction
+	Aggression map[string]int
+	NextDecay  int64
+}
+
+// Snapshot implements Snapshotter.
+func (tss *TradeSanctionsSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeSanctionsState{
+		Sanctions:  tss.sanctions,
+		Aggression: tss.aggression,
+		NextDecay:  tss.nextDecay,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tss *TradeSanctionsSystem) Restore(data []byte) error {
+	var state tradeSanctionsState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tss.sanctions = state.Sanctions
+	tss.aggression = state.Aggression
+	tss.nextDecay = state.NextDecay
+	if tss.sanctions == nil {
+		tss.sanctions = make(map[string]*Sanction)
+	}
+	if tss.aggression == nil {
+		tss.aggression = make(map[string]int)
+	}
+	return nil
+}
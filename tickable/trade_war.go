@@ -2,14 +2,17 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
+	"maps"
+	"slices"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TradeWarSystem{
-		BaseSystem: NewBaseSystem("TradeWar", 59),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TradeWarSystem{
+			BaseSystem: NewBaseSystem("TradeWar", 59).Every(500),
+		}
 	})
 }
 
@@ -47,10 +50,6 @@ type TradeWar struct {
 }
 
 func (tws *TradeWarSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := tws.GetContext()
 	if ctx == nil {
 		return
@@ -62,7 +61,7 @@ func (tws *TradeWarSystem) OnTick(tick int64) {
 	}
 
 	if tws.nextCheck == 0 {
-		tws.nextCheck = tick + 3000 + int64(rand.Intn(5000))
+		tws.nextCheck = tick + 3000 + int64(tws.Rand().Intn(5000))
 	}
 
 	players := ctx.GetPlayers()
@@ -100,7 +99,7 @@ func (tws *TradeWarSystem) OnTick(tick int64) {
 	if tick < tws.nextCheck {
 		return
 	}
-	tws.nextCheck = tick + 5000 + int64(rand.Intn(8000))
+	tws.nextCheck = tick + 5000 + int64(tws.Rand().Intn(8000))
 
 	// Don't have too many active wars
 	activeCount := 0
@@ -137,7 +136,8 @@ func (tws *TradeWarSystem) detectTradeWar(game GameProvider, players []*entities
 		for _, res := range resources {
 			// Find factions with low stock (they're competing to buy)
 			var competitors []string
-			for faction, planets := range factionPlanets {
+			for _, faction := range slices.Sorted(maps.Keys(factionPlanets)) {
+				planets := factionPlanets[faction]
 				totalStock := 0
 				for _, p := range planets {
 					totalStock += p.GetStoredAmount(res)
@@ -164,7 +164,7 @@ func (tws *TradeWarSystem) detectTradeWar(game GameProvider, players []*entities
 			}
 
 			// 20% chance to trigger
-			if rand.Intn(5) != 0 {
+			if tws.Rand().Intn(5) != 0 {
 				continue
 			}
 
@@ -174,7 +174,7 @@ func (tws *TradeWarSystem) detectTradeWar(game GameProvider, players []*entities
 				FactionA:   competitors[0],
 				FactionB:   competitors[1],
 				Resource:   res,
-				TicksLeft:  3000 + rand.Intn(2000),
+				TicksLeft:  3000 + tws.Rand().Intn(2000),
 				Active:     true,
 			}
 			tws.wars = append(tws.wars, war)
@@ -197,3 +197,28 @@ func (tws *TradeWarSystem) GetActiveWars() []*TradeWar {
 	}
 	return result
 }
+
+// tradeWarState is the persisted form of TradeWarSystem.
+type tradeWarState struct {
+	Wars      []*TradeWar
+	NextCheck int64
+}
+
+// Snapshot implements Snapshotter.
+func (tws *TradeWarSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(tradeWarState{
+		Wars:      tws.wars,
+		NextCheck: tws.nextCheck,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tws *TradeWarSystem) Restore(data []byte) error {
+	var state tradeWarState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tws.wars = state.Wars
+	tws.nextCheck = state.NextCheck
+	return nil
+}
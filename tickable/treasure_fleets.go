@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&TreasureFleetSystem{
-		BaseSystem: NewBaseSystem("TreasureFleets", 85),
+	RegisterSystemFunc(func() TickableSystem {
+		return &TreasureFleetSystem{
+			BaseSystem: NewBaseSystem("TreasureFleets", 85).Every(500),
+		}
 	})
 }
 
@@ -53,10 +54,6 @@ type TreasureFleet struct {
 }
 
 func (tfs *TreasureFleetSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := tfs.GetContext()
 	if ctx == nil {
 		return
@@ -68,7 +65,7 @@ func (tfs *TreasureFleetSystem) OnTick(tick int64) {
 	}
 
 	if tfs.nextFleet == 0 {
-		tfs.nextFleet = tick + 8000 + int64(rand.Intn(10000))
+		tfs.nextFleet = tick + 8000 + int64(tfs.Rand().Intn(10000))
 	}
 
 	players := ctx.GetPlayers()
@@ -100,7 +97,7 @@ func (tfs *TreasureFleetSystem) OnTick(tick int64) {
 
 			// Pick the connected system closest to destination
 			// Simple: just pick a random connected system
-			fleet.CurrentSystem = connected[rand.Intn(len(connected))]
+			fleet.CurrentSystem = connected[tfs.Rand().Intn(len(connected))]
 
 			// Update name
 			fleet.SysName = fmt.Sprintf("SYS-%d", fleet.CurrentSystem+1)
@@ -128,7 +125,7 @@ func (tfs *TreasureFleetSystem) OnTick(tick int64) {
 
 	// Spawn new fleet
 	if tick >= tfs.nextFleet {
-		tfs.nextFleet = tick + 15000 + int64(rand.Intn(15000))
+		tfs.nextFleet = tick + 15000 + int64(tfs.Rand().Intn(15000))
 
 		activeCount := 0
 		for _, f := range tfs.fleets {
@@ -148,7 +145,7 @@ func (tfs *TreasureFleetSystem) checkInterception(fleet *TreasureFleet, players
 		player *entities.Player
 		ships  int
 	}
-	forces := make(map[string]*factionForce)
+	var forces []*factionForce
 
 	for _, p := range players {
 		if p == nil {
@@ -166,7 +163,7 @@ func (tfs *TreasureFleetSystem) checkInterception(fleet *TreasureFleet, players
 			}
 		}
 		if count >= 3 {
-			forces[p.Name] = &factionForce{p, count}
+			forces = append(forces, &factionForce{p, count})
 		}
 	}
 
@@ -188,7 +185,7 @@ func (tfs *TreasureFleetSystem) checkInterception(fleet *TreasureFleet, players
 		successChance = 95
 	}
 
-	if rand.Intn(100) >= successChance {
+	if tfs.Rand().Intn(100) >= successChance {
 		// Failed interception
 		game.LogEvent("event", interceptor.player.Name,
 			fmt.Sprintf("💰 %s attempted to intercept the treasure fleet in %s but the escorts fought them off!",
@@ -211,14 +208,14 @@ func (tfs *TreasureFleetSystem) spawnFleet(game GameProvider, systems []*entitie
 		return
 	}
 
-	a := rand.Intn(len(systems))
-	b := rand.Intn(len(systems))
+	a := tfs.Rand().Intn(len(systems))
+	b := tfs.Rand().Intn(len(systems))
 	for b == a {
-		b = rand.Intn(len(systems))
+		b = tfs.Rand().Intn(len(systems))
 	}
 
-	value := 10000 + rand.Intn(40000)
-	escorts := 2 + rand.Intn(3)
+	value := 10000 + tfs.Rand().Intn(40000)
+	escorts := 2 + tfs.Rand().Intn(3)
 
 	fleet := &TreasureFleet{
 		CurrentSystem: systems[a].ID,
@@ -234,3 +231,28 @@ func (tfs *TreasureFleetSystem) spawnFleet(game GameProvider, systems []*entitie
 		fmt.Sprintf("💰 TREASURE FLEET departed from %s heading to %s! Carrying %dcr in goods with %d escorts. Intercept with 3+ warships!",
 			systems[a].Name, systems[b].Name, value, escorts))
 }
+
+// treasureFleetState is the persisted form of TreasureFleetSystem.
+type treasureFleetState struct {
+	Fleets    []*TreasureFleet
+	NextFleet int64
+}
+
+// Snapshot implements Snapshotter.
+func (tfs *TreasureFleetSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(treasureFleetState{
+		Fleets:    tfs.fleets,
+		NextFleet: tfs.nextFleet,
+	})
+}
+
+// Restore implements Snapshotter.
+func (tfs *TreasureFleetSystem) Restore(data []byte) error {
+	var state treasureFleetState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	tfs.fleets = state.Fleets
+	tfs.nextFleet = state.NextFleet
+	return nil
+}
@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&UnderdogBonusSystem{
-		BaseSystem: NewBaseSystem("UnderdogBonus", 131),
+	RegisterSystemFunc(func() TickableSystem {
+		return &UnderdogBonusSystem{
+			BaseSystem: NewBaseSystem("UnderdogBonus", 131).Every(1000),
+		}
 	})
 }
 
@@ -34,10 +35,6 @@ type UnderdogBonusSystem struct {
 }
 
 func (ubs *UnderdogBonusSystem) OnTick(tick int64) {
-	if tick%1000 != 0 {
-		return
-	}
-
 	ctx := ubs.GetContext()
 	if ctx == nil {
 		return
@@ -108,7 +105,7 @@ func (ubs *UnderdogBonusSystem) OnTick(tick int64) {
 		p.Credits += creditBonus
 
 		// Population bonus on owned planets
-		if rand.Intn(3) == 0 {
+		if ubs.Rand().Intn(3) == 0 {
 			for _, sys := range systems {
 				for _, e := range sys.Entities {
 					if planet, ok := e.(*entities.Planet); ok && planet.Owner == p.Name {
@@ -128,7 +125,7 @@ func (ubs *UnderdogBonusSystem) OnTick(tick int64) {
 		ubs.nextAnnounce = tick + 15000
 	}
 	if tick >= ubs.nextAnnounce {
-		ubs.nextAnnounce = tick + 15000 + int64(rand.Intn(10000))
+		ubs.nextAnnounce = tick + 15000 + int64(ubs.Rand().Intn(10000))
 
 		for _, p := range players {
 			if p == nil {
@@ -144,3 +141,25 @@ func (ubs *UnderdogBonusSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// underdogBonusState is the persisted form of UnderdogBonusSystem.
+type underdogBonusState struct {
+	NextAnnounce int64
+}
+
+// Snapshot implements Snapshotter.
+func (ubs *UnderdogBonusSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(underdogBonusState{
+		NextAnnounce: ubs.nextAnnounce,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ubs *UnderdogBonusSystem) Restore(data []byte) error {
+	var state underdogBonusState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ubs.nextAnnounce = state.NextAnnounce
+	return nil
+}
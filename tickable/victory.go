@@ -7,8 +7,10 @@ import (
 )
 
 func init() {
-	RegisterSystem(&VictorySystem{
-		BaseSystem: NewBaseSystem("Victory", 40),
+	RegisterSystemFunc(func() TickableSystem {
+		return &VictorySystem{
+			BaseSystem: NewBaseSystem("Victory", 40).Every(1000),
+		}
 	})
 }
 
@@ -25,7 +27,8 @@ func init() {
 // The game continues (sandbox) but the achievement is permanent.
 type VictorySystem struct {
 	*BaseSystem
-	achieved map[string]map[string]bool // player → victory type → achieved
+	achieved   map[string]map[string]bool // player → victory type → achieved
+	conditions map[string]bool            // victory types in play (nil = all)
 }
 
 type victoryCondition struct {
@@ -95,12 +98,37 @@ var victories = []victoryCondition{
 	},
 }
 
-func (vs *VictorySystem) OnTick(tick int64) {
-	// Check every 1000 ticks (~100 seconds)
-	if tick%1000 != 0 {
+// VictoryConditions lists every victory type, in check order.
+func VictoryConditions() []string {
+	names := make([]string, len(victories))
+	for i, vc := range victories {
+		names[i] = vc.name
+	}
+	return names
+}
+
+// SetConditions limits the game to the named victory types (empty = all).
+// Names are assumed valid; see VictoryConditions.
+func (vs *VictorySystem) SetConditions(names []string) {
+	if len(names) == 0 {
+		vs.conditions = nil
 		return
 	}
+	vs.conditions = make(map[string]bool, len(names))
+	for _, name := range names {
+		vs.conditions[name] = true
+	}
+}
+
+// VictorySystem returns the registry's victory system, or nil.
+func (r *Registry) VictorySystem() *VictorySystem {
+	if vs, ok := r.SystemByName("Victory").(*VictorySystem); ok {
+		return vs
+	}
+	return nil
+}
 
+func (vs *VictorySystem) OnTick(tick int64) {
 	ctx := vs.GetContext()
 	if ctx == nil {
 		return
@@ -139,6 +167,9 @@ func (vs *VictorySystem) OnTick(tick int64) {
 			if vs.achieved[player.Name][vc.name] {
 				continue // already achieved
 			}
+			if vs.conditions != nil && !vs.conditions[vc.name] {
+				continue // not in play this game
+			}
 
 			if vc.check(player, systems, trips) {
 				vs.achieved[player.Name][vc.name] = true
@@ -149,3 +180,25 @@ func (vs *VictorySystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// victoryState is the persisted form of VictorySystem.
+type victoryState struct {
+	Achieved map[string]map[string]bool
+}
+
+// Snapshot implements Snapshotter.
+func (vs *VictorySystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(victoryState{
+		Achieved: vs.achieved,
+	})
+}
+
+// Restore implements Snapshotter.
+func (vs *VictorySystem) Restore(data []byte) error {
+	var state victoryState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	vs.achieved = state.Achieved
+	return nil
+}
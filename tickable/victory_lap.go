@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&VictoryLapSystem{
-		BaseSystem: NewBaseSystem("VictoryLap", 110),
+	RegisterSystemFunc(func() TickableSystem {
+		return &VictoryLapSystem{
+			BaseSystem: NewBaseSystem("VictoryLap", 110).Every(5000),
+		}
 	})
 }
 
@@ -34,10 +35,6 @@ type VictoryLapSystem struct {
 }
 
 func (vls *VictoryLapSystem) OnTick(tick int64) {
-	if tick%5000 != 0 {
-		return
-	}
-
 	ctx := vls.GetContext()
 	if ctx == nil {
 		return
@@ -176,7 +173,7 @@ func (vls *VictoryLapSystem) OnTick(tick int64) {
 	}
 
 	// Announce all champions periodically
-	if rand.Intn(3) == 0 {
+	if vls.Rand().Intn(3) == 0 {
 		msg := "👑 Champions: "
 		for cat, faction := range vls.champions {
 			msg += fmt.Sprintf("%s=%s ", cat, faction)
@@ -184,3 +181,31 @@ func (vls *VictoryLapSystem) OnTick(tick int64) {
 		game.LogEvent("intel", "", msg)
 	}
 }
+
+// victoryLapState is the persisted form of VictoryLapSystem.
+type victoryLapState struct {
+	Champions map[string]string
+	NextEval  int64
+}
+
+// Snapshot implements Snapshotter.
+func (vls *VictoryLapSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(victoryLapState{
+		Champions: vls.champions,
+		NextEval:  vls.nextEval,
+	})
+}
+
+// Restore implements Snapshotter.
+func (vls *VictoryLapSystem) Restore(data []byte) error {
+	var state victoryLapState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	vls.champions = state.Champions
+	if vls.champions == nil {
+		vls.champions = make(map[string]string)
+	}
+	vls.nextEval = state.NextEval
+	return nil
+}
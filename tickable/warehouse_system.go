@@ -2,14 +2,15 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 
 	"github.com/hunterjsb/xandaris/entities"
 )
 
 func init() {
-	RegisterSystem(&WarehouseSystem{
-		BaseSystem: NewBaseSystem("Warehouse", 97),
+	RegisterSystemFunc(func() TickableSystem {
+		return &WarehouseSystem{
+			BaseSystem: NewBaseSystem("Warehouse", 97).Every(500),
+		}
 	})
 }
 
@@ -33,10 +34,6 @@ type WarehouseSystem struct {
 }
 
 func (ws *WarehouseSystem) OnTick(tick int64) {
-	if tick%500 != 0 {
-		return
-	}
-
 	ctx := ws.GetContext()
 	if ctx == nil {
 		return
@@ -104,6 +101,29 @@ func (ws *WarehouseSystem) OnTick(tick int64) {
 			}
 		}
 	}
+}
+
+// warehouseState is the persisted form of WarehouseSystem.
+type warehouseState struct {
+	LastWarning map[int]int64
+}
 
-	_ = rand.Intn // suppress unused import if needed
+// Snapshot implements Snapshotter.
+func (ws *WarehouseSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(warehouseState{
+		LastWarning: ws.lastWarning,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ws *WarehouseSystem) Restore(data []byte) error {
+	var state warehouseState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ws.lastWarning = state.LastWarning
+	if ws.lastWarning == nil {
+		ws.lastWarning = make(map[int]int64)
+	}
+	return nil
 }
@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&WealthTaxSystem{
-		BaseSystem: NewBaseSystem("WealthTax", 137),
+	RegisterSystemFunc(func() TickableSystem {
+		return &WealthTaxSystem{
+			BaseSystem: NewBaseSystem("WealthTax", 137).Every(2000),
+		}
 	})
 }
 
@@ -35,10 +36,6 @@ type WealthTaxSystem struct {
 }
 
 func (wts *WealthTaxSystem) OnTick(tick int64) {
-	if tick%2000 != 0 {
-		return
-	}
-
 	ctx := wts.GetContext()
 	if ctx == nil {
 		return
@@ -112,7 +109,7 @@ func (wts *WealthTaxSystem) OnTick(tick int64) {
 
 	// Periodic report
 	if tick >= wts.nextReport {
-		wts.nextReport = tick + 15000 + int64(rand.Intn(10000))
+		wts.nextReport = tick + 15000 + int64(wts.Rand().Intn(10000))
 		if totalTax > 0 {
 			game.LogEvent("intel", "",
 				fmt.Sprintf("🏛️ Galactic wealth tax collected %dcr this interval. Fund balance: %dcr. Invest your credits!",
@@ -120,3 +117,28 @@ func (wts *WealthTaxSystem) OnTick(tick int64) {
 		}
 	}
 }
+
+// wealthTaxState is the persisted form of WealthTaxSystem.
+type wealthTaxState struct {
+	GalacticFund int
+	NextReport   int64
+}
+
+// Snapshot implements Snapshotter.
+func (wts *WealthTaxSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(wealthTaxState{
+		GalacticFund: wts.galacticFund,
+		NextReport:   wts.nextReport,
+	})
+}
+
+// Restore implements Snapshotter.
+func (wts *WealthTaxSystem) Restore(data []byte) error {
+	var state wealthTaxState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	wts.galacticFund = state.GalacticFund
+	wts.nextReport = state.NextReport
+	return nil
+}
@@ -2,12 +2,13 @@ package tickable
 
 import (
 	"fmt"
-	"math/rand"
 )
 
 func init() {
-	RegisterSystem(&WormholeSystem{
-		BaseSystem: NewBaseSystem("Wormholes", 38),
+	RegisterSystemFunc(func() TickableSystem {
+		return &WormholeSystem{
+			BaseSystem: NewBaseSystem("Wormholes", 38).Every(100),
+		}
 	})
 }
 
@@ -36,10 +37,6 @@ type Wormhole struct {
 }
 
 func (ws *WormholeSystem) OnTick(tick int64) {
-	if tick%100 != 0 {
-		return
-	}
-
 	ctx := ws.GetContext()
 	if ctx == nil {
 		return
@@ -54,7 +51,7 @@ func (ws *WormholeSystem) OnTick(tick int64) {
 
 	// Initialize spawn timer — first wormhole spawns quickly after startup
 	if ws.nextSpawn == 0 {
-		ws.nextSpawn = tick + 500 + int64(rand.Intn(1000))
+		ws.nextSpawn = tick + 500 + int64(ws.Rand().Intn(1000))
 	}
 
 	// Decay existing wormholes
@@ -74,20 +71,20 @@ func (ws *WormholeSystem) OnTick(tick int64) {
 
 	// Spawn new wormhole
 	if tick >= ws.nextSpawn && len(systems) > 5 {
-		ws.nextSpawn = tick + 8000 + int64(rand.Intn(5000))
+		ws.nextSpawn = tick + 8000 + int64(ws.Rand().Intn(5000))
 
 		// Pick two distant systems
-		a := rand.Intn(len(systems))
-		b := rand.Intn(len(systems))
+		a := ws.Rand().Intn(len(systems))
+		b := ws.Rand().Intn(len(systems))
 		for b == a || abs(a-b) < 5 {
-			b = rand.Intn(len(systems))
+			b = ws.Rand().Intn(len(systems))
 		}
 
 		wh := &Wormhole{
 			ID:        len(ws.wormholes) + 1,
 			SystemA:   systems[a].ID,
 			SystemB:   systems[b].ID,
-			TicksLeft: 4000 + rand.Intn(3000), // 4000-7000 ticks (~7-12 min)
+			TicksLeft: 4000 + ws.Rand().Intn(3000), // 4000-7000 ticks (~7-12 min)
 			Active:    true,
 		}
 		ws.wormholes = append(ws.wormholes, wh)
@@ -120,3 +117,28 @@ func abs(x int) int {
 	}
 	return x
 }
+
+// wormholeState is the persisted form of WormholeSystem.
+type wormholeState struct {
+	Wormholes []*Wormhole
+	NextSpawn int64
+}
+
+// Snapshot implements Snapshotter.
+func (ws *WormholeSystem) Snapshot() ([]byte, error) {
+	return encodeSnapshot(wormholeState{
+		Wormholes: ws.wormholes,
+		NextSpawn: ws.nextSpawn,
+	})
+}
+
+// Restore implements Snapshotter.
+func (ws *WormholeSystem) Restore(data []byte) error {
+	var state wormholeState
+	if err := decodeSnapshot(data, &state); err != nil {
+		return err
+	}
+	ws.wormholes = state.Wormholes
+	ws.nextSpawn = state.NextSpawn
+	return nil
+}
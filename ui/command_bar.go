@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/hajimehoshi/ebiten/v2"
+	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/game"
 	"github.com/hunterjsb/xandaris/tickable"
@@ -1341,7 +1342,14 @@ func (cb *CommandBar) handleOrder(input string) {
 	// Parse: "sell iron above 300" or "buy water below 100"
 	// Format: order sell <resource> above <threshold> [qty <n>]
 	// Format: order buy <resource> below <threshold> [qty <n>]
+	// With a quantity first it's a limit order instead (see handleLimitOrder).
 	parts := strings.Fields(input)
+	if len(parts) >= 3 {
+		if _, err := strconv.Atoi(parts[1]); err == nil {
+			cb.handleLimitOrder(parts)
+			return
+		}
+	}
 	if len(parts) < 4 {
 		cb.addFeedMessage("Usage: order sell <resource> above <threshold>", utils.SystemRed)
 		cb.addFeedMessage("       order buy <resource> below <threshold>", utils.SystemRed)
@@ -1419,6 +1427,145 @@ func (cb *CommandBar) handleOrder(input string) {
 		action, qty, resource, direction, threshold), utils.SystemGreen)
 }
 
+// limitOrderKeywords end the resource name in a limit order.
+var limitOrderKeywords = map[string]bool{
+	"@": true, "stop": true, "tp": true, "market": true,
+	"ioc": true, "fok": true, "until": true, "show": true,
+}
+
+func (cb *CommandBar) handleLimitOrder(parts []string) {
+	// Format: order <buy|sell> <qty> <resource> [@ <price>] [stop|tp <trigger>] [market]
+	//         [ioc|fok] [until <tick>] [show <n>]
+	// e.g. "order sell 100 iron @ 80 show 20", "order sell 50 oil stop 40 market"
+	usage := func(msg string) {
+		cb.addFeedMessage(msg, utils.SystemRed)
+		cb.addFeedMessage("Usage: order <buy|sell> <qty> <resource> @ <price> [stop|tp <n>] [market] [ioc|fok] [until <tick>] [show <n>]", utils.SystemRed)
+	}
+
+	action := parts[0]
+	if action != "buy" && action != "sell" {
+		usage("Order action must be 'buy' or 'sell'")
+		return
+	}
+	qty, _ := strconv.Atoi(parts[1])
+
+	i := 2
+	var words []string
+	for ; i < len(parts) && !limitOrderKeywords[parts[i]] && !strings.HasPrefix(parts[i], "@"); i++ {
+		words = append(words, strings.ToUpper(parts[i][:1])+parts[i][1:])
+	}
+	resource := strings.Join(words, " ")
+	switch strings.ToLower(resource) {
+	case "rm", "rare metals", "rare metal":
+		resource = "Rare Metals"
+	case "he3", "helium", "helium-3":
+		resource = "Helium-3"
+	case "elec", "electronics":
+		resource = "Electronics"
+	}
+	if resource == "" {
+		usage("Missing resource")
+		return
+	}
+
+	data := game.LimitOrderCommandData{Resource: resource, Action: action, Quantity: qty}
+	trigger, market := "", false
+	// number reads the value after a keyword ("@80" carries its own)
+	number := func(tok string) (int, bool) {
+		if v := strings.TrimPrefix(tok, "@"); v != "" && v != tok {
+			n, err := strconv.Atoi(v)
+			return n, err == nil
+		}
+		if i+1 >= len(parts) {
+			return 0, false
+		}
+		i++
+		n, err := strconv.Atoi(parts[i])
+		return n, err == nil
+	}
+	for ; i < len(parts); i++ {
+		tok := parts[i]
+		var ok bool
+		switch {
+		case strings.HasPrefix(tok, "@"):
+			data.Price, ok = number(tok)
+		case tok == "stop" || tok == "tp":
+			trigger = tok
+			data.StopPrice, ok = number("")
+		case tok == "market":
+			market, ok = true, true
+		case tok == "ioc" || tok == "fok":
+			data.TimeInForce, ok = tok, true
+		case tok == "until":
+			var n int
+			n, ok = number("")
+			data.ExpiresAt = int64(n)
+		case tok == "show":
+			data.Display, ok = number("")
+		}
+		if !ok {
+			usage(fmt.Sprintf("Can't read %q", tok))
+			return
+		}
+	}
+
+	switch {
+	case trigger == "stop" && market:
+		data.Type = economy.OrderStop
+	case trigger == "stop":
+		data.Type = economy.OrderStopLimit
+	case trigger == "tp" && market:
+		data.Type = economy.OrderTakeProfit
+	case trigger == "tp":
+		data.Type = economy.OrderTakeProfitLimit
+	}
+	if data.Price <= 0 && !(market && action == "sell") {
+		usage("Missing price (@ <price>)")
+		return
+	}
+
+	player := cb.ctx.GetHumanPlayer()
+	if player == nil || len(player.OwnedPlanets) == 0 {
+		cb.addFeedMessage("No planet to trade from", utils.SystemRed)
+		return
+	}
+	planet := player.OwnedPlanets[0]
+	data.PlanetID = planet.GetID()
+	data.SystemID = -1
+	for _, sys := range cb.ctx.GetSystems() {
+		for _, e := range sys.Entities {
+			if e == planet {
+				data.SystemID = sys.ID
+			}
+		}
+	}
+	if data.SystemID < 0 {
+		cb.addFeedMessage("Can't find your planet's system", utils.SystemRed)
+		return
+	}
+
+	cmdCh := cb.ctx.GetCommandChannel()
+	if cmdCh == nil {
+		cb.addFeedMessage("Command channel unavailable", utils.SystemRed)
+		return
+	}
+	cmdCh <- game.GameCommand{Type: game.CmdLimitOrder, Data: data}
+
+	kind := "Limit order"
+	if data.Type != "" {
+		kind = strings.ReplaceAll(data.Type, "_", "-") + " order"
+		kind = strings.ToUpper(kind[:1]) + kind[1:]
+	}
+	msg := fmt.Sprintf("%s: %s %d %s", kind, action, qty, resource)
+	if data.Price > 0 {
+		msg += fmt.Sprintf(" @ %dcr", data.Price)
+	}
+	if data.StopPrice > 0 {
+		msg += fmt.Sprintf(" when price hits %d", data.StopPrice)
+	}
+	cb.addFeedMessage(msg, utils.SystemGreen)
+}
+
 func (cb *CommandBar) showOrders() {
 	state := cb.ctx.GetState()
 	if state == nil {
@@ -1468,6 +1615,7 @@ func (cb *CommandBar) showHelp() {
 		{"/build <type>", "Build (mine/factory/etc)"},
 		{"/buy /sell <n> <res>", "Trade resources"},
 		{"/order sell iron above 300", "Auto-trade"},
+		{"/order buy 50 iron @ 90", "Limit order (stop|tp <n>, ioc|fok, until <tick>, show <n>)"},
 		{"/orders", "List standing orders"},
 		{"/pause /1x /2x /4x /8x", "Speed control"},
 	}
@@ -40,6 +40,7 @@ type GameContext interface {
 	// Market access
 	GetMarket() *economy.Market
 	GetTradeExecutor() *economy.TradeExecutor
+	GetCandleHistory() *economy.CandleHistory
 
 	// Event log for command bar / event feed
 	GetEventLog() *game.EventLog
@@ -8,6 +8,7 @@ import (
 
 	"github.com/hajimehoshi/ebiten/v2"
 	"github.com/hajimehoshi/ebiten/v2/inpututil"
+	"github.com/hajimehoshi/ebiten/v2/vector"
 	"github.com/hunterjsb/xandaris/economy"
 	"github.com/hunterjsb/xandaris/entities"
 	"github.com/hunterjsb/xandaris/utils"
@@ -47,10 +48,18 @@ type MarketView struct {
 	statusColor       color.RGBA
 	statusTicks       int
 	tradingPlanet     *entities.Planet // the planet trades are scoped to
+	tradingSystemID   int              // its system, -1 if unknown
 	refreshCounter    int
+
+	// Price chart: candles for the selected commodity
+	chartResource   string
+	chartResolution int64
+	chartGalaxy     bool // galaxy-wide prices instead of the trading system's
+	chartHits       map[string]image.Rectangle
 }
 
 type tableRowHit struct {
+	row  image.Rectangle
 	buy  image.Rectangle
 	sell image.Rectangle
 }
@@ -107,6 +116,9 @@ func NewMarketView(ctx GameContext) *MarketView {
 		tablePanel:        table,
 		instructionsPanel: instructions,
 		rowHits:           make(map[string]tableRowHit),
+		tradingSystemID:   -1,
+		chartResolution:   economy.CandleResolutions[0],
+		chartHits:         make(map[string]image.Rectangle),
 	}
 }
 
@@ -197,6 +209,13 @@ func (mv *MarketView) Update() error {
 				return nil
 			}
 		}
+		for resource, hits := range mv.rowHits {
+			if pointInRect(mx, my, hits.row) {
+				mv.chartResource = resource
+				return nil
+			}
+		}
+		mv.handleChartClick(mx, my)
 	}
 	return nil
 }
@@ -288,6 +307,7 @@ func (mv *MarketView) Draw(screen *ebiten.Image) {
 	y := headerY + 24
 	rowHeight := 28
 	mv.rowHits = make(map[string]tableRowHit)
+	rowLeft := mv.tablePanel.X + 12
 
 	endIndex := mv.scrollOffset + mv.maxVisibleRows
 	if endIndex > len(mv.rows) {
@@ -305,7 +325,11 @@ func (mv *MarketView) Draw(screen *ebiten.Image) {
 			screen.DrawImage(hoverBg, opts)
 		}
 
-		DrawText(screen, row.resource, colResource, y, utils.Theme.TextLight)
+		nameColor := utils.Theme.TextLight
+		if row.resource == mv.chartResource {
+			nameColor = utils.Theme.Accent
+		}
+		DrawText(screen, row.resource, colResource, y, nameColor)
 
 		// Stock: player's stock on trading planet (white = plenty, red = low)
 		stockColor := utils.TextPrimary
@@ -422,6 +446,7 @@ func (mv *MarketView) Draw(screen *ebiten.Image) {
 		DrawText(screen, sellLabel, colAction+60, y, utils.SystemRed)
 
 		mv.rowHits[row.resource] = tableRowHit{
+			row:  image.Rect(rowLeft, y-4, colAction-10, y+rowHeight-4),
 			buy:  makeLabelRect(colAction, y, buyLabel),
 			sell: makeLabelRect(colAction+60, y, sellLabel),
 		}
@@ -435,8 +460,11 @@ func (mv *MarketView) Draw(screen *ebiten.Image) {
 		DrawText(screen, scrollText, mv.tablePanel.X+20, mv.tablePanel.Y+mv.tablePanel.Height-24, utils.TextSecondary)
 	}
 
-	// Trade history section (below commodity rows)
-	mv.drawTradeHistory(screen, y+10)
+	// Trade history and the price chart share the space below the commodity rows
+	startY := y + 10
+	DrawLine(screen, mv.tablePanel.X+15, startY, mv.tablePanel.X+mv.tablePanel.Width-15, startY, utils.PanelBorder)
+	mv.drawTradeHistory(screen, startY+8)
+	mv.drawPriceChart(screen, startY+8)
 
 	mv.drawInstructions(screen)
 }
@@ -458,8 +486,6 @@ func (mv *MarketView) drawTradeHistory(screen *ebiten.Image, startY int) {
 	if startY+15 > maxY {
 		return
 	}
-	DrawLine(screen, mv.tablePanel.X+15, startY, mv.tablePanel.X+mv.tablePanel.Width-15, startY, utils.PanelBorder)
-	startY += 8
 	DrawText(screen, "Recent Trades", mv.tablePanel.X+20, startY, utils.TextSecondary)
 	startY += 18
 
@@ -491,6 +517,165 @@ func (mv *MarketView) drawTradeHistory(screen *ebiten.Image, startY int) {
 	}
 }
 
+// drawPriceChart draws candlesticks of the selected commodity's mid price,
+// with volume bars underneath, in the right half of the space below the
+// commodity rows. Clicking a commodity selects it; the labels in the chart
+// header pick the candle width and local or galaxy-wide prices.
+func (mv *MarketView) drawPriceChart(screen *ebiten.Image, startY int) {
+	mv.chartHits = make(map[string]image.Rectangle)
+	history := mv.ctx.GetCandleHistory()
+	if history == nil || mv.chartResource == "" {
+		return
+	}
+
+	left := mv.tablePanel.X + mv.tablePanel.Width/2
+	right := mv.tablePanel.X + mv.tablePanel.Width - 20
+	bottom := mv.tablePanel.Y + mv.tablePanel.Height - 30
+	if startY+120 > bottom {
+		return
+	}
+
+	// Header: commodity and scope, then the clickable options
+	systemID := economy.GlobalCandles
+	scope := "Galaxy"
+	if !mv.chartGalaxy && mv.tradingSystemID >= 0 {
+		systemID = mv.tradingSystemID
+		scope = "Local"
+	}
+	DrawText(screen, fmt.Sprintf("%s — %s price", mv.chartResource, scope), left, startY, utils.TextSecondary)
+
+	type chartOption struct {
+		key, label string
+		active     bool
+	}
+	options := []chartOption{
+		{"galaxy", "[Galaxy]", systemID == economy.GlobalCandles},
+		{"local", "[Local]", systemID != economy.GlobalCandles},
+	}
+	for i := len(economy.CandleResolutions) - 1; i >= 0; i-- {
+		res := economy.CandleResolutions[i]
+		options = append(options, chartOption{fmt.Sprintf("%d", res), fmt.Sprintf("[%dt]", res), res == mv.chartResolution})
+	}
+	optX := right
+	for _, opt := range options {
+		if opt.key == "local" && mv.tradingSystemID < 0 {
+			continue
+		}
+		optX -= len(opt.label)*utils.CharWidth() + 8
+		c := utils.TextSecondary
+		if opt.active {
+			c = utils.Theme.Accent
+		}
+		DrawText(screen, opt.label, optX, startY, c)
+		mv.chartHits[opt.key] = makeLabelRect(optX, startY, opt.label)
+	}
+
+	const labelWidth = 60 // price axis labels, left of the plot
+	const volumeHeight = 30
+	plotLeft := left + labelWidth
+	plotTop := startY + 24
+	plotBottom := bottom - volumeHeight - 6
+	plotWidth := right - plotLeft
+
+	candles := history.Candles(systemID, mv.chartResource, mv.chartResolution, 0)
+	if len(candles) == 0 {
+		DrawText(screen, "No price history yet", plotLeft, plotTop+10, utils.TextSecondary)
+		return
+	}
+
+	// Candles are 3-12px wide; keep the most recent ones that fit
+	slot := plotWidth / len(candles)
+	if slot > 12 {
+		slot = 12
+	}
+	if slot < 3 {
+		slot = 3
+		candles = candles[len(candles)-plotWidth/slot:]
+	}
+	bodyWidth := slot * 2 / 3
+	if bodyWidth < 1 {
+		bodyWidth = 1
+	}
+
+	hi, lo, maxVolume := candles[0].High, candles[0].Low, 0
+	for _, c := range candles {
+		if c.High > hi {
+			hi = c.High
+		}
+		if c.Low < lo {
+			lo = c.Low
+		}
+		if c.Volume > maxVolume {
+			maxVolume = c.Volume
+		}
+	}
+	if hi-lo < hi*0.01 {
+		// Flat market: give it a 1% band so candles sit mid-chart
+		hi, lo = hi*1.005, lo*0.995
+	}
+	priceY := func(p float64) int {
+		return plotBottom - int((p-lo)/(hi-lo)*float64(plotBottom-plotTop))
+	}
+
+	DrawRectOutline(screen, plotLeft-4, plotTop-4, plotWidth+8, plotBottom-plotTop+8, utils.PanelBorder)
+	DrawText(screen, fmt.Sprintf("%.0f", hi), left, plotTop-6, utils.TextSecondary)
+	DrawText(screen, fmt.Sprintf("%.0f", lo), left, plotBottom-10, utils.TextSecondary)
+
+	volumeColor := color.RGBA{90, 110, 160, 180}
+	for i, c := range candles {
+		x := plotLeft + i*slot + slot/2
+		col := utils.SystemGreen
+		if c.Close < c.Open {
+			col = utils.SystemRed
+		}
+		DrawLine(screen, x, priceY(c.High), x, priceY(c.Low), col)
+
+		top, base := priceY(c.Open), priceY(c.Close)
+		if top > base {
+			top, base = base, top
+		}
+		height := base - top
+		if height < 1 {
+			height = 1
+		}
+		vector.FillRect(screen, float32(x-bodyWidth/2), float32(top), float32(bodyWidth), float32(height), col, false)
+
+		if maxVolume > 0 && c.Volume > 0 {
+			h := c.Volume * volumeHeight / maxVolume
+			if h < 1 {
+				h = 1
+			}
+			vector.FillRect(screen, float32(x-bodyWidth/2), float32(bottom-h), float32(bodyWidth), float32(h), volumeColor, false)
+		}
+	}
+
+	last := candles[len(candles)-1]
+	DrawText(screen, fmt.Sprintf("O %.0f  H %.0f  L %.0f  C %.0f  Vol %d", last.Open, last.High, last.Low, last.Close, last.Volume),
+		plotLeft, bottom+6, utils.TextSecondary)
+}
+
+// handleChartClick applies a click on one of the price chart's options.
+func (mv *MarketView) handleChartClick(mx, my int) {
+	for key, rect := range mv.chartHits {
+		if !pointInRect(mx, my, rect) {
+			continue
+		}
+		switch key {
+		case "galaxy":
+			mv.chartGalaxy = true
+		case "local":
+			mv.chartGalaxy = false
+		default:
+			for _, res := range economy.CandleResolutions {
+				if key == fmt.Sprintf("%d", res) {
+					mv.chartResolution = res
+				}
+			}
+		}
+		return
+	}
+}
+
 func (mv *MarketView) handleTrade(resource string, buy bool) {
 	exec := mv.ctx.GetTradeExecutor()
 	if exec == nil {
@@ -586,6 +771,8 @@ func (mv *MarketView) refreshData() {
 		}
 	}
 
+	mv.tradingSystemID = tradingSystemID
+
 	// Get market snapshot for dynamic prices (after tradingSystemID is known)
 	market := mv.ctx.GetMarket()
 	type priceInfo struct {
@@ -747,6 +934,11 @@ func (mv *MarketView) refreshData() {
 		}
 		return mv.rows[i].galaxySupply > mv.rows[j].galaxySupply
 	})
+
+	// Keep charting the selected commodity; default to the top row
+	if mv.getRow(mv.chartResource) == nil && len(mv.rows) > 0 {
+		mv.chartResource = mv.rows[0].resource
+	}
 }
 
 // SetReturnView configures which view to go back to when exiting the market